@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 
 	"fmt"
@@ -8,36 +10,198 @@ import (
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 	"github.com/hashicorp/terraform-plugin-sdk/plugin"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"io"
 	"os"
 	"regexp"
+	"sort"
+	"strings"
 )
 
+// providerAddressFmt defines the default address used to identify the provider when running in debug mode. Terraform
+// uses this address as the key of the TF_REATTACH_PROVIDERS env variable to find the running provider process
+const providerAddressFmt = "registry.terraform.io/-/%s"
+
+// importAllCommand is the name of the subcommand that outputs the terraform imports for all the existing objects of a resource
+const importAllCommand = "import-all"
+
+// validateCommand is the name of the subcommand that outputs the issues found while analysing the OpenAPI document
+const validateCommand = "validate"
+
+// import-all output formats
+const importFormatBlock = "block"
+const importFormatCommand = "command"
+
 func main() {
 
+	if len(os.Args) > 1 && os.Args[1] == importAllCommand {
+		if err := runImportAll(os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("[ERROR] %s failed: %s", importAllCommand, err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == validateCommand {
+		if err := runValidate(os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("[ERROR] %s failed: %s", validateCommand, err)
+		}
+		return
+	}
+
+	var debugMode bool
+	var providerNameOverride string
+	var providerAddress string
+	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.StringVar(&providerNameOverride, "provider-name", "", "provider name to use instead of the one derived from the binary name (e,g: when the binary is built by a debugger)")
+	flag.StringVar(&providerAddress, "provider-address", "", "provider address used by terraform to reattach to the provider when running in debug mode")
+	flag.Parse()
+
 	log.Printf("Running OpenAPI Terraform Provider v%s-%s; Released on: %s", version.Version, version.Commit, version.Date)
 
-	ex, err := os.Executable()
+	providerName, err := resolveProviderName(providerNameOverride)
 	if err != nil {
-		log.Fatalf("[ERROR] There was an error when getting the provider binary name: %s", err)
+		log.Fatalf("[ERROR] %s", err)
 	}
 
+	p := openapi.ProviderOpenAPI{ProviderName: providerName}
+	provider, err := p.CreateSchemaProvider()
+	if err != nil {
+		log.Fatalf("[ERROR] There was an error initialising the terraform provider: %s", err)
+	}
+
+	serveOpts := &plugin.ServeOpts{
+		ProviderFunc: func() terraform.ResourceProvider {
+			return provider
+		},
+	}
+
+	if debugMode {
+		if providerAddress == "" {
+			providerAddress = getProviderAddress(providerName)
+		}
+		log.Printf("[INFO] Running provider '%s' in debug mode (address: %s)", providerName, providerAddress)
+		err = plugin.Debug(context.Background(), providerAddress, serveOpts)
+		if err != nil {
+			log.Fatalf("[ERROR] There was an error running the provider in debug mode: %s", err)
+		}
+		return
+	}
+
+	plugin.Serve(serveOpts)
+}
+
+// resolveProviderName returns the override provider name if provided, otherwise the provider name is derived from the binary name
+func resolveProviderName(providerNameOverride string) (string, error) {
+	if providerNameOverride != "" {
+		return providerNameOverride, nil
+	}
+	ex, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("there was an error when getting the provider binary name: %s", err)
+	}
 	providerName, err := getProviderName(ex)
 	if err != nil {
-		log.Fatalf("[ERROR] There was an error when getting the provider's name from the binary '%s': %s", ex, err)
+		return "", fmt.Errorf("there was an error when getting the provider's name from the binary '%s': %s", ex, err)
 	}
+	return providerName, nil
+}
 
+// runImportAll outputs the terraform import blocks (or commands) for all the existing objects of the resource specified
+// in the arguments, e,g: terraform-provider-openapi import-all -resource cdns_v1 -filter label=my_label -format command
+func runImportAll(args []string, out io.Writer) error {
+	var providerNameOverride, resourceName, parentIDs, format string
+	filters := importFilters{}
+	flags := flag.NewFlagSet(importAllCommand, flag.ContinueOnError)
+	flags.StringVar(&providerNameOverride, "provider-name", "", "provider name to use instead of the one derived from the binary name")
+	flags.StringVar(&resourceName, "resource", "", "name of the resource to import without the provider prefix (e,g: cdns_v1)")
+	flags.StringVar(&parentIDs, "parent-ids", "", "parent IDs separated by forward slashes, required if the resource is a sub-resource (e,g: 1234/567)")
+	flags.Var(filters, "filter", "property name and value the objects must match in the form name=value, can be specified multiple times")
+	flags.StringVar(&format, "format", importFormatBlock, fmt.Sprintf("output format, either '%s' (terraform import blocks) or '%s' (terraform import commands)", importFormatBlock, importFormatCommand))
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if resourceName == "" {
+		return fmt.Errorf("the -resource flag is required")
+	}
+	if format != importFormatBlock && format != importFormatCommand {
+		return fmt.Errorf("format '%s' not supported, please use either '%s' or '%s'", format, importFormatBlock, importFormatCommand)
+	}
+	var ids []string
+	if parentIDs != "" {
+		ids = strings.Split(parentIDs, "/")
+	}
+
+	providerName, err := resolveProviderName(providerNameOverride)
+	if err != nil {
+		return err
+	}
 	p := openapi.ProviderOpenAPI{ProviderName: providerName}
-	provider, err := p.CreateSchemaProvider()
+	importTargets, err := p.ImportAll(resourceName, ids, filters)
 	if err != nil {
-		log.Fatalf("[ERROR] There was an error initialising the terraform provider: %s", err)
+		return err
+	}
+	for _, importTarget := range importTargets {
+		if format == importFormatCommand {
+			fmt.Fprintln(out, importTarget.ImportCommand())
+			continue
+		}
+		fmt.Fprintln(out, importTarget.ImportBlock())
+	}
+	return nil
+}
+
+// runValidate analyses the OpenAPI document configured for the provider and outputs the issues found, e,g:
+// terraform-provider-openapi validate -strict. If strict is enabled, an error is returned when issues are found.
+func runValidate(args []string, out io.Writer) error {
+	var providerNameOverride string
+	var strict bool
+	flags := flag.NewFlagSet(validateCommand, flag.ContinueOnError)
+	flags.StringVar(&providerNameOverride, "provider-name", "", "provider name to use instead of the one derived from the binary name")
+	flags.BoolVar(&strict, "strict", false, "fail if any issue is found in the OpenAPI document")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	providerName, err := resolveProviderName(providerNameOverride)
+	if err != nil {
+		return err
 	}
+	p := openapi.ProviderOpenAPI{ProviderName: providerName}
+	warnings, err := p.Validate()
+	if err != nil {
+		return err
+	}
+	if len(warnings) == 0 {
+		fmt.Fprintln(out, "No issues found in the OpenAPI document")
+		return nil
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "Warning: %s\n", warning)
+	}
+	if strict {
+		return fmt.Errorf("%d issue(s) found in the OpenAPI document", len(warnings))
+	}
+	return nil
+}
 
-	plugin.Serve(
-		&plugin.ServeOpts{
-			ProviderFunc: func() terraform.ResourceProvider {
-				return provider
-			},
-		})
+// importFilters implements flag.Value so the -filter flag can be specified multiple times
+type importFilters map[string]string
+
+func (f importFilters) String() string {
+	var filters []string
+	for name, value := range f {
+		filters = append(filters, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(filters)
+	return strings.Join(filters, ",")
+}
+
+func (f importFilters) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("filter '%s' must be in the form name=value", value)
+	}
+	f[parts[0]] = parts[1]
+	return nil
 }
 
 func getProviderName(binaryName string) (string, error) {
@@ -52,3 +216,8 @@ func getProviderName(binaryName string) (string, error) {
 	}
 	return match[1], nil
 }
+
+// getProviderAddress returns the default address terraform will use to reattach to the provider running in debug mode
+func getProviderAddress(providerName string) string {
+	return fmt.Sprintf(providerAddressFmt, providerName)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"io/ioutil"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -81,3 +82,72 @@ func TestGetProviderName(t *testing.T) {
 	})
 
 }
+
+func TestGetProviderAddress(t *testing.T) {
+	Convey("Given a provider name", t, func() {
+		providerName := "goa"
+		Convey("When getProviderAddress method is called", func() {
+			providerAddress := getProviderAddress(providerName)
+			Convey("Then the provider address returned should be the expected one", func() {
+				So(providerAddress, ShouldEqual, "registry.terraform.io/-/goa")
+			})
+		})
+	})
+}
+
+func TestImportFilters(t *testing.T) {
+	Convey("Given an empty importFilters", t, func() {
+		filters := importFilters{}
+		Convey("When Set is called with values in the form name=value", func() {
+			err1 := filters.Set("label=my_label")
+			err2 := filters.Set("query=a=b")
+			Convey("Then the errors returned should be nil", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+			})
+			Convey("And the filters should contain the expected values", func() {
+				So(filters, ShouldResemble, importFilters{"label": "my_label", "query": "a=b"})
+				So(filters.String(), ShouldEqual, "label=my_label,query=a=b")
+			})
+		})
+		Convey("When Set is called with a value that is not in the form name=value", func() {
+			err := filters.Set("label")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "filter 'label' must be in the form name=value")
+			})
+		})
+	})
+}
+
+func TestRunImportAll(t *testing.T) {
+	Convey("Given the import-all arguments without the resource", t, func() {
+		args := []string{"-provider-name", "goa"}
+		Convey("When runImportAll method is called", func() {
+			err := runImportAll(args, ioutil.Discard)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the -resource flag is required")
+			})
+		})
+	})
+	Convey("Given the import-all arguments with a not supported format", t, func() {
+		args := []string{"-provider-name", "goa", "-resource", "cdns_v1", "-format", "json"}
+		Convey("When runImportAll method is called", func() {
+			err := runImportAll(args, ioutil.Discard)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "format 'json' not supported, please use either 'block' or 'command'")
+			})
+		})
+	})
+}
+
+func TestRunValidate(t *testing.T) {
+	Convey("Given the validate arguments containing a flag that is not supported", t, func() {
+		args := []string{"-provider-name", "goa", "-resource", "cdns_v1"}
+		Convey("When runValidate method is called", func() {
+			err := runValidate(args, ioutil.Discard)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "flag provided but not defined: -resource")
+			})
+		})
+	})
+}
@@ -1,40 +1,89 @@
 package openapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"path"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
-func checkHTTPStatusCode(openAPIResource SpecResource, res *http.Response, expectedHTTPStatusCodes []int) error {
+// checkHTTPStatusCode returns an error if the response status code is not one of the expected ones. The error contains
+// the response status code and the error details returned by the API parsed with the given error parser (see
+// getAPIErrorDetails). The resource name, HTTP method and resolved path are expected to be added by the caller using
+// newResourceOperationError.
+func checkHTTPStatusCode(openAPIResource SpecResource, res *http.Response, expectedHTTPStatusCodes []int, errorParser apiErrorParser) error {
 	if !responseContainsExpectedStatus(expectedHTTPStatusCodes, res.StatusCode) {
-		var resBody string
 		b, err := ioutil.ReadAll(res.Body)
 		if err != nil {
-			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d - Error '%s' occurred while reading the response body", openAPIResource.GetResourceName(), res.StatusCode, err)
+			return fmt.Errorf("HTTP Response Status Code %d - Error '%s' occurred while reading the response body", res.StatusCode, err)
 		}
-		if b != nil && len(b) > 0 {
-			resBody = string(b)
+		if strings.HasPrefix(res.Header.Get("Content-Type"), problemJSONContentType) {
+			errorParser = problemJSONAPIErrorParser{}
 		}
+		apiErrorDetails := getAPIErrorDetails(errorParser, b)
 		switch res.StatusCode {
 		case http.StatusUnauthorized:
-			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d - Unauthorized: API access is denied due to invalid credentials (%s)", openAPIResource.GetResourceName(), res.StatusCode, resBody)
+			return fmt.Errorf("HTTP Response Status Code %d - Unauthorized: API access is denied due to invalid credentials (%s)", res.StatusCode, apiErrorDetails)
 		case http.StatusNotFound:
-			return &openapierr.NotFoundError{OriginalError: fmt.Errorf("HTTP Response Status Code %d - Not Found. Could not find resource instance: %s", res.StatusCode, resBody)}
+			return &openapierr.NotFoundError{OriginalError: fmt.Errorf("HTTP Response Status Code %d - Not Found. Could not find resource instance: %s", res.StatusCode, apiErrorDetails)}
 		default:
-			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d not matching expected one %v (%s)", openAPIResource.GetResourceName(), res.StatusCode, expectedHTTPStatusCodes, resBody)
+			return fmt.Errorf("HTTP Response Status Code %d not matching expected one %v (%s)", res.StatusCode, expectedHTTPStatusCodes, apiErrorDetails)
 		}
 	}
 	return nil
 }
 
+// getAPIErrorDetails returns the error code and message contained in the given API error response body as parsed by the
+// given error parser (the defaultAPIErrorParser if nil). If the body does not contain any of these, the raw body is
+// returned instead.
+func getAPIErrorDetails(errorParser apiErrorParser, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if errorParser == nil {
+		errorParser = defaultAPIErrorParser{}
+	}
+	code, message, ok := errorParser.parseError(body)
+	if !ok {
+		return string(body)
+	}
+	var details []string
+	if code != "" {
+		details = append(details, fmt.Sprintf("code='%s'", code))
+	}
+	if message != "" {
+		details = append(details, fmt.Sprintf("message='%s'", message))
+	}
+	return strings.Join(details, ", ")
+}
+
+// getAPIErrorCode returns the error code returned by the API in the response body as parsed by the given error parser
+// (the defaultAPIErrorParser if nil). An empty string is returned if the body does not contain an error code.
+func getAPIErrorCode(errorParser apiErrorParser, body []byte) string {
+	if errorParser == nil {
+		errorParser = defaultAPIErrorParser{}
+	}
+	code, _, _ := errorParser.parseError(body)
+	return code
+}
+
+// newResourceOperationError returns an error including the kind of terraform resource (e,g: resource, data source),
+// the resource name, the HTTP method and the resolved path of the API operation that failed
+func newResourceOperationError(kind, resourceName, httpMethod, resolvedPath string, err error) error {
+	return fmt.Errorf("[%s='%s'] %s %s failed: %s", kind, resourceName, httpMethod, resolvedPath, err)
+}
+
 func responseContainsExpectedStatus(expectedStatusCodes []int, responseStatusCode int) bool {
 	for _, expectedStatusCode := range expectedStatusCodes {
 		if expectedStatusCode == responseStatusCode {
@@ -56,6 +105,68 @@ func getParentIDsAndResourcePath(openAPIResource SpecResource, data *schema.Reso
 	return
 }
 
+// resolveParentIDs populates the parent ID properties that are not configured using the value of the parent look up
+// properties (x-terraform-resource-lookup-property). The parent ID is resolved listing the parent resources and finding
+// the one matching the look up value. Parents are resolved in order so the IDs of the ancestors are available when
+// listing nested parents.
+func resolveParentIDs(openAPIResource SpecResource, openAPIClient ClientOpenAPI, data *schema.ResourceData) error {
+	parentResourceInfo := openAPIResource.GetParentResourceInfo()
+	if parentResourceInfo == nil {
+		return nil
+	}
+	parentIDs := []string{}
+	for idx, parentPropertyName := range parentResourceInfo.GetParentPropertiesNames() {
+		parentID, _ := data.Get(parentPropertyName).(string)
+		if lookupPropertyName := parentResourceInfo.getParentLookupPropertyName(idx); parentID == "" && lookupPropertyName != "" {
+			lookupValue, _ := data.Get(lookupPropertyName).(string)
+			if lookupValue == "" {
+				return fmt.Errorf("either '%s' or '%s' must be configured", parentPropertyName, lookupPropertyName)
+			}
+			var err error
+			if parentID, err = lookupParentID(parentResourceInfo.getParentLookup(idx), openAPIClient, lookupValue, parentIDs); err != nil {
+				return fmt.Errorf("failed to resolve '%s' from '%s' value '%s': %s", parentPropertyName, lookupPropertyName, lookupValue, err)
+			}
+			if err := data.Set(parentPropertyName, parentID); err != nil {
+				return err
+			}
+		}
+		parentIDs = append(parentIDs, parentID)
+	}
+	return nil
+}
+
+// lookupParentID lists the parent resources and returns the ID of the only parent which look up property matches the
+// given lookupValue
+func lookupParentID(parentLookup *parentResourceLookup, openAPIClient ClientOpenAPI, lookupValue string, parentIDs []string) (string, error) {
+	responsePayload, err := listAllPages(openAPIClient, parentLookup.listResource, parentIDs...)
+	if err != nil {
+		return "", err
+	}
+	listSchema, err := parentLookup.listResource.GetResourceSchema()
+	if err != nil {
+		return "", err
+	}
+	identifierProperty, err := listSchema.getResourceIdentifier()
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, item := range responsePayload {
+		if value, ok := primitiveValueToString(item[parentLookup.lookupProperty]); ok && value == lookupValue {
+			if id, ok := primitiveValueToString(item[identifierProperty]); ok {
+				matches = append(matches, id)
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no parent resource found with %s '%s'", parentLookup.lookupProperty, lookupValue)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("more than one parent resource found with %s '%s' (%s)", parentLookup.lookupProperty, lookupValue, strings.Join(matches, ", "))
+}
+
 func getParentIDs(openAPIResource SpecResource, data *schema.ResourceData) ([]string, error) {
 	if openAPIResource == nil {
 		return []string{}, errors.New("can't get parent ids from an empty SpecResource")
@@ -103,12 +214,20 @@ func updateStateWithPayloadDataAndOptions(openAPIResource SpecResource, remoteDa
 	for propertyName, propertyRemoteValue := range remoteData {
 		property, err := resourceSchema.getProperty(propertyName)
 		if err != nil {
-			log.Printf("[WARN] The API returned a property that is not specified in the resource's schema definition in the OpenAPI document - error = %s", err)
+			if resourceSchema.Discriminator != "" {
+				log.Printf("[DEBUG] ignoring property '%s' returned by the API as it is not part of the polymorphic resource schema (discriminator '%s')", propertyName, resourceSchema.Discriminator)
+				continue
+			}
+			log.Printf("[DEBUG] The API returned a property that is not specified in the resource's schema definition in the OpenAPI document - error = %s", err)
 			continue
 		}
 		if property.isPropertyNamedID() {
 			continue
 		}
+		if property.Upload != nil {
+			// the API returns the reference to the file uploaded, whereas the state keeps the local file path configured
+			continue
+		}
 
 		propValue := propertyRemoteValue
 		if ignoreListOrderEnabled && property.shouldIgnoreOrder() {
@@ -120,6 +239,9 @@ func updateStateWithPayloadDataAndOptions(openAPIResource SpecResource, remoteDa
 		if err != nil {
 			return err
 		}
+		if ignoreListOrderEnabled && property.isMapOfObjectsProperty() {
+			value = orderMapOfObjectsItems(resourceLocalData.Get(property.GetTerraformCompliantPropertyName()), value)
+		}
 		if value != nil {
 			if err := setResourceDataProperty(*property, value, resourceLocalData); err != nil {
 				return err
@@ -129,6 +251,54 @@ func updateStateWithPayloadDataAndOptions(openAPIResource SpecResource, remoteDa
 	return nil
 }
 
+// getUnknownPayloadFields returns the sorted names of the properties in the payload returned by the API that are not
+// defined in the resource schema. The properties of the subtypes of polymorphic schemas (discriminator) are expected and
+// hence not considered unknown
+func getUnknownPayloadFields(openAPIResource SpecResource, remoteData map[string]interface{}) ([]string, error) {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	unknownFields := []string{}
+	if resourceSchema.Discriminator != "" {
+		return unknownFields, nil
+	}
+	for propertyName := range remoteData {
+		if _, err := resourceSchema.getProperty(propertyName); err != nil {
+			unknownFields = append(unknownFields, propertyName)
+		}
+	}
+	sort.Strings(unknownFields)
+	return unknownFields, nil
+}
+
+// checkUnknownPayloadFields reports the properties returned by the API that are not defined in the resource schema (and
+// hence not saved in the state) as configured in the provider unknown_fields property: warn logs a warning listing them
+// and error returns an error listing them. Nothing is reported by default
+func checkUnknownPayloadFields(openAPIResource SpecResource, remoteData map[string]interface{}, i interface{}) error {
+	client, ok := i.(unknownFieldsClient)
+	if !ok {
+		return nil
+	}
+	unknownFieldsMode := client.getUnknownFields()
+	if unknownFieldsMode != unknownFieldsWarn && unknownFieldsMode != unknownFieldsError {
+		return nil
+	}
+	unknownFields, err := getUnknownPayloadFields(openAPIResource, remoteData)
+	if err != nil {
+		return err
+	}
+	if len(unknownFields) == 0 {
+		return nil
+	}
+	err = fmt.Errorf("the API returned properties that are not defined in the '%s' schema in the OpenAPI document: %s", openAPIResource.GetResourceName(), strings.Join(unknownFields, ", "))
+	if unknownFieldsMode == unknownFieldsWarn {
+		log.Printf("[WARN] %s", err)
+		return nil
+	}
+	return err
+}
+
 // processIgnoreOrderIfEnabled checks whether the property has enabled the `IgnoreItemsOrder` field and if so, goes ahead
 // and returns a new list trying to match as much as possible the input order from the user (not remotes). The following use
 // cases are supported:
@@ -178,14 +348,32 @@ func convertPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty,
 	if propertyValue == nil {
 		return nil, nil
 	}
+	if property.RawJSON {
+		rawJSONValue, err := json.Marshal(propertyValue)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s' value can not be encoded as JSON: %s", property.Name, err)
+		}
+		return string(rawJSONValue), nil
+	}
 	dataValueKind := reflect.TypeOf(propertyValue).Kind()
 	switch dataValueKind {
 	case reflect.Map:
+		if property.isMapOfObjectsProperty() {
+			return convertMapOfObjectsPayloadToLocalStateDataValue(property, propertyValue.(map[string]interface{}))
+		}
+		if property.isMapProperty() {
+			return convertMapPayloadToLocalStateDataValue(property, propertyValue.(map[string]interface{}))
+		}
 		objectInput := map[string]interface{}{}
 		mapValue := propertyValue.(map[string]interface{})
 		for propertyName, propertyValue := range mapValue {
 			schemaDefinitionProperty, err := property.SpecSchemaDefinition.getProperty(propertyName)
 			if err != nil {
+				// the objects of polymorphic schemas may contain properties of the subtypes that are not part of the schema
+				if property.SpecSchemaDefinition.Discriminator != "" {
+					log.Printf("[DEBUG] ignoring property '%s' of object property '%s' as it is not part of the polymorphic object schema (discriminator '%s')", propertyName, property.Name, property.SpecSchemaDefinition.Discriminator)
+					continue
+				}
 				return nil, err
 			}
 			var propValue interface{}
@@ -229,7 +417,7 @@ func convertPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty,
 		}
 		return nil, fmt.Errorf("property '%s' is supposed to be an array objects", property.Name)
 	case reflect.String:
-		return propertyValue.(string), nil
+		return property.Transforms.revert(propertyValue.(string)), nil
 	case reflect.Int:
 		if useString {
 			return fmt.Sprintf("%d", propertyValue.(int)), nil
@@ -267,33 +455,206 @@ func convertPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty,
 	}
 }
 
+// convertMapPayloadToLocalStateDataValue returns the given map value converting the values to the map values type. The
+// keys matching the ignored key prefixes configured for the property (e,g: system tags injected by the API) are left out
+// so they do not cause diffs with the values configured by the user
+func convertMapPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty, mapValue map[string]interface{}) (map[string]interface{}, error) {
+	mapInput := map[string]interface{}{}
+	for key, value := range property.removeIgnoredMapKeys(mapValue) {
+		valueProperty := &SpecSchemaDefinitionProperty{Name: key, Type: property.MapValuesType}
+		convertedValue, err := convertPayloadToLocalStateDataValue(valueProperty, value, property.MapValuesType == TypeString)
+		if err != nil {
+			return nil, err
+		}
+		mapInput[key] = convertedValue
+	}
+	return mapInput, nil
+}
+
+// convertMapOfObjectsPayloadToLocalStateDataValue returns the given map value as the list of blocks (sorted by key)
+// terraform expects for maps which values are objects, where each block contains the map key in the 'key' attribute
+// along with the value properties
+func convertMapOfObjectsPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty, mapValue map[string]interface{}) ([]interface{}, error) {
+	mapValue = property.removeIgnoredMapKeys(mapValue)
+	keys := make([]string, 0, len(mapValue))
+	for key := range mapValue {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// the values are converted as items of a list of objects so the types of the value properties are kept, as terraform
+	// honors property types for resource schemas attached to TypeList properties
+	valuesProperty := &SpecSchemaDefinitionProperty{Name: property.Name, Type: TypeList, ArrayItemsType: TypeObject, SpecSchemaDefinition: property.SpecSchemaDefinition}
+	listInput := []interface{}{}
+	for _, key := range keys {
+		convertedValue, err := convertPayloadToLocalStateDataValue(valuesProperty, mapValue[key], false)
+		if err != nil {
+			return nil, err
+		}
+		objectValue, ok := convertedValue.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("property '%s' is supposed to be a map of objects but the value for key '%s' is not an object", property.Name, key)
+		}
+		objectValue[mapOfObjectsKeyAttributeName] = key
+		listInput = append(listInput, objectValue)
+	}
+	return listInput, nil
+}
+
+// orderMapOfObjectsItems sorts the given list of blocks representing a map of objects following the order of the keys
+// in the desired value (input from user), so the user can configure the map entries in any order without causing diffs.
+// The keys not present in the desired value are kept at the end in the given order.
+func orderMapOfObjectsItems(desiredValue interface{}, value interface{}) interface{} {
+	desiredItems, ok := desiredValue.([]interface{})
+	if !ok || len(desiredItems) == 0 {
+		return value
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	positions := map[string]int{}
+	for idx, desiredItem := range desiredItems {
+		if key, ok := getMapOfObjectsItemKey(desiredItem); ok {
+			if _, exists := positions[key]; !exists {
+				positions[key] = idx
+			}
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		key1, _ := getMapOfObjectsItemKey(items[i])
+		key2, _ := getMapOfObjectsItemKey(items[j])
+		position1, exists1 := positions[key1]
+		position2, exists2 := positions[key2]
+		if exists1 && exists2 {
+			return position1 < position2
+		}
+		return exists1 && !exists2
+	})
+	return items
+}
+
+// getMapOfObjectsItemKey returns the map key of the given block representing a map of objects entry
+func getMapOfObjectsItemKey(item interface{}) (string, bool) {
+	object, ok := item.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	key, ok := object[mapOfObjectsKeyAttributeName].(string)
+	return key, ok
+}
+
 // setResourceDataProperty sets the expectedValue for the given schemaDefinitionPropertyName using the terraform compliant property name
 func setResourceDataProperty(schemaDefinitionProperty SpecSchemaDefinitionProperty, value interface{}, resourceLocalData *schema.ResourceData) error {
 	return resourceLocalData.Set(schemaDefinitionProperty.GetTerraformCompliantPropertyName(), value)
 }
 
+// populatePayloadWithResponseHeaders populates the properties configured with a response header (x-terraform-field-response-header)
+// with the corresponding header value if the payload does not contain already a value for the property. If the header is
+// the Location header, the value used is the last segment of the location path which is expected to be the resource id.
+func populatePayloadWithResponseHeaders(openAPIResource SpecResource, res *http.Response, payload map[string]interface{}) error {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		if property.ResponseHeader == "" || payload[property.Name] != nil {
+			continue
+		}
+		headerValue := res.Header.Get(property.ResponseHeader)
+		if headerValue == "" {
+			continue
+		}
+		if http.CanonicalHeaderKey(property.ResponseHeader) == "Location" {
+			if location, err := url.Parse(headerValue); err == nil {
+				headerValue = path.Base(strings.TrimSuffix(location.Path, "/"))
+			}
+		}
+		value, err := convertPrimitiveValueToPropertyType(property.Type, headerValue)
+		if err != nil {
+			return fmt.Errorf("response header '%s' value can not be used for property '%s': %s", property.ResponseHeader, property.Name, err)
+		}
+		payload[property.Name] = value
+	}
+	return nil
+}
+
 // setStateID sets the local resource's data ID with the newly identifier created in the POST API request. Refer to
 // r.resourceInfo.getResourceIdentifier() for more info regarding what property is selected as the identifier.
 func setStateID(openAPIres SpecResource, resourceLocalData *schema.ResourceData, payload map[string]interface{}) error {
-	resourceSchema, err := openAPIres.GetResourceSchema()
+	id, err := getPayloadID(openAPIres, payload)
 	if err != nil {
 		return err
 	}
+	resourceLocalData.SetId(id)
+	return nil
+}
+
+// getPayloadID returns the value of the resource's identifier property in the given payload
+func getPayloadID(openAPIres SpecResource, payload map[string]interface{}) (string, error) {
+	resourceSchema, err := openAPIres.GetResourceSchema()
+	if err != nil {
+		return "", err
+	}
 	identifierProperty, err := resourceSchema.getResourceIdentifier()
 	if err != nil {
-		return err
+		return "", err
 	}
 	if payload[identifierProperty] == nil {
-		return fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
+		return "", fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
 	}
 
 	switch payload[identifierProperty].(type) {
 	case int:
-		resourceLocalData.SetId(strconv.Itoa(payload[identifierProperty].(int)))
+		return strconv.Itoa(payload[identifierProperty].(int)), nil
 	case float64:
-		resourceLocalData.SetId(strconv.Itoa(int(payload[identifierProperty].(float64))))
+		return strconv.Itoa(int(payload[identifierProperty].(float64))), nil
 	default:
-		resourceLocalData.SetId(payload[identifierProperty].(string))
+		return payload[identifierProperty].(string), nil
 	}
-	return nil
+}
+
+// convertPrimitiveValueToPropertyType converts the given value to the propertyType if the value is a primitive of a
+// different type (e,g: a number stored as a string). Non primitive values are returned as is.
+func convertPrimitiveValueToPropertyType(propertyType schemaDefinitionPropertyType, value interface{}) (interface{}, error) {
+	stringValue, isPrimitive := primitiveValueToString(value)
+	if !isPrimitive {
+		return value, nil
+	}
+	switch propertyType {
+	case TypeString:
+		return stringValue, nil
+	case TypeInt:
+		f, err := strconv.ParseFloat(stringValue, 64)
+		if err != nil || f != float64(int(f)) {
+			return nil, fmt.Errorf("value '%v' can not be converted to %s", value, propertyType)
+		}
+		return int(f), nil
+	case TypeFloat:
+		f, err := strconv.ParseFloat(stringValue, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value '%v' can not be converted to %s", value, propertyType)
+		}
+		return f, nil
+	case TypeBool:
+		b, err := strconv.ParseBool(stringValue)
+		if err != nil {
+			return nil, fmt.Errorf("value '%v' can not be converted to %s", value, propertyType)
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+func primitiveValueToString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int:
+		return strconv.Itoa(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
 }
@@ -37,7 +37,7 @@ func TestCheckHTTPStatusCode(t *testing.T) {
 				StatusCode: http.StatusInternalServerError,
 			},
 			inputStatusCodes: []int{http.StatusOK},
-			expectedError:    errors.New("[resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] (some backend error)"),
+			expectedError:    errors.New("HTTP Response Status Code 500 not matching expected one [200] (some backend error)"),
 		},
 		{
 			name: "response known with code 401 Unauthorized",
@@ -46,14 +46,33 @@ func TestCheckHTTPStatusCode(t *testing.T) {
 				StatusCode: http.StatusUnauthorized,
 			},
 			inputStatusCodes: []int{http.StatusOK},
-			expectedError:    errors.New("[resource='resourceName'] HTTP Response Status Code 401 - Unauthorized: API access is denied due to invalid credentials (unauthorized)"),
+			expectedError:    errors.New("HTTP Response Status Code 401 - Unauthorized: API access is denied due to invalid credentials (unauthorized)"),
+		},
+		{
+			name: "response that IS NOT expected containing an API error body",
+			inputResponse: &http.Response{
+				Body:       ioutil.NopCloser(strings.NewReader(`{"code":"internal_error","message":"something went wrong"}`)),
+				StatusCode: http.StatusInternalServerError,
+			},
+			inputStatusCodes: []int{http.StatusOK},
+			expectedError:    errors.New("HTTP Response Status Code 500 not matching expected one [200] (code='internal_error', message='something went wrong')"),
+		},
+		{
+			name: "response that IS NOT expected containing an RFC 7807 problem details body",
+			inputResponse: &http.Response{
+				Header:     http.Header{"Content-Type": []string{"application/problem+json; charset=utf-8"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"type":"https://api.com/probs/out-of-credit","title":"Out of credit","detail":"Your current balance is 30"}`)),
+				StatusCode: http.StatusForbidden,
+			},
+			inputStatusCodes: []int{http.StatusOK},
+			expectedError:    errors.New("HTTP Response Status Code 403 not matching expected one [200] (code='https://api.com/probs/out-of-credit', message='Your current balance is 30')"),
 		},
 	}
 	Convey("Given a specStubResource", t, func() {
 		openAPIResource := &specStubResource{name: "resourceName"}
 		for _, tc := range testCases {
 			Convey(fmt.Sprintf("When checkHTTPStatusCode is called: %s", tc.name), func() {
-				err := checkHTTPStatusCode(openAPIResource, tc.inputResponse, tc.inputStatusCodes)
+				err := checkHTTPStatusCode(openAPIResource, tc.inputResponse, tc.inputStatusCodes, defaultAPIErrorParser{})
 				Convey("Then the error returned should be the expected one", func() {
 					So(err, ShouldResemble, tc.expectedError)
 				})
@@ -62,6 +81,143 @@ func TestCheckHTTPStatusCode(t *testing.T) {
 	})
 }
 
+func TestGetAPIErrorDetails(t *testing.T) {
+	testCases := []struct {
+		name            string
+		body            string
+		expectedDetails string
+	}{
+		{
+			name:            "empty body",
+			body:            "",
+			expectedDetails: "",
+		},
+		{
+			name:            "body that is not JSON",
+			body:            "some backend error",
+			expectedDetails: "some backend error",
+		},
+		{
+			name:            "body containing the code and message at the root level",
+			body:            `{"code": 1001, "message": "invalid label"}`,
+			expectedDetails: "code='1001', message='invalid label'",
+		},
+		{
+			name:            "body containing the code and message nested in an error object",
+			body:            `{"error": {"code": "invalid_request", "message": "invalid label"}}`,
+			expectedDetails: "code='invalid_request', message='invalid label'",
+		},
+		{
+			name:            "body containing only the message",
+			body:            `{"message": "invalid label"}`,
+			expectedDetails: "message='invalid label'",
+		},
+		{
+			name:            "JSON body not containing the code nor the message",
+			body:            `{"description": "invalid label"}`,
+			expectedDetails: `{"description": "invalid label"}`,
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedDetails, getAPIErrorDetails(defaultAPIErrorParser{}, []byte(tc.body)), tc.name)
+	}
+}
+
+func TestGetAPIErrorCode(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		expectedCode string
+	}{
+		{
+			name:         "empty body",
+			body:         "",
+			expectedCode: "",
+		},
+		{
+			name:         "body that is not JSON",
+			body:         "some backend error",
+			expectedCode: "",
+		},
+		{
+			name:         "body containing the code at the root level",
+			body:         `{"code": "operation_in_progress", "message": "another operation is in progress"}`,
+			expectedCode: "operation_in_progress",
+		},
+		{
+			name:         "body containing the code nested in an error object",
+			body:         `{"error": {"code": "operation_in_progress"}}`,
+			expectedCode: "operation_in_progress",
+		},
+		{
+			name:         "body containing a numeric code",
+			body:         `{"code": 1001}`,
+			expectedCode: "1001",
+		},
+		{
+			name:         "body not containing the code",
+			body:         `{"message": "another operation is in progress"}`,
+			expectedCode: "",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedCode, getAPIErrorCode(defaultAPIErrorParser{}, []byte(tc.body)), tc.name)
+	}
+}
+
+func TestNewResourceOperationError(t *testing.T) {
+	err := newResourceOperationError(resourceKind, "cdns_v1", http.MethodGet, "/v1/cdns/1234", errors.New("HTTP Response Status Code 500 not matching expected one [200] (message='something went wrong')"))
+	assert.EqualError(t, err, "[resource='cdns_v1'] GET /v1/cdns/1234 failed: HTTP Response Status Code 500 not matching expected one [200] (message='something went wrong')")
+}
+
+func TestPopulatePayloadWithResponseHeaders(t *testing.T) {
+	resourceIDProperty := newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil)
+	resourceIDProperty.ResponseHeader = "Location"
+	versionProperty := newIntSchemaDefinitionPropertyWithDefaults("version", "", false, true, nil)
+	versionProperty.ResponseHeader = "X-Resource-Version"
+	openAPIResource := newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{resourceIDProperty, versionProperty},
+	})
+	testCases := []struct {
+		name            string
+		headers         http.Header
+		payload         map[string]interface{}
+		expectedPayload map[string]interface{}
+		expectedError   error
+	}{
+		{
+			name:            "empty payload and response containing the Location and X-Resource-Version headers",
+			headers:         http.Header{"Location": []string{"https://api.example.com/v1/cdns/1234?some=query"}, "X-Resource-Version": []string{"2"}},
+			payload:         map[string]interface{}{},
+			expectedPayload: map[string]interface{}{"id": "1234", "version": 2},
+		},
+		{
+			name:            "payload containing already the id",
+			headers:         http.Header{"Location": []string{"/v1/cdns/1234/"}},
+			payload:         map[string]interface{}{"id": "5678"},
+			expectedPayload: map[string]interface{}{"id": "5678"},
+		},
+		{
+			name:            "response without headers",
+			headers:         http.Header{},
+			payload:         map[string]interface{}{},
+			expectedPayload: map[string]interface{}{},
+		},
+		{
+			name:            "response header value not matching the property type",
+			headers:         http.Header{"X-Resource-Version": []string{"latest"}},
+			payload:         map[string]interface{}{},
+			expectedPayload: map[string]interface{}{},
+			expectedError:   errors.New("response header 'X-Resource-Version' value can not be used for property 'version': value 'latest' can not be converted to integer"),
+		},
+	}
+	for _, tc := range testCases {
+		err := populatePayloadWithResponseHeaders(openAPIResource, &http.Response{Header: tc.headers}, tc.payload)
+		assert.Equal(t, tc.expectedError, err, tc.name)
+		assert.Equal(t, tc.expectedPayload, tc.payload, tc.name)
+	}
+}
+
 func TestResponseContainsExpectedStatus(t *testing.T) {
 	testCases := []struct {
 		name                     string
@@ -240,6 +396,75 @@ func Test_getParentIDs(t *testing.T) {
 	})
 }
 
+func TestResolveParentIDs(t *testing.T) {
+	Convey("Given a sub-resource which parent can be looked up by label", t, func() {
+		parentListResource := newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				idProperty,
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+			},
+		})
+		s := &specStubResource{
+			name:                   "firewall",
+			path:                   "/v1/cdns/{id}/firewall",
+			schemaDefinition:       &SpecSchemaDefinition{},
+			parentResourceNames:    []string{"cdns_v1"},
+			fullParentResourceName: "cdns_v1",
+			parentLookups:          []*parentResourceLookup{{lookupProperty: "label", listResource: parentListResource}},
+		}
+		resourceData := (&schema.Resource{Schema: map[string]*schema.Schema{
+			"cdns_v1_id":    {Type: schema.TypeString, Optional: true, Computed: true},
+			"cdns_v1_label": {Type: schema.TypeString, Optional: true},
+		}}).Data(nil)
+		client := &clientOpenAPIStub{
+			responseListPayload: []map[string]interface{}{
+				{"id": "cdnID1", "label": "cdn one"},
+				{"id": "cdnID2", "label": "cdn two"},
+				{"id": "cdnID3", "label": "cdn duplicated"},
+				{"id": "cdnID4", "label": "cdn duplicated"},
+			},
+		}
+		Convey("When resolveParentIDs is called with a resource data containing the parent look up value", func() {
+			So(resourceData.Set("cdns_v1_label", "cdn two"), ShouldBeNil)
+			err := resolveParentIDs(s, client, resourceData)
+			Convey("Then the parent ID should be resolved from the parent matching the look up value", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get("cdns_v1_id"), ShouldEqual, "cdnID2")
+				So(client.parentIDsReceived, ShouldBeEmpty)
+			})
+		})
+		Convey("When resolveParentIDs is called with a resource data containing the parent ID", func() {
+			So(resourceData.Set("cdns_v1_id", "cdnID1"), ShouldBeNil)
+			client.error = errors.New("list should not be called")
+			err := resolveParentIDs(s, client, resourceData)
+			Convey("Then the configured parent ID should be kept", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get("cdns_v1_id"), ShouldEqual, "cdnID1")
+			})
+		})
+		Convey("When resolveParentIDs is called with a look up value that does not match any parent", func() {
+			So(resourceData.Set("cdns_v1_label", "cdn unknown"), ShouldBeNil)
+			err := resolveParentIDs(s, client, resourceData)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to resolve 'cdns_v1_id' from 'cdns_v1_label' value 'cdn unknown': no parent resource found with label 'cdn unknown'")
+			})
+		})
+		Convey("When resolveParentIDs is called with a look up value that matches more than one parent", func() {
+			So(resourceData.Set("cdns_v1_label", "cdn duplicated"), ShouldBeNil)
+			err := resolveParentIDs(s, client, resourceData)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to resolve 'cdns_v1_id' from 'cdns_v1_label' value 'cdn duplicated': more than one parent resource found with label 'cdn duplicated' (cdnID3, cdnID4)")
+			})
+		})
+		Convey("When resolveParentIDs is called with a resource data that contains neither the parent ID nor the look up value", func() {
+			err := resolveParentIDs(s, client, resourceData)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "either 'cdns_v1_id' or 'cdns_v1_label' must be configured")
+			})
+		})
+	})
+}
+
 func TestUpdateStateWithPayloadData(t *testing.T) {
 	Convey("Given a resource factory", t, func() {
 		objectSchemaDefinition := &SpecSchemaDefinition{
@@ -547,6 +772,72 @@ func TestUpdateStateWithPayloadDataAndOptions(t *testing.T) {
 	})
 }
 
+func TestUpdateStateWithPayloadDataUploadProperties(t *testing.T) {
+	Convey("Given a resource factory containing a property configured with an upload endpoint", t, func() {
+		uploadProperty := newStringSchemaDefinitionPropertyWithDefaults("certificate", "", true, false, nil)
+		uploadProperty.Upload = &specUpload{path: "/v1/uploads", referenceProperty: uploadDefaultReferenceProperty}
+		r, resourceData := testCreateResourceFactory(t, stringProperty, uploadProperty)
+		resourceData.Set(uploadProperty.Name, "/tmp/certificate.pem")
+		Convey("When updateStateWithPayloadData is called with the payload returned by the API containing the reference to the file uploaded", func() {
+			err := updateStateWithPayloadData(r.openAPIResource, map[string]interface{}{stringProperty.Name: "someValue", uploadProperty.Name: "upload-1234"}, resourceData)
+			Convey("Then the state should keep the local file path configured", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someValue")
+				So(resourceData.Get(uploadProperty.Name), ShouldEqual, "/tmp/certificate.pem")
+			})
+		})
+	})
+}
+
+func TestUpdateStateWithPayloadDataPolymorphicSchemas(t *testing.T) {
+	Convey("Given a resource factory containing a polymorphic object property (discriminator)", t, func() {
+		petSchemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("pet_type", "", true, false, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("name", "", false, false, nil),
+			},
+			Discriminator: "pet_type",
+		}
+		petProperty := newObjectSchemaDefinitionPropertyWithDefaults("pet", "", true, false, false, nil, petSchemaDefinition)
+		r, resourceData := testCreateResourceFactory(t, petProperty)
+		Convey("When updateStateWithPayloadData is called with a remote object of a subtype containing properties that are not part of the schema", func() {
+			remoteData := map[string]interface{}{
+				petProperty.Name: map[string]interface{}{
+					"pet_type":    "dog",
+					"name":        "rex",
+					"bark_volume": 11,
+				},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			Convey("Then the error should be nil and only the properties of the schema should be stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get(petProperty.GetTerraformCompliantPropertyName()), ShouldResemble, map[string]interface{}{"pet_type": "dog", "name": "rex"})
+			})
+		})
+	})
+	Convey("Given a resource factory containing a non polymorphic object property", t, func() {
+		objectSchemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("name", "", false, false, nil),
+			},
+		}
+		objectProperty := newObjectSchemaDefinitionPropertyWithDefaults("object_property", "", true, false, false, nil, objectSchemaDefinition)
+		r, resourceData := testCreateResourceFactory(t, objectProperty)
+		Convey("When updateStateWithPayloadData is called with a remote object containing properties that are not part of the schema", func() {
+			remoteData := map[string]interface{}{
+				objectProperty.Name: map[string]interface{}{
+					"name":    "rex",
+					"unknown": 11,
+				},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "property with name 'unknown' not existing in resource schema definition")
+			})
+		})
+	})
+}
+
 func TestConvertPayloadToLocalStateDataValue(t *testing.T) {
 
 	Convey("Given a resource factory", t, func() {
@@ -561,6 +852,61 @@ func TestConvertPayloadToLocalStateDataValue(t *testing.T) {
 			})
 		})
 
+		Convey("When convertPayloadToLocalStateDataValue is called with a string property configured with transforms and a transformed value", func() {
+			property := &SpecSchemaDefinitionProperty{Name: "project", Type: TypeString, Transforms: propertyTransforms{{kind: propertyTransformPrefix, prefix: "projects/"}}}
+			resultValue, err := convertPayloadToLocalStateDataValue(property, "projects/my-project", false)
+			Convey("Then the error should be nil and the result value should be the value with the transforms reverted", func() {
+				So(err, ShouldBeNil)
+				So(resultValue, ShouldEqual, "my-project")
+			})
+		})
+
+		Convey("When convertPayloadToLocalStateDataValue is called with a property exposed as raw JSON and an object value", func() {
+			property := &SpecSchemaDefinitionProperty{Name: "settings", Type: TypeString, RawJSON: true}
+			dataValue := map[string]interface{}{"enabled": true, "ports": []interface{}{80, 443}}
+			resultValue, err := convertPayloadToLocalStateDataValue(property, dataValue, false)
+			Convey("Then the error should be nil and the result value should be the JSON representation of the value", func() {
+				So(err, ShouldBeNil)
+				So(resultValue, ShouldEqual, `{"enabled":true,"ports":[80,443]}`)
+			})
+		})
+
+		Convey("When convertPayloadToLocalStateDataValue is called with a map property configured with ignored key prefixes and a map value", func() {
+			property := &SpecSchemaDefinitionProperty{Name: "tags", Type: TypeMap, MapValuesType: TypeString, IgnoredKeyPrefixes: []string{"aws:", "system/"}}
+			dataValue := map[string]interface{}{"env": "prod", "aws:created_by": "someone", "system/owner": "someteam"}
+			resultValue, err := convertPayloadToLocalStateDataValue(property, dataValue, false)
+			Convey("Then the error should be nil and the result value should not contain the keys with the ignored prefixes", func() {
+				So(err, ShouldBeNil)
+				So(resultValue, ShouldResemble, map[string]interface{}{"env": "prod"})
+			})
+		})
+
+		Convey("When convertPayloadToLocalStateDataValue is called with a map property which values are objects and a map value", func() {
+			property := &SpecSchemaDefinitionProperty{
+				Name:          "environments",
+				Type:          TypeMap,
+				MapValuesType: TypeObject,
+				SpecSchemaDefinition: &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						newIntSchemaDefinitionPropertyWithDefaults("replicas", "", true, false, nil),
+						newStringSchemaDefinitionPropertyWithDefaults("region", "", false, false, nil),
+					},
+				},
+			}
+			dataValue := map[string]interface{}{
+				"prod": map[string]interface{}{"replicas": float64(3), "region": "us-east-1"},
+				"dev":  map[string]interface{}{"replicas": float64(1), "region": "us-west-2"},
+			}
+			resultValue, err := convertPayloadToLocalStateDataValue(property, dataValue, false)
+			Convey("Then the error should be nil and the result value should be the list of map entries sorted by key keeping the value types", func() {
+				So(err, ShouldBeNil)
+				So(resultValue, ShouldResemble, []interface{}{
+					map[string]interface{}{"key": "dev", "replicas": 1, "region": "us-west-2"},
+					map[string]interface{}{"key": "prod", "replicas": 3, "region": "us-east-1"},
+				})
+			})
+		})
+
 		Convey("When convertPayloadToLocalStateDataValue is called with a bool property and a bool value", func() {
 			property := newBoolSchemaDefinitionPropertyWithDefaults("bool_property", "", false, false, nil)
 			dataValue := true
@@ -1269,3 +1615,47 @@ func TestProcessIgnoreOrderIfEnabled(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, output, tc.name)
 	}
 }
+
+func TestOrderMapOfObjectsItems(t *testing.T) {
+	items := func(keys ...string) []interface{} {
+		result := []interface{}{}
+		for _, key := range keys {
+			result = append(result, map[string]interface{}{mapOfObjectsKeyAttributeName: key})
+		}
+		return result
+	}
+	testCases := []struct {
+		name          string
+		desiredValue  interface{}
+		value         interface{}
+		expectedValue interface{}
+	}{
+		{
+			name:          "no desired value",
+			desiredValue:  nil,
+			value:         items("a", "b"),
+			expectedValue: items("a", "b"),
+		},
+		{
+			name:          "desired value with the same keys in different order",
+			desiredValue:  items("c", "a", "b"),
+			value:         items("a", "b", "c"),
+			expectedValue: items("c", "a", "b"),
+		},
+		{
+			name:          "value containing keys not present in the desired value",
+			desiredValue:  items("c", "a"),
+			value:         items("a", "b", "c", "d"),
+			expectedValue: items("c", "a", "b", "d"),
+		},
+		{
+			name:          "value missing keys present in the desired value",
+			desiredValue:  items("c", "b", "a"),
+			value:         items("a", "c"),
+			expectedValue: items("c", "a"),
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedValue, orderMapOfObjectsItems(tc.desiredValue, tc.value), tc.name)
+	}
+}
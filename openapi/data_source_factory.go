@@ -1,17 +1,45 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
+	"log"
 	"math"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
+// dataSourceKind is the kind of terraform resource used in the errors returned by the data source operations
+const dataSourceKind = "data source"
+
 const dataSourceFilterPropertyName = "filter"
 const dataSourceFilterSchemaNamePropertyName = "name"
 const dataSourceFilterSchemaValuesPropertyName = "values"
+const dataSourceFilterSchemaOperatorPropertyName = "operator"
+const dataSourcePageSizePropertyName = "page_size"
+const dataSourceMostRecentPropertyName = "most_recent"
+
+// dataSourceTotalCountPropertyName is the attribute of the plural data sources containing the number of items returned
+// (count is a terraform meta-argument hence it can not be used as attribute name)
+const dataSourceTotalCountPropertyName = "total_count"
+
+// The operators supported by the data source filters. Except for equals, the operators match the string representation of
+// the payload value
+const (
+	filterOperatorEquals   = "equals"
+	filterOperatorContains = "contains"
+	filterOperatorPrefix   = "prefix"
+	filterOperatorSuffix   = "suffix"
+	filterOperatorRegex    = "regex"
+)
+
+// filterNumberEpsilon is the relative tolerance used when comparing numeric filter values
+const filterNumberEpsilon = 1e-9
 
 type dataSourceFactory struct {
 	openAPIResource SpecResource
@@ -19,8 +47,13 @@ type dataSourceFactory struct {
 
 type filters []filter
 type filter struct {
-	name  string
-	value string
+	name string
+	// values are the values the payload value is matched against. The filter matches if any of the values matches
+	values []string
+	// operator is how the payload value is matched against the filter values, equals if empty
+	operator string
+	// regexes are the filter values compiled when the operator is regex
+	regexes []*regexp.Regexp
 }
 
 func newDataSourceFactory(openAPIResource SpecResource) dataSourceFactory {
@@ -50,9 +83,121 @@ func (d dataSourceFactory) createTerraformDataSourceSchema() (map[string]*schema
 		return nil, err
 	}
 	dataSourceSchema[dataSourceFilterPropertyName] = d.dataSourceFiltersSchema()
+	if d.supportsPageSize() {
+		dataSourceSchema[dataSourcePageSizePropertyName] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: positiveIntValidateFunc,
+			Description:  "Number of items requested per page when listing the resources, overriding the page size configured in the provider",
+		}
+	}
+	if mostRecentProperty := d.getMostRecentProperty(); mostRecentProperty != nil {
+		dataSourceSchema[dataSourceMostRecentPropertyName] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: fmt.Sprintf("If more than one item matches the filters, use the most recent one according to the '%s' property", mostRecentProperty.GetTerraformCompliantPropertyName()),
+		}
+	}
 	return dataSourceSchema, nil
 }
 
+// supportsPageSize returns true if the data source exposes the page_size argument, which is the case if the list
+// operation supports requesting the page size (x-terraform-pagination-page-size-param) unless the data source schema
+// already contains a property with the same name
+func (d dataSourceFactory) supportsPageSize() bool {
+	if !d.openAPIResource.getResourceOperations().List.supportsPageSize() {
+		return false
+	}
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return false
+	}
+	if _, err := specSchema.getPropertyBasedOnTerraformName(dataSourcePageSizePropertyName); err == nil {
+		log.Printf("[WARN] '%s' data source already contains a property named '%s', skipping the page size argument", d.openAPIResource.GetResourceName(), dataSourcePageSizePropertyName)
+		return false
+	}
+	return true
+}
+
+// getMostRecentProperty returns the timestamp property used to select the most recent item when the data source
+// most_recent argument is enabled. Nil is returned if the list operation is not configured with the
+// x-terraform-most-recent-property extension, the property is not a string, integer or number property of the data source
+// schema or the data source schema already contains a property named most_recent
+func (d dataSourceFactory) getMostRecentProperty() *SpecSchemaDefinitionProperty {
+	operation := d.openAPIResource.getResourceOperations().List
+	if operation == nil || operation.mostRecentProperty == "" {
+		return nil
+	}
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil
+	}
+	resourceName := d.openAPIResource.GetResourceName()
+	if _, err := specSchema.getPropertyBasedOnTerraformName(dataSourceMostRecentPropertyName); err == nil {
+		log.Printf("[WARN] '%s' data source already contains a property named '%s', skipping the most recent argument", resourceName, dataSourceMostRecentPropertyName)
+		return nil
+	}
+	property, err := specSchema.getProperty(operation.mostRecentProperty)
+	if err != nil {
+		log.Printf("[WARN] '%s' data source %s configured in %s, skipping the most recent argument", resourceName, err, extTfMostRecentProperty)
+		return nil
+	}
+	if property.Type != TypeString && property.Type != TypeInt && property.Type != TypeFloat {
+		log.Printf("[WARN] '%s' data source property '%s' configured in %s is of type '%s' which is not supported for timestamps, skipping the most recent argument", resourceName, property.Name, extTfMostRecentProperty, property.Type)
+		return nil
+	}
+	return property
+}
+
+// getClientWithPageSize returns the client configured with the page size set in the data source page_size argument, if
+// the data source supports it
+func (d dataSourceFactory) getClientWithPageSize(openAPIClient ClientOpenAPI, data *schema.ResourceData) ClientOpenAPI {
+	if !d.supportsPageSize() {
+		return openAPIClient
+	}
+	client, ok := openAPIClient.(pageSizeClient)
+	if !ok {
+		return openAPIClient
+	}
+	if pageSize, ok := data.Get(dataSourcePageSizePropertyName).(int); ok && pageSize > 0 {
+		return client.withPageSize(pageSize)
+	}
+	return openAPIClient
+}
+
+// getClientWithFilterQueryParameters returns the client configured to send the filters as the query parameters declared
+// in the list operation, so the API filters the collection instead of returning all the items. The items returned are
+// still filtered client side, hence APIs ignoring the query parameters return the same results.
+func (d dataSourceFactory) getClientWithFilterQueryParameters(openAPIClient ClientOpenAPI, filters filters) ClientOpenAPI {
+	client, ok := openAPIClient.(filterQueryParametersClient)
+	if !ok {
+		return openAPIClient
+	}
+	return client.withFilterQueryParameters(d.getFilterQueryParameters(filters))
+}
+
+// getFilterQueryParameters returns the values of the filters which names match the query parameters flagged as filters
+// in the list operation (indexed by the query parameter terraform name). Only the filters using the equals operator with
+// a single value can be sent as query parameters.
+func (d dataSourceFactory) getFilterQueryParameters(filters filters) map[string]string {
+	queryParameters := map[string]string{}
+	operation := d.openAPIResource.getResourceOperations().List
+	if operation == nil {
+		return queryParameters
+	}
+	for _, filter := range filters {
+		if (filter.operator != "" && filter.operator != filterOperatorEquals) || len(filter.values) != 1 {
+			continue
+		}
+		for _, queryParam := range operation.QueryParameters {
+			if queryParam.IsFilter && queryParam.GetQueryParamTerraformName() == filter.name {
+				queryParameters[filter.name] = filter.values[0]
+			}
+		}
+	}
+	return queryParameters
+}
+
 func (d dataSourceFactory) dataSourceFiltersSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeSet,
@@ -69,13 +214,21 @@ func (d dataSourceFactory) dataSourceFiltersSchema() *schema.Schema {
 					Required: true,
 					Elem:     &schema.Schema{Type: schema.TypeString},
 				},
+				dataSourceFilterSchemaOperatorPropertyName: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      filterOperatorEquals,
+					ValidateFunc: filterOperatorValidateFunc,
+					Description:  "How the property value is matched against the filter value: equals (default), contains, prefix, suffix or regex",
+				},
 			},
 		},
 	}
 }
 
 func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error {
-	openAPIClient := i.(ClientOpenAPI)
+	openAPIClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
 
 	if d.openAPIResource == nil {
 		return fmt.Errorf("missing openAPI resource configuration")
@@ -84,28 +237,31 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
 
-	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
-	if err != nil {
+	if err := resolveParentIDs(d.openAPIResource, openAPIClient, data); err != nil {
 		return err
 	}
 
-	filters, err := d.validateInput(data)
+	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
 		return err
 	}
 
-	responsePayload := []map[string]interface{}{}
-	resp, err := openAPIClient.List(d.openAPIResource, &responsePayload, parentIDs...)
+	filters, err := d.validateInput(data)
 	if err != nil {
 		return err
 	}
 
-	if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return fmt.Errorf("[data source='%s'] GET %s failed: %s", resourceName, resourcePath, err)
+	openAPIClient = d.getClientWithPageSize(openAPIClient, data)
+	openAPIClient = d.getClientWithFilterQueryParameters(openAPIClient, filters)
+	// all the pages are fetched if the list operation is paginated (x-terraform-pagination), otherwise the items
+	// matching the filters that are not in the first page would be missed
+	items, err := listAllPages(openAPIClient, d.openAPIResource, parentIDs...)
+	if err != nil {
+		return newResourceOperationError(dataSourceKind, resourceName, http.MethodGet, resourcePath, err)
 	}
 
 	var filteredResults []map[string]interface{}
-	for _, payloadItem := range responsePayload {
+	for _, payloadItem := range items {
 		match := d.filterMatch(filters, payloadItem)
 		if match {
 			filteredResults = append(filteredResults, payloadItem)
@@ -117,7 +273,15 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 	}
 
 	if len(filteredResults) > 1 {
-		return fmt.Errorf("your query returned contains more than one result. Please change your search criteria to make it more specific")
+		mostRecentProperty := d.getMostRecentProperty()
+		if mostRecentProperty == nil || !data.Get(dataSourceMostRecentPropertyName).(bool) {
+			return fmt.Errorf("your query returned contains more than one result. Please change your search criteria to make it more specific")
+		}
+		mostRecentItem, err := getMostRecentItem(filteredResults, mostRecentProperty)
+		if err != nil {
+			return fmt.Errorf("[%s='%s'] %s", dataSourceKind, resourceName, err)
+		}
+		filteredResults = []map[string]interface{}{mostRecentItem}
 	}
 
 	err = setStateID(d.openAPIResource, data, filteredResults[0])
@@ -125,31 +289,60 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 
+	if err := checkUnknownPayloadFields(d.openAPIResource, filteredResults[0], i); err != nil {
+		return err
+	}
+	if err := encryptPayloadValues(d.openAPIResource, dataSourceKind, filteredResults[0], data, i); err != nil {
+		return err
+	}
 	return dataSourceUpdateStateWithPayloadData(d.openAPIResource, filteredResults[0], data)
 }
 
+// getMostRecentItem returns the item which timestamp property holds the most recent time. The timestamps are expected to
+// be RFC3339 date-times in string properties and Unix times in seconds in integer and number properties. If several items
+// hold the most recent time the first one is returned
+func getMostRecentItem(items []map[string]interface{}, timestampProperty *SpecSchemaDefinitionProperty) (map[string]interface{}, error) {
+	var mostRecentItem map[string]interface{}
+	var mostRecentTime time.Time
+	for _, item := range items {
+		timestamp, err := parseTimestamp(item[timestampProperty.Name])
+		if err != nil {
+			return nil, fmt.Errorf("could not select the most recent item, property '%s' %s", timestampProperty.Name, err)
+		}
+		if mostRecentItem == nil || timestamp.After(mostRecentTime) {
+			mostRecentItem = item
+			mostRecentTime = timestamp
+		}
+	}
+	return mostRecentItem, nil
+}
+
+// parseTimestamp returns the time represented by the given payload value, either a RFC3339 date-time or a Unix time in
+// seconds
+func parseTimestamp(value interface{}) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, fmt.Errorf("is missing")
+	}
+	if stringValue, ok := value.(string); ok {
+		timestamp, err := time.Parse(time.RFC3339, stringValue)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("value '%s' is not a RFC3339 date-time", stringValue)
+		}
+		return timestamp, nil
+	}
+	if seconds, ok := toFloat64(value); ok {
+		wholeSeconds, fraction := math.Modf(seconds)
+		return time.Unix(int64(wholeSeconds), int64(fraction*float64(time.Second))), nil
+	}
+	return time.Time{}, fmt.Errorf("value '%v' is not a timestamp", value)
+}
+
 func (d dataSourceFactory) filterMatch(filters filters, payloadItem map[string]interface{}) bool {
 	specSchemaDefinition, _ := d.openAPIResource.GetResourceSchema() // ignoring error because will be caught beforehand when data source is constructed via createTerraformDataSourceSchema
 	for _, filter := range filters {
-		if val, exists := payloadItem[filter.name]; exists {
-			schemaProperty, _ := specSchemaDefinition.getProperty(filter.name)
-			var value string
-			switch schemaProperty.Type {
-			case TypeInt:
-				value = strconv.Itoa(val.(int))
-			case TypeFloat:
-				v := val.(float64)                            //because of payloadItem is map[string]interface{} a float with decimal point is treat as an int
-				if _, decimal := math.Modf(v); decimal == 0 { //we recognize this special case here and print the value accordingly
-					value = fmt.Sprintf("%.1f", val) //if it's like 6.0, force the .0 to be there and match the filetr condition
-				} else {
-					value = fmt.Sprintf("%g", val) //if the float has a decimal part != 0  the use the %g to keep it real float value
-				}
-			case TypeBool:
-				value = strconv.FormatBool(val.(bool))
-			default:
-				value = val.(string)
-			}
-			if value == filter.value {
+		if val, exists := getFilterPayloadValue(payloadItem, filter.name); exists {
+			propertyType, _ := getFilterPropertyType(specSchemaDefinition, filter.name)
+			if filter.match(propertyType, val) {
 				continue
 			}
 		}
@@ -158,6 +351,141 @@ func (d dataSourceFactory) filterMatch(filters filters, payloadItem map[string]i
 	return true
 }
 
+// match returns true if the given payload value matches any of the filter values according to the filter operator
+func (f filter) match(propertyType schemaDefinitionPropertyType, payloadValue interface{}) bool {
+	for i, value := range f.values {
+		var regex *regexp.Regexp
+		if i < len(f.regexes) {
+			regex = f.regexes[i]
+		}
+		if f.matchValue(propertyType, payloadValue, value, regex) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchValue returns true if the given payload value matches the given filter value according to the filter operator.
+// The payload values that are not primitives never match the contains, prefix, suffix and regex operators
+func (f filter) matchValue(propertyType schemaDefinitionPropertyType, payloadValue interface{}, filterValue string, regex *regexp.Regexp) bool {
+	if f.operator == "" || f.operator == filterOperatorEquals {
+		return filterValueMatch(propertyType, payloadValue, filterValue)
+	}
+	stringValue, ok := primitiveValueToString(payloadValue)
+	if !ok {
+		return false
+	}
+	switch f.operator {
+	case filterOperatorContains:
+		return strings.Contains(stringValue, filterValue)
+	case filterOperatorPrefix:
+		return strings.HasPrefix(stringValue, filterValue)
+	case filterOperatorSuffix:
+		return strings.HasSuffix(stringValue, filterValue)
+	case filterOperatorRegex:
+		if regex == nil {
+			var err error
+			if regex, err = regexp.Compile(filterValue); err != nil {
+				return false
+			}
+		}
+		return regex.MatchString(stringValue)
+	}
+	return false
+}
+
+func filterOperatorValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	switch value {
+	case filterOperatorEquals, filterOperatorContains, filterOperatorPrefix, filterOperatorSuffix, filterOperatorRegex:
+	default:
+		errs = append(errs, fmt.Errorf("property '%s' value '%v' is not valid, the supported values are: %s, %s, %s, %s and %s", key, value, filterOperatorEquals, filterOperatorContains, filterOperatorPrefix, filterOperatorSuffix, filterOperatorRegex))
+	}
+	return
+}
+
+// filterValueMatch returns true if the given payload value matches the filter value. Integer and number values are
+// compared numerically regardless of the type the payload value was decoded with (e,g: JSON numbers are decoded as
+// float64) and the notation used in the filter value (e,g: 1e3, 1000 and 1000.0 are equal). The rest of values are
+// compared using their string representation.
+func filterValueMatch(propertyType schemaDefinitionPropertyType, payloadValue interface{}, filterValue string) bool {
+	switch propertyType {
+	case TypeInt:
+		if payloadInt, ok := toInt64(payloadValue); ok {
+			if filterInt, err := strconv.ParseInt(strings.TrimSpace(filterValue), 10, 64); err == nil {
+				return payloadInt == filterInt
+			}
+		}
+		return filterNumberMatch(payloadValue, filterValue)
+	case TypeFloat:
+		return filterNumberMatch(payloadValue, filterValue)
+	case TypeBool:
+		payloadBool, ok := payloadValue.(bool)
+		filterBool, err := strconv.ParseBool(strings.TrimSpace(filterValue))
+		return ok && err == nil && payloadBool == filterBool
+	}
+	return fmt.Sprint(payloadValue) == filterValue
+}
+
+// filterNumberMatch returns true if the given payload value and filter value are equal numbers, within a relative
+// tolerance of filterNumberEpsilon to absorb the precision lost when the values are converted to float64
+func filterNumberMatch(payloadValue interface{}, filterValue string) bool {
+	payloadNumber, ok := toFloat64(payloadValue)
+	if !ok {
+		return false
+	}
+	filterNumber, err := strconv.ParseFloat(strings.TrimSpace(filterValue), 64)
+	if err != nil {
+		return false
+	}
+	if payloadNumber == filterNumber {
+		return true
+	}
+	return math.Abs(payloadNumber-filterNumber) <= filterNumberEpsilon*math.Max(math.Abs(payloadNumber), math.Abs(filterNumber))
+}
+
+// toInt64 returns the given value as an int64 if it's an integer or a float64 without decimal part (as JSON numbers
+// are decoded)
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if _, decimal := math.Modf(v); decimal == 0 && math.Abs(v) < math.MaxInt64 {
+			return int64(v), true
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// toFloat64 returns the given numeric value as a float64
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
 func (d dataSourceFactory) validateInput(data *schema.ResourceData) (filters, error) {
 	filters := filters{}
 	inputFilters := data.Get(dataSourceFilterPropertyName)
@@ -166,20 +494,95 @@ func (d dataSourceFactory) validateInput(data *schema.ResourceData) (filters, er
 		filterPropertyName := f[dataSourceFilterSchemaNamePropertyName].(string)
 		s, _ := d.openAPIResource.GetResourceSchema() // ignoring error because will be caught beforehand when data source is constructed via createTerraformDataSourceSchema
 
-		specSchemaDefinitionProperty, err := s.getProperty(filterPropertyName)
-		if err != nil {
-			return nil, fmt.Errorf("filter name does not match any of the schema properties: %s", err)
+		if _, err := getFilterPropertyType(s, filterPropertyName); err != nil {
+			return nil, err
 		}
 
-		if !specSchemaDefinitionProperty.isPrimitiveProperty() {
-			return nil, fmt.Errorf("property not supported as as filter: %s", specSchemaDefinitionProperty.GetTerraformCompliantPropertyName())
+		operator, _ := f[dataSourceFilterSchemaOperatorPropertyName].(string)
+		var values []string
+		var regexes []*regexp.Regexp
+		for _, filterValue := range f[dataSourceFilterSchemaValuesPropertyName].([]interface{}) {
+			value, _ := filterValue.(string)
+			if operator == filterOperatorRegex {
+				regex, err := regexp.Compile(value)
+				if err != nil {
+					return nil, fmt.Errorf("filter '%s' regex '%s' not valid: %s", filterPropertyName, value, err)
+				}
+				regexes = append(regexes, regex)
+			}
+			values = append(values, value)
 		}
-
-		filterValue := f[dataSourceFilterSchemaValuesPropertyName].([]interface{})
-		if len(filterValue) > 1 {
-			return nil, fmt.Errorf("filters for primitive properties can not have more than one value in the values field")
+		if len(values) == 0 {
+			return nil, fmt.Errorf("filter '%s' must have at least one value in the values field", filterPropertyName)
 		}
-		filters = append(filters, filter{filterPropertyName, filterValue[0].(string)})
+		filters = append(filters, filter{name: filterPropertyName, values: values, operator: operator, regexes: regexes})
 	}
 	return filters, nil
 }
+
+// getFilterPropertyType returns the type of the primitive property the given filter name refers to. Nested properties
+// are referred to using dotted paths where each segment is either the name of an object property or the key of a map
+// property (e,g: config.protocol for the protocol property of the config object or tags.environment for the environment
+// key of the tags map). Names matching a top level property (even if they contain dots) refer to that property.
+func getFilterPropertyType(specSchemaDefinition *SpecSchemaDefinition, filterName string) (schemaDefinitionPropertyType, error) {
+	property, err := specSchemaDefinition.getProperty(filterName)
+	if err == nil || !strings.Contains(filterName, ".") {
+		if err != nil {
+			return "", fmt.Errorf("filter name does not match any of the schema properties: %s", err)
+		}
+		if !property.isPrimitiveProperty() {
+			return "", fmt.Errorf("property not supported as as filter: %s", property.GetTerraformCompliantPropertyName())
+		}
+		return property.Type, nil
+	}
+	segments := strings.Split(filterName, ".")
+	schemaDefinition := specSchemaDefinition
+	for i := 0; i < len(segments) && schemaDefinition != nil; i++ {
+		property, err := schemaDefinition.getProperty(segments[i])
+		if err != nil {
+			return "", fmt.Errorf("filter name does not match any of the schema properties: %s", err)
+		}
+		isLastSegment := i == len(segments)-1
+		switch {
+		case property.isPrimitiveProperty() && isLastSegment:
+			return property.Type, nil
+		case property.isObjectProperty() && !isLastSegment:
+			schemaDefinition = property.SpecSchemaDefinition
+		case property.isMapProperty() && !isLastSegment:
+			i++ // the next segment is the map key
+			valueProperty := &SpecSchemaDefinitionProperty{Name: segments[i], Type: property.MapValuesType}
+			if i == len(segments)-1 && valueProperty.isPrimitiveProperty() {
+				return valueProperty.Type, nil
+			}
+			if !property.isMapOfObjectsProperty() || i == len(segments)-1 {
+				return "", fmt.Errorf("property not supported as as filter: %s", filterName)
+			}
+			schemaDefinition = property.SpecSchemaDefinition
+		default:
+			return "", fmt.Errorf("property not supported as as filter: %s", filterName)
+		}
+	}
+	return "", fmt.Errorf("property not supported as as filter: %s", filterName)
+}
+
+// getFilterPayloadValue returns the value in the payload item the given filter name refers to, navigating the nested
+// objects and maps for dotted paths. See getFilterPropertyType for the supported filter names.
+func getFilterPayloadValue(payloadItem map[string]interface{}, filterName string) (interface{}, bool) {
+	if value, exists := payloadItem[filterName]; exists {
+		return value, true
+	}
+	if !strings.Contains(filterName, ".") {
+		return nil, false
+	}
+	var value interface{} = payloadItem
+	for _, segment := range strings.Split(filterName, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[segment]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
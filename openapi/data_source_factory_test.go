@@ -2,8 +2,13 @@ package openapi
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/dikhan/http_goclient"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -125,6 +130,155 @@ func TestCreateTerraformDataSourceSchema(t *testing.T) {
 	}
 }
 
+func TestCreateTerraformDataSourceSchemaPageSize(t *testing.T) {
+	labelProperty := newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil)
+	pageSizeProperty := newStringSchemaDefinitionPropertyWithDefaults(dataSourcePageSizePropertyName, "", false, false, nil)
+	testCases := []struct {
+		name                 string
+		openAPIResource      SpecResource
+		expectedPageSizeType schema.ValueType
+	}{
+		{
+			name: "list operation supports requesting the page size",
+			openAPIResource: &specStubResource{
+				schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{labelProperty}},
+				resourceListOperation: &specResourceOperation{pageSizeParam: "limit"},
+			},
+			expectedPageSizeType: schema.TypeInt,
+		},
+		{
+			name: "list operation supports requesting the page size but the data source contains a property named page_size",
+			openAPIResource: &specStubResource{
+				schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{pageSizeProperty}},
+				resourceListOperation: &specResourceOperation{pageSizeParam: "limit"},
+			},
+			expectedPageSizeType: schema.TypeString,
+		},
+		{
+			name: "list operation does not support requesting the page size",
+			openAPIResource: &specStubResource{
+				schemaDefinition: &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{labelProperty}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s, err := dataSourceFactory{openAPIResource: tc.openAPIResource}.createTerraformDataSourceSchema()
+		require.NoError(t, err, tc.name)
+		if tc.expectedPageSizeType == schema.TypeInvalid {
+			assert.NotContains(t, s, dataSourcePageSizePropertyName, tc.name)
+			continue
+		}
+		assert.Equal(t, tc.expectedPageSizeType, s[dataSourcePageSizePropertyName].Type, tc.name)
+	}
+}
+
+func TestCreateTerraformDataSourceSchemaMostRecent(t *testing.T) {
+	labelProperty := newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil)
+	createdAtProperty := newStringSchemaDefinitionPropertyWithDefaults("created_at", "", false, true, nil)
+	testCases := []struct {
+		name                   string
+		openAPIResource        SpecResource
+		expectedMostRecentType schema.ValueType
+	}{
+		{
+			name: "list operation configured with the most recent property",
+			openAPIResource: &specStubResource{
+				schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{labelProperty, createdAtProperty}},
+				resourceListOperation: &specResourceOperation{mostRecentProperty: "created_at"},
+			},
+			expectedMostRecentType: schema.TypeBool,
+		},
+		{
+			name: "list operation configured with a most recent property that does not exist",
+			openAPIResource: &specStubResource{
+				schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{labelProperty}},
+				resourceListOperation: &specResourceOperation{mostRecentProperty: "created_at"},
+			},
+		},
+		{
+			name: "list operation configured with a most recent property that is not a timestamp",
+			openAPIResource: &specStubResource{
+				schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{newBoolSchemaDefinitionPropertyWithDefaults("created_at", "", false, true, nil)}},
+				resourceListOperation: &specResourceOperation{mostRecentProperty: "created_at"},
+			},
+		},
+		{
+			name: "list operation configured with the most recent property but the data source contains a property named most_recent",
+			openAPIResource: &specStubResource{
+				schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{createdAtProperty, newStringSchemaDefinitionPropertyWithDefaults(dataSourceMostRecentPropertyName, "", false, false, nil)}},
+				resourceListOperation: &specResourceOperation{mostRecentProperty: "created_at"},
+			},
+			expectedMostRecentType: schema.TypeString,
+		},
+		{
+			name: "list operation not configured with the most recent property",
+			openAPIResource: &specStubResource{
+				schemaDefinition: &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{labelProperty, createdAtProperty}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s, err := dataSourceFactory{openAPIResource: tc.openAPIResource}.createTerraformDataSourceSchema()
+		require.NoError(t, err, tc.name)
+		if tc.expectedMostRecentType == schema.TypeInvalid {
+			assert.NotContains(t, s, dataSourceMostRecentPropertyName, tc.name)
+			continue
+		}
+		assert.Equal(t, tc.expectedMostRecentType, s[dataSourceMostRecentPropertyName].Type, tc.name)
+	}
+}
+
+func TestDataSourceGetClientWithPageSize(t *testing.T) {
+	d := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil)}},
+			resourceListOperation: &specResourceOperation{pageSizeParam: "limit"},
+		},
+	}
+	s, err := d.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	providerClient := &ProviderClient{providerConfiguration: providerConfiguration{PageSize: 500}}
+
+	data := schema.TestResourceDataRaw(t, s, map[string]interface{}{dataSourcePageSizePropertyName: 50})
+	client := d.getClientWithPageSize(providerClient, data)
+	assert.Equal(t, 50, client.(*ProviderClient).providerConfiguration.PageSize)
+
+	data = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	client = d.getClientWithPageSize(providerClient, data)
+	assert.Equal(t, 500, client.(*ProviderClient).providerConfiguration.PageSize)
+}
+
+func TestDataSourceGetClientWithFilterQueryParameters(t *testing.T) {
+	d := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			resourceListOperation: &specResourceOperation{
+				QueryParameters: SpecQueryParameters{
+					{Name: "label", IsFilter: true},
+					{Name: "ownerId", IsFilter: true},
+					{Name: "status", IsFilter: true},
+					{Name: "region", IsFilter: true},
+					{Name: "force", Value: "true"},
+				},
+			},
+		},
+	}
+	filters := filters{
+		{name: "label", values: []string{"some label"}, operator: filterOperatorEquals},
+		{name: "owner_id", values: []string{"owner1"}},
+		{name: "status", values: []string{"active", "pending"}, operator: filterOperatorEquals},
+		{name: "region", values: []string{"us-"}, operator: filterOperatorPrefix},
+		{name: "force", values: []string{"false"}, operator: filterOperatorEquals},
+		{name: "id", values: []string{"id1"}, operator: filterOperatorEquals},
+	}
+
+	client := d.getClientWithFilterQueryParameters(&ProviderClient{}, filters)
+
+	assert.Equal(t, map[string]string{"label": "some label", "owner_id": "owner1"}, client.(*ProviderClient).filterQueryParameters)
+
+	stub := &clientOpenAPIStub{}
+	assert.Equal(t, stub, d.getClientWithFilterQueryParameters(stub, filters))
+}
+
 func TestDataSourceRead(t *testing.T) {
 	// Given
 	dataSourceFactory := dataSourceFactory{
@@ -232,7 +386,7 @@ func TestDataSourceRead(t *testing.T) {
 		if tc.expectedError == nil {
 			assert.Nil(t, err, tc.name)
 			// assert that the filtered data source contains the same values as the ones returned by the API
-			assert.Equal(t, 8, len(resourceData.State().Attributes), tc.name)                //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 4 prop given by the filter)
+			assert.Equal(t, 9, len(resourceData.State().Attributes), tc.name)                //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 5 prop given by the filter)
 			assert.Equal(t, client.responseListPayload[0]["id"], resourceData.Id(), tc.name) //resourceData.Id() is being called instead of resourceData.Get("id") because id property is a special one kept by Terraform
 			assert.Equal(t, client.responseListPayload[0]["label"], resourceData.Get("label"), tc.name)
 			expectedOwners := client.responseListPayload[0]["owners"].([]string)
@@ -389,13 +543,189 @@ func TestDataSourceRead_ForNestedObjects(t *testing.T) {
 	// Then
 	assert.Nil(t, err)
 	// assert that the filtered data source contains the same values as the ones returned by the API
-	assert.Equal(t, 10, len(resourceData.State().Attributes))               //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 4 prop given by the filter)
+	assert.Equal(t, 11, len(resourceData.State().Attributes))               //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 5 prop given by the filter)
 	assert.Equal(t, client.responseListPayload[0]["id"], resourceData.Id()) //resourceData.Id() is being called instead of resourceData.Get("id") because id property is a special one kept by Terraform
 	assert.Equal(t, client.responseListPayload[0]["label"], resourceData.Get("nested_object"))
 	assert.Equal(t, "data_resourceName", telemetryHandlerResourceNameReceived)
 	assert.Equal(t, TelemetryResourceOperationRead, telemetryHandlerTFOperationReceived)
 }
 
+func TestDataSourceRead_PaginatedListOperation(t *testing.T) {
+	pages := []string{`[{"id":"1","label":"first"},{"id":"2","label":"second"}]`, `[{"id":"3","label":"third"}]`}
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		page := 0
+		fmt.Sscanf(req.URL.Query().Get("page"), "%d", &page)
+		if page < len(pages)-1 {
+			rw.Header().Set("Link", fmt.Sprintf(`</v1/cdns?page=%d>; rel="next"`, page+1))
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte(pages[page]))
+	}))
+	defer api.Close()
+	d := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			name: "cdns_v1",
+			path: "/v1/cdns",
+			schemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				},
+			},
+			resourceListOperation: &specResourceOperation{pagination: &specPagination{style: paginationStyleLink}},
+		},
+	}
+	resourceSchema, err := d.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		dataSourceFilterPropertyName: []interface{}{newFilter("label", []interface{}{"third"})},
+	})
+	client := &ProviderClient{
+		openAPIBackendConfiguration: &specStubBackendConfiguration{host: strings.TrimPrefix(api.URL, "http://"), httpScheme: "http"},
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newAPIAuthenticator(nil),
+	}
+
+	err = d.read(resourceData, client)
+
+	require.NoError(t, err)
+	assert.Equal(t, "3", resourceData.Id())
+	assert.Equal(t, "third", resourceData.Get("label"))
+}
+
+func TestDataSourceRead_EncryptedProperty(t *testing.T) {
+	encryptedProperty := newStringSchemaDefinitionPropertyWithDefaults("secret", "", false, true, nil)
+	encryptedProperty.Encrypted = true
+	d := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			name: "resourceName",
+			schemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					encryptedProperty,
+				},
+			},
+		},
+	}
+	resourceSchema, err := d.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	client := &clientOpenAPIStateEncryptionStub{
+		clientOpenAPIStub:  &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"id": "someID", "secret": "secretValue"}}},
+		stateEncryptionKey: testStateEncryptionKey,
+	}
+	err = d.read(resourceData, client)
+	require.NoError(t, err)
+	stateValue := resourceData.Get("secret").(string)
+	assert.True(t, strings.HasPrefix(stateValue, encryptedStateValuePrefix))
+	decryptedValue, err := decryptStateValue(testStateEncryptionKey, stateValue)
+	require.NoError(t, err)
+	assert.Equal(t, "secretValue", decryptedValue)
+
+	resourceData = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	client = &clientOpenAPIStateEncryptionStub{
+		clientOpenAPIStub: &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"id": "someID", "secret": "secretValue"}}},
+	}
+	err = d.read(resourceData, client)
+	assert.EqualError(t, err, "[data source='resourceName'] property 'secret' is configured to be encrypted in the state but the provider is not configured with the 'state_encryption_key' property")
+	assert.Empty(t, resourceData.Get("secret"))
+}
+
+func TestDataSourceRead_MostRecent(t *testing.T) {
+	testCases := []struct {
+		name              string
+		timestampProperty *SpecSchemaDefinitionProperty
+		mostRecent        bool
+		responsePayload   []map[string]interface{}
+		expectedID        string
+		expectedError     string
+	}{
+		{
+			name:              "most recent item selected based on RFC3339 date-times",
+			timestampProperty: newStringSchemaDefinitionPropertyWithDefaults("created_at", "", false, true, nil),
+			mostRecent:        true,
+			responsePayload: []map[string]interface{}{
+				{"id": "old", "label": "my_label", "created_at": "2020-01-01T10:00:00Z"},
+				{"id": "new", "label": "my_label", "created_at": "2020-01-01T12:00:00+01:00"},
+				{"id": "other", "label": "other_label", "created_at": "2021-01-01T10:00:00Z"},
+			},
+			expectedID: "new",
+		},
+		{
+			name:              "most recent item selected based on Unix times",
+			timestampProperty: newIntSchemaDefinitionPropertyWithDefaults("created_at", "", false, true, nil),
+			mostRecent:        true,
+			responsePayload: []map[string]interface{}{
+				{"id": "new", "label": "my_label", "created_at": float64(1577880000)},
+				{"id": "old", "label": "my_label", "created_at": float64(1577872800)},
+			},
+			expectedID: "new",
+		},
+		{
+			name:              "most recent disabled",
+			timestampProperty: newStringSchemaDefinitionPropertyWithDefaults("created_at", "", false, true, nil),
+			mostRecent:        false,
+			responsePayload: []map[string]interface{}{
+				{"id": "old", "label": "my_label", "created_at": "2020-01-01T10:00:00Z"},
+				{"id": "new", "label": "my_label", "created_at": "2020-01-02T10:00:00Z"},
+			},
+			expectedError: "your query returned contains more than one result. Please change your search criteria to make it more specific",
+		},
+		{
+			name:              "timestamp that can not be parsed",
+			timestampProperty: newStringSchemaDefinitionPropertyWithDefaults("created_at", "", false, true, nil),
+			mostRecent:        true,
+			responsePayload: []map[string]interface{}{
+				{"id": "old", "label": "my_label", "created_at": "2020-01-01T10:00:00Z"},
+				{"id": "new", "label": "my_label", "created_at": "yesterday"},
+			},
+			expectedError: "[data source='cdns_v1'] could not select the most recent item, property 'created_at' value 'yesterday' is not a RFC3339 date-time",
+		},
+		{
+			name:              "missing timestamp",
+			timestampProperty: newStringSchemaDefinitionPropertyWithDefaults("created_at", "", false, true, nil),
+			mostRecent:        true,
+			responsePayload: []map[string]interface{}{
+				{"id": "old", "label": "my_label", "created_at": "2020-01-01T10:00:00Z"},
+				{"id": "new", "label": "my_label"},
+			},
+			expectedError: "[data source='cdns_v1'] could not select the most recent item, property 'created_at' is missing",
+		},
+	}
+	for _, tc := range testCases {
+		d := dataSourceFactory{
+			openAPIResource: &specStubResource{
+				name: "cdns_v1",
+				schemaDefinition: &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+						newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+						tc.timestampProperty,
+					},
+				},
+				resourceListOperation: &specResourceOperation{mostRecentProperty: "created_at"},
+			},
+		}
+		resourceSchema, err := d.createTerraformDataSourceSchema()
+		require.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+			dataSourceFilterPropertyName:     []interface{}{newFilter("label", []interface{}{"my_label"})},
+			dataSourceMostRecentPropertyName: tc.mostRecent,
+		})
+
+		err = d.read(resourceData, &clientOpenAPIStub{responseListPayload: tc.responsePayload})
+
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedID, resourceData.Id(), tc.name)
+	}
+}
+
 func TestDataSourceRead_Fails_NilOpenAPIResource(t *testing.T) {
 	err := dataSourceFactory{}.read(&schema.ResourceData{}, &clientOpenAPIStub{})
 	assert.EqualError(t, err, "missing openAPI resource configuration")
@@ -415,6 +745,7 @@ func TestDataSourceRead_Fails_Because_Cannot_extract_ParentsID(t *testing.T) {
 func TestDataSourceRead_Fails_Because_List_Operation_Returns_Err(t *testing.T) {
 	dataSourceFactory := dataSourceFactory{
 		openAPIResource: &specStubResource{
+			name: "some resource",
 			schemaDefinition: &SpecSchemaDefinition{
 				Properties: SpecSchemaDefinitionProperties{
 					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
@@ -440,7 +771,7 @@ func TestDataSourceRead_Fails_Because_List_Operation_Returns_Err(t *testing.T) {
 		error: errors.New("some error"),
 	}
 	err = dataSourceFactory.read(resourceData, client)
-	assert.EqualError(t, err, "some error")
+	assert.EqualError(t, err, "[data source='some resource'] GET  failed: some error")
 }
 
 func TestDataSourceRead_Fails_Because_Bad_Status_Code(t *testing.T) {
@@ -470,7 +801,7 @@ func TestDataSourceRead_Fails_Because_Bad_Status_Code(t *testing.T) {
 	// When
 	err = dataSourceFactory.read(resourceData, client)
 	// Then
-	assert.Equal(t, errors.New("[data source='some resource'] GET  failed: [resource='some resource'] HTTP Response Status Code 400 not matching expected one [200] ()"), err)
+	assert.Equal(t, errors.New("[data source='some resource'] GET  failed: HTTP Response Status Code 400 not matching expected one [200] ()"), err)
 }
 
 func TestValidateInput(t *testing.T) {
@@ -536,7 +867,7 @@ func TestValidateInput(t *testing.T) {
 			expectedError:   errors.New("property not supported as as filter: not_primitive"),
 		},
 		{
-			name: "data source populated with an incorrect filter containing multiple values for a primitive property",
+			name: "data source populated with a filter containing multiple values for a primitive property",
 			specSchemaDefinition: &SpecSchemaDefinition{
 				Properties: SpecSchemaDefinitionProperties{
 					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
@@ -547,8 +878,101 @@ func TestValidateInput(t *testing.T) {
 					newFilter("label", []interface{}{"value1", "value2"}),
 				},
 			},
+			expectedFilters: filters{filter{name: "label", values: []string{"value1", "value2"}, operator: filterOperatorEquals}},
+			expectedError:   nil,
+		},
+		{
+			name: "data source populated with a regex filter containing an invalid regular expression",
+			specSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+				},
+			},
+			filtersInput: map[string]interface{}{
+				dataSourceFilterPropertyName: []interface{}{
+					map[string]interface{}{
+						dataSourceFilterSchemaNamePropertyName:     "label",
+						dataSourceFilterSchemaValuesPropertyName:   []interface{}{"some-("},
+						dataSourceFilterSchemaOperatorPropertyName: filterOperatorRegex,
+					},
+				},
+			},
+			expectedFilters: nil,
+			expectedError:   errors.New("filter 'label' regex 'some-(' not valid: error parsing regexp: missing closing ): `some-(`"),
+		},
+		{
+			name: "data source populated with a filter using an operator",
+			specSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+				},
+			},
+			filtersInput: map[string]interface{}{
+				dataSourceFilterPropertyName: []interface{}{
+					map[string]interface{}{
+						dataSourceFilterSchemaNamePropertyName:     "label",
+						dataSourceFilterSchemaValuesPropertyName:   []interface{}{"some-"},
+						dataSourceFilterSchemaOperatorPropertyName: filterOperatorPrefix,
+					},
+				},
+			},
+			expectedFilters: filters{filter{name: "label", values: []string{"some-"}, operator: filterOperatorPrefix}},
+			expectedError:   nil,
+		},
+		{
+			name: "data source populated with filters on nested object and map properties",
+			specSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newObjectSchemaDefinitionPropertyWithDefaults("config", "", false, true, false, nil, &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							newStringSchemaDefinitionPropertyWithDefaults("protocol", "", false, true, nil),
+						},
+					}),
+					&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeMap, MapValuesType: TypeString, ReadOnly: true},
+				},
+			},
+			filtersInput: map[string]interface{}{
+				dataSourceFilterPropertyName: []interface{}{
+					newFilter("config.protocol", []interface{}{"https"}),
+					newFilter("tags.environment", []interface{}{"prod"}),
+				},
+			},
+			expectedFilters: filters{filter{name: "config.protocol", values: []string{"https"}, operator: filterOperatorEquals}, filter{name: "tags.environment", values: []string{"prod"}, operator: filterOperatorEquals}},
+			expectedError:   nil,
+		},
+		{
+			name: "data source populated with a filter on a nested property that does not exist",
+			specSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newObjectSchemaDefinitionPropertyWithDefaults("config", "", false, true, false, nil, &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							newStringSchemaDefinitionPropertyWithDefaults("protocol", "", false, true, nil),
+						},
+					}),
+				},
+			},
+			filtersInput: map[string]interface{}{
+				dataSourceFilterPropertyName: []interface{}{
+					newFilter("config.port", []interface{}{"443"}),
+				},
+			},
 			expectedFilters: nil,
-			expectedError:   errors.New("filters for primitive properties can not have more than one value in the values field"),
+			expectedError:   errors.New("filter name does not match any of the schema properties: property with name 'port' not existing in resource schema definition"),
+		},
+		{
+			name: "data source populated with a filter on a nested path that does not end in a primitive property",
+			specSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+				},
+			},
+			filtersInput: map[string]interface{}{
+				dataSourceFilterPropertyName: []interface{}{
+					newFilter("label.length", []interface{}{"5"}),
+				},
+			},
+			expectedFilters: nil,
+			expectedError:   errors.New("property not supported as as filter: label.length"),
 		},
 	}
 
@@ -592,7 +1016,7 @@ func TestFilterMatch(t *testing.T) {
 				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"label", "some label"},
+				filter{name: "label", values: []string{"some label"}},
 			},
 			payloadItem: map[string]interface{}{
 				"label": "some label",
@@ -606,7 +1030,7 @@ func TestFilterMatch(t *testing.T) {
 				newIntSchemaDefinitionPropertyWithDefaults("int property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"int property name", "5"},
+				filter{name: "int property name", values: []string{"5"}},
 			},
 			payloadItem: map[string]interface{}{
 				"int property name": 5,
@@ -620,7 +1044,7 @@ func TestFilterMatch(t *testing.T) {
 				newNumberSchemaDefinitionPropertyWithDefaults("float property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"float property name", "6.0"},
+				filter{name: "float property name", values: []string{"6.0"}},
 			},
 			payloadItem: map[string]interface{}{
 				"float property name": 6.0, //because 6.0 is treateted as an interface golang keeps only the int part (6) so we need to treat thi case specially
@@ -634,7 +1058,7 @@ func TestFilterMatch(t *testing.T) {
 				newNumberSchemaDefinitionPropertyWithDefaults("float property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"float property name", "6.89"},
+				filter{name: "float property name", values: []string{"6.89"}},
 			},
 			payloadItem: map[string]interface{}{
 				"float property name": 6.89,
@@ -642,13 +1066,83 @@ func TestFilterMatch(t *testing.T) {
 			expectedResult: true,
 			expectedError:  nil,
 		},
+		{
+			name: "happy path - payloadItem matches the filter for int property decoded from JSON as float64",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newIntSchemaDefinitionPropertyWithDefaults("int property name", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "int property name", values: []string{"1e3"}},
+			},
+			payloadItem: map[string]interface{}{
+				"int property name": float64(1000),
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filter for float property using scientific notation",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newNumberSchemaDefinitionPropertyWithDefaults("float property name", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "float property name", values: []string{"1.5e-7"}},
+			},
+			payloadItem: map[string]interface{}{
+				"float property name": 0.00000015,
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filter for float property with high precision",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newNumberSchemaDefinitionPropertyWithDefaults("float property name", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "float property name", values: []string{"0.3"}},
+			},
+			payloadItem: map[string]interface{}{
+				"float property name": 0.1 + 0.2,
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem doesn't match the filter value for float property",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newNumberSchemaDefinitionPropertyWithDefaults("float property name", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "float property name", values: []string{"6.8901"}},
+			},
+			payloadItem: map[string]interface{}{
+				"float property name": 6.89,
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem doesn't match the filter value for int property with a non numeric filter value",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newIntSchemaDefinitionPropertyWithDefaults("int property name", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "int property name", values: []string{"five"}},
+			},
+			payloadItem: map[string]interface{}{
+				"int property name": 5,
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
 		{
 			name: "happy path - payloadItem matches the filter for bool property",
 			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
 				newBoolSchemaDefinitionPropertyWithDefaults("bool property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"bool property name", "false"},
+				filter{name: "bool property name", values: []string{"false"}},
 			},
 			payloadItem: map[string]interface{}{
 				"bool property name": false,
@@ -662,7 +1156,7 @@ func TestFilterMatch(t *testing.T) {
 				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"invalid filter name", "some label"},
+				filter{name: "invalid filter name", values: []string{"some label"}},
 			},
 			payloadItem: map[string]interface{}{
 				"label": "some label",
@@ -676,11 +1170,172 @@ func TestFilterMatch(t *testing.T) {
 				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"label", "invalid filter value"},
+				filter{name: "label", values: []string{"invalid filter value"}},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filter using the contains operator",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"me la"}, operator: filterOperatorContains},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filter using the prefix operator",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"some"}, operator: filterOperatorPrefix},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filter using the suffix operator",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"label"}, operator: filterOperatorSuffix},
 			},
 			payloadItem: map[string]interface{}{
 				"label": "some label",
 			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filter using the regex operator",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"^some-[0-9a-f]{6}$"}, operator: filterOperatorRegex},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some-1a2b3c",
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem doesn't match the filter using the prefix operator",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"label"}, operator: filterOperatorPrefix},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem doesn't match the filter using the regex operator",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"^other"}, operator: filterOperatorRegex},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filter using the prefix operator for int property",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "port", values: []string{"80"}, operator: filterOperatorPrefix},
+			},
+			payloadItem: map[string]interface{}{
+				"port": float64(8080),
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches any of the filter values",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"other label", "some label"}},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem doesn't match any of the filter values",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", values: []string{"^other", "^another"}, operator: filterOperatorRegex},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
+		{
+			name: "happy path - payloadItem matches the filters for nested object and map properties",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				newObjectSchemaDefinitionPropertyWithDefaults("config", "", false, true, false, nil, &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						newIntSchemaDefinitionPropertyWithDefaults("port", "", false, true, nil),
+					},
+				}),
+				&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeMap, MapValuesType: TypeString, ReadOnly: true},
+			},
+			filters: filters{
+				filter{name: "config.port", values: []string{"443"}},
+				filter{name: "tags.environment", values: []string{"prod"}},
+			},
+			payloadItem: map[string]interface{}{
+				"config": map[string]interface{}{"port": float64(443)},
+				"tags":   map[string]interface{}{"environment": "prod"},
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem is missing the map key the filter refers to",
+			specSchemaDefinitionProperties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeMap, MapValuesType: TypeString, ReadOnly: true},
+			},
+			filters: filters{
+				filter{name: "tags.environment", values: []string{"prod"}},
+			},
+			payloadItem: map[string]interface{}{
+				"tags": map[string]interface{}{"owner": "prod"},
+			},
 			expectedResult: false,
 			expectedError:  nil,
 		},
@@ -705,7 +1360,8 @@ func TestFilterMatch(t *testing.T) {
 func assertFilter(t *testing.T, filters filters, expectedFilter filter, msgAndArgs ...interface{}) bool {
 	for _, f := range filters {
 		if f.name == expectedFilter.name {
-			assert.Equal(t, expectedFilter.value, f.value, msgAndArgs)
+			assert.Equal(t, expectedFilter.values, f.values, msgAndArgs)
+			assert.Equal(t, expectedFilter.operator, f.operator, msgAndArgs)
 		}
 	}
 	return false
@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const dataSourceIDsPropertyName = "ids"
+const dataSourceIDsNamesPropertyName = "names"
+
+// dataSourceIDsNameProperty is the resource property which values are exposed in the names attribute of the IDs data sources
+const dataSourceIDsNameProperty = "name"
+
+// dataSourceIDsKind is the kind of terraform resource used in the errors returned by the IDs data source operations
+const dataSourceIDsKind = "data source ids"
+
+// dataSourceIDsFactory creates lightweight data sources that list a collection and only expose the IDs (and names if
+// the resource has a name property) of the items, which is handy to iterate over the children of a parent resource
+// (e,g: with for_each) without mapping the full payloads of the items
+type dataSourceIDsFactory struct {
+	openAPIResource SpecResource
+	// dataSource provides the sort support shared with the list data source
+	dataSource dataSourceFactory
+}
+
+func newDataSourceIDsFactory(openAPIResource SpecResource) dataSourceIDsFactory {
+	return dataSourceIDsFactory{
+		openAPIResource: openAPIResource,
+		dataSource:      newDataSourceFactory(openAPIResource),
+	}
+}
+
+func (d dataSourceIDsFactory) getDataSourceIDsName() string {
+	return fmt.Sprintf("%s_ids", d.openAPIResource.GetResourceName())
+}
+
+func (d dataSourceIDsFactory) createTerraformIDsDataSource() (*schema.Resource, error) {
+	s, err := d.createTerraformDataSourceIDsSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Resource{
+		Schema:             s,
+		Read:               d.read,
+		DeprecationMessage: d.openAPIResource.getDeprecationMessage(),
+	}, nil
+}
+
+// createTerraformDataSourceIDsSchema returns the schema of the IDs data source which only contains the parent properties
+// (needed to list sub-resources), the sort arguments (if supported) and the computed ids, names and total_count attributes
+func (d dataSourceIDsFactory) createTerraformDataSourceIDsSchema() (map[string]*schema.Schema, error) {
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	dataSourceSchema := map[string]*schema.Schema{}
+	for _, property := range specSchema.ConvertToDataSourceSpecSchemaDefinition().Properties {
+		if !property.IsParentProperty {
+			continue
+		}
+		tfSchema, err := property.terraformSchema()
+		if err != nil {
+			return nil, err
+		}
+		dataSourceSchema[property.GetTerraformCompliantPropertyName()] = tfSchema
+	}
+	d.dataSource.addSortSchema(dataSourceSchema)
+	dataSourceSchema[dataSourceIDsPropertyName] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "IDs of the items in the collection",
+	}
+	dataSourceSchema[dataSourceTotalCountPropertyName] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of items in the collection",
+	}
+	if d.hasNameProperty() {
+		dataSourceSchema[dataSourceIDsNamesPropertyName] = &schema.Schema{
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Names of the items in the collection indexed by the item ID",
+		}
+	}
+	return dataSourceSchema, nil
+}
+
+// hasNameProperty returns true if the resource schema contains a string property called name
+func (d dataSourceIDsFactory) hasNameProperty() bool {
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return false
+	}
+	property, err := specSchema.getProperty(dataSourceIDsNameProperty)
+	return err == nil && property.Type == TypeString && !property.IsParentProperty
+}
+
+func (d dataSourceIDsFactory) read(data *schema.ResourceData, i interface{}) error {
+	openAPIClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
+
+	if d.openAPIResource == nil {
+		return fmt.Errorf("missing openAPI resource configuration")
+	}
+	resourceName := d.getDataSourceIDsName()
+
+	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+
+	if err := resolveParentIDs(d.openAPIResource, openAPIClient, data); err != nil {
+		return err
+	}
+
+	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
+	if err != nil {
+		return err
+	}
+
+	resultsSort, err := d.dataSource.getSort(data)
+	if err != nil {
+		return err
+	}
+
+	items, err := listAllPages(openAPIClient, d.openAPIResource, parentIDs...)
+	if err != nil {
+		return newResourceOperationError(dataSourceIDsKind, resourceName, http.MethodGet, resourcePath, err)
+	}
+	if resultsSort != nil {
+		resultsSort.sort(items)
+	}
+
+	ids := []string{}
+	names := map[string]interface{}{}
+	for _, item := range items {
+		id, err := getPayloadID(d.openAPIResource, item)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		if name, ok := item[dataSourceIDsNameProperty].(string); ok {
+			names[id] = name
+		}
+	}
+
+	// the data source ID is the path of the collection listed so different parents result in different IDs
+	data.SetId(resourcePath)
+	if err := data.Set(dataSourceIDsPropertyName, ids); err != nil {
+		return err
+	}
+	if err := data.Set(dataSourceTotalCountPropertyName, len(ids)); err != nil {
+		return err
+	}
+	if d.hasNameProperty() {
+		return data.Set(dataSourceIDsNamesPropertyName, names)
+	}
+	return nil
+}
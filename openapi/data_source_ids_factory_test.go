@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDataSourceIDsName(t *testing.T) {
+	d := newDataSourceIDsFactory(&specStubResource{name: "cdn"})
+	assert.Equal(t, "cdn_ids", d.getDataSourceIDsName())
+}
+
+func TestCreateTerraformDataSourceIDsSchema(t *testing.T) {
+	parentProperty := newStringSchemaDefinitionPropertyWithDefaults("cdns_v1_id", "", true, false, nil)
+	parentProperty.IsParentProperty = true
+	testCases := []struct {
+		name               string
+		properties         SpecSchemaDefinitionProperties
+		expectedProperties []string
+	}{
+		{
+			name: "resource without name property",
+			properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+			},
+			expectedProperties: []string{dataSourceSortByPropertyName, dataSourceSortOrderPropertyName, dataSourceIDsPropertyName, dataSourceTotalCountPropertyName},
+		},
+		{
+			name: "sub-resource with name property",
+			properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+				parentProperty,
+			},
+			expectedProperties: []string{"cdns_v1_id", dataSourceSortByPropertyName, dataSourceSortOrderPropertyName, dataSourceIDsPropertyName, dataSourceIDsNamesPropertyName, dataSourceTotalCountPropertyName},
+		},
+	}
+	for _, tc := range testCases {
+		d := newDataSourceIDsFactory(&specStubResource{schemaDefinition: &SpecSchemaDefinition{Properties: tc.properties}})
+		s, err := d.createTerraformDataSourceIDsSchema()
+		require.NoError(t, err, tc.name)
+		var properties []string
+		for propertyName := range s {
+			properties = append(properties, propertyName)
+		}
+		assert.ElementsMatch(t, tc.expectedProperties, properties, tc.name)
+		assert.True(t, s[dataSourceIDsPropertyName].Computed, tc.name)
+		if parent, ok := s["cdns_v1_id"]; ok {
+			assert.True(t, parent.Required, tc.name)
+		}
+	}
+}
+
+func TestCreateTerraformIDsDataSource_Fails_Because_Schema_is_not_valid(t *testing.T) {
+	d := newDataSourceIDsFactory(&specStubResource{error: errors.New("data source schema has an error")})
+	_, err := d.createTerraformIDsDataSource()
+	assert.EqualError(t, err, "data source schema has an error")
+}
+
+func TestDataSourceIDsRead(t *testing.T) {
+	parentProperty := newStringSchemaDefinitionPropertyWithDefaults("cdns_v1_id", "", true, false, nil)
+	parentProperty.IsParentProperty = true
+	d := newDataSourceIDsFactory(&specStubResource{
+		name: "cdns_v1_firewalls_v1",
+		path: "/v1/cdns/parentPropertyID/v1/firewalls",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+				parentProperty,
+			},
+		},
+		fullParentResourceName: "cdns_v1",
+		parentResourceNames:    []string{"cdns_v1"},
+	})
+	dataSourceSchema, err := d.createTerraformDataSourceIDsSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{"cdns_v1_id": "parentPropertyID"})
+	client := &clientOpenAPIStub{
+		responseListPayload: []map[string]interface{}{
+			{"id": "firewall1", "name": "first", "unsupported": map[string]interface{}{"nested": []interface{}{1, "a"}}},
+			{"id": "firewall2"},
+		},
+	}
+
+	err = d.read(resourceData, client)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"parentPropertyID"}, client.parentIDsReceived)
+	assert.Equal(t, "/v1/cdns/parentPropertyID/v1/firewalls", resourceData.Id())
+	assert.Equal(t, []interface{}{"firewall1", "firewall2"}, resourceData.Get(dataSourceIDsPropertyName))
+	assert.Equal(t, 2, resourceData.Get(dataSourceTotalCountPropertyName))
+	assert.Equal(t, map[string]interface{}{"firewall1": "first"}, resourceData.Get(dataSourceIDsNamesPropertyName))
+}
+
+func TestDataSourceIDsRead_Sorted(t *testing.T) {
+	d := newDataSourceIDsFactory(&specStubResource{
+		name: "cdns_v1",
+		path: "/v1/cdns",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", true, false, nil),
+			},
+		},
+	})
+	dataSourceSchema, err := d.createTerraformDataSourceIDsSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{
+		dataSourceSortByPropertyName:    "port",
+		dataSourceSortOrderPropertyName: sortOrderDesc,
+	})
+	client := &clientOpenAPIStub{
+		responseListPayload: []map[string]interface{}{
+			{"id": "cdn1", "port": float64(80)},
+			{"id": "cdn2", "port": float64(8080)},
+			{"id": "cdn3", "port": float64(443)},
+		},
+	}
+
+	err = d.read(resourceData, client)
+
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"cdn2", "cdn3", "cdn1"}, resourceData.Get(dataSourceIDsPropertyName))
+}
+
+func TestDataSourceIDsRead_Fails(t *testing.T) {
+	testCases := []struct {
+		name          string
+		client        *clientOpenAPIStub
+		expectedError string
+	}{
+		{
+			name:          "list operation fails",
+			client:        &clientOpenAPIStub{error: errors.New("some error")},
+			expectedError: "[data source ids='cdns_v1_ids'] GET /v1/cdns failed: some error",
+		},
+		{
+			name:          "item without identifier",
+			client:        &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"label": "some label"}}},
+			expectedError: "response object returned from the API is missing mandatory identifier property 'id'",
+		},
+	}
+	for _, tc := range testCases {
+		d := newDataSourceIDsFactory(&specStubResource{
+			name: "cdns_v1",
+			path: "/v1/cdns",
+			schemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				},
+			},
+		})
+		dataSourceSchema, err := d.createTerraformDataSourceIDsSchema()
+		require.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{})
+		err = d.read(resourceData, tc.client)
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
@@ -9,6 +9,9 @@ import (
 
 const dataSourceInstanceIDProperty = "id"
 
+// dataSourceInstanceKind is the kind of terraform resource used in the errors returned by the data source instance operations
+const dataSourceInstanceKind = "data source instance"
+
 type dataSourceInstanceFactory struct {
 	openAPIResource SpecResource
 }
@@ -29,8 +32,9 @@ func (d dataSourceInstanceFactory) createTerraformInstanceDataSource() (*schema.
 		return nil, err
 	}
 	return &schema.Resource{
-		Schema: s,
-		Read:   d.read,
+		Schema:             s,
+		Read:               d.read,
+		DeprecationMessage: d.openAPIResource.getDeprecationMessage(),
 	}, nil
 }
 
@@ -55,7 +59,8 @@ func (d dataSourceInstanceFactory) dataSourceInstanceSchema() *schema.Schema {
 }
 
 func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}) error {
-	openAPIClient := i.(ClientOpenAPI)
+	openAPIClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
 
 	if d.openAPIResource == nil {
 		return fmt.Errorf("missing openAPI resource configuration")
@@ -64,6 +69,10 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
 
+	if err := resolveParentIDs(d.openAPIResource, openAPIClient, data); err != nil {
+		return err
+	}
+
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
 		return err
@@ -75,14 +84,21 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 	responsePayload := map[string]interface{}{}
 	resp, err := openAPIClient.Get(d.openAPIResource, id.(string), &responsePayload, parentIDs...)
 	if err != nil {
-		return err
+		return newResourceOperationError(dataSourceInstanceKind, resourceName, http.MethodGet, fmt.Sprintf("%s/%s", resourcePath, id), err)
 	}
-	if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return fmt.Errorf("[data source instance='%s'] GET %s failed: %s", resourceName, resourcePath, err)
+	operation := d.openAPIResource.getResourceOperations().Get
+	if err := checkHTTPStatusCode(d.openAPIResource, resp, operation.getSuccessStatusCodes([]int{http.StatusOK}), operation.getErrorParser()); err != nil {
+		return newResourceOperationError(dataSourceInstanceKind, resourceName, http.MethodGet, fmt.Sprintf("%s/%s", resourcePath, id), err)
 	}
 	err = setStateID(d.openAPIResource, data, responsePayload)
 	if err != nil {
 		return err
 	}
+	if err := checkUnknownPayloadFields(d.openAPIResource, responsePayload, i); err != nil {
+		return err
+	}
+	if err := encryptPayloadValues(d.openAPIResource, dataSourceInstanceKind, responsePayload, data, i); err != nil {
+		return err
+	}
 	return dataSourceUpdateStateWithPayloadData(d.openAPIResource, responsePayload, data)
 }
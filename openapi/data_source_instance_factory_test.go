@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -179,13 +180,13 @@ func TestDataSourceInstanceRead(t *testing.T) {
 			inputID:         "ID",
 			responsePayload: map[string]interface{}{},
 			returnHTTPCode:  http.StatusNotFound,
-			expectedError:   errors.New("[data source instance='resourceName_instance'] GET  failed: HTTP Response Status Code 404 - Not Found. Could not find resource instance: "),
+			expectedError:   errors.New("[data source instance='resourceName_instance'] GET /ID failed: HTTP Response Status Code 404 - Not Found. Could not find resource instance: "),
 		},
 		{
 			name:          "get operation returns an error",
 			inputID:       "ID",
 			returnedError: errors.New("some api error in the get operation"),
-			expectedError: errors.New("some api error in the get operation"),
+			expectedError: errors.New("[data source instance='resourceName_instance'] GET /ID failed: some api error in the get operation"),
 		},
 	}
 
@@ -236,6 +237,38 @@ func TestDataSourceInstanceRead(t *testing.T) {
 	}
 }
 
+func TestDataSourceInstanceRead_EncryptedProperty(t *testing.T) {
+	encryptedProperty := newStringSchemaDefinitionPropertyWithDefaults("secret", "", false, true, nil)
+	encryptedProperty.Encrypted = true
+	d := dataSourceInstanceFactory{
+		openAPIResource: &specStubResource{
+			name: "resourceName",
+			schemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					encryptedProperty,
+				},
+			},
+		},
+	}
+	resourceSchema, err := d.createTerraformDataSourceInstanceSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{dataSourceInstanceIDProperty: "someID"})
+	client := &clientOpenAPIStateEncryptionStub{
+		clientOpenAPIStub:  &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "someID", "secret": "secretValue"}},
+		stateEncryptionKey: testStateEncryptionKey,
+	}
+
+	err = d.read(resourceData, client)
+
+	require.NoError(t, err)
+	stateValue := resourceData.Get("secret").(string)
+	assert.True(t, strings.HasPrefix(stateValue, encryptedStateValuePrefix))
+	decryptedValue, err := decryptStateValue(testStateEncryptionKey, stateValue)
+	require.NoError(t, err)
+	assert.Equal(t, "secretValue", decryptedValue)
+}
+
 func TestDataSourceInstanceRead_Fails_Because_Schema_is_not_valid(t *testing.T) {
 	dataSourceFactory := dataSourceInstanceFactory{
 		openAPIResource: &specStubResource{
@@ -0,0 +1,198 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const dataSourceListResultsPropertyName = "results"
+
+// dataSourceListKind is the kind of terraform resource used in the errors returned by the list data source operations
+const dataSourceListKind = "data source list"
+
+// dataSourceListFactory creates the plural data sources that return all the items of a collection matching the filters
+// (as opposed to the data sources created by the dataSourceFactory which fail if more than one item matches), which is
+// handy to iterate over the matching items (e,g: with for_each)
+type dataSourceListFactory struct {
+	openAPIResource SpecResource
+	// dataSource provides the filters and page size support shared with the singular data source
+	dataSource dataSourceFactory
+}
+
+func newDataSourceListFactory(openAPIResource SpecResource) dataSourceListFactory {
+	return dataSourceListFactory{
+		openAPIResource: openAPIResource,
+		dataSource:      newDataSourceFactory(openAPIResource),
+	}
+}
+
+func (d dataSourceListFactory) getDataSourceListName() string {
+	return fmt.Sprintf("%s_list", d.openAPIResource.GetResourceName())
+}
+
+func (d dataSourceListFactory) createTerraformListDataSource() (*schema.Resource, error) {
+	s, err := d.createTerraformDataSourceListSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Resource{
+		Schema:             s,
+		Read:               d.read,
+		DeprecationMessage: d.openAPIResource.getDeprecationMessage(),
+	}, nil
+}
+
+// createTerraformDataSourceListSchema returns the schema of the list data source which contains the parent properties
+// (needed to list sub-resources), the filters, the page size and sort arguments (if supported) and the computed results
+// and total_count attributes. Each element of the results contains the id of the item as well as the rest of the resource
+// properties
+func (d dataSourceListFactory) createTerraformDataSourceListSchema() (map[string]*schema.Schema, error) {
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	dataSourceSchema := map[string]*schema.Schema{}
+	resultSchema := map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for _, property := range specSchema.ConvertToDataSourceSpecSchemaDefinition().Properties {
+		tfSchema, err := property.terraformSchema()
+		if err != nil {
+			return nil, err
+		}
+		if property.IsParentProperty {
+			dataSourceSchema[property.GetTerraformCompliantPropertyName()] = tfSchema
+			continue
+		}
+		if property.isPropertyNamedID() {
+			continue
+		}
+		resultSchema[property.GetTerraformCompliantPropertyName()] = tfSchema
+	}
+	dataSourceSchema[dataSourceFilterPropertyName] = d.dataSource.dataSourceFiltersSchema()
+	if d.dataSource.supportsPageSize() {
+		dataSourceSchema[dataSourcePageSizePropertyName] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: positiveIntValidateFunc,
+			Description:  "Number of items requested per page when listing the resources, overriding the page size configured in the provider",
+		}
+	}
+	d.dataSource.addSortSchema(dataSourceSchema)
+	dataSourceSchema[dataSourceListResultsPropertyName] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: resultSchema},
+		Description: "Items in the collection matching the filters",
+	}
+	dataSourceSchema[dataSourceTotalCountPropertyName] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of items in the collection matching the filters",
+	}
+	return dataSourceSchema, nil
+}
+
+func (d dataSourceListFactory) read(data *schema.ResourceData, i interface{}) error {
+	openAPIClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
+
+	if d.openAPIResource == nil {
+		return fmt.Errorf("missing openAPI resource configuration")
+	}
+	resourceName := d.getDataSourceListName()
+
+	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+
+	if err := resolveParentIDs(d.openAPIResource, openAPIClient, data); err != nil {
+		return err
+	}
+
+	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
+	if err != nil {
+		return err
+	}
+
+	filters, err := d.dataSource.validateInput(data)
+	if err != nil {
+		return err
+	}
+
+	resultsSort, err := d.dataSource.getSort(data)
+	if err != nil {
+		return err
+	}
+
+	openAPIClient = d.dataSource.getClientWithPageSize(openAPIClient, data)
+	openAPIClient = d.dataSource.getClientWithFilterQueryParameters(openAPIClient, filters)
+	items, err := listAllPages(openAPIClient, d.openAPIResource, parentIDs...)
+	if err != nil {
+		return newResourceOperationError(dataSourceListKind, resourceName, http.MethodGet, resourcePath, err)
+	}
+
+	var filteredItems []map[string]interface{}
+	for _, item := range items {
+		if d.dataSource.filterMatch(filters, item) {
+			filteredItems = append(filteredItems, item)
+		}
+	}
+	if resultsSort != nil {
+		resultsSort.sort(filteredItems)
+	}
+
+	results := []interface{}{}
+	for _, item := range filteredItems {
+		if err := checkUnknownPayloadFields(d.openAPIResource, item, i); err != nil {
+			return err
+		}
+		// the results are replaced as a whole on every read so the values are always encrypted again
+		if err := encryptPayloadValues(d.openAPIResource, dataSourceListKind, item, nil, i); err != nil {
+			return err
+		}
+		result, err := d.convertPayloadToResult(item)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	// the data source ID is the path of the collection listed so different parents result in different IDs
+	data.SetId(resourcePath)
+	if err := data.Set(dataSourceListResultsPropertyName, results); err != nil {
+		return err
+	}
+	return data.Set(dataSourceTotalCountPropertyName, len(results))
+}
+
+// convertPayloadToResult converts the given item returned by the API into an element of the results attribute. The
+// properties returned by the API that are not part of the resource schema are ignored
+func (d dataSourceListFactory) convertPayloadToResult(item map[string]interface{}) (map[string]interface{}, error) {
+	id, err := getPayloadID(d.openAPIResource, item)
+	if err != nil {
+		return nil, err
+	}
+	resourceSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{"id": id}
+	for propertyName, propertyRemoteValue := range item {
+		property, err := resourceSchema.getProperty(propertyName)
+		if err != nil || property.IsParentProperty || property.isPropertyNamedID() {
+			continue
+		}
+		value, err := convertPayloadToLocalStateDataValue(property, propertyRemoteValue, false)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			result[property.GetTerraformCompliantPropertyName()] = value
+		}
+	}
+	return result, nil
+}
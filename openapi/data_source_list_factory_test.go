@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDataSourceListName(t *testing.T) {
+	d := newDataSourceListFactory(&specStubResource{name: "cdn"})
+	assert.Equal(t, "cdn_list", d.getDataSourceListName())
+}
+
+func TestCreateTerraformDataSourceListSchema(t *testing.T) {
+	parentProperty := newStringSchemaDefinitionPropertyWithDefaults("cdns_v1_id", "", true, false, nil)
+	parentProperty.IsParentProperty = true
+	d := newDataSourceListFactory(&specStubResource{
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+				parentProperty,
+			},
+		},
+	})
+
+	s, err := d.createTerraformDataSourceListSchema()
+
+	require.NoError(t, err)
+	var properties []string
+	for propertyName := range s {
+		properties = append(properties, propertyName)
+	}
+	assert.ElementsMatch(t, []string{"cdns_v1_id", dataSourceFilterPropertyName, dataSourceSortByPropertyName, dataSourceSortOrderPropertyName, dataSourceListResultsPropertyName, dataSourceTotalCountPropertyName}, properties)
+	assert.True(t, s["cdns_v1_id"].Required)
+	assert.True(t, s[dataSourceListResultsPropertyName].Computed)
+	assert.True(t, s[dataSourceTotalCountPropertyName].Computed)
+	resultSchema := s[dataSourceListResultsPropertyName].Elem.(*schema.Resource).Schema
+	assert.Len(t, resultSchema, 2)
+	assert.True(t, resultSchema["id"].Computed)
+	assert.True(t, resultSchema["label"].Computed)
+	assert.False(t, resultSchema["label"].Required)
+}
+
+func TestCreateTerraformListDataSource_Fails_Because_Schema_is_not_valid(t *testing.T) {
+	d := newDataSourceListFactory(&specStubResource{error: errors.New("data source schema has an error")})
+	_, err := d.createTerraformListDataSource()
+	assert.EqualError(t, err, "data source schema has an error")
+}
+
+func TestDataSourceListRead(t *testing.T) {
+	d := newDataSourceListFactory(&specStubResource{
+		name: "cdns_v1",
+		path: "/v1/cdns",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", true, false, nil),
+			},
+		},
+	})
+	dataSourceSchema, err := d.createTerraformDataSourceListSchema()
+	require.NoError(t, err)
+	testCases := []struct {
+		name            string
+		filters         []interface{}
+		sortBy          string
+		sortOrder       string
+		expectedResults []interface{}
+	}{
+		{
+			name:    "items matching the filter",
+			filters: []interface{}{map[string]interface{}{"name": "port", "values": []interface{}{"80"}}},
+			expectedResults: []interface{}{
+				map[string]interface{}{"id": "cdn1", "label": "first", "port": 80},
+				map[string]interface{}{"id": "cdn3", "label": "third", "port": 80},
+			},
+		},
+		{
+			name:    "no filters",
+			filters: []interface{}{},
+			expectedResults: []interface{}{
+				map[string]interface{}{"id": "cdn1", "label": "first", "port": 80},
+				map[string]interface{}{"id": "cdn2", "label": "second", "port": 443},
+				map[string]interface{}{"id": "cdn3", "label": "third", "port": 80},
+			},
+		},
+		{
+			name:      "items sorted in descending order",
+			filters:   []interface{}{},
+			sortBy:    "port",
+			sortOrder: sortOrderDesc,
+			expectedResults: []interface{}{
+				map[string]interface{}{"id": "cdn2", "label": "second", "port": 443},
+				map[string]interface{}{"id": "cdn1", "label": "first", "port": 80},
+				map[string]interface{}{"id": "cdn3", "label": "third", "port": 80},
+			},
+		},
+		{
+			name:            "no items matching the filter",
+			filters:         []interface{}{map[string]interface{}{"name": "label", "values": []interface{}{"other"}}},
+			expectedResults: []interface{}{},
+		},
+	}
+	for _, tc := range testCases {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{
+			dataSourceFilterPropertyName:    tc.filters,
+			dataSourceSortByPropertyName:    tc.sortBy,
+			dataSourceSortOrderPropertyName: tc.sortOrder,
+		})
+		client := &clientOpenAPIStub{
+			responseListPayload: []map[string]interface{}{
+				{"id": "cdn1", "label": "first", "port": float64(80), "unsupported": "ignored"},
+				{"id": "cdn2", "label": "second", "port": float64(443)},
+				{"id": "cdn3", "label": "third", "port": float64(80)},
+			},
+		}
+
+		err := d.read(resourceData, client)
+
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, "/v1/cdns", resourceData.Id(), tc.name)
+		assert.Equal(t, tc.expectedResults, resourceData.Get(dataSourceListResultsPropertyName), tc.name)
+		assert.Equal(t, len(tc.expectedResults), resourceData.Get(dataSourceTotalCountPropertyName), tc.name)
+	}
+}
+
+func TestDataSourceListRead_EncryptedProperty(t *testing.T) {
+	encryptedProperty := newStringSchemaDefinitionPropertyWithDefaults("secret", "", false, true, nil)
+	encryptedProperty.Encrypted = true
+	d := newDataSourceListFactory(&specStubResource{
+		name: "cdns_v1",
+		path: "/v1/cdns",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				encryptedProperty,
+			},
+		},
+	})
+	dataSourceSchema, err := d.createTerraformDataSourceListSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{})
+	client := &clientOpenAPIStateEncryptionStub{
+		clientOpenAPIStub:  &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"id": "cdn1", "secret": "secretValue"}}},
+		stateEncryptionKey: testStateEncryptionKey,
+	}
+
+	err = d.read(resourceData, client)
+
+	require.NoError(t, err)
+	results := resourceData.Get(dataSourceListResultsPropertyName).([]interface{})
+	require.Len(t, results, 1)
+	stateValue := results[0].(map[string]interface{})["secret"].(string)
+	assert.True(t, strings.HasPrefix(stateValue, encryptedStateValuePrefix))
+	decryptedValue, err := decryptStateValue(testStateEncryptionKey, stateValue)
+	require.NoError(t, err)
+	assert.Equal(t, "secretValue", decryptedValue)
+}
+
+func TestDataSourceListRead_Fails(t *testing.T) {
+	testCases := []struct {
+		name          string
+		client        *clientOpenAPIStub
+		expectedError string
+	}{
+		{
+			name:          "list operation fails",
+			client:        &clientOpenAPIStub{error: errors.New("some error")},
+			expectedError: "[data source list='cdns_v1_list'] GET /v1/cdns failed: some error",
+		},
+		{
+			name:          "item without identifier",
+			client:        &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"label": "some label"}}},
+			expectedError: "response object returned from the API is missing mandatory identifier property 'id'",
+		},
+	}
+	for _, tc := range testCases {
+		d := newDataSourceListFactory(&specStubResource{
+			name: "cdns_v1",
+			path: "/v1/cdns",
+			schemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				},
+			},
+		})
+		dataSourceSchema, err := d.createTerraformDataSourceListSchema()
+		require.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{})
+		err = d.read(resourceData, tc.client)
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
@@ -0,0 +1,141 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const dataSourceSortByPropertyName = "sort_by"
+const dataSourceSortOrderPropertyName = "sort_order"
+
+// The orders supported by the data source sort_order argument
+const (
+	sortOrderAsc  = "asc"
+	sortOrderDesc = "desc"
+)
+
+// dataSourceSort defines how the items returned by the API are sorted so the data source results are deterministic
+// regardless of the order the API returns the items in
+type dataSourceSort struct {
+	// property is the name of the property (or dotted path to a nested property as in the filters) the items are sorted by
+	property     string
+	propertyType schemaDefinitionPropertyType
+	descending   bool
+}
+
+// supportsSort returns true if the data source exposes the sort_by and sort_order arguments, which is the case unless the
+// data source schema already contains properties with the same names
+func (d dataSourceFactory) supportsSort() bool {
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return false
+	}
+	for _, propertyName := range []string{dataSourceSortByPropertyName, dataSourceSortOrderPropertyName} {
+		if _, err := specSchema.getPropertyBasedOnTerraformName(propertyName); err == nil {
+			log.Printf("[WARN] '%s' data source already contains a property named '%s', skipping the sort arguments", d.openAPIResource.GetResourceName(), propertyName)
+			return false
+		}
+	}
+	return true
+}
+
+// addSortSchema adds the sort_by and sort_order arguments to the given data source schema if the data source supports them
+func (d dataSourceFactory) addSortSchema(dataSourceSchema map[string]*schema.Schema) {
+	if !d.supportsSort() {
+		return
+	}
+	dataSourceSchema[dataSourceSortByPropertyName] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Name of the property the items matching the filters are sorted by",
+	}
+	dataSourceSchema[dataSourceSortOrderPropertyName] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: sortOrderValidateFunc,
+		Description:  "Order the items matching the filters are sorted in: asc (default) or desc",
+	}
+}
+
+// getSort returns how the items should be sorted according to the data source sort_by and sort_order arguments. Nil is
+// returned if the data source does not support sorting or the sort_by argument is not set
+func (d dataSourceFactory) getSort(data *schema.ResourceData) (*dataSourceSort, error) {
+	if !d.supportsSort() {
+		return nil, nil
+	}
+	sortBy, _ := data.Get(dataSourceSortByPropertyName).(string)
+	if sortBy == "" {
+		return nil, nil
+	}
+	specSchema, _ := d.openAPIResource.GetResourceSchema() // ignoring error because will be caught beforehand when data source is constructed via createTerraformDataSourceSchema
+	propertyType, err := getFilterPropertyType(specSchema, sortBy)
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s' not valid: %s", dataSourceSortByPropertyName, sortBy, err)
+	}
+	sortOrder, _ := data.Get(dataSourceSortOrderPropertyName).(string)
+	return &dataSourceSort{property: sortBy, propertyType: propertyType, descending: sortOrder == sortOrderDesc}, nil
+}
+
+// sort sorts the given items in place. The sort is stable so items with equal values keep the order returned by the API,
+// and the items missing the property are placed last regardless of the order
+func (s *dataSourceSort) sort(items []map[string]interface{}) {
+	sort.SliceStable(items, func(i, j int) bool {
+		valueI, existsI := getFilterPayloadValue(items[i], s.property)
+		valueJ, existsJ := getFilterPayloadValue(items[j], s.property)
+		existsI = existsI && valueI != nil
+		existsJ = existsJ && valueJ != nil
+		if !existsI || !existsJ {
+			return existsI && !existsJ
+		}
+		if s.descending {
+			return compareSortValues(s.propertyType, valueJ, valueI) < 0
+		}
+		return compareSortValues(s.propertyType, valueI, valueJ) < 0
+	})
+}
+
+// compareSortValues returns a negative number if a sorts before b, a positive number if b sorts before a and zero if they
+// are equal. Integer and number values are compared numerically, bool values sort false before true and the rest of
+// values are compared using their string representation
+func compareSortValues(propertyType schemaDefinitionPropertyType, a, b interface{}) int {
+	switch propertyType {
+	case TypeInt, TypeFloat:
+		numberA, okA := toFloat64(a)
+		numberB, okB := toFloat64(b)
+		if okA && okB {
+			switch {
+			case numberA < numberB:
+				return -1
+			case numberA > numberB:
+				return 1
+			}
+			return 0
+		}
+	case TypeBool:
+		boolA, okA := a.(bool)
+		boolB, okB := b.(bool)
+		if okA && okB {
+			switch {
+			case boolA == boolB:
+				return 0
+			case boolB:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func sortOrderValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	switch value {
+	case sortOrderAsc, sortOrderDesc:
+	default:
+		errs = append(errs, fmt.Errorf("property '%s' value '%v' is not valid, the supported values are: %s and %s", key, value, sortOrderAsc, sortOrderDesc))
+	}
+	return
+}
@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceGetSort(t *testing.T) {
+	schemaDefinition := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+			newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, nil),
+		},
+	}
+	testCases := []struct {
+		name          string
+		input         map[string]interface{}
+		expectedSort  *dataSourceSort
+		expectedError string
+	}{
+		{
+			name:         "sort_by not set",
+			input:        map[string]interface{}{},
+			expectedSort: nil,
+		},
+		{
+			name:         "sort_by set with the default order",
+			input:        map[string]interface{}{dataSourceSortByPropertyName: "port"},
+			expectedSort: &dataSourceSort{property: "port", propertyType: TypeInt},
+		},
+		{
+			name:         "sort_by set with descending order",
+			input:        map[string]interface{}{dataSourceSortByPropertyName: "label", dataSourceSortOrderPropertyName: sortOrderDesc},
+			expectedSort: &dataSourceSort{property: "label", propertyType: TypeString, descending: true},
+		},
+		{
+			name:          "sort_by set to a property that does not exist",
+			input:         map[string]interface{}{dataSourceSortByPropertyName: "name"},
+			expectedError: "sort_by 'name' not valid: filter name does not match any of the schema properties: property with name 'name' not existing in resource schema definition",
+		},
+	}
+	for _, tc := range testCases {
+		d := newDataSourceListFactory(&specStubResource{schemaDefinition: schemaDefinition})
+		s, err := d.createTerraformDataSourceListSchema()
+		require.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, s, tc.input)
+
+		sort, err := d.dataSource.getSort(resourceData)
+
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedSort, sort, tc.name)
+	}
+}
+
+func TestDataSourceSupportsSort(t *testing.T) {
+	d := newDataSourceListFactory(&specStubResource{schemaDefinition: &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults(dataSourceSortOrderPropertyName, "", false, false, nil)},
+	}})
+	s, err := d.createTerraformDataSourceListSchema()
+	require.NoError(t, err)
+	assert.False(t, d.dataSource.supportsSort())
+	assert.NotContains(t, s, dataSourceSortByPropertyName)
+	assert.NotContains(t, s, dataSourceSortOrderPropertyName)
+}
+
+func TestDataSourceSchemaWithoutSort(t *testing.T) {
+	// the singular data source fails if more than one item matches the filters, hence it does not expose the sort arguments
+	d := newDataSourceFactory(&specStubResource{schemaDefinition: &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil)},
+	}})
+	s, err := d.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	assert.NotContains(t, s, dataSourceSortByPropertyName)
+	assert.NotContains(t, s, dataSourceSortOrderPropertyName)
+}
+
+func TestDataSourceSort(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sort        dataSourceSort
+		items       []map[string]interface{}
+		expectedIDs []string
+	}{
+		{
+			name: "numbers sorted numerically in ascending order",
+			sort: dataSourceSort{property: "port", propertyType: TypeInt},
+			items: []map[string]interface{}{
+				{"id": "1", "port": float64(443)},
+				{"id": "2", "port": float64(80)},
+				{"id": "3", "port": float64(8080)},
+			},
+			expectedIDs: []string{"2", "1", "3"},
+		},
+		{
+			name: "strings sorted in descending order with the items missing the property last",
+			sort: dataSourceSort{property: "label", propertyType: TypeString, descending: true},
+			items: []map[string]interface{}{
+				{"id": "1", "label": "b"},
+				{"id": "2"},
+				{"id": "3", "label": "c"},
+				{"id": "4", "label": nil},
+				{"id": "5", "label": "a"},
+			},
+			expectedIDs: []string{"3", "1", "5", "2", "4"},
+		},
+		{
+			name: "equal values keep the order returned by the API",
+			sort: dataSourceSort{property: "enabled", propertyType: TypeBool},
+			items: []map[string]interface{}{
+				{"id": "1", "enabled": true},
+				{"id": "2", "enabled": false},
+				{"id": "3", "enabled": true},
+				{"id": "4", "enabled": false},
+			},
+			expectedIDs: []string{"2", "4", "1", "3"},
+		},
+		{
+			name: "nested properties",
+			sort: dataSourceSort{property: "config.protocol", propertyType: TypeString},
+			items: []map[string]interface{}{
+				{"id": "1", "config": map[string]interface{}{"protocol": "https"}},
+				{"id": "2", "config": map[string]interface{}{"protocol": "http"}},
+			},
+			expectedIDs: []string{"2", "1"},
+		},
+	}
+	for _, tc := range testCases {
+		tc.sort.sort(tc.items)
+		var ids []string
+		for _, item := range tc.items {
+			ids = append(ids, item["id"].(string))
+		}
+		assert.Equal(t, tc.expectedIDs, ids, tc.name)
+	}
+}
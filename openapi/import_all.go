@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// importTargetNameRegex matches the characters that are not allowed in terraform resource names
+var importTargetNameRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// ImportTarget describes an existing API object that can be imported into terraform
+type ImportTarget struct {
+	// ResourceType is the terraform resource type (e,g: openapi_cdns_v1)
+	ResourceType string
+	// ResourceName is the name given to the resource in the terraform configuration (e,g: cdns_v1_1234)
+	ResourceName string
+	// ID is the ID used to import the resource. For sub-resources it contains the parent IDs too (e,g: 1234/567)
+	ID string
+}
+
+// ImportBlock returns the terraform import block (terraform >= 1.5) that imports the API object
+func (t ImportTarget) ImportBlock() string {
+	return fmt.Sprintf("import {\n  to = %s.%s\n  id = %q\n}\n", t.ResourceType, t.ResourceName, t.ID)
+}
+
+// ImportCommand returns the terraform import command that imports the API object
+func (t ImportTarget) ImportCommand() string {
+	return fmt.Sprintf("terraform import %s.%s %s", t.ResourceType, t.ResourceName, t.ID)
+}
+
+// ImportAll walks the list endpoint of the given resource (e,g: cdns_v1) and returns an ImportTarget for each of the
+// existing API objects matching all the filters provided (property name and value). The parent IDs must be provided
+// if the resource is a sub-resource. The provider is configured with the values provided via environment variables
+// and the plugin configuration file in the same way terraform would do when the provider block is empty.
+func (p *ProviderOpenAPI) ImportAll(resourceName string, parentIDs []string, filters map[string]string) ([]ImportTarget, error) {
+	provider, err := p.CreateSchemaProvider()
+	if err != nil {
+		return nil, err
+	}
+	listResource, err := p.getImportAllListResource(resourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := provider.Configure(terraform.NewResourceConfigRaw(map[string]interface{}{})); err != nil {
+		return nil, fmt.Errorf("failed to configure the provider: %s", err)
+	}
+	openAPIClient, ok := provider.Meta().(ClientOpenAPI)
+	if !ok {
+		return nil, fmt.Errorf("provider configured with an unexpected client")
+	}
+	return getImportTargets(fmt.Sprintf("%s_%s", p.ProviderName, resourceName), listResource, openAPIClient, parentIDs, filters)
+}
+
+// getImportAllListResource returns the list data source of the given resource. An error is returned if the resource
+// is not exposed by the provider or it does not have a list endpoint
+func (p *ProviderOpenAPI) getImportAllListResource(resourceName string) (SpecResource, error) {
+	resources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	resourceExists := false
+	for _, resource := range resources {
+		if resource.GetResourceName() == resourceName && !resource.ShouldIgnoreResource() {
+			resourceExists = true
+			break
+		}
+	}
+	if !resourceExists {
+		return nil, fmt.Errorf("resource '%s' is not exposed by the provider", resourceName)
+	}
+	for _, dataSource := range p.specAnalyser.GetTerraformCompliantDataSources() {
+		if dataSource.GetResourceName() == resourceName {
+			return dataSource, nil
+		}
+	}
+	return nil, fmt.Errorf("resource '%s' does not have a list endpoint", resourceName)
+}
+
+// getImportTargets lists the objects of the given list resource and returns the import targets for the ones matching
+// the filters
+func getImportTargets(resourceType string, listResource SpecResource, openAPIClient ClientOpenAPI, parentIDs []string, filters map[string]string) ([]ImportTarget, error) {
+	resourceName := listResource.GetResourceName()
+	expectedParentIDs := 0
+	if parentResourceInfo := listResource.GetParentResourceInfo(); parentResourceInfo != nil {
+		expectedParentIDs = len(parentResourceInfo.GetParentPropertiesNames())
+	}
+	if len(parentIDs) != expectedParentIDs {
+		return nil, fmt.Errorf("resource '%s' expects %d parent IDs but %d were provided", resourceName, expectedParentIDs, len(parentIDs))
+	}
+	resourceSchema, err := listResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	for filterName := range filters {
+		property, err := resourceSchema.getProperty(filterName)
+		if err != nil {
+			return nil, fmt.Errorf("filter name does not match any of the schema properties: %s", err)
+		}
+		if !property.isPrimitiveProperty() {
+			return nil, fmt.Errorf("property not supported as as filter: %s", property.GetTerraformCompliantPropertyName())
+		}
+	}
+
+	resourcePath, err := listResource.getResourcePath(parentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responsePayload, err := listAllPages(openAPIClient, listResource, parentIDs...)
+	if err != nil {
+		return nil, newResourceOperationError(dataSourceKind, resourceName, http.MethodGet, resourcePath, err)
+	}
+
+	var importTargets []ImportTarget
+	for _, payloadItem := range responsePayload {
+		if !importFiltersMatch(filters, payloadItem) {
+			continue
+		}
+		id, err := getPayloadID(listResource, payloadItem)
+		if err != nil {
+			return nil, err
+		}
+		importTargets = append(importTargets, ImportTarget{
+			ResourceType: resourceType,
+			ResourceName: importTargetNameRegex.ReplaceAllString(fmt.Sprintf("%s_%s", resourceName, id), "_"),
+			ID:           strings.Join(append(append([]string{}, parentIDs...), id), "/"),
+		})
+	}
+	return importTargets, nil
+}
+
+// importFiltersMatch returns true if the payload contains all the filters' properties with the values expected
+func importFiltersMatch(filters map[string]string, payloadItem map[string]interface{}) bool {
+	for name, expectedValue := range filters {
+		value, isPrimitive := primitiveValueToString(payloadItem[name])
+		if !isPrimitive || value != expectedValue {
+			return false
+		}
+	}
+	return true
+}
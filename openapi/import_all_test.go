@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetImportTargets(t *testing.T) {
+	listResource := &specStubResource{
+		name: "cdns_v1",
+		path: "/v1/cdns",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, nil),
+				newListSchemaDefinitionPropertyWithDefaults("owners", "", true, false, false, []string{"value1"}, TypeString, nil),
+			},
+		},
+	}
+	subResource := &specStubResource{
+		name: "cdns_v1_firewalls_v1",
+		path: "/v1/cdns/{id}/v1/firewalls",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			},
+		},
+		fullParentResourceName: "cdns_v1",
+		parentResourceNames:    []string{"cdns_v1"},
+	}
+	responsePayload := []map[string]interface{}{
+		{"id": "someID", "label": "someLabel", "port": float64(80)},
+		{"id": "some:other/ID", "label": "someOtherLabel", "port": float64(8080)},
+	}
+
+	testCases := []struct {
+		name                  string
+		listResource          SpecResource
+		client                *clientOpenAPIStub
+		parentIDs             []string
+		filters               map[string]string
+		expectedImportTargets []ImportTarget
+		expectedError         error
+	}{
+		{
+			name:         "all the objects are returned when no filters are provided",
+			listResource: listResource,
+			client:       &clientOpenAPIStub{responseListPayload: responsePayload},
+			expectedImportTargets: []ImportTarget{
+				{ResourceType: "openapi_cdns_v1", ResourceName: "cdns_v1_someID", ID: "someID"},
+				{ResourceType: "openapi_cdns_v1", ResourceName: "cdns_v1_some_other_ID", ID: "some:other/ID"},
+			},
+		},
+		{
+			name:         "only the objects matching all the filters are returned",
+			listResource: listResource,
+			client:       &clientOpenAPIStub{responseListPayload: responsePayload},
+			filters:      map[string]string{"label": "someOtherLabel", "port": "8080"},
+			expectedImportTargets: []ImportTarget{
+				{ResourceType: "openapi_cdns_v1", ResourceName: "cdns_v1_some_other_ID", ID: "some:other/ID"},
+			},
+		},
+		{
+			name:                  "no objects are returned when none match the filters",
+			listResource:          listResource,
+			client:                &clientOpenAPIStub{responseListPayload: responsePayload},
+			filters:               map[string]string{"label": "nonExistingLabel"},
+			expectedImportTargets: nil,
+		},
+		{
+			name:                  "the parent IDs are included in the import ID of sub-resources",
+			listResource:          subResource,
+			client:                &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"id": "someFirewallID"}}},
+			parentIDs:             []string{"someCdnID"},
+			expectedImportTargets: []ImportTarget{{ResourceType: "openapi_cdns_v1_firewalls_v1", ResourceName: "cdns_v1_firewalls_v1_someFirewallID", ID: "someCdnID/someFirewallID"}},
+		},
+		{
+			name:          "the parent IDs are missing for a sub-resource",
+			listResource:  subResource,
+			client:        &clientOpenAPIStub{},
+			expectedError: errors.New("resource 'cdns_v1_firewalls_v1' expects 1 parent IDs but 0 were provided"),
+		},
+		{
+			name:          "the filter does not match any property",
+			listResource:  listResource,
+			client:        &clientOpenAPIStub{},
+			filters:       map[string]string{"nonExisting": "value"},
+			expectedError: errors.New("filter name does not match any of the schema properties: property with name 'nonExisting' not existing in resource schema definition"),
+		},
+		{
+			name:          "the filter property is not a primitive",
+			listResource:  listResource,
+			client:        &clientOpenAPIStub{},
+			filters:       map[string]string{"owners": "value"},
+			expectedError: errors.New("property not supported as as filter: owners"),
+		},
+		{
+			name:          "the list operation fails",
+			listResource:  listResource,
+			client:        &clientOpenAPIStub{error: errors.New("some error")},
+			expectedError: errors.New("[data source='cdns_v1'] GET /v1/cdns failed: some error"),
+		},
+		{
+			name:          "an object is missing the identifier property",
+			listResource:  listResource,
+			client:        &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"label": "someLabel"}}},
+			expectedError: errors.New("response object returned from the API is missing mandatory identifier property 'id'"),
+		},
+	}
+
+	for _, tc := range testCases {
+		importTargets, err := getImportTargets("openapi_"+tc.listResource.GetResourceName(), tc.listResource, tc.client, tc.parentIDs, tc.filters)
+		if tc.expectedError == nil {
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, tc.expectedImportTargets, importTargets, tc.name)
+			assert.Equal(t, tc.parentIDs, tc.client.parentIDsReceived, tc.name)
+		} else {
+			assert.Equal(t, tc.expectedError.Error(), err.Error(), tc.name)
+		}
+	}
+}
+
+func TestImportTarget(t *testing.T) {
+	importTarget := ImportTarget{ResourceType: "openapi_cdns_v1", ResourceName: "cdns_v1_someID", ID: "someCdnID/someID"}
+	assert.Equal(t, "import {\n  to = openapi_cdns_v1.cdns_v1_someID\n  id = \"someCdnID/someID\"\n}\n", importTarget.ImportBlock())
+	assert.Equal(t, "terraform import openapi_cdns_v1.cdns_v1_someID someCdnID/someID", importTarget.ImportCommand())
+}
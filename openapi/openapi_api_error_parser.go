@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// apiErrorFormatDefault parses the code and message fields at the root level of the payload or nested in an error
+	// object (e,g: {"error": {"code": "...", "message": "..."}})
+	apiErrorFormatDefault = "default"
+	// apiErrorFormatProblemJSON parses RFC 7807 problem details (application/problem+json)
+	apiErrorFormatProblemJSON = "problem+json"
+	// apiErrorFormatText uses the whole response body as the error message
+	apiErrorFormatText = "text"
+)
+
+// problemJSONContentType is the media type of RFC 7807 problem details, which are always parsed with the
+// problemJSONAPIErrorParser regardless of the error format configured
+const problemJSONContentType = "application/problem+json"
+
+// apiErrorParser parses the error code and message returned by the API in the body of the error responses
+type apiErrorParser interface {
+	// parseError returns the error code and message contained in the given response body. False is returned if the body
+	// does not contain any of them in the format supported by the parser.
+	parseError(body []byte) (code, message string, ok bool)
+}
+
+// apiErrorParsers contains the parsers supported indexed by the error format configured via the x-terraform-error-format
+// extension
+var apiErrorParsers = map[string]apiErrorParser{
+	apiErrorFormatDefault:     defaultAPIErrorParser{},
+	apiErrorFormatProblemJSON: problemJSONAPIErrorParser{},
+	apiErrorFormatText:        textAPIErrorParser{},
+}
+
+// getAPIErrorParser returns the parser for the given error format and whether the format is supported. The default
+// parser is returned if the error format is empty.
+func getAPIErrorParser(errorFormat string) (apiErrorParser, bool) {
+	if errorFormat == "" {
+		return defaultAPIErrorParser{}, true
+	}
+	errorParser, exists := apiErrorParsers[strings.ToLower(strings.TrimSpace(errorFormat))]
+	if !exists {
+		return defaultAPIErrorParser{}, false
+	}
+	return errorParser, true
+}
+
+// defaultAPIErrorParser parses the code and message fields at the root level of the payload (e,g: {"code": "...",
+// "message": "..."}) or nested in an error object (e,g: {"error": {"code": "...", "message": "..."}})
+type defaultAPIErrorParser struct{}
+
+func (defaultAPIErrorParser) parseError(body []byte) (string, string, bool) {
+	payload, ok := getAPIErrorPayload(body)
+	if !ok {
+		return "", "", false
+	}
+	code := getAPIErrorStringField(payload, "code")
+	message := getAPIErrorStringField(payload, "message")
+	return code, message, code != "" || message != ""
+}
+
+// problemJSONAPIErrorParser parses RFC 7807 problem details (e,g: {"type": "https://api.com/probs/out-of-credit",
+// "title": "You do not have enough credit.", "detail": "Your current balance is 30, but that costs 50."}). The code is
+// the problem type (unless it's about:blank) and the message is the detail, falling back to the title if not provided.
+// A 'code' extension member takes preference over the problem type.
+type problemJSONAPIErrorParser struct{}
+
+func (problemJSONAPIErrorParser) parseError(body []byte) (string, string, bool) {
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", "", false
+	}
+	code := getAPIErrorStringField(payload, "code")
+	if problemType := getAPIErrorStringField(payload, "type"); code == "" && problemType != "about:blank" {
+		code = problemType
+	}
+	message := getAPIErrorStringField(payload, "detail")
+	if message == "" {
+		message = getAPIErrorStringField(payload, "title")
+	}
+	return code, message, code != "" || message != ""
+}
+
+// textAPIErrorParser uses the whole response body (trimmed) as the error message
+type textAPIErrorParser struct{}
+
+func (textAPIErrorParser) parseError(body []byte) (string, string, bool) {
+	message := strings.TrimSpace(string(body))
+	return "", message, message != ""
+}
+
+// getAPIErrorPayload returns the object containing the error fields returned by the API, which is either the root of
+// the payload or the object nested in the 'error' field. False is returned if the body is not a JSON object.
+func getAPIErrorPayload(body []byte) (map[string]interface{}, bool) {
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, false
+	}
+	if nestedError, ok := payload["error"].(map[string]interface{}); ok {
+		return nestedError, true
+	}
+	return payload, true
+}
+
+// getAPIErrorStringField returns the string representation of the given field of the payload; empty if the field is
+// not present
+func getAPIErrorStringField(payload map[string]interface{}, field string) string {
+	value, exists := payload[field]
+	if !exists || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAPIErrorParser(t *testing.T) {
+	testCases := []struct {
+		name                string
+		errorFormat         string
+		expectedParser      apiErrorParser
+		expectedIsSupported bool
+	}{
+		{name: "empty error format", errorFormat: "", expectedParser: defaultAPIErrorParser{}, expectedIsSupported: true},
+		{name: "default error format", errorFormat: "default", expectedParser: defaultAPIErrorParser{}, expectedIsSupported: true},
+		{name: "problem+json error format", errorFormat: "problem+json", expectedParser: problemJSONAPIErrorParser{}, expectedIsSupported: true},
+		{name: "text error format with different case and spaces", errorFormat: " Text ", expectedParser: textAPIErrorParser{}, expectedIsSupported: true},
+		{name: "not supported error format", errorFormat: "xml", expectedParser: defaultAPIErrorParser{}, expectedIsSupported: false},
+	}
+	for _, tc := range testCases {
+		errorParser, isSupported := getAPIErrorParser(tc.errorFormat)
+		assert.Equal(t, tc.expectedParser, errorParser, tc.name)
+		assert.Equal(t, tc.expectedIsSupported, isSupported, tc.name)
+	}
+}
+
+func TestAPIErrorParsers(t *testing.T) {
+	testCases := []struct {
+		name            string
+		errorParser     apiErrorParser
+		body            string
+		expectedCode    string
+		expectedMessage string
+		expectedOK      bool
+	}{
+		{
+			name:            "default parser with the code and message nested in an error object",
+			errorParser:     defaultAPIErrorParser{},
+			body:            `{"error": {"code": "invalid_request", "message": "invalid label"}}`,
+			expectedCode:    "invalid_request",
+			expectedMessage: "invalid label",
+			expectedOK:      true,
+		},
+		{
+			name:        "default parser with a body that is not JSON",
+			errorParser: defaultAPIErrorParser{},
+			body:        "some backend error",
+			expectedOK:  false,
+		},
+		{
+			name:            "problem+json parser with the type, title and detail",
+			errorParser:     problemJSONAPIErrorParser{},
+			body:            `{"type": "https://api.com/probs/out-of-credit", "title": "Out of credit", "status": 403, "detail": "Your current balance is 30"}`,
+			expectedCode:    "https://api.com/probs/out-of-credit",
+			expectedMessage: "Your current balance is 30",
+			expectedOK:      true,
+		},
+		{
+			name:            "problem+json parser with the about:blank type and no detail",
+			errorParser:     problemJSONAPIErrorParser{},
+			body:            `{"type": "about:blank", "title": "Not Found", "status": 404}`,
+			expectedCode:    "",
+			expectedMessage: "Not Found",
+			expectedOK:      true,
+		},
+		{
+			name:            "problem+json parser with a code extension member",
+			errorParser:     problemJSONAPIErrorParser{},
+			body:            `{"type": "https://api.com/probs/conflict", "title": "Conflict", "code": "operation_in_progress"}`,
+			expectedCode:    "operation_in_progress",
+			expectedMessage: "Conflict",
+			expectedOK:      true,
+		},
+		{
+			name:        "problem+json parser with a JSON body not containing problem details",
+			errorParser: problemJSONAPIErrorParser{},
+			body:        `{"description": "invalid label"}`,
+			expectedOK:  false,
+		},
+		{
+			name:            "text parser",
+			errorParser:     textAPIErrorParser{},
+			body:            " some backend error\n",
+			expectedMessage: "some backend error",
+			expectedOK:      true,
+		},
+		{
+			name:        "text parser with an empty body",
+			errorParser: textAPIErrorParser{},
+			body:        " ",
+			expectedOK:  false,
+		},
+	}
+	for _, tc := range testCases {
+		code, message, ok := tc.errorParser.parseError([]byte(tc.body))
+		assert.Equal(t, tc.expectedCode, code, tc.name)
+		assert.Equal(t, tc.expectedMessage, message, tc.name)
+		assert.Equal(t, tc.expectedOK, ok, tc.name)
+	}
+}
@@ -1,11 +1,14 @@
 package openapi
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 
@@ -19,6 +22,8 @@ const (
 	httpPost   httpMethodSupported = "POST"
 	httpPut    httpMethodSupported = "PUT"
 	httpDelete httpMethodSupported = "DELETE"
+	httpHead   httpMethodSupported = "HEAD"
+	httpPatch  httpMethodSupported = "PATCH"
 )
 
 // ClientOpenAPI defines the behaviour expected to be implemented for the OpenAPI Client used in the Terraform OpenAPI Provider
@@ -40,56 +45,189 @@ type ProviderClient struct {
 	providerConfiguration       providerConfiguration
 	apiAuthenticator            specAuthenticator
 	telemetryHandler            TelemetryHandler
+	// resourceQueryParameters contains the query parameter values configured in the resource attributes (indexed by
+	// the query parameter terraform name)
+	resourceQueryParameters map[string]string
+	// filterQueryParameters contains the values of the data source filters sent as query parameters (indexed by the
+	// query parameter terraform name)
+	filterQueryParameters map[string]string
+	// retryDeadline is the time until which the requests failing with retryable errors are retried. If not set, the
+	// requests are retried up to the max number of retries configured for the resource (retryableErrorMaxRetries by default)
+	retryDeadline time.Time
+	// ctx is the context of the resource or data source operation the client is bound to, which expires once the
+	// operation_timeout configured in the provider elapses. If nil, the requests are not bound to any operation
+	ctx context.Context
+	// rateLimiters holds the rate limiters that throttle the requests sent to the API hosts based on the rate limits
+	// documented and signaled by the API. It is shared across the provider instances in the plugin process so all the
+	// requests sent to the same host are throttled together. If nil, the requests are not throttled
+	rateLimiters *rateLimiterRegistry
+	// rateLimitInterval is the min time between requests based on the rate limit documented in the OpenAPI document
+	rateLimitInterval time.Duration
+	// providerName is the name of the provider the client is configured for
+	providerName string
+	// lastModified holds the Last-Modified values returned when the resource instances were last read, which are sent
+	// in the If-Unmodified-Since header of the conditional requests. If nil, conditional requests are not performed
+	lastModified *lastModifiedCache
+	// ifUnmodifiedSince is the value sent in the If-Unmodified-Since header of the requests, if not empty
+	ifUnmodifiedSince string
+	// deleteQueues holds the queues limiting the deletes performed concurrently for the resources configured with the
+	// x-terraform-delete-max-concurrency extension. If nil, the deletes are not limited
+	deleteQueues *deleteQueueRegistry
+	// deleteBatcher groups the deletes of the resources configured with the x-terraform-bulk-delete-path extension. If
+	// nil, the resource instances are deleted one by one
+	deleteBatcher *deleteBatcher
+	// readBatcher groups the reads performed during the refresh of the resources configured with the
+	// x-terraform-batch-read-ids-param extension. If nil, the resource instances are read one by one
+	readBatcher *readBatcher
+	// credentialsRotation keeps track of the credentials (primary or secondary) accepted by the API when the provider
+	// is configured with secondary credentials. If nil, the requests are only sent with the primary credentials
+	credentialsRotation *credentialsRotation
 }
 
 // Post performs a POST request to the server API based on the resource configuration and the payload passed in
 func (o *ProviderClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
-	resourceURL, err := o.getResourceURL(resource, parentIDs)
+	operation := resource.getResourceOperations().Post
+	resourceURL, err := o.getResourceURL(resource, operation, parentIDs)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().Post
-	return o.performRequest(httpPost, resourceURL, operation, requestPayload, responsePayload)
+	requestPayload, err = o.uploadFiles(resource, operation, requestPayload, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpPost, resource.GetResourceName(), resourceURL, operation, requestPayload, responsePayload)
 }
 
 // Put performs a PUT request to the server API based on the resource configuration and the payload passed in
 func (o *ProviderClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
-	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	operation := resource.getResourceOperations().Put
+	resourceURL, err := o.getResourceIDURL(resource, operation, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().Put
-	return o.performRequest(httpPut, resourceURL, operation, requestPayload, responsePayload)
+	requestPayload, err = o.uploadFiles(resource, operation, requestPayload, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return o.performConditionalRequest(httpPut, resource, id, resourceURL, operation, requestPayload, responsePayload, parentIDs)
 }
 
 // Get performs a GET request to the server API based on the resource configuration and the resource instance id passed in
 func (o *ProviderClient) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
-	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	operation := resource.getResourceOperations().Get
+	resourceURL, err := o.getResourceIDURL(resource, operation, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().Get
-	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+	resp, err := o.performRequest(httpGet, resource.GetResourceName(), resourceURL, operation, nil, responsePayload)
+	if err == nil {
+		o.recordLastModified(resourceURL, resp)
+	}
+	return resp, err
 }
 
 // List performs a GET request to the root level endpoint of the resource (e,g: GET /v1/groups)
 func (o *ProviderClient) List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
-	resourceURL, err := o.getResourceURL(resource, parentIDs)
+	operation := resource.getResourceOperations().List
+	resourceURL, err := o.getResourceURL(resource, operation, parentIDs)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().List
-	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+	resourceURL = o.appendPageSize(operation, resourceURL)
+	return o.performRequest(httpGet, resource.GetResourceName(), resourceURL, operation, nil, responsePayload)
 }
 
 // Delete performs a DELETE request to the server API based on the resource configuration and the resource instance id passed in
 func (o *ProviderClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
-	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	operation := resource.getResourceOperations().Delete
+	if operation != nil {
+		operation = withTooManyRequestsRetries(operation)
+		if operation.bulkDelete != nil && o.deleteBatcher != nil {
+			return o.bulkDelete(resource, operation, id, parentIDs)
+		}
+	}
+	resourceURL, err := o.getResourceIDURL(resource, operation, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().Delete
-	return o.performRequest(httpDelete, resourceURL, operation, nil, nil)
+	if operation != nil && operation.deleteMaxConcurrency > 0 && o.deleteQueues != nil {
+		queue := o.deleteQueues.get(fmt.Sprintf("%s_%s", o.providerName, resource.GetResourceName()))
+		ctx := o.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if err := queue.acquire(ctx, operation.deleteMaxConcurrency); err != nil {
+			return nil, o.checkOperationTimeout(httpDelete, resourceURL)
+		}
+		defer queue.release()
+	}
+	return o.performConditionalRequest(httpDelete, resource, id, resourceURL, operation, nil, nil, parentIDs)
+}
+
+// resourceHeadersClient is implemented by the clients that support overriding the header values configured in the
+// provider with the values configured in the resource header attributes (x-terraform-header-resource-attribute)
+type resourceHeadersClient interface {
+	withResourceHeaders(headers map[string]string) ClientOpenAPI
+}
+
+// withResourceHeaders returns a copy of the client where the given header values (indexed by the header terraform
+// configuration name) override the header values configured in the provider
+func (o *ProviderClient) withResourceHeaders(headers map[string]string) ClientOpenAPI {
+	if len(headers) == 0 {
+		return o
+	}
+	client := *o
+	client.providerConfiguration.Headers = map[string]string{}
+	for headerName, headerValue := range o.providerConfiguration.Headers {
+		client.providerConfiguration.Headers[headerName] = headerValue
+	}
+	for headerName, headerValue := range headers {
+		client.providerConfiguration.Headers[headerName] = headerValue
+	}
+	return &client
+}
+
+// resourceQueryParametersClient is implemented by the clients that support sending the query parameter values configured
+// in the resource query parameter attributes (x-terraform-query-param-resource-attribute)
+type resourceQueryParametersClient interface {
+	withResourceQueryParameters(queryParameters map[string]string) ClientOpenAPI
+}
+
+// withResourceQueryParameters returns a copy of the client that sends the given query parameter values (indexed by the
+// query parameter terraform name) for the operations declaring those query parameters
+func (o *ProviderClient) withResourceQueryParameters(queryParameters map[string]string) ClientOpenAPI {
+	if len(queryParameters) == 0 {
+		return o
+	}
+	client := *o
+	client.resourceQueryParameters = map[string]string{}
+	for name, value := range o.resourceQueryParameters {
+		client.resourceQueryParameters[name] = value
+	}
+	for name, value := range queryParameters {
+		client.resourceQueryParameters[name] = value
+	}
+	return &client
+}
+
+// filterQueryParametersClient is implemented by the clients that support sending the data source filter values as the
+// query parameters declared in the list operation, so the collection is filtered server side
+type filterQueryParametersClient interface {
+	withFilterQueryParameters(queryParameters map[string]string) ClientOpenAPI
+}
+
+// withFilterQueryParameters returns a copy of the client that sends the given filter values (indexed by the query
+// parameter terraform name) for the operations declaring those query parameters as filters
+func (o *ProviderClient) withFilterQueryParameters(queryParameters map[string]string) ClientOpenAPI {
+	if len(queryParameters) == 0 {
+		return o
+	}
+	client := *o
+	client.filterQueryParameters = map[string]string{}
+	for name, value := range queryParameters {
+		client.filterQueryParameters[name] = value
+	}
+	return &client
 }
 
 // GetTelemetryHandler returns the configured telemetry handler
@@ -97,7 +235,27 @@ func (o *ProviderClient) GetTelemetryHandler() TelemetryHandler {
 	return o.telemetryHandler
 }
 
-func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+func (o *ProviderClient) performRequest(method httpMethodSupported, resourceName, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	return o.performRequestWithCredentialsRotation(method, resourceName, resourceURL, operation, requestPayload, responsePayload)
+}
+
+// performAuthenticatedRequest performs the request with the credentials configured in the client
+func (o *ProviderClient) performAuthenticatedRequest(method httpMethodSupported, resourceName, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	reqContext, err := o.prepareRequestContext(method, resourceURL, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	if method == httpPost || method == httpPut {
+		requestPayload = o.appendRuntimeMetadataProperties(requestPayload)
+	}
+
+	return o.sendRequestWithRetries(method, resourceName, reqContext, operation, requestPayload, responsePayload)
+}
+
+// prepareRequestContext returns the URL (including the operation query parameters) and the headers (including the
+// credentials) the request for the given operation is sent with
+func (o *ProviderClient) prepareRequestContext(method httpMethodSupported, resourceURL string, operation *specResourceOperation) (*authContext, error) {
 	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
@@ -107,22 +265,48 @@ func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
 	}
-	log.Printf("[DEBUG] Performing %s %s", method, reqContext.url)
+
+	reqContext.url, err = o.appendOperationQueryParameters(operation.QueryParameters, reqContext.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
+	}
+	log.Printf("[DEBUG] Performing %s %s%s", method, reqContext.url, operation.getOperationIDLogSuffix())
 
 	userAgentHeader := version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
 	o.appendUserAgentHeader(reqContext.headers, userAgentHeader)
+	o.appendGlobalHeaders(reqContext.headers)
+	o.appendIdentityHeaders(reqContext.headers)
+	o.appendRuntimeMetadataHeaders(reqContext.headers)
+	if o.ifUnmodifiedSince != "" {
+		reqContext.headers[ifUnmodifiedSinceHeader] = o.ifUnmodifiedSince
+	}
 
 	o.logHeadersSafely(reqContext.headers)
 
+	return reqContext, nil
+}
+
+func (o *ProviderClient) sendRequest(method httpMethodSupported, reqContext *authContext, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
 	switch method {
 	case httpPost:
+		if responsePayload == nil {
+			// the response body is not decoded so APIs responding with no content are supported (e,g: bulk delete and pre-delete endpoints)
+			return o.sendJSONRequest(http.MethodPost, reqContext.url, reqContext.headers, requestPayload)
+		}
 		return o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
 	case httpPut:
+		if responsePayload == nil {
+			return o.sendJSONRequest(http.MethodPut, reqContext.url, reqContext.headers, requestPayload)
+		}
 		return o.httpClient.PutJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
 	case httpGet:
 		return o.httpClient.Get(reqContext.url, reqContext.headers, &responsePayload)
 	case httpDelete:
 		return o.httpClient.Delete(reqContext.url, reqContext.headers)
+	case httpHead:
+		return o.sendHeadRequest(reqContext.url, reqContext.headers)
+	case httpPatch:
+		return o.sendJSONRequest(http.MethodPatch, reqContext.url, reqContext.headers, requestPayload)
 	}
 	return nil, fmt.Errorf("method '%s' not supported", method)
 }
@@ -143,6 +327,60 @@ func (o *ProviderClient) logHeadersSafely(headers map[string]string) {
 	}
 }
 
+// appendOperationQueryParameters returns the given url including the query parameters the operation requires. The values
+// configured in the resource attributes take preference over the values pinned in the OpenAPI document. The query
+// parameters flagged as filters are only sent if the data source filters set a value for them.
+func (o ProviderClient) appendOperationQueryParameters(operationQueryParameters SpecQueryParameters, resourceURL string) (string, error) {
+	queryValues := url.Values{}
+	// the query parameters already present in the URL (e,g: next page links) are not appended again
+	var existingQueryValues url.Values
+	if parsedURL, err := url.Parse(resourceURL); err == nil {
+		existingQueryValues = parsedURL.Query()
+	}
+	for _, queryParam := range operationQueryParameters {
+		if _, exists := existingQueryValues[queryParam.Name]; exists {
+			continue
+		}
+		value := queryParam.Value
+		if queryParam.IsResourceAttribute {
+			if resourceValue, exists := o.resourceQueryParameters[queryParam.GetQueryParamTerraformName()]; exists && resourceValue != "" {
+				value = resourceValue
+			}
+		}
+		if queryParam.IsFilter {
+			if filterValue, exists := o.filterQueryParameters[queryParam.GetQueryParamTerraformName()]; exists && filterValue != "" {
+				queryValues.Set(queryParam.Name, filterValue)
+			}
+			continue
+		}
+		if value == "" {
+			if queryParam.IsRequired {
+				return "", fmt.Errorf("required query parameter '%s' is missing the value. Please make sure the property '%s' is configured with a value in the resource's terraform configuration", queryParam.Name, queryParam.GetQueryParamTerraformName())
+			}
+			continue
+		}
+		queryValues.Set(queryParam.Name, value)
+	}
+	if len(queryValues) == 0 {
+		return resourceURL, nil
+	}
+	separator := "?"
+	if strings.Contains(resourceURL, "?") {
+		separator = "&"
+	}
+	return resourceURL + separator + queryValues.Encode(), nil
+}
+
+// appendGlobalHeaders adds the headers required in every operation of the OpenAPI document to the given headers, so they
+// are also sent in the requests that are not documented as operations (e,g: HEAD operations)
+func (o ProviderClient) appendGlobalHeaders(headers map[string]string) {
+	for headerName, value := range o.providerConfiguration.GlobalHeaders {
+		if existingValue := headers[headerName]; existingValue == "" {
+			headers[headerName] = value
+		}
+	}
+}
+
 // appendOperationHeaders returns a maps containing the headers passed in and adds whatever headers the operation requires. The values
 // are retrieved from the provider configuration.
 func (o ProviderClient) appendOperationHeaders(operationHeaders []SpecHeaderParam, headers map[string]string) error {
@@ -159,24 +397,27 @@ func (o ProviderClient) appendOperationHeaders(operationHeaders []SpecHeaderPara
 	return nil
 }
 
-func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string) (string, error) {
+// getResourceURL returns the resource URL the given operation is performed against. If the operation is configured
+// with a host override (x-terraform-operation-host) it takes preference over the global and resource hosts.
+func (o ProviderClient) getResourceURL(resource SpecResource, operation *specResourceOperation, parentIDs []string) (string, error) {
+	resourceRelativePath, err := resource.getResourcePath(parentIDs)
+	if err != nil {
+		return "", err
+	}
+	return o.getURL(resource, operation, resourceRelativePath)
+}
+
+// getURL returns the URL of the given path (relative to the base path) which is managed by the given resource. The host
+// and base path are resolved the same way for all the paths of the resource (e,g: the resource path or the path of a
+// bulk delete endpoint).
+func (o ProviderClient) getURL(resource SpecResource, operation *specResourceOperation, resourceRelativePath string) (string, error) {
 	var host string
-	var err error
 
-	isMultiRegion, _, regions, err := o.openAPIBackendConfiguration.IsMultiRegion()
+	region, err := o.getResolvedRegion()
 	if err != nil {
 		return "", err
 	}
-	if isMultiRegion {
-		// get region value provided by user in the terraform configuration file
-		region := o.providerConfiguration.getRegion()
-		// otherwise, if not provided falling back to the default value specified in the service provider swagger file
-		if region == "" {
-			region, err = o.openAPIBackendConfiguration.GetDefaultRegion(regions)
-			if err != nil {
-				return "", err
-			}
-		}
+	if region != "" {
 		host, err = o.openAPIBackendConfiguration.getHostByRegion(region)
 		if err != nil {
 			return "", err
@@ -188,11 +429,7 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		}
 	}
 
-	basePath := o.openAPIBackendConfiguration.getBasePath()
-	resourceRelativePath, err := resource.getResourcePath(parentIDs)
-	if err != nil {
-		return "", err
-	}
+	basePath := o.getBasePath(resource, resourceRelativePath)
 
 	// Fall back to override the host if value is not empty; otherwise global host will be used as usual
 	hostOverride, err := resource.getHost()
@@ -204,6 +441,11 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		host = hostOverride
 	}
 
+	if operation != nil && operation.host != "" {
+		log.Printf("[INFO] resource '%s' operation is configured with host override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, operation.host, host)
+		host = operation.host
+	}
+
 	if endPointHost := o.providerConfiguration.getEndPoint(resource.GetResourceName()); endPointHost != "" {
 		log.Printf("[INFO] resource '%s' is configured with endpoint override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, endPointHost, host)
 		host = endPointHost
@@ -233,11 +475,24 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 	return fmt.Sprintf("%s://%s%s", defaultScheme, host, path), nil
 }
 
-func (o ProviderClient) getResourceIDURL(resource SpecResource, parentIDs []string, id string) (string, error) {
+// getBasePath resolves the base path the API calls of the given resource are made against. The base path configured in
+// the resource (x-terraform-resource-base-path) takes preference over the global base path, so resources served by a
+// different host (x-terraform-resource-host) can either retain, replace or strip (with "/") the global base path.
+func (o ProviderClient) getBasePath(resource SpecResource, resourceRelativePath string) string {
+	basePath := o.openAPIBackendConfiguration.getBasePath()
+	resourceBasePath := resource.getBasePath()
+	if resourceBasePath == "" {
+		return basePath
+	}
+	log.Printf("[INFO] resource '%s' is configured with base path override, API calls will be made against the base path '%s' instead of '%s'", resourceRelativePath, resourceBasePath, basePath)
+	return resourceBasePath
+}
+
+func (o ProviderClient) getResourceIDURL(resource SpecResource, operation *specResourceOperation, parentIDs []string, id string) (string, error) {
 	if strings.Contains(id, "/") {
 		return "", fmt.Errorf("instance ID (%s) contains not supported characters (forward slashes)", id)
 	}
-	url, err := o.getResourceURL(resource, parentIDs)
+	url, err := o.getResourceURL(resource, operation, parentIDs)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"log"
+	"net/http"
+	"sync"
+)
+
+const lastModifiedHeader = "Last-Modified"
+const ifUnmodifiedSinceHeader = "If-Unmodified-Since"
+
+// lastModifiedCache holds the Last-Modified values returned by the API when the resource instances were last read,
+// indexed by the resource instance URL. It is shared by the copies of the client
+type lastModifiedCache struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newLastModifiedCache() *lastModifiedCache {
+	return &lastModifiedCache{values: map[string]string{}}
+}
+
+func (c *lastModifiedCache) get(resourceURL string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[resourceURL]
+}
+
+// set stores the Last-Modified value for the given resource instance URL. Empty values remove the previous value stored
+func (c *lastModifiedCache) set(resourceURL, lastModified string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if lastModified == "" {
+		delete(c.values, resourceURL)
+		return
+	}
+	c.values[resourceURL] = lastModified
+}
+
+// recordLastModified stores the Last-Modified header returned by the API when the resource instance was read
+// successfully so it can be sent in the If-Unmodified-Since header of the subsequent conditional requests
+func (o *ProviderClient) recordLastModified(resourceURL string, resp *http.Response) {
+	if o.lastModified == nil || resp == nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return
+	}
+	o.lastModified.set(resourceURL, resp.Header.Get(lastModifiedHeader))
+}
+
+// withIfUnmodifiedSince returns a copy of the client that sends the given value in the If-Unmodified-Since header
+func (o *ProviderClient) withIfUnmodifiedSince(value string) *ProviderClient {
+	client := *o
+	client.ifUnmodifiedSince = value
+	return &client
+}
+
+// performConditionalRequest performs the request for operations configured with the x-terraform-conditional-request
+// extension sending the If-Unmodified-Since header with the Last-Modified value returned when the resource instance was
+// last read. If the API responds with 412 Precondition Failed, the resource instance is read again to get the fresh
+// Last-Modified value and the request is retried once.
+func (o *ProviderClient) performConditionalRequest(method httpMethodSupported, resource SpecResource, id, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}, parentIDs []string) (*http.Response, error) {
+	resourceName := resource.GetResourceName()
+	if !operation.conditionalRequestEnabled || o.lastModified == nil {
+		return o.performRequest(method, resourceName, resourceURL, operation, requestPayload, responsePayload)
+	}
+	resp, err := o.withIfUnmodifiedSince(o.lastModified.get(resourceURL)).performRequest(method, resourceName, resourceURL, operation, requestPayload, responsePayload)
+	if err != nil || resp.StatusCode != http.StatusPreconditionFailed {
+		return resp, err
+	}
+	log.Printf("[INFO] %s %s responded with 412 Precondition Failed, reading the resource again and retrying the request", method, resourceURL)
+	if _, err := o.Get(resource, id, &map[string]interface{}{}, parentIDs...); err != nil {
+		return nil, err
+	}
+	resetResponsePayload(responsePayload)
+	return o.withIfUnmodifiedSince(o.lastModified.get(resourceURL)).performRequest(method, resourceName, resourceURL, operation, requestPayload, responsePayload)
+}
@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPerformConditionalRequest(t *testing.T) {
+	Convey("Given an API that supports conditional requests and a resource that is modified outside terraform after it was read", t, func() {
+		lastModified := "Wed, 21 Oct 2020 07:28:00 GMT"
+		var putIfUnmodifiedSinceValues []string
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			switch req.Method {
+			case http.MethodGet:
+				rw.Header().Set(lastModifiedHeader, lastModified)
+				rw.WriteHeader(http.StatusOK)
+			case http.MethodPut:
+				putIfUnmodifiedSinceValues = append(putIfUnmodifiedSinceValues, req.Header.Get(ifUnmodifiedSinceHeader))
+				if req.Header.Get(ifUnmodifiedSinceHeader) != "" && req.Header.Get(ifUnmodifiedSinceHeader) != lastModified {
+					rw.WriteHeader(http.StatusPreconditionFailed)
+					rw.Write([]byte(`{"message":"resource was modified"}`))
+					return
+				}
+				rw.WriteHeader(http.StatusOK)
+			}
+			rw.Write([]byte(`{"id":"1234"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "/", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			lastModified:                newLastModifiedCache(),
+		}
+		newResource := func(conditionalRequestEnabled bool) *specStubResource {
+			return &specStubResource{
+				path:                 "/v1/resource",
+				resourceGetOperation: &specResourceOperation{},
+				resourcePutOperation: &specResourceOperation{conditionalRequestEnabled: conditionalRequestEnabled},
+			}
+		}
+		Convey("When providerClient PUT method is called with an operation configured with conditional requests", func() {
+			resource := newResource(true)
+			_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+			So(err, ShouldBeNil)
+			previousLastModified := lastModified
+			lastModified = "Thu, 22 Oct 2020 07:28:00 GMT"
+			resp, err := providerClient.Put(resource, "1234", map[string]interface{}{}, &map[string]interface{}{})
+			Convey("Then the request should be sent with the Last-Modified value read and retried with the fresh value after the API responds with 412", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(putIfUnmodifiedSinceValues, ShouldResemble, []string{previousLastModified, lastModified})
+			})
+		})
+		Convey("When providerClient PUT method is called with an operation that is not configured with conditional requests", func() {
+			resource := newResource(false)
+			_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+			So(err, ShouldBeNil)
+			resp, err := providerClient.Put(resource, "1234", map[string]interface{}{}, &map[string]interface{}{})
+			Convey("Then the request should be sent without the If-Unmodified-Since header", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(putIfUnmodifiedSinceValues, ShouldResemble, []string{""})
+			})
+		})
+	})
+}
+
+func TestLastModifiedCache(t *testing.T) {
+	Convey("Given a lastModifiedCache", t, func() {
+		cache := newLastModifiedCache()
+		Convey("When set is called with a value and then with an empty value", func() {
+			cache.set("http://host.com/v1/resource/1234", "Wed, 21 Oct 2020 07:28:00 GMT")
+			value := cache.get("http://host.com/v1/resource/1234")
+			cache.set("http://host.com/v1/resource/1234", "")
+			Convey("Then the value should be stored and then removed", func() {
+				So(value, ShouldEqual, "Wed, 21 Oct 2020 07:28:00 GMT")
+				So(cache.get("http://host.com/v1/resource/1234"), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"log"
+	"net/http"
+	"sync"
+)
+
+// providerPropertySecondaryCredentialsSuffix is appended to the name of the API key security definition properties to
+// build the name of the provider properties holding the secondary credentials (e,g: apikey_auth_secondary)
+const providerPropertySecondaryCredentialsSuffix = "_secondary"
+
+// credentialsRotation keeps track of the credentials (primary or secondary) the API last accepted, so once the API rejects
+// the primary credentials (e,g: the key was rotated in the middle of a long apply) the following requests are sent with
+// the secondary credentials straight away. It is shared by all the copies of the client of a provider instance.
+type credentialsRotation struct {
+	mu           sync.Mutex
+	useSecondary bool
+}
+
+func newCredentialsRotation() *credentialsRotation {
+	return &credentialsRotation{}
+}
+
+func (c *credentialsRotation) isSecondaryActive() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.useSecondary
+}
+
+func (c *credentialsRotation) setSecondaryActive(useSecondary bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.useSecondary = useSecondary
+}
+
+// withCredentials returns a copy of the client where the security definitions configured with secondary credentials
+// use the secondary credentials if secondary is true. The client is returned as is if there are no secondary credentials
+func (o *ProviderClient) withCredentials(secondary bool) *ProviderClient {
+	if !secondary || len(o.providerConfiguration.SecondarySecuritySchemaDefinitions) == 0 {
+		return o
+	}
+	client := *o
+	client.providerConfiguration.SecuritySchemaDefinitions = map[string]specAPIKeyAuthenticator{}
+	for secDefName, authenticator := range o.providerConfiguration.SecuritySchemaDefinitions {
+		client.providerConfiguration.SecuritySchemaDefinitions[secDefName] = authenticator
+	}
+	for secDefName, authenticator := range o.providerConfiguration.SecondarySecuritySchemaDefinitions {
+		client.providerConfiguration.SecuritySchemaDefinitions[secDefName] = authenticator
+	}
+	return &client
+}
+
+// performRequestWithCredentialsRotation performs the request with the credentials the API last accepted and, if the API
+// responds with 401 Unauthorized and the provider is configured with secondary credentials, the request is performed
+// again with the other credentials. The credentials accepted by the API are used for the following requests
+func (o *ProviderClient) performRequestWithCredentialsRotation(method httpMethodSupported, resourceName, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	if o.credentialsRotation == nil || len(o.providerConfiguration.SecondarySecuritySchemaDefinitions) == 0 {
+		return o.performAuthenticatedRequest(method, resourceName, resourceURL, operation, requestPayload, responsePayload)
+	}
+	useSecondary := o.credentialsRotation.isSecondaryActive()
+	resp, err := o.withCredentials(useSecondary).performAuthenticatedRequest(method, resourceName, resourceURL, operation, requestPayload, responsePayload)
+	if err != nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	log.Printf("[WARN] %s %s responded with 401 Unauthorized, retrying with the %s credentials", method, resourceURL, getCredentialsName(!useSecondary))
+	resetResponsePayload(responsePayload)
+	resp, err = o.withCredentials(!useSecondary).performAuthenticatedRequest(method, resourceName, resourceURL, operation, requestPayload, responsePayload)
+	if err == nil && resp != nil && resp.StatusCode != http.StatusUnauthorized {
+		log.Printf("[INFO] the API accepted the %s credentials, using them for the following requests", getCredentialsName(!useSecondary))
+		o.credentialsRotation.setSecondaryActive(!useSecondary)
+	}
+	return resp, err
+}
+
+func getCredentialsName(secondary bool) string {
+	if secondary {
+		return "secondary"
+	}
+	return "primary"
+}
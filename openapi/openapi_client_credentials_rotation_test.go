@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderClientCredentialsRotation(t *testing.T) {
+	Convey("Given a provider client configured with primary and secondary credentials and an API that only accepts the secondary credentials", t, func() {
+		var keysReceived []string
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			keysReceived = append(keysReceived, req.Header.Get(authorizationHeader))
+			if req.Header.Get(authorizationHeader) != "secondaryKey" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				rw.Write([]byte(`{"error":"unauthorized"}`))
+				return
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`{"id":"1","name":"some name"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{host: strings.TrimPrefix(api.URL, "http://"), httpScheme: "http"},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newAPIAuthenticator(nil),
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"apikey_auth": apiKeyHeaderAuthenticator{apiKey: apiKey{name: authorizationHeader, value: "primaryKey"}},
+				},
+				SecondarySecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"apikey_auth": apiKeyHeaderAuthenticator{apiKey: apiKey{name: authorizationHeader, value: "secondaryKey"}},
+				},
+			},
+			credentialsRotation: newCredentialsRotation(),
+		}
+		resource := &specStubResource{
+			name: "resource",
+			path: "/v1/resource",
+			resourceGetOperation: &specResourceOperation{
+				SecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_auth"}},
+			},
+		}
+		Convey("When Get is called twice", func() {
+			firstPayload := map[string]interface{}{}
+			firstResp, firstErr := providerClient.Get(resource, "1", &firstPayload)
+			secondPayload := map[string]interface{}{}
+			secondResp, secondErr := providerClient.Get(resource, "1", &secondPayload)
+			Convey("Then the first request should be retried with the secondary credentials and the second request should use them straight away", func() {
+				So(firstErr, ShouldBeNil)
+				So(firstResp.StatusCode, ShouldEqual, http.StatusOK)
+				So(firstPayload, ShouldResemble, map[string]interface{}{"id": "1", "name": "some name"})
+				So(secondErr, ShouldBeNil)
+				So(secondResp.StatusCode, ShouldEqual, http.StatusOK)
+				So(secondPayload["id"], ShouldEqual, "1")
+				So(keysReceived, ShouldResemble, []string{"primaryKey", "secondaryKey", "secondaryKey"})
+				So(providerClient.providerConfiguration.SecuritySchemaDefinitions["apikey_auth"].getContext().(apiKey).value, ShouldEqual, "primaryKey")
+			})
+		})
+		Convey("When Get is called with a client that is not configured with secondary credentials", func() {
+			providerClient.providerConfiguration.SecondarySecuritySchemaDefinitions = map[string]specAPIKeyAuthenticator{}
+			resp, err := providerClient.Get(resource, "1", &map[string]interface{}{})
+			Convey("Then the request should not be retried", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+				So(keysReceived, ShouldResemble, []string{"primaryKey"})
+			})
+		})
+	})
+}
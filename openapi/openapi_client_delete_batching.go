@@ -0,0 +1,291 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bulkDeleteBatchWindow is the time the first delete of a batch waits for other deletes of the same resource type (and
+// parent) to join the batch before calling the bulk delete endpoint
+var bulkDeleteBatchWindow = 500 * time.Millisecond
+
+// pathParameterPlaceholderRegex matches the path parameters (e,g: {cdn_id}) of the paths configured in the extensions
+var pathParameterPlaceholderRegex = regexp.MustCompile(`{[\w-]+}`)
+
+// deleteQueue limits the number of deletes of a resource type performed concurrently. The deletes waiting for a slot
+// are served in the same order they were queued so the API receives them in a predictable order.
+type deleteQueue struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	// nextTicket is the ticket handed to the next delete queued and servingTicket the ticket of the next delete allowed
+	// to run once there is a slot available
+	nextTicket    uint64
+	servingTicket uint64
+	running       int
+	// abandoned holds the tickets of the deletes that stopped waiting because their operation expired, which are skipped
+	abandoned map[uint64]bool
+}
+
+func newDeleteQueue() *deleteQueue {
+	q := &deleteQueue{abandoned: map[uint64]bool{}}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// acquire blocks until all the deletes queued before have started and there are less than maxConcurrency deletes running.
+// If the given context expires while waiting, the delete leaves the queue and the context error is returned.
+func (q *deleteQueue) acquire(ctx context.Context, maxConcurrency int) error {
+	if ctx.Done() != nil {
+		// wake up the waiting deletes once the context expires so this one can leave the queue
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				q.mu.Lock()
+				q.cond.Broadcast()
+				q.mu.Unlock()
+			case <-stop:
+			}
+		}()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ticket := q.nextTicket
+	q.nextTicket++
+	for ticket != q.servingTicket || q.running >= maxConcurrency {
+		if err := ctx.Err(); err != nil {
+			q.abandon(ticket)
+			return err
+		}
+		q.cond.Wait()
+	}
+	q.servingTicket++
+	q.skipAbandoned()
+	q.running++
+	q.cond.Broadcast()
+	return nil
+}
+
+// abandon removes the given ticket from the queue so the deletes queued after it are not blocked. Must be called with
+// the queue lock held.
+func (q *deleteQueue) abandon(ticket uint64) {
+	q.abandoned[ticket] = true
+	q.skipAbandoned()
+	q.cond.Broadcast()
+}
+
+// skipAbandoned moves the serving ticket past the tickets abandoned. Must be called with the queue lock held.
+func (q *deleteQueue) skipAbandoned() {
+	for q.abandoned[q.servingTicket] {
+		delete(q.abandoned, q.servingTicket)
+		q.servingTicket++
+	}
+}
+
+// release frees the slot taken by a delete once it has completed
+func (q *deleteQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.running--
+	q.cond.Broadcast()
+}
+
+// deleteQueueRegistry holds the delete queues of the resource types. Like the rate limiters, the same registry is used by
+// all the provider instances configured in the plugin process.
+type deleteQueueRegistry struct {
+	mu     sync.Mutex
+	queues map[string]*deleteQueue
+}
+
+// sharedDeleteQueues is the delete queue registry shared by the provider instances configured in the plugin process
+var sharedDeleteQueues = newDeleteQueueRegistry()
+
+func newDeleteQueueRegistry() *deleteQueueRegistry {
+	return &deleteQueueRegistry{queues: map[string]*deleteQueue{}}
+}
+
+// get returns the delete queue for the given key, creating it if it does not exist yet
+func (r *deleteQueueRegistry) get(key string) *deleteQueue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, exists := r.queues[key]
+	if !exists {
+		queue = newDeleteQueue()
+		r.queues[key] = queue
+	}
+	return queue
+}
+
+// deleteBatch is a group of resource instances deleted with a single call to the bulk delete endpoint. The response is
+// shared by all the deletes in the batch.
+type deleteBatch struct {
+	ids  []string
+	full chan struct{}
+	done chan struct{}
+	// statusCode, header and body are the response of the bulk delete endpoint, set once done is closed
+	statusCode int
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+// response returns a copy of the bulk delete response so each delete in the batch can consume the body independently
+func (b *deleteBatch) response() (*http.Response, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &http.Response{
+		StatusCode: b.statusCode,
+		Header:     b.header,
+		Body:       ioutil.NopCloser(bytes.NewReader(b.body)),
+	}, nil
+}
+
+// deleteBatcher groups the deletes of the same resource type (and parent) received within the batch window. Each
+// provider instance has its own batcher so deletes are never sent with the credentials of a different provider instance.
+type deleteBatcher struct {
+	mu      sync.Mutex
+	pending map[string]*deleteBatch
+}
+
+func newDeleteBatcher() *deleteBatcher {
+	return &deleteBatcher{pending: map[string]*deleteBatch{}}
+}
+
+// add adds the given id to the batch pending for the given key, starting a new batch if there is none. The leader
+// returned is true for the delete that started the batch, which is responsible for calling the bulk delete endpoint.
+func (d *deleteBatcher) add(key, id string, maxBatchSize int) (*deleteBatch, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	batch, exists := d.pending[key]
+	if !exists {
+		batch = &deleteBatch{full: make(chan struct{}), done: make(chan struct{})}
+		d.pending[key] = batch
+	}
+	batch.ids = append(batch.ids, id)
+	if len(batch.ids) >= maxBatchSize {
+		delete(d.pending, key)
+		close(batch.full)
+	}
+	return batch, !exists
+}
+
+// seal closes the given batch so no more ids are added to it and returns the ids in the batch
+func (d *deleteBatcher) seal(key string, batch *deleteBatch) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending[key] == batch {
+		delete(d.pending, key)
+	}
+	return batch.ids
+}
+
+// bulkDelete deletes the resource instance calling the bulk delete endpoint configured for the resource. The instance is
+// added to the batch pending for the resource type and parent, and the first delete of the batch calls the endpoint
+// on behalf of the rest once the batch is full or the batch window has elapsed.
+func (o *ProviderClient) bulkDelete(resource SpecResource, operation *specResourceOperation, id string, parentIDs []string) (*http.Response, error) {
+	bulkDeletePath, err := resolveBulkDeletePath(operation.bulkDelete.path, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	bulkDeleteURL, err := o.getURL(resource, operation, bulkDeletePath)
+	if err != nil {
+		return nil, err
+	}
+	// the deletes are only batched with deletes sent with the same headers, as the resource header attributes and the
+	// identity_headers attribute may override the values configured in the provider
+	key := fmt.Sprintf("%s_%s %s %v %v", o.providerName, resource.GetResourceName(), bulkDeleteURL, o.providerConfiguration.Headers, o.providerConfiguration.IdentityHeaders)
+	batch, leader := o.deleteBatcher.add(key, id, operation.bulkDelete.maxBatchSize)
+	if leader {
+		select {
+		case <-batch.full:
+		case <-time.After(bulkDeleteBatchWindow):
+		}
+		ids := o.deleteBatcher.seal(key, batch)
+		log.Printf("[INFO] deleting %d instances of resource '%s' calling the bulk delete endpoint %s", len(ids), resource.GetResourceName(), bulkDeleteURL)
+		requestPayload := map[string]interface{}{operation.bulkDelete.idsProperty: ids}
+		resp, err := o.performRequest(httpPost, resource.GetResourceName(), bulkDeleteURL, operation, requestPayload, nil)
+		batch.err = err
+		if resp != nil {
+			batch.statusCode = resp.StatusCode
+			batch.header = resp.Header
+			batch.body = readBulkDeleteResponseBody(resp)
+		}
+		close(batch.done)
+	}
+	if !o.waitBatch(batch.done) {
+		return nil, o.checkOperationTimeout(httpPost, bulkDeleteURL)
+	}
+	return batch.response()
+}
+
+// waitBatch blocks until the given batch done channel is closed, returning early with false if the operation the client
+// is bound to expires
+func (o *ProviderClient) waitBatch(done chan struct{}) bool {
+	if o.ctx == nil {
+		<-done
+		return true
+	}
+	select {
+	case <-done:
+		return true
+	case <-o.ctx.Done():
+		return false
+	}
+}
+
+// readBulkDeleteResponseBody returns the body of the bulk delete response, which may be empty (e,g: 204 No Content)
+func readBulkDeleteResponseBody(resp *http.Response) []byte {
+	if resp.Body == nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+// resolveBulkDeletePath replaces the path parameters in the given bulk delete path with the given parent IDs (escaped),
+// in order
+func resolveBulkDeletePath(path string, parentIDs []string) (string, error) {
+	resolvedPath, err := resolvePathParameters(path, parentIDs)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve the bulk delete path '%s': %s", path, err)
+	}
+	return resolvedPath, nil
+}
+
+// resolvePathParameters replaces the path parameters in the given path with the given parent IDs (escaped), in order
+func resolvePathParameters(path string, parentIDs []string) (string, error) {
+	pathParameters := pathParameterPlaceholderRegex.FindAllString(path, -1)
+	if len(pathParameters) != len(parentIDs) {
+		return "", fmt.Errorf("the number of path parameters does not match the number of parent IDs %v", parentIDs)
+	}
+	for i, pathParameter := range pathParameters {
+		path = strings.Replace(path, pathParameter, url.PathEscape(parentIDs[i]), 1)
+	}
+	return path, nil
+}
+
+// withTooManyRequestsRetries returns a copy of the given delete operation that also retries the requests the API responds
+// to with 429 Too Many Requests, so bursts of deletes back off (honoring the Retry-After header) instead of failing
+func withTooManyRequestsRetries(operation *specResourceOperation) *specResourceOperation {
+	if operation.isRetryableError(http.StatusTooManyRequests, "") {
+		return operation
+	}
+	deleteOperation := *operation
+	deleteOperation.retryableErrors = append([]specRetryableError{{statusCode: http.StatusTooManyRequests}}, operation.retryableErrors...)
+	return &deleteOperation
+}
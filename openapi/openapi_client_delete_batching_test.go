@@ -0,0 +1,331 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteQueue(t *testing.T) {
+	Convey("Given a delete queue", t, func() {
+		queue := newDeleteQueue()
+		Convey("When several deletes are performed concurrently with a max concurrency of 2", func() {
+			var mu sync.Mutex
+			running, maxRunning := 0, 0
+			var order []int
+			var wg sync.WaitGroup
+			for i := 0; i < 6; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					queue.acquire(context.Background(), 2)
+					mu.Lock()
+					running++
+					if running > maxRunning {
+						maxRunning = running
+					}
+					order = append(order, i)
+					mu.Unlock()
+					time.Sleep(10 * time.Millisecond)
+					mu.Lock()
+					running--
+					mu.Unlock()
+					queue.release()
+				}(i)
+				// give the goroutine time to be queued so the queue order is deterministic
+				time.Sleep(2 * time.Millisecond)
+			}
+			wg.Wait()
+			Convey("Then no more than 2 deletes should run at the same time", func() {
+				So(maxRunning, ShouldEqual, 2)
+			})
+			Convey("And the deletes should start in the order they were queued", func() {
+				So(order, ShouldResemble, []int{0, 1, 2, 3, 4, 5})
+			})
+		})
+	})
+}
+
+func TestDeleteQueueAcquireContextExpired(t *testing.T) {
+	Convey("Given a delete queue with no slots available", t, func() {
+		queue := newDeleteQueue()
+		queue.acquire(context.Background(), 1)
+		Convey("When a delete bound to an operation that expires while waiting is queued", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			err := queue.acquire(ctx, 1)
+			Convey("Then the error returned should be the context error", func() {
+				So(err, ShouldResemble, context.DeadlineExceeded)
+			})
+			Convey("And the deletes queued after it should not be blocked once the slot is released", func() {
+				queue.release()
+				acquired := make(chan struct{})
+				go func() {
+					queue.acquire(context.Background(), 1)
+					close(acquired)
+				}()
+				var isAcquired bool
+				select {
+				case <-acquired:
+					isAcquired = true
+				case <-time.After(time.Second):
+				}
+				So(isAcquired, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestDeleteQueueRegistryGet(t *testing.T) {
+	registry := newDeleteQueueRegistry()
+	assert.Same(t, registry.get("provider_cdn_v1"), registry.get("provider_cdn_v1"))
+	assert.True(t, registry.get("provider_cdn_v1") != registry.get("provider_cdn_firewall_v1"))
+}
+
+func TestResolveBulkDeletePath(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		parentIDs     []string
+		expectedPath  string
+		expectedError string
+	}{
+		{
+			name:         "path without parameters",
+			path:         "/v1/cdns/bulk-delete",
+			expectedPath: "/v1/cdns/bulk-delete",
+		},
+		{
+			name:         "path with parent parameters",
+			path:         "/v1/cdns/{cdn_id}/firewalls/{firewall_id}/rules/bulk-delete",
+			parentIDs:    []string{"cdnID", "firewallID"},
+			expectedPath: "/v1/cdns/cdnID/firewalls/firewallID/rules/bulk-delete",
+		},
+		{
+			name:         "path with parent IDs containing reserved characters",
+			path:         "/v1/cdns/{cdn_id}/firewalls/bulk-delete",
+			parentIDs:    []string{"cdn/ID?x=1"},
+			expectedPath: "/v1/cdns/cdn%2FID%3Fx=1/firewalls/bulk-delete",
+		},
+		{
+			name:          "path with parameters not matching the parent IDs",
+			path:          "/v1/cdns/{cdn_id}/firewalls/bulk-delete",
+			expectedError: "could not resolve the bulk delete path '/v1/cdns/{cdn_id}/firewalls/bulk-delete': the number of path parameters does not match the number of parent IDs []",
+		},
+	}
+	for _, tc := range testCases {
+		path, err := resolveBulkDeletePath(tc.path, tc.parentIDs)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedPath, path, tc.name)
+	}
+}
+
+func TestWithTooManyRequestsRetries(t *testing.T) {
+	Convey("Given a delete operation configured with retryable errors", t, func() {
+		operation := &specResourceOperation{retryableErrors: []specRetryableError{{statusCode: http.StatusConflict}}}
+		Convey("When withTooManyRequestsRetries is called", func() {
+			deleteOperation := withTooManyRequestsRetries(operation)
+			Convey("Then the operation returned should also retry 429 Too Many Requests", func() {
+				So(deleteOperation.isRetryableError(http.StatusTooManyRequests, ""), ShouldBeTrue)
+				So(deleteOperation.isRetryableError(http.StatusConflict, ""), ShouldBeTrue)
+			})
+			Convey("And the original operation should not be modified", func() {
+				So(operation.isRetryableError(http.StatusTooManyRequests, ""), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a delete operation already configured to retry 429 Too Many Requests", t, func() {
+		operation := &specResourceOperation{retryableErrors: []specRetryableError{{statusCode: http.StatusTooManyRequests}}}
+		Convey("When withTooManyRequestsRetries is called", func() {
+			deleteOperation := withTooManyRequestsRetries(operation)
+			Convey("Then the same operation should be returned", func() {
+				So(deleteOperation, ShouldEqual, operation)
+			})
+		})
+	})
+}
+
+func TestProviderClientDeleteRetriesTooManyRequests(t *testing.T) {
+	defaultInitialBackoff, defaultMaxBackoff := retryableErrorInitialBackoff, retryableErrorMaxBackoff
+	retryableErrorInitialBackoff, retryableErrorMaxBackoff = time.Millisecond, 2*time.Millisecond
+	defer func() {
+		retryableErrorInitialBackoff, retryableErrorMaxBackoff = defaultInitialBackoff, defaultMaxBackoff
+	}()
+	Convey("Given an API that responds to the delete with 429 Too Many Requests before succeeding", t, func() {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			if requests == 1 {
+				rw.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		Convey("When providerClient DELETE method is called for a resource not configured with retryable errors", func() {
+			resource := &specStubResource{path: "/v1/resource", resourceDeleteOperation: &specResourceOperation{}}
+			resp, err := providerClient.Delete(resource, "1234")
+			Convey("Then the delete should be retried until the API succeeds", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+				So(requests, ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestProviderClientDeleteWithBulkDelete(t *testing.T) {
+	defaultBulkDeleteBatchWindow := bulkDeleteBatchWindow
+	bulkDeleteBatchWindow = 100 * time.Millisecond
+	defer func() { bulkDeleteBatchWindow = defaultBulkDeleteBatchWindow }()
+	Convey("Given an API exposing a bulk delete endpoint", t, func() {
+		var mu sync.Mutex
+		var paths []string
+		var batches [][]string
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			payload := map[string][]string{}
+			json.NewDecoder(req.Body).Decode(&payload)
+			mu.Lock()
+			paths = append(paths, req.Method+" "+req.URL.Path)
+			batches = append(batches, payload["resource_ids"])
+			mu.Unlock()
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			deleteBatcher:               newDeleteBatcher(),
+		}
+		deleteInstances := func(resource SpecResource, ids ...string) ([]*http.Response, []error) {
+			responses := make([]*http.Response, len(ids))
+			errs := make([]error, len(ids))
+			var wg sync.WaitGroup
+			for i, id := range ids {
+				wg.Add(1)
+				go func(i int, id string) {
+					defer wg.Done()
+					responses[i], errs[i] = providerClient.Delete(resource, id, "parentID")
+				}(i, id)
+			}
+			wg.Wait()
+			return responses, errs
+		}
+		Convey("When providerClient DELETE method is called concurrently for several instances of a resource configured with the bulk delete endpoint", func() {
+			resource := &specStubResource{
+				path: "/v1/parents/parentID/resource",
+				resourceDeleteOperation: &specResourceOperation{
+					bulkDelete: &specBulkDelete{path: "/v1/parents/{parent_id}/resource/bulk-delete", idsProperty: "resource_ids", maxBatchSize: 100},
+				},
+			}
+			responses, errs := deleteInstances(resource, "1", "2", "3")
+			Convey("Then the instances should be deleted with a single call to the bulk delete endpoint", func() {
+				So(paths, ShouldResemble, []string{"POST /v1/parents/parentID/resource/bulk-delete"})
+				So(batches[0], ShouldHaveLength, 3)
+				So(batches[0], ShouldContain, "1")
+				So(batches[0], ShouldContain, "2")
+				So(batches[0], ShouldContain, "3")
+			})
+			Convey("And each delete should get the bulk delete response", func() {
+				So(errs, ShouldResemble, []error{nil, nil, nil})
+				for _, resp := range responses {
+					So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+				}
+			})
+		})
+		Convey("When providerClient DELETE method is called concurrently for more instances than the max batch size", func() {
+			resource := &specStubResource{
+				path: "/v1/parents/parentID/resource",
+				resourceDeleteOperation: &specResourceOperation{
+					bulkDelete: &specBulkDelete{path: "/v1/parents/{parent_id}/resource/bulk-delete", idsProperty: "resource_ids", maxBatchSize: 2},
+				},
+			}
+			_, errs := deleteInstances(resource, "1", "2", "3")
+			Convey("Then the instances should be deleted in batches not exceeding the max batch size", func() {
+				So(errs, ShouldResemble, []error{nil, nil, nil})
+				So(batches, ShouldHaveLength, 2)
+				So(len(batches[0])+len(batches[1]), ShouldEqual, 3)
+			})
+		})
+	})
+}
+
+func TestProviderClientDeleteWithBulkDeleteIdentityHeaders(t *testing.T) {
+	defaultBulkDeleteBatchWindow := bulkDeleteBatchWindow
+	bulkDeleteBatchWindow = 100 * time.Millisecond
+	defer func() { bulkDeleteBatchWindow = defaultBulkDeleteBatchWindow }()
+	Convey("Given an API exposing a bulk delete endpoint and two clients configured with different identity headers", t, func() {
+		var mu sync.Mutex
+		batches := map[string][]string{}
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			payload := map[string][]string{}
+			json.NewDecoder(req.Body).Decode(&payload)
+			mu.Lock()
+			tenant := req.Header.Get("X-Tenant-Id")
+			batches[tenant] = append(batches[tenant], payload["resource_ids"]...)
+			mu.Unlock()
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		batcher := newDeleteBatcher()
+		newClient := func(tenant string) *ProviderClient {
+			return &ProviderClient{
+				openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+				httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+				apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+				providerConfiguration:       providerConfiguration{IdentityHeaders: map[string]string{"X-Tenant-Id": tenant}},
+				deleteBatcher:               batcher,
+			}
+		}
+		clients := []*ProviderClient{newClient("tenant-a"), newClient("tenant-b")}
+		resource := &specStubResource{
+			path: "/v1/resource",
+			resourceDeleteOperation: &specResourceOperation{
+				bulkDelete: &specBulkDelete{path: "/v1/resource/bulk-delete", idsProperty: "resource_ids", maxBatchSize: 100},
+			},
+		}
+		Convey("When providerClient DELETE method is called concurrently with both clients", func() {
+			errs := make([]error, 4)
+			var wg sync.WaitGroup
+			for i, id := range []string{"a1", "b1", "a2", "b2"} {
+				wg.Add(1)
+				go func(i int, id string) {
+					defer wg.Done()
+					_, errs[i] = clients[i%2].Delete(resource, id)
+				}(i, id)
+			}
+			wg.Wait()
+			Convey("Then the instances should only be batched with the instances deleted with the same identity headers", func() {
+				So(errs, ShouldResemble, []error{nil, nil, nil, nil})
+				So(batches["tenant-a"], ShouldHaveLength, 2)
+				So(batches["tenant-a"], ShouldContain, "a1")
+				So(batches["tenant-a"], ShouldContain, "a2")
+				So(batches["tenant-b"], ShouldHaveLength, 2)
+				So(batches["tenant-b"], ShouldContain, "b1")
+				So(batches["tenant-b"], ShouldContain, "b2")
+			})
+		})
+	})
+}
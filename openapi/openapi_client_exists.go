@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dikhan/http_goclient"
+)
+
+// existsClient is implemented by the clients that support checking whether a resource exists with a HEAD request to the
+// resource instance path, which is cheaper than reading the whole resource
+type existsClient interface {
+	Head(resource SpecResource, id string, parentIDs ...string) (*http.Response, error)
+}
+
+// Head performs a HEAD request to the server API based on the resource configuration and the resource instance id passed in
+func (o *ProviderClient) Head(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Head
+	if operation == nil {
+		return nil, errors.New("resource does not support HEAD operation")
+	}
+	resourceURL, err := o.getResourceIDURL(resource, operation, parentIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpHead, resource.GetResourceName(), resourceURL, operation, nil, nil)
+}
+
+// sendHeadRequest sends a HEAD request with the given headers. HEAD requests are only supported by the http clients backed
+// by a net/http client
+func (o *ProviderClient) sendHeadRequest(url string, headers map[string]string) (*http.Response, error) {
+	goClient, ok := o.httpClient.(*http_goclient.HttpClient)
+	if !ok || goClient.HttpClient == nil {
+		return nil, errors.New("HEAD requests are not supported by the http client")
+	}
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for headerName, headerValue := range headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	return goClient.HttpClient.Do(req)
+}
@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderClientHead(t *testing.T) {
+	Convey("Given a provider client and an API that supports HEAD requests", t, func() {
+		var methodReceived, pathReceived, authHeaderReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			methodReceived = req.Method
+			pathReceived = req.URL.Path
+			authHeaderReceived = req.Header.Get("Authentication")
+			if strings.HasSuffix(req.URL.Path, "/deleted") {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "/", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		resource := &specStubResource{path: "/v1/resource", resourceHeadOperation: &specResourceOperation{}}
+		Convey("When providerClient Head method is called with the id of an existing resource", func() {
+			resp, err := providerClient.Head(resource, "1234")
+			Convey("Then the HEAD request should be sent to the resource instance path with the auth headers", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(methodReceived, ShouldEqual, http.MethodHead)
+				So(pathReceived, ShouldEqual, "/v1/resource/1234")
+				So(authHeaderReceived, ShouldEqual, "Bearer secret!")
+			})
+		})
+		Convey("When providerClient Head method is called with the id of a resource that no longer exists", func() {
+			resp, err := providerClient.Head(resource, "deleted")
+			Convey("Then the response returned should have the 404 status code", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+			})
+		})
+		Convey("When providerClient Head method is called with a resource that does not declare a HEAD operation", func() {
+			_, err := providerClient.Head(&specStubResource{path: "/v1/resource"}, "1234")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource does not support HEAD operation")
+			})
+		})
+	})
+}
@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// hostOverrideTransport is a http.RoundTripper that overrides the Host header and the TLS server name (SNI) of the
+// requests sent to the configured hosts. This enables reaching APIs sitting behind shared ingresses or load balancers
+// that route the requests based on a host name different from the address the connection is made to.
+type hostOverrideTransport struct {
+	// hostHeaders contains the Host header values indexed by the host the requests are sent to
+	hostHeaders map[string]string
+	// tlsTransports contains the transports configured with the TLS server names indexed by the host the requests are
+	// sent to
+	tlsTransports map[string]http.RoundTripper
+	transport     http.RoundTripper
+}
+
+// newHostOverrideTransport returns a http.RoundTripper that wraps the given transport overriding the Host header and the
+// TLS server name of the requests sent to the hosts configured in the given maps, which are indexed by the host (with
+// the port if the requests are sent to a non default port) the requests are sent to. If there are no overrides the
+// transport is returned as is. The TLS server names can only be overridden if the given transport is a *http.Transport,
+// otherwise they are ignored
+func newHostOverrideTransport(hostHeaders, tlsServerNames map[string]string, transport http.RoundTripper) http.RoundTripper {
+	if len(hostHeaders) == 0 && len(tlsServerNames) == 0 {
+		return transport
+	}
+	tlsTransports := map[string]http.RoundTripper{}
+	if len(tlsServerNames) > 0 {
+		if baseTransport, ok := transport.(*http.Transport); ok {
+			for host, serverName := range tlsServerNames {
+				tlsTransports[host] = sharedTLSServerNameTransports.get(baseTransport, serverName)
+			}
+		} else {
+			log.Printf("[WARN] the TLS server names configured can not be overridden as the http transport in use (%T) is not supported", transport)
+		}
+	}
+	return &hostOverrideTransport{hostHeaders: hostHeaders, tlsTransports: tlsTransports, transport: transport}
+}
+
+// tlsServerNameTransportRegistry holds the transports configured with TLS server names. The same registry is used by all
+// the provider instances configured in the plugin process, so the transports (and their connection pools) are reused
+// instead of being created every time a provider is configured.
+type tlsServerNameTransportRegistry struct {
+	mu         sync.Mutex
+	transports map[tlsServerNameTransportKey]*http.Transport
+}
+
+// tlsServerNameTransportKey identifies a transport configured with a TLS server name by the transport it is copied from
+// and the TLS server name
+type tlsServerNameTransportKey struct {
+	transport  *http.Transport
+	serverName string
+}
+
+// sharedTLSServerNameTransports is the TLS server name transport registry shared by the provider instances configured in
+// the plugin process
+var sharedTLSServerNameTransports = newTLSServerNameTransportRegistry()
+
+func newTLSServerNameTransportRegistry() *tlsServerNameTransportRegistry {
+	return &tlsServerNameTransportRegistry{transports: map[tlsServerNameTransportKey]*http.Transport{}}
+}
+
+// get returns the copy of the given transport sending the given TLS server name, creating it if it does not exist yet
+func (r *tlsServerNameTransportRegistry) get(transport *http.Transport, serverName string) *http.Transport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := tlsServerNameTransportKey{transport: transport, serverName: serverName}
+	tlsTransport, exists := r.transports[key]
+	if !exists {
+		tlsTransport = newTLSServerNameTransport(transport, serverName)
+		r.transports[key] = tlsTransport
+	}
+	return tlsTransport
+}
+
+// newTLSServerNameTransport returns a copy of the given transport sending the given TLS server name. The rest of the
+// configuration of the given transport (e,g: proxy, HTTP/2 or insecure skip verify) is preserved
+func newTLSServerNameTransport(transport *http.Transport, serverName string) *http.Transport {
+	tlsTransport := transport.Clone()
+	if tlsTransport.TLSClientConfig == nil {
+		tlsTransport.TLSClientConfig = &tls.Config{}
+	}
+	tlsTransport.TLSClientConfig.ServerName = serverName
+	return tlsTransport
+}
+
+func (t *hostOverrideTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.transport
+	hostHeader := ""
+	// the host with the port takes precedence over the host name only
+	for _, host := range []string{req.URL.Hostname(), req.URL.Host} {
+		if tlsTransport, ok := t.tlsTransports[host]; ok {
+			transport = tlsTransport
+		}
+		if value, ok := t.hostHeaders[host]; ok {
+			hostHeader = value
+		}
+	}
+	if hostHeader != "" {
+		// the request is copied as the RoundTripper must not modify the request received
+		req = req.WithContext(req.Context())
+		req.Host = hostHeader
+	}
+	return transport.RoundTrip(req)
+}
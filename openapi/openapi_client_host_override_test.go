@@ -0,0 +1,131 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostOverrideTransport(t *testing.T) {
+	var hostReceived, serverNameReceived string
+	api := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		hostReceived = req.Host
+		rw.WriteHeader(http.StatusOK)
+	}))
+	api.TLS = &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverNameReceived = hello.ServerName
+			return nil, nil
+		},
+	}
+	api.StartTLS()
+	defer api.Close()
+	apiURL, err := url.Parse(api.URL)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name               string
+		hostHeaders        map[string]string
+		tlsServerNames     map[string]string
+		expectedHost       string
+		expectedServerName string
+	}{
+		{
+			name:         "no overrides",
+			expectedHost: apiURL.Host,
+		},
+		{
+			name:               "overrides configured for the host with the port",
+			hostHeaders:        map[string]string{apiURL.Host: "api.service.com"},
+			tlsServerNames:     map[string]string{apiURL.Host: "ingress.service.com"},
+			expectedHost:       "api.service.com",
+			expectedServerName: "ingress.service.com",
+		},
+		{
+			name:               "overrides configured for the host name",
+			hostHeaders:        map[string]string{apiURL.Hostname(): "api.service.com"},
+			tlsServerNames:     map[string]string{apiURL.Hostname(): "ingress.service.com"},
+			expectedHost:       "api.service.com",
+			expectedServerName: "ingress.service.com",
+		},
+		{
+			name:           "overrides configured for other hosts",
+			hostHeaders:    map[string]string{"other.service.com": "api.service.com"},
+			tlsServerNames: map[string]string{"other.service.com": "ingress.service.com"},
+			expectedHost:   apiURL.Host,
+		},
+	}
+	for _, tc := range testCases {
+		hostReceived, serverNameReceived = "", ""
+		// #nosec G402
+		baseTransport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+		client := &http.Client{Transport: newHostOverrideTransport(tc.hostHeaders, tc.tlsServerNames, baseTransport)}
+		req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+		require.NoError(t, err, tc.name)
+
+		resp, err := client.Do(req)
+
+		require.NoError(t, err, tc.name)
+		resp.Body.Close()
+		assert.Equal(t, tc.expectedHost, hostReceived, tc.name)
+		assert.Equal(t, tc.expectedServerName, serverNameReceived, tc.name)
+		assert.Equal(t, apiURL.Host, req.Host, tc.name)
+	}
+}
+
+// roundTripperFunc is a http.RoundTripper implemented by a function
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHostOverrideTransportNotHTTPTransport(t *testing.T) {
+	var hostReceived string
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		hostReceived = req.Host
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	client := &http.Client{Transport: newHostOverrideTransport(map[string]string{"api.service.com": "ingress.service.com"}, map[string]string{"api.service.com": "ingress.service.com"}, transport)}
+
+	resp, err := client.Get("https://api.service.com/v1/cdns")
+
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "ingress.service.com", hostReceived)
+}
+
+func TestNewTLSServerNameTransport(t *testing.T) {
+	// #nosec G402
+	baseTransport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, ForceAttemptHTTP2: true, MaxIdleConns: 10}
+
+	tlsTransport := newTLSServerNameTransport(baseTransport, "ingress.service.com")
+
+	assert.Equal(t, "ingress.service.com", tlsTransport.TLSClientConfig.ServerName)
+	assert.True(t, tlsTransport.TLSClientConfig.InsecureSkipVerify)
+	assert.True(t, tlsTransport.ForceAttemptHTTP2)
+	assert.Equal(t, 10, tlsTransport.MaxIdleConns)
+	assert.Empty(t, baseTransport.TLSClientConfig.ServerName)
+	assert.Equal(t, "ingress.service.com", newTLSServerNameTransport(&http.Transport{}, "ingress.service.com").TLSClientConfig.ServerName)
+}
+
+func TestTLSServerNameTransportRegistry(t *testing.T) {
+	registry := newTLSServerNameTransportRegistry()
+	baseTransport := &http.Transport{}
+
+	tlsTransport := registry.get(baseTransport, "ingress.service.com")
+
+	assert.Same(t, tlsTransport, registry.get(baseTransport, "ingress.service.com"))
+	assert.True(t, tlsTransport != registry.get(baseTransport, "other.service.com"))
+	assert.True(t, tlsTransport != registry.get(&http.Transport{}, "ingress.service.com"))
+}
+
+func TestNewHostOverrideTransport_NoOverrides(t *testing.T) {
+	transport := &http.Transport{}
+	assert.Equal(t, transport, newHostOverrideTransport(map[string]string{}, nil, transport))
+}
@@ -0,0 +1,34 @@
+package openapi
+
+// identityHeadersClient is implemented by the clients that support overriding the identity headers configured in the
+// provider (identity_headers) with the values configured in the resource identity_headers attribute
+type identityHeadersClient interface {
+	withIdentityHeaders(headers map[string]string) ClientOpenAPI
+}
+
+// withIdentityHeaders returns a copy of the client where the given identity headers (indexed by the header name) are
+// added to the identity headers configured in the provider, overriding the values of the headers configured in both
+func (o *ProviderClient) withIdentityHeaders(headers map[string]string) ClientOpenAPI {
+	if len(headers) == 0 {
+		return o
+	}
+	client := *o
+	client.providerConfiguration.IdentityHeaders = map[string]string{}
+	for headerName, headerValue := range o.providerConfiguration.IdentityHeaders {
+		client.providerConfiguration.IdentityHeaders[headerName] = headerValue
+	}
+	for headerName, headerValue := range headers {
+		client.providerConfiguration.IdentityHeaders[headerName] = headerValue
+	}
+	return &client
+}
+
+// appendIdentityHeaders adds the identity headers (e,g: the tenant, organization or project the API calls are scoped to)
+// unless the headers are already set with a value
+func (o *ProviderClient) appendIdentityHeaders(headers map[string]string) {
+	for headerName, value := range o.providerConfiguration.IdentityHeaders {
+		if existingValue := headers[headerName]; existingValue == "" {
+			headers[headerName] = value
+		}
+	}
+}
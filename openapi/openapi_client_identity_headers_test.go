@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAppendIdentityHeaders(t *testing.T) {
+	Convey("Given a providerClient configured with identity headers", t, func() {
+		providerClient := &ProviderClient{providerConfiguration: providerConfiguration{IdentityHeaders: map[string]string{"X-Tenant-Id": "tenant-a", "X-Project-Id": "project-a"}}}
+		Convey("When appendIdentityHeaders is called with headers already containing one of the identity headers with a value and other one empty", func() {
+			headers := map[string]string{"X-Tenant-Id": "someTenant", "X-Project-Id": ""}
+			providerClient.appendIdentityHeaders(headers)
+			Convey("Then the headers with a value should be kept and the rest should be set to the identity header values", func() {
+				So(headers, ShouldResemble, map[string]string{"X-Tenant-Id": "someTenant", "X-Project-Id": "project-a"})
+			})
+		})
+	})
+}
+
+func TestWithIdentityHeaders(t *testing.T) {
+	Convey("Given a providerClient configured with identity headers", t, func() {
+		providerClient := &ProviderClient{providerConfiguration: providerConfiguration{IdentityHeaders: map[string]string{"X-Tenant-Id": "tenant-a", "X-Project-Id": "project-a"}}}
+		Convey("When withIdentityHeaders is called with identity headers overriding one of the provider values", func() {
+			client := providerClient.withIdentityHeaders(map[string]string{"X-Tenant-Id": "tenant-b", "X-Org-Id": "org-b"})
+			Convey("Then the client returned should contain the merged identity headers", func() {
+				So(client.(*ProviderClient).providerConfiguration.IdentityHeaders, ShouldResemble, map[string]string{"X-Tenant-Id": "tenant-b", "X-Project-Id": "project-a", "X-Org-Id": "org-b"})
+			})
+			Convey("And the identity headers of the original client should not be modified", func() {
+				So(providerClient.providerConfiguration.IdentityHeaders, ShouldResemble, map[string]string{"X-Tenant-Id": "tenant-a", "X-Project-Id": "project-a"})
+			})
+		})
+		Convey("When withIdentityHeaders is called with no identity headers", func() {
+			client := providerClient.withIdentityHeaders(map[string]string{})
+			Convey("Then the same client should be returned", func() {
+				So(client, ShouldEqual, providerClient)
+			})
+		})
+	})
+}
@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const maxBodySizeConfigurationHint = "The max body size can be configured with the 'max_body_size' property in the provider or in the plugin configuration file"
+
+// maxBodySizeTransport is a http.RoundTripper that fails the requests which request or response bodies exceed the max
+// body size. The bodies are measured as they are sent and received, avoiding serialising or buffering them in memory
+// only to check their size
+type maxBodySizeTransport struct {
+	maxBodySize int64
+	transport   http.RoundTripper
+}
+
+// newMaxBodySizeTransport returns a http.RoundTripper that wraps the given transport limiting the size of the request
+// and response bodies. If the max body size is not positive (e,g: not configured) there is no limit and the transport is
+// returned as is
+func newMaxBodySizeTransport(maxBodySize int64, transport http.RoundTripper) http.RoundTripper {
+	if maxBodySize <= 0 {
+		return transport
+	}
+	return &maxBodySizeTransport{maxBodySize: maxBodySize, transport: transport}
+}
+
+func (t *maxBodySizeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.ContentLength > t.maxBodySize {
+			req.Body.Close()
+			return nil, t.tooLargeError("request", req, req.ContentLength)
+		}
+		if req.ContentLength < 0 {
+			// the request is copied as the RoundTripper must not modify the request received
+			req = req.WithContext(req.Context())
+			req.Body = &maxBodySizeReader{body: req.Body, remaining: t.maxBodySize, err: t.tooLargeError("request", req, -1)}
+		}
+	}
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength > t.maxBodySize {
+		resp.Body.Close()
+		return nil, t.tooLargeError("response", req, resp.ContentLength)
+	}
+	resp.Body = &maxBodySizeReader{body: resp.Body, remaining: t.maxBodySize, err: t.tooLargeError("response", req, -1)}
+	return resp, nil
+}
+
+// tooLargeError returns the error of a request or response (as specified by bodyKind) which body exceeds the max body
+// size. A negative size means the size of the body is not known
+func (t *maxBodySizeTransport) tooLargeError(bodyKind string, req *http.Request, size int64) error {
+	if size < 0 {
+		return fmt.Errorf("the %s body for %s %s exceeds the max body size allowed (%d bytes). %s", bodyKind, req.Method, req.URL, t.maxBodySize, maxBodySizeConfigurationHint)
+	}
+	return fmt.Errorf("the %s body for %s %s is %d bytes which exceeds the max body size allowed (%d bytes). %s", bodyKind, req.Method, req.URL, size, t.maxBodySize, maxBodySizeConfigurationHint)
+}
+
+// maxBodySizeReader is a io.ReadCloser that returns an error once more than the remaining bytes are read
+type maxBodySizeReader struct {
+	body      io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (r *maxBodySizeReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, r.err
+	}
+	// Reading one extra byte allows to tell apart bodies of exactly the max size from the ones exceeding it
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.body.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return 0, r.err
+	}
+	return n, err
+}
+
+func (r *maxBodySizeReader) Close() error {
+	return r.body.Close()
+}
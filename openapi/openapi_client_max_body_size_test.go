@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMaxBodySizeTransportRequestBody(t *testing.T) {
+	Convey("Given a server that counts the requests received", t, func() {
+		requestsReceived := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived++
+			ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+		requestBody := `{"label":"some label"}` // 22 bytes
+		Convey("When a request is performed with a client which max body size is greater or equal than the request body size", func() {
+			client := &http.Client{Transport: newMaxBodySizeTransport(22, http.DefaultTransport)}
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader(requestBody))
+			Convey("Then the request should be sent", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+				So(requestsReceived, ShouldEqual, 1)
+			})
+		})
+		Convey("When a request is performed with a client which max body size is smaller than the request content length", func() {
+			client := &http.Client{Transport: newMaxBodySizeTransport(10, http.DefaultTransport)}
+			_, err := client.Post(server.URL, "application/json", strings.NewReader(requestBody))
+			Convey("Then the request should not be sent and the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEndWith, fmt.Sprintf("the request body for POST %s is 22 bytes which exceeds the max body size allowed (10 bytes). The max body size can be configured with the 'max_body_size' property in the provider or in the plugin configuration file", server.URL))
+				So(requestsReceived, ShouldEqual, 0)
+			})
+		})
+		Convey("When a request without content length is performed with a client which max body size is smaller than the request body", func() {
+			client := &http.Client{Transport: newMaxBodySizeTransport(10, http.DefaultTransport)}
+			req, err := http.NewRequest(http.MethodPost, server.URL, ioutil.NopCloser(strings.NewReader(requestBody)))
+			So(err, ShouldBeNil)
+			So(req.ContentLength, ShouldEqual, 0)
+			req.ContentLength = -1
+			_, err = client.Do(req)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "exceeds the max body size allowed (10 bytes)")
+			})
+		})
+	})
+}
+
+func TestMaxBodySizeTransport(t *testing.T) {
+	Convey("Given a server that returns a response body of 10 bytes with and without content length", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/chunked" {
+				w.(http.Flusher).Flush() // forces the response to be sent without content length
+			}
+			w.Write([]byte(`"12345678"`))
+		}))
+		defer server.Close()
+		Convey("When a request is performed with a client which max body size is greater or equal than the response size", func() {
+			client := &http.Client{Transport: newMaxBodySizeTransport(10, http.DefaultTransport)}
+			resp, err := client.Get(server.URL + "/chunked")
+			So(err, ShouldBeNil)
+			body, err := ioutil.ReadAll(resp.Body)
+			Convey("Then the response body should be read successfully", func() {
+				So(err, ShouldBeNil)
+				So(string(body), ShouldEqual, `"12345678"`)
+			})
+		})
+		Convey("When a request is performed with a client which max body size is smaller than the response content length", func() {
+			client := &http.Client{Transport: newMaxBodySizeTransport(5, http.DefaultTransport)}
+			_, err := client.Get(server.URL)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "is 10 bytes which exceeds the max body size allowed (5 bytes)")
+			})
+		})
+		Convey("When a request is performed with a client which max body size is smaller than the response without content length", func() {
+			client := &http.Client{Transport: newMaxBodySizeTransport(5, http.DefaultTransport)}
+			resp, err := client.Get(server.URL + "/chunked")
+			So(err, ShouldBeNil)
+			_, err = ioutil.ReadAll(resp.Body)
+			Convey("Then the error returned when reading the body should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(strings.HasSuffix(err.Error(), "exceeds the max body size allowed (5 bytes). The max body size can be configured with the 'max_body_size' property in the provider or in the plugin configuration file"), ShouldBeTrue)
+			})
+		})
+		Convey("When newMaxBodySizeTransport is called with the max body size check disabled", func() {
+			transport := newMaxBodySizeTransport(-1, http.DefaultTransport)
+			Convey("Then the transport returned should be the one provided", func() {
+				So(transport, ShouldEqual, http.DefaultTransport)
+			})
+		})
+	})
+}
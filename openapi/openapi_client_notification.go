@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// operationNotificationTimeout is the max time to wait for the notification webhook to respond
+var operationNotificationTimeout = 5 * time.Second
+
+const (
+	operationNotificationResultSuccess = "success"
+	operationNotificationResultFailure = "failure"
+)
+
+// operationNotification is the summary of a mutating resource operation sent to the notification webhook
+type operationNotification struct {
+	// ResourceType is the terraform resource type (e,g: openapi_cdns_v1)
+	ResourceType string `json:"resource_type"`
+	// ResourceID is the ID of the resource instance. It might be empty if the create operation failed before the API
+	// created the resource
+	ResourceID string                     `json:"resource_id"`
+	Operation  TelemetryResourceOperation `json:"operation"`
+	// Result is either 'success' or 'failure'
+	Result string `json:"result"`
+	// Error contains the error message if the operation failed
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Timestamp  string `json:"timestamp"`
+}
+
+// operationNotificationClient is implemented by the clients that support notifying the result of the mutating resource
+// operations (create, update and delete)
+type operationNotificationClient interface {
+	notifyOperation(resourceName, resourceID string, tfOperation TelemetryResourceOperation, duration time.Duration, operationErr error)
+}
+
+// notifyOperation POSTs the summary of the given resource operation to the notification webhook configured in the
+// provider. Notification failures are logged but never fail the terraform operation.
+func (o *ProviderClient) notifyOperation(resourceName, resourceID string, tfOperation TelemetryResourceOperation, duration time.Duration, operationErr error) {
+	webhookURL := o.providerConfiguration.NotificationWebhookURL
+	if webhookURL == "" {
+		return
+	}
+	notification := newOperationNotification(o.providerName, resourceName, resourceID, tfOperation, duration, operationErr)
+	if err := sendOperationNotification(webhookURL, notification); err != nil {
+		log.Printf("[WARN] failed to notify the %s operation of resource '%s' (id: '%s') to the notification webhook: %s", tfOperation, notification.ResourceType, resourceID, err)
+	}
+}
+
+func newOperationNotification(providerName, resourceName, resourceID string, tfOperation TelemetryResourceOperation, duration time.Duration, operationErr error) operationNotification {
+	resourceType := resourceName
+	if providerName != "" {
+		resourceType = fmt.Sprintf("%s_%s", providerName, resourceName)
+	}
+	notification := operationNotification{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Operation:    tfOperation,
+		Result:       operationNotificationResultSuccess,
+		DurationMs:   int64(duration / time.Millisecond),
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+	}
+	if operationErr != nil {
+		notification.Result = operationNotificationResultFailure
+		notification.Error = operationErr.Error()
+	}
+	return notification
+}
+
+func sendOperationNotification(webhookURL string, notification operationNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	httpClient := &http.Client{Timeout: operationNotificationTimeout}
+	resp, err := httpClient.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
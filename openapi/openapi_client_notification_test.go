@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNotifyOperation(t *testing.T) {
+	Convey("Given a provider client configured with a notification webhook", t, func() {
+		var notificationsReceived []operationNotification
+		var methodReceived, contentTypeReceived string
+		webhook := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			methodReceived = req.Method
+			contentTypeReceived = req.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(req.Body)
+			notification := operationNotification{}
+			json.Unmarshal(body, &notification)
+			notificationsReceived = append(notificationsReceived, notification)
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer webhook.Close()
+		providerClient := &ProviderClient{providerName: "openapi", providerConfiguration: providerConfiguration{NotificationWebhookURL: webhook.URL}}
+		Convey("When notifyOperation is called with a successful operation", func() {
+			providerClient.notifyOperation("cdns_v1", "someID", TelemetryResourceOperationCreate, 1500*time.Millisecond, nil)
+			Convey("Then the webhook should receive the summary of the operation", func() {
+				So(methodReceived, ShouldEqual, http.MethodPost)
+				So(contentTypeReceived, ShouldEqual, "application/json")
+				So(notificationsReceived, ShouldHaveLength, 1)
+				So(notificationsReceived[0].ResourceType, ShouldEqual, "openapi_cdns_v1")
+				So(notificationsReceived[0].ResourceID, ShouldEqual, "someID")
+				So(notificationsReceived[0].Operation, ShouldEqual, TelemetryResourceOperationCreate)
+				So(notificationsReceived[0].Result, ShouldEqual, operationNotificationResultSuccess)
+				So(notificationsReceived[0].Error, ShouldBeEmpty)
+				So(notificationsReceived[0].DurationMs, ShouldEqual, 1500)
+				So(notificationsReceived[0].Timestamp, ShouldNotBeEmpty)
+			})
+		})
+		Convey("When notifyOperation is called with a failed operation", func() {
+			providerClient.notifyOperation("cdns_v1", "someID", TelemetryResourceOperationDelete, time.Second, errors.New("some error"))
+			Convey("Then the webhook should receive the failure along with the error", func() {
+				So(notificationsReceived, ShouldHaveLength, 1)
+				So(notificationsReceived[0].Operation, ShouldEqual, TelemetryResourceOperationDelete)
+				So(notificationsReceived[0].Result, ShouldEqual, operationNotificationResultFailure)
+				So(notificationsReceived[0].Error, ShouldEqual, "some error")
+			})
+		})
+	})
+	Convey("Given a provider client configured with a notification webhook that fails", t, func() {
+		webhook := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer webhook.Close()
+		Convey("When sendOperationNotification is called", func() {
+			err := sendOperationNotification(webhook.URL, operationNotification{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the webhook responded with status code 500")
+			})
+		})
+	})
+	Convey("Given a provider client that is not configured with a notification webhook", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When notifyOperation is called", func() {
+			Convey("Then no notification should be sent", func() {
+				So(func() {
+					providerClient.notifyOperation("cdns_v1", "someID", TelemetryResourceOperationCreate, time.Second, nil)
+				}, ShouldNotPanic)
+			})
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+)
+
+// operationTimeoutClient is implemented by the clients that support bounding the resource and data source operations to
+// the operation_timeout configured in the provider, cancelling the in-flight requests, retries and polling once it expires
+type operationTimeoutClient interface {
+	withOperationTimeout() (ClientOpenAPI, context.CancelFunc)
+	getOperationDeadline() (time.Time, bool)
+}
+
+// getClientWithOperationTimeout returns a client bound to the operation_timeout configured in the provider and the
+// function that must be called once the operation completes. If the client does not support it, the given client is
+// returned.
+func getClientWithOperationTimeout(providerClient ClientOpenAPI) (ClientOpenAPI, context.CancelFunc) {
+	if client, ok := providerClient.(operationTimeoutClient); ok {
+		return client.withOperationTimeout()
+	}
+	return providerClient, func() {}
+}
+
+// getPollingTimeout returns the given polling timeout capped to the time left until the operation the client is bound
+// to expires, so the polling does not carry on past the operation_timeout configured in the provider
+func getPollingTimeout(providerClient ClientOpenAPI, timeout time.Duration) time.Duration {
+	client, ok := providerClient.(operationTimeoutClient)
+	if !ok {
+		return timeout
+	}
+	deadline, ok := client.getOperationDeadline()
+	if !ok {
+		return timeout
+	}
+	if remaining := time.Until(deadline); remaining < timeout {
+		return remaining
+	}
+	return timeout
+}
+
+// withOperationTimeout returns a copy of the client which requests are performed with a context that expires once the
+// operation_timeout configured in the provider elapses (counted from now). The context is shared by all the requests
+// performed with the returned client, and the cancel function returned must be called once the operation completes.
+// If the provider is not configured with an operation timeout or the client is already bound to an operation (e,g: the
+// read performed as part of the create), the same client is returned.
+func (o *ProviderClient) withOperationTimeout() (ClientOpenAPI, context.CancelFunc) {
+	timeout := o.providerConfiguration.OperationTimeout
+	if timeout <= 0 || o.ctx != nil {
+		return o, func() {}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	client := *o
+	client.ctx = ctx
+	client.httpClient = withRequestContext(ctx, o.httpClient)
+	return &client, cancel
+}
+
+// getOperationDeadline returns the time the operation the client is bound to expires, if any
+func (o *ProviderClient) getOperationDeadline() (time.Time, bool) {
+	if o.ctx == nil {
+		return time.Time{}, false
+	}
+	return o.ctx.Deadline()
+}
+
+// checkOperationTimeout returns an error if the operation the client is bound to has expired
+func (o *ProviderClient) checkOperationTimeout(method httpMethodSupported, resourceURL string) error {
+	if o.ctx == nil || o.ctx.Err() == nil {
+		return nil
+	}
+	return fmt.Errorf("%s %s cancelled: the operation exceeded the '%s' configured in the provider (%s)", method, resourceURL, providerPropertyOperationTimeout, o.providerConfiguration.OperationTimeout)
+}
+
+// sleep waits for the given duration, returning early with false if the operation the client is bound to expires
+func (o *ProviderClient) sleep(d time.Duration) bool {
+	if o.ctx == nil {
+		time.Sleep(d)
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-o.ctx.Done():
+		return false
+	}
+}
+
+// withRequestContext returns a copy of the given http client which requests are sent with the given context, so they are
+// cancelled once the context expires. The clients that are not backed by a net/http client are returned as is.
+func withRequestContext(ctx context.Context, httpClient http_goclient.HttpClientIface) http_goclient.HttpClientIface {
+	goClient, ok := httpClient.(*http_goclient.HttpClient)
+	if !ok || goClient.HttpClient == nil {
+		return httpClient
+	}
+	netClient := *goClient.HttpClient
+	netClient.Transport = &contextTransport{ctx: ctx, transport: netClient.Transport}
+	contextClient := *goClient
+	contextClient.HttpClient = &netClient
+	return &contextClient
+}
+
+// contextTransport sends the requests with the given context so the in-flight requests are cancelled once the context
+// expires
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(req.WithContext(t.ctx))
+}
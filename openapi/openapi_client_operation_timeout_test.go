@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithOperationTimeout(t *testing.T) {
+	Convey("Given a providerClient not configured with an operation timeout", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When withOperationTimeout is called", func() {
+			client, cancel := providerClient.withOperationTimeout()
+			defer cancel()
+			Convey("Then the same client should be returned", func() {
+				So(client, ShouldEqual, providerClient)
+				_, hasDeadline := providerClient.getOperationDeadline()
+				So(hasDeadline, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a providerClient configured with an operation timeout", t, func() {
+		providerClient := &ProviderClient{
+			httpClient:            &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{OperationTimeout: time.Minute},
+		}
+		Convey("When withOperationTimeout is called", func() {
+			client, cancel := providerClient.withOperationTimeout()
+			defer cancel()
+			Convey("Then the client returned should be bound to an operation expiring once the timeout elapses", func() {
+				deadline, hasDeadline := client.(*ProviderClient).getOperationDeadline()
+				So(hasDeadline, ShouldBeTrue)
+				So(deadline, ShouldHappenWithin, time.Second, time.Now().Add(time.Minute))
+			})
+			Convey("And the original client should not be modified", func() {
+				_, hasDeadline := providerClient.getOperationDeadline()
+				So(hasDeadline, ShouldBeFalse)
+			})
+			Convey("And calling withOperationTimeout on the client returned should return the same client", func() {
+				nestedClient, nestedCancel := client.(*ProviderClient).withOperationTimeout()
+				defer nestedCancel()
+				So(nestedClient, ShouldEqual, client)
+			})
+		})
+	})
+}
+
+func TestProviderClientOperationTimeout(t *testing.T) {
+	Convey("Given an API that takes longer to respond than the operation timeout configured in the provider", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			select {
+			case <-req.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			rw.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration:       providerConfiguration{OperationTimeout: 100 * time.Millisecond},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		Convey("When providerClient GET method is called with a client bound to the operation timeout", func() {
+			client, cancel := providerClient.withOperationTimeout()
+			defer cancel()
+			start := time.Now()
+			_, err := client.Get(&specStubResource{path: "/v1/resource", resourceGetOperation: &specResourceOperation{}}, "1234", nil)
+			Convey("Then the in-flight request should be cancelled once the operation timeout expires", func() {
+				So(err.Error(), ShouldEqual, "GET "+api.URL+"/v1/resource/1234 cancelled: the operation exceeded the 'operation_timeout' configured in the provider (100ms)")
+				So(time.Since(start), ShouldBeLessThan, 2*time.Second)
+			})
+		})
+	})
+	Convey("Given an API that keeps responding with a retryable error", t, func() {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			rw.Write([]byte(`{"message":"service unavailable"}`))
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration:       providerConfiguration{OperationTimeout: 100 * time.Millisecond},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		Convey("When providerClient GET method is called with a client bound to the operation timeout and the retry backoff is longer than the timeout", func() {
+			client, cancel := providerClient.withOperationTimeout()
+			defer cancel()
+			operation := &specResourceOperation{
+				retryableErrors: []specRetryableError{{statusCode: http.StatusServiceUnavailable}},
+				retryBackoff:    &specRetryBackoff{initialBackoff: 10 * time.Second},
+			}
+			start := time.Now()
+			_, err := client.Get(&specStubResource{path: "/v1/resource", resourceGetOperation: operation}, "1234", nil)
+			Convey("Then the retries should be cancelled once the operation timeout expires", func() {
+				So(err.Error(), ShouldEqual, "GET "+api.URL+"/v1/resource/1234 cancelled: the operation exceeded the 'operation_timeout' configured in the provider (100ms)")
+				So(requests, ShouldEqual, 1)
+				So(time.Since(start), ShouldBeLessThan, 2*time.Second)
+			})
+		})
+	})
+	Convey("Given an API which rate limit has been reached", t, func() {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			rw.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		rateLimiters := newRateLimiterRegistry()
+		rateLimiters.get(apiURL.Host, 0).nextRequest = time.Now().Add(10 * time.Second)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration:       providerConfiguration{OperationTimeout: 100 * time.Millisecond},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			rateLimiters:                rateLimiters,
+		}
+		Convey("When providerClient GET method is called with a client bound to the operation timeout and the request is held longer than the timeout", func() {
+			client, cancel := providerClient.withOperationTimeout()
+			defer cancel()
+			start := time.Now()
+			_, err := client.Get(&specStubResource{path: "/v1/resource", resourceGetOperation: &specResourceOperation{}}, "1234", nil)
+			Convey("Then the request should not be sent once the operation timeout expires", func() {
+				So(err.Error(), ShouldEqual, "GET "+api.URL+"/v1/resource/1234 cancelled: the operation exceeded the 'operation_timeout' configured in the provider (100ms)")
+				So(requests, ShouldEqual, 0)
+				So(time.Since(start), ShouldBeLessThan, 2*time.Second)
+			})
+		})
+	})
+}
+
+func TestGetPollingTimeout(t *testing.T) {
+	boundClient, cancel := (&ProviderClient{providerConfiguration: providerConfiguration{OperationTimeout: time.Minute}}).withOperationTimeout()
+	defer cancel()
+	assert.Equal(t, 10*time.Minute, getPollingTimeout(&clientOpenAPIStub{}, 10*time.Minute), "client not supporting operation timeouts")
+	assert.Equal(t, 10*time.Minute, getPollingTimeout(&ProviderClient{}, 10*time.Minute), "client not bound to an operation")
+	assert.InDelta(t, float64(time.Minute), float64(getPollingTimeout(boundClient, 10*time.Minute)), float64(time.Second), "polling timeout longer than the time left")
+	assert.Equal(t, 30*time.Second, getPollingTimeout(boundClient, 30*time.Second), "polling timeout shorter than the time left")
+}
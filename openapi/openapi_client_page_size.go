@@ -0,0 +1,39 @@
+package openapi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// pageSizeClient is implemented by the clients that support overriding the page size configured in the provider (e,g:
+// with the page_size argument of the data sources)
+type pageSizeClient interface {
+	withPageSize(pageSize int) ClientOpenAPI
+}
+
+// withPageSize returns a copy of the client where the list operations request pages of the given size. Not positive
+// values keep the page size configured in the provider
+func (o *ProviderClient) withPageSize(pageSize int) ClientOpenAPI {
+	if pageSize <= 0 {
+		return o
+	}
+	client := *o
+	client.providerConfiguration.PageSize = pageSize
+	return &client
+}
+
+// appendPageSize returns the given list URL including the page size query parameter configured in the operation
+// (x-terraform-pagination-page-size-param). The URL is returned as is if the operation does not support the page size
+// or no page size is configured
+func (o *ProviderClient) appendPageSize(operation *specResourceOperation, resourceURL string) string {
+	pageSize := operation.getPageSize(o.providerConfiguration.PageSize)
+	if pageSize <= 0 {
+		return resourceURL
+	}
+	separator := "?"
+	if strings.Contains(resourceURL, "?") {
+		separator = "&"
+	}
+	return resourceURL + separator + url.Values{operation.pageSizeParam: []string{strconv.Itoa(pageSize)}}.Encode()
+}
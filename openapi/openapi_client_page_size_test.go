@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPageSize(t *testing.T) {
+	testCases := []struct {
+		name             string
+		operation        *specResourceOperation
+		pageSize         int
+		expectedPageSize int
+	}{
+		{name: "nil operation", operation: nil, pageSize: 50, expectedPageSize: 0},
+		{name: "operation that does not support the page size", operation: &specResourceOperation{}, pageSize: 50, expectedPageSize: 0},
+		{name: "page size not configured", operation: &specResourceOperation{pageSizeParam: "limit"}, pageSize: 0, expectedPageSize: 0},
+		{name: "page size without max page size", operation: &specResourceOperation{pageSizeParam: "limit"}, pageSize: 500, expectedPageSize: 500},
+		{name: "page size lower than the max page size", operation: &specResourceOperation{pageSizeParam: "limit", maxPageSize: 100}, pageSize: 50, expectedPageSize: 50},
+		{name: "page size greater than the max page size", operation: &specResourceOperation{pageSizeParam: "limit", maxPageSize: 100}, pageSize: 500, expectedPageSize: 100},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedPageSize, tc.operation.getPageSize(tc.pageSize), tc.name)
+	}
+}
+
+func TestProviderClientListPageSize(t *testing.T) {
+	Convey("Given a provider client configured with a page size and an API that supports requesting the page size", t, func() {
+		var queriesReceived []string
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			queriesReceived = append(queriesReceived, req.URL.RawQuery)
+			if req.URL.Query().Get("page_token") == "" {
+				rw.Header().Set("X-Next-Page-Token", "second")
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`[{"id":"1"}]`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{host: strings.TrimPrefix(api.URL, "http://"), httpScheme: "http"},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newAPIAuthenticator(nil),
+			providerConfiguration:       providerConfiguration{PageSize: 500},
+		}
+		listResource := &specStubResource{
+			name: "resource",
+			path: "/v1/resource",
+			resourceListOperation: &specResourceOperation{
+				pageSizeParam: "limit",
+				maxPageSize:   100,
+				pagination:    &specPagination{style: paginationStyleToken, nextTokenHeader: "X-Next-Page-Token", tokenQueryParam: "page_token"},
+			},
+		}
+		Convey("When listAllPages is called", func() {
+			items, err := listAllPages(providerClient, listResource)
+			Convey("Then all the pages should be requested with the page size capped to the max page size", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 2)
+				So(queriesReceived, ShouldResemble, []string{"limit=100", "limit=100&page_token=second"})
+			})
+		})
+		Convey("When listAllPages is called with a client configured with a different page size", func() {
+			_, err := listAllPages(providerClient.withPageSize(20), listResource)
+			Convey("Then the pages should be requested with the page size of the client", func() {
+				So(err, ShouldBeNil)
+				So(queriesReceived, ShouldResemble, []string{"limit=20", "limit=20&page_token=second"})
+				So(providerClient.providerConfiguration.PageSize, ShouldEqual, 500)
+			})
+		})
+		Convey("When List is called for a resource which list operation does not support the page size", func() {
+			_, err := providerClient.List(&specStubResource{name: "resource", path: "/v1/resource", resourceListOperation: &specResourceOperation{}}, &[]map[string]interface{}{})
+			Convey("Then the page size should not be requested", func() {
+				So(err, ShouldBeNil)
+				So(queriesReceived, ShouldResemble, []string{""})
+			})
+		})
+	})
+}
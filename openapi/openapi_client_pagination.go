@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxListPages is the max number of pages fetched from a paginated list operation, protecting against APIs that keep
+// returning the same next page
+var maxListPages = 1000
+
+// linkHeaderNextRegex matches the URL of the Link header entry with rel="next" (e,g: <https://host/v1/resource?page=2>; rel="next")
+var linkHeaderNextRegex = regexp.MustCompile(`<([^>]*)>[^,]*;\s*rel="?next"?`)
+
+// paginatedListClient is implemented by the clients that support fetching the subsequent pages of the list operations
+// configured with the x-terraform-pagination extension
+type paginatedListClient interface {
+	listNextPage(resource SpecResource, previousResponse *http.Response, responsePayload interface{}, parentIDs ...string) (*http.Response, bool, error)
+}
+
+// listNextPage performs a GET request to fetch the page following the given previous response of the resource list
+// operation. False is returned if the list operation is not paginated or the previous response was the last page.
+func (o *ProviderClient) listNextPage(resource SpecResource, previousResponse *http.Response, responsePayload interface{}, parentIDs ...string) (*http.Response, bool, error) {
+	operation := resource.getResourceOperations().List
+	if operation == nil || operation.pagination == nil || previousResponse == nil {
+		return nil, false, nil
+	}
+	resourceURL, err := o.getResourceURL(resource, operation, parentIDs)
+	if err != nil {
+		return nil, false, err
+	}
+	resourceURL = o.appendPageSize(operation, resourceURL)
+	nextPageURL, err := getNextPageURL(operation.pagination, resourceURL, previousResponse)
+	if err != nil || nextPageURL == "" {
+		return nil, false, err
+	}
+	resp, err := o.performRequest(httpGet, resource.GetResourceName(), nextPageURL, operation, nil, responsePayload)
+	return resp, true, err
+}
+
+// getNextPageURL returns the URL of the page following the given response based on the pagination style. An empty
+// string is returned if there are no more pages.
+func getNextPageURL(pagination *specPagination, resourceURL string, previousResponse *http.Response) (string, error) {
+	switch pagination.style {
+	case paginationStyleLink:
+		match := linkHeaderNextRegex.FindStringSubmatch(strings.Join(previousResponse.Header[http.CanonicalHeaderKey("Link")], ","))
+		if match == nil {
+			return "", nil
+		}
+		baseURL, err := url.Parse(resourceURL)
+		if err != nil {
+			return "", err
+		}
+		nextURL, err := baseURL.Parse(match[1])
+		if err != nil {
+			return "", fmt.Errorf("invalid next page link '%s': %s", match[1], err)
+		}
+		// the request credentials must not be sent to hosts other than the API's one
+		if nextURL.Host != baseURL.Host {
+			return "", fmt.Errorf("next page link '%s' points to a host different than the API's host '%s'", match[1], baseURL.Host)
+		}
+		return nextURL.String(), nil
+	case paginationStyleToken:
+		token := previousResponse.Header.Get(pagination.nextTokenHeader)
+		if token == "" {
+			return "", nil
+		}
+		separator := "?"
+		if strings.Contains(resourceURL, "?") {
+			separator = "&"
+		}
+		return resourceURL + separator + url.Values{pagination.tokenQueryParam: []string{token}}.Encode(), nil
+	}
+	return "", nil
+}
+
+// listAllPages performs the list operation of the given resource and returns the items of all the pages when the
+// operation is paginated (x-terraform-pagination) and the client supports it; otherwise the items of the first page
+// are returned
+func listAllPages(openAPIClient ClientOpenAPI, listResource SpecResource, parentIDs ...string) ([]map[string]interface{}, error) {
+	operation := listResource.getResourceOperations().List
+	successStatusCodes := operation.getSuccessStatusCodes([]int{http.StatusOK})
+
+	items := []map[string]interface{}{}
+	responsePayload := []map[string]interface{}{}
+	resp, err := openAPIClient.List(listResource, &responsePayload, parentIDs...)
+	for page := 1; ; page++ {
+		if err != nil {
+			return nil, err
+		}
+		if err := checkHTTPStatusCode(listResource, resp, successStatusCodes, operation.getErrorParser()); err != nil {
+			return nil, err
+		}
+		items = append(items, responsePayload...)
+
+		client, ok := openAPIClient.(paginatedListClient)
+		if !ok {
+			return items, nil
+		}
+		if page >= maxListPages {
+			log.Printf("[WARN] stopped listing '%s' after reaching the max number of pages (%d)", listResource.GetResourceName(), maxListPages)
+			return items, nil
+		}
+		var hasNextPage bool
+		responsePayload = []map[string]interface{}{}
+		resp, hasNextPage, err = client.listNextPage(listResource, resp, &responsePayload, parentIDs...)
+		if !hasNextPage && err == nil {
+			return items, nil
+		}
+	}
+}
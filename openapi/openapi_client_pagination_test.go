@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNextPageURL(t *testing.T) {
+	linkPagination := &specPagination{style: paginationStyleLink}
+	tokenPagination := &specPagination{style: paginationStyleToken, nextTokenHeader: "X-Next-Page-Token", tokenQueryParam: "page_token"}
+	resourceURL := "http://host.com/v1/resource"
+	testCases := []struct {
+		name            string
+		pagination      *specPagination
+		headers         http.Header
+		expectedNextURL string
+		expectedError   error
+	}{
+		{
+			name:            "link pagination with an absolute next link",
+			pagination:      linkPagination,
+			headers:         http.Header{"Link": []string{`<http://host.com/v1/resource?page=1>; rel="prev", <http://host.com/v1/resource?page=3>; rel="next"`}},
+			expectedNextURL: "http://host.com/v1/resource?page=3",
+		},
+		{
+			name:            "link pagination with a relative next link",
+			pagination:      linkPagination,
+			headers:         http.Header{"Link": []string{`</v1/resource?page=2>; rel=next`}},
+			expectedNextURL: "http://host.com/v1/resource?page=2",
+		},
+		{
+			name:            "link pagination without next link",
+			pagination:      linkPagination,
+			headers:         http.Header{"Link": []string{`<http://host.com/v1/resource?page=1>; rel="prev"`}},
+			expectedNextURL: "",
+		},
+		{
+			name:          "link pagination with a next link pointing to a different host",
+			pagination:    linkPagination,
+			headers:       http.Header{"Link": []string{`<http://other.com/v1/resource?page=2>; rel="next"`}},
+			expectedError: errors.New("next page link 'http://other.com/v1/resource?page=2' points to a host different than the API's host 'host.com'"),
+		},
+		{
+			name:            "token pagination with a next token",
+			pagination:      tokenPagination,
+			headers:         http.Header{"X-Next-Page-Token": []string{"abc=="}},
+			expectedNextURL: "http://host.com/v1/resource?page_token=abc%3D%3D",
+		},
+		{
+			name:            "token pagination without next token",
+			pagination:      tokenPagination,
+			headers:         http.Header{},
+			expectedNextURL: "",
+		},
+	}
+	for _, tc := range testCases {
+		nextURL, err := getNextPageURL(tc.pagination, resourceURL, &http.Response{Header: tc.headers})
+		assert.Equal(t, tc.expectedError, err, tc.name)
+		assert.Equal(t, tc.expectedNextURL, nextURL, tc.name)
+	}
+}
+
+func TestListAllPages(t *testing.T) {
+	newProviderClient := func(api *httptest.Server) *ProviderClient {
+		return &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(nil),
+		}
+	}
+	pages := []string{`[{"id":"1"},{"id":"2"}]`, `[{"id":"3"}]`, `[{"id":"4"}]`}
+
+	Convey("Given an API that paginates the list operation using Link headers", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			page := 0
+			fmt.Sscanf(req.URL.Query().Get("page"), "%d", &page)
+			if page < len(pages)-1 {
+				rw.Header().Set("Link", fmt.Sprintf(`</v1/resource?page=%d>; rel="next"`, page+1))
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(pages[page]))
+		}))
+		defer api.Close()
+		listResource := &specStubResource{name: "resource", path: "/v1/resource", resourceListOperation: &specResourceOperation{pagination: &specPagination{style: paginationStyleLink}}}
+		Convey("When listAllPages is called", func() {
+			items, err := listAllPages(newProviderClient(api), listResource)
+			Convey("Then the items of all the pages should be returned", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}, {"id": "2"}, {"id": "3"}, {"id": "4"}})
+			})
+		})
+		Convey("When listAllPages is called with a max number of pages", func() {
+			defaultMaxListPages := maxListPages
+			maxListPages = 2
+			defer func() { maxListPages = defaultMaxListPages }()
+			items, err := listAllPages(newProviderClient(api), listResource)
+			Convey("Then only the items of the pages up to the max should be returned", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}, {"id": "2"}, {"id": "3"}})
+			})
+		})
+	})
+
+	Convey("Given an API that paginates the list operation using next page tokens", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			page := 0
+			switch req.URL.Query().Get("page_token") {
+			case "second":
+				page = 1
+				rw.Header().Set("X-Next-Page-Token", "third")
+			case "third":
+				page = 2
+			default:
+				rw.Header().Set("X-Next-Page-Token", "second")
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(pages[page]))
+		}))
+		defer api.Close()
+		listResource := &specStubResource{name: "resource", path: "/v1/resource", resourceListOperation: &specResourceOperation{pagination: &specPagination{style: paginationStyleToken, nextTokenHeader: "X-Next-Page-Token", tokenQueryParam: "page_token"}}}
+		Convey("When listAllPages is called", func() {
+			items, err := listAllPages(newProviderClient(api), listResource)
+			Convey("Then the items of all the pages should be returned", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}, {"id": "2"}, {"id": "3"}, {"id": "4"}})
+			})
+		})
+	})
+
+	Convey("Given an API that fails when fetching the second page", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			if req.URL.Query().Get("page") != "" {
+				rw.WriteHeader(http.StatusInternalServerError)
+				rw.Write([]byte(`{"message":"internal error"}`))
+				return
+			}
+			rw.Header().Set("Link", `</v1/resource?page=1>; rel="next"`)
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(pages[0]))
+		}))
+		defer api.Close()
+		listResource := &specStubResource{name: "resource", path: "/v1/resource", resourceListOperation: &specResourceOperation{pagination: &specPagination{style: paginationStyleLink}}}
+		Convey("When listAllPages is called", func() {
+			_, err := listAllPages(newProviderClient(api), listResource)
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a client that does not support pagination", t, func() {
+		client := &clientOpenAPIStub{responseListPayload: []map[string]interface{}{{"id": "1"}}}
+		listResource := &specStubResource{name: "resource", path: "/v1/resource", resourceListOperation: &specResourceOperation{pagination: &specPagination{style: paginationStyleLink}}}
+		Convey("When listAllPages is called", func() {
+			items, err := listAllPages(client, listResource)
+			Convey("Then the items of the first page should be returned", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}})
+			})
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dikhan/http_goclient"
+)
+
+// preDeleteClient is implemented by the clients that support performing the request the API requires before a resource
+// instance can be deleted (x-terraform-pre-delete-method)
+type preDeleteClient interface {
+	preDelete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error)
+}
+
+// preDelete performs the pre-delete request configured in the resource DELETE operation for the resource instance id
+// passed in
+func (o *ProviderClient) preDelete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Delete
+	if operation == nil || operation.preDelete == nil {
+		return nil, errors.New("resource does not support pre-delete requests")
+	}
+	resourceURL, err := o.getPreDeleteURL(resource, operation, id, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpMethodSupported(operation.preDelete.method), resource.GetResourceName(), resourceURL, operation, operation.preDelete.payload, nil)
+}
+
+// getPreDeleteURL returns the URL of the pre-delete request, which is the resource instance URL unless a path is configured
+func (o *ProviderClient) getPreDeleteURL(resource SpecResource, operation *specResourceOperation, id string, parentIDs []string) (string, error) {
+	if operation.preDelete.path == "" {
+		return o.getResourceIDURL(resource, operation, parentIDs, id)
+	}
+	pathParameters := pathParameterPlaceholderRegex.FindAllString(operation.preDelete.path, -1)
+	ids := append(append([]string{}, parentIDs...), id)
+	if len(pathParameters) != len(ids) {
+		return "", fmt.Errorf("could not resolve the pre-delete path '%s': the number of path parameters does not match the number of parent IDs and instance ID %v", operation.preDelete.path, ids)
+	}
+	path := operation.preDelete.path
+	for i, pathParameter := range pathParameters {
+		path = strings.Replace(path, pathParameter, ids[i], 1)
+	}
+	return o.getURL(resource, operation, path)
+}
+
+// sendJSONRequest sends a request with the given method, headers and JSON payload without decoding the response body,
+// which is left to the caller. These requests are only supported by the http clients backed by a net/http client
+func (o *ProviderClient) sendJSONRequest(method, url string, headers map[string]string, requestPayload interface{}) (*http.Response, error) {
+	goClient, ok := o.httpClient.(*http_goclient.HttpClient)
+	if !ok || goClient.HttpClient == nil {
+		return nil, fmt.Errorf("%s requests are not supported by the http client", method)
+	}
+	var body []byte
+	if requestPayload != nil {
+		var err error
+		if body, err = json.Marshal(requestPayload); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for headerName, headerValue := range headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	return goClient.HttpClient.Do(req)
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderClientPreDelete(t *testing.T) {
+	Convey("Given a provider client and an API that requires disabling the resources before deleting them", t, func() {
+		var methodReceived, pathReceived, bodyReceived, authHeaderReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			methodReceived = req.Method
+			pathReceived = req.URL.Path
+			authHeaderReceived = req.Header.Get("Authentication")
+			body, _ := ioutil.ReadAll(req.Body)
+			bodyReceived = string(body)
+			rw.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "/", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		Convey("When providerClient preDelete method is called for a resource configured with a PATCH pre-delete request", func() {
+			resource := &specStubResource{
+				path:                    "/v1/resource",
+				resourceDeleteOperation: &specResourceOperation{preDelete: &specPreDelete{method: http.MethodPatch, payload: map[string]interface{}{"status": "disabled"}}},
+			}
+			resp, err := providerClient.preDelete(resource, "1234")
+			Convey("Then the PATCH request should be sent to the resource instance path with the payload and the auth headers", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(methodReceived, ShouldEqual, http.MethodPatch)
+				So(pathReceived, ShouldEqual, "/v1/resource/1234")
+				So(bodyReceived, ShouldEqual, `{"status":"disabled"}`)
+				So(authHeaderReceived, ShouldEqual, "Bearer secret!")
+			})
+		})
+		Convey("When providerClient preDelete method is called for a sub-resource configured with a POST pre-delete request to a different path", func() {
+			resource := &specStubResource{
+				path:                    "/v1/cdns/parentID/firewalls",
+				resourceDeleteOperation: &specResourceOperation{preDelete: &specPreDelete{method: http.MethodPost, path: "/v1/cdns/{cdn_id}/firewalls/{id}/disable"}},
+			}
+			_, err := providerClient.preDelete(resource, "1234", "parentID")
+			Convey("Then the POST request should be sent to the pre-delete path resolved with the parent and instance IDs", func() {
+				So(err, ShouldBeNil)
+				So(methodReceived, ShouldEqual, http.MethodPost)
+				So(pathReceived, ShouldEqual, "/v1/cdns/parentID/firewalls/1234/disable")
+			})
+		})
+		Convey("When providerClient preDelete method is called for a resource configured with a pre-delete path that can not be resolved", func() {
+			resource := &specStubResource{
+				path:                    "/v1/resource",
+				resourceDeleteOperation: &specResourceOperation{preDelete: &specPreDelete{method: http.MethodPost, path: "/v1/resource/{parent_id}/{id}/disable"}},
+			}
+			_, err := providerClient.preDelete(resource, "1234")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "could not resolve the pre-delete path '/v1/resource/{parent_id}/{id}/disable': the number of path parameters does not match the number of parent IDs and instance ID [1234]")
+			})
+		})
+		Convey("When providerClient preDelete method is called for a resource that is not configured with a pre-delete request", func() {
+			_, err := providerClient.preDelete(&specStubResource{path: "/v1/resource", resourceDeleteOperation: &specResourceOperation{}}, "1234")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource does not support pre-delete requests")
+			})
+		})
+	})
+}
@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const rateLimitRemainingHeader = "X-RateLimit-Remaining"
+const rateLimitResetHeader = "X-RateLimit-Reset"
+const retryAfterHeader = "Retry-After"
+
+// rateLimitResetTimestampThreshold is the X-RateLimit-Reset value from which the value is considered a unix timestamp
+// instead of the number of seconds left until the rate limit window resets
+const rateLimitResetTimestampThreshold = 1000000000
+
+// rateLimitMaxWait is the max time the requests are held when the API signals that the rate limit has been reached
+var rateLimitMaxWait = 60 * time.Second
+
+// rateLimitBackendConfiguration is implemented by the backend configurations that support documenting the rate limits
+// of the API (x-ratelimit-limit and x-ratelimit-period)
+type rateLimitBackendConfiguration interface {
+	getRateLimit() (int, time.Duration, error)
+}
+
+// rateLimiter throttles the requests sent to the API so the rate limits are not exceeded. The requests are spaced out
+// based on the rate limit documented in the OpenAPI document, and held until the rate limit window resets when the API
+// responds with no remaining requests (X-RateLimit-Remaining and X-RateLimit-Reset headers).
+type rateLimiter struct {
+	mu sync.Mutex
+	// interval is the min time between requests. Zero means the requests are not spaced out
+	interval time.Duration
+	// nextRequest is the time from which the next request is allowed
+	nextRequest time.Time
+}
+
+// rateLimiterRegistry holds the rate limiters of the API hosts. The same registry is used by all the provider instances
+// configured in the plugin process (e,g: aliased provider blocks pointing at the same host), so the requests sent to a
+// host are throttled together and the global API quotas are respected.
+type rateLimiterRegistry struct {
+	mu       sync.Mutex
+	limiters map[string]*rateLimiter
+}
+
+// sharedRateLimiters is the rate limiter registry shared by the provider instances configured in the plugin process
+var sharedRateLimiters = newRateLimiterRegistry()
+
+func newRateLimiterRegistry() *rateLimiterRegistry {
+	return &rateLimiterRegistry{limiters: map[string]*rateLimiter{}}
+}
+
+// get returns the rate limiter for the given host, creating it if it does not exist yet. If the limiter already exists
+// with a shorter interval (e,g: provider instances configured with different OpenAPI documents for the same host) the
+// longest interval is kept so the strictest rate limit is honored.
+func (r *rateLimiterRegistry) get(host string, interval time.Duration) *rateLimiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limiter, exists := r.limiters[host]
+	if !exists {
+		limiter = &rateLimiter{interval: interval}
+		r.limiters[host] = limiter
+		return limiter
+	}
+	limiter.mu.Lock()
+	if interval > limiter.interval {
+		limiter.interval = interval
+	}
+	limiter.mu.Unlock()
+	return limiter
+}
+
+// getRateLimitInterval returns the min time between requests based on the rate limit documented in the given backend
+// configuration. Zero is returned if the rate limit is not documented
+func getRateLimitInterval(backendConfiguration SpecBackendConfiguration) (time.Duration, error) {
+	configuration, ok := backendConfiguration.(rateLimitBackendConfiguration)
+	if !ok {
+		return 0, nil
+	}
+	limit, period, err := configuration.getRateLimit()
+	if err != nil {
+		return 0, err
+	}
+	if limit <= 0 {
+		return 0, nil
+	}
+	log.Printf("[INFO] the API requests will be limited to %d per %s as documented in the OpenAPI document", limit, period)
+	return period / time.Duration(limit), nil
+}
+
+// getRateLimitHost returns the host the requests sent to the given URL are throttled by. If the URL can not be parsed
+// the URL itself is used
+func getRateLimitHost(requestURL string) string {
+	u, err := url.Parse(requestURL)
+	if err != nil || u.Host == "" {
+		return requestURL
+	}
+	return u.Host
+}
+
+// wait blocks using the given sleep function until the next request is allowed and books the following slot. False is
+// returned if the sleep function returns early (e,g: the operation the request belongs to has timed out)
+func (l *rateLimiter) wait(sleep func(time.Duration) bool) bool {
+	l.mu.Lock()
+	now := time.Now()
+	if l.nextRequest.Before(now) {
+		l.nextRequest = now
+	}
+	waitTime := l.nextRequest.Sub(now)
+	l.nextRequest = l.nextRequest.Add(l.interval)
+	l.mu.Unlock()
+	if waitTime > 0 {
+		log.Printf("[DEBUG] holding the request for %s to honor the API rate limit", waitTime)
+		return sleep(waitTime)
+	}
+	return true
+}
+
+// update holds the next requests until the rate limit window resets if the given response signals there are no
+// requests remaining, or until the time specified in the Retry-After header if the API responded with 429 Too Many
+// Requests
+func (l *rateLimiter) update(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	now := time.Now()
+	resetTime, limited := getRateLimitResetTime(resp.Header, now)
+	if !limited && resp.StatusCode == http.StatusTooManyRequests {
+		resetTime, limited = getRetryAfterTime(resp.Header, now)
+	}
+	if !limited {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if resetTime.After(l.nextRequest) {
+		l.nextRequest = resetTime
+	}
+}
+
+// getRateLimitResetTime returns the time the rate limit window resets if the given headers signal there are no requests
+// remaining. The reset value can either be the number of seconds left or a unix timestamp, and the time returned is
+// capped to rateLimitMaxWait from now.
+func getRateLimitResetTime(header http.Header, now time.Time) (time.Time, bool) {
+	remaining, err := strconv.Atoi(strings.TrimSpace(header.Get(rateLimitRemainingHeader)))
+	if err != nil || remaining > 0 {
+		return time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(strings.TrimSpace(header.Get(rateLimitResetHeader)), 10, 64)
+	if err != nil || reset <= 0 {
+		return time.Time{}, false
+	}
+	resetTime := now.Add(time.Duration(reset) * time.Second)
+	if reset >= rateLimitResetTimestampThreshold {
+		resetTime = time.Unix(reset, 0)
+	}
+	if !resetTime.After(now) {
+		return time.Time{}, false
+	}
+	if maxResetTime := now.Add(rateLimitMaxWait); resetTime.After(maxResetTime) {
+		resetTime = maxResetTime
+	}
+	return resetTime, true
+}
+
+// getRetryAfterTime returns the time specified in the Retry-After header, which can either be the number of seconds to
+// wait or an HTTP date. The time returned is capped to rateLimitMaxWait from now.
+func getRetryAfterTime(header http.Header, now time.Time) (time.Time, bool) {
+	value := strings.TrimSpace(header.Get(retryAfterHeader))
+	if value == "" {
+		return time.Time{}, false
+	}
+	var retryTime time.Time
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		retryTime = now.Add(time.Duration(seconds) * time.Second)
+	} else if date, err := http.ParseTime(value); err == nil {
+		retryTime = date
+	} else {
+		return time.Time{}, false
+	}
+	if !retryTime.After(now) {
+		return time.Time{}, false
+	}
+	if maxRetryTime := now.Add(rateLimitMaxWait); retryTime.After(maxRetryTime) {
+		retryTime = maxRetryTime
+	}
+	return retryTime, true
+}
@@ -0,0 +1,301 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/go-openapi/spec"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRateLimitInterval(t *testing.T) {
+	Convey("Given a backend configuration documenting the API rate limit", t, func() {
+		backendConfiguration := &specV2BackendConfiguration{
+			spec: &spec.Swagger{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extRateLimitLimit: float64(10), extRateLimitPeriod: float64(2)}}},
+		}
+		Convey("When getRateLimitInterval is called", func() {
+			interval, err := getRateLimitInterval(backendConfiguration)
+			Convey("Then the interval returned should space out the requests based on the rate limit", func() {
+				So(err, ShouldBeNil)
+				So(interval, ShouldEqual, 200*time.Millisecond)
+			})
+		})
+	})
+	Convey("Given a backend configuration documenting an invalid rate limit", t, func() {
+		backendConfiguration := &specV2BackendConfiguration{
+			spec: &spec.Swagger{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extRateLimitLimit: "ten"}}},
+		}
+		Convey("When getRateLimitInterval is called", func() {
+			_, err := getRateLimitInterval(backendConfiguration)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "invalid 'x-ratelimit-limit' extension value 'ten': the value must be a positive integer")
+			})
+		})
+	})
+	Convey("Given a backend configuration that does not support documenting rate limits", t, func() {
+		backendConfiguration := &specStubBackendConfiguration{}
+		Convey("When getRateLimitInterval is called", func() {
+			interval, err := getRateLimitInterval(backendConfiguration)
+			Convey("Then the interval returned should not space out the requests", func() {
+				So(err, ShouldBeNil)
+				So(interval, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestRateLimiterRegistryGet(t *testing.T) {
+	Convey("Given a rate limiter registry", t, func() {
+		registry := newRateLimiterRegistry()
+		Convey("When get is called several times for the same host", func() {
+			limiter1 := registry.get("api.example.com", 100*time.Millisecond)
+			limiter2 := registry.get("api.example.com", 200*time.Millisecond)
+			limiter3 := registry.get("api.example.com", 50*time.Millisecond)
+			Convey("Then the same rate limiter should be returned keeping the longest interval", func() {
+				So(limiter2, ShouldEqual, limiter1)
+				So(limiter3, ShouldEqual, limiter1)
+				So(limiter1.interval, ShouldEqual, 200*time.Millisecond)
+			})
+		})
+		Convey("When get is called for different hosts", func() {
+			limiter1 := registry.get("api.example.com", 100*time.Millisecond)
+			limiter2 := registry.get("other.example.com", 100*time.Millisecond)
+			Convey("Then different rate limiters should be returned", func() {
+				So(limiter2, ShouldNotEqual, limiter1)
+			})
+		})
+	})
+}
+
+func TestGetRateLimitHost(t *testing.T) {
+	assert.Equal(t, "api.example.com", getRateLimitHost("https://api.example.com/v1/cdns/1234"))
+	assert.Equal(t, "127.0.0.1:8080", getRateLimitHost("http://127.0.0.1:8080/v1/cdns"))
+	assert.Equal(t, "not a url", getRateLimitHost("not a url"))
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	Convey("Given a rate limiter configured with an interval", t, func() {
+		limiter := &rateLimiter{interval: 20 * time.Millisecond}
+		sleep := (&ProviderClient{}).sleep
+		Convey("When wait is called several times", func() {
+			start := time.Now()
+			So(limiter.wait(sleep), ShouldBeTrue)
+			So(limiter.wait(sleep), ShouldBeTrue)
+			So(limiter.wait(sleep), ShouldBeTrue)
+			Convey("Then the calls should be spaced out by the interval", func() {
+				So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 40*time.Millisecond)
+			})
+		})
+		Convey("When wait is called with a sleep function that returns early", func() {
+			So(limiter.wait(sleep), ShouldBeTrue)
+			waited := limiter.wait(func(time.Duration) bool { return false })
+			Convey("Then wait should return false", func() {
+				So(waited, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a rate limiter updated with a response signaling that there are no requests remaining", t, func() {
+		defaultRateLimitMaxWait := rateLimitMaxWait
+		rateLimitMaxWait = 30 * time.Millisecond
+		defer func() { rateLimitMaxWait = defaultRateLimitMaxWait }()
+		limiter := &rateLimiter{}
+		limiter.update(&http.Response{Header: newRateLimitHeaders("0", "10")})
+		Convey("When wait is called", func() {
+			start := time.Now()
+			limiter.wait((&ProviderClient{}).sleep)
+			Convey("Then the call should be held until the rate limit resets (capped to the max wait)", func() {
+				So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 30*time.Millisecond)
+			})
+		})
+	})
+}
+
+// newRateLimitHeaders returns the headers the API responds with to signal the rate limit status. The headers are set
+// with the canonical key as they would be when received from the API
+func newRateLimitHeaders(remaining, reset string) http.Header {
+	header := http.Header{}
+	header.Set(rateLimitRemainingHeader, remaining)
+	if reset != "" {
+		header.Set(rateLimitResetHeader, reset)
+	}
+	return header
+}
+
+func TestGetRateLimitResetTime(t *testing.T) {
+	defaultRateLimitMaxWait := rateLimitMaxWait
+	rateLimitMaxWait = time.Minute
+	defer func() { rateLimitMaxWait = defaultRateLimitMaxWait }()
+	now := time.Unix(1600000000, 0)
+	testCases := []struct {
+		name              string
+		headers           http.Header
+		expectedResetTime time.Time
+		expectedLimited   bool
+	}{
+		{
+			name:            "no rate limit headers",
+			headers:         http.Header{},
+			expectedLimited: false,
+		},
+		{
+			name:            "requests remaining",
+			headers:         newRateLimitHeaders("5", "10"),
+			expectedLimited: false,
+		},
+		{
+			name:            "no requests remaining without reset header",
+			headers:         newRateLimitHeaders("0", ""),
+			expectedLimited: false,
+		},
+		{
+			name:              "no requests remaining with reset in seconds",
+			headers:           newRateLimitHeaders("0", "10"),
+			expectedResetTime: now.Add(10 * time.Second),
+			expectedLimited:   true,
+		},
+		{
+			name:              "no requests remaining with reset as unix timestamp",
+			headers:           newRateLimitHeaders("0", strconv.FormatInt(now.Unix()+30, 10)),
+			expectedResetTime: now.Add(30 * time.Second),
+			expectedLimited:   true,
+		},
+		{
+			name:            "no requests remaining with reset as unix timestamp in the past",
+			headers:         newRateLimitHeaders("0", strconv.FormatInt(now.Unix()-30, 10)),
+			expectedLimited: false,
+		},
+		{
+			name:              "no requests remaining with reset exceeding the max wait",
+			headers:           newRateLimitHeaders("0", "3600"),
+			expectedResetTime: now.Add(time.Minute),
+			expectedLimited:   true,
+		},
+	}
+	for _, tc := range testCases {
+		resetTime, limited := getRateLimitResetTime(tc.headers, now)
+		assert.Equal(t, tc.expectedLimited, limited, tc.name)
+		assert.True(t, tc.expectedResetTime.Equal(resetTime), tc.name)
+	}
+}
+
+func TestGetRetryAfterTime(t *testing.T) {
+	defaultRateLimitMaxWait := rateLimitMaxWait
+	rateLimitMaxWait = time.Minute
+	defer func() { rateLimitMaxWait = defaultRateLimitMaxWait }()
+	now := time.Unix(1600000000, 0)
+	testCases := []struct {
+		name              string
+		retryAfter        string
+		expectedRetryTime time.Time
+		expectedLimited   bool
+	}{
+		{
+			name:            "no retry after header",
+			retryAfter:      "",
+			expectedLimited: false,
+		},
+		{
+			name:              "retry after in seconds",
+			retryAfter:        "10",
+			expectedRetryTime: now.Add(10 * time.Second),
+			expectedLimited:   true,
+		},
+		{
+			name:              "retry after as http date",
+			retryAfter:        now.Add(20 * time.Second).UTC().Format(http.TimeFormat),
+			expectedRetryTime: now.Add(20 * time.Second),
+			expectedLimited:   true,
+		},
+		{
+			name:            "retry after as http date in the past",
+			retryAfter:      now.Add(-20 * time.Second).UTC().Format(http.TimeFormat),
+			expectedLimited: false,
+		},
+		{
+			name:              "retry after exceeding the max wait",
+			retryAfter:        "3600",
+			expectedRetryTime: now.Add(time.Minute),
+			expectedLimited:   true,
+		},
+		{
+			name:            "invalid retry after",
+			retryAfter:      "soon",
+			expectedLimited: false,
+		},
+	}
+	for _, tc := range testCases {
+		header := http.Header{}
+		if tc.retryAfter != "" {
+			header.Set(retryAfterHeader, tc.retryAfter)
+		}
+		retryTime, limited := getRetryAfterTime(header, now)
+		assert.Equal(t, tc.expectedLimited, limited, tc.name)
+		assert.True(t, tc.expectedRetryTime.Equal(retryTime), tc.name)
+	}
+}
+
+func TestRateLimiterUpdateWithRetryAfter(t *testing.T) {
+	Convey("Given a rate limiter", t, func() {
+		limiter := &rateLimiter{}
+		header := http.Header{}
+		header.Set(retryAfterHeader, "10")
+		Convey("When update is called with a 429 response containing the Retry-After header", func() {
+			limiter.update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: header})
+			Convey("Then the next requests should be held until the retry time", func() {
+				So(limiter.nextRequest, ShouldHappenAfter, time.Now().Add(9*time.Second))
+			})
+		})
+		Convey("When update is called with a non 429 response containing the Retry-After header", func() {
+			limiter.update(&http.Response{StatusCode: http.StatusServiceUnavailable, Header: header})
+			Convey("Then the next requests should not be held", func() {
+				So(limiter.nextRequest.IsZero(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestSendRequestWithRateLimiter(t *testing.T) {
+	defaultRateLimitMaxWait := rateLimitMaxWait
+	rateLimitMaxWait = 30 * time.Millisecond
+	defer func() { rateLimitMaxWait = defaultRateLimitMaxWait }()
+	Convey("Given an API that signals there are no requests remaining", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.Header().Set(rateLimitRemainingHeader, "0")
+			rw.Header().Set(rateLimitResetHeader, "1")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`{"id":"someID"}`))
+		}))
+		defer api.Close()
+		rateLimiters := newRateLimiterRegistry()
+		providerClient := &ProviderClient{httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}}, rateLimiters: rateLimiters}
+		Convey("When sendRequestWithRetries is called twice", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, &specResourceOperation{}, nil, &responsePayload)
+			So(err, ShouldBeNil)
+			start := time.Now()
+			_, err = providerClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, &specResourceOperation{}, nil, &responsePayload)
+			Convey("Then the second request should be held until the rate limit resets", func() {
+				So(err, ShouldBeNil)
+				So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 30*time.Millisecond)
+			})
+		})
+		Convey("When sendRequestWithRetries is called by another client sharing the rate limiters (e,g: an aliased provider)", func() {
+			otherProviderClient := &ProviderClient{httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}}, rateLimiters: rateLimiters}
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, &specResourceOperation{}, nil, &responsePayload)
+			So(err, ShouldBeNil)
+			start := time.Now()
+			_, err = otherProviderClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, &specResourceOperation{}, nil, &responsePayload)
+			Convey("Then the request of the other client should also be held until the rate limit resets", func() {
+				So(err, ShouldBeNil)
+				So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 30*time.Millisecond)
+			})
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// batchReadWindow is the time the first read of a batch waits for other reads of the same resource type (and parent) to
+// join the batch before calling the list operation filtered by the IDs in the batch
+var batchReadWindow = 100 * time.Millisecond
+
+// batchReadClient is implemented by the clients that support coalescing the reads of the resource instances performed
+// during the refresh into list calls filtered by the instance IDs (x-terraform-batch-read-ids-param)
+type batchReadClient interface {
+	batchGet(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+}
+
+// readBatch is a group of resource instances read with a single call to the list operation. The items returned are
+// shared by all the reads in the batch.
+type readBatch struct {
+	ids  []string
+	full chan struct{}
+	done chan struct{}
+	// header and items (encoded payloads indexed by ID) are the response of the list operation, set once done is closed
+	header http.Header
+	items  map[string][]byte
+	err    error
+}
+
+// readBatcher groups the reads of the same resource type (and parent) received within the batch window
+type readBatcher struct {
+	mu      sync.Mutex
+	pending map[string]*readBatch
+}
+
+func newReadBatcher() *readBatcher {
+	return &readBatcher{pending: map[string]*readBatch{}}
+}
+
+// add adds the given id to the batch pending for the given key, starting a new batch if there is none. The leader
+// returned is true for the read that started the batch, which is responsible for calling the list operation.
+func (r *readBatcher) add(key, id string, maxBatchSize int) (*readBatch, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	batch, exists := r.pending[key]
+	if !exists {
+		batch = &readBatch{full: make(chan struct{}), done: make(chan struct{})}
+		r.pending[key] = batch
+	}
+	batch.ids = append(batch.ids, id)
+	if len(batch.ids) >= maxBatchSize {
+		delete(r.pending, key)
+		close(batch.full)
+	}
+	return batch, !exists
+}
+
+// seal closes the given batch so no more ids are added to it and returns the ids in the batch
+func (r *readBatcher) seal(key string, batch *readBatch) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending[key] == batch {
+		delete(r.pending, key)
+	}
+	return batch.ids
+}
+
+// batchGet reads the resource instance as part of a batch of reads of the same resource type and parent, calling the
+// list operation filtered by the IDs in the batch once the batch is full or the batch window has elapsed. The instance
+// is read with a regular GET request instead if the resource does not support batch reads, the list call fails or the
+// instance is not part of the items returned (e,g: it no longer exists or the API ignored the filter), so a missing
+// item never results in the resource being removed from the state without confirmation.
+func (o *ProviderClient) batchGet(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().List
+	if !o.supportsBatchRead(resource) {
+		return o.Get(resource, id, responsePayload, parentIDs...)
+	}
+	listURL, err := o.getResourceURL(resource, operation, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	// the reads are only batched with reads sent with the same headers, as the resource header attributes may override
+	// the values configured in the provider
+	key := fmt.Sprintf("%s_%s %s %v %v", o.providerName, resource.GetResourceName(), listURL, o.providerConfiguration.Headers, o.providerConfiguration.IdentityHeaders)
+	batch, leader := o.readBatcher.add(key, id, operation.batchRead.maxBatchSize)
+	if leader {
+		select {
+		case <-batch.full:
+		case <-time.After(batchReadWindow):
+		}
+		ids := o.readBatcher.seal(key, batch)
+		log.Printf("[INFO] reading %d instances of resource '%s' calling the list operation %s", len(ids), resource.GetResourceName(), listURL)
+		batch.header, batch.items, batch.err = o.listBatch(resource, operation, listURL, ids)
+		close(batch.done)
+	}
+	<-batch.done
+	if batch.err != nil {
+		log.Printf("[WARN] batch read of resource '%s' failed, reading instance '%s' individually: %s", resource.GetResourceName(), id, batch.err)
+		return o.Get(resource, id, responsePayload, parentIDs...)
+	}
+	item, found := batch.items[id]
+	if !found {
+		log.Printf("[DEBUG] instance '%s' of resource '%s' not returned by the batch read, reading it individually", id, resource.GetResourceName())
+		return o.Get(resource, id, responsePayload, parentIDs...)
+	}
+	if err := json.Unmarshal(item, responsePayload); err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     batch.header,
+		Body:       ioutil.NopCloser(bytes.NewReader(item)),
+	}, nil
+}
+
+// supportsBatchRead returns true if the reads of the given resource can be batched. The resources which instances are
+// updated or deleted with conditional requests are read individually so the Last-Modified of each instance is recorded
+func (o *ProviderClient) supportsBatchRead(resource SpecResource) bool {
+	if o.readBatcher == nil {
+		return false
+	}
+	operations := resource.getResourceOperations()
+	if operations.List == nil || operations.List.batchRead == nil {
+		return false
+	}
+	for _, operation := range []*specResourceOperation{operations.Put, operations.Delete} {
+		if operation != nil && operation.conditionalRequestEnabled {
+			return false
+		}
+	}
+	return true
+}
+
+// listBatch calls the list operation filtered by the given IDs and returns the response headers along with the items
+// returned (encoded) indexed by ID
+func (o *ProviderClient) listBatch(resource SpecResource, operation *specResourceOperation, listURL string, ids []string) (http.Header, map[string][]byte, error) {
+	separator := "?"
+	if strings.Contains(listURL, "?") {
+		separator = "&"
+	}
+	batchURL := listURL + separator + url.Values{operation.batchRead.idsParam: []string{strings.Join(ids, ",")}}.Encode()
+	responsePayload := []map[string]interface{}{}
+	resp, err := o.performRequest(httpGet, resource.GetResourceName(), batchURL, operation, nil, &responsePayload)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkHTTPStatusCode(resource, resp, operation.getSuccessStatusCodes([]int{http.StatusOK}), operation.getErrorParser()); err != nil {
+		return nil, nil, err
+	}
+	items := map[string][]byte{}
+	for _, item := range responsePayload {
+		id, err := getPayloadID(resource, item)
+		if err != nil {
+			return nil, nil, err
+		}
+		encodedItem, err := json.Marshal(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		items[id] = encodedItem
+	}
+	return resp.Header, items, nil
+}
@@ -0,0 +1,131 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadBatcher(t *testing.T) {
+	batcher := newReadBatcher()
+	batch, leader := batcher.add("provider_cdn_v1", "1", 2)
+	assert.True(t, leader)
+	sameBatch, leader := batcher.add("provider_cdn_v1", "2", 2)
+	assert.False(t, leader)
+	assert.Equal(t, batch, sameBatch)
+	select {
+	case <-batch.full:
+	default:
+		assert.Fail(t, "the batch should be full once it reaches the max batch size")
+	}
+	_, leader = batcher.add("provider_cdn_v1", "3", 2)
+	assert.True(t, leader, "a new batch should be started once the previous one is full")
+	assert.Equal(t, []string{"1", "2"}, batcher.seal("provider_cdn_v1", batch))
+}
+
+func TestProviderClientBatchGet(t *testing.T) {
+	defaultBatchReadWindow := batchReadWindow
+	batchReadWindow = 100 * time.Millisecond
+	defer func() { batchReadWindow = defaultBatchReadWindow }()
+	Convey("Given an API which list operation supports filtering by IDs", t, func() {
+		var mu sync.Mutex
+		var requests []string
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			requests = append(requests, req.URL.Path)
+			mu.Unlock()
+			rw.Header().Set("Content-Type", "application/json")
+			if req.URL.Path == "/v1/resource" {
+				items := []map[string]interface{}{}
+				for _, id := range strings.Split(req.URL.Query().Get("ids"), ",") {
+					// the instance 3 no longer exists
+					if id != "3" {
+						items = append(items, map[string]interface{}{"id": id, "label": "label" + id})
+					}
+				}
+				json.NewEncoder(rw).Encode(items)
+				return
+			}
+			if req.URL.Path == "/v1/resource/3" {
+				rw.WriteHeader(http.StatusNotFound)
+				rw.Write([]byte(`{"message":"not found"}`))
+				return
+			}
+			json.NewEncoder(rw).Encode(map[string]interface{}{"id": strings.TrimPrefix(req.URL.Path, "/v1/resource/"), "label": "label"})
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			readBatcher:                 newReadBatcher(),
+		}
+		resource := newSpecStubResource("resource", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{idProperty, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil)},
+		})
+		resource.resourceGetOperation = &specResourceOperation{}
+		readInstances := func(ids ...string) ([]map[string]interface{}, []*http.Response, []error) {
+			payloads := make([]map[string]interface{}, len(ids))
+			responses := make([]*http.Response, len(ids))
+			errs := make([]error, len(ids))
+			var wg sync.WaitGroup
+			for i, id := range ids {
+				wg.Add(1)
+				go func(i int, id string) {
+					defer wg.Done()
+					payloads[i] = map[string]interface{}{}
+					responses[i], errs[i] = providerClient.batchGet(resource, id, &payloads[i])
+				}(i, id)
+			}
+			wg.Wait()
+			return payloads, responses, errs
+		}
+		Convey("When batchGet is called concurrently for several instances of a resource configured with batch reads", func() {
+			resource.resourceListOperation = &specResourceOperation{batchRead: &specBatchRead{idsParam: "ids", maxBatchSize: 100}}
+			payloads, responses, errs := readInstances("1", "2", "3")
+			Convey("Then the instances returned by the list operation should be read with a single call", func() {
+				So(errs, ShouldResemble, []error{nil, nil, nil})
+				So(payloads[0], ShouldResemble, map[string]interface{}{"id": "1", "label": "label1"})
+				So(payloads[1], ShouldResemble, map[string]interface{}{"id": "2", "label": "label2"})
+				So(responses[0].StatusCode, ShouldEqual, http.StatusOK)
+				So(responses[1].StatusCode, ShouldEqual, http.StatusOK)
+			})
+			Convey("And the instance not returned by the list operation should be read individually", func() {
+				So(responses[2].StatusCode, ShouldEqual, http.StatusNotFound)
+				sort.Strings(requests)
+				So(requests, ShouldResemble, []string{"/v1/resource", "/v1/resource/3"})
+			})
+		})
+		Convey("When batchGet is called for a resource not configured with batch reads", func() {
+			resource.resourceListOperation = &specResourceOperation{}
+			payloads, _, errs := readInstances("1", "2")
+			Convey("Then the instances should be read individually", func() {
+				So(errs, ShouldResemble, []error{nil, nil})
+				So(payloads[0]["id"], ShouldEqual, "1")
+				So(payloads[1]["id"], ShouldEqual, "2")
+				sort.Strings(requests)
+				So(requests, ShouldResemble, []string{"/v1/resource/1", "/v1/resource/2"})
+			})
+		})
+		Convey("When batchGet is called for a resource updated with conditional requests", func() {
+			resource.resourceListOperation = &specResourceOperation{batchRead: &specBatchRead{idsParam: "ids", maxBatchSize: 100}}
+			resource.resourcePutOperation = &specResourceOperation{conditionalRequestEnabled: true}
+			_, _, errs := readInstances("1")
+			Convey("Then the instance should be read individually", func() {
+				So(errs, ShouldResemble, []error{nil})
+				So(requests, ShouldResemble, []string{"/v1/resource/1"})
+			})
+		})
+	})
+}
@@ -0,0 +1,12 @@
+package openapi
+
+// readOnlyModeClient is implemented by the clients that support disabling the mutating resource operations (create,
+// update and delete), e,g: break-glass accounts or providers used purely as a data inventory layer
+type readOnlyModeClient interface {
+	isReadOnlyMode() bool
+}
+
+// isReadOnlyMode returns true if the provider is configured with read_only = true
+func (o *ProviderClient) isReadOnlyMode() bool {
+	return o.providerConfiguration.ReadOnly
+}
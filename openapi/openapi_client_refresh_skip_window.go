@@ -0,0 +1,15 @@
+package openapi
+
+import "time"
+
+// refreshSkipWindowClient is implemented by the clients that support skipping the refresh of the resources that were read
+// recently (as recorded in the last_read_at attribute), cutting the refresh time of huge states against slow APIs
+type refreshSkipWindowClient interface {
+	getRefreshSkipWindow() time.Duration
+}
+
+// getRefreshSkipWindow returns the refresh_skip_window configured in the provider. Zero means the resources are always
+// refreshed
+func (o *ProviderClient) getRefreshSkipWindow() time.Duration {
+	return o.providerConfiguration.RefreshSkipWindow
+}
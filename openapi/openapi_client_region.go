@@ -0,0 +1,23 @@
+package openapi
+
+// regionClient is implemented by the clients that support multi-region APIs, exposing the region the API calls of the
+// resources are made against so it can be tracked in the state
+type regionClient interface {
+	getResolvedRegion() (string, error)
+}
+
+// getResolvedRegion returns the region the API calls are made against if the API is multi-region: the region configured
+// in the provider or, if not provided, the default region documented in the OpenAPI document. An empty region is
+// returned if the API is not multi-region
+func (o ProviderClient) getResolvedRegion() (string, error) {
+	isMultiRegion, _, regions, err := o.openAPIBackendConfiguration.IsMultiRegion()
+	if err != nil || !isMultiRegion {
+		return "", err
+	}
+	// get region value provided by user in the terraform configuration file
+	if region := o.providerConfiguration.getRegion(); region != "" {
+		return region, nil
+	}
+	// otherwise, if not provided falling back to the default value specified in the service provider swagger file
+	return o.openAPIBackendConfiguration.GetDefaultRegion(regions)
+}
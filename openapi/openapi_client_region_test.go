@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResolvedRegion(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		backendConfiguration *specStubBackendConfiguration
+		providerRegion       string
+		expectedRegion       string
+		expectedError        string
+	}{
+		{
+			name:                 "backend configuration that is not multi-region",
+			backendConfiguration: &specStubBackendConfiguration{host: "www.host.com"},
+			providerRegion:       "us-west1",
+			expectedRegion:       "",
+		},
+		{
+			name:                 "multi-region backend configuration and the provider configured with a region",
+			backendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", regions: []string{"us-east1", "us-west1"}},
+			providerRegion:       "us-west1",
+			expectedRegion:       "us-west1",
+		},
+		{
+			name:                 "multi-region backend configuration and the provider not configured with a region",
+			backendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", regions: []string{"us-east1", "us-west1"}},
+			expectedRegion:       "us-east1",
+		},
+		{
+			name:                 "multi-region backend configuration failing to resolve whether the API is multi-region",
+			backendConfiguration: &specStubBackendConfiguration{err: errors.New("some error")},
+			expectedError:        "some error",
+		},
+		{
+			name:                 "multi-region backend configuration failing to resolve the default region",
+			backendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", regions: []string{"us-east1"}, defaultRegionErr: errors.New("some default region error")},
+			expectedError:        "some default region error",
+		},
+	}
+	for _, tc := range testCases {
+		providerClient := ProviderClient{
+			openAPIBackendConfiguration: tc.backendConfiguration,
+			providerConfiguration:       providerConfiguration{Region: tc.providerRegion},
+		}
+		region, err := providerClient.getResolvedRegion()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedRegion, region, tc.name)
+	}
+}
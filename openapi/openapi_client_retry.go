@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// retryableErrorMaxRetries is the max number of times a request is retried when the API responds with one of the
+// retryable errors configured for the operation and the client is not configured with a retry timeout
+var retryableErrorMaxRetries = 5
+
+// retryableErrorInitialBackoff is the time to wait before the first retry. The wait time doubles on each retry
+var retryableErrorInitialBackoff = 1 * time.Second
+
+// retryableErrorMaxBackoff is the max time to wait between retries
+var retryableErrorMaxBackoff = 30 * time.Second
+
+// retryTimeoutClient is implemented by the clients that support bounding the retries of the requests to the timeout
+// configured for the resource operation (e,g: the timeouts block in the terraform configuration)
+type retryTimeoutClient interface {
+	withRetryTimeout(timeout time.Duration) ClientOpenAPI
+}
+
+// withRetryTimeout returns a copy of the client that keeps retrying the requests that fail with retryable errors until
+// the given timeout (counted from now) expires, instead of giving up after a fixed number of retries. The timeout is
+// shared by all the requests performed with the returned client.
+func (o *ProviderClient) withRetryTimeout(timeout time.Duration) ClientOpenAPI {
+	if timeout <= 0 {
+		return o
+	}
+	client := *o
+	client.retryDeadline = time.Now().Add(timeout)
+	return &client
+}
+
+// sendRequestWithRetries sends the request and retries it with exponential backoff while the API responds with any of
+// the retryable errors configured for the operation (x-terraform-retryable-errors). The last response is returned
+// once the API responds with a different response or no more retries are allowed.
+func (o *ProviderClient) sendRequestWithRetries(method httpMethodSupported, resourceName string, reqContext *authContext, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	retryBackoff := o.getRetryBackoff(resourceName, operation)
+	backoff := retryBackoff.initialBackoff
+	var limiter *rateLimiter
+	if o.rateLimiters != nil {
+		limiter = o.rateLimiters.get(getRateLimitHost(reqContext.url), o.rateLimitInterval)
+	}
+	for retry := 1; ; retry++ {
+		if limiter != nil && !limiter.wait(o.sleep) {
+			return nil, o.checkOperationTimeout(method, reqContext.url)
+		}
+		if err := o.checkOperationTimeout(method, reqContext.url); err != nil {
+			return nil, err
+		}
+		resp, err := o.sendRequest(method, reqContext, requestPayload, responsePayload)
+		if timeoutErr := o.checkOperationTimeout(method, reqContext.url); err != nil && timeoutErr != nil {
+			return nil, timeoutErr
+		}
+		if limiter != nil {
+			limiter.update(resp)
+		}
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			o.submitAPICallEventMetric(resourceName, method, operation.getOperationID(), TelemetryAPICallEventRateLimited)
+		}
+		if err != nil || resp == nil || len(operation.retryableErrors) == 0 || !isRetryAllowed(retry, retryBackoff.maxRetries, backoff, o.retryDeadline) {
+			return resp, err
+		}
+		errorCode := getResponseErrorCode(resp, responsePayload, operation.getErrorParser())
+		if !operation.isRetryableError(resp.StatusCode, errorCode) {
+			return resp, err
+		}
+		log.Printf("[INFO] %s %s%s responded with a retryable error (status code: %d, error code: '%s'), retrying in %s (retry %d)", method, reqContext.url, operation.getOperationIDLogSuffix(), resp.StatusCode, errorCode, backoff, retry)
+		o.submitAPICallEventMetric(resourceName, method, operation.getOperationID(), TelemetryAPICallEventRetry)
+		resetResponsePayload(responsePayload)
+		if !o.sleep(backoff) {
+			return nil, o.checkOperationTimeout(method, reqContext.url)
+		}
+		backoff *= 2
+		if backoff > retryBackoff.maxBackoff {
+			backoff = retryBackoff.maxBackoff
+		}
+	}
+}
+
+// submitAPICallEventMetric submits the metric for the given event observed when calling the API for the resource if the
+// client is configured with a telemetry handler
+func (o *ProviderClient) submitAPICallEventMetric(resourceName string, method httpMethodSupported, operationID string, event TelemetryAPICallEvent) {
+	if o.telemetryHandler == nil || resourceName == "" {
+		return
+	}
+	o.telemetryHandler.SubmitAPICallEventMetrics(resourceName, string(method), operationID, event)
+}
+
+// getRetryBackoff returns the backoff used to retry the requests of the given resource operation: the default backoff
+// overridden by the one configured for the operation in the OpenAPI document, which in turn is overridden by the one
+// configured for the resource in the provider (retry_backoff)
+func (o *ProviderClient) getRetryBackoff(resourceName string, operation *specResourceOperation) specRetryBackoff {
+	retryBackoff := specRetryBackoff{
+		maxRetries:     retryableErrorMaxRetries,
+		initialBackoff: retryableErrorInitialBackoff,
+		maxBackoff:     retryableErrorMaxBackoff,
+	}
+	if operation != nil && operation.retryBackoff != nil {
+		retryBackoff = retryBackoff.override(*operation.retryBackoff)
+	}
+	if providerRetryBackoff, exists := o.providerConfiguration.RetryBackoffs[resourceName]; exists {
+		retryBackoff = retryBackoff.override(providerRetryBackoff)
+	}
+	return retryBackoff
+}
+
+// isRetryAllowed returns true if the request can be retried after waiting the given backoff. If a deadline is provided
+// the request can be retried as long as the deadline is not exceeded; otherwise the given max number of retries applies
+func isRetryAllowed(retry, maxRetries int, backoff time.Duration, deadline time.Time) bool {
+	if deadline.IsZero() {
+		return retry <= maxRetries
+	}
+	return time.Now().Add(backoff).Before(deadline)
+}
+
+// getResponseErrorCode returns the error code contained in the response body as parsed by the given error parser. If the
+// body has already been consumed when decoding it into the response payload, the error code is looked up in the response
+// payload instead. The response body is restored so it can be read again by the caller.
+func getResponseErrorCode(resp *http.Response, responsePayload interface{}, errorParser apiErrorParser) string {
+	if resp.Body != nil {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err == nil && len(body) > 0 {
+			return getAPIErrorCode(errorParser, body)
+		}
+	}
+	if responsePayload == nil {
+		return ""
+	}
+	payload, err := json.Marshal(responsePayload)
+	if err != nil {
+		return ""
+	}
+	return getAPIErrorCode(errorParser, payload)
+}
+
+// resetResponsePayload removes the values decoded into the response payload so the response of the request being
+// retried does not contain leftovers from the previous response
+func resetResponsePayload(responsePayload interface{}) {
+	switch payload := responsePayload.(type) {
+	case map[string]interface{}:
+		for key := range payload {
+			delete(payload, key)
+		}
+	case *map[string]interface{}:
+		if payload != nil {
+			*payload = map[string]interface{}{}
+		}
+	case *[]map[string]interface{}:
+		if payload != nil {
+			*payload = []map[string]interface{}{}
+		}
+	}
+}
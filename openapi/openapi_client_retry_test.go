@@ -0,0 +1,231 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSendRequestWithRetries(t *testing.T) {
+	defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff := retryableErrorMaxRetries, retryableErrorInitialBackoff, retryableErrorMaxBackoff
+	retryableErrorMaxRetries, retryableErrorInitialBackoff, retryableErrorMaxBackoff = 2, time.Millisecond, 2*time.Millisecond
+	defer func() {
+		retryableErrorMaxRetries, retryableErrorInitialBackoff, retryableErrorMaxBackoff = defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff
+	}()
+
+	newAPI := func(failures int, statusCode int, body string) (*httptest.Server, *int) {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			rw.Header().Set("Content-Type", "application/json")
+			if requests <= failures {
+				rw.WriteHeader(statusCode)
+				rw.Write([]byte(body))
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`{"id":"someID"}`))
+		}))
+		return api, &requests
+	}
+	providerClient := &ProviderClient{httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}}}
+	operation := &specResourceOperation{
+		retryableErrors: []specRetryableError{{statusCode: http.StatusConflict, errorCode: "operation_in_progress"}},
+	}
+
+	Convey("Given an API that responds with a retryable error before succeeding", t, func() {
+		api, requests := newAPI(2, http.StatusConflict, `{"error":{"code":"operation_in_progress"}}`)
+		defer api.Close()
+		Convey("When sendRequestWithRetries is called with an operation configured with the retryable error", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, operation, nil, &responsePayload)
+			Convey("Then the request should be retried until the API succeeds", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(*requests, ShouldEqual, 3)
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "someID"})
+			})
+		})
+	})
+
+	Convey("Given an API that keeps responding with a retryable error", t, func() {
+		api, requests := newAPI(10, http.StatusConflict, `{"code":"operation_in_progress"}`)
+		defer api.Close()
+		Convey("When sendRequestWithRetries is called with an operation configured with the retryable error", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, operation, nil, &responsePayload)
+			Convey("Then the last response should be returned once the max number of retries is reached", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+				So(*requests, ShouldEqual, retryableErrorMaxRetries+1)
+			})
+			Convey("And the response body should still be readable", func() {
+				body, err := ioutil.ReadAll(resp.Body)
+				So(err, ShouldBeNil)
+				So(getAPIErrorCode(defaultAPIErrorParser{}, body), ShouldEqual, "operation_in_progress")
+			})
+		})
+	})
+
+	Convey("Given an API that responds with a retryable error more times than the max number of retries", t, func() {
+		api, requests := newAPI(retryableErrorMaxRetries+1, http.StatusConflict, `{"code":"operation_in_progress"}`)
+		defer api.Close()
+		Convey("When sendRequestWithRetries is called with a client configured with a retry timeout", func() {
+			client := providerClient.withRetryTimeout(time.Minute).(*ProviderClient)
+			responsePayload := map[string]interface{}{}
+			resp, err := client.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, operation, nil, &responsePayload)
+			Convey("Then the request should be retried until the API succeeds since the timeout has not expired", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(*requests, ShouldEqual, retryableErrorMaxRetries+2)
+			})
+		})
+	})
+
+	Convey("Given an API that responds with a rate limited error before succeeding", t, func() {
+		api, requests := newAPI(2, http.StatusTooManyRequests, `{"message":"too many requests"}`)
+		defer api.Close()
+		Convey("When sendRequestWithRetries is called by a client configured with a telemetry handler and an operation configured to retry rate limited errors", func() {
+			var events []TelemetryAPICallEvent
+			client := &ProviderClient{
+				httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}},
+				telemetryHandler: &telemetryHandlerStub{
+					submitAPICallEventMetricsFunc: func(resourceName, httpMethod, operationID string, event TelemetryAPICallEvent) {
+						So(resourceName, ShouldEqual, "resourceName")
+						So(httpMethod, ShouldEqual, "GET")
+						So(operationID, ShouldEqual, "getResource")
+						events = append(events, event)
+					},
+				},
+			}
+			responsePayload := map[string]interface{}{}
+			resp, err := client.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, &specResourceOperation{operationID: "getResource", retryableErrors: []specRetryableError{{statusCode: http.StatusTooManyRequests}}}, nil, &responsePayload)
+			Convey("Then the request should be retried until the API succeeds", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(*requests, ShouldEqual, 3)
+			})
+			Convey("And the rate limited responses and the retries should be submitted to the telemetry handler", func() {
+				So(events, ShouldResemble, []TelemetryAPICallEvent{TelemetryAPICallEventRateLimited, TelemetryAPICallEventRetry, TelemetryAPICallEventRateLimited, TelemetryAPICallEventRetry})
+			})
+		})
+	})
+
+	Convey("Given an API that responds with an error with a different error code", t, func() {
+		api, requests := newAPI(1, http.StatusConflict, `{"code":"already_exists"}`)
+		defer api.Close()
+		Convey("When sendRequestWithRetries is called with an operation configured with a retryable error", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, operation, nil, &responsePayload)
+			Convey("Then the request should not be retried", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+				So(*requests, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given an API that responds with an error", t, func() {
+		api, requests := newAPI(1, http.StatusConflict, `{"code":"operation_in_progress"}`)
+		defer api.Close()
+		Convey("When sendRequestWithRetries is called with an operation that does not have retryable errors", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.sendRequestWithRetries(httpGet, "resourceName", &authContext{url: api.URL, headers: map[string]string{}}, &specResourceOperation{}, nil, &responsePayload)
+			Convey("Then the request should not be retried", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusConflict)
+				So(*requests, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestResetResponsePayload(t *testing.T) {
+	Convey("Given a pointer to a response payload containing some values", t, func() {
+		responsePayload := map[string]interface{}{"code": "operation_in_progress"}
+		Convey("When resetResponsePayload is called", func() {
+			resetResponsePayload(&responsePayload)
+			Convey("Then the response payload should be empty", func() {
+				So(responsePayload, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a pointer to a list response payload containing some values", t, func() {
+		responsePayload := []map[string]interface{}{{"id": "someID"}}
+		Convey("When resetResponsePayload is called", func() {
+			resetResponsePayload(&responsePayload)
+			Convey("Then the response payload should be empty", func() {
+				So(responsePayload, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestWithRetryTimeout(t *testing.T) {
+	Convey("Given a providerClient", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When withRetryTimeout is called with a timeout", func() {
+			client := providerClient.withRetryTimeout(time.Minute)
+			Convey("Then a copy of the client with the retry deadline set should be returned", func() {
+				So(client, ShouldNotEqual, providerClient)
+				So(client.(*ProviderClient).retryDeadline, ShouldHappenWithin, time.Second, time.Now().Add(time.Minute))
+				So(providerClient.retryDeadline.IsZero(), ShouldBeTrue)
+			})
+		})
+		Convey("When withRetryTimeout is called with a zero timeout", func() {
+			client := providerClient.withRetryTimeout(0)
+			Convey("Then the same client should be returned", func() {
+				So(client, ShouldEqual, providerClient)
+			})
+		})
+	})
+}
+
+func TestProviderClientGetRetryBackoff(t *testing.T) {
+	defaultRetryBackoff := specRetryBackoff{maxRetries: retryableErrorMaxRetries, initialBackoff: retryableErrorInitialBackoff, maxBackoff: retryableErrorMaxBackoff}
+	Convey("Given a provider client configured with the retry backoff of a resource", t, func() {
+		providerClient := &ProviderClient{
+			providerConfiguration: providerConfiguration{
+				RetryBackoffs: map[string]specRetryBackoff{"resourceName": {maxRetries: 20}},
+			},
+		}
+		operation := &specResourceOperation{retryBackoff: &specRetryBackoff{maxRetries: 10, initialBackoff: 100 * time.Millisecond}}
+		Convey("When getRetryBackoff is called for the resource with an operation configuring the retry backoff", func() {
+			retryBackoff := providerClient.getRetryBackoff("resourceName", operation)
+			Convey("Then the values configured in the provider should take preference over the operation ones", func() {
+				So(retryBackoff, ShouldResemble, specRetryBackoff{maxRetries: 20, initialBackoff: 100 * time.Millisecond, maxBackoff: retryableErrorMaxBackoff})
+			})
+		})
+		Convey("When getRetryBackoff is called for other resource with an operation that does not configure the retry backoff", func() {
+			retryBackoff := providerClient.getRetryBackoff("otherResourceName", &specResourceOperation{})
+			Convey("Then the default retry backoff should be returned", func() {
+				So(retryBackoff, ShouldResemble, defaultRetryBackoff)
+			})
+		})
+	})
+}
+
+func TestIsRetryAllowed(t *testing.T) {
+	Convey("Given no deadline", t, func() {
+		Convey("When isRetryAllowed is called", func() {
+			Convey("Then the retry should be allowed until the max number of retries is reached", func() {
+				So(isRetryAllowed(retryableErrorMaxRetries, retryableErrorMaxRetries, time.Second, time.Time{}), ShouldBeTrue)
+				So(isRetryAllowed(retryableErrorMaxRetries+1, retryableErrorMaxRetries, time.Second, time.Time{}), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a deadline", t, func() {
+		deadline := time.Now().Add(time.Minute)
+		Convey("When isRetryAllowed is called", func() {
+			Convey("Then the retry should be allowed as long as the backoff does not exceed the deadline regardless of the number of retries", func() {
+				So(isRetryAllowed(retryableErrorMaxRetries+1, retryableErrorMaxRetries, time.Second, deadline), ShouldBeTrue)
+				So(isRetryAllowed(1, retryableErrorMaxRetries, 2*time.Minute, deadline), ShouldBeFalse)
+			})
+		})
+	})
+}
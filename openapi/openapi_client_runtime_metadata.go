@@ -0,0 +1,31 @@
+package openapi
+
+// appendRuntimeMetadataHeaders adds the runtime metadata headers configured in the provider (runtime_metadata_headers)
+// unless the headers are already set
+func (o *ProviderClient) appendRuntimeMetadataHeaders(headers map[string]string) {
+	for headerName, value := range o.providerConfiguration.RuntimeMetadataHeaders {
+		if _, exists := headers[headerName]; !exists {
+			headers[headerName] = value
+		}
+	}
+}
+
+// appendRuntimeMetadataProperties returns a copy of the given request payload containing the runtime metadata properties
+// configured in the provider (runtime_metadata_properties). The properties already present in the payload are kept as
+// is, and payloads that are not objects are returned untouched
+func (o *ProviderClient) appendRuntimeMetadataProperties(requestPayload interface{}) interface{} {
+	payload, ok := requestPayload.(map[string]interface{})
+	if !ok || len(o.providerConfiguration.RuntimeMetadataProperties) == 0 {
+		return requestPayload
+	}
+	payloadWithMetadata := map[string]interface{}{}
+	for propertyName, value := range payload {
+		payloadWithMetadata[propertyName] = value
+	}
+	for propertyName, value := range o.providerConfiguration.RuntimeMetadataProperties {
+		if _, exists := payloadWithMetadata[propertyName]; !exists {
+			payloadWithMetadata[propertyName] = value
+		}
+	}
+	return payloadWithMetadata
+}
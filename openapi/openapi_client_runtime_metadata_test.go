@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAppendRuntimeMetadataHeaders(t *testing.T) {
+	Convey("Given a providerClient configured with runtime metadata headers", t, func() {
+		providerClient := &ProviderClient{providerConfiguration: providerConfiguration{RuntimeMetadataHeaders: map[string]string{"X-Terraform-Workspace": "production", "X-Terraform-Run-Id": "run-1234"}}}
+		Convey("When appendRuntimeMetadataHeaders is called with headers already containing one of the runtime metadata headers", func() {
+			headers := map[string]string{"X-Terraform-Run-Id": "someValue"}
+			providerClient.appendRuntimeMetadataHeaders(headers)
+			Convey("Then the missing runtime metadata headers should be added and the existing ones should be kept", func() {
+				So(headers, ShouldResemble, map[string]string{"X-Terraform-Workspace": "production", "X-Terraform-Run-Id": "someValue"})
+			})
+		})
+	})
+}
+
+func TestAppendRuntimeMetadataProperties(t *testing.T) {
+	Convey("Given a providerClient configured with runtime metadata properties", t, func() {
+		providerClient := &ProviderClient{providerConfiguration: providerConfiguration{RuntimeMetadataProperties: map[string]string{"workspace": "production", "label": "run-1234"}}}
+		Convey("When appendRuntimeMetadataProperties is called with a payload already containing one of the properties", func() {
+			payload := map[string]interface{}{"name": "someName", "label": "someLabel"}
+			payloadWithMetadata := providerClient.appendRuntimeMetadataProperties(payload)
+			Convey("Then the payload returned should contain the missing runtime metadata properties", func() {
+				So(payloadWithMetadata, ShouldResemble, map[string]interface{}{"name": "someName", "label": "someLabel", "workspace": "production"})
+			})
+			Convey("And the original payload should not be modified", func() {
+				So(payload, ShouldResemble, map[string]interface{}{"name": "someName", "label": "someLabel"})
+			})
+		})
+		Convey("When appendRuntimeMetadataProperties is called with a payload that is not an object", func() {
+			payloadWithMetadata := providerClient.appendRuntimeMetadataProperties(nil)
+			Convey("Then the payload should be returned untouched", func() {
+				So(payloadWithMetadata, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -15,8 +15,12 @@ type clientOpenAPIStub struct {
 	idReceived          string
 	parentIDsReceived   []string
 	telemetryHandler    TelemetryHandler
+	responseHeaders     http.Header
 
-	funcPut func() (*http.Response, error)
+	funcPut       func() (*http.Response, error)
+	funcPreDelete func() (*http.Response, error)
+	// preDeleteIDReceived is the id of the instance the pre-delete request was performed for
+	preDeleteIDReceived string
 }
 
 func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
@@ -92,6 +96,23 @@ func (c *clientOpenAPIStub) Delete(resource SpecResource, id string, parentIDs .
 	return c.generateStubResponse(http.StatusNoContent), nil
 }
 
+func (c *clientOpenAPIStub) Head(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.idReceived = id
+	c.parentIDsReceived = parentIDs
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) preDelete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	c.preDeleteIDReceived = id
+	if c.funcPreDelete != nil {
+		return c.funcPreDelete()
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
 func (c *clientOpenAPIStub) GetTelemetryHandler() TelemetryHandler {
 	return c.telemetryHandler
 }
@@ -99,6 +120,7 @@ func (c *clientOpenAPIStub) GetTelemetryHandler() TelemetryHandler {
 func (c *clientOpenAPIStub) generateStubResponse(defaultHTTPCode int) *http.Response {
 	return &http.Response{
 		StatusCode: c.returnCode(defaultHTTPCode),
+		Header:     c.responseHeaders,
 		Body:       ioutil.NopCloser(strings.NewReader("")),
 	}
 }
@@ -33,6 +33,135 @@ func TestProviderClient(t *testing.T) {
 	})
 }
 
+func TestWithResourceHeaders(t *testing.T) {
+	Convey("Given a providerClient configured with some header values", t, func() {
+		providerClient := &ProviderClient{
+			providerConfiguration: providerConfiguration{
+				Headers: map[string]string{
+					"x_tenant_id":  "providerTenant",
+					"x_request_id": "providerRequestID",
+				},
+			},
+		}
+		Convey("When withResourceHeaders is called with some resource header values", func() {
+			client := providerClient.withResourceHeaders(map[string]string{"x_tenant_id": "resourceTenant"})
+			Convey("Then the client returned should contain the resource header values overriding the provider ones", func() {
+				So(client.(*ProviderClient).providerConfiguration.Headers, ShouldResemble, map[string]string{"x_tenant_id": "resourceTenant", "x_request_id": "providerRequestID"})
+			})
+			Convey("And the original client header values should not be modified", func() {
+				So(providerClient.providerConfiguration.Headers["x_tenant_id"], ShouldEqual, "providerTenant")
+			})
+		})
+		Convey("When withResourceHeaders is called with no resource header values", func() {
+			client := providerClient.withResourceHeaders(map[string]string{})
+			Convey("Then the same client should be returned", func() {
+				So(client, ShouldEqual, providerClient)
+			})
+		})
+	})
+}
+
+func TestWithResourceQueryParameters(t *testing.T) {
+	Convey("Given a providerClient", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When withResourceQueryParameters is called with some resource query parameter values", func() {
+			client := providerClient.withResourceQueryParameters(map[string]string{"validate_only": "true"})
+			Convey("Then the client returned should contain the resource query parameter values", func() {
+				So(client.(*ProviderClient).resourceQueryParameters, ShouldResemble, map[string]string{"validate_only": "true"})
+			})
+			Convey("And the original client should not be modified", func() {
+				So(providerClient.resourceQueryParameters, ShouldBeNil)
+			})
+		})
+		Convey("When withResourceQueryParameters is called with no resource query parameter values", func() {
+			client := providerClient.withResourceQueryParameters(map[string]string{})
+			Convey("Then the same client should be returned", func() {
+				So(client, ShouldEqual, providerClient)
+			})
+		})
+	})
+}
+
+func TestAppendOperationQueryParameters(t *testing.T) {
+	Convey("Given a providerClient configured with a resource query parameter value", t, func() {
+		providerClient := ProviderClient{resourceQueryParameters: map[string]string{"validate_only": "true"}}
+		Convey("When appendOperationQueryParameters is called with pinned and resource attribute query parameters", func() {
+			queryParameters := SpecQueryParameters{
+				{Name: "force", Value: "true"},
+				{Name: "validateOnly", Value: "false", IsResourceAttribute: true},
+				{Name: "dryRun", IsResourceAttribute: true},
+			}
+			resourceURL, err := providerClient.appendOperationQueryParameters(queryParameters, "http://host.com/v1/resource")
+			Convey("Then the url returned should contain the query parameters with values sorted by name", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?force=true&validateOnly=true")
+			})
+		})
+		Convey("When appendOperationQueryParameters is called with a url that already contains query parameters", func() {
+			resourceURL, err := providerClient.appendOperationQueryParameters(SpecQueryParameters{{Name: "force", Value: "true"}}, "http://host.com/v1/resource?api_key=secret")
+			Convey("Then the query parameters should be appended to the existing ones", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?api_key=secret&force=true")
+			})
+		})
+		Convey("When appendOperationQueryParameters is called with a url that already contains some of the query parameters", func() {
+			resourceURL, err := providerClient.appendOperationQueryParameters(SpecQueryParameters{{Name: "force", Value: "true"}, {Name: "limit", Value: "20"}}, "http://host.com/v1/resource?limit=20&page=2")
+			Convey("Then the query parameters already present should not be appended again", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?limit=20&page=2&force=true")
+			})
+		})
+		Convey("When appendOperationQueryParameters is called with no query parameters", func() {
+			resourceURL, err := providerClient.appendOperationQueryParameters(nil, "http://host.com/v1/resource")
+			Convey("Then the url should not be modified", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource")
+			})
+		})
+		Convey("When appendOperationQueryParameters is called with a required query parameter that has no value", func() {
+			_, err := providerClient.appendOperationQueryParameters(SpecQueryParameters{{Name: "dryRun", IsRequired: true, IsResourceAttribute: true}}, "http://host.com/v1/resource")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "required query parameter 'dryRun' is missing the value. Please make sure the property 'dry_run' is configured with a value in the resource's terraform configuration")
+			})
+		})
+	})
+	Convey("Given a providerClient configured with a filter query parameter value", t, func() {
+		providerClient := ProviderClient{filterQueryParameters: map[string]string{"owner_id": "owner1"}}
+		Convey("When appendOperationQueryParameters is called with query parameters flagged as filters", func() {
+			queryParameters := SpecQueryParameters{
+				{Name: "ownerId", IsFilter: true},
+				{Name: "label", IsRequired: true, IsFilter: true},
+			}
+			resourceURL, err := providerClient.appendOperationQueryParameters(queryParameters, "http://host.com/v1/resource")
+			Convey("Then the url returned should only contain the filter query parameters with values", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?ownerId=owner1")
+			})
+		})
+	})
+}
+
+func TestWithFilterQueryParameters(t *testing.T) {
+	Convey("Given a providerClient", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When withFilterQueryParameters is called with some filter values", func() {
+			client := providerClient.withFilterQueryParameters(map[string]string{"label": "some label"})
+			Convey("Then the client returned should contain the filter values", func() {
+				So(client.(*ProviderClient).filterQueryParameters, ShouldResemble, map[string]string{"label": "some label"})
+			})
+			Convey("And the original client should not be modified", func() {
+				So(providerClient.filterQueryParameters, ShouldBeNil)
+			})
+		})
+		Convey("When withFilterQueryParameters is called with no filter values", func() {
+			client := providerClient.withFilterQueryParameters(map[string]string{})
+			Convey("Then the same client should be returned", func() {
+				So(client, ShouldEqual, providerClient)
+			})
+		})
+	})
+}
+
 func TestAppendOperationHeaders(t *testing.T) {
 	Convey("Given a providerClient set up with stub auth that injects some headers to the request", t, func() {
 		operationHeader := "operationHeader"
@@ -107,6 +236,19 @@ func TestAppendOperationHeaders(t *testing.T) {
 	})
 }
 
+func TestAppendGlobalHeaders(t *testing.T) {
+	Convey("Given a providerClient configured with global headers", t, func() {
+		providerClient := &ProviderClient{providerConfiguration: providerConfiguration{GlobalHeaders: map[string]string{"X-Api-Version": "2", "X-Request-Source": "terraform"}}}
+		Convey("When appendGlobalHeaders is called with headers already containing one of the global headers", func() {
+			headers := map[string]string{"X-Api-Version": "3", "Content-Type": "application/json"}
+			providerClient.appendGlobalHeaders(headers)
+			Convey("Then the existing header values should be kept and the missing global headers should be added", func() {
+				So(headers, ShouldResemble, map[string]string{"X-Api-Version": "3", "X-Request-Source": "terraform", "Content-Type": "application/json"})
+			})
+		})
+	})
+}
+
 func TestAppendUserAgentHeader(t *testing.T) {
 	Convey("Given a providerClient and user agent header value", t, func() {
 		providerClient := &ProviderClient{}
@@ -164,7 +306,7 @@ func TestGetResourceIDURL(t *testing.T) {
 					},
 				},
 			}
-			resourceURL, err := providerClient.getResourceIDURL(r, []string{}, expectedID)
+			resourceURL, err := providerClient.getResourceIDURL(r, nil, []string{}, expectedID)
 			Convey("The error should be nil and the resourceURL returned should be built from the schemes, host, base path, and path in the client and the ID passed", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -185,7 +327,7 @@ func TestGetResourceIDURL(t *testing.T) {
 					},
 				},
 			}
-			resourceURL, err := providerClient.getResourceIDURL(r, []string{}, expectedID)
+			resourceURL, err := providerClient.getResourceIDURL(r, nil, []string{}, expectedID)
 			Convey("Then the error should be nil and the resourceURL should equal the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -206,7 +348,7 @@ func TestGetResourceIDURL(t *testing.T) {
 					},
 				},
 			}
-			resourceURL, err := providerClient.getResourceIDURL(r, parentIDs, expectedID)
+			resourceURL, err := providerClient.getResourceIDURL(r, nil, parentIDs, expectedID)
 			Convey("Then the error should be nil and the resourceURL should equal", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -225,7 +367,7 @@ func TestGetResourceIDURL(t *testing.T) {
 					},
 				},
 			}
-			resourceURL, err := providerClient.getResourceIDURL(r, []string{}, "5678")
+			resourceURL, err := providerClient.getResourceIDURL(r, nil, []string{}, "5678")
 			Convey("The error should not be nil and the resourceURL should be empty", func() {
 				So(err.Error(), ShouldEqual, "could not resolve sub-resource path correctly '/v1/resource/{resource_id}/subresource' with the given ids - missing ids to resolve the path params properly: []")
 				So(resourceURL, ShouldBeEmpty)
@@ -241,7 +383,7 @@ func TestGetResourceIDURL(t *testing.T) {
 					},
 				},
 			}
-			_, err := providerClient.getResourceIDURL(r, []string{}, "")
+			_, err := providerClient.getResourceIDURL(r, nil, []string{}, "")
 			Convey("Then the error returned should match the expected one", func() {
 				So(err.Error(), ShouldEqual, "could not build the resourceIDURL: required instance id value is missing")
 			})
@@ -296,7 +438,7 @@ func TestGetResourceURL_edge_cases(t *testing.T) {
 						},
 					},
 				}
-				actualResourceURL, err := providerClient.getResourceIDURL(r, tc.parentIDs, tc.id)
+				actualResourceURL, err := providerClient.getResourceIDURL(r, nil, tc.parentIDs, tc.id)
 				if tc.expectedError != "" {
 					Convey("Then the error returned should not be nil", func() {
 						So(err.Error(), ShouldEqual, tc.expectedError)
@@ -342,7 +484,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -352,11 +494,56 @@ func TestGetResourceURL(t *testing.T) {
 			})
 		})
 
+		Convey("When getResourceURL is called with an operation configured with a host override", func() {
+			specStubResource := &specStubResource{path: "/v1/resource", host: "resource.host.com"}
+			resourceURL, err := providerClient.getResourceURL(specStubResource, &specResourceOperation{host: "replica.host.com"}, []string{})
+			Convey("Then the resourceURL returned should be built using the operation host", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://replica.host.com/api/v1/resource")
+			})
+		})
+
+		Convey("When getResourceURL is called with a resource configured with a host override", func() {
+			specStubResource := &specStubResource{path: "/v1/resource", host: "resource.host.com"}
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
+			Convey("Then the resourceURL returned should retain the global base path", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://resource.host.com/api/v1/resource")
+			})
+		})
+
+		Convey("When getResourceURL is called with a resource configured with a host and base path override", func() {
+			specStubResource := &specStubResource{path: "/v1/resource", host: "resource.host.com", basePath: "/other-api"}
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
+			Convey("Then the resourceURL returned should be built using the resource base path", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://resource.host.com/other-api/v1/resource")
+			})
+		})
+
+		Convey("When getResourceURL is called with a resource configured to strip the global base path", func() {
+			specStubResource := &specStubResource{path: "/v1/resource", host: "resource.host.com", basePath: "/"}
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
+			Convey("Then the resourceURL returned should not contain any base path", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://resource.host.com/v1/resource")
+			})
+		})
+
+		Convey("When getResourceIDURL is called with an operation configured with a host override", func() {
+			specStubResource := &specStubResource{path: "/v1/resource"}
+			resourceURL, err := providerClient.getResourceIDURL(specStubResource, &specResourceOperation{host: "replica.host.com"}, []string{}, "1234")
+			Convey("Then the resourceURL returned should be built using the operation host", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://replica.host.com/api/v1/resource/1234")
+			})
+		})
+
 		Convey("When getResourceURL is called with a resource which blows up on getResourcePath", func() {
 			specStubResource := &specStubResource{
 				funcGetResourcePath: func(parentIDs []string) (string, error) { return "", errors.New("getResourcePath blew up") },
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err.Error(), ShouldNotBeNil)
 				So(resourceURL, ShouldBeEmpty)
@@ -376,7 +563,7 @@ func TestGetResourceURL(t *testing.T) {
 					},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{expectedParentID})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{expectedParentID})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -398,7 +585,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -420,7 +607,7 @@ func TestGetResourceURL(t *testing.T) {
 			}
 
 			specStubResource := &specStubResource{}
-			_, err := providerClient.getResourceURL(specStubResource, []string{})
+			_, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, "host and path are mandatory attributes to get the resource URL - host[''], path['']")
@@ -438,7 +625,7 @@ func TestGetResourceURL(t *testing.T) {
 					},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(resourceURL, ShouldEqual, "")
 				So(err.Error(), ShouldEqual, "getHTTPScheme blew up")
@@ -454,7 +641,7 @@ func TestGetResourceURL(t *testing.T) {
 				},
 			}
 			specStubResource := &specStubResource{path: "whatever"}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				So(resourceURL, ShouldStartWith, "http://")
@@ -471,7 +658,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -503,7 +690,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -535,7 +722,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -567,7 +754,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -599,7 +786,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -636,7 +823,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -673,7 +860,7 @@ func TestGetResourceURL(t *testing.T) {
 					SecuritySchemes:  SpecSecuritySchemes{},
 				},
 			}
-			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			resourceURL, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				expectedProtocol, _ := providerClient.openAPIBackendConfiguration.getHTTPScheme()
@@ -700,7 +887,7 @@ func TestGetResourceURL(t *testing.T) {
 		}
 		Convey("When getResourceURL with a specResource with a resource path", func() {
 			specStubResource := &specStubResource{}
-			_, err := providerClient.getResourceURL(specStubResource, []string{})
+			_, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, expectedError)
@@ -725,7 +912,7 @@ func TestGetResourceURL(t *testing.T) {
 		}
 		Convey("When getResourceURL with a specResource with a resource path", func() {
 			specStubResource := &specStubResource{}
-			_, err := providerClient.getResourceURL(specStubResource, []string{})
+			_, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, expectedError)
@@ -750,7 +937,7 @@ func TestGetResourceURL(t *testing.T) {
 		}
 		Convey("When getResourceURL with a specResource with a resource path", func() {
 			specStubResource := &specStubResource{}
-			_, err := providerClient.getResourceURL(specStubResource, []string{})
+			_, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, expectedError)
@@ -774,7 +961,7 @@ func TestGetResourceURL(t *testing.T) {
 		}
 		Convey("When getResourceURL with a specResource with a resource path", func() {
 			specStubResource := &specStubResource{}
-			_, err := providerClient.getResourceURL(specStubResource, []string{})
+			_, err := providerClient.getResourceURL(specStubResource, nil, []string{})
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, expectedError)
@@ -829,7 +1016,7 @@ func TestPerformRequest(t *testing.T) {
 			expectedPath := "/v1/resource"
 			resourceURL := fmt.Sprintf("%s://%s%s%s", expectedProtocol, expectedHost, expectedBasePath, expectedPath)
 
-			_, err := providerClient.performRequest("POST", resourceURL, resourcePostOperation, requestPayload, responsePayload)
+			_, err := providerClient.performRequest("POST", "resourceName", resourceURL, resourcePostOperation, requestPayload, responsePayload)
 			Convey("Then the result returned should be the expected one", func() {
 				So(err, ShouldBeNil)
 				// client should have received the right URL
@@ -854,7 +1041,7 @@ func TestPerformRequest(t *testing.T) {
 				responses:        specResponses{},
 				SecuritySchemes:  SpecSecuritySchemes{},
 			}
-			_, err := providerClient.performRequest("NotSupportedMethod", "", resourcePostOperation, nil, nil)
+			_, err := providerClient.performRequest("NotSupportedMethod", "resourceName", "", resourcePostOperation, nil, nil)
 			Convey("Then the error message returned should be", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, "method 'NotSupportedMethod' not supported")
@@ -871,7 +1058,7 @@ func TestPerformRequest(t *testing.T) {
 				responses:       specResponses{},
 				SecuritySchemes: SpecSecuritySchemes{},
 			}
-			_, err := providerClient.performRequest("POST", "http://host.com/resource", resourcePostOperation, nil, nil)
+			_, err := providerClient.performRequest("POST", "resourceName", "http://host.com/resource", resourcePostOperation, nil, nil)
 			Convey("Then the error message returned should be", func() {
 				So(err.Error(), ShouldEqual, "failed to configure the API request for POST http://host.com/resource: required header 'some_not_configured_header' is missing the value. Please make sure the property 'some_not_configured_header' is configured with a value in the provider's terraform configuration")
 			})
@@ -884,7 +1071,7 @@ func TestPerformRequest(t *testing.T) {
 					err:         fmt.Errorf("some error with prep auth"),
 				},
 			}
-			_, err := providerClient.performRequest("POST", "", &specResourceOperation{}, nil, nil)
+			_, err := providerClient.performRequest("POST", "resourceName", "", &specResourceOperation{}, nil, nil)
 			Convey("Then the error message returned should be", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldEqual, "failed to configure the API request for POST : some error with prep auth")
@@ -0,0 +1,37 @@
+package openapi
+
+import "fmt"
+
+const (
+	// unknownFieldsIgnore drops the properties returned by the API that are not defined in the OpenAPI document
+	unknownFieldsIgnore = "ignore"
+	// unknownFieldsWarn logs a warning listing the properties returned by the API that are not defined in the OpenAPI document
+	unknownFieldsWarn = "warn"
+	// unknownFieldsError fails the operation if the API returns properties that are not defined in the OpenAPI document
+	unknownFieldsError = "error"
+)
+
+// otfVarUnknownFields is the environment variable that sets the provider unknown_fields property if not configured
+// (e,g: OTF_UNKNOWN_FIELDS=error in CI pipelines)
+const otfVarUnknownFields = "OTF_UNKNOWN_FIELDS"
+
+// unknownFieldsClient is implemented by the clients that support reporting the properties returned by the API that are
+// not defined in the OpenAPI document, which helps spec authors notice their OpenAPI document is out of sync with the API
+type unknownFieldsClient interface {
+	getUnknownFields() string
+}
+
+// getUnknownFields returns the unknown_fields configured in the provider. Empty means the unknown properties are ignored
+func (o *ProviderClient) getUnknownFields() string {
+	return o.providerConfiguration.UnknownFields
+}
+
+// unknownFieldsValidateFunc validates that the value of the provider unknown_fields property is supported
+func unknownFieldsValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	switch value {
+	case unknownFieldsIgnore, unknownFieldsWarn, unknownFieldsError:
+	default:
+		errs = append(errs, fmt.Errorf("property '%s' value '%v' is not valid, the supported values are: %s, %s and %s", key, value, unknownFieldsIgnore, unknownFieldsWarn, unknownFieldsError))
+	}
+	return
+}
@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUnknownPayloadFields(t *testing.T) {
+	resourceSchema := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			idProperty,
+			newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+		},
+	}
+	polymorphicSchema := &SpecSchemaDefinition{
+		Properties:    resourceSchema.Properties,
+		Discriminator: "pet_type",
+	}
+	remoteData := map[string]interface{}{"id": "1234", "label": "label", "owner": "owner", "color": "blue"}
+	testCases := []struct {
+		name          string
+		client        interface{}
+		resource      SpecResource
+		remoteData    map[string]interface{}
+		expectedError string
+	}{
+		{name: "client that does not support reporting unknown fields", client: &clientOpenAPIStub{}, resource: newSpecStubResource("resource", "/v1/resource", false, resourceSchema), remoteData: remoteData},
+		{name: "unknown fields not configured", client: &ProviderClient{}, resource: newSpecStubResource("resource", "/v1/resource", false, resourceSchema), remoteData: remoteData},
+		{name: "unknown fields ignored", client: &ProviderClient{providerConfiguration: providerConfiguration{UnknownFields: unknownFieldsIgnore}}, resource: newSpecStubResource("resource", "/v1/resource", false, resourceSchema), remoteData: remoteData},
+		{name: "unknown fields reported as warnings", client: &ProviderClient{providerConfiguration: providerConfiguration{UnknownFields: unknownFieldsWarn}}, resource: newSpecStubResource("resource", "/v1/resource", false, resourceSchema), remoteData: remoteData},
+		{name: "unknown fields reported as errors", client: &ProviderClient{providerConfiguration: providerConfiguration{UnknownFields: unknownFieldsError}}, resource: newSpecStubResource("resource", "/v1/resource", false, resourceSchema), remoteData: remoteData, expectedError: "the API returned properties that are not defined in the 'resource' schema in the OpenAPI document: color, owner"},
+		{name: "payload without unknown fields reported as errors", client: &ProviderClient{providerConfiguration: providerConfiguration{UnknownFields: unknownFieldsError}}, resource: newSpecStubResource("resource", "/v1/resource", false, resourceSchema), remoteData: map[string]interface{}{"id": "1234", "label": "label"}},
+		{name: "polymorphic schema fields reported as errors", client: &ProviderClient{providerConfiguration: providerConfiguration{UnknownFields: unknownFieldsError}}, resource: newSpecStubResource("resource", "/v1/resource", false, polymorphicSchema), remoteData: remoteData},
+	}
+	for _, tc := range testCases {
+		err := checkUnknownPayloadFields(tc.resource, tc.remoteData, tc.client)
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestUnknownFieldsValidateFunc(t *testing.T) {
+	for _, value := range []string{unknownFieldsIgnore, unknownFieldsWarn, unknownFieldsError} {
+		_, errs := unknownFieldsValidateFunc(value, providerPropertyUnknownFields)
+		assert.Empty(t, errs, value)
+	}
+	_, errs := unknownFieldsValidateFunc("strict", providerPropertyUnknownFields)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "property 'unknown_fields' value 'strict' is not valid, the supported values are: ignore, warn and error")
+}
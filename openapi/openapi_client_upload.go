@@ -0,0 +1,254 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/dikhan/http_goclient"
+)
+
+// uploadChunkMaxRetries is the max number of times the upload of a chunk is retried when it fails with a network error or
+// a server error, resuming the upload from the last byte received by the API
+var uploadChunkMaxRetries = 3
+
+// uploadRangeRegex matches the Range header returned by the API with the bytes of the file received so far (e,g: bytes=0-1023)
+var uploadRangeRegex = regexp.MustCompile(`^bytes=0-(\d+)$`)
+
+// uploadFiles uploads the local files configured in the properties of the request payload that are configured with the
+// x-terraform-upload-path extension. The payload returned contains the references returned by the upload endpoints
+// instead of the file paths; the given payload is not modified
+func (o *ProviderClient) uploadFiles(resource SpecResource, operation *specResourceOperation, requestPayload interface{}, parentIDs []string) (interface{}, error) {
+	payload, ok := requestPayload.(map[string]interface{})
+	if !ok || operation == nil {
+		return requestPayload, nil
+	}
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return requestPayload, err
+	}
+	var uploadedPayload map[string]interface{}
+	for _, property := range resourceSchema.Properties {
+		if property.Upload == nil {
+			continue
+		}
+		filePath, ok := payload[property.Name].(string)
+		if !ok || filePath == "" {
+			continue
+		}
+		reference, err := o.uploadFile(resource, operation, property.Upload, filePath, parentIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload the file '%s' configured in property '%s': %s", filePath, property.Name, err)
+		}
+		if uploadedPayload == nil {
+			uploadedPayload = map[string]interface{}{}
+			for k, v := range payload {
+				uploadedPayload[k] = v
+			}
+		}
+		uploadedPayload[property.Name] = reference
+	}
+	if uploadedPayload == nil {
+		return requestPayload, nil
+	}
+	return uploadedPayload, nil
+}
+
+// uploadFile streams the given local file to the upload endpoint and returns the reference to the uploaded file returned
+// by the endpoint. The file is sent with a single request unless the upload is configured with a chunk size smaller
+// than the file, in which case it is sent in chunks with a resumable upload
+func (o *ProviderClient) uploadFile(resource SpecResource, operation *specResourceOperation, upload *specUpload, filePath string, parentIDs []string) (interface{}, error) {
+	uploadPath, err := resolvePathParameters(upload.path, parentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve the upload path '%s': %s", upload.path, err)
+	}
+	uploadURL, err := o.getURL(resource, operation, uploadPath)
+	if err != nil {
+		return nil, err
+	}
+	reqContext, err := o.prepareRequestContext(httpPost, uploadURL, operation)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := fileInfo.Size()
+	log.Printf("[INFO] uploading file '%s' (%d bytes) to %s", filePath, size, reqContext.url)
+	var resp *http.Response
+	if upload.chunkSize <= 0 || size <= upload.chunkSize {
+		resp, err = o.sendUploadRequest(http.MethodPost, reqContext.url, reqContext.headers, io.NewSectionReader(file, 0, size), size, "")
+	} else {
+		resp, err = o.uploadChunks(reqContext, file, size, upload.chunkSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return readUploadReference(resp, upload.referenceProperty)
+}
+
+// uploadChunks uploads the given file in chunks of the given size with a resumable upload: the first chunk is POSTed to
+// the upload endpoint, which responds with 202 Accepted and the URL of the upload session in the Location header, and
+// the rest of chunks are PUT to the upload session URL. Each chunk is sent with the Content-Range header and the API
+// responds with 202 Accepted until the last chunk is received. If a chunk fails, the bytes received by the API are
+// queried with an empty PUT request (Content-Range: bytes */<size>) and the upload resumes from there.
+func (o *ProviderClient) uploadChunks(reqContext *authContext, file *os.File, size, chunkSize int64) (*http.Response, error) {
+	method, sessionURL := http.MethodPost, reqContext.url
+	var offset int64
+	retries := 0
+	backoff := retryableErrorInitialBackoff
+	for {
+		end := offset + chunkSize
+		if end > size {
+			end = size
+		}
+		contentRange := fmt.Sprintf("bytes %d-%d/%d", offset, end-1, size)
+		resp, err := o.sendUploadRequest(method, sessionURL, reqContext.headers, io.NewSectionReader(file, offset, end-offset), end-offset, contentRange)
+		if err == nil {
+			switch {
+			case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated:
+				return resp, nil
+			case resp.StatusCode == http.StatusAccepted:
+				resp.Body.Close()
+				if method == http.MethodPost {
+					if sessionURL, err = getUploadSessionURL(reqContext.url, resp); err != nil {
+						return nil, err
+					}
+					method = http.MethodPut
+				}
+				offset = getUploadOffset(resp, end)
+				retries, backoff = 0, retryableErrorInitialBackoff
+				continue
+			case resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests:
+				return nil, newUploadStatusError(method, sessionURL, resp)
+			}
+			err = newUploadStatusError(method, sessionURL, resp)
+		}
+		if retries >= uploadChunkMaxRetries {
+			return nil, err
+		}
+		retries++
+		log.Printf("[INFO] upload of chunk '%s' to %s failed, resuming the upload in %s (retry %d): %s", contentRange, sessionURL, backoff, retries, err)
+		if !o.sleep(backoff) {
+			return nil, o.checkOperationTimeout(httpMethodSupported(method), sessionURL)
+		}
+		if backoff *= 2; backoff > retryableErrorMaxBackoff {
+			backoff = retryableErrorMaxBackoff
+		}
+		if method == http.MethodPost {
+			// the upload session is not created until the first chunk is received, hence the upload starts over
+			continue
+		}
+		statusResp, err := o.sendUploadRequest(http.MethodPut, sessionURL, reqContext.headers, nil, 0, fmt.Sprintf("bytes */%d", size))
+		if err != nil {
+			continue
+		}
+		switch statusResp.StatusCode {
+		case http.StatusOK, http.StatusCreated:
+			// the API already received the whole file
+			return statusResp, nil
+		case http.StatusAccepted:
+			statusResp.Body.Close()
+			offset = getUploadOffset(statusResp, 0)
+		default:
+			statusResp.Body.Close()
+		}
+	}
+}
+
+// sendUploadRequest sends a request with the given method, headers and binary body of the given length. The body is
+// streamed so the file uploaded is never buffered in memory. These requests are only supported by the http clients
+// backed by a net/http client
+func (o *ProviderClient) sendUploadRequest(method, url string, headers map[string]string, body io.Reader, contentLength int64, contentRange string) (*http.Response, error) {
+	goClient, ok := o.httpClient.(*http_goclient.HttpClient)
+	if !ok || goClient.HttpClient == nil {
+		return nil, errors.New("file uploads are not supported by the http client")
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = contentLength
+	if contentLength == 0 {
+		req.Body = http.NoBody
+	}
+	for headerName, headerValue := range headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if contentRange != "" {
+		req.Header.Set("Content-Range", contentRange)
+	}
+	return goClient.HttpClient.Do(req)
+}
+
+// getUploadSessionURL returns the URL of the upload session returned in the Location header of the response to the first
+// chunk, resolved against the upload URL if it is relative
+func getUploadSessionURL(uploadURL string, resp *http.Response) (string, error) {
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("the response to the first chunk uploaded to %s does not contain the upload session URL in the Location header", uploadURL)
+	}
+	base, err := url.Parse(uploadURL)
+	if err != nil {
+		return "", err
+	}
+	sessionURL, err := base.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid upload session URL '%s': %s", location, err)
+	}
+	return sessionURL.String(), nil
+}
+
+// getUploadOffset returns the offset the upload continues from based on the Range header of the given response (the
+// bytes received so far by the API). The given default offset is returned if the response does not contain the header
+func getUploadOffset(resp *http.Response, defaultOffset int64) int64 {
+	matches := uploadRangeRegex.FindStringSubmatch(resp.Header.Get("Range"))
+	if matches == nil {
+		return defaultOffset
+	}
+	lastByte, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return defaultOffset
+	}
+	return lastByte + 1
+}
+
+// readUploadReference returns the value of the given property in the upload endpoint response, which is the reference to
+// the file uploaded sent to the API in the resource request
+func readUploadReference(resp *http.Response, referenceProperty string) (interface{}, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newUploadStatusError(resp.Request.Method, resp.Request.URL.String(), resp)
+	}
+	payload := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode the upload response: %s", err)
+	}
+	reference, exists := payload[referenceProperty]
+	if !exists || reference == nil {
+		return nil, fmt.Errorf("the upload response does not contain the '%s' property", referenceProperty)
+	}
+	return reference, nil
+}
+
+// newUploadStatusError returns the error of an upload request the API responded to with an unexpected status code
+func newUploadStatusError(method, url string, resp *http.Response) error {
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("%s %s responded with an unexpected status code %d: %s", method, url, resp.StatusCode, body)
+}
@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderClientPostWithUpload(t *testing.T) {
+	Convey("Given a local file and an API exposing an upload endpoint", t, func() {
+		dir, _ := ioutil.TempDir("", "upload")
+		defer os.RemoveAll(dir)
+		filePath := filepath.Join(dir, "certificate.pem")
+		ioutil.WriteFile(filePath, []byte("certificate contents"), 0600)
+
+		var uploadReceived []byte
+		var uploadContentType string
+		var payloadReceived map[string]interface{}
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/v1/cdns/cdnID/uploads":
+				uploadReceived, _ = ioutil.ReadAll(req.Body)
+				uploadContentType = req.Header.Get("Content-Type")
+				rw.WriteHeader(http.StatusCreated)
+				rw.Write([]byte(`{"upload_id":"upload-1234"}`))
+			case "/v1/cdns/cdnID/certificates":
+				json.NewDecoder(req.Body).Decode(&payloadReceived)
+				rw.WriteHeader(http.StatusCreated)
+				rw.Write([]byte(`{"id":"1234"}`))
+			default:
+				rw.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		uploadProperty := newStringSchemaDefinitionPropertyWithDefaults("certificate", "", true, false, nil)
+		uploadProperty.Upload = &specUpload{path: "/v1/cdns/{cdn_id}/uploads", referenceProperty: "upload_id"}
+		resource := &specStubResource{
+			path:                  "/v1/cdns/cdnID/certificates",
+			schemaDefinition:      &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{uploadProperty}},
+			resourcePostOperation: &specResourceOperation{},
+		}
+		Convey("When providerClient POST method is called with a payload containing the file path in the upload property", func() {
+			requestPayload := map[string]interface{}{"certificate": filePath}
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Post(resource, requestPayload, &responsePayload, "cdnID")
+			Convey("Then the file should be uploaded to the upload endpoint", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+				So(string(uploadReceived), ShouldEqual, "certificate contents")
+				So(uploadContentType, ShouldEqual, "application/octet-stream")
+			})
+			Convey("And the API should receive the reference to the file uploaded instead of the file path", func() {
+				So(payloadReceived, ShouldResemble, map[string]interface{}{"certificate": "upload-1234"})
+			})
+			Convey("And the payload passed in should not be modified", func() {
+				So(requestPayload, ShouldResemble, map[string]interface{}{"certificate": filePath})
+			})
+		})
+		Convey("When providerClient POST method is called with a payload containing a file path that does not exist", func() {
+			_, err := providerClient.Post(resource, map[string]interface{}{"certificate": filepath.Join(dir, "missing.pem")}, &map[string]interface{}{}, "cdnID")
+			Convey("Then the error returned should mention the property and the file", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, fmt.Sprintf("failed to upload the file '%s' configured in property 'certificate'", filepath.Join(dir, "missing.pem")))
+			})
+		})
+	})
+}
+
+func TestProviderClientUploadChunks(t *testing.T) {
+	defaultInitialBackoff := retryableErrorInitialBackoff
+	retryableErrorInitialBackoff = time.Millisecond
+	defer func() { retryableErrorInitialBackoff = defaultInitialBackoff }()
+	Convey("Given a local file and an API exposing a resumable upload endpoint that fails the second chunk once", t, func() {
+		dir, _ := ioutil.TempDir("", "upload")
+		defer os.RemoveAll(dir)
+		filePath := filepath.Join(dir, "image.bin")
+		ioutil.WriteFile(filePath, []byte("0123456789abcdefghij"), 0600) // 20 bytes
+
+		var mu sync.Mutex
+		var received []byte
+		var contentRanges []string
+		failed := false
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			chunk, _ := ioutil.ReadAll(req.Body)
+			contentRange := req.Header.Get("Content-Range")
+			contentRanges = append(contentRanges, req.Method+" "+req.URL.Path+" "+contentRange)
+			if contentRange == "bytes */20" {
+				rw.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(received)-1))
+				rw.WriteHeader(http.StatusAccepted)
+				return
+			}
+			if contentRange == "bytes 8-15/20" && !failed {
+				failed = true
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			received = append(received, chunk...)
+			if len(received) < 20 {
+				rw.Header().Set("Location", "/v1/uploads/session-1")
+				rw.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(received)-1))
+				rw.WriteHeader(http.StatusAccepted)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			rw.Write([]byte(`{"id":"upload-1234"}`))
+		}))
+		defer api.Close()
+		apiURL, _ := url.Parse(api.URL)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiURL.Host, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		resource := &specStubResource{path: "/v1/images"}
+		upload := &specUpload{path: "/v1/uploads", referenceProperty: "id", chunkSize: 8}
+		Convey("When uploadFile is called with a chunk size smaller than the file", func() {
+			reference, err := providerClient.uploadFile(resource, &specResourceOperation{}, upload, filePath, nil)
+			Convey("Then the file should be uploaded in chunks resuming from the last byte received after the failure", func() {
+				So(err, ShouldBeNil)
+				So(reference, ShouldEqual, "upload-1234")
+				So(string(received), ShouldEqual, "0123456789abcdefghij")
+				So(contentRanges, ShouldResemble, []string{
+					"POST /v1/uploads bytes 0-7/20",
+					"PUT /v1/uploads/session-1 bytes 8-15/20",
+					"PUT /v1/uploads/session-1 bytes */20",
+					"PUT /v1/uploads/session-1 bytes 8-15/20",
+					"PUT /v1/uploads/session-1 bytes 16-19/20",
+				})
+			})
+		})
+	})
+}
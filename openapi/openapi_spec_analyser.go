@@ -31,6 +31,9 @@ type SpecAnalyser interface {
 	// (e,g: host, protocols, etc) which is then used in the ProviderClient to communicate with the API as specified in
 	// the configuration.
 	GetAPIBackendConfiguration() (SpecBackendConfiguration, error)
+	// GetWarnings returns the non-fatal issues found while analysing the OpenAPI document (e,g: endpoints that could not
+	// be exposed as resources or data sources), so users can understand why a resource is missing or incomplete.
+	GetWarnings() []string
 }
 
 // SpecAnalyserVersion defines the type for versions supported in the SpecAnalyser
@@ -7,6 +7,8 @@ type specAnalyserStub struct {
 	security             *specSecurityStub
 	headers              SpecHeaderParameters
 	backendConfiguration SpecBackendConfiguration
+	warnings             []string
+	specVersion          string
 	error                error
 }
 
@@ -35,3 +37,11 @@ func (s *specAnalyserStub) GetAPIBackendConfiguration() (SpecBackendConfiguratio
 	}
 	return s.backendConfiguration, nil
 }
+
+func (s *specAnalyserStub) GetWarnings() []string {
+	return s.warnings
+}
+
+func (s *specAnalyserStub) getSpecVersion() string {
+	return s.specVersion
+}
@@ -19,10 +19,15 @@ func newAPIAuthenticator(globalSecuritySchemes *SpecSecuritySchemes) specAuthent
 }
 
 // Check if the operation contains any security policy. In the case where the operation contains multiple security
-// policies, the first one found in the list will be the one returned.
+// policies, the first one found in the list will be the one returned. As per the OpenAPI specification, the operation
+// security overrides the global security; hence operations declaring an empty security (e,g: public endpoints with
+// 'security: []') do not require authentication even if the global configuration contains security schemes.
 // For more information about multiple api keys refer to https://swagger.io/docs/specification/authentication/api-keys/#multiple
 func (oa apiAuth) authRequired(url string, operationSecuritySchemes SpecSecuritySchemes) (bool, SpecSecuritySchemes) {
-	// TODO: check in the OpenAPI spec whether operation overrides global schemes or can complement global configuration?
+	if operationSecuritySchemes != nil && len(operationSecuritySchemes) == 0 {
+		log.Printf("operation security for '%s' is declared empty (overriding global security config if applicable), no authentication is required", url)
+		return false, nil
+	}
 	if len(operationSecuritySchemes) != 0 {
 		log.Printf("operation security policies found for '%s' (overriding global security config if applicable). Selected the following based on order of appearance in the list %+v", url, operationSecuritySchemes)
 		return true, operationSecuritySchemes
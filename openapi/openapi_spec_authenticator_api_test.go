@@ -57,6 +57,36 @@ func TestAuthRequired(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a provider configuration containing global security schemes and an operation that declares an empty security (public endpoint)", t, func() {
+		operationSecuritySchemes := createSecuritySchemes([]map[string][]string{})
+		url := "https://www.host.com/v1/resource"
+		oa := apiAuth{
+			globalSecuritySchemes: &SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}},
+		}
+		Convey("When authRequired method is called", func() {
+			authRequired, operationSecurityPolicies := oa.authRequired(url, operationSecuritySchemes)
+			Convey("Then the operation should not require authentication since the empty security overrides the global one", func() {
+				So(authRequired, ShouldBeFalse)
+				So(operationSecurityPolicies, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a provider configuration containing global security schemes and an operation that does not declare security", t, func() {
+		operationSecuritySchemes := createSecuritySchemes(nil)
+		url := "https://www.host.com/v1/resource"
+		oa := apiAuth{
+			globalSecuritySchemes: &SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}},
+		}
+		Convey("When authRequired method is called", func() {
+			authRequired, operationSecurityPolicies := oa.authRequired(url, operationSecuritySchemes)
+			Convey("Then the global security schemes should be required", func() {
+				So(authRequired, ShouldBeTrue)
+				So(operationSecurityPolicies, ShouldResemble, SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}})
+			})
+		})
+	})
 }
 
 func TestFetchRequiredAuthenticators(t *testing.T) {
@@ -215,7 +245,7 @@ func TestPrepareAuth(t *testing.T) {
 			name:                          "apiAuthenticator set up with global security schemes that match security definitions defined in the provider configuration and the operation does not override the global security",
 			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
 			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{},
+			inputOperationSecuritySchemes: nil,
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -259,7 +289,7 @@ func TestPrepareAuth(t *testing.T) {
 			name:                          "apiAuthenticator set up with global security schemes 'apiKey' that are not defined in the provider configuration and the operation does not have any specific security scheme",
 			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}),
 			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{},
+			inputOperationSecuritySchemes: nil,
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -297,7 +327,7 @@ func TestPrepareAuth(t *testing.T) {
 			name:                          "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration but it's missing the value",
 			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
 			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{},
+			inputOperationSecuritySchemes: nil,
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
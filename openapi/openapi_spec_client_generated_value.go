@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// clientGeneratedUUID generates a random (version 4) UUID, e,g: 123e4567-e89b-42d3-a456-426614174000
+	clientGeneratedUUID = "uuid"
+	// clientGeneratedTimestamp generates the current time in RFC3339 format, e,g: 2020-01-02T15:04:05Z
+	clientGeneratedTimestamp = "timestamp"
+	// clientGeneratedRandomString generates a random string of the configured length using the configured charset
+	clientGeneratedRandomString = "random_string"
+)
+
+const clientGeneratedDefaultLength = 16
+const clientGeneratedDefaultCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// clientGeneratedValue defines how the value of a property configured with the x-terraform-client-generated extension is
+// generated by the provider when the resource is created and the user did not configure a value for the property
+type clientGeneratedValue struct {
+	// kind is one of clientGeneratedUUID, clientGeneratedTimestamp or clientGeneratedRandomString
+	kind string
+	// length and charset are only used for clientGeneratedRandomString
+	length  int
+	charset string
+}
+
+func (c *clientGeneratedValue) generate() (string, error) {
+	switch c.kind {
+	case clientGeneratedUUID:
+		return generateUUID()
+	case clientGeneratedTimestamp:
+		return time.Now().UTC().Format(time.RFC3339), nil
+	case clientGeneratedRandomString:
+		return generateRandomString(c.length, c.charset)
+	}
+	return "", fmt.Errorf("client generated value '%s' not supported", c.kind)
+}
+
+// generateUUID returns a random (version 4) UUID as described in RFC 4122
+func generateUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// generateRandomString returns a string of the given length made of characters randomly picked from the given charset
+func generateRandomString(length int, charset string) (string, error) {
+	chars := []rune(charset)
+	if length <= 0 || len(chars) == 0 {
+		return "", fmt.Errorf("the length must be a positive number and the charset must not be empty")
+	}
+	result := make([]rune, length)
+	for i := range result {
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", err
+		}
+		result[i] = chars[idx.Int64()]
+	}
+	return string(result), nil
+}
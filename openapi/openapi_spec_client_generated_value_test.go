@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientGeneratedValueGenerate(t *testing.T) {
+	Convey("Given a client generated value of kind uuid", t, func() {
+		c := &clientGeneratedValue{kind: clientGeneratedUUID}
+		Convey("When generate is called twice", func() {
+			value1, err1 := c.generate()
+			value2, err2 := c.generate()
+			Convey("Then different version 4 UUIDs should be returned", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(uuidRegex.MatchString(value1), ShouldBeTrue)
+				So(value1[14:15], ShouldEqual, "4")
+				So(value2, ShouldNotEqual, value1)
+			})
+		})
+	})
+	Convey("Given a client generated value of kind timestamp", t, func() {
+		c := &clientGeneratedValue{kind: clientGeneratedTimestamp}
+		Convey("When generate is called", func() {
+			value, err := c.generate()
+			Convey("Then the current time in RFC3339 format should be returned", func() {
+				So(err, ShouldBeNil)
+				timestamp, err := time.Parse(time.RFC3339, value)
+				So(err, ShouldBeNil)
+				So(timestamp, ShouldHappenWithin, 2*time.Second, time.Now())
+			})
+		})
+	})
+	Convey("Given a client generated value of kind random_string", t, func() {
+		c := &clientGeneratedValue{kind: clientGeneratedRandomString, length: 24, charset: "ab"}
+		Convey("When generate is called", func() {
+			value, err := c.generate()
+			Convey("Then a string of the configured length made of the charset characters should be returned", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldHaveLength, 24)
+				So(strings.Trim(value, "ab"), ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a client generated value of an unsupported kind", t, func() {
+		c := &clientGeneratedValue{kind: "sequence"}
+		Convey("When generate is called", func() {
+			_, err := c.generate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "client generated value 'sequence' not supported")
+			})
+		})
+	})
+}
+
+func TestGenerateRandomString(t *testing.T) {
+	value, err := generateRandomString(8, clientGeneratedDefaultCharset)
+	assert.Nil(t, err)
+	assert.Len(t, value, 8)
+
+	_, err = generateRandomString(0, clientGeneratedDefaultCharset)
+	assert.EqualError(t, err, "the length must be a positive number and the charset must not be empty")
+
+	_, err = generateRandomString(8, "")
+	assert.EqualError(t, err, "the length must be a positive number and the charset must not be empty")
+}
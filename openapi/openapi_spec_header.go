@@ -10,6 +10,12 @@ type SpecHeaderParam struct {
 	Name          string
 	TerraformName string
 	IsRequired    bool
+	// IsResourceAttribute defines whether the header is also exposed as an attribute of the resources which operations
+	// contain the header. The value configured in the resource takes preference over the value configured in the provider.
+	IsResourceAttribute bool
+	// IsGlobal defines whether the header is required in every operation of the OpenAPI document (e,g: X-Api-Version), in
+	// which case it's a required property of the provider and it's sent in every API request
+	IsGlobal bool
 }
 
 // GetHeaderTerraformConfigurationName returns the terraform compliant name of the header. If the header TerraformName
@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SpecInspection is a read-only view of the resources and data sources the OpenAPI provider discovers when analysing an
+// OpenAPI document. It enables external tools (e,g: catalogs, UIs) to rely on the same analysis the provider uses rather
+// than re-implementing the Swagger parsing rules.
+type SpecInspection struct {
+	// Resources contains the terraform compliant resources, including the ones configured to be ignored
+	Resources []SpecResourceInspection
+	// DataSources contains the terraform compliant data sources
+	DataSources []SpecResourceInspection
+}
+
+// SpecResourceInspection is a read-only view of a resource (or data source) as analysed by the OpenAPI provider
+type SpecResourceInspection struct {
+	// Name is the name of the resource without the provider name prefix (e,g: cdns_v1)
+	Name string
+	// Description documents what the resource manages; empty if not provided
+	Description string
+	// Path is the resource root path as defined in the OpenAPI document, including the parent path parameters for
+	// sub-resources (e,g: /v1/cdns/{cdn_id}/v1/firewalls)
+	Path string
+	// Ignored is true if the resource is configured with the x-terraform-exclude-resource extension
+	Ignored bool
+	// DeprecationMessage is the message displayed to users when the resource is deprecated; empty if not deprecated
+	DeprecationMessage string
+	// SchemaVersion is the version of the resource schema
+	SchemaVersion int
+	// ParentPropertiesNames contains the names of the properties identifying the parents of a sub-resource; empty for
+	// resources that are not sub-resources
+	ParentPropertiesNames []string
+	// Properties contains the resource properties as exposed in the terraform schema
+	Properties SpecSchemaDefinitionProperties
+	// Operations contains the operations supported by the resource (in the order list, create, read, update, delete and
+	// exists)
+	Operations []SpecOperationInspection
+	// Extensions contains the x-terraform-* extensions configured at the resource level (path items and model definition)
+	Extensions map[string]interface{}
+}
+
+// SpecOperationInspection is a read-only view of a resource operation as analysed by the OpenAPI provider
+type SpecOperationInspection struct {
+	// Name is the name of the operation: list, create, read, update, delete or exists
+	Name string
+	// Method is the HTTP method the operation is performed with
+	Method string
+	// OperationID is the operationId of the operation in the OpenAPI document; empty if the operation has no operationId
+	OperationID string
+	// HeaderParameters contains the header parameters sent along with the operation requests
+	HeaderParameters SpecHeaderParameters
+	// QueryParameters contains the query parameters sent along with the operation requests
+	QueryParameters SpecQueryParameters
+	// SecuritySchemes contains the security schemes that apply specifically to the operation
+	SecuritySchemes SpecSecuritySchemes
+	// SuccessStatusCodes contains the response status codes the provider considers successful for the operation
+	SuccessStatusCodes []int
+	// Extensions contains the x-terraform-* extensions configured in the operation
+	Extensions map[string]interface{}
+}
+
+// inspectableSpecResource is implemented by the SpecResources that expose the information from the OpenAPI document
+// which is not available through the SpecResource interface, such as the raw resource path and the extensions
+type inspectableSpecResource interface {
+	// getPathTemplate returns the resource root path without resolving the parent path parameters
+	getPathTemplate() string
+	// getResourceExtensions returns the x-terraform-* extensions configured at the resource level
+	getResourceExtensions() map[string]interface{}
+}
+
+// InspectSpec returns a read-only view of the resources and data sources discovered by the given SpecAnalyser
+func InspectSpec(specAnalyser SpecAnalyser) (*SpecInspection, error) {
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	specInspection := &SpecInspection{}
+	for _, resource := range resources {
+		resourceInspection, err := InspectResource(resource)
+		if err != nil {
+			return nil, err
+		}
+		specInspection.Resources = append(specInspection.Resources, *resourceInspection)
+	}
+	for _, dataSource := range specAnalyser.GetTerraformCompliantDataSources() {
+		dataSourceInspection, err := InspectResource(dataSource)
+		if err != nil {
+			return nil, err
+		}
+		specInspection.DataSources = append(specInspection.DataSources, *dataSourceInspection)
+	}
+	return specInspection, nil
+}
+
+// InspectResource returns a read-only view of the given SpecResource
+func InspectResource(resource SpecResource) (*SpecResourceInspection, error) {
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	schemaVersion, err := resource.getSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	resourceInspection := &SpecResourceInspection{
+		Name:               resource.GetResourceName(),
+		Description:        resource.GetResourceDescription(),
+		Ignored:            resource.ShouldIgnoreResource(),
+		DeprecationMessage: resource.getDeprecationMessage(),
+		SchemaVersion:      schemaVersion,
+		Operations:         inspectResourceOperations(resource.getResourceOperations()),
+		Extensions:         map[string]interface{}{},
+	}
+	if resourceSchema != nil {
+		resourceInspection.Properties = resourceSchema.Properties
+	}
+	if parentResourceInfo := resource.GetParentResourceInfo(); parentResourceInfo != nil {
+		resourceInspection.ParentPropertiesNames = parentResourceInfo.GetParentPropertiesNames()
+	}
+	if inspectableResource, ok := resource.(inspectableSpecResource); ok {
+		resourceInspection.Path = inspectableResource.getPathTemplate()
+		resourceInspection.Extensions = inspectableResource.getResourceExtensions()
+	}
+	return resourceInspection, nil
+}
+
+// getOperationIDs returns the operationId of the given operations indexed by the operation name (list, create, read,
+// update, delete or exists); operations with no operationId are not included
+func getOperationIDs(operations specResourceOperations) map[string]string {
+	operationIDs := map[string]string{}
+	for _, operation := range inspectResourceOperations(operations) {
+		if operation.OperationID != "" {
+			operationIDs[operation.Name] = operation.OperationID
+		}
+	}
+	return operationIDs
+}
+
+func inspectResourceOperations(operations specResourceOperations) []SpecOperationInspection {
+	operationInspections := []SpecOperationInspection{}
+	for _, o := range []struct {
+		name               string
+		method             string
+		operation          *specResourceOperation
+		defaultStatusCodes []int
+	}{
+		{"list", http.MethodGet, operations.List, []int{http.StatusOK}},
+		{"create", http.MethodPost, operations.Post, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}},
+		{"read", http.MethodGet, operations.Get, []int{http.StatusOK}},
+		{"update", http.MethodPut, operations.Put, []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}},
+		{"delete", http.MethodDelete, operations.Delete, []int{http.StatusNoContent, http.StatusOK, http.StatusAccepted}},
+		{"exists", http.MethodHead, operations.Head, []int{http.StatusOK, http.StatusNoContent}},
+	} {
+		if o.operation == nil {
+			continue
+		}
+		extensions := o.operation.extensions
+		if extensions == nil {
+			extensions = map[string]interface{}{}
+		}
+		operationInspections = append(operationInspections, SpecOperationInspection{
+			Name:               o.name,
+			Method:             o.method,
+			OperationID:        o.operation.operationID,
+			HeaderParameters:   o.operation.HeaderParameters,
+			QueryParameters:    o.operation.QueryParameters,
+			SecuritySchemes:    o.operation.SecuritySchemes,
+			SuccessStatusCodes: o.operation.getSuccessStatusCodes(o.defaultStatusCodes),
+			Extensions:         extensions,
+		})
+	}
+	return operationInspections
+}
+
+// getTerraformExtensions returns the x-terraform-* extensions contained in the given extensions
+func getTerraformExtensions(extensions map[string]interface{}) map[string]interface{} {
+	terraformExtensions := map[string]interface{}{}
+	for key, value := range extensions {
+		if strings.HasPrefix(strings.ToLower(key), "x-terraform-") {
+			terraformExtensions[strings.ToLower(key)] = value
+		}
+	}
+	return terraformExtensions
+}
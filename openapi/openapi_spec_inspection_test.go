@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInspectResource(t *testing.T) {
+	Convey("Given a SpecV2Resource configured with resource and operation level extensions", t, func() {
+		rootPathItem := spec.PathItem{
+			VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceName: "cdn", "x-other-extension": "ignored"}},
+			PathItemProps: spec.PathItemProps{
+				Post: &spec.Operation{
+					VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceTimeout: "30s", extTfResourceSchemaVersion: "2"}},
+					OperationProps: spec.OperationProps{
+						ID:      "createCDN",
+						Summary: "Manages CDNs",
+						Responses: &spec.Responses{
+							ResponsesProps: spec.ResponsesProps{StatusCodeResponses: map[int]spec.Response{http.StatusCreated: {}}},
+						},
+					},
+				},
+			},
+		}
+		instancePathItem := spec.PathItem{
+			PathItemProps: spec.PathItemProps{
+				Get: &spec.Operation{
+					OperationProps: spec.OperationProps{
+						Responses: &spec.Responses{
+							ResponsesProps: spec.ResponsesProps{StatusCodeResponses: map[int]spec.Response{http.StatusOK: {}}},
+						},
+					},
+				},
+				Delete: &spec.Operation{
+					VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceExistenceCheck: true}},
+					OperationProps: spec.OperationProps{
+						Responses: &spec.Responses{
+							ResponsesProps: spec.ResponsesProps{StatusCodeResponses: map[int]spec.Response{http.StatusNoContent: {}}},
+						},
+					},
+				},
+			},
+		}
+		schema := spec.Schema{
+			VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{"x-terraform-docs-category": "networking"}},
+			SchemaProps: spec.SchemaProps{
+				Properties: map[string]spec.Schema{
+					"id":    {SchemaProps: spec.SchemaProps{Type: []string{"string"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+					"label": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+				Required: []string{"label"},
+			},
+		}
+		r, err := newSpecV2Resource("/v1/cdns", schema, rootPathItem, instancePathItem, map[string]spec.Schema{}, map[string]spec.PathItem{})
+		So(err, ShouldBeNil)
+		Convey("When InspectResource is called", func() {
+			resourceInspection, err := InspectResource(r)
+			Convey("Then the resource inspection returned should contain the resource as analysed by the provider", func() {
+				So(err, ShouldBeNil)
+				So(resourceInspection.Name, ShouldEqual, "cdn_v1")
+				So(resourceInspection.Description, ShouldEqual, "Manages CDNs")
+				So(resourceInspection.Path, ShouldEqual, "/v1/cdns")
+				So(resourceInspection.Ignored, ShouldBeFalse)
+				So(resourceInspection.SchemaVersion, ShouldEqual, 2)
+				So(resourceInspection.ParentPropertiesNames, ShouldBeEmpty)
+				So(resourceInspection.Properties, ShouldHaveLength, 2)
+				So(resourceInspection.Extensions, ShouldResemble, map[string]interface{}{extTfResourceName: "cdn", "x-terraform-docs-category": "networking"})
+			})
+			Convey("And the operations should be returned in order with their extensions and success status codes", func() {
+				So(resourceInspection.Operations, ShouldHaveLength, 3)
+				So(resourceInspection.Operations[0].Name, ShouldEqual, "create")
+				So(resourceInspection.Operations[0].Method, ShouldEqual, http.MethodPost)
+				So(resourceInspection.Operations[0].OperationID, ShouldEqual, "createCDN")
+				So(resourceInspection.Operations[0].SuccessStatusCodes, ShouldResemble, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted})
+				So(resourceInspection.Operations[0].Extensions, ShouldResemble, map[string]interface{}{extTfResourceTimeout: "30s", extTfResourceSchemaVersion: "2"})
+				So(resourceInspection.Operations[1].Name, ShouldEqual, "read")
+				So(resourceInspection.Operations[1].Method, ShouldEqual, http.MethodGet)
+				So(resourceInspection.Operations[1].OperationID, ShouldBeEmpty)
+				So(r.GetOperationIDs(), ShouldResemble, map[string]string{"create": "createCDN"})
+				So(resourceInspection.Operations[1].Extensions, ShouldBeEmpty)
+				So(resourceInspection.Operations[2].Name, ShouldEqual, "delete")
+				So(resourceInspection.Operations[2].Method, ShouldEqual, http.MethodDelete)
+				So(resourceInspection.Operations[2].Extensions, ShouldResemble, map[string]interface{}{extTfResourceExistenceCheck: true})
+			})
+		})
+	})
+	Convey("Given a SpecResource which schema can not be loaded", t, func() {
+		r := &specStubResource{error: errors.New("some error")}
+		Convey("When InspectResource is called", func() {
+			_, err := InspectResource(r)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "some error")
+			})
+		})
+	})
+}
+
+func TestInspectSpec(t *testing.T) {
+	Convey("Given a SpecAnalyser with resources and data sources", t, func() {
+		specAnalyser := &specAnalyserStub{
+			resources: []SpecResource{
+				newSpecStubResource("cdn_v1", "/v1/cdns", false, &SpecSchemaDefinition{}),
+				newSpecStubResource("lb_v1", "/v1/lbs", true, &SpecSchemaDefinition{}),
+			},
+			dataSources: []SpecResource{newSpecStubResource("cdn_v1", "/v1/cdns", false, &SpecSchemaDefinition{})},
+		}
+		Convey("When InspectSpec is called", func() {
+			specInspection, err := InspectSpec(specAnalyser)
+			Convey("Then all the resources and data sources should be returned, including the ignored ones", func() {
+				So(err, ShouldBeNil)
+				So(specInspection.Resources, ShouldHaveLength, 2)
+				So(specInspection.Resources[0].Name, ShouldEqual, "cdn_v1")
+				So(specInspection.Resources[1].Name, ShouldEqual, "lb_v1")
+				So(specInspection.Resources[1].Ignored, ShouldBeTrue)
+				So(specInspection.DataSources, ShouldHaveLength, 1)
+				So(specInspection.DataSources[0].Name, ShouldEqual, "cdn_v1")
+			})
+		})
+	})
+	Convey("Given a SpecAnalyser that fails to load the resources", t, func() {
+		specAnalyser := &specAnalyserStub{error: errors.New("some error")}
+		Convey("When InspectSpec is called", func() {
+			_, err := InspectSpec(specAnalyser)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "some error")
+			})
+		})
+	})
+}
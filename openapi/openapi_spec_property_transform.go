@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	// propertyTransformLowercase converts the value to lower case, e,g: "MyBucket" is sent as "mybucket"
+	propertyTransformLowercase = "lowercase"
+	// propertyTransformTrim removes the leading and trailing white spaces of the value
+	propertyTransformTrim = "trim"
+	// propertyTransformBase64Encode encodes the value in base64 (standard encoding); the value returned by the API is decoded
+	propertyTransformBase64Encode = "base64-encode"
+	// propertyTransformPrefix prepends the prefix following the colon to the value (e,g: prefix:projects/); the prefix is
+	// removed from the value returned by the API
+	propertyTransformPrefix = "prefix:"
+)
+
+// propertyTransform defines a transformation configured with the x-terraform-transform extension that is applied to the
+// value configured by the user before it is sent to the API. The inverse transformation is applied to the value returned
+// by the API when the resource is read, if the transformation is reversible.
+type propertyTransform struct {
+	// kind is one of propertyTransformLowercase, propertyTransformTrim, propertyTransformBase64Encode or propertyTransformPrefix
+	kind string
+	// prefix is only used for propertyTransformPrefix
+	prefix string
+}
+
+// propertyTransforms contains the transformations of a property in the order they are applied
+type propertyTransforms []propertyTransform
+
+// newPropertyTransform returns the transformation described by the given x-terraform-transform value
+func newPropertyTransform(transform string) (propertyTransform, error) {
+	switch {
+	case transform == propertyTransformLowercase, transform == propertyTransformTrim, transform == propertyTransformBase64Encode:
+		return propertyTransform{kind: transform}, nil
+	case strings.HasPrefix(transform, propertyTransformPrefix) && len(transform) > len(propertyTransformPrefix):
+		return propertyTransform{kind: propertyTransformPrefix, prefix: strings.TrimPrefix(transform, propertyTransformPrefix)}, nil
+	}
+	return propertyTransform{}, fmt.Errorf("transform '%s' not supported, supported values are: %s, %s, %s and %s<value>", transform, propertyTransformLowercase, propertyTransformTrim, propertyTransformBase64Encode, propertyTransformPrefix)
+}
+
+func (t propertyTransform) apply(value string) string {
+	switch t.kind {
+	case propertyTransformLowercase:
+		return strings.ToLower(value)
+	case propertyTransformTrim:
+		return strings.TrimSpace(value)
+	case propertyTransformBase64Encode:
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	case propertyTransformPrefix:
+		return t.prefix + value
+	}
+	return value
+}
+
+// revert returns the value before the transformation was applied. Lossy transformations (lowercase and trim) return the
+// value as is, as do the base64 transformations if the value is not base64 encoded.
+func (t propertyTransform) revert(value string) string {
+	switch t.kind {
+	case propertyTransformBase64Encode:
+		decodedValue, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			log.Printf("[WARN] value '%s' could not be base64 decoded, keeping the value returned by the API: %s", value, err)
+			return value
+		}
+		return string(decodedValue)
+	case propertyTransformPrefix:
+		return strings.TrimPrefix(value, t.prefix)
+	}
+	return value
+}
+
+// apply returns the value resulting of applying all the transformations in order. Empty values are not transformed as
+// they mean the property is not configured.
+func (t propertyTransforms) apply(value string) string {
+	if value == "" {
+		return value
+	}
+	for _, transform := range t {
+		value = transform.apply(value)
+	}
+	return value
+}
+
+// revert returns the value resulting of reverting all the transformations in the reverse order
+func (t propertyTransforms) revert(value string) string {
+	if value == "" {
+		return value
+	}
+	for i := len(t) - 1; i >= 0; i-- {
+		value = t[i].revert(value)
+	}
+	return value
+}
+
+// equivalent returns true if both values are sent to the API with the same value once the transformations are applied
+// (e,g: "MyBucket" and "mybucket" with the lowercase transformation)
+func (t propertyTransforms) equivalent(value, otherValue string) bool {
+	return t.apply(value) == t.apply(otherValue)
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPropertyTransform(t *testing.T) {
+	testCases := []struct {
+		name              string
+		transform         string
+		expectedTransform propertyTransform
+		expectedError     string
+	}{
+		{name: "lowercase transform", transform: "lowercase", expectedTransform: propertyTransform{kind: propertyTransformLowercase}},
+		{name: "trim transform", transform: "trim", expectedTransform: propertyTransform{kind: propertyTransformTrim}},
+		{name: "base64 encode transform", transform: "base64-encode", expectedTransform: propertyTransform{kind: propertyTransformBase64Encode}},
+		{name: "prefix transform", transform: "prefix:projects/", expectedTransform: propertyTransform{kind: propertyTransformPrefix, prefix: "projects/"}},
+		{name: "prefix transform without prefix", transform: "prefix:", expectedError: "transform 'prefix:' not supported, supported values are: lowercase, trim, base64-encode and prefix:<value>"},
+		{name: "unsupported transform", transform: "uppercase", expectedError: "transform 'uppercase' not supported, supported values are: lowercase, trim, base64-encode and prefix:<value>"},
+	}
+	for _, tc := range testCases {
+		transform, err := newPropertyTransform(tc.transform)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedTransform, transform, tc.name)
+	}
+}
+
+func TestPropertyTransforms(t *testing.T) {
+	Convey("Given property transforms that trim, lowercase, base64 encode and prefix the value", t, func() {
+		transforms := propertyTransforms{
+			{kind: propertyTransformTrim},
+			{kind: propertyTransformLowercase},
+			{kind: propertyTransformBase64Encode},
+			{kind: propertyTransformPrefix, prefix: "b64:"},
+		}
+		Convey("When apply is called with a value", func() {
+			value := transforms.apply(" MyValue ")
+			Convey("Then the transformations should be applied in order", func() {
+				So(value, ShouldEqual, "b64:bXl2YWx1ZQ==")
+			})
+		})
+		Convey("When revert is called with a value returned by the API", func() {
+			value := transforms.revert("b64:bXl2YWx1ZQ==")
+			Convey("Then the reversible transformations should be reverted in the reverse order", func() {
+				So(value, ShouldEqual, "myvalue")
+			})
+		})
+		Convey("When revert is called with a value that is not base64 encoded", func() {
+			value := transforms.revert("b64:not base64")
+			Convey("Then the value should be kept as is once the prefix is removed", func() {
+				So(value, ShouldEqual, "not base64")
+			})
+		})
+		Convey("When apply and revert are called with an empty value", func() {
+			Convey("Then the value should not be transformed", func() {
+				So(transforms.apply(""), ShouldEqual, "")
+				So(transforms.revert(""), ShouldEqual, "")
+			})
+		})
+		Convey("When equivalent is called with values that are transformed to the same value", func() {
+			Convey("Then the result should be true", func() {
+				So(transforms.equivalent("myvalue", " MyValue"), ShouldBeTrue)
+			})
+		})
+		Convey("When equivalent is called with values that are transformed to different values", func() {
+			Convey("Then the result should be false", func() {
+				So(transforms.equivalent("myvalue", "othervalue"), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,35 @@
+package openapi
+
+import "github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+
+// SpecQueryParameters groups a list of SpecQueryParam
+type SpecQueryParameters []SpecQueryParam
+
+// SpecQueryParam defines the properties for a non auth query parameter declared in a resource operation
+type SpecQueryParam struct {
+	Name string
+	// Value contains the constant value pinned in the x-terraform-query-param-value extension. The value configured in
+	// the resource attribute (if the query parameter is exposed as a resource attribute) takes preference.
+	Value      string
+	IsRequired bool
+	// IsResourceAttribute defines whether the query parameter is exposed as an attribute of the resources which operations
+	// contain the query parameter.
+	IsResourceAttribute bool
+	// IsFilter defines whether the query parameter is neither pinned to a value nor exposed as a resource attribute, in
+	// which case it is only sent with the value of the data source filter matching its name (e,g: ?label=)
+	IsFilter bool
+}
+
+// GetQueryParamTerraformName returns the terraform compliant name of the query parameter
+func (q SpecQueryParam) GetQueryParamTerraformName() string {
+	return terraformutils.ConvertToTerraformCompliantName(q.Name)
+}
+
+func (s SpecQueryParameters) specQueryParamExists(specQueryParam SpecQueryParam) bool {
+	for _, registeredQueryParam := range s {
+		if registeredQueryParam.GetQueryParamTerraformName() == specQueryParam.GetQueryParamTerraformName() {
+			return true
+		}
+	}
+	return false
+}
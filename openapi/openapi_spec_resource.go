@@ -8,11 +8,32 @@ import (
 type SpecResource interface {
 	GetResourceName() string
 	getHost() (string, error)
+	// getBasePath returns the base path that overrides the global base path for the resource API calls ("/" meaning no
+	// base path at all); empty if the resource uses the global base path
+	getBasePath() string
 	getResourcePath(parentIDs []string) (string, error)
 	GetResourceSchema() (*SpecSchemaDefinition, error)
 	ShouldIgnoreResource() bool
 	getResourceOperations() specResourceOperations
 	getTimeouts() (*specTimeouts, error)
+	// getSchemaVersion returns the version of the resource schema which is used to upgrade the existing states when the
+	// schema changes
+	getSchemaVersion() (int, error)
+	// getDeprecationMessage returns the message displayed to users when the resource is deprecated; empty if the resource
+	// is not deprecated
+	getDeprecationMessage() string
+	// getResourceNameAliases returns the previous names of the resource which are registered too (as deprecated) so
+	// existing states and configurations keep working while users migrate to the current name
+	getResourceNameAliases() []string
+	// getResponseHeaderAttributes returns the response headers exposed as computed attributes of the resource, indexed by
+	// the terraform name of the attribute; empty if none are configured
+	getResponseHeaderAttributes() map[string]string
+	// GetResourceDescription returns the summary of the operation the resource is based on (or its description if the
+	// summary is not provided), which documents what the resource manages; empty if none are provided
+	GetResourceDescription() string
+	// GetOperationIDs returns the operationId of the resource operations indexed by the operation name (list, create,
+	// read, update, delete or exists); operations with no operationId are not included
+	GetOperationIDs() map[string]string
 	// GetParentResourceInfo returns a struct populated with relevant ParentResourceInfo if the resource is considered
 	// a sub-resource; nil otherwise.
 	GetParentResourceInfo() *ParentResourceInfo
@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	"regexp"
+)
+
+// resourceNameVersionRegex matches the resource names which end with the version of the resource path (e,g: cdns_v1),
+// capturing the name without the version and the version
+var resourceNameVersionRegex = regexp.MustCompile(`^(.+)_(v\d+)$`)
+
+// apiVersionSpecResource is a SpecResource which API calls are made against the path of a different version of the
+// resource (e,g: /v2/cdns instead of /v1/cdns). The rest of the resource configuration (schema, operations, etc) is the
+// one of the wrapped resource.
+type apiVersionSpecResource struct {
+	SpecResource
+	// apiVersionResource is the resource exposed in the version the API calls are made against
+	apiVersionResource SpecResource
+}
+
+func (a apiVersionSpecResource) getResourcePath(parentIDs []string) (string, error) {
+	return a.apiVersionResource.getResourcePath(parentIDs)
+}
+
+// getResourceAPIVersions returns the versions of the resources exposed in multiple versions (e,g: cdns_v1 and cdns_v2)
+// indexed by the resource name. The versions of each resource are indexed by the version (e,g: v1), and the resources
+// exposed in a single version are left out.
+func getResourceAPIVersions(openAPIResources []SpecResource) map[string]map[string]SpecResource {
+	resourcesByName := map[string]map[string]SpecResource{}
+	for _, openAPIResource := range openAPIResources {
+		if openAPIResource.ShouldIgnoreResource() {
+			continue
+		}
+		matches := resourceNameVersionRegex.FindStringSubmatch(openAPIResource.GetResourceName())
+		if len(matches) != 3 {
+			continue
+		}
+		if _, exists := resourcesByName[matches[1]]; !exists {
+			resourcesByName[matches[1]] = map[string]SpecResource{}
+		}
+		resourcesByName[matches[1]][matches[2]] = openAPIResource
+	}
+	apiVersions := map[string]map[string]SpecResource{}
+	for _, versions := range resourcesByName {
+		if len(versions) < 2 {
+			continue
+		}
+		for _, openAPIResource := range versions {
+			apiVersions[openAPIResource.GetResourceName()] = versions
+		}
+	}
+	return apiVersions
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetResourceAPIVersions(t *testing.T) {
+	Convey("Given a list of resources where some of them are exposed in multiple versions", t, func() {
+		cdnsV1 := newSpecStubResource("cdns_v1", "/v1/cdns", false, nil)
+		cdnsV2 := newSpecStubResource("cdns_v2", "/v2/cdns", false, nil)
+		usersV1 := newSpecStubResource("users_v1", "/v1/users", false, nil)
+		groups := newSpecStubResource("groups", "/groups", false, nil)
+		networksV1 := newSpecStubResource("networks_v1", "/v1/networks", false, nil)
+		ignoredNetworksV2 := newSpecStubResource("networks_v2", "/v2/networks", true, nil)
+		Convey("When getResourceAPIVersions is called", func() {
+			apiVersions := getResourceAPIVersions([]SpecResource{cdnsV1, cdnsV2, usersV1, groups, networksV1, ignoredNetworksV2})
+			Convey("Then the versions of the resources exposed in multiple versions should be returned", func() {
+				So(apiVersions, ShouldHaveLength, 2)
+				So(apiVersions["cdns_v1"], ShouldResemble, map[string]SpecResource{"v1": cdnsV1, "v2": cdnsV2})
+				So(apiVersions["cdns_v2"], ShouldResemble, map[string]SpecResource{"v1": cdnsV1, "v2": cdnsV2})
+			})
+			Convey("And the resources exposed in a single version (not counting the ignored resources) should be left out", func() {
+				So(apiVersions, ShouldNotContainKey, "users_v1")
+				So(apiVersions, ShouldNotContainKey, "groups")
+				So(apiVersions, ShouldNotContainKey, "networks_v1")
+			})
+		})
+	})
+}
+
+func TestAPIVersionSpecResourceGetResourcePath(t *testing.T) {
+	Convey("Given a resource wrapped to make the API calls against the path of a different version", t, func() {
+		resource := apiVersionSpecResource{
+			SpecResource:       newSpecStubResource("cdns_v1", "/v1/cdns", false, nil),
+			apiVersionResource: newSpecStubResource("cdns_v2", "/v2/cdns", false, nil),
+		}
+		Convey("When getResourcePath is called", func() {
+			path, err := resource.getResourcePath(nil)
+			Convey("Then the path of the other version should be returned and the rest of the configuration should be kept", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/v2/cdns")
+				So(resource.GetResourceName(), ShouldEqual, "cdns_v1")
+			})
+		})
+	})
+}
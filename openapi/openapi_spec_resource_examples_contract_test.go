@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestSpecExamplesRoundTrip uses the examples defined in the example OpenAPI documents (the resource schema definition
+// 'example' and the GET 200 response 'examples') to verify that payloads can be converted into terraform state and back
+// without losing information. Any lossy conversion is reported as a failure, catching unsupported constructs before
+// users hit them. New example documents can be added to the testdata/schemas folder.
+func TestSpecExamplesRoundTrip(t *testing.T) {
+	specFiles, err := goldenSpecFiles(goldenSchemasDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, specFile := range specFiles {
+		Convey(fmt.Sprintf("Given the example OpenAPI document '%s'", specFile), t, func() {
+			a, err := newSpecAnalyserV2(specFile)
+			So(err, ShouldBeNil)
+			resources, err := a.GetTerraformCompliantResources()
+			So(err, ShouldBeNil)
+			for _, resource := range resources {
+				examples := getResourceExamples(resource)
+				for idx, example := range examples {
+					Convey(fmt.Sprintf("When the example %d of resource '%s' is converted to terraform state and back to a payload", idx, resource.GetResourceName()), func() {
+						lossyConversions, err := checkExampleRoundTrip(resource, example)
+						Convey("Then the conversion should not lose any information", func() {
+							So(err, ShouldBeNil)
+							So(lossyConversions, ShouldBeEmpty)
+						})
+					})
+				}
+			}
+		})
+	}
+}
+
+func TestCheckExampleRoundTrip(t *testing.T) {
+	Convey("Given a resource with a float property nested in an object and a readOnly property", t, func() {
+		r := newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				idProperty,
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("status", "", false, true, nil),
+				&SpecSchemaDefinitionProperty{
+					Name: "object_property",
+					Type: TypeObject,
+					SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "weight", Type: TypeFloat},
+						},
+					},
+				},
+			},
+		})
+		Convey("When checkExampleRoundTrip is called with an example that can be converted without losing information", func() {
+			lossyConversions, err := checkExampleRoundTrip(r, map[string]interface{}{"id": "some-id", "label": "label", "status": "deployed", "object_property": map[string]interface{}{"weight": 1.5}})
+			Convey("Then no lossy conversions should be reported", func() {
+				So(err, ShouldBeNil)
+				So(lossyConversions, ShouldBeEmpty)
+			})
+		})
+		Convey("When checkExampleRoundTrip is called with an example that loses precision in the object float property", func() {
+			lossyConversions, err := checkExampleRoundTrip(r, map[string]interface{}{"label": "label", "object_property": map[string]interface{}{"weight": 1.234}})
+			Convey("Then the lossy conversion should be reported", func() {
+				So(err, ShouldBeNil)
+				So(lossyConversions, ShouldResemble, []string{"property 'object_property': example value map[weight:1.234] does not match round trip value map[weight:1.23]"})
+			})
+		})
+		Convey("When checkExampleRoundTrip is called with an example containing properties not defined in the schema", func() {
+			lossyConversions, err := checkExampleRoundTrip(r, map[string]interface{}{"label": "label", "unknown": "value"})
+			Convey("Then the unknown property should be reported", func() {
+				So(err, ShouldBeNil)
+				So(lossyConversions, ShouldResemble, []string{"property 'unknown': not defined in the resource schema"})
+			})
+		})
+	})
+}
+
+// getResourceExamples returns the object examples defined in the resource schema definition and the GET 200 response
+func getResourceExamples(resource SpecResource) []map[string]interface{} {
+	var examples []map[string]interface{}
+	r, ok := resource.(*SpecV2Resource)
+	if !ok {
+		return examples
+	}
+	if example, ok := r.SchemaDefinition.Example.(map[string]interface{}); ok {
+		examples = append(examples, example)
+	}
+	if r.InstancePathItem.Get != nil && r.InstancePathItem.Get.Responses != nil {
+		if response, exists := r.InstancePathItem.Get.Responses.StatusCodeResponses[http.StatusOK]; exists {
+			var mimeTypes []string
+			for mimeType := range response.Examples {
+				mimeTypes = append(mimeTypes, mimeType)
+			}
+			sort.Strings(mimeTypes)
+			for _, mimeType := range mimeTypes {
+				if example, ok := response.Examples[mimeType].(map[string]interface{}); ok {
+					examples = append(examples, example)
+				}
+			}
+		}
+	}
+	return examples
+}
+
+// checkExampleRoundTrip saves the example payload into a terraform state, builds back the request payload from that state
+// and returns the list of properties which values are not the same as in the example. ReadOnly properties, the id and
+// parent properties are not expected to be part of the request payload and therefore are not compared.
+func checkExampleRoundTrip(resource SpecResource, example map[string]interface{}) ([]string, error) {
+	r := newResourceFactory(resource)
+	s, err := r.createTerraformResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	resourceData := (&schema.Resource{Schema: s}).Data(nil)
+	if err := updateStateWithPayloadData(resource, example, resourceData); err != nil {
+		return nil, err
+	}
+	payload := r.createPayloadFromLocalStateData(resourceData)
+
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	var propertyNames []string
+	for propertyName := range example {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+
+	lossyConversions := []string{}
+	for _, propertyName := range propertyNames {
+		property, err := resourceSchema.getProperty(propertyName)
+		if err != nil {
+			lossyConversions = append(lossyConversions, fmt.Sprintf("property '%s': not defined in the resource schema", propertyName))
+			continue
+		}
+		if property.isReadOnly() || property.isPropertyNamedID() || property.IsParentProperty {
+			continue
+		}
+		expected, err := normalizeExampleValue(writableExampleValue(property, example[propertyName]))
+		if err != nil {
+			return nil, err
+		}
+		actual, err := normalizeExampleValue(payload[propertyName])
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			lossyConversions = append(lossyConversions, fmt.Sprintf("property '%s': example value %v does not match round trip value %v", propertyName, expected, actual))
+		}
+	}
+	return lossyConversions, nil
+}
+
+// writableExampleValue removes from object values the nested readOnly properties as these are not sent in the request payloads
+func writableExampleValue(property *SpecSchemaDefinitionProperty, value interface{}) interface{} {
+	if property.SpecSchemaDefinition == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object := map[string]interface{}{}
+		for name, nestedValue := range v {
+			nestedProperty, err := property.SpecSchemaDefinition.getProperty(name)
+			if err != nil {
+				object[name] = nestedValue
+				continue
+			}
+			if nestedProperty.isReadOnly() {
+				continue
+			}
+			object[name] = writableExampleValue(nestedProperty, nestedValue)
+		}
+		return object
+	case []interface{}:
+		items := []interface{}{}
+		for _, item := range v {
+			items = append(items, writableExampleValue(property, item))
+		}
+		return items
+	}
+	return value
+}
+
+// normalizeExampleValue serializes the value and reads it back so numbers are compared regardless of their go type
+func normalizeExampleValue(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
@@ -1,16 +1,260 @@
 package openapi
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
 type specResourceOperations struct {
 	List   *specResourceOperation
 	Post   *specResourceOperation
 	Get    *specResourceOperation
 	Put    *specResourceOperation
 	Delete *specResourceOperation
+	// Head is set for resources which instance path declares a HEAD operation, which is used to check whether the resource
+	// still exists without reading it
+	Head *specResourceOperation
 }
 
 // specResourceOperation defines a resource operation
 type specResourceOperation struct {
+	// operationID is the operationId of the operation in the OpenAPI document, which is included in the logs and the
+	// telemetry so they can be correlated with the API documentation and the server side logs
+	operationID      string
 	SecuritySchemes  SpecSecuritySchemes
 	HeaderParameters SpecHeaderParameters
+	QueryParameters  SpecQueryParameters
 	responses        specResponses
+	// successStatusCodes contains the status codes configured with the x-terraform-success-status-codes extension. If
+	// populated, these override the successful status codes documented in the operation responses.
+	successStatusCodes []int
+	// successConditions contains the conditions configured with the x-terraform-success-conditions extension that the
+	// response payload must meet for the operation to be considered successful (e,g: APIs returning 200 with an embedded
+	// error object). Only supported in POST operations
+	successConditions []specPollCondition
+	// existenceCheckEnabled is set to true for DELETE operations configured with the x-terraform-resource-existence-check
+	// extension, in which case the resource is read before it's deleted and the deletion is skipped if it no longer exists
+	existenceCheckEnabled bool
+	// conditionalRequestEnabled is set to true for PUT and DELETE operations configured with the
+	// x-terraform-conditional-request extension, in which case the requests are sent with the If-Unmodified-Since header
+	conditionalRequestEnabled bool
+	// retryableErrors contains the errors configured with the x-terraform-retryable-errors extension that should be
+	// retried with backoff instead of failing straight away
+	retryableErrors []specRetryableError
+	// retryBackoff is set for operations of resources configured with the x-terraform-retry-* extensions and overrides
+	// the default backoff used to retry the retryable errors
+	retryBackoff *specRetryBackoff
+	// pagination is set for list operations configured with the x-terraform-pagination extension and describes how the
+	// subsequent pages of the list are fetched
+	pagination *specPagination
+	// pageSizeParam is set for list operations configured with the x-terraform-pagination-page-size-param extension and
+	// contains the query parameter used to request the page size configured by the user
+	pageSizeParam string
+	// maxPageSize is set for list operations configured with the x-terraform-pagination-max-page-size extension and caps
+	// the page size requested. Zero means no limit
+	maxPageSize int
+	// mostRecentProperty is set for list operations configured with the x-terraform-most-recent-property extension and
+	// contains the timestamp property used to select the most recent item when several items match the data source filters
+	mostRecentProperty string
+	// host is set for operations configured with the x-terraform-operation-host extension and overrides the host the
+	// API calls for the operation are made against
+	host string
+	// deleteMaxConcurrency is set for DELETE operations configured with the x-terraform-delete-max-concurrency extension
+	// and limits the number of instances of the resource deleted concurrently. Zero means no limit
+	deleteMaxConcurrency int
+	// bulkDelete is set for DELETE operations configured with the x-terraform-bulk-delete-path extension, in which case the
+	// instances are deleted in batches calling the bulk delete endpoint instead
+	bulkDelete *specBulkDelete
+	// preDelete is set for DELETE operations configured with the x-terraform-pre-delete-method extension and describes
+	// the request performed before the resource is deleted (e,g: disabling the resource)
+	preDelete *specPreDelete
+	// batchRead is set for list operations configured with the x-terraform-batch-read-ids-param extension, in which case
+	// the reads of the instances performed during the refresh are coalesced into list calls filtered by the instance IDs
+	batchRead *specBatchRead
+	// errorParser parses the error code and message returned by the API in the error responses of the operation, selected
+	// with the x-terraform-error-format extension. If nil, the defaultAPIErrorParser is used
+	errorParser apiErrorParser
+	// extensions contains the x-terraform-* extensions configured in the operation, which are exposed as is when the
+	// analysed spec is inspected
+	extensions map[string]interface{}
+}
+
+const bulkDeleteDefaultIDsProperty = "ids"
+const bulkDeleteDefaultMaxBatchSize = 100
+
+// specBulkDelete defines the bulk delete endpoint used to delete several instances of a resource with a single request
+type specBulkDelete struct {
+	// path is the path of the bulk delete endpoint (relative to the base path). It may contain the same path parameters
+	// as the resource path, which are resolved with the parent IDs (e,g: /v1/cdns/{cdn_id}/firewalls/bulk-delete)
+	path string
+	// idsProperty is the request payload property containing the list of IDs to delete
+	idsProperty string
+	// maxBatchSize is the max number of IDs sent in a single request
+	maxBatchSize int
+}
+
+// specPreDelete defines the request the API requires before a resource instance can be deleted (e,g: PATCH the resource
+// with status=disabled)
+type specPreDelete struct {
+	// method is the HTTP method of the request: POST, PUT or PATCH
+	method string
+	// path is the path of the request (relative to the base path). Its path parameters are resolved with the parent IDs
+	// followed by the resource instance ID (e,g: /v1/cdns/{cdn_id}/disable). If empty, the resource instance path is used
+	path string
+	// payload is the request payload, if any
+	payload interface{}
+}
+
+const batchReadDefaultMaxBatchSize = 100
+
+// specBatchRead defines how several instances of a resource are read with a single call to the list operation
+type specBatchRead struct {
+	// idsParam is the query parameter of the list operation used to send the comma separated list of IDs to read
+	idsParam string
+	// maxBatchSize is the max number of IDs sent in a single request
+	maxBatchSize int
+}
+
+const (
+	// paginationStyleLink paginates following the URL in the Link response header with rel="next" (RFC 8288)
+	paginationStyleLink = "link"
+	// paginationStyleToken paginates sending the token returned in a response header as a query parameter
+	paginationStyleToken = "token"
+)
+
+// specPagination defines how the subsequent pages of a paginated list operation are fetched
+type specPagination struct {
+	// style is the pagination style: paginationStyleLink or paginationStyleToken
+	style string
+	// nextTokenHeader is the response header containing the token of the next page (only for paginationStyleToken)
+	nextTokenHeader string
+	// tokenQueryParam is the query parameter used to send the token of the next page (only for paginationStyleToken)
+	tokenQueryParam string
+}
+
+// specRetryableError defines an API error that should be retried. If the errorCode is empty, any response with the
+// status code is retried; otherwise the error code returned in the response body must match too.
+type specRetryableError struct {
+	statusCode int
+	errorCode  string
+}
+
+// specRetryBackoff defines how the requests failing with retryable errors are retried. Zero values mean that the value
+// is not configured and the default one applies.
+type specRetryBackoff struct {
+	// maxRetries is the max number of times a request is retried
+	maxRetries int
+	// initialBackoff is the time to wait before the first retry. The wait time doubles on each retry
+	initialBackoff time.Duration
+	// maxBackoff is the max time to wait between retries
+	maxBackoff time.Duration
+}
+
+// override returns a copy of the retry backoff where the values configured in the given retry backoff take preference
+func (b specRetryBackoff) override(other specRetryBackoff) specRetryBackoff {
+	if other.maxRetries > 0 {
+		b.maxRetries = other.maxRetries
+	}
+	if other.initialBackoff > 0 {
+		b.initialBackoff = other.initialBackoff
+	}
+	if other.maxBackoff > 0 {
+		b.maxBackoff = other.maxBackoff
+	}
+	return b
+}
+
+// getSuccessStatusCodes returns the response status codes that are considered successful for the operation. The status
+// codes configured via the x-terraform-success-status-codes extension take preference; otherwise the 2xx status codes
+// documented in the operation responses are considered successful in addition to the given defaultStatusCodes.
+func (o *specResourceOperation) getSuccessStatusCodes(defaultStatusCodes []int) []int {
+	if o == nil {
+		return defaultStatusCodes
+	}
+	if len(o.successStatusCodes) > 0 {
+		return o.successStatusCodes
+	}
+	statusCodes := append([]int{}, defaultStatusCodes...)
+	var documentedStatusCodes []int
+	for statusCode := range o.responses {
+		if statusCode >= 200 && statusCode < 300 && !responseContainsExpectedStatus(defaultStatusCodes, statusCode) {
+			documentedStatusCodes = append(documentedStatusCodes, statusCode)
+		}
+	}
+	sort.Ints(documentedStatusCodes)
+	return append(statusCodes, documentedStatusCodes...)
+}
+
+// checkSuccessConditions returns an error if the given response payload does not meet any of the success conditions
+// configured for the operation
+func (o *specResourceOperation) checkSuccessConditions(payload map[string]interface{}) error {
+	if o == nil {
+		return nil
+	}
+	for _, condition := range o.successConditions {
+		if !condition.isMet(payload) {
+			value, _ := getPayloadValueAtPath(payload, condition.path)
+			return fmt.Errorf("response payload does not meet the success condition '%s == %s' (actual value: %v)", strings.Join(condition.path, "."), condition.value, value)
+		}
+	}
+	return nil
+}
+
+// getOperationID returns the operationId of the operation; empty if the operation is nil or has no operationId
+func (o *specResourceOperation) getOperationID() string {
+	if o == nil {
+		return ""
+	}
+	return o.operationID
+}
+
+// getOperationIDLogSuffix returns the suffix appended to the log lines of the requests performed for the operation so
+// they can be correlated with the operationId; empty if the operation has no operationId
+func (o *specResourceOperation) getOperationIDLogSuffix() string {
+	if o.getOperationID() == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (operationId: %s)", o.operationID)
+}
+
+// supportsPageSize returns true if the operation supports requesting the page size
+func (o *specResourceOperation) supportsPageSize() bool {
+	return o != nil && o.pageSizeParam != ""
+}
+
+// getPageSize returns the page size requested for the given page size configured by the user, capped to the max page
+// size supported by the operation. Zero is returned if the operation does not support requesting the page size or the
+// page size is not configured
+func (o *specResourceOperation) getPageSize(pageSize int) int {
+	if !o.supportsPageSize() || pageSize <= 0 {
+		return 0
+	}
+	if o.maxPageSize > 0 && pageSize > o.maxPageSize {
+		return o.maxPageSize
+	}
+	return pageSize
+}
+
+// getErrorParser returns the parser used to parse the error responses of the operation
+func (o *specResourceOperation) getErrorParser() apiErrorParser {
+	if o == nil || o.errorParser == nil {
+		return defaultAPIErrorParser{}
+	}
+	return o.errorParser
+}
+
+// isRetryableError returns true if the given status code and error code returned by the API match any of the retryable
+// errors configured for the operation
+func (o *specResourceOperation) isRetryableError(statusCode int, errorCode string) bool {
+	if o == nil {
+		return false
+	}
+	for _, retryableError := range o.retryableErrors {
+		if retryableError.statusCode == statusCode && (retryableError.errorCode == "" || retryableError.errorCode == errorCode) {
+			return true
+		}
+	}
+	return false
 }
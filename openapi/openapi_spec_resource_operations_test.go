@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecResourceOperationGetSuccessStatusCodes(t *testing.T) {
+	defaultStatusCodes := []int{http.StatusOK, http.StatusAccepted}
+	Convey("Given a nil specResourceOperation", t, func() {
+		var operation *specResourceOperation
+		Convey("When getSuccessStatusCodes method is called", func() {
+			statusCodes := operation.getSuccessStatusCodes(defaultStatusCodes)
+			Convey("Then the default status codes should be returned", func() {
+				So(statusCodes, ShouldResemble, defaultStatusCodes)
+			})
+		})
+	})
+	Convey("Given a specResourceOperation documenting 2xx and error responses", t, func() {
+		operation := &specResourceOperation{
+			responses: specResponses{
+				http.StatusOK:                  &specResponse{},
+				http.StatusNoContent:           &specResponse{},
+				http.StatusCreated:             &specResponse{},
+				http.StatusInternalServerError: &specResponse{},
+			},
+		}
+		Convey("When getSuccessStatusCodes method is called", func() {
+			statusCodes := operation.getSuccessStatusCodes(defaultStatusCodes)
+			Convey("Then the default status codes and the documented 2xx status codes should be returned", func() {
+				So(statusCodes, ShouldResemble, []int{http.StatusOK, http.StatusAccepted, http.StatusCreated, http.StatusNoContent})
+			})
+		})
+	})
+	Convey("Given a specResourceOperation configured with success status codes", t, func() {
+		operation := &specResourceOperation{
+			responses: specResponses{
+				http.StatusCreated: &specResponse{},
+			},
+			successStatusCodes: []int{http.StatusNoContent},
+		}
+		Convey("When getSuccessStatusCodes method is called", func() {
+			statusCodes := operation.getSuccessStatusCodes(defaultStatusCodes)
+			Convey("Then only the configured success status codes should be returned", func() {
+				So(statusCodes, ShouldResemble, []int{http.StatusNoContent})
+			})
+		})
+	})
+}
+
+func TestSpecResourceOperationIsRetryableError(t *testing.T) {
+	Convey("Given a specResourceOperation configured with retryable errors", t, func() {
+		operation := &specResourceOperation{
+			retryableErrors: []specRetryableError{
+				{statusCode: http.StatusConflict, errorCode: "operation_in_progress"},
+				{statusCode: http.StatusServiceUnavailable},
+			},
+		}
+		Convey("When isRetryableError method is called with a status code and error code that match a retryable error", func() {
+			Convey("Then the result should be true", func() {
+				So(operation.isRetryableError(http.StatusConflict, "operation_in_progress"), ShouldBeTrue)
+			})
+		})
+		Convey("When isRetryableError method is called with a status code that matches a retryable error but a different error code", func() {
+			Convey("Then the result should be false", func() {
+				So(operation.isRetryableError(http.StatusConflict, "already_exists"), ShouldBeFalse)
+			})
+		})
+		Convey("When isRetryableError method is called with a status code that matches a retryable error without error code", func() {
+			Convey("Then the result should be true regardless of the error code", func() {
+				So(operation.isRetryableError(http.StatusServiceUnavailable, ""), ShouldBeTrue)
+				So(operation.isRetryableError(http.StatusServiceUnavailable, "maintenance"), ShouldBeTrue)
+			})
+		})
+		Convey("When isRetryableError method is called with a status code that does not match any retryable error", func() {
+			Convey("Then the result should be false", func() {
+				So(operation.isRetryableError(http.StatusBadRequest, "operation_in_progress"), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a nil specResourceOperation", t, func() {
+		var operation *specResourceOperation
+		Convey("When isRetryableError method is called", func() {
+			Convey("Then the result should be false", func() {
+				So(operation.isRetryableError(http.StatusConflict, "operation_in_progress"), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestSpecResourceOperationCheckSuccessConditions(t *testing.T) {
+	Convey("Given a specResourceOperation configured with success conditions", t, func() {
+		operation := &specResourceOperation{
+			successConditions: []specPollCondition{
+				{path: []string{"status"}, value: "ok"},
+				{path: []string{"result", "created"}, value: "true"},
+			},
+		}
+		Convey("When checkSuccessConditions method is called with a payload meeting all the conditions", func() {
+			err := operation.checkSuccessConditions(map[string]interface{}{"status": "ok", "result": map[string]interface{}{"created": true}})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When checkSuccessConditions method is called with a payload that does not meet one of the conditions", func() {
+			err := operation.checkSuccessConditions(map[string]interface{}{"status": "ok", "error": map[string]interface{}{"code": "quota_exceeded"}})
+			Convey("Then the error returned should describe the condition not met", func() {
+				So(err.Error(), ShouldEqual, "response payload does not meet the success condition 'result.created == true' (actual value: <nil>)")
+			})
+		})
+	})
+	Convey("Given a nil specResourceOperation", t, func() {
+		var operation *specResourceOperation
+		Convey("When checkSuccessConditions method is called", func() {
+			err := operation.checkSuccessConditions(map[string]interface{}{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestSpecRetryBackoffOverride(t *testing.T) {
+	Convey("Given a retry backoff", t, func() {
+		retryBackoff := specRetryBackoff{maxRetries: 5, initialBackoff: time.Second, maxBackoff: 30 * time.Second}
+		Convey("When override method is called with a retry backoff that only configures some of the values", func() {
+			result := retryBackoff.override(specRetryBackoff{initialBackoff: 100 * time.Millisecond})
+			Convey("Then only the configured values should be overridden", func() {
+				So(result, ShouldResemble, specRetryBackoff{maxRetries: 5, initialBackoff: 100 * time.Millisecond, maxBackoff: 30 * time.Second})
+			})
+		})
+	})
+}
@@ -1,11 +1,28 @@
 package openapi
 
+import (
+	"fmt"
+)
+
 type specResponses map[int]*specResponse
 
 type specResponse struct {
 	isPollingEnabled    bool
 	pollTargetStatuses  []string
 	pollPendingStatuses []string
+	// pollStatusPath is set for responses configured with the x-terraform-resource-poll-status-path extension and contains
+	// the path to the payload property holding the status of the resource (e,g: [metadata state phase]), which takes
+	// preference over the status property identified in the resource schema
+	pollStatusPath []string
+	// pollCompletedConditions is set for responses configured with the x-terraform-resource-poll-completed-conditions
+	// extension and contains the conditions that must all be met for the resource to be considered completed
+	pollCompletedConditions []specPollCondition
+}
+
+// specPollCondition defines a condition on the value of a (possibly nested) payload property (e,g: health.status == healthy)
+type specPollCondition struct {
+	path  []string
+	value string
 }
 
 func (s specResponses) getResponse(responseStatusCode int) *specResponse {
@@ -15,3 +32,41 @@ func (s specResponses) getResponse(responseStatusCode int) *specResponse {
 	}
 	return response
 }
+
+// pollCompletedConditionsMet returns true if all the completed conditions of the response are met by the given payload
+func (s *specResponse) pollCompletedConditionsMet(payload map[string]interface{}) bool {
+	for _, condition := range s.pollCompletedConditions {
+		if !condition.isMet(payload) {
+			return false
+		}
+	}
+	return true
+}
+
+// isMet returns true if the property the condition refers to is present in the given payload and its value matches the
+// condition value. Non string values are compared using their string representation (e,g: true, 3)
+func (c specPollCondition) isMet(payload map[string]interface{}) bool {
+	value, exists := getPayloadValueAtPath(payload, c.path)
+	if !exists || value == nil {
+		return false
+	}
+	return fmt.Sprintf("%v", value) == c.value
+}
+
+// getPayloadValueAtPath returns the value of the property found following the given path through the nested objects of
+// the payload (e,g: [metadata state phase]) and whether the property exists
+func getPayloadValueAtPath(payload map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = payload
+	for _, propertyName := range path {
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return nil, false
+		}
+		propertyValue, exists := object[propertyName]
+		if !exists {
+			return nil, false
+		}
+		value = propertyValue
+	}
+	return value, true
+}
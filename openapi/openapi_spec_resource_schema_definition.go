@@ -12,15 +12,25 @@ type SpecSchemaDefinitionProperties []*SpecSchemaDefinitionProperty
 // SpecSchemaDefinition defines a struct for a schema definition
 type SpecSchemaDefinition struct {
 	Properties SpecSchemaDefinitionProperties
+	// Discriminator contains the name of the property used to tell apart the subtypes of polymorphic schemas (discriminator
+	// field in the OpenAPI document); empty if the schema is not polymorphic. The payloads of polymorphic schemas may contain
+	// properties of the subtypes which are not part of the schema, these are ignored when the payloads are read.
+	Discriminator string
 }
 
 // ConvertToDataSourceSpecSchemaDefinition transforms the current SpecSchemaDefinition into a data source SpecSchemaDefinition. This
 // means that all the properties that form the schema will be made optional, computed and they won't have default values.
 func (s *SpecSchemaDefinition) ConvertToDataSourceSpecSchemaDefinition() *SpecSchemaDefinition {
 	specSchemaDefinition := &SpecSchemaDefinition{
-		Properties: SpecSchemaDefinitionProperties{},
+		Properties:    SpecSchemaDefinitionProperties{},
+		Discriminator: s.Discriminator,
 	}
 	for _, p := range s.Properties {
+		// the API never returns the request only properties so they would always be empty in the data sources, and the
+		// upload properties contain the local file path uploaded which the data sources can not know
+		if p.RequestOnly || p.Upload != nil {
+			continue
+		}
 		dataSourceSpecSchemaDefinitionProperty := s.convertToDataSourceSpecSchemaDefinitionProperty(*p)
 		specSchemaDefinition.Properties = append(specSchemaDefinition.Properties, dataSourceSpecSchemaDefinitionProperty)
 	}
@@ -29,6 +39,8 @@ func (s *SpecSchemaDefinition) ConvertToDataSourceSpecSchemaDefinition() *SpecSc
 
 func (s *SpecSchemaDefinition) convertToDataSourceSpecSchemaDefinitionProperty(specSchemaDefinitionProperty SpecSchemaDefinitionProperty) *SpecSchemaDefinitionProperty {
 	if specSchemaDefinitionProperty.IsParentProperty {
+		// data sources are read only so re-creation does not apply
+		specSchemaDefinitionProperty.ForceNew = false
 		return &specSchemaDefinitionProperty
 	}
 	specSchemaDefinitionProperty.Required = false
@@ -36,7 +48,8 @@ func (s *SpecSchemaDefinition) convertToDataSourceSpecSchemaDefinitionProperty(s
 	specSchemaDefinitionProperty.Default = nil
 	if specSchemaDefinitionProperty.SpecSchemaDefinition != nil {
 		dataSourceObjectSpecSchemaDefinition := &SpecSchemaDefinition{
-			Properties: SpecSchemaDefinitionProperties{},
+			Properties:    SpecSchemaDefinitionProperties{},
+			Discriminator: specSchemaDefinitionProperty.SpecSchemaDefinition.Discriminator,
 		}
 		for _, objectProperty := range specSchemaDefinitionProperty.SpecSchemaDefinition.Properties {
 			dataSourceObjectProperty := s.convertToDataSourceSpecSchemaDefinitionProperty(*objectProperty)
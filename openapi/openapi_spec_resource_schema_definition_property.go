@@ -1,8 +1,13 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
+	"log"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -24,11 +29,20 @@ const (
 	TypeList schemaDefinitionPropertyType = "list"
 	// TypeObject defines a schema definition property of type object
 	TypeObject schemaDefinitionPropertyType = "object"
+	// TypeMap defines a schema definition property of type map (object with free-form keys described with additionalProperties)
+	TypeMap schemaDefinitionPropertyType = "map"
 )
 
 const idDefaultPropertyName = "id"
 const statusDefaultPropertyName = "status"
 
+// mapOfObjectsKeyAttributeName is the name of the block attribute containing the map key for map properties which values
+// are objects, since these are represented in terraform as a list of blocks
+const mapOfObjectsKeyAttributeName = "key"
+
+// uuidRegex matches UUIDs in their canonical textual representation (e,g: 123e4567-e89b-12d3-a456-426614174000)
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // SpecSchemaDefinitionProperty defines the attributes for a schema property
 type SpecSchemaDefinitionProperty struct {
 	Name           string
@@ -37,9 +51,68 @@ type SpecSchemaDefinitionProperty struct {
 	ArrayItemsType schemaDefinitionPropertyType
 	Description    string
 
+	// MapValuesType contains the type of the values for map type properties. If the values are objects, the values
+	// schema is described in the SpecSchemaDefinition
+	MapValuesType schemaDefinitionPropertyType
+
 	// IgnoreItemsOrder if set to true means that the array items order should be ignored
 	IgnoreItemsOrder bool
 
+	// ArrayItemsEnum contains the allowed values of the items for array properties which items are primitives declaring
+	// the enum attribute. Each item configured is validated against this list at plan time
+	ArrayItemsEnum []interface{}
+
+	// IgnoredKeyPrefixes contains the prefixes of the map keys that are ignored when the map is read from the API (e,g:
+	// system tags injected by the API like "aws:" or "system/"), so they do not show up as diffs
+	IgnoredKeyPrefixes []string
+
+	// ForceNewItemFields contains the names of the item properties of arrays of objects which changes in the existing items
+	// force the re-creation of the resource. Changes in the rest of the item properties as well as adding or removing
+	// items update the resource in place.
+	ForceNewItemFields []string
+
+	// RenamedFrom contains the name the property had in previous versions of the resource schema. It is used when upgrading
+	// existing states to move the value stored under the previous name to the current one.
+	RenamedFrom string
+
+	// ResponseHeader contains the name of the response header the property value is read from when the API does not
+	// return the property in the response payload (e,g: the resource id returned in the Location header)
+	ResponseHeader string
+
+	// IgnoreDrift if set to true means that the value returned by the API when the resource is read is ignored if the
+	// state already contains a value for the property (e,g: server managed fields that change outside terraform)
+	IgnoreDrift bool
+
+	// RequestOnly if set to true means that the property is only sent in the requests and never returned by the API (e,g:
+	// passwords or properties that are only part of the request model), hence the value configured is kept in the state
+	// and the property is not exposed in the data sources
+	RequestOnly bool
+
+	// Encrypted if set to true means that the value returned by the API is encrypted with the key configured in the
+	// provider before it is persisted in the state. Only supported in readOnly string properties
+	Encrypted bool
+
+	// ClientGenerated is set for properties configured with the x-terraform-client-generated extension, which value is
+	// generated by the provider when the resource is created if the user does not configure it
+	ClientGenerated *clientGeneratedValue
+
+	// Upload is set for properties configured with the x-terraform-upload-path extension, which value is the path of a
+	// local file uploaded to the upload endpoint. The API receives the reference returned by the upload endpoint and the
+	// file path configured is kept in the state
+	Upload *specUpload
+
+	// ParentIDFormat contains the format of the parent resource ID (parentIDFormatUUID or parentIDFormatInteger) that the
+	// value of parent properties is validated against at plan time; empty if the parent ID format is not declared
+	ParentIDFormat string
+
+	// RawJSON if set to true means that the property type is not supported and the property is exposed as a string
+	// containing the JSON encoded value instead (x-terraform-unsupported-types: json)
+	RawJSON bool
+
+	// Transforms contains the transformations configured with the x-terraform-transform extension that are applied to the
+	// value configured by the user before it is sent to the API (e,g: lowercase). Only supported in string properties
+	Transforms propertyTransforms
+
 	Required bool
 	// ReadOnly properties are included in responses but not in request
 	ReadOnly bool
@@ -61,6 +134,9 @@ type SpecSchemaDefinitionProperty struct {
 	// Default field is only for informative purposes to know what the openapi spec for the property stated the default value is
 	// As per the openapi spec default attributes, the value is expected to be computed by the API
 	Default interface{}
+	// Example contains the sample value documented in the openapi spec for the property (example attribute). It is only
+	// used for documentation purposes
+	Example interface{}
 	// only for object type properties or arrays type properties with array items of type object
 	SpecSchemaDefinition *SpecSchemaDefinition
 }
@@ -80,6 +156,42 @@ func (s *SpecSchemaDefinitionProperty) GetTerraformCompliantPropertyName() strin
 	return terraformutils.ConvertToTerraformCompliantName(s.Name)
 }
 
+// GetExample returns the property example value documented in the openapi spec serialised as JSON (e,g: "some value",
+// 12, ["a", "b"]), which matches the terraform syntax for primitives and lists of primitives. An empty string is returned
+// if the property does not have an example.
+func (s *SpecSchemaDefinitionProperty) GetExample() string {
+	if s.Example == nil {
+		return ""
+	}
+	example, err := json.Marshal(s.Example)
+	if err != nil {
+		log.Printf("[DEBUG] ignoring example of property '%s' as it can not be serialised: %s", s.Name, err)
+		return ""
+	}
+	return string(example)
+}
+
+// getTerraformDescription returns the description of the terraform schema attribute, containing the property example
+// (if any) so users get a realistic sample value for the attribute
+func (s *SpecSchemaDefinitionProperty) getTerraformDescription() string {
+	description := s.Description
+	if len(s.ArrayItemsEnum) > 0 {
+		description = appendDescriptionSentence(description, fmt.Sprintf("Allowed values: %s", s.getArrayItemsEnumDescription()))
+	}
+	example := s.GetExample()
+	if example == "" {
+		return description
+	}
+	return appendDescriptionSentence(description, fmt.Sprintf("Example: %s", example))
+}
+
+func appendDescriptionSentence(description, sentence string) string {
+	if description == "" {
+		return sentence
+	}
+	return fmt.Sprintf("%s. %s", strings.TrimSuffix(description, "."), sentence)
+}
+
 // This is the workaround to be able to process objects that contain properties that are not of the same type and may
 // contain other configurations like be computed properties (More info here: https://github.com/hashicorp/terraform/issues/22511)
 // The object properties that have EnableLegacyComplexObjectBlockConfiguration set to true will be represented in Terraform schema
@@ -123,6 +235,53 @@ func (s *SpecSchemaDefinitionProperty) isArrayProperty() bool {
 	return s.Type == TypeList
 }
 
+func (s *SpecSchemaDefinitionProperty) isMapProperty() bool {
+	return s.Type == TypeMap
+}
+
+// isMapOfObjectsProperty returns true for map properties which values are objects (e,g: environments keyed by name)
+func (s *SpecSchemaDefinitionProperty) isMapOfObjectsProperty() bool {
+	return s.Type == TypeMap && s.MapValuesType == TypeObject
+}
+
+// isIgnoredMapKey returns true if the given map key starts with any of the ignored key prefixes configured for the property
+func (s *SpecSchemaDefinitionProperty) isIgnoredMapKey(key string) bool {
+	for _, prefix := range s.IgnoredKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeIgnoredMapKeys returns a copy of the given map value without the keys matching the ignored key prefixes
+func (s *SpecSchemaDefinitionProperty) removeIgnoredMapKeys(value map[string]interface{}) map[string]interface{} {
+	filteredValue := map[string]interface{}{}
+	for key, keyValue := range value {
+		if !s.isIgnoredMapKey(key) {
+			filteredValue[key] = keyValue
+		}
+	}
+	return filteredValue
+}
+
+// equalMaps returns true if both map values contain the same keys and values (ignoring the keys matching the ignored key
+// prefixes). The values are compared using their string representation as terraform keeps map values as strings
+func (s *SpecSchemaDefinitionProperty) equalMaps(map1, map2 map[string]interface{}) bool {
+	map1 = s.removeIgnoredMapKeys(map1)
+	map2 = s.removeIgnoredMapKeys(map2)
+	if len(map1) != len(map2) {
+		return false
+	}
+	for key, value1 := range map1 {
+		value2, exists := map2[key]
+		if !exists || fmt.Sprint(value1) != fmt.Sprint(value2) {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *SpecSchemaDefinitionProperty) shouldIgnoreOrder() bool {
 	return s.Type == TypeList && s.IgnoreItemsOrder
 }
@@ -181,6 +340,8 @@ func (s *SpecSchemaDefinitionProperty) terraformType() (schema.ValueType, error)
 		return schema.TypeBool, nil
 	case TypeList:
 		return schema.TypeList, nil
+	case TypeMap:
+		return schema.TypeMap, nil
 	}
 	return schema.TypeInvalid, fmt.Errorf("non supported type %s", s.Type)
 }
@@ -199,8 +360,22 @@ func (s *SpecSchemaDefinitionProperty) isTerraformListOfSimpleValues() (bool, *s
 	return false, nil
 }
 
+func (s *SpecSchemaDefinitionProperty) terraformMapValuesSchema() (*schema.Schema, error) {
+	switch s.MapValuesType {
+	case TypeString:
+		return &schema.Schema{Type: schema.TypeString}, nil
+	case TypeInt:
+		return &schema.Schema{Type: schema.TypeInt}, nil
+	case TypeFloat:
+		return &schema.Schema{Type: schema.TypeFloat}, nil
+	case TypeBool:
+		return &schema.Schema{Type: schema.TypeBool}, nil
+	}
+	return nil, fmt.Errorf("map property '%s' has values of type '%s' which is not supported", s.Name, s.MapValuesType)
+}
+
 func (s *SpecSchemaDefinitionProperty) terraformObjectSchema() (*schema.Resource, error) {
-	if s.Type == TypeObject || (s.Type == TypeList && s.ArrayItemsType == TypeObject) {
+	if s.Type == TypeObject || (s.Type == TypeList && s.ArrayItemsType == TypeObject) || s.isMapOfObjectsProperty() {
 		if s.SpecSchemaDefinition == nil {
 			return nil, fmt.Errorf("missing spec schema definition for property '%s' of type '%s'", s.Name, s.Type)
 		}
@@ -216,6 +391,27 @@ func (s *SpecSchemaDefinitionProperty) terraformObjectSchema() (*schema.Resource
 	return nil, fmt.Errorf("object schema can only be formed for types %s or types %s with elems of type %s: found type='%s' elemType='%s' instead", TypeObject, TypeList, TypeObject, s.Type, s.ArrayItemsType)
 }
 
+// terraformMapOfObjectsSchema returns the block schema for map properties which values are objects. Terraform maps only
+// support primitive values, so these maps are represented as a list of blocks containing the map key in the 'key'
+// attribute along with the value properties
+func (s *SpecSchemaDefinitionProperty) terraformMapOfObjectsSchema() (*schema.Resource, error) {
+	objectSchema, err := s.terraformObjectSchema()
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := objectSchema.Schema[mapOfObjectsKeyAttributeName]; exists {
+		return nil, fmt.Errorf("map property '%s' has values containing a property named '%s' which collides with the attribute holding the map keys", s.Name, mapOfObjectsKeyAttributeName)
+	}
+	keySchema := &schema.Schema{Type: schema.TypeString, Description: "The map key"}
+	if s.isReadOnly() {
+		keySchema.Computed = true
+	} else {
+		keySchema.Required = true
+	}
+	objectSchema.Schema[mapOfObjectsKeyAttributeName] = keySchema
+	return objectSchema, nil
+}
+
 // shouldUseLegacyTerraformSDKBlockApproachForComplexObjects returns true if one of the following scenarios match:
 // - the SpecSchemaDefinitionProperty is of type object and in turn contains at least one nested property that is an object.
 // - the SpecSchemaDefinitionProperty is of type object and also has the EnableLegacyComplexObjectBlockConfiguration set to true
@@ -242,6 +438,7 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 		return nil, err
 	}
 	terraformSchema.Type = schemaType
+	terraformSchema.Description = s.getTerraformDescription()
 
 	// complex data structures
 	switch s.Type {
@@ -258,6 +455,9 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 
 	case TypeList:
 		if isListOfPrimitives, elemSchema := s.isTerraformListOfSimpleValues(); isListOfPrimitives {
+			if len(s.ArrayItemsEnum) > 0 {
+				elemSchema.ValidateFunc = s.validateArrayItemsEnum()
+			}
 			terraformSchema.Elem = elemSchema
 		} else {
 			objectSchema, err := s.terraformObjectSchema()
@@ -266,6 +466,22 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 			}
 			terraformSchema.Elem = objectSchema
 		}
+
+	case TypeMap:
+		if s.isMapOfObjectsProperty() {
+			terraformSchema.Type = schema.TypeList
+			mapOfObjectsSchema, err := s.terraformMapOfObjectsSchema()
+			if err != nil {
+				return nil, err
+			}
+			terraformSchema.Elem = mapOfObjectsSchema
+			break
+		}
+		mapValuesSchema, err := s.terraformMapValuesSchema()
+		if err != nil {
+			return nil, err
+		}
+		terraformSchema.Elem = mapValuesSchema
 	}
 
 	// A computed property could be one of:
@@ -289,10 +505,21 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	}
 
 	// ValidateFunc is not yet supported on lists or sets
-	if !s.isArrayProperty() && !s.isObjectProperty() {
+	if !s.isArrayProperty() && !s.isObjectProperty() && !s.isMapProperty() {
 		terraformSchema.ValidateFunc = s.validateFunc()
 	}
 
+	// JSON values that only differ in formatting (e,g: whitespaces or the order of the object keys) are equivalent
+	if s.RawJSON {
+		terraformSchema.DiffSuppressFunc = suppressEquivalentJSONDiffs
+	} else if len(s.Transforms) > 0 {
+		// values that are sent to the API with the same value once transformed (e,g: "MyBucket" and "mybucket" with the
+		// lowercase transformation) are equivalent
+		terraformSchema.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			return s.Transforms.equivalent(old, new)
+		}
+	}
+
 	// Don't populate Default if property is readOnly as the property is expected to be computed by the API. Terraform does
 	// not allow properties with Computed = true having the Default field populated, otherwise the following error will be
 	// thrown at runtime: Default must be nil if computed
@@ -311,10 +538,80 @@ func (s *SpecSchemaDefinitionProperty) validateFunc() schema.SchemaValidateFunc
 		if s.Required && s.ReadOnly {
 			errors = append(errors, fmt.Errorf("property '%s' is configured as required and can not be configured as computed too", s.Name))
 		}
+		if s.IsParentProperty {
+			if err := s.validateParentIDFormat(v); err != nil {
+				errors = append(errors, err)
+			}
+		}
+		if s.RawJSON {
+			if value, ok := v.(string); ok && value != "" && !json.Valid([]byte(value)) {
+				errors = append(errors, fmt.Errorf("property '%s' must contain a valid JSON value but got '%s'", s.Name, value))
+			}
+		}
 		return
 	}
 }
 
+// suppressEquivalentJSONDiffs suppresses the diffs between JSON values that are semantically equivalent
+func suppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+// validateParentIDFormat checks that the given parent property value matches the format of the parent resource ID, so
+// misconfigurations like referencing the parent by name instead of by ID are caught at plan time rather than failing
+// with a 404 when the API is called
+func (s *SpecSchemaDefinitionProperty) validateParentIDFormat(v interface{}) error {
+	value, ok := v.(string)
+	if !ok || value == "" {
+		return nil
+	}
+	switch s.ParentIDFormat {
+	case parentIDFormatUUID:
+		if uuidRegex.MatchString(value) {
+			return nil
+		}
+	case parentIDFormatInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return fmt.Errorf("property '%s' must contain the parent resource ID which is expected to be of format %s but got '%s', make sure the parent is referenced by its ID (e,g: <parent_resource>.<name>.id) and not by its name", s.Name, s.ParentIDFormat, value)
+}
+
+// validateArrayItemsEnum returns the validation func applied to each of the items of array properties which items declare
+// the enum attribute, so values not supported by the API are caught at plan time. The values are compared using their
+// string representation since numeric enum values are unmarshalled from the OpenAPI document as float64 whereas
+// terraform provides the integer items as int
+func (s *SpecSchemaDefinitionProperty) validateArrayItemsEnum() schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		for _, allowedValue := range s.ArrayItemsEnum {
+			if fmt.Sprintf("%v", v) == fmt.Sprintf("%v", allowedValue) {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf("property '%s' contains the value '%v' which is not one of the allowed values: %s", s.Name, v, s.getArrayItemsEnumDescription()))
+		return
+	}
+}
+
+// getArrayItemsEnumDescription returns the allowed values of the array items as a comma separated list
+func (s *SpecSchemaDefinitionProperty) getArrayItemsEnumDescription() string {
+	allowedValues := make([]string, 0, len(s.ArrayItemsEnum))
+	for _, allowedValue := range s.ArrayItemsEnum {
+		allowedValues = append(allowedValues, fmt.Sprintf("%v", allowedValue))
+	}
+	return strings.Join(allowedValues, ", ")
+}
+
 func (s *SpecSchemaDefinitionProperty) equal(item1, item2 interface{}) bool {
 	return s.equalItems(s.Type, item1, item2)
 }
@@ -364,6 +661,11 @@ func (s *SpecSchemaDefinitionProperty) equalItems(itemsType schemaDefinitionProp
 		for idx := range list1 {
 			return s.equalItems(s.ArrayItemsType, list1[idx], list2[idx])
 		}
+	case TypeMap:
+		if !s.validateValueType(item1, reflect.Map) || !s.validateValueType(item2, reflect.Map) {
+			return false
+		}
+		return s.equalMaps(item1.(map[string]interface{}), item2.(map[string]interface{}))
 	case TypeObject:
 		if !s.validateValueType(item1, reflect.Map) || !s.validateValueType(item2, reflect.Map) {
 			return false
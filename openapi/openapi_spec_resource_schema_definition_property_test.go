@@ -885,6 +885,161 @@ func TestSpecSchemaDefinitionIsPropertyWithNestedObjects(t *testing.T) {
 }
 
 func TestTerraformSchema(t *testing.T) {
+	Convey("Given a swagger schema definition property with a description and an example", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:        "string_property",
+			Type:        TypeString,
+			Description: "Some description",
+			Example:     "some value",
+		}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the terraform schema description should contain the example", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Description, ShouldEqual, `Some description. Example: "some value"`)
+			})
+		})
+	})
+
+	Convey("Given a swagger schema definition property configured with the lowercase transform", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:       "bucket_name",
+			Type:       TypeString,
+			Transforms: propertyTransforms{{kind: propertyTransformLowercase}},
+		}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the terraform schema should suppress the diffs between values that are the same once transformed", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.DiffSuppressFunc, ShouldNotBeNil)
+				So(tfPropSchema.DiffSuppressFunc("bucket_name", "mybucket", "MyBucket", nil), ShouldBeTrue)
+				So(tfPropSchema.DiffSuppressFunc("bucket_name", "mybucket", "OtherBucket", nil), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a swagger schema definition property exposed as raw JSON", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:    "settings",
+			Type:    TypeString,
+			RawJSON: true,
+		}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the terraform schema should be a string that suppresses the diffs between equivalent JSON values", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Type, ShouldEqual, schema.TypeString)
+				So(tfPropSchema.DiffSuppressFunc, ShouldNotBeNil)
+				So(tfPropSchema.DiffSuppressFunc("settings", `{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, nil), ShouldBeTrue)
+				So(tfPropSchema.DiffSuppressFunc("settings", `{"a": 1}`, `{"a": 2}`, nil), ShouldBeFalse)
+				So(tfPropSchema.DiffSuppressFunc("settings", "", `{"a": 1}`, nil), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a swagger schema definition list property which items declare the enum attribute", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:           "ports",
+			Type:           TypeList,
+			ArrayItemsType: TypeInt,
+			Description:    "Ports exposed",
+			ArrayItemsEnum: []interface{}{float64(80), float64(443)},
+		}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the terraform schema description should contain the allowed values", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Description, ShouldEqual, "Ports exposed. Allowed values: 80, 443")
+			})
+			Convey("And the items validation should accept the allowed values", func() {
+				_, errs := tfPropSchema.Elem.(*schema.Schema).ValidateFunc(443, "ports.0")
+				So(errs, ShouldBeEmpty)
+			})
+			Convey("And the items validation should reject values that are not allowed", func() {
+				_, errs := tfPropSchema.Elem.(*schema.Schema).ValidateFunc(8080, "ports.0")
+				So(errs, ShouldHaveLength, 1)
+				So(errs[0].Error(), ShouldEqual, "property 'ports' contains the value '8080' which is not one of the allowed values: 80, 443")
+			})
+		})
+	})
+
+	Convey("Given a swagger schema definition map property with values of type string", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:          "tags",
+			Type:          TypeMap,
+			MapValuesType: TypeString,
+		}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the terraform schema should be a map with string values", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Type, ShouldEqual, schema.TypeMap)
+				So(tfPropSchema.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
+				So(tfPropSchema.ValidateFunc, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a swagger schema definition map property with values of a non supported type", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:          "tags",
+			Type:          TypeMap,
+			MapValuesType: TypeList,
+		}
+		Convey("When terraformSchema method is called", func() {
+			_, err := s.terraformSchema()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "map property 'tags' has values of type 'list' which is not supported")
+			})
+		})
+	})
+
+	Convey("Given a swagger schema definition map property with values of type object", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:          "environments",
+			Type:          TypeMap,
+			MapValuesType: TypeObject,
+			SpecSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newIntSchemaDefinitionPropertyWithDefaults("replicas", "", true, false, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("region", "", false, false, nil),
+				},
+			},
+		}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the terraform schema should be a list of blocks containing the map key along with the value properties", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Type, ShouldEqual, schema.TypeList)
+				elem := tfPropSchema.Elem.(*schema.Resource)
+				So(elem.Schema, ShouldContainKey, "replicas")
+				So(elem.Schema, ShouldContainKey, "region")
+				So(elem.Schema, ShouldContainKey, mapOfObjectsKeyAttributeName)
+				So(elem.Schema[mapOfObjectsKeyAttributeName].Type, ShouldEqual, schema.TypeString)
+				So(elem.Schema[mapOfObjectsKeyAttributeName].Required, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a swagger schema definition map property with values of type object containing a property named key", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name:          "environments",
+			Type:          TypeMap,
+			MapValuesType: TypeObject,
+			SpecSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("key", "", false, false, nil),
+				},
+			},
+		}
+		Convey("When terraformSchema method is called", func() {
+			_, err := s.terraformSchema()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "map property 'environments' has values containing a property named 'key' which collides with the attribute holding the map keys")
+			})
+		})
+	})
+
 	Convey("Given a swagger schema definition that has two nested properties - one being a simple object and the other one a primitive", t, func() {
 		expectedNestedObjectPropertyName := "nested_object1"
 		s := &SpecSchemaDefinitionProperty{
@@ -1509,6 +1664,45 @@ func TestTerraformSchema(t *testing.T) {
 	})
 }
 
+func TestGetExample(t *testing.T) {
+	testCases := []struct {
+		name            string
+		example         interface{}
+		expectedExample string
+	}{
+		{name: "no example", example: nil, expectedExample: ""},
+		{name: "string example", example: "some value", expectedExample: `"some value"`},
+		{name: "int example", example: 12, expectedExample: "12"},
+		{name: "float example", example: 12.95, expectedExample: "12.95"},
+		{name: "bool example", example: true, expectedExample: "true"},
+		{name: "list example", example: []interface{}{"a", "b"}, expectedExample: `["a","b"]`},
+		{name: "example that can not be serialised", example: func() {}, expectedExample: ""},
+	}
+	for _, tc := range testCases {
+		s := &SpecSchemaDefinitionProperty{Name: "propertyName", Example: tc.example}
+		assert.Equal(t, tc.expectedExample, s.GetExample(), tc.name)
+	}
+}
+
+func TestGetTerraformDescription(t *testing.T) {
+	testCases := []struct {
+		name                string
+		description         string
+		example             interface{}
+		expectedDescription string
+	}{
+		{name: "description without example", description: "Some description", expectedDescription: "Some description"},
+		{name: "description with example", description: "Some description", example: "some value", expectedDescription: `Some description. Example: "some value"`},
+		{name: "description ending with a period with example", description: "Some description.", example: 12, expectedDescription: "Some description. Example: 12"},
+		{name: "example without description", example: true, expectedDescription: "Example: true"},
+		{name: "no description nor example", expectedDescription: ""},
+	}
+	for _, tc := range testCases {
+		s := &SpecSchemaDefinitionProperty{Name: "propertyName", Description: tc.description, Example: tc.example}
+		assert.Equal(t, tc.expectedDescription, s.getTerraformDescription(), tc.name)
+	}
+}
+
 func TestValidateFunc(t *testing.T) {
 
 	Convey("Given a schemaDefinitionProperty that is computed and has a default value set", t, func() {
@@ -1535,6 +1729,23 @@ func TestValidateFunc(t *testing.T) {
 		})
 	})
 
+	Convey("Given a schemaDefinitionProperty exposed as raw JSON", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "settings", Type: TypeString, RawJSON: true}
+		Convey("When validateFunc is called with a valid JSON value", func() {
+			_, err := s.validateFunc()(`{"enabled": true}`, "")
+			Convey("Then the error returned should be empty", func() {
+				So(err, ShouldBeEmpty)
+			})
+		})
+		Convey("When validateFunc is called with an invalid JSON value", func() {
+			_, err := s.validateFunc()(`{"enabled": }`, "")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeEmpty)
+				So(err[0].Error(), ShouldEqual, `property 'settings' must contain a valid JSON value but got '{"enabled": }'`)
+			})
+		})
+	})
+
 	Convey("Given a schemaDefinitionProperty that is computed and required", t, func() {
 		s := newStringSchemaDefinitionProperty("propertyName", "", true, true, false, false, false, false, false, false, nil)
 		Convey("When validateFunc is called with a schema definition property", func() {
@@ -1547,6 +1758,50 @@ func TestValidateFunc(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a parent schemaDefinitionProperty which parent ID is a uuid", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "cdns_v1_id", Type: TypeString, IsParentProperty: true, ParentIDFormat: parentIDFormatUUID}
+		Convey("When validateFunc is called with a uuid", func() {
+			_, err := s.validateFunc()("123e4567-e89b-12d3-a456-426614174000", "cdns_v1_id")
+			Convey("Then no errors should be returned", func() {
+				So(err, ShouldBeEmpty)
+			})
+		})
+		Convey("When validateFunc is called with a value that is not a uuid (e,g: the parent name)", func() {
+			_, err := s.validateFunc()("my-cdn", "cdns_v1_id")
+			Convey("Then the error returned should explain the parent ID is expected", func() {
+				So(err, ShouldHaveLength, 1)
+				So(err[0].Error(), ShouldEqual, "property 'cdns_v1_id' must contain the parent resource ID which is expected to be of format uuid but got 'my-cdn', make sure the parent is referenced by its ID (e,g: <parent_resource>.<name>.id) and not by its name")
+			})
+		})
+	})
+
+	Convey("Given a parent schemaDefinitionProperty which parent ID is an integer", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "projects_v1_id", Type: TypeString, IsParentProperty: true, ParentIDFormat: parentIDFormatInteger}
+		Convey("When validateFunc is called with an integer", func() {
+			_, err := s.validateFunc()("1234", "projects_v1_id")
+			Convey("Then no errors should be returned", func() {
+				So(err, ShouldBeEmpty)
+			})
+		})
+		Convey("When validateFunc is called with a value that is not an integer", func() {
+			_, err := s.validateFunc()("my-project", "projects_v1_id")
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldHaveLength, 1)
+				So(err[0].Error(), ShouldContainSubstring, "property 'projects_v1_id' must contain the parent resource ID which is expected to be of format integer but got 'my-project'")
+			})
+		})
+	})
+
+	Convey("Given a parent schemaDefinitionProperty which parent ID format is not declared", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "cdns_v1_id", Type: TypeString, IsParentProperty: true}
+		Convey("When validateFunc is called with any value", func() {
+			_, err := s.validateFunc()("my-cdn", "cdns_v1_id")
+			Convey("Then no errors should be returned", func() {
+				So(err, ShouldBeEmpty)
+			})
+		})
+	})
 }
 
 func TestEqualItems(t *testing.T) {
@@ -1763,6 +2018,28 @@ func TestEqualItems(t *testing.T) {
 			remoteItem:     map[string]interface{}{"group": "someGroup"},
 			expectedOutput: false,
 		},
+		// Map use cases
+		{
+			name:           "map input value matches map remote value ignoring the keys with the ignored key prefixes",
+			schemaDefProp:  SpecSchemaDefinitionProperty{Type: TypeMap, MapValuesType: TypeString, IgnoredKeyPrefixes: []string{"aws:"}},
+			inputItem:      map[string]interface{}{"env": "prod"},
+			remoteItem:     map[string]interface{}{"env": "prod", "aws:created_by": "system"},
+			expectedOutput: true,
+		},
+		{
+			name:           "map input value doesn't match map remote value",
+			schemaDefProp:  SpecSchemaDefinitionProperty{Type: TypeMap, MapValuesType: TypeString},
+			inputItem:      map[string]interface{}{"env": "prod"},
+			remoteItem:     map[string]interface{}{"env": "dev"},
+			expectedOutput: false,
+		},
+		{
+			name:           "map input value is not a map",
+			schemaDefProp:  SpecSchemaDefinitionProperty{Type: TypeMap, MapValuesType: TypeString},
+			inputItem:      "not_a_map",
+			remoteItem:     map[string]interface{}{"env": "prod"},
+			expectedOutput: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -38,6 +38,7 @@ func TestConvertToDataSourceSpecSchemaDefinition_WithParentProp(t *testing.T) {
 				Computed:         false,
 				Default:          "defaultParentPropValue",
 				IsParentProperty: true,
+				ForceNew:         true,
 			},
 			{
 				Name:     "someProp",
@@ -53,12 +54,34 @@ func TestConvertToDataSourceSpecSchemaDefinition_WithParentProp(t *testing.T) {
 	assert.True(t, parentProp.Required)
 	assert.False(t, parentProp.Computed)
 	assert.Equal(t, "defaultParentPropValue", parentProp.Default)
+	assert.False(t, parentProp.ForceNew)
 	prop := dataSourceSpecSchemaDef.Properties[1]
 	assert.False(t, prop.Required)
 	assert.True(t, prop.Computed)
 	assert.Nil(t, prop.Default)
 }
 
+func TestConvertToDataSourceSpecSchemaDefinition_WithRequestOnlyProp(t *testing.T) {
+	s := SpecSchemaDefinition{
+		Properties: []*SpecSchemaDefinitionProperty{
+			{
+				Name:        "password",
+				Type:        TypeString,
+				Required:    true,
+				RequestOnly: true,
+			},
+			{
+				Name:     "someProp",
+				Type:     TypeString,
+				Required: true,
+			},
+		},
+	}
+	dataSourceSpecSchemaDef := s.ConvertToDataSourceSpecSchemaDefinition()
+	assert.Len(t, dataSourceSpecSchemaDef.Properties, 1)
+	assert.Equal(t, "someProp", dataSourceSpecSchemaDef.Properties[0].Name)
+}
+
 func TestConvertToDataSourceSpecSchemaDefinitionProperty_ObjectProp(t *testing.T) {
 	s := SpecSchemaDefinition{
 		Properties: []*SpecSchemaDefinitionProperty{
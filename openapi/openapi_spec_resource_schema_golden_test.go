@@ -0,0 +1,210 @@
+package openapi
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// goldenSchemasDir contains the example OpenAPI documents (*.yaml/*.json) used to render the golden files. Each example
+// document has a corresponding {name}.golden file containing the terraform schemas generated for the document resources.
+// When the schema generation logic changes, the golden files can be re-generated running:
+// go test ./openapi -run TestSchemaGenerationGoldenFiles -update-golden
+// and the resulting diff reviewed as part of the change.
+const goldenSchemasDir = "testdata/schemas"
+
+var updateGoldenFiles = flag.Bool("update-golden", false, "update the golden files in testdata/schemas with the terraform schemas generated for the example OpenAPI documents")
+
+func TestSchemaGenerationGoldenFiles(t *testing.T) {
+	specFiles, err := goldenSpecFiles(goldenSchemasDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, specFile := range specFiles {
+		Convey(fmt.Sprintf("Given the example OpenAPI document '%s'", specFile), t, func() {
+			goldenFile := strings.TrimSuffix(specFile, filepath.Ext(specFile)) + ".golden"
+			Convey("When the terraform schemas are generated and rendered", func() {
+				a, err := newSpecAnalyserV2(specFile)
+				So(err, ShouldBeNil)
+				rendered, err := renderTerraformSchemas(a)
+				So(err, ShouldBeNil)
+				if *updateGoldenFiles {
+					So(ioutil.WriteFile(goldenFile, []byte(rendered), 0644), ShouldBeNil)
+				}
+				Convey("Then the rendered schemas should match the golden file", func() {
+					expected, err := ioutil.ReadFile(goldenFile) // #nosec G304
+					So(err, ShouldBeNil)
+					So(rendered, ShouldEqual, string(expected))
+				})
+			})
+		})
+	}
+}
+
+func TestRenderTerraformSchema(t *testing.T) {
+	Convey("Given a terraform schema containing primitive, list and object properties", t, func() {
+		s := map[string]*schema.Schema{
+			"name":   {Type: schema.TypeString, Required: true, ForceNew: true},
+			"port":   {Type: schema.TypeInt, Optional: true, Default: 80},
+			"tags":   {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"secret": {Type: schema.TypeString, Optional: true, Computed: true, Sensitive: true},
+			"object": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"account": {Type: schema.TypeString, Optional: true},
+			}}},
+		}
+		Convey("When renderTerraformSchema is called", func() {
+			var b bytes.Buffer
+			renderTerraformSchema(&b, s, "  ")
+			Convey("Then the properties should be rendered sorted by name including the nested schemas", func() {
+				So(b.String(), ShouldEqual, `  name: TypeString required force_new
+  object: TypeList optional max_items=1
+    account: TypeString optional
+  port: TypeInt optional default=80
+  secret: TypeString optional computed sensitive
+  tags: TypeList optional elem=TypeString
+`)
+			})
+		})
+	})
+}
+
+func goldenSpecFiles(dir string) ([]string, error) {
+	var specFiles []string
+	for _, pattern := range []string{"*.yaml", "*.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		specFiles = append(specFiles, matches...)
+	}
+	sort.Strings(specFiles)
+	return specFiles, nil
+}
+
+// goldenProviderName is the name of the provider the golden schemas are rendered for
+const goldenProviderName = "openapi"
+
+// renderTerraformSchemas renders deterministically the terraform schemas of all the resources exposed by the given spec
+// analyser as the users get them: the resources are created by the provider factory, which is configured with the
+// provider properties that add attributes to the resources, and the id and timeouts added by terraform are included
+func renderTerraformSchemas(a SpecAnalyser) (string, error) {
+	p := providerFactory{
+		name:         goldenProviderName,
+		specAnalyser: a,
+		providerBlock: providerBlockConfiguration{
+			found:      true,
+			attributes: map[string]bool{providerPropertyRefreshSkipWindow: true, providerPropertyIdentityHeaders: true},
+		},
+	}
+	resourceMap, _, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+	if err != nil {
+		return "", err
+	}
+	resources, err := a.GetTerraformCompliantResources()
+	if err != nil {
+		return "", err
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].GetResourceName() < resources[j].GetResourceName()
+	})
+	var b bytes.Buffer
+	for _, resource := range resources {
+		if resource.ShouldIgnoreResource() {
+			continue
+		}
+		resourceName, err := p.getProviderResourceName(resource.GetResourceName())
+		if err != nil {
+			return "", err
+		}
+		terraformResource, exists := resourceMap[resourceName]
+		if !exists {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "resource %s\n", resourceName)
+		if description := resource.GetResourceDescription(); description != "" {
+			fmt.Fprintf(&b, "  # %s\n", description)
+		}
+		renderTerraformSchema(&b, withTerraformImplicitAttributes(terraformResource), "  ")
+	}
+	return b.String(), nil
+}
+
+// withTerraformImplicitAttributes returns the schema of the given resource including the id attribute and the timeouts
+// block terraform adds to the resource schemas (see schema.Resource CoreConfigSchema). The timeouts block is rendered as
+// a single item list
+func withTerraformImplicitAttributes(resource *schema.Resource) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+	for name, property := range resource.Schema {
+		s[name] = property
+	}
+	if _, exists := s["id"]; !exists {
+		s["id"] = &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true}
+	}
+	if _, exists := s[schema.TimeoutsConfigKey]; !exists && resource.Timeouts != nil {
+		timeouts := map[string]*schema.Schema{}
+		for name, timeout := range map[string]*time.Duration{
+			schema.TimeoutCreate:  resource.Timeouts.Create,
+			schema.TimeoutRead:    resource.Timeouts.Read,
+			schema.TimeoutUpdate:  resource.Timeouts.Update,
+			schema.TimeoutDelete:  resource.Timeouts.Delete,
+			schema.TimeoutDefault: resource.Timeouts.Default,
+		} {
+			if timeout != nil {
+				timeouts[name] = &schema.Schema{Type: schema.TypeString, Optional: true}
+			}
+		}
+		s[schema.TimeoutsConfigKey] = &schema.Schema{Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: &schema.Resource{Schema: timeouts}}
+	}
+	return s
+}
+
+func renderTerraformSchema(b *bytes.Buffer, terraformSchema map[string]*schema.Schema, indent string) {
+	var names []string
+	for name := range terraformSchema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := terraformSchema[name]
+		attributes := []string{s.Type.String()}
+		for _, f := range []struct {
+			name    string
+			enabled bool
+		}{
+			{"required", s.Required},
+			{"optional", s.Optional},
+			{"computed", s.Computed},
+			{"force_new", s.ForceNew},
+			{"sensitive", s.Sensitive},
+		} {
+			if f.enabled {
+				attributes = append(attributes, f.name)
+			}
+		}
+		if s.MaxItems > 0 {
+			attributes = append(attributes, fmt.Sprintf("max_items=%d", s.MaxItems))
+		}
+		if s.Default != nil {
+			attributes = append(attributes, fmt.Sprintf("default=%v", s.Default))
+		}
+		if elem, ok := s.Elem.(*schema.Schema); ok {
+			attributes = append(attributes, fmt.Sprintf("elem=%s", elem.Type))
+		}
+		fmt.Fprintf(b, "%s%s: %s\n", indent, name, strings.Join(attributes, " "))
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			renderTerraformSchema(b, elem.Schema, indent+"  ")
+		}
+	}
+}
@@ -5,7 +5,13 @@ import "github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 // SpecSecuritySchemes groups a list of SpecSecurityScheme
 type SpecSecuritySchemes []SpecSecurityScheme
 
+// createSecuritySchemes translates the given security requirements into SpecSecuritySchemes. Nil is returned if the
+// security requirements are not declared, whereas an empty (non nil) SpecSecuritySchemes is returned if they are declared
+// empty (e,g: 'security: []' or 'security: [{}]'), meaning that no authentication is required.
 func createSecuritySchemes(securitySchemes []map[string][]string) SpecSecuritySchemes {
+	if securitySchemes == nil {
+		return nil
+	}
 	schemes := SpecSecuritySchemes{}
 	for _, securityScheme := range securitySchemes {
 		for securitySchemeName := range securityScheme {
@@ -102,4 +102,27 @@ func TestCreateSecuritySchemes(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given securitySchemes that are not declared", t, func() {
+		var securitySchemes []map[string][]string
+		Convey("When createSecuritySchemes method is called with the securitySchemes", func() {
+			specSecuritySchemes := createSecuritySchemes(securitySchemes)
+			Convey("Then the specSecuritySchemes should be nil", func() {
+				So(specSecuritySchemes, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given securitySchemes that are declared empty (e,g: 'security: []' or 'security: [{}]')", t, func() {
+		Convey("When createSecuritySchemes method is called with the securitySchemes", func() {
+			emptySecurity := createSecuritySchemes([]map[string][]string{})
+			emptyRequirementSecurity := createSecuritySchemes([]map[string][]string{{}})
+			Convey("Then the specSecuritySchemes should be empty but not nil", func() {
+				So(emptySecurity, ShouldNotBeNil)
+				So(emptySecurity, ShouldBeEmpty)
+				So(emptyRequirementSecurity, ShouldNotBeNil)
+				So(emptyRequirementSecurity, ShouldBeEmpty)
+			})
+		})
+	})
 }
@@ -1,6 +1,10 @@
 package openapi
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+)
 
 // ParentResourceInfo contains the information related to the parent information. For instance, a subresource would have
 // this struct populated with the parent info so the resource name and corresponding parent properties can be configured in the
@@ -10,6 +14,26 @@ type ParentResourceInfo struct {
 	fullParentResourceName string
 	parentURIs             []string
 	parentInstanceURIs     []string
+	// parentLookups contains for each parent (same order as parentResourceNames) the configuration needed to resolve the
+	// parent ID from the value of one of the parent properties; nil for the parents that do not support look ups.
+	parentLookups []*parentResourceLookup
+	// parentIDFormats contains for each parent (same order as parentResourceNames) the format of the parent ID as declared
+	// in the path parameter identifying the parent (parentIDFormatUUID or parentIDFormatInteger); empty if not declared.
+	parentIDFormats []string
+}
+
+const (
+	// parentIDFormatUUID defines that the parent resource ID is a UUID (path parameter declared with format uuid)
+	parentIDFormatUUID = "uuid"
+	// parentIDFormatInteger defines that the parent resource ID is an integer (path parameter declared with type integer)
+	parentIDFormatInteger = "integer"
+)
+
+// parentResourceLookup defines how a parent resource ID can be resolved by listing the parent resources (using the
+// parent's list endpoint) and finding the one which lookupProperty matches the value configured in the sub-resource
+type parentResourceLookup struct {
+	lookupProperty string
+	listResource   SpecResource
 }
 
 // GetParentPropertiesNames is responsible to building the parent properties names for a resource that is a subresource
@@ -25,3 +49,31 @@ func (info *ParentResourceInfo) GetParentPropertiesNames() []string {
 func (info *ParentResourceInfo) SetParentResourceNames(parentResourceNames []string) {
 	info.parentResourceNames = parentResourceNames
 }
+
+// getParentLookup returns the look up configuration for the parent at the given position; nil if the parent does not support
+// being referenced by a property other than the ID
+func (info *ParentResourceInfo) getParentLookup(idx int) *parentResourceLookup {
+	if idx < 0 || idx >= len(info.parentLookups) {
+		return nil
+	}
+	return info.parentLookups[idx]
+}
+
+// getParentLookupPropertyName returns the name of the sub-resource property used to reference the parent at the given
+// position by its look up property (e,g: cdns_v1_label); empty if the parent does not support look ups
+func (info *ParentResourceInfo) getParentLookupPropertyName(idx int) string {
+	parentLookup := info.getParentLookup(idx)
+	if parentLookup == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s_%s", info.parentResourceNames[idx], terraformutils.ConvertToTerraformCompliantName(parentLookup.lookupProperty))
+}
+
+// getParentIDFormat returns the format of the ID of the parent at the given position (parentIDFormatUUID or
+// parentIDFormatInteger); empty if the format is not declared
+func (info *ParentResourceInfo) getParentIDFormat(idx int) string {
+	if idx < 0 || idx >= len(info.parentIDFormats) {
+		return ""
+	}
+	return info.parentIDFormats[idx]
+}
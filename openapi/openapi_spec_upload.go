@@ -0,0 +1,19 @@
+package openapi
+
+// uploadDefaultReferenceProperty is the property of the upload endpoint response containing the reference to the file
+// uploaded if the x-terraform-upload-reference-property extension is not present
+const uploadDefaultReferenceProperty = "id"
+
+// specUpload defines the upload endpoint the local file configured in a property (x-terraform-upload-path) is uploaded
+// to before the resource request is sent. The resource request contains the reference to the uploaded file returned by
+// the upload endpoint instead of the file contents, so large files are streamed from disk rather than buffered in memory
+type specUpload struct {
+	// path is the path of the upload endpoint (relative to the base path). It may contain the same path parameters as
+	// the resource path, which are resolved with the parent IDs (e,g: /v1/cdns/{cdn_id}/certificates/upload)
+	path string
+	// referenceProperty is the property of the upload endpoint response containing the reference to the file uploaded
+	referenceProperty string
+	// chunkSize is the max number of bytes sent per request. If zero, the file is uploaded with a single request;
+	// otherwise the file is uploaded in chunks with a resumable upload
+	chunkSize int64
+}
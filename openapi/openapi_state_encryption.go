@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// encryptedStateValuePrefix prefixes the values of the properties encrypted in the state, so they can be told apart from
+// plain values (e,g: states created before the property was configured to be encrypted)
+const encryptedStateValuePrefix = "encrypted:v1:"
+
+// stateEncryptionKeySize is the size (in bytes) of the AES-256 key used to encrypt the property values in the state
+const stateEncryptionKeySize = 32
+
+// stateEncryptionClient is implemented by the clients that support encrypting the values of the properties configured
+// with the x-terraform-encrypted extension before they are persisted in the state
+type stateEncryptionClient interface {
+	getStateEncryptionKey() []byte
+}
+
+// getStateEncryptionKey returns the key configured in the provider state_encryption_key property; nil if not configured
+func (o *ProviderClient) getStateEncryptionKey() []byte {
+	return o.providerConfiguration.StateEncryptionKey
+}
+
+// parseStateEncryptionKey decodes the given base64 encoded state encryption key
+func parseStateEncryptionKey(value string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(key) != stateEncryptionKeySize {
+		return nil, fmt.Errorf("the value must be a base64 encoded %d byte key (e,g: openssl rand -base64 %d)", stateEncryptionKeySize, stateEncryptionKeySize)
+	}
+	return key, nil
+}
+
+// encryptPayloadValues replaces in the given payload the values of the properties configured with the x-terraform-encrypted
+// extension with their encrypted values, so they are never persisted in plain text in the state by any of the resources
+// or data sources. The value stored in the given state (if any) is decrypted and kept as is if the API returned the same
+// value, so refreshing the resource does not rewrite it. The kind is the kind of terraform resource used in the errors.
+func encryptPayloadValues(openAPIResource SpecResource, kind string, payload map[string]interface{}, data *schema.ResourceData, i interface{}) error {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		if !property.Encrypted {
+			continue
+		}
+		value, exists := payload[property.Name]
+		if !exists || value == nil {
+			continue
+		}
+		var key []byte
+		if client, ok := i.(stateEncryptionClient); ok {
+			key = client.getStateEncryptionKey()
+		}
+		if len(key) == 0 {
+			return fmt.Errorf("[%s='%s'] property '%s' is configured to be encrypted in the state but the provider is not configured with the '%s' property", kind, openAPIResource.GetResourceName(), property.Name, providerPropertyStateEncryptionKey)
+		}
+		plainValue := fmt.Sprint(value)
+		if data != nil {
+			if stateValue, ok := data.Get(property.GetTerraformCompliantPropertyName()).(string); ok && stateValue != "" {
+				if decryptedValue, err := decryptStateValue(key, stateValue); err == nil && decryptedValue == plainValue {
+					payload[property.Name] = stateValue
+					continue
+				}
+			}
+		}
+		encryptedValue, err := encryptStateValue(key, plainValue)
+		if err != nil {
+			return fmt.Errorf("[%s='%s'] failed to encrypt property '%s': %s", kind, openAPIResource.GetResourceName(), property.Name, err)
+		}
+		payload[property.Name] = encryptedValue
+	}
+	return nil
+}
+
+func stateEncryptionKeyValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseStateEncryptionKey(value.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("property '%s' is not valid: %s", key, err))
+	}
+	return
+}
+
+// encryptStateValue encrypts the given value with AES-GCM using the given key. The value returned contains the random
+// nonce followed by the cipher text, base64 encoded and prefixed with encryptedStateValuePrefix
+func encryptStateValue(key []byte, value string) (string, error) {
+	gcm, err := newStateEncryptionCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedStateValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptStateValue decrypts the given value encrypted with encryptStateValue using the given key
+func decryptStateValue(key []byte, value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedStateValuePrefix) {
+		return "", errors.New("the value is not encrypted")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedStateValuePrefix))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newStateEncryptionCipher(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("the encrypted value is too short")
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func newStateEncryptionCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStateEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncryptStateValue(t *testing.T) {
+	Convey("Given a state encryption key", t, func() {
+		Convey("When encryptStateValue is called with a value", func() {
+			encryptedValue, err := encryptStateValue(testStateEncryptionKey, "secret")
+			So(err, ShouldBeNil)
+			Convey("Then the value returned should be prefixed and not contain the plain value", func() {
+				So(encryptedValue, ShouldStartWith, encryptedStateValuePrefix)
+				So(encryptedValue, ShouldNotContainSubstring, "secret")
+			})
+			Convey("And decryptStateValue should return the plain value", func() {
+				decryptedValue, err := decryptStateValue(testStateEncryptionKey, encryptedValue)
+				So(err, ShouldBeNil)
+				So(decryptedValue, ShouldEqual, "secret")
+			})
+			Convey("And decryptStateValue should fail if a different key is used", func() {
+				_, err := decryptStateValue([]byte(strings.Repeat("x", stateEncryptionKeySize)), encryptedValue)
+				So(err, ShouldNotBeNil)
+			})
+		})
+		Convey("When encryptStateValue is called twice with the same value", func() {
+			encryptedValue1, _ := encryptStateValue(testStateEncryptionKey, "secret")
+			encryptedValue2, _ := encryptStateValue(testStateEncryptionKey, "secret")
+			Convey("Then the values returned should be different as a random nonce is used", func() {
+				So(encryptedValue1, ShouldNotEqual, encryptedValue2)
+			})
+		})
+		Convey("When decryptStateValue is called with a plain value", func() {
+			_, err := decryptStateValue(testStateEncryptionKey, "secret")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the value is not encrypted")
+			})
+		})
+	})
+}
+
+func TestParseStateEncryptionKey(t *testing.T) {
+	key, err := parseStateEncryptionKey(base64.StdEncoding.EncodeToString(testStateEncryptionKey))
+	assert.Nil(t, err)
+	assert.Equal(t, testStateEncryptionKey, key)
+
+	_, err = parseStateEncryptionKey(base64.StdEncoding.EncodeToString([]byte("short key")))
+	assert.EqualError(t, err, "the value must be a base64 encoded 32 byte key (e,g: openssl rand -base64 32)")
+
+	_, err = parseStateEncryptionKey("not base64!")
+	assert.NotNil(t, err)
+
+	_, errs := stateEncryptionKeyValidateFunc("not base64!", providerPropertyStateEncryptionKey)
+	assert.Len(t, errs, 1)
+}
@@ -18,9 +18,14 @@ func newStubAuthenticator(expectedHeader, expectedHeaderValue string, err error)
 }
 
 func (s *specStubAuthenticator) prepareAuth(url string, operationSecuritySchemes SpecSecuritySchemes, providerConfig providerConfiguration) (*authContext, error) {
-	// mimicking api key header auth which does not change the url at all
-	if s.authContext.url == "" {
-		s.authContext.url = url
+	// mimicking api key header auth which does not change the url at all. A copy of the auth context is returned as the
+	// clients append headers to it and may perform requests to different URLs concurrently (e,g: batched reads)
+	authContext := &authContext{url: s.authContext.url, headers: map[string]string{}}
+	for name, value := range s.authContext.headers {
+		authContext.headers[name] = value
 	}
-	return s.authContext, s.err
+	if authContext.url == "" {
+		authContext.url = url
+	}
+	return authContext, s.err
 }
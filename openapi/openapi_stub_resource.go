@@ -2,20 +2,28 @@ package openapi
 
 // specStubResource is a stub implementation of SpecResource interface which is used for testing purposes
 type specStubResource struct {
-	name                    string
-	host                    string
-	path                    string
-	shouldIgnore            bool
-	schemaDefinition        *SpecSchemaDefinition
-	resourceGetOperation    *specResourceOperation
-	resourcePostOperation   *specResourceOperation
-	resourceListOperation   *specResourceOperation
-	resourcePutOperation    *specResourceOperation
-	resourceDeleteOperation *specResourceOperation
-	timeouts                *specTimeouts
+	name                     string
+	host                     string
+	basePath                 string
+	path                     string
+	shouldIgnore             bool
+	schemaDefinition         *SpecSchemaDefinition
+	resourceGetOperation     *specResourceOperation
+	resourcePostOperation    *specResourceOperation
+	resourceListOperation    *specResourceOperation
+	resourcePutOperation     *specResourceOperation
+	resourceDeleteOperation  *specResourceOperation
+	resourceHeadOperation    *specResourceOperation
+	timeouts                 *specTimeouts
+	schemaVersion            int
+	deprecationMessage       string
+	nameAliases              []string
+	description              string
+	responseHeaderAttributes map[string]string
 
 	parentResourceNames    []string
 	fullParentResourceName string
+	parentLookups          []*parentResourceLookup
 
 	funcGetResourcePath   func(parentIDs []string) (string, error)
 	funcGetResourceSchema func() (*SpecSchemaDefinition, error)
@@ -68,22 +76,55 @@ func (s *specStubResource) getResourceOperations() specResourceOperations {
 		Get:    s.resourceGetOperation,
 		Put:    s.resourcePutOperation,
 		Delete: s.resourceDeleteOperation,
+		Head:   s.resourceHeadOperation,
 	}
 }
 
+func (s *specStubResource) GetOperationIDs() map[string]string {
+	return getOperationIDs(s.getResourceOperations())
+}
+
 func (s *specStubResource) getTimeouts() (*specTimeouts, error) {
 	return s.timeouts, nil
 }
 
+func (s *specStubResource) getSchemaVersion() (int, error) {
+	if s.error != nil {
+		return 0, s.error
+	}
+	return s.schemaVersion, nil
+}
+
+func (s *specStubResource) getDeprecationMessage() string {
+	return s.deprecationMessage
+}
+
+func (s *specStubResource) getResourceNameAliases() []string {
+	return s.nameAliases
+}
+
+func (s *specStubResource) getResponseHeaderAttributes() map[string]string {
+	return s.responseHeaderAttributes
+}
+
+func (s *specStubResource) GetResourceDescription() string {
+	return s.description
+}
+
 func (s *specStubResource) getHost() (string, error) {
 	return s.host, nil
 }
 
+func (s *specStubResource) getBasePath() string {
+	return s.basePath
+}
+
 func (s *specStubResource) GetParentResourceInfo() *ParentResourceInfo {
 	subRes := ParentResourceInfo{}
 	if len(s.parentResourceNames) > 0 && s.fullParentResourceName != "" {
 		subRes.parentResourceNames = s.parentResourceNames
 		subRes.fullParentResourceName = s.fullParentResourceName
+		subRes.parentLookups = s.parentLookups
 		return &subRes
 	}
 	return nil
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
 	"github.com/go-openapi/spec"
@@ -12,6 +14,8 @@ import (
 
 const extTfProviderMultiRegionFQDN = "x-terraform-provider-multiregion-fqdn"
 const extTfProviderRegions = "x-terraform-provider-regions"
+const extRateLimitLimit = "x-ratelimit-limit"
+const extRateLimitPeriod = "x-ratelimit-period"
 
 type specV2BackendConfiguration struct {
 	openAPIDocumentURL string
@@ -140,3 +144,50 @@ func (o specV2BackendConfiguration) getHTTPScheme() (string, error) {
 
 	return defaultScheme, nil
 }
+
+// getRateLimit returns the max number of requests the API allows within the period returned, as documented in the root
+// level extensions x-ratelimit-limit and x-ratelimit-period (in seconds, defaults to 1 second). A zero limit is returned
+// if the document does not specify any rate limit.
+func (o specV2BackendConfiguration) getRateLimit() (int, time.Duration, error) {
+	limit, err := o.getPositiveIntExtension(extRateLimitLimit)
+	if err != nil || limit == 0 {
+		return 0, 0, err
+	}
+	periodSeconds, err := o.getPositiveIntExtension(extRateLimitPeriod)
+	if err != nil {
+		return 0, 0, err
+	}
+	if periodSeconds == 0 {
+		periodSeconds = 1
+	}
+	return limit, time.Duration(periodSeconds) * time.Second, nil
+}
+
+// getPositiveIntExtension returns the value of the given root level extension. Zero is returned if the extension is not present
+func (o specV2BackendConfiguration) getPositiveIntExtension(extension string) (int, error) {
+	value, exists := o.spec.Extensions[extension]
+	if !exists {
+		return 0, nil
+	}
+	var intValue int
+	switch v := value.(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("invalid '%s' extension value '%v': the value must be a positive integer", extension, value)
+		}
+		intValue = int(v)
+	case int:
+		intValue = v
+	case string:
+		var err error
+		if intValue, err = strconv.Atoi(v); err != nil {
+			return 0, fmt.Errorf("invalid '%s' extension value '%v': the value must be a positive integer", extension, value)
+		}
+	default:
+		return 0, fmt.Errorf("invalid '%s' extension value '%v': the value must be a positive integer", extension, value)
+	}
+	if intValue <= 0 {
+		return 0, fmt.Errorf("invalid '%s' extension value '%v': the value must be a positive integer", extension, value)
+	}
+	return intValue, nil
+}
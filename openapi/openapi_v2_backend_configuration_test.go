@@ -1,10 +1,13 @@
 package openapi
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -536,3 +539,51 @@ func TestGetHTTPSchemes(t *testing.T) {
 
 	}
 }
+
+func TestGetRateLimit(t *testing.T) {
+	testCases := []struct {
+		name           string
+		extensions     spec.Extensions
+		expectedLimit  int
+		expectedPeriod time.Duration
+		expectedError  error
+	}{
+		{
+			name:           "rate limit not documented",
+			extensions:     spec.Extensions{},
+			expectedLimit:  0,
+			expectedPeriod: 0,
+		},
+		{
+			name:           "rate limit documented without period",
+			extensions:     spec.Extensions{extRateLimitLimit: float64(10)},
+			expectedLimit:  10,
+			expectedPeriod: time.Second,
+		},
+		{
+			name:           "rate limit documented with period",
+			extensions:     spec.Extensions{extRateLimitLimit: "100", extRateLimitPeriod: float64(60)},
+			expectedLimit:  100,
+			expectedPeriod: time.Minute,
+		},
+		{
+			name:          "rate limit with a value that is not an integer",
+			extensions:    spec.Extensions{extRateLimitLimit: float64(1.5)},
+			expectedError: errors.New("invalid 'x-ratelimit-limit' extension value '1.5': the value must be a positive integer"),
+		},
+		{
+			name:          "rate limit period with a negative value",
+			extensions:    spec.Extensions{extRateLimitLimit: float64(10), extRateLimitPeriod: float64(-1)},
+			expectedError: errors.New("invalid 'x-ratelimit-period' extension value '-1': the value must be a positive integer"),
+		},
+	}
+	for _, tc := range testCases {
+		specV2BackendConfiguration := specV2BackendConfiguration{
+			spec: &spec.Swagger{VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions}},
+		}
+		limit, period, err := specV2BackendConfiguration.getRateLimit()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+		assert.Equal(t, tc.expectedLimit, limit, tc.name)
+		assert.Equal(t, tc.expectedPeriod, period, tc.name)
+	}
+}
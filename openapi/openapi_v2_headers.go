@@ -6,6 +6,7 @@ import (
 )
 
 const extTfHeader = "x-terraform-header"
+const extTfHeaderResourceAttribute = "x-terraform-header-resource-attribute"
 
 type parameterGroups [][]spec.Parameter
 
@@ -29,10 +30,11 @@ func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups)
 				headers[parameter.Name] = parameter.Name
 				switch parameter.In {
 				case "header":
+					isResourceAttribute, _ := parameter.Extensions.GetBool(extTfHeaderResourceAttribute)
 					if preferredName, exists := parameter.Extensions.GetString(extTfHeader); exists {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, TerraformName: preferredName, IsRequired: parameter.Required})
+						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, TerraformName: preferredName, IsRequired: parameter.Required, IsResourceAttribute: isResourceAttribute})
 					} else {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required})
+						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required, IsResourceAttribute: isResourceAttribute})
 					}
 				}
 			} else {
@@ -65,9 +67,42 @@ func getAllHeaderParameters(paths map[string]spec.PathItem) SpecHeaderParameters
 			}
 		}
 	}
+	globalHeaders := getGlobalRequiredHeaderNames(paths)
+	for i := range specHeaderParameters {
+		specHeaderParameters[i].IsGlobal = globalHeaders[specHeaderParameters[i].Name]
+	}
 	return specHeaderParameters
 }
 
+// getGlobalRequiredHeaderNames returns the names of the header parameters that are required in every operation of the
+// given paths
+func getGlobalRequiredHeaderNames(paths map[string]spec.PathItem) map[string]bool {
+	var globalHeaders map[string]bool
+	for _, path := range paths {
+		for _, operation := range []*spec.Operation{path.Post, path.Get, path.Put, path.Delete} {
+			if operation == nil {
+				continue
+			}
+			requiredHeaders := map[string]bool{}
+			for _, parameter := range operation.Parameters {
+				if parameter.In == "header" && parameter.Required {
+					requiredHeaders[parameter.Name] = true
+				}
+			}
+			if globalHeaders == nil {
+				globalHeaders = requiredHeaders
+				continue
+			}
+			for headerName := range globalHeaders {
+				if !requiredHeaders[headerName] {
+					delete(globalHeaders, headerName)
+				}
+			}
+		}
+	}
+	return globalHeaders
+}
+
 // appendOperationParametersIfPresent is a helper function that checks whether the given operation is not nil and if so
 // appends its parameters to the parametersGroups
 func appendOperationParametersIfPresent(parametersGroups parameterGroups, operation *spec.Operation) parameterGroups {
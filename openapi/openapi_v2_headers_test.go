@@ -46,6 +46,30 @@ func TestGetHeaderConfigurations(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a list of parameters containing one header parameter with the 'x-terraform-header-resource-attribute' extension", t, func() {
+		parameters := parameterGroups{
+			[]spec.Parameter{
+				{
+					ParamProps: spec.ParamProps{
+						Name:     "X-Tenant-ID",
+						In:       "header",
+						Required: true,
+					},
+					VendorExtensible: spec.VendorExtensible{
+						Extensions: spec.Extensions{
+							"x-terraform-header-resource-attribute": true,
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetHeaderConfigurationsForParameterGroups method is called", func() {
+			headerConfigProps := getHeaderConfigurationsForParameterGroups(parameters)
+			Convey("Then the header configs returned should contain 'x_tenant_id' exposed as a resource attribute", func() {
+				So(headerConfigProps, ShouldContain, SpecHeaderParam{Name: "X-Tenant-ID", TerraformName: "", IsRequired: true, IsResourceAttribute: true})
+			})
+		})
+	})
 	Convey("Given a list of parameters containing one required header parameter with the 'x-terraform-header' extension", t, func() {
 		parameters := parameterGroups{
 			[]spec.Parameter{
@@ -269,9 +293,41 @@ func TestGetAllHeaderParameters(t *testing.T) {
 		}
 		Convey("When getPathHeaderParams method is called", func() {
 			headerConfigProps := getAllHeaderParameters(spec.Paths.Paths)
-			Convey("Then the headers shoud contain just one header since the other header names were the same", func() {
+			Convey("Then the headers shoud contain just one header since the other header names were the same, which is global as it's required in every operation", func() {
 				So(len(headerConfigProps), ShouldEqual, 1)
-				So(headerConfigProps, ShouldContain, SpecHeaderParam{Name: "X-Request-ID", IsRequired: true})
+				So(headerConfigProps, ShouldContain, SpecHeaderParam{Name: "X-Request-ID", IsRequired: true, IsGlobal: true})
+			})
+		})
+	})
+	Convey("Given a swagger doc containing a header required in every operation and headers required only in some operations", t, func() {
+		apiVersionHeader := spec.Parameter{ParamProps: spec.ParamProps{Name: "X-Api-Version", In: "header", Required: true}}
+		requestIDHeader := spec.Parameter{ParamProps: spec.ParamProps{Name: "X-Request-ID", In: "header", Required: true}}
+		optionalRequestIDHeader := spec.Parameter{ParamProps: spec.ParamProps{Name: "X-Request-ID", In: "header"}}
+		spec := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Paths: &spec.Paths{
+					Paths: map[string]spec.PathItem{
+						"/v1/cdns": {
+							PathItemProps: spec.PathItemProps{
+								Post: &spec.Operation{OperationProps: spec.OperationProps{Parameters: []spec.Parameter{apiVersionHeader, requestIDHeader}}},
+							},
+						},
+						"/v1/cdns/{id}": {
+							PathItemProps: spec.PathItemProps{
+								Get:    &spec.Operation{OperationProps: spec.OperationProps{Parameters: []spec.Parameter{apiVersionHeader, optionalRequestIDHeader}}},
+								Delete: &spec.Operation{OperationProps: spec.OperationProps{Parameters: []spec.Parameter{apiVersionHeader}}},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getAllHeaderParameters method is called", func() {
+			headerConfigProps := getAllHeaderParameters(spec.Paths.Paths)
+			Convey("Then only the header required in every operation should be global", func() {
+				So(len(headerConfigProps), ShouldEqual, 2)
+				So(headerConfigProps, ShouldContain, SpecHeaderParam{Name: "X-Api-Version", IsRequired: true, IsGlobal: true})
+				So(headerConfigProps, ShouldNotContain, SpecHeaderParam{Name: "X-Request-ID", IsRequired: true, IsGlobal: true})
 			})
 		})
 	})
@@ -0,0 +1,39 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/go-openapi/spec"
+)
+
+const extTfQueryParamValue = "x-terraform-query-param-value"
+const extTfQueryParamResourceAttribute = "x-terraform-query-param-resource-attribute"
+
+// getQueryParameters returns the query parameters of the given operation parameters that are either pinned to a constant
+// value (x-terraform-query-param-value) or exposed as resource attributes (x-terraform-query-param-resource-attribute).
+// Other query parameters are returned as filters, which are only sent with the value of the data source filter matching
+// their name.
+func getQueryParameters(parameters []spec.Parameter) SpecQueryParameters {
+	queryParameters := SpecQueryParameters{}
+	for _, parameter := range parameters {
+		if parameter.In != "query" {
+			continue
+		}
+		queryParam := SpecQueryParam{Name: parameter.Name, IsRequired: parameter.Required}
+		queryParam.IsResourceAttribute, _ = parameter.Extensions.GetBool(extTfQueryParamResourceAttribute)
+		if value, exists := parameter.Extensions[extTfQueryParamValue]; exists && value != nil {
+			queryParam.Value = fmt.Sprintf("%v", value)
+		}
+		if queryParam.Value == "" && !queryParam.IsResourceAttribute {
+			log.Printf("[DEBUG] query parameter '%s' is neither pinned to a value nor exposed as a resource attribute, it will only be sent with the value of the data source filter matching its name", parameter.Name)
+			queryParam.IsFilter = true
+		}
+		if queryParameters.specQueryParamExists(queryParam) {
+			log.Printf("[DEBUG] found duplicate query parameter '%s' for an operation, ignoring it as it has been registered already", parameter.Name)
+			continue
+		}
+		queryParameters = append(queryParameters, queryParam)
+	}
+	return queryParameters
+}
@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetQueryParameters(t *testing.T) {
+	Convey("Given a list of parameters containing query parameters pinned to a value, exposed as resource attributes and with no extensions", t, func() {
+		parameters := []spec.Parameter{
+			{
+				ParamProps: spec.ParamProps{Name: "force", In: "query"},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{extTfQueryParamValue: true},
+				},
+			},
+			{
+				ParamProps: spec.ParamProps{Name: "validateOnly", In: "query", Required: true},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{extTfQueryParamResourceAttribute: true},
+				},
+			},
+			{
+				ParamProps: spec.ParamProps{Name: "page", In: "query"},
+			},
+			{
+				ParamProps: spec.ParamProps{Name: "X-Request-ID", In: "header"},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{extTfQueryParamValue: "value"},
+				},
+			},
+		}
+		Convey("When getQueryParameters is called", func() {
+			queryParameters := getQueryParameters(parameters)
+			Convey("Then the query parameters should be returned and the ones with no extensions should be flagged as filters", func() {
+				So(queryParameters, ShouldResemble, SpecQueryParameters{
+					{Name: "force", Value: "true"},
+					{Name: "validateOnly", IsRequired: true, IsResourceAttribute: true},
+					{Name: "page", IsFilter: true},
+				})
+			})
+			Convey("And the query parameter terraform names should be terraform compliant", func() {
+				So(queryParameters[1].GetQueryParamTerraformName(), ShouldEqual, "validate_only")
+			})
+		})
+	})
+	Convey("Given a list of parameters containing a duplicated query parameter", t, func() {
+		parameters := []spec.Parameter{
+			{
+				ParamProps: spec.ParamProps{Name: "force", In: "query"},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{extTfQueryParamValue: "true"},
+				},
+			},
+			{
+				ParamProps: spec.ParamProps{Name: "force", In: "query"},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{extTfQueryParamValue: "false"},
+				},
+			},
+		}
+		Convey("When getQueryParameters is called", func() {
+			queryParameters := getQueryParameters(parameters)
+			Convey("Then the first query parameter registered should be kept", func() {
+				So(queryParameters, ShouldResemble, SpecQueryParameters{{Name: "force", Value: "true"}})
+			})
+		})
+	})
+}
@@ -1,17 +1,21 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 	"github.com/go-openapi/spec"
 )
 
-const pathParameterRegex = "/({[\\w]*})*/"
+const pathParameterRegex = "/({[\\w-]*})*/"
 
 // resourceVersionRegexTemplate is used to identify the version attached to the given resource. The parameter in the
 // template will be replaced with the actual resource name so if there is a match the version grabbed is assured to belong
@@ -44,10 +48,14 @@ const resourceNameRegex = "((/[\\w-]*[/]?))+$"
 // matches[1][1]: Group 1. /v2/firewalls
 // matches[1][2]: Group 2. v2
 // matches[1][3]: Group 3. firewalls
-const resourceParentNameRegex = `(\/(?:\w+\/)?(?:v\d+\/)?\w+)\/{\w+}`
+const resourceParentNameRegex = `(\/(?:[\w-]+\/)?(?:v\d+\/)?[\w-]+)\/{[\w-]+}`
 
 const resourceInstanceRegex = "((?:.*)){.*}"
 
+// pathParamNameRegex is used to identify the path parameters (and their names) present in a path, e,g: given "/v1/projects/{project-id}/clusters"
+// the match will contain "{project-id}" and the sub-match "project-id"
+const pathParamNameRegex = `{([\w-]+)}`
+
 // Definition level extensions
 const extTfImmutable = "x-terraform-immutable"
 const extTfForceNew = "x-terraform-force-new"
@@ -58,16 +66,78 @@ const extTfID = "x-terraform-id"
 const extTfComputed = "x-terraform-computed"
 const extTfComplexObjectType = "x-terraform-complex-object-legacy-config"
 const extTfIgnoreOrder = "x-terraform-ignore-order"
+const extTfFieldRenamedFrom = "x-terraform-field-renamed-from"
+const extTfFieldResponseHeader = "x-terraform-field-response-header"
+const extTfIgnoreDrift = "x-terraform-ignore-drift"
+const extTfIgnoreKeyPrefixes = "x-terraform-ignore-key-prefixes"
+const extTfForceNewItemFields = "x-terraform-force-new-item-fields"
+const extTfUnsupportedTypes = "x-terraform-unsupported-types"
+const extTfTransform = "x-terraform-transform"
+
+// Supported values of the x-terraform-unsupported-types extension
+const (
+	// unsupportedTypesError fails the resources containing properties which type is not supported (default)
+	unsupportedTypesError = "error"
+	// unsupportedTypesIgnore drops the properties which type is not supported from the resources
+	unsupportedTypesIgnore = "ignore"
+	// unsupportedTypesJSON exposes the properties which type is not supported as strings containing the JSON encoded value
+	unsupportedTypesJSON = "json"
+)
+const extTfRequestOnly = "x-terraform-request-only"
+const extTfEncrypted = "x-terraform-encrypted"
+const extTfClientGenerated = "x-terraform-client-generated"
+const extTfClientGeneratedLength = "x-terraform-client-generated-length"
+const extTfClientGeneratedCharset = "x-terraform-client-generated-charset"
 const extIgnoreOrder = "x-ignore-order"
+const extTfUploadPath = "x-terraform-upload-path"
+const extTfUploadReferenceProperty = "x-terraform-upload-reference-property"
+const extTfUploadChunkSize = "x-terraform-upload-chunk-size"
 
 // Operation level extensions
 const extTfResourceTimeout = "x-terraform-resource-timeout"
 const extTfResourcePollEnabled = "x-terraform-resource-poll-enabled"
 const extTfResourcePollTargetStatuses = "x-terraform-resource-poll-completed-statuses"
 const extTfResourcePollPendingStatuses = "x-terraform-resource-poll-pending-statuses"
+const extTfResourcePollStatusPath = "x-terraform-resource-poll-status-path"
+const extTfResourcePollCompletedConditions = "x-terraform-resource-poll-completed-conditions"
 const extTfExcludeResource = "x-terraform-exclude-resource"
+const extTfDataSourceOnly = "x-terraform-data-source-only"
 const extTfResourceName = "x-terraform-resource-name"
+const extTfResourceNameAliases = "x-terraform-resource-name-aliases"
 const extTfResourceURL = "x-terraform-resource-host"
+const extTfResourceBasePath = "x-terraform-resource-base-path"
+const extTfResourceSchemaVersion = "x-terraform-resource-schema-version"
+const extTfSuccessStatusCodes = "x-terraform-success-status-codes"
+const extTfSuccessConditions = "x-terraform-success-conditions"
+const extTfResourceDeprecated = "x-terraform-resource-deprecated"
+const extTfResourceLookupProperty = "x-terraform-resource-lookup-property"
+const extTfResourceExistenceCheck = "x-terraform-resource-existence-check"
+const extTfResourceResponseHeaders = "x-terraform-resource-response-headers"
+const extTfDeleteMaxConcurrency = "x-terraform-delete-max-concurrency"
+const extTfBulkDeletePath = "x-terraform-bulk-delete-path"
+const extTfBulkDeleteIDsProperty = "x-terraform-bulk-delete-ids-property"
+const extTfBulkDeleteMaxBatchSize = "x-terraform-bulk-delete-max-batch-size"
+const extTfPreDeleteMethod = "x-terraform-pre-delete-method"
+const extTfPreDeletePath = "x-terraform-pre-delete-path"
+const extTfPreDeletePayload = "x-terraform-pre-delete-payload"
+const extTfBatchReadIDsParam = "x-terraform-batch-read-ids-param"
+const extTfBatchReadMaxBatchSize = "x-terraform-batch-read-max-batch-size"
+const extTfRetryableErrors = "x-terraform-retryable-errors"
+const extTfRetryMaxRetries = "x-terraform-retry-max-retries"
+const extTfRetryInitialBackoff = "x-terraform-retry-initial-backoff"
+const extTfRetryMaxBackoff = "x-terraform-retry-max-backoff"
+const extTfPagination = "x-terraform-pagination"
+const extTfPaginationNextTokenHeader = "x-terraform-pagination-next-token-header"
+const extTfPaginationTokenParam = "x-terraform-pagination-token-param"
+const extTfPaginationPageSizeParam = "x-terraform-pagination-page-size-param"
+const extTfPaginationMaxPageSize = "x-terraform-pagination-max-page-size"
+const extTfMostRecentProperty = "x-terraform-most-recent-property"
+const extTfOperationHost = "x-terraform-operation-host"
+const extTfConditionalRequest = "x-terraform-conditional-request"
+const extTfErrorFormat = "x-terraform-error-format"
+
+// Parameter level extensions
+const extTfParentResource = "x-terraform-parent-resource"
 
 // SpecV2Resource defines a struct that implements the SpecResource interface and it's based on OpenAPI v2 specification
 type SpecV2Resource struct {
@@ -88,6 +158,19 @@ type SpecV2Resource struct {
 
 	Paths map[string]spec.PathItem
 
+	// complexObjectLegacyConfigEnabled is set when the x-terraform-complex-object-legacy-config extension is enabled at
+	// the root level of the document, in which case all the object properties are configured as blocks
+	complexObjectLegacyConfigEnabled bool
+
+	// errorFormat is set when the x-terraform-error-format extension is configured at the root level of the document, in
+	// which case the error responses of all the operations are parsed accordingly unless the operation overrides it
+	errorFormat string
+
+	// unsupportedTypes is set when the x-terraform-unsupported-types extension is configured at the root level of the
+	// document, in which case the properties which type is not supported are either ignored or exposed as JSON strings
+	// instead of failing the whole resource
+	unsupportedTypes string
+
 	// Cached objects that are loaded once (when the corresponding function that loads the object is called the first time) and
 	// on subsequent method calls the cached object is returned instead saving executing time.
 
@@ -200,22 +283,38 @@ func (o *SpecV2Resource) buildResourceNameFromPath(resourcePath, preferredName s
 	resourceName = strings.Replace(matches[len(matches)-1], "/", "", -1)
 	resourceName = strings.ReplaceAll(resourceName, "-", "_")
 
-	versionRegex, _ := regexp.Compile(fmt.Sprintf(resourceVersionRegexTemplate, resourceName))
+	version := getResourceVersion(resourcePath)
 
 	if preferredName != "" {
 		resourceName = preferredName
 	}
 
 	fullResourceName := resourceName
-	v := versionRegex.FindAllStringSubmatch(resourcePath, -1)
-	if len(v) > 0 {
-		version := v[0][1]
+	if version != "" {
 		fullResourceName = fmt.Sprintf("%s_%s", resourceName, version)
 	}
 
 	return fullResourceName, nil
 }
 
+// getResourceVersion returns the version the given resource path refers to (e,g: /v1/cdns -> v1). If the resource path
+// is not versioned an empty string is returned.
+func getResourceVersion(resourcePath string) string {
+	nameRegex, _ := regexp.Compile(resourceNameRegex)
+	matches := nameRegex.FindStringSubmatch(resourcePath)
+	if len(matches) < 2 {
+		return ""
+	}
+	resourceName := strings.Replace(matches[len(matches)-1], "/", "", -1)
+	resourceName = strings.ReplaceAll(resourceName, "-", "_")
+	versionRegex, _ := regexp.Compile(fmt.Sprintf(resourceVersionRegexTemplate, resourceName))
+	v := versionRegex.FindAllStringSubmatch(resourcePath, -1)
+	if len(v) > 0 {
+		return v[0][1]
+	}
+	return ""
+}
+
 // getResourcePath returns the root path of the resource. If the resource is a subresource and therefore the path contains
 // path parameters these will be resolved accordingly based on the ids provided. For instance, considering the given
 // resource path "/v1/cdns/{cdn_id}/v1/firewalls" and the []strin{"cdnID"} the returned path will be "/v1/cdns/cdnID/v1/firewalls".
@@ -259,7 +358,10 @@ func (o *SpecV2Resource) getResourcePath(parentIDs []string) (string, error) {
 // getHost can return an empty host in which case the expectation is that the host used will be the one specified in the
 // swagger host attribute or if not present the host used will be the host where the swagger file was served
 func (o *SpecV2Resource) getHost() (string, error) {
-	overrideHost := getResourceOverrideHost(o.RootPathItem.Post)
+	overrideHost, err := openapiutils.InterpolateEnvVariables(getResourceOverrideHost(o.RootPathItem.Post))
+	if err != nil {
+		return "", err
+	}
 	if overrideHost == "" {
 		return "", nil
 	}
@@ -273,6 +375,23 @@ func (o *SpecV2Resource) getHost() (string, error) {
 	return overrideHost, nil
 }
 
+// GetHost returns the host the resource is served from when it is configured with the x-terraform-resource-host
+// extension (resolved for the resource region in multi-region resources); empty if the resource is served from the
+// global host. This is exposed so consumers of the spec analyser (e,g: the code generator) can honor it
+func (o *SpecV2Resource) GetHost() (string, error) {
+	return o.getHost()
+}
+
+// getBasePath returns the base path configured in the resource root's POST operation x-terraform-resource-base-path
+// extension, which overrides the global base path. A value of "/" means the resource is not served under any base path.
+// Empty is returned if the resource does not override the global base path
+func (o *SpecV2Resource) getBasePath() string {
+	if o.RootPathItem.Post == nil {
+		return ""
+	}
+	return o.getExtensionStringValue(o.RootPathItem.Post.Extensions, extTfResourceBasePath)
+}
+
 func (o *SpecV2Resource) getResourceOperations() specResourceOperations {
 	return specResourceOperations{
 		List:   o.createResourceOperation(o.RootPathItem.Get),
@@ -280,7 +399,26 @@ func (o *SpecV2Resource) getResourceOperations() specResourceOperations {
 		Get:    o.createResourceOperation(o.InstancePathItem.Get),
 		Put:    o.createResourceOperation(o.InstancePathItem.Put),
 		Delete: o.createResourceOperation(o.InstancePathItem.Delete),
+		Head:   o.createResourceOperation(o.InstancePathItem.Head),
+	}
+}
+
+// getPathTemplate returns the resource root path as defined in the OpenAPI document, without resolving the parent path
+// parameters
+func (o *SpecV2Resource) getPathTemplate() string {
+	return o.Path
+}
+
+// getResourceExtensions returns the x-terraform-* extensions configured in the resource root and instance paths as well
+// as in the resource model definition
+func (o *SpecV2Resource) getResourceExtensions() map[string]interface{} {
+	extensions := getTerraformExtensions(o.RootPathItem.Extensions)
+	for _, ext := range []map[string]interface{}{o.InstancePathItem.Extensions, o.SchemaDefinition.Extensions} {
+		for key, value := range getTerraformExtensions(ext) {
+			extensions[key] = value
+		}
 	}
+	return extensions
 }
 
 // ShouldIgnoreResource checks whether the POST operation for a given resource as the 'x-terraform-exclude-resource' extension
@@ -304,36 +442,32 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 		log.Printf("[DEBUG] GetParentResourceInfo hit the cache for '%s'", o.Name)
 		return o.parentResourceInfoCached
 	}
-	resourceParentRegex, _ := regexp.Compile(resourceParentNameRegex)
-	parentMatches := resourceParentRegex.FindAllStringSubmatch(o.Path, -1)
-	if len(parentMatches) > 0 {
-		var parentURI string
-		var parentInstanceURI string
-
-		var parentResourceNames, parentURIs, parentInstanceURIs []string
+	parentURIs, parentInstanceURIs := o.getExplicitParentURIs()
+	if parentURIs == nil {
+		resourceParentRegex, _ := regexp.Compile(resourceParentNameRegex)
+		// the parent URIs are built from the beginning of the path so static prefixes containing more than one segment
+		// (e,g: /api/admin/v1/projects/{id}/clusters) are kept in the parent URIs
+		parentMatches := resourceParentRegex.FindAllStringSubmatchIndex(o.Path, -1)
 		for _, match := range parentMatches {
-			fullMatch := match[0]
-			rootPath := match[1]
-			parentURI = parentInstanceURI + rootPath
-			parentInstanceURI = parentInstanceURI + fullMatch
-			parentURIs = append(parentURIs, parentURI)
-			parentInstanceURIs = append(parentInstanceURIs, parentInstanceURI)
+			parentURIs = append(parentURIs, o.Path[:match[3]])
+			parentInstanceURIs = append(parentInstanceURIs, o.Path[:match[1]])
 		}
+	}
+	if len(parentURIs) > 0 {
+		var parentResourceNames []string
+		var parentLookups []*parentResourceLookup
+		var parentIDFormats []string
 
 		fullParentResourceName := ""
 		preferredParentName := ""
 		for _, parentURI := range parentURIs {
+			var parentLookup *parentResourceLookup
 			// `o.Paths` is used to read the preferred name over that resource if `x-terraform-preferred-name` is set
-			if o.Paths != nil {
-				if parent, ok := o.Paths[parentURI]; ok {
-					preferredParentName = o.getPreferredName(parent)
-				} else {
-					// Falling back to checking path with trailing slash
-					if parent, ok := o.Paths[parentURI+"/"]; ok {
-						preferredParentName = o.getPreferredName(parent)
-					}
-				}
+			if parent, ok := findPathItem(o.Paths, parentURI); ok {
+				preferredParentName = o.getPreferredName(parent)
+				parentLookup = o.getParentResourceLookup(parentURI, parent)
 			}
+			parentLookups = append(parentLookups, parentLookup)
 			parentResourceName, err := o.buildResourceNameFromPath(parentURI, preferredParentName)
 			if err != nil {
 				log.Printf("[ERROR] could not build parent resource info due to the following error: %s", err)
@@ -342,6 +476,9 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 			parentResourceNames = append(parentResourceNames, parentResourceName)
 			fullParentResourceName = fullParentResourceName + parentResourceName + "_"
 		}
+		for _, parentInstanceURI := range parentInstanceURIs {
+			parentIDFormats = append(parentIDFormats, o.getParentIDFormat(parentInstanceURI))
+		}
 		fullParentResourceName = strings.TrimRight(fullParentResourceName, "_")
 
 		sub := &ParentResourceInfo{
@@ -349,6 +486,8 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 			fullParentResourceName: fullParentResourceName,
 			parentURIs:             parentURIs,
 			parentInstanceURIs:     parentInstanceURIs,
+			parentLookups:          parentLookups,
+			parentIDFormats:        parentIDFormats,
 		}
 		o.parentResourceInfoCached = sub
 		log.Printf("[DEBUG] GetParentResourceInfo cache loaded for '%s'", o.Name)
@@ -357,6 +496,131 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 	return nil
 }
 
+// getExplicitParentURIs returns the parent root and instance URIs based on the path parameters of the resource root POST
+// operation that are mapped to a parent resource via the x-terraform-parent-resource extension, e,g: given the resource
+// path "/api/admin/v1/projects/{projectId}/clusters" and the path parameter projectId mapped to "/api/admin/v1/projects", the parent
+// URIs returned will be ["/api/admin/v1/projects"] and the instance URIs ["/api/admin/v1/projects/{projectId}"]. Path
+// parameters that are not mapped default to the part of the path preceding them. Nil is returned if none of the path
+// parameters are mapped, in which case the parent resources are inferred from the path
+func (o *SpecV2Resource) getExplicitParentURIs() ([]string, []string) {
+	if o.RootPathItem.Post == nil {
+		return nil, nil
+	}
+	explicitParents := map[string]string{}
+	for _, parameter := range o.RootPathItem.Post.Parameters {
+		if parameter.In != "path" {
+			continue
+		}
+		if parentURI := o.getExtensionStringValue(parameter.Extensions, extTfParentResource); parentURI != "" {
+			explicitParents[parameter.Name] = parentURI
+		}
+	}
+	if len(explicitParents) == 0 {
+		return nil, nil
+	}
+	var parentURIs, parentInstanceURIs []string
+	pathParamRegex, _ := regexp.Compile(pathParamNameRegex)
+	for _, match := range pathParamRegex.FindAllStringSubmatchIndex(o.Path, -1) {
+		parentInstanceURI := o.Path[:match[1]]
+		parentURI, mapped := explicitParents[o.Path[match[2]:match[3]]]
+		if !mapped {
+			parentURI = strings.TrimRight(o.Path[:match[0]], "/")
+		}
+		parentURIs = append(parentURIs, parentURI)
+		parentInstanceURIs = append(parentInstanceURIs, parentInstanceURI)
+	}
+	return parentURIs, parentInstanceURIs
+}
+
+// getParentIDFormat returns the format of the parent ID identified by the last path parameter of the given parent instance
+// URI (e,g: cdn_id in "/v1/cdns/{cdn_id}"), based on how the path parameter is declared in the resource operations:
+// parentIDFormatInteger if the parameter is of type integer and parentIDFormatUUID if it has format uuid. Empty is
+// returned if the parameter is not declared or its format is not one of the above.
+func (o *SpecV2Resource) getParentIDFormat(parentInstanceURI string) string {
+	pathParamRegex, _ := regexp.Compile(pathParamNameRegex)
+	matches := pathParamRegex.FindAllStringSubmatch(parentInstanceURI, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	parameterName := matches[len(matches)-1][1]
+	var parameters []spec.Parameter
+	parameters = append(parameters, o.RootPathItem.Parameters...)
+	if o.RootPathItem.Post != nil {
+		parameters = append(parameters, o.RootPathItem.Post.Parameters...)
+	}
+	parameters = append(parameters, o.InstancePathItem.Parameters...)
+	if o.InstancePathItem.Get != nil {
+		parameters = append(parameters, o.InstancePathItem.Get.Parameters...)
+	}
+	for _, parameter := range parameters {
+		if parameter.In != "path" || parameter.Name != parameterName {
+			continue
+		}
+		switch {
+		case parameter.Type == "integer":
+			return parentIDFormatInteger
+		case parameter.Type == "string" && parameter.Format == "uuid":
+			return parentIDFormatUUID
+		}
+		return ""
+	}
+	return ""
+}
+
+// findPathItem looks up the given path in the paths provided. If the path is not found as is, the path with trailing
+// slash is checked and finally any path matching the given one regardless of the names used for the path parameters
+// (e,g: "/v1/projects/{projectId}" would match "/v1/projects/{id}")
+func findPathItem(paths map[string]spec.PathItem, path string) (spec.PathItem, bool) {
+	if paths == nil {
+		return spec.PathItem{}, false
+	}
+	if pathItem, exists := paths[path]; exists {
+		return pathItem, true
+	}
+	if pathItem, exists := paths[path+"/"]; exists {
+		return pathItem, true
+	}
+	pathParamRegex, _ := regexp.Compile(pathParamNameRegex)
+	normalise := func(p string) string {
+		return pathParamRegex.ReplaceAllString(p, "{}")
+	}
+	normalisedPath := normalise(path)
+	for p, pathItem := range paths {
+		if normalisedP := normalise(p); normalisedP == normalisedPath || normalisedP == normalisedPath+"/" {
+			return pathItem, true
+		}
+	}
+	return spec.PathItem{}, false
+}
+
+// getParentResourceLookup returns the look up configuration of the given parent if the parent root POST operation contains
+// the x-terraform-resource-lookup-property extension and the parent exposes a list endpoint (GET operation returning an
+// array of objects containing the look up property); nil otherwise.
+func (o *SpecV2Resource) getParentResourceLookup(parentURI string, parent spec.PathItem) *parentResourceLookup {
+	if parent.Post == nil {
+		return nil
+	}
+	lookupProperty := o.getExtensionStringValue(parent.Post.Extensions, extTfResourceLookupProperty)
+	if lookupProperty == "" {
+		return nil
+	}
+	itemsSchema, err := getListItemsSchema(parent)
+	if err != nil {
+		log.Printf("[WARN] parent '%s' can not be looked up by '%s' as its list endpoint is not valid: %s", parentURI, lookupProperty, err)
+		return nil
+	}
+	if _, exists := itemsSchema.Properties[lookupProperty]; !exists {
+		log.Printf("[WARN] parent '%s' can not be looked up by '%s' as the property is not returned by its list endpoint", parentURI, lookupProperty)
+		return nil
+	}
+	listResource, err := newSpecV2DataSource(parentURI, *itemsSchema, parent, o.Paths)
+	if err != nil {
+		log.Printf("[WARN] parent '%s' can not be looked up by '%s': %s", parentURI, lookupProperty, err)
+		return nil
+	}
+	return &parentResourceLookup{lookupProperty: lookupProperty, listResource: listResource}
+}
+
 // GetResourceSchema returns the resource schema
 func (o *SpecV2Resource) GetResourceSchema() (*SpecSchemaDefinition, error) {
 	if o.specSchemaDefinitionCached != nil {
@@ -386,19 +650,57 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 	// This map ensures no duplicates will happen if the schema happens to have a parent id property. if so, it will be overridden with the expected parent property configuration (e,g: making the prop required)
 	schemaProps := map[string]*SpecSchemaDefinitionProperty{}
 	for propertyName, property := range schema.Properties {
+		rawJSON := false
+		if err := o.getPropertyTypeError(property); err != nil {
+			switch o.unsupportedTypes {
+			case unsupportedTypesIgnore:
+				log.Printf("[WARN] ignoring property '%s' of resource '%s' as its type is not supported: %s", propertyName, o.Name, err)
+				continue
+			case unsupportedTypesJSON:
+				log.Printf("[WARN] property '%s' of resource '%s' is exposed as a JSON string as its type is not supported: %s", propertyName, o.Name, err)
+				property = o.getRawJSONPropertySchema(property)
+				rawJSON = true
+			}
+		}
 		schemaDefinitionProperty, err := o.createSchemaDefinitionProperty(propertyName, property, schema.Required)
 		if err != nil {
 			return nil, err
 		}
+		schemaDefinitionProperty.RawJSON = rawJSON
 		schemaProps[propertyName] = schemaDefinitionProperty
 	}
+	if schema.Discriminator != "" {
+		schemaDefinition.Discriminator = schema.Discriminator
+		if err := o.addDiscriminatorProperty(schema.Discriminator, schemaProps); err != nil {
+			return nil, err
+		}
+	}
 	if addParentProps {
 		parentResourceInfo := o.GetParentResourceInfo()
 		if parentResourceInfo != nil {
 			parentPropertyNames := parentResourceInfo.GetParentPropertiesNames()
-			for _, parentPropertyName := range parentPropertyNames {
-				pr, _ := o.createSchemaDefinitionProperty(parentPropertyName, spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}, []string{parentPropertyName})
+			for idx, parentPropertyName := range parentPropertyNames {
+				stringSchema := spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}
+				if lookupPropertyName := parentResourceInfo.getParentLookupPropertyName(idx); lookupPropertyName != "" {
+					// parents that can be looked up can be referenced either by ID or by the look up property; the ID
+					// is then optional and computed from the look up property value if not configured
+					pr, _ := o.createSchemaDefinitionProperty(parentPropertyName, stringSchema, nil)
+					pr.IsParentProperty = true
+					pr.ParentIDFormat = parentResourceInfo.getParentIDFormat(idx)
+					pr.Computed = true
+					pr.ForceNew = true
+					schemaProps[parentPropertyName] = pr
+					lookupPr, _ := o.createSchemaDefinitionProperty(lookupPropertyName, stringSchema, nil)
+					lookupPr.IsParentProperty = true
+					schemaProps[lookupPropertyName] = lookupPr
+					continue
+				}
+				pr, _ := o.createSchemaDefinitionProperty(parentPropertyName, stringSchema, []string{parentPropertyName})
 				pr.IsParentProperty = true
+				pr.ParentIDFormat = parentResourceInfo.getParentIDFormat(idx)
+				// the sub-resource must be re-created when the parent changes (e,g: the parent is replaced) as it can not
+				// be moved from one parent to another
+				pr.ForceNew = true
 				schemaProps[parentPropertyName] = pr
 			}
 		}
@@ -410,6 +712,28 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 	return schemaDefinition, nil
 }
 
+// addDiscriminatorProperty makes sure the discriminator property of a polymorphic schema is exposed as a computed attribute,
+// so the subtype of the remote object is available in the state. The discriminator property is added as a computed
+// string property if the schema does not define it, and it is made computed if the schema defines it as optional.
+func (o *SpecV2Resource) addDiscriminatorProperty(discriminator string, schemaProps map[string]*SpecSchemaDefinitionProperty) error {
+	if discriminatorProperty, exists := schemaProps[discriminator]; exists {
+		if !discriminatorProperty.Required {
+			discriminatorProperty.Computed = true
+		}
+		return nil
+	}
+	discriminatorSchema := spec.Schema{
+		SchemaProps:        spec.SchemaProps{Type: spec.StringOrArray{"string"}},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true},
+	}
+	discriminatorProperty, err := o.createSchemaDefinitionProperty(discriminator, discriminatorSchema, nil)
+	if err != nil {
+		return err
+	}
+	schemaProps[discriminator] = discriminatorProperty
+	return nil
+}
+
 func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, property spec.Schema, requiredProperties []string) (*SpecSchemaDefinitionProperty, error) {
 	schemaDefinitionProperty := &SpecSchemaDefinitionProperty{}
 
@@ -420,8 +744,23 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 	}
 	schemaDefinitionProperty.Type = propertyType
 	schemaDefinitionProperty.Description = property.Description
+	schemaDefinitionProperty.Example = property.Example
 
-	if isObject, schemaDefinition, err := o.isObjectProperty(property); isObject || err != nil {
+	if isMap, mapValuesType, _ := o.isMapProperty(property); isMap {
+		schemaDefinitionProperty.MapValuesType = mapValuesType
+		if mapValuesType == TypeObject {
+			_, valuesSchema, err := o.isObjectProperty(*property.AdditionalProperties.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to process map type property '%s': %s", propertyName, err)
+			}
+			valuesSchemaDefinition, err := o.getSchemaDefinition(valuesSchema)
+			if err != nil {
+				return nil, err
+			}
+			schemaDefinitionProperty.SpecSchemaDefinition = valuesSchemaDefinition
+		}
+		log.Printf("[DEBUG] found map type property '%s' with values of type '%s'", propertyName, mapValuesType)
+	} else if isObject, schemaDefinition, err := o.isObjectProperty(property); isObject || err != nil {
 		if err != nil {
 			return nil, fmt.Errorf("failed to process object type property '%s': %s", propertyName, err)
 		}
@@ -451,10 +790,16 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.ArrayItemsType = itemsType
 		schemaDefinitionProperty.SpecSchemaDefinition = itemsSchema // only diff than nil if type is object
 
+		if itemsType != TypeObject && property.Items != nil && property.Items.Schema != nil && len(property.Items.Schema.Enum) > 0 {
+			schemaDefinitionProperty.ArrayItemsEnum = property.Items.Schema.Enum
+		}
+
 		if o.isBoolExtensionEnabled(property.Extensions, extTfIgnoreOrder) || o.isBoolExtensionEnabled(property.Extensions, extIgnoreOrder) {
 			schemaDefinitionProperty.IgnoreItemsOrder = true
 		}
 
+		schemaDefinitionProperty.ForceNewItemFields = o.getForceNewItemFields(propertyName, property.Extensions, itemsType, itemsSchema)
+
 		log.Printf("[DEBUG] found array type property '%s' with items of type '%s'", propertyName, itemsType)
 	}
 
@@ -462,6 +807,44 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.PreferredName = preferredPropertyName
 	}
 
+	if renamedFrom, exists := property.Extensions.GetString(extTfFieldRenamedFrom); exists {
+		schemaDefinitionProperty.RenamedFrom = renamedFrom
+	}
+
+	if responseHeader, exists := property.Extensions.GetString(extTfFieldResponseHeader); exists {
+		schemaDefinitionProperty.ResponseHeader = responseHeader
+	}
+
+	if o.isBoolExtensionEnabled(property.Extensions, extTfIgnoreDrift) {
+		schemaDefinitionProperty.IgnoreDrift = true
+	}
+
+	if o.isBoolExtensionEnabled(property.Extensions, extTfRequestOnly) {
+		schemaDefinitionProperty.RequestOnly = true
+	}
+
+	if o.isBoolExtensionEnabled(property.Extensions, extTfEncrypted) {
+		if propertyType != TypeString || !property.ReadOnly {
+			log.Printf("[WARN] ignoring '%s' extension in property '%s' as it is only supported in readOnly string properties", extTfEncrypted, propertyName)
+		} else {
+			schemaDefinitionProperty.Encrypted = true
+		}
+	}
+
+	if ignoredKeyPrefixes := o.getIgnoredKeyPrefixes(property.Extensions); len(ignoredKeyPrefixes) > 0 {
+		if propertyType != TypeMap {
+			log.Printf("[WARN] ignoring '%s' extension in property '%s' as it is only supported in map type properties", extTfIgnoreKeyPrefixes, propertyName)
+		} else {
+			schemaDefinitionProperty.IgnoredKeyPrefixes = ignoredKeyPrefixes
+		}
+	}
+
+	transforms, err := o.getPropertyTransforms(propertyName, property.Extensions, propertyType)
+	if err != nil {
+		return nil, err
+	}
+	schemaDefinitionProperty.Transforms = transforms
+
 	// Set the property as required (if not required the property will be considered optional)
 	required := o.isRequired(propertyName, requiredProperties)
 	if required {
@@ -515,7 +898,7 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.IsStatusIdentifier = true
 	}
 
-	if o.isBoolExtensionEnabled(property.Extensions, extTfComplexObjectType) {
+	if o.isBoolExtensionEnabled(property.Extensions, extTfComplexObjectType) || (o.complexObjectLegacyConfigEnabled && propertyType == TypeObject) {
 		schemaDefinitionProperty.EnableLegacyComplexObjectBlockConfiguration = true
 	}
 
@@ -524,6 +907,24 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 	// Link: https://swagger.io/docs/specification/describing-parameters#default
 	schemaDefinitionProperty.Default = property.Default
 
+	clientGenerated, err := o.getClientGeneratedValue(propertyName, property, propertyType)
+	if err != nil {
+		return nil, err
+	}
+	if clientGenerated != nil {
+		// the provider generates the value if the user does not configure it, hence the property is optional computed even
+		// if the API requires it
+		schemaDefinitionProperty.ClientGenerated = clientGenerated
+		schemaDefinitionProperty.Required = false
+		schemaDefinitionProperty.Computed = true
+	}
+
+	upload, err := o.getUpload(propertyName, property, propertyType)
+	if err != nil {
+		return nil, err
+	}
+	schemaDefinitionProperty.Upload = upload
+
 	return schemaDefinitionProperty, nil
 }
 
@@ -620,9 +1021,39 @@ func (o *SpecV2Resource) validateArrayItems(property spec.Schema) (schemaDefinit
 	return itemsType, nil
 }
 
+// getPropertyTypeError returns the error describing why the type of the given property (or the type of its array items)
+// is not supported; nil if the type is supported
+func (o *SpecV2Resource) getPropertyTypeError(property spec.Schema) error {
+	if _, err := o.getPropertyType(property); err != nil {
+		return err
+	}
+	if o.isArrayTypeProperty(property) {
+		_, err := o.validateArrayItems(property)
+		return err
+	}
+	return nil
+}
+
+// getRawJSONPropertySchema returns the string property schema used to expose the given property, which type is not
+// supported, as a JSON encoded string. The description, readOnly attribute and extensions of the property are kept.
+func (o *SpecV2Resource) getRawJSONPropertySchema(property spec.Schema) spec.Schema {
+	return spec.Schema{
+		VendorExtensible: property.VendorExtensible,
+		SchemaProps: spec.SchemaProps{
+			Type:        spec.StringOrArray{"string"},
+			Description: property.Description,
+		},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{
+			ReadOnly: property.ReadOnly,
+		},
+	}
+}
+
 func (o *SpecV2Resource) getPropertyType(property spec.Schema) (schemaDefinitionPropertyType, error) {
 	if o.isArrayTypeProperty(property) {
 		return TypeList, nil
+	} else if isMap, _, err := o.isMapProperty(property); isMap || err != nil {
+		return TypeMap, err
 	} else if isObject, _, err := o.isObjectProperty(property); isObject || err != nil {
 		return TypeObject, err
 	} else if property.Type.Contains("string") {
@@ -656,6 +1087,169 @@ func (o *SpecV2Resource) isObjectProperty(property spec.Schema) (bool, *spec.Sch
 	return false, nil, nil
 }
 
+// isMapProperty returns true if the given property is an object with free-form keys (e,g: tags) described with
+// additionalProperties and no properties, along with the type of the map values. The values can either be of primitive
+// types or objects (e,g: environments keyed by name); if additionalProperties does not specify a schema the values are
+// considered strings.
+func (o *SpecV2Resource) isMapProperty(property spec.Schema) (bool, schemaDefinitionPropertyType, error) {
+	if len(property.Properties) != 0 || property.Ref.Ref.GetURL() != nil || property.AdditionalProperties == nil {
+		return false, "", nil
+	}
+	if len(property.Type) > 0 && !o.isObjectTypeProperty(property) {
+		return false, "", nil
+	}
+	valuesSchema := property.AdditionalProperties.Schema
+	if valuesSchema == nil {
+		if !property.AdditionalProperties.Allows {
+			return false, "", nil
+		}
+		return true, TypeString, nil
+	}
+	if valuesSchema.Ref.Ref.GetURL() == nil && len(valuesSchema.Type) == 0 && len(valuesSchema.Properties) == 0 {
+		return true, TypeString, nil
+	}
+	valuesType, err := o.getPropertyType(*valuesSchema)
+	if err != nil {
+		return true, "", fmt.Errorf("failed to process map values: %s", err)
+	}
+	if !o.isArrayItemPrimitiveType(valuesType) && valuesType != TypeObject {
+		return true, "", fmt.Errorf("map values of type '%s' are not supported", valuesType)
+	}
+	return true, valuesType, nil
+}
+
+// getIgnoredKeyPrefixes returns the map key prefixes specified in the x-terraform-ignore-key-prefixes extension, either
+// as a comma separated string (e,g: "aws:,system/") or a list of strings
+func (o *SpecV2Resource) getIgnoredKeyPrefixes(extensions spec.Extensions) []string {
+	return o.getExtensionStringList(extensions, extTfIgnoreKeyPrefixes)
+}
+
+// getForceNewItemFields returns the item properties specified in the x-terraform-force-new-item-fields extension, either
+// as a comma separated string (e,g: "name,zone") or a list of strings. The extension is only supported in arrays of
+// objects and the fields must be properties of the items schema; otherwise they are ignored.
+func (o *SpecV2Resource) getForceNewItemFields(propertyName string, extensions spec.Extensions, itemsType schemaDefinitionPropertyType, itemsSchema *SpecSchemaDefinition) []string {
+	fields := o.getExtensionStringList(extensions, extTfForceNewItemFields)
+	if len(fields) == 0 {
+		return nil
+	}
+	if itemsType != TypeObject || itemsSchema == nil {
+		log.Printf("[WARN] ignoring '%s' extension in property '%s' as it is only supported in array properties which items are objects", extTfForceNewItemFields, propertyName)
+		return nil
+	}
+	var forceNewItemFields []string
+	for _, field := range fields {
+		if _, err := itemsSchema.getProperty(field); err != nil {
+			log.Printf("[WARN] ignoring field '%s' configured in the '%s' extension of property '%s' as the items do not contain such property", field, extTfForceNewItemFields, propertyName)
+			continue
+		}
+		forceNewItemFields = append(forceNewItemFields, field)
+	}
+	return forceNewItemFields
+}
+
+// getExtensionStringList returns the values of the given extension, which can be specified either as a comma separated
+// string or a list of strings. Empty values are skipped.
+func (o *SpecV2Resource) getExtensionStringList(extensions spec.Extensions, key string) []string {
+	if extensions == nil {
+		return nil
+	}
+	var values []string
+	if stringSlice, ok := extensions.GetStringSlice(key); ok {
+		values = stringSlice
+	} else if value, ok := extensions.GetString(key); ok {
+		values = strings.Split(value, ",")
+	}
+	var stringList []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			stringList = append(stringList, value)
+		}
+	}
+	return stringList
+}
+
+// getClientGeneratedValue returns how the value of the property is generated by the provider as specified in the
+// x-terraform-client-generated extension (uuid, timestamp or random_string). Random strings can be configured with the
+// x-terraform-client-generated-length and x-terraform-client-generated-charset extensions. Nil is returned if the
+// extension is not present; the extension is only supported in string properties that are not readOnly and do not have
+// a default value.
+func (o *SpecV2Resource) getClientGeneratedValue(propertyName string, property spec.Schema, propertyType schemaDefinitionPropertyType) (*clientGeneratedValue, error) {
+	kind := o.getExtensionStringValue(property.Extensions, extTfClientGenerated)
+	if kind == "" {
+		return nil, nil
+	}
+	if propertyType != TypeString || property.ReadOnly || property.Default != nil {
+		return nil, fmt.Errorf("failed to process property '%s': the '%s' extension is only supported in string properties that are not readOnly and do not have a default value", propertyName, extTfClientGenerated)
+	}
+	clientGenerated := &clientGeneratedValue{kind: kind}
+	switch kind {
+	case clientGeneratedUUID, clientGeneratedTimestamp:
+	case clientGeneratedRandomString:
+		clientGenerated.length = clientGeneratedDefaultLength
+		if value, exists := property.Extensions[extTfClientGeneratedLength]; exists {
+			length, ok := value.(float64)
+			if !ok || length <= 0 || length != float64(int(length)) {
+				return nil, fmt.Errorf("failed to process property '%s': invalid '%s' value '%v', the value must be a positive integer", propertyName, extTfClientGeneratedLength, value)
+			}
+			clientGenerated.length = int(length)
+		}
+		clientGenerated.charset = clientGeneratedDefaultCharset
+		if charset := o.getExtensionStringValue(property.Extensions, extTfClientGeneratedCharset); charset != "" {
+			clientGenerated.charset = charset
+		}
+	default:
+		return nil, fmt.Errorf("failed to process property '%s': invalid '%s' value '%s', supported values are: %s, %s and %s", propertyName, extTfClientGenerated, kind, clientGeneratedUUID, clientGeneratedTimestamp, clientGeneratedRandomString)
+	}
+	return clientGenerated, nil
+}
+
+// getUpload returns the upload endpoint configured in the x-terraform-upload-path extension, which the local file
+// configured in the property is uploaded to. The chunk size is configured with the x-terraform-upload-chunk-size extension
+// and the response property containing the file reference with the x-terraform-upload-reference-property extension ('id'
+// by default). Nil is returned if the extension is not present; the extension is only supported in string properties
+// that are not readOnly.
+func (o *SpecV2Resource) getUpload(propertyName string, property spec.Schema, propertyType schemaDefinitionPropertyType) (*specUpload, error) {
+	path := o.getExtensionStringValue(property.Extensions, extTfUploadPath)
+	if path == "" {
+		return nil, nil
+	}
+	if propertyType != TypeString || property.ReadOnly {
+		return nil, fmt.Errorf("failed to process property '%s': the '%s' extension is only supported in string properties that are not readOnly", propertyName, extTfUploadPath)
+	}
+	upload := &specUpload{
+		path:              path,
+		referenceProperty: o.getExtensionStringValue(property.Extensions, extTfUploadReferenceProperty),
+		chunkSize:         int64(o.getPositiveIntExtensionValue(property.Extensions, extTfUploadChunkSize)),
+	}
+	if upload.referenceProperty == "" {
+		upload.referenceProperty = uploadDefaultReferenceProperty
+	}
+	return upload, nil
+}
+
+// getPropertyTransforms returns the transformations specified in the x-terraform-transform extension, either as a list or
+// a comma separated string (e,g: "trim,lowercase"), in the order they are applied. The extension is only supported in
+// string properties; it is ignored otherwise.
+func (o *SpecV2Resource) getPropertyTransforms(propertyName string, extensions spec.Extensions, propertyType schemaDefinitionPropertyType) (propertyTransforms, error) {
+	values := o.getExtensionStringList(extensions, extTfTransform)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if propertyType != TypeString {
+		log.Printf("[WARN] ignoring '%s' extension in property '%s' as it is only supported in string properties", extTfTransform, propertyName)
+		return nil, nil
+	}
+	transforms := propertyTransforms{}
+	for _, value := range values {
+		transform, err := newPropertyTransform(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process property '%s': invalid '%s' value: %s", propertyName, extTfTransform, err)
+		}
+		transforms = append(transforms, transform)
+	}
+	return transforms, nil
+}
+
 func (o *SpecV2Resource) isArrayProperty(property spec.Schema) (bool, schemaDefinitionPropertyType, *SpecSchemaDefinition, error) {
 	if o.isArrayTypeProperty(property) {
 		itemsType, err := o.validateArrayItems(property)
@@ -711,6 +1305,10 @@ func (o *SpecV2Resource) getPreferredName(path spec.PathItem) string {
 	if preferredName == "" && path.Post != nil {
 		preferredName, _ = path.Post.Extensions.GetString(extTfResourceName)
 	}
+	// data sources are based on root paths exposing only the GET operation
+	if preferredName == "" && path.Get != nil {
+		preferredName, _ = path.Get.Extensions.GetString(extTfResourceName)
+	}
 	return preferredName
 }
 
@@ -728,19 +1326,286 @@ func (o *SpecV2Resource) createResourceOperation(operation *spec.Operation) *spe
 	headerParameters := getHeaderConfigurations(operation.Parameters)
 	securitySchemes := createSecuritySchemes(operation.Security)
 	return &specResourceOperation{
-		HeaderParameters: headerParameters,
-		SecuritySchemes:  securitySchemes,
-		responses:        o.createResponses(operation),
+		operationID:               operation.ID,
+		HeaderParameters:          headerParameters,
+		QueryParameters:           getQueryParameters(operation.Parameters),
+		SecuritySchemes:           securitySchemes,
+		responses:                 o.createResponses(operation),
+		successStatusCodes:        o.getSuccessStatusCodes(operation),
+		successConditions:         o.getPayloadConditions(operation.Extensions, extTfSuccessConditions),
+		existenceCheckEnabled:     o.isBoolExtensionEnabled(operation.Extensions, extTfResourceExistenceCheck),
+		conditionalRequestEnabled: o.isBoolExtensionEnabled(operation.Extensions, extTfConditionalRequest),
+		retryableErrors:           o.getRetryableErrors(operation),
+		retryBackoff:              o.getRetryBackoff(operation),
+		pagination:                o.getPagination(operation),
+		pageSizeParam:             o.getExtensionStringValue(operation.Extensions, extTfPaginationPageSizeParam),
+		maxPageSize:               o.getPositiveIntExtensionValue(operation.Extensions, extTfPaginationMaxPageSize),
+		mostRecentProperty:        o.getExtensionStringValue(operation.Extensions, extTfMostRecentProperty),
+		host:                      o.getOperationHost(operation),
+		deleteMaxConcurrency:      o.getPositiveIntExtensionValue(operation.Extensions, extTfDeleteMaxConcurrency),
+		bulkDelete:                o.getBulkDelete(operation),
+		preDelete:                 o.getPreDelete(operation),
+		batchRead:                 o.getBatchRead(operation),
+		errorParser:               o.getErrorParser(operation),
+		extensions:                getTerraformExtensions(operation.Extensions),
+	}
+}
+
+// getErrorParser returns the parser for the error format configured in the operation x-terraform-error-format extension
+// or, if not present, the error format configured at the root level of the document. The default parser is returned if
+// the error format is not supported
+func (o *SpecV2Resource) getErrorParser(operation *spec.Operation) apiErrorParser {
+	errorFormat := o.getExtensionStringValue(operation.Extensions, extTfErrorFormat)
+	if errorFormat == "" {
+		errorFormat = o.errorFormat
+	}
+	errorParser, supported := getAPIErrorParser(errorFormat)
+	if !supported {
+		log.Printf("[WARN] ignoring the extension '%s' with not supported value '%s', the supported values are: %s, %s, %s", extTfErrorFormat, errorFormat, apiErrorFormatDefault, apiErrorFormatProblemJSON, apiErrorFormatText)
+	}
+	return errorParser
+}
+
+// getPositiveIntExtensionValue returns the value of the given extension if it is a positive integer. Zero is returned if
+// the extension is not present or its value is not valid
+func (o *SpecV2Resource) getPositiveIntExtensionValue(extensions spec.Extensions, key string) int {
+	value, exists := extensions[key]
+	if !exists {
+		return 0
+	}
+	if number, isNumber := value.(float64); isNumber && number > 0 && number == float64(int(number)) {
+		return int(number)
+	}
+	log.Printf("[WARN] ignoring invalid '%s' extension value '%v': the value must be a positive integer", key, value)
+	return 0
+}
+
+// getBulkDelete returns the bulk delete endpoint configured in the operation x-terraform-bulk-delete-path extension; nil
+// if the extension is not present
+func (o *SpecV2Resource) getBulkDelete(operation *spec.Operation) *specBulkDelete {
+	path := o.getExtensionStringValue(operation.Extensions, extTfBulkDeletePath)
+	if path == "" {
+		return nil
+	}
+	bulkDelete := &specBulkDelete{
+		path:         path,
+		idsProperty:  o.getExtensionStringValue(operation.Extensions, extTfBulkDeleteIDsProperty),
+		maxBatchSize: o.getPositiveIntExtensionValue(operation.Extensions, extTfBulkDeleteMaxBatchSize),
+	}
+	if bulkDelete.idsProperty == "" {
+		bulkDelete.idsProperty = bulkDeleteDefaultIDsProperty
+	}
+	if bulkDelete.maxBatchSize == 0 {
+		bulkDelete.maxBatchSize = bulkDeleteDefaultMaxBatchSize
+	}
+	return bulkDelete
+}
+
+// getPreDelete returns the pre-delete request configured in the operation x-terraform-pre-delete-method extension; nil if
+// the extension is not present or the method is not supported. The payload configured in the x-terraform-pre-delete-payload
+// extension can be either an object or a string containing the JSON payload
+func (o *SpecV2Resource) getPreDelete(operation *spec.Operation) *specPreDelete {
+	method := strings.ToUpper(o.getExtensionStringValue(operation.Extensions, extTfPreDeleteMethod))
+	if method == "" {
+		return nil
+	}
+	if method != http.MethodPost && method != http.MethodPut && method != http.MethodPatch {
+		log.Printf("[WARN] ignoring the operation extension '%s' as the method '%s' is not supported, the supported methods are POST, PUT and PATCH", extTfPreDeleteMethod, method)
+		return nil
+	}
+	preDelete := &specPreDelete{
+		method: method,
+		path:   o.getExtensionStringValue(operation.Extensions, extTfPreDeletePath),
+	}
+	switch payload := operation.Extensions[extTfPreDeletePayload].(type) {
+	case nil:
+	case string:
+		if err := json.Unmarshal([]byte(payload), &preDelete.payload); err != nil {
+			log.Printf("[WARN] ignoring the operation extension '%s' as the payload is not valid JSON: %s", extTfPreDeletePayload, err)
+			return nil
+		}
+	default:
+		preDelete.payload = payload
+	}
+	return preDelete
+}
+
+// getBatchRead returns the batch read configured in the list operation x-terraform-batch-read-ids-param extension; nil if
+// the extension is not present
+func (o *SpecV2Resource) getBatchRead(operation *spec.Operation) *specBatchRead {
+	idsParam := o.getExtensionStringValue(operation.Extensions, extTfBatchReadIDsParam)
+	if idsParam == "" {
+		return nil
+	}
+	batchRead := &specBatchRead{
+		idsParam:     idsParam,
+		maxBatchSize: o.getPositiveIntExtensionValue(operation.Extensions, extTfBatchReadMaxBatchSize),
+	}
+	if batchRead.maxBatchSize == 0 {
+		batchRead.maxBatchSize = batchReadDefaultMaxBatchSize
+	}
+	return batchRead
+}
+
+// getSuccessStatusCodes returns the status codes configured in the operation x-terraform-success-status-codes extension.
+// The extension value must be a comma separated list of status codes (e,g: "200,204"). Values that are not valid
+// status codes are ignored.
+func (o *SpecV2Resource) getSuccessStatusCodes(operation *spec.Operation) []int {
+	var statusCodes []int
+	value, exists := operation.Extensions.GetString(extTfSuccessStatusCodes)
+	if !exists {
+		return statusCodes
+	}
+	for _, statusCodeValue := range strings.Split(value, ",") {
+		statusCode, err := strconv.Atoi(strings.TrimSpace(statusCodeValue))
+		if err != nil || statusCode < 100 || statusCode > 599 {
+			log.Printf("[WARN] ignoring invalid status code '%s' in the operation extension '%s'", statusCodeValue, extTfSuccessStatusCodes)
+			continue
+		}
+		statusCodes = append(statusCodes, statusCode)
+	}
+	return statusCodes
+}
+
+// getRetryableErrors returns the errors configured in the operation x-terraform-retryable-errors extension. The extension
+// value must be a comma separated list of status codes optionally followed by the error code returned in the response
+// body (e,g: "409:operation_in_progress,503"). Values that are not valid are ignored.
+func (o *SpecV2Resource) getRetryableErrors(operation *spec.Operation) []specRetryableError {
+	var retryableErrors []specRetryableError
+	value, exists := operation.Extensions.GetString(extTfRetryableErrors)
+	if !exists {
+		return retryableErrors
+	}
+	for _, retryableErrorValue := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(retryableErrorValue), ":", 2)
+		statusCode, err := strconv.Atoi(parts[0])
+		if err != nil || statusCode < 100 || statusCode > 599 {
+			log.Printf("[WARN] ignoring invalid retryable error '%s' in the operation extension '%s'", retryableErrorValue, extTfRetryableErrors)
+			continue
+		}
+		retryableError := specRetryableError{statusCode: statusCode}
+		if len(parts) == 2 {
+			retryableError.errorCode = strings.TrimSpace(parts[1])
+		}
+		retryableErrors = append(retryableErrors, retryableError)
+	}
+	return retryableErrors
+}
+
+// getRetryBackoff returns the retry backoff configured with the x-terraform-retry-max-retries, x-terraform-retry-initial-backoff
+// and x-terraform-retry-max-backoff extensions. The extensions can be set in the root path POST operation to apply to
+// all the operations of the resource, and in each of the operations to override the resource level values. Nil is
+// returned if none of the extensions are present. Invalid values are ignored.
+func (o *SpecV2Resource) getRetryBackoff(operation *spec.Operation) *specRetryBackoff {
+	var retryBackoff *specRetryBackoff
+	for _, op := range []*spec.Operation{o.RootPathItem.Post, operation} {
+		if op == nil {
+			continue
+		}
+		if backoff, exists := o.getOperationRetryBackoff(op); exists {
+			if retryBackoff == nil {
+				retryBackoff = &specRetryBackoff{}
+			}
+			*retryBackoff = retryBackoff.override(backoff)
+		}
+	}
+	return retryBackoff
+}
+
+func (o *SpecV2Resource) getOperationRetryBackoff(operation *spec.Operation) (specRetryBackoff, bool) {
+	backoff := specRetryBackoff{}
+	exists := false
+	if value, ok := operation.Extensions[extTfRetryMaxRetries]; ok {
+		exists = true
+		if maxRetries, isNumber := value.(float64); isNumber && maxRetries > 0 && maxRetries == float64(int(maxRetries)) {
+			backoff.maxRetries = int(maxRetries)
+		} else {
+			log.Printf("[WARN] ignoring invalid '%s' extension value '%v': the value must be a positive integer", extTfRetryMaxRetries, value)
+		}
+	}
+	if initialBackoff, ok := o.getRetryBackoffDuration(operation, extTfRetryInitialBackoff); ok {
+		exists = true
+		backoff.initialBackoff = initialBackoff
 	}
+	if maxBackoff, ok := o.getRetryBackoffDuration(operation, extTfRetryMaxBackoff); ok {
+		exists = true
+		backoff.maxBackoff = maxBackoff
+	}
+	return backoff, exists
+}
+
+// getRetryBackoffDuration returns the duration configured in the given operation extension and whether the extension
+// is present. Zero is returned if the duration is not valid
+func (o *SpecV2Resource) getRetryBackoffDuration(operation *spec.Operation, extension string) (time.Duration, bool) {
+	duration, err := o.getTimeDuration(operation.Extensions, extension)
+	if err != nil {
+		log.Printf("[WARN] ignoring invalid '%s' extension value: %s", extension, err)
+		return 0, true
+	}
+	if duration == nil {
+		return 0, false
+	}
+	return *duration, true
+}
+
+// getPagination returns the pagination configured in the operation x-terraform-pagination extension. The extension
+// value must be either 'link', to follow the Link response header with rel="next", or 'token', to send the token returned
+// in the x-terraform-pagination-next-token-header response header as the x-terraform-pagination-token-param query
+// parameter. Nil is returned if the operation is not paginated or the pagination is not valid.
+func (o *SpecV2Resource) getPagination(operation *spec.Operation) *specPagination {
+	style, exists := operation.Extensions.GetString(extTfPagination)
+	if !exists {
+		return nil
+	}
+	switch style {
+	case paginationStyleLink:
+		return &specPagination{style: style}
+	case paginationStyleToken:
+		nextTokenHeader := o.getExtensionStringValue(operation.Extensions, extTfPaginationNextTokenHeader)
+		tokenQueryParam := o.getExtensionStringValue(operation.Extensions, extTfPaginationTokenParam)
+		if nextTokenHeader == "" || tokenQueryParam == "" {
+			log.Printf("[WARN] ignoring the operation extension '%s' as the token pagination requires the extensions '%s' and '%s'", extTfPagination, extTfPaginationNextTokenHeader, extTfPaginationTokenParam)
+			return nil
+		}
+		return &specPagination{style: style, nextTokenHeader: nextTokenHeader, tokenQueryParam: tokenQueryParam}
+	}
+	log.Printf("[WARN] ignoring invalid pagination style '%s' in the operation extension '%s'", style, extTfPagination)
+	return nil
+}
+
+// getOperationHost returns the host configured in the operation x-terraform-operation-host extension, which overrides
+// the global and resource hosts for the API calls made for this operation (e,g: reads served by a read replica host).
+// The value supports environment variable placeholders and multi-region hosts. An empty host is returned if the
+// extension is not present or its value can not be resolved.
+func (o *SpecV2Resource) getOperationHost(operation *spec.Operation) string {
+	operationHost, err := openapiutils.InterpolateEnvVariables(o.getExtensionStringValue(operation.Extensions, extTfOperationHost))
+	if err != nil {
+		log.Printf("[WARN] ignoring the operation extension '%s': %s", extTfOperationHost, err)
+		return ""
+	}
+	if operationHost == "" {
+		return ""
+	}
+	multiRegionHost, err := openapiutils.GetMultiRegionHost(operationHost, o.Region)
+	if err != nil {
+		log.Printf("[WARN] ignoring the operation extension '%s': %s", extTfOperationHost, err)
+		return ""
+	}
+	if multiRegionHost != "" {
+		return multiRegionHost
+	}
+	return operationHost
 }
 
 func (o *SpecV2Resource) createResponses(operation *spec.Operation) specResponses {
 	responses := specResponses{}
 	for statusCode, response := range operation.Responses.StatusCodeResponses { //panics on ImportState if the swagger doesn't define status code responses
 		responses[statusCode] = &specResponse{
-			isPollingEnabled:    o.isResourcePollingEnabled(response),
-			pollTargetStatuses:  o.getResourcePollTargetStatuses(response),
-			pollPendingStatuses: o.getResourcePollPendingStatuses(response),
+			isPollingEnabled:        o.isResourcePollingEnabled(response),
+			pollTargetStatuses:      o.getResourcePollTargetStatuses(response),
+			pollPendingStatuses:     o.getResourcePollPendingStatuses(response),
+			pollStatusPath:          o.getResourcePollStatusPath(response),
+			pollCompletedConditions: o.getResourcePollCompletedConditions(response),
 		}
 	}
 	return responses
@@ -764,6 +1629,47 @@ func (o *SpecV2Resource) getResourcePollPendingStatuses(response spec.Response)
 	return o.getPollingStatuses(response, extTfResourcePollPendingStatuses)
 }
 
+// getResourcePollStatusPath returns the path to the payload property holding the status of the resource configured with
+// the x-terraform-resource-poll-status-path extension as dot separated property names (e,g: metadata.state.phase). Nil is
+// returned if the extension is not present
+func (o *SpecV2Resource) getResourcePollStatusPath(response spec.Response) []string {
+	statusPath, _ := response.Extensions.GetString(extTfResourcePollStatusPath)
+	statusPath = strings.TrimSpace(statusPath)
+	if statusPath == "" {
+		return nil
+	}
+	return strings.Split(statusPath, ".")
+}
+
+// getResourcePollCompletedConditions returns the conditions configured with the x-terraform-resource-poll-completed-conditions
+// extension as comma separated conditions in the form of 'property.path == value' (e,g: "metadata.state.phase == ready,
+// health.status == healthy"). The conditions that are not valid are ignored
+func (o *SpecV2Resource) getResourcePollCompletedConditions(response spec.Response) []specPollCondition {
+	return o.getPayloadConditions(response.Extensions, extTfResourcePollCompletedConditions)
+}
+
+// getPayloadConditions returns the conditions configured with the given extension as comma separated conditions in the
+// form of 'property.path == value'. The conditions that are not valid are ignored
+func (o *SpecV2Resource) getPayloadConditions(extensions spec.Extensions, extension string) []specPollCondition {
+	conditionsValue, exists := extensions.GetString(extension)
+	if !exists {
+		return nil
+	}
+	var conditions []specPollCondition
+	for _, condition := range strings.Split(conditionsValue, ",") {
+		parts := strings.Split(condition, "==")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			log.Printf("[WARN] ignoring the condition '%s' of the extension '%s': the condition must be in the form of 'property.path == value'", condition, extension)
+			continue
+		}
+		conditions = append(conditions, specPollCondition{
+			path:  strings.Split(strings.TrimSpace(parts[0]), "."),
+			value: strings.TrimSpace(parts[1]),
+		})
+	}
+	return conditions
+}
+
 func (o *SpecV2Resource) getPollingStatuses(response spec.Response, extension string) []string {
 	var statuses []string
 	if resourcePollTargets, exists := response.Extensions.GetString(extension); exists {
@@ -799,6 +1705,147 @@ func (o *SpecV2Resource) getTimeouts() (*specTimeouts, error) {
 	}, nil
 }
 
+// getSchemaVersion returns the schema version of the resource specified in the root path POST operation with the
+// x-terraform-resource-schema-version extension. If the extension is not present the version returned is 0.
+func (o *SpecV2Resource) getSchemaVersion() (int, error) {
+	if o.RootPathItem.Post == nil {
+		return 0, nil
+	}
+	value, exists := o.RootPathItem.Post.Extensions[extTfResourceSchemaVersion]
+	if !exists {
+		return 0, nil
+	}
+	var version int
+	switch v := value.(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("invalid schema version value '%v': the value must be a positive integer", value)
+		}
+		version = int(v)
+	case int:
+		version = v
+	case string:
+		var err error
+		if version, err = strconv.Atoi(v); err != nil {
+			return 0, fmt.Errorf("invalid schema version value '%v': the value must be a positive integer", value)
+		}
+	default:
+		return 0, fmt.Errorf("invalid schema version value '%v': the value must be a positive integer", value)
+	}
+	if version < 0 {
+		return 0, fmt.Errorf("invalid schema version value '%v': the value must be a positive integer", value)
+	}
+	return version, nil
+}
+
+// getDeprecationMessage returns the deprecation message specified in the root path POST operation with the
+// x-terraform-resource-deprecated extension (e,g: "use cdns_v2 instead"). If the extension is not present an empty
+// string is returned meaning the resource is not deprecated.
+func (o *SpecV2Resource) getDeprecationMessage() string {
+	if o.RootPathItem.Post == nil {
+		return ""
+	}
+	return o.getExtensionStringValue(o.RootPathItem.Post.Extensions, extTfResourceDeprecated)
+}
+
+// getResourceNameAliases returns the previous names of the resource (e,g: the name the resource had before the
+// x-terraform-resource-name extension was introduced or changed) specified with the x-terraform-resource-name-aliases
+// extension either at the root path level or in the root path POST operation. The extension value can be either a list
+// or a comma separated string. The region is appended to the aliases of the multi-region resources.
+func (o *SpecV2Resource) getResourceNameAliases() []string {
+	aliases := o.getExtensionStringList(o.RootPathItem.Extensions, extTfResourceNameAliases)
+	if len(aliases) == 0 && o.RootPathItem.Post != nil {
+		aliases = o.getExtensionStringList(o.RootPathItem.Post.Extensions, extTfResourceNameAliases)
+	}
+	if o.Region == "" {
+		return aliases
+	}
+	var regionalAliases []string
+	for _, alias := range aliases {
+		regionalAliases = append(regionalAliases, fmt.Sprintf("%s_%s", alias, o.Region))
+	}
+	return regionalAliases
+}
+
+// getResponseHeaderAttributes returns the response headers specified in the root path POST operation with the
+// x-terraform-resource-response-headers extension, indexed by the terraform name of the computed attribute they are
+// exposed in. The extension value can be either a map of header names to attribute names (e,g: X-Version: version) or
+// a list of header names, in which case the attribute names are the terraform compliant names of the headers.
+func (o *SpecV2Resource) getResponseHeaderAttributes() map[string]string {
+	if o.RootPathItem.Post == nil {
+		return nil
+	}
+	value, exists := o.RootPathItem.Post.Extensions[extTfResourceResponseHeaders]
+	if !exists {
+		return nil
+	}
+	responseHeaderAttributes := map[string]string{}
+	switch headers := value.(type) {
+	case map[string]interface{}:
+		for headerName, attributeName := range headers {
+			name, ok := attributeName.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				log.Printf("[WARN] ignoring response header '%s' configured in the '%s' extension of resource '%s' as the attribute name is not a string", headerName, extTfResourceResponseHeaders, o.Name)
+				continue
+			}
+			responseHeaderAttributes[terraformutils.ConvertToTerraformCompliantName(strings.TrimSpace(name))] = headerName
+		}
+	case []interface{}:
+		for _, headerName := range headers {
+			name, ok := headerName.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				log.Printf("[WARN] ignoring response header '%v' configured in the '%s' extension of resource '%s' as it is not a string", headerName, extTfResourceResponseHeaders, o.Name)
+				continue
+			}
+			responseHeaderAttributes[terraformutils.ConvertToTerraformCompliantName(strings.TrimSpace(name))] = strings.TrimSpace(name)
+		}
+	default:
+		log.Printf("[WARN] ignoring '%s' extension of resource '%s' as the value must be either a map of header names to attribute names or a list of header names", extTfResourceResponseHeaders, o.Name)
+	}
+	return responseHeaderAttributes
+}
+
+// GetResourceDescription returns the summary of the root path POST operation, or the description if the summary is not
+// provided. Data sources are based on root paths exposing only the GET operation, hence the GET operation is used when the
+// root path does not expose the POST operation.
+func (o *SpecV2Resource) GetResourceDescription() string {
+	operation := o.RootPathItem.Post
+	if operation == nil {
+		operation = o.RootPathItem.Get
+	}
+	if operation == nil {
+		return ""
+	}
+	if summary := strings.TrimSpace(operation.Summary); summary != "" {
+		return summary
+	}
+	return strings.TrimSpace(operation.Description)
+}
+
+// getRequestJSONSchema returns the JSON Schema of the resource definition the request payloads are validated against.
+// The definitions of the document are embedded so the validators resolve the references to them (#/definitions/...), and
+// the read only properties are not required since they are never sent to the API.
+func (o *SpecV2Resource) getRequestJSONSchema() ([]byte, error) {
+	jsonSchema := o.SchemaDefinition
+	jsonSchema.Required = nil
+	for _, required := range o.SchemaDefinition.Required {
+		if property, exists := o.SchemaDefinition.Properties[required]; exists && property.ReadOnly {
+			continue
+		}
+		jsonSchema.Required = append(jsonSchema.Required, required)
+	}
+	jsonSchema.Definitions = spec.Definitions{}
+	for name, definition := range o.SchemaDefinitions {
+		jsonSchema.Definitions[name] = definition
+	}
+	return json.Marshal(jsonSchema)
+}
+
+// GetOperationIDs returns the operationId of the resource operations indexed by the operation name
+func (o *SpecV2Resource) GetOperationIDs() map[string]string {
+	return getOperationIDs(o.getResourceOperations())
+}
+
 func (o *SpecV2Resource) getResourceTimeout(operation *spec.Operation) (*time.Duration, error) {
 	if operation == nil {
 		return nil, nil
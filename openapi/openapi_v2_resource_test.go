@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -914,6 +915,42 @@ func TestParentResourceInfo(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a SpecV2Resource configured with a path that is a sub-resource of a parent mounted under a static prefix with multiple segments", t, func() {
+		r := SpecV2Resource{
+			Path: "/api/admin/v2/organisations/{org_id}/admin-users",
+			Paths: map[string]spec.PathItem{
+				"/api/admin/v2/organisations": {
+					PathItemProps: spec.PathItemProps{
+						Post: &spec.Operation{},
+					},
+				},
+			},
+		}
+		Convey("When ParentResourceInfo is called", func() {
+			parentResourceInfo := r.GetParentResourceInfo()
+			Convey("Then the parent URIs returned should contain the whole static prefix", func() {
+				So(parentResourceInfo, ShouldNotBeNil)
+				So(parentResourceInfo.parentResourceNames, ShouldResemble, []string{"organisations_v2"})
+				So(parentResourceInfo.parentURIs, ShouldResemble, []string{"/api/admin/v2/organisations"})
+				So(parentResourceInfo.parentInstanceURIs, ShouldResemble, []string{"/api/admin/v2/organisations/{org_id}"})
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource configured with a path that is a sub-resource of a parent which name contains hyphens", t, func() {
+		r := SpecV2Resource{
+			Path:  "/cdn-groups/{id}/firewalls",
+			Paths: map[string]spec.PathItem{},
+		}
+		Convey("When ParentResourceInfo is called", func() {
+			parentResourceInfo := r.GetParentResourceInfo()
+			Convey("Then the parent should be identified", func() {
+				So(parentResourceInfo, ShouldNotBeNil)
+				So(parentResourceInfo.parentResourceNames, ShouldResemble, []string{"cdn_groups"})
+				So(parentResourceInfo.parentURIs, ShouldResemble, []string{"/cdn-groups"})
+				So(parentResourceInfo.parentInstanceURIs, ShouldResemble, []string{"/cdn-groups/{id}"})
+			})
+		})
+	})
 	Convey("Given a SpecV2Resource configured with a path that is indeed a sub-resource (both using versioning)", t, func() {
 		r := SpecV2Resource{
 			Path: "/v1/cdns/{id}/v2/firewalls",
@@ -1160,6 +1197,10 @@ func assertSchemaProperty(actualSpecSchemaDefinition *SpecSchemaDefinition, expe
 
 func assertSchemaParentProperty(actualSpecSchemaDefinition *SpecSchemaDefinition, expectedName string) {
 	assertSchemaProperty(actualSpecSchemaDefinition, expectedName, TypeString, true, false, false)
+	parentProperty, err := actualSpecSchemaDefinition.getProperty(expectedName)
+	So(err, ShouldBeNil)
+	So(parentProperty.IsParentProperty, ShouldBeTrue)
+	So(parentProperty.ForceNew, ShouldBeTrue)
 }
 
 func TestGetResourceSchema(t *testing.T) {
@@ -1570,6 +1611,139 @@ func TestGetSchemaDefinition(t *testing.T) {
 	})
 }
 
+func TestGetSchemaDefinitionWithUnsupportedTypes(t *testing.T) {
+	schema := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{
+				"label": {
+					SchemaProps: spec.SchemaProps{
+						Type: spec.StringOrArray{"string"},
+					},
+				},
+				"free_form": {
+					SchemaProps: spec.SchemaProps{
+						Description: "free form value",
+					},
+					SwaggerSchemaProps: spec.SwaggerSchemaProps{
+						ReadOnly: true,
+					},
+				},
+				"matrix": {
+					SchemaProps: spec.SchemaProps{
+						Type:  spec.StringOrArray{"array"},
+						Items: &spec.SchemaOrArray{Schema: spec.ArrayProperty(spec.StringProperty())},
+					},
+				},
+			},
+		},
+	}
+	Convey("Given a SpecV2Resource configured to fail with unsupported types (default)", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When getSchemaDefinition is called with a schema containing properties which type is not supported", func() {
+			_, err := r.getSchemaDefinition(&schema)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource configured to ignore the properties with unsupported types", t, func() {
+		r := &SpecV2Resource{unsupportedTypes: unsupportedTypesIgnore}
+		Convey("When getSchemaDefinition is called with a schema containing properties which type is not supported", func() {
+			schemaDefinition, err := r.getSchemaDefinition(&schema)
+			Convey("Then the properties which type is not supported should be dropped", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinition.Properties, ShouldHaveLength, 1)
+				So(schemaDefinition.Properties[0].Name, ShouldEqual, "label")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource configured to expose the properties with unsupported types as JSON strings", t, func() {
+		r := &SpecV2Resource{unsupportedTypes: unsupportedTypesJSON}
+		Convey("When getSchemaDefinition is called with a schema containing properties which type is not supported", func() {
+			schemaDefinition, err := r.getSchemaDefinition(&schema)
+			So(err, ShouldBeNil)
+			So(schemaDefinition.Properties, ShouldHaveLength, 3)
+			Convey("Then the properties which type is not supported should be exposed as JSON strings", func() {
+				freeFormProperty, err := schemaDefinition.getProperty("free_form")
+				So(err, ShouldBeNil)
+				So(freeFormProperty.Type, ShouldEqual, TypeString)
+				So(freeFormProperty.RawJSON, ShouldBeTrue)
+				So(freeFormProperty.ReadOnly, ShouldBeTrue)
+				So(freeFormProperty.Description, ShouldEqual, "free form value")
+				matrixProperty, err := schemaDefinition.getProperty("matrix")
+				So(err, ShouldBeNil)
+				So(matrixProperty.Type, ShouldEqual, TypeString)
+				So(matrixProperty.RawJSON, ShouldBeTrue)
+			})
+			Convey("And the supported properties should be kept as is", func() {
+				labelProperty, err := schemaDefinition.getProperty("label")
+				So(err, ShouldBeNil)
+				So(labelProperty.RawJSON, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetSchemaDefinitionWithDiscriminator(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When getSchemaDefinition is called with a polymorphic schema that does not define the discriminator property", func() {
+			schemaDefinition, err := r.getSchemaDefinition(&spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"name": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+					},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{Discriminator: "pet_type"},
+			})
+			Convey("Then the discriminator should be exposed as a computed property", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinition.Discriminator, ShouldEqual, "pet_type")
+				discriminatorProperty, err := schemaDefinition.getProperty("pet_type")
+				So(err, ShouldBeNil)
+				So(discriminatorProperty.Type, ShouldEqual, TypeString)
+				So(discriminatorProperty.ReadOnly, ShouldBeTrue)
+				So(discriminatorProperty.Computed, ShouldBeTrue)
+			})
+		})
+		Convey("When getSchemaDefinition is called with a polymorphic schema that defines the discriminator property as optional", func() {
+			schemaDefinition, err := r.getSchemaDefinition(&spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"pet_type": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+					},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{Discriminator: "pet_type"},
+			})
+			Convey("Then the discriminator property should be optional and computed", func() {
+				So(err, ShouldBeNil)
+				discriminatorProperty, err := schemaDefinition.getProperty("pet_type")
+				So(err, ShouldBeNil)
+				So(discriminatorProperty.Required, ShouldBeFalse)
+				So(discriminatorProperty.Computed, ShouldBeTrue)
+			})
+		})
+		Convey("When getSchemaDefinition is called with a polymorphic schema that defines the discriminator property as required", func() {
+			schemaDefinition, err := r.getSchemaDefinition(&spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Required: []string{"pet_type"},
+					Properties: map[string]spec.Schema{
+						"pet_type": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+					},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{Discriminator: "pet_type"},
+			})
+			Convey("Then the discriminator property should remain required", func() {
+				So(err, ShouldBeNil)
+				discriminatorProperty, err := schemaDefinition.getProperty("pet_type")
+				So(err, ShouldBeNil)
+				So(discriminatorProperty.Required, ShouldBeTrue)
+				So(discriminatorProperty.Computed, ShouldBeFalse)
+			})
+		})
+	})
+}
+
 func TestGetSchemaDefinitionWithOptions(t *testing.T) {
 	Convey("Given a blank SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
@@ -1942,6 +2116,25 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
+		Convey("When createSchemaDefinitionProperty is called with a propertyName and propertySchema that has an example", func() {
+			propertyName := "propertyName"
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:        spec.StringOrArray{"string"},
+					Description: "some description",
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					Example: "some example",
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty(propertyName, propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should contain the example", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Description, ShouldEqual, "some description")
+				So(schemaDefinitionProperty.Example, ShouldEqual, "some example")
+			})
+		})
+
 		Convey("When createSchemaDefinitionProperty is called with a propertyName and non required propertySchema of type array with items of type string", func() {
 			propertyName := "propertyName"
 			propertySchema := spec.Schema{
@@ -2215,6 +2408,165 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-field-renamed-from' and 'x-terraform-field-response-header' extensions", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfFieldRenamedFrom:    "previous_name",
+						extTfFieldResponseHeader: "Location",
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as expected", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.RenamedFrom, ShouldEqual, "previous_name")
+				So(schemaDefinitionProperty.ResponseHeader, ShouldEqual, "Location")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-ignore-drift' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfIgnoreDrift: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured to ignore drift", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.IgnoreDrift, ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-request-only' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfRequestOnly: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as request only", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.RequestOnly, ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a readOnly string property schema that has the 'x-terraform-encrypted' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfEncrypted: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as encrypted", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Encrypted, ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a non readOnly property schema that has the 'x-terraform-encrypted' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfEncrypted: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the extension should be ignored", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Encrypted, ShouldBeFalse)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a map property schema that has the 'x-terraform-ignore-key-prefixes' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:                 spec.StringOrArray{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: spec.StringProperty()},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfIgnoreKeyPrefixes: "aws:,system/",
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("tags", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as a map ignoring the keys with the given prefixes", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Type, ShouldEqual, TypeMap)
+				So(schemaDefinitionProperty.MapValuesType, ShouldEqual, TypeString)
+				So(schemaDefinitionProperty.IgnoredKeyPrefixes, ShouldResemble, []string{"aws:", "system/"})
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a map property schema which values are objects", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{
+							Type: spec.StringOrArray{"object"},
+							Properties: map[string]spec.Schema{
+								"replicas": *spec.Int64Property(),
+								"region":   *spec.StringProperty(),
+							},
+						},
+					}},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("environments", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as a map of objects", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Type, ShouldEqual, TypeMap)
+				So(schemaDefinitionProperty.MapValuesType, ShouldEqual, TypeObject)
+				So(schemaDefinitionProperty.isMapOfObjectsProperty(), ShouldBeTrue)
+				So(schemaDefinitionProperty.SpecSchemaDefinition.Properties, ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a non map property schema that has the 'x-terraform-ignore-key-prefixes' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfIgnoreKeyPrefixes: "aws:",
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the extension should be ignored", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.IgnoredKeyPrefixes, ShouldBeEmpty)
+			})
+		})
+
 		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-force-new' extension", func() {
 			expectedForceNewValue := true
 			propertySchema := spec.Schema{
@@ -2255,6 +2607,29 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
+		Convey("When createSchemaDefinitionProperty is called by a resource with the root level 'x-terraform-complex-object-legacy-config' extension enabled", func() {
+			r := SpecV2Resource{complexObjectLegacyConfigEnabled: true}
+			objectSchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Properties: map[string]spec.Schema{
+						"account": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+					},
+				},
+			}
+			objectProperty, err := r.createSchemaDefinitionProperty("objectProperty", objectSchema, []string{})
+			So(err, ShouldBeNil)
+			stringProperty, err := r.createSchemaDefinitionProperty("stringProperty", spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}, []string{})
+			So(err, ShouldBeNil)
+			Convey("Then the object properties should be configured as blocks even if they do not have the extension", func() {
+				So(objectProperty.EnableLegacyComplexObjectBlockConfiguration, ShouldBeTrue)
+				So(objectProperty.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects(), ShouldBeTrue)
+			})
+			Convey("And the non object properties should not be affected", func() {
+				So(stringProperty.EnableLegacyComplexObjectBlockConfiguration, ShouldBeFalse)
+			})
+		})
+
 		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-sensitive' extension", func() {
 			expectedSensitiveValue := true
 			propertySchema := spec.Schema{
@@ -3135,41 +3510,298 @@ func TestResourceIsObjectProperty(t *testing.T) {
 	})
 }
 
-func TestResourceIsArrayProperty(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		Convey("When isArrayProperty is called with an array type property that has items of type object (nested)", func() {
-			propertySchema := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
-					Items: &spec.SchemaOrArray{
-						Schema: &spec.Schema{
-							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"object"},
-								Properties: map[string]spec.Schema{
-									"prop1": {
-										SchemaProps: spec.SchemaProps{
-											Type: spec.StringOrArray{"string"},
-										},
-									},
-									"prop2": {
-										SchemaProps: spec.SchemaProps{
-											Type: spec.StringOrArray{"integer"},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			}
-			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldBeNil)
-				So(isArray, ShouldBeTrue)
-				So(arrayItemType, ShouldEqual, TypeObject)
-				So(objectItemSchema, ShouldNotBeNil)
-				exists, _ := assertPropertyExists(objectItemSchema.Properties, "prop1")
+func TestResourceIsMapProperty(t *testing.T) {
+	r := &SpecV2Resource{}
+	testCases := []struct {
+		name               string
+		property           spec.Schema
+		expectedIsMap      bool
+		expectedValuesType schemaDefinitionPropertyType
+		expectedErr        string
+	}{
+		{
+			name:               "object with additionalProperties of type string",
+			property:           spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: spec.StringProperty()}}},
+			expectedIsMap:      true,
+			expectedValuesType: TypeString,
+		},
+		{
+			name:               "object with additionalProperties of type integer",
+			property:           spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: spec.Int64Property()}}},
+			expectedIsMap:      true,
+			expectedValuesType: TypeInt,
+		},
+		{
+			name:               "object with additionalProperties set to true",
+			property:           spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, AdditionalProperties: &spec.SchemaOrBool{Allows: true}}},
+			expectedIsMap:      true,
+			expectedValuesType: TypeString,
+		},
+		{
+			name:          "object with additionalProperties set to false",
+			property:      spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, AdditionalProperties: &spec.SchemaOrBool{Allows: false}}},
+			expectedIsMap: false,
+		},
+		{
+			name:          "object with properties and additionalProperties",
+			property:      spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Properties: map[string]spec.Schema{"prop1": {}}, AdditionalProperties: &spec.SchemaOrBool{Allows: true}}},
+			expectedIsMap: false,
+		},
+		{
+			name:          "object without additionalProperties",
+			property:      spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}}},
+			expectedIsMap: false,
+		},
+		{
+			name:          "string property",
+			property:      spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+			expectedIsMap: false,
+		},
+		{
+			name:          "object with additionalProperties of type array",
+			property:      spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: spec.ArrayProperty(spec.StringProperty())}}},
+			expectedIsMap: true,
+			expectedErr:   "map values of type 'list' are not supported",
+		},
+		{
+			name:               "object with additionalProperties of type object",
+			property:           spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Properties: map[string]spec.Schema{"replicas": *spec.Int64Property()}}}}}},
+			expectedIsMap:      true,
+			expectedValuesType: TypeObject,
+		},
+		{
+			name:          "object with additionalProperties of type map",
+			property:      spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, AdditionalProperties: &spec.SchemaOrBool{Allows: true, Schema: spec.MapProperty(spec.StringProperty())}}},
+			expectedIsMap: true,
+			expectedErr:   "map values of type 'map' are not supported",
+		},
+	}
+	for _, tc := range testCases {
+		isMap, valuesType, err := r.isMapProperty(tc.property)
+		assert.Equal(t, tc.expectedIsMap, isMap, tc.name)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedValuesType, valuesType, tc.name)
+	}
+}
+
+func TestGetIgnoredKeyPrefixes(t *testing.T) {
+	r := &SpecV2Resource{}
+	testCases := []struct {
+		name             string
+		extensions       spec.Extensions
+		expectedPrefixes []string
+	}{
+		{
+			name:             "no extensions",
+			extensions:       nil,
+			expectedPrefixes: nil,
+		},
+		{
+			name:             "comma separated prefixes",
+			extensions:       spec.Extensions{extTfIgnoreKeyPrefixes: "aws:, system/,"},
+			expectedPrefixes: []string{"aws:", "system/"},
+		},
+		{
+			name:             "list of prefixes",
+			extensions:       spec.Extensions{extTfIgnoreKeyPrefixes: []interface{}{"aws:", "system/"}},
+			expectedPrefixes: []string{"aws:", "system/"},
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedPrefixes, r.getIgnoredKeyPrefixes(tc.extensions), tc.name)
+	}
+}
+
+func TestGetResponseHeaderAttributes(t *testing.T) {
+	testCases := []struct {
+		name                             string
+		rootPathItem                     spec.PathItem
+		expectedResponseHeaderAttributes map[string]string
+	}{
+		{
+			name:                             "root path without POST operation",
+			rootPathItem:                     spec.PathItem{},
+			expectedResponseHeaderAttributes: nil,
+		},
+		{
+			name:                             "POST operation without the extension",
+			rootPathItem:                     spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{}}},
+			expectedResponseHeaderAttributes: nil,
+		},
+		{
+			name: "extension containing a map of header names to attribute names",
+			rootPathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceResponseHeaders: map[string]interface{}{"X-Version": "version", "Location": "resourceLocation", "X-Other": 1}}},
+			}}},
+			expectedResponseHeaderAttributes: map[string]string{"version": "X-Version", "resource_location": "Location"},
+		},
+		{
+			name: "extension containing a list of header names",
+			rootPathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceResponseHeaders: []interface{}{"X-Version", "Location"}}},
+			}}},
+			expectedResponseHeaderAttributes: map[string]string{"x_version": "X-Version", "location": "Location"},
+		},
+		{
+			name: "extension with a non supported value",
+			rootPathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceResponseHeaders: "X-Version"}},
+			}}},
+			expectedResponseHeaderAttributes: map[string]string{},
+		},
+	}
+	for _, tc := range testCases {
+		r := &SpecV2Resource{RootPathItem: tc.rootPathItem}
+		assert.Equal(t, tc.expectedResponseHeaderAttributes, r.getResponseHeaderAttributes(), tc.name)
+	}
+}
+
+func TestGetForceNewItemFields(t *testing.T) {
+	r := &SpecV2Resource{}
+	itemsSchema := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("zone", "", false, false, nil),
+		},
+	}
+	testCases := []struct {
+		name               string
+		extensions         spec.Extensions
+		itemsType          schemaDefinitionPropertyType
+		itemsSchema        *SpecSchemaDefinition
+		expectedItemFields []string
+	}{
+		{
+			name:               "no extensions",
+			extensions:         nil,
+			itemsType:          TypeObject,
+			itemsSchema:        itemsSchema,
+			expectedItemFields: nil,
+		},
+		{
+			name:               "comma separated item fields",
+			extensions:         spec.Extensions{extTfForceNewItemFields: "name, zone"},
+			itemsType:          TypeObject,
+			itemsSchema:        itemsSchema,
+			expectedItemFields: []string{"name", "zone"},
+		},
+		{
+			name:               "list of item fields",
+			extensions:         spec.Extensions{extTfForceNewItemFields: []interface{}{"zone"}},
+			itemsType:          TypeObject,
+			itemsSchema:        itemsSchema,
+			expectedItemFields: []string{"zone"},
+		},
+		{
+			name:               "item fields not present in the items schema are ignored",
+			extensions:         spec.Extensions{extTfForceNewItemFields: "name,non_existing"},
+			itemsType:          TypeObject,
+			itemsSchema:        itemsSchema,
+			expectedItemFields: []string{"name"},
+		},
+		{
+			name:               "array of primitives is not supported",
+			extensions:         spec.Extensions{extTfForceNewItemFields: "name"},
+			itemsType:          TypeString,
+			itemsSchema:        nil,
+			expectedItemFields: nil,
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedItemFields, r.getForceNewItemFields("array_property", tc.extensions, tc.itemsType, tc.itemsSchema), tc.name)
+	}
+}
+
+func TestGetPropertyTransforms(t *testing.T) {
+	r := &SpecV2Resource{}
+	testCases := []struct {
+		name               string
+		extensions         spec.Extensions
+		propertyType       schemaDefinitionPropertyType
+		expectedTransforms propertyTransforms
+		expectedError      string
+	}{
+		{
+			name:               "no extensions",
+			extensions:         nil,
+			propertyType:       TypeString,
+			expectedTransforms: nil,
+		},
+		{
+			name:               "comma separated transforms",
+			extensions:         spec.Extensions{extTfTransform: "trim, lowercase"},
+			propertyType:       TypeString,
+			expectedTransforms: propertyTransforms{{kind: propertyTransformTrim}, {kind: propertyTransformLowercase}},
+		},
+		{
+			name:               "list of transforms",
+			extensions:         spec.Extensions{extTfTransform: []interface{}{"base64-encode", "prefix:b64:"}},
+			propertyType:       TypeString,
+			expectedTransforms: propertyTransforms{{kind: propertyTransformBase64Encode}, {kind: propertyTransformPrefix, prefix: "b64:"}},
+		},
+		{
+			name:               "non string property is not supported",
+			extensions:         spec.Extensions{extTfTransform: "lowercase"},
+			propertyType:       TypeInt,
+			expectedTransforms: nil,
+		},
+		{
+			name:          "unsupported transform",
+			extensions:    spec.Extensions{extTfTransform: "lowercase,reverse"},
+			propertyType:  TypeString,
+			expectedError: "failed to process property 'string_property': invalid 'x-terraform-transform' value: transform 'reverse' not supported, supported values are: lowercase, trim, base64-encode and prefix:<value>",
+		},
+	}
+	for _, tc := range testCases {
+		transforms, err := r.getPropertyTransforms("string_property", tc.extensions, tc.propertyType)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedTransforms, transforms, tc.name)
+	}
+}
+
+func TestResourceIsArrayProperty(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isArrayProperty is called with an array type property that has items of type object (nested)", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"object"},
+								Properties: map[string]spec.Schema{
+									"prop1": {
+										SchemaProps: spec.SchemaProps{
+											Type: spec.StringOrArray{"string"},
+										},
+									},
+									"prop2": {
+										SchemaProps: spec.SchemaProps{
+											Type: spec.StringOrArray{"integer"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(isArray, ShouldBeTrue)
+				So(arrayItemType, ShouldEqual, TypeObject)
+				So(objectItemSchema, ShouldNotBeNil)
+				exists, _ := assertPropertyExists(objectItemSchema.Properties, "prop1")
 				So(exists, ShouldBeTrue)
 				exists, _ = assertPropertyExists(objectItemSchema.Properties, "prop2")
 				So(exists, ShouldBeTrue)
@@ -3474,6 +4106,19 @@ func TestGetPreferredName(t *testing.T) {
 			},
 			expectedResourceName: "rootLevelPreferredName",
 		},
+		{
+			name: "path item with the extension 'x-terraform-resource-name' on the GET level (data source root paths)",
+			inputPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{extTfResourceName: "getLevelPreferredName"},
+						},
+					},
+				},
+			},
+			expectedResourceName: "getLevelPreferredName",
+		},
 		{
 			name:                 " an empty path item",
 			inputPathItem:        spec.PathItem{},
@@ -3511,153 +4156,465 @@ func TestGetExtensionStringValue(t *testing.T) {
 	})
 }
 
-func TestCreateResponses(t *testing.T) {
+func TestGetSuccessStatusCodes(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
-		Convey("When createResponses method is called with an operation that has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
-			expectedTarget := "deployed"
-			expectedStatus := "deploy_pending"
+		Convey(fmt.Sprintf("When getSuccessStatusCodes method is called with an operation that has the '%s' extension", extTfSuccessStatusCodes), func() {
 			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollEnabled, true)
-			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
-			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
+			extensions.Add(extTfSuccessStatusCodes, "200, 204,not_a_code,999")
 			operation := &spec.Operation{
-				OperationProps: spec.OperationProps{
-					Responses: &spec.Responses{
-						ResponsesProps: spec.ResponsesProps{
-							StatusCodeResponses: map[int]spec.Response{
-								http.StatusAccepted: {
-									VendorExtensible: spec.VendorExtensible{
-										Extensions: extensions,
-									},
-								},
-							},
-						},
-					},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: extensions,
 				},
 			}
-			specResponses := r.createResponses(operation)
-			Convey("Then the result returned should be the expected one", func() {
-				So(specResponses, ShouldNotBeEmpty)
-				So(specResponses, ShouldContainKey, http.StatusAccepted)
-				So(specResponses[http.StatusAccepted].isPollingEnabled, ShouldBeTrue)
-				So(specResponses[http.StatusAccepted].pollTargetStatuses, ShouldContain, expectedTarget)
-				So(specResponses[http.StatusAccepted].pollPendingStatuses, ShouldContain, expectedStatus)
+			statusCodes := r.getSuccessStatusCodes(operation)
+			Convey("Then the valid status codes configured in the extension should be returned", func() {
+				So(statusCodes, ShouldResemble, []int{http.StatusOK, http.StatusNoContent})
 			})
 		})
-
-		Convey("When createResponses method is called with an operation does not have any status responses", func() {
-			operation := &spec.Operation{
-				OperationProps: spec.OperationProps{
-					Responses: &spec.Responses{
-						ResponsesProps: spec.ResponsesProps{
-							StatusCodeResponses: map[int]spec.Response{},
-						},
-					},
-				},
-			}
-			specResponses := r.createResponses(operation)
-			Convey("Then the spec responses map returned should not be empty", func() {
-				So(specResponses, ShouldBeEmpty)
+		Convey(fmt.Sprintf("When getSuccessStatusCodes method is called with an operation that does not have the '%s' extension", extTfSuccessStatusCodes), func() {
+			statusCodes := r.getSuccessStatusCodes(&spec.Operation{})
+			Convey("Then the status codes returned should be empty", func() {
+				So(statusCodes, ShouldBeEmpty)
 			})
 		})
 	})
 }
 
-func TestIsResourcePollingEnabled(t *testing.T) {
+func TestCreateResourceOperationExistenceCheck(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
-		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollEnabled, true)
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
-			}
-			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
-			Convey("Then the bool returned should be true", func() {
-				So(isResourcePollingEnabled, ShouldBeTrue)
-			})
-		})
-		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to false", func() {
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation that has the '%s' extension set to true", extTfResourceExistenceCheck), func() {
 			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollEnabled, false)
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
-			}
-			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
-			Convey("Then the bool returned should be false", func() {
-				So(isResourcePollingEnabled, ShouldBeFalse)
+			extensions.Add(extTfResourceExistenceCheck, true)
+			operation := r.createResourceOperation(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}, OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation returned should have the existence check enabled", func() {
+				So(operation.existenceCheckEnabled, ShouldBeTrue)
 			})
 		})
-		Convey("When isResourcePollingEnabled method is called with list of responses where non of the codes match the given response http code", func() {
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusOK: {},
-					},
-				},
-			}
-			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusOK])
-			Convey("Then bool returned should be false", func() {
-				So(isResourcePollingEnabled, ShouldBeFalse)
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation that does not have the '%s' extension", extTfResourceExistenceCheck), func() {
+			operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation returned should not have the existence check enabled", func() {
+				So(operation.existenceCheckEnabled, ShouldBeFalse)
 			})
 		})
 	})
 }
 
-func TestGetResourcePollTargetStatuses(t *testing.T) {
+func TestCreateResourceOperationConditionalRequest(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
-		Convey("When getResourcePollTargetStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
-			expectedTarget := "deployed"
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation that has the '%s' extension set to true", extTfConditionalRequest), func() {
 			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
-			}
-			statuses := r.getResourcePollTargetStatuses(responses.StatusCodeResponses[http.StatusAccepted])
-			Convey("Then the status returned should contain", func() {
-				So(statuses, ShouldContain, expectedTarget)
+			extensions.Add(extTfConditionalRequest, true)
+			operation := r.createResourceOperation(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}, OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation returned should have the conditional requests enabled", func() {
+				So(operation.conditionalRequestEnabled, ShouldBeTrue)
+			})
+		})
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation that does not have the '%s' extension", extTfConditionalRequest), func() {
+			operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation returned should not have the conditional requests enabled", func() {
+				So(operation.conditionalRequestEnabled, ShouldBeFalse)
 			})
 		})
 	})
 }
 
-func TestGetResourcePollPendingStatuses(t *testing.T) {
+func TestCreateResourceOperationDeleteMaxConcurrency(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
-		Convey("When getResourcePollPendingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-pending-statuses'", func() {
-			expectedStatus := "deploy_pending"
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation that has the '%s' extension", extTfDeleteMaxConcurrency), func() {
 			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
-			responses := spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
+			extensions.Add(extTfDeleteMaxConcurrency, float64(5))
+			operation := r.createResourceOperation(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}, OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation returned should have the delete max concurrency configured", func() {
+				So(operation.deleteMaxConcurrency, ShouldEqual, 5)
+			})
+		})
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation that has the '%s' extension with an invalid value", extTfDeleteMaxConcurrency), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfDeleteMaxConcurrency, float64(-1))
+			operation := r.createResourceOperation(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}, OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation returned should not limit the deletes", func() {
+				So(operation.deleteMaxConcurrency, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestGetErrorParser(t *testing.T) {
+	testCases := []struct {
+		name                string
+		globalErrorFormat   string
+		operationExtensions spec.Extensions
+		expectedParser      apiErrorParser
+	}{
+		{name: "error format not configured", operationExtensions: spec.Extensions{}, expectedParser: defaultAPIErrorParser{}},
+		{name: "error format configured at the root level", globalErrorFormat: apiErrorFormatText, operationExtensions: spec.Extensions{}, expectedParser: textAPIErrorParser{}},
+		{name: "error format configured at the operation level", operationExtensions: spec.Extensions{extTfErrorFormat: apiErrorFormatProblemJSON}, expectedParser: problemJSONAPIErrorParser{}},
+		{name: "error format configured at the operation level overriding the root level", globalErrorFormat: apiErrorFormatText, operationExtensions: spec.Extensions{extTfErrorFormat: apiErrorFormatProblemJSON}, expectedParser: problemJSONAPIErrorParser{}},
+		{name: "not supported error format", operationExtensions: spec.Extensions{extTfErrorFormat: "xml"}, expectedParser: defaultAPIErrorParser{}},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{errorFormat: tc.globalErrorFormat}
+		errorParser := r.getErrorParser(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: tc.operationExtensions}})
+		assert.Equal(t, tc.expectedParser, errorParser, tc.name)
+	}
+}
+
+func TestGetBulkDelete(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getBulkDelete method is called with an operation that has the '%s' extension", extTfBulkDeletePath), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfBulkDeletePath, "/v1/cdns/{cdn_id}/firewalls/bulk-delete")
+			bulkDelete := r.getBulkDelete(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the bulk delete returned should use the default IDs property and max batch size", func() {
+				So(bulkDelete, ShouldResemble, &specBulkDelete{path: "/v1/cdns/{cdn_id}/firewalls/bulk-delete", idsProperty: bulkDeleteDefaultIDsProperty, maxBatchSize: bulkDeleteDefaultMaxBatchSize})
+			})
+		})
+		Convey(fmt.Sprintf("When getBulkDelete method is called with an operation that has the '%s', '%s' and '%s' extensions", extTfBulkDeletePath, extTfBulkDeleteIDsProperty, extTfBulkDeleteMaxBatchSize), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfBulkDeletePath, "/v1/cdns/bulk-delete")
+			extensions.Add(extTfBulkDeleteIDsProperty, "cdn_ids")
+			extensions.Add(extTfBulkDeleteMaxBatchSize, float64(20))
+			bulkDelete := r.getBulkDelete(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the bulk delete returned should be configured with the extension values", func() {
+				So(bulkDelete, ShouldResemble, &specBulkDelete{path: "/v1/cdns/bulk-delete", idsProperty: "cdn_ids", maxBatchSize: 20})
+			})
+		})
+		Convey(fmt.Sprintf("When getBulkDelete method is called with an operation that does not have the '%s' extension", extTfBulkDeletePath), func() {
+			bulkDelete := r.getBulkDelete(&spec.Operation{})
+			Convey("Then the bulk delete returned should be nil", func() {
+				So(bulkDelete, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetPreDelete(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getPreDelete method is called with an operation that has the '%s' and '%s' extensions", extTfPreDeleteMethod, extTfPreDeletePayload), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfPreDeleteMethod, "patch")
+			extensions.Add(extTfPreDeletePayload, map[string]interface{}{"status": "disabled"})
+			preDelete := r.getPreDelete(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the pre-delete returned should be sent to the resource instance path with the payload", func() {
+				So(preDelete, ShouldResemble, &specPreDelete{method: "PATCH", payload: map[string]interface{}{"status": "disabled"}})
+			})
+		})
+		Convey(fmt.Sprintf("When getPreDelete method is called with an operation that has the '%s', '%s' and '%s' extensions with a JSON string payload", extTfPreDeleteMethod, extTfPreDeletePath, extTfPreDeletePayload), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfPreDeleteMethod, "POST")
+			extensions.Add(extTfPreDeletePath, "/v1/cdns/{id}/disable")
+			extensions.Add(extTfPreDeletePayload, `{"reason": "terraform destroy"}`)
+			preDelete := r.getPreDelete(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the pre-delete returned should be configured with the extension values", func() {
+				So(preDelete, ShouldResemble, &specPreDelete{method: "POST", path: "/v1/cdns/{id}/disable", payload: map[string]interface{}{"reason": "terraform destroy"}})
+			})
+		})
+		Convey(fmt.Sprintf("When getPreDelete method is called with an operation that has the '%s' extension with a method that is not supported", extTfPreDeleteMethod), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfPreDeleteMethod, "GET")
+			preDelete := r.getPreDelete(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the pre-delete returned should be nil", func() {
+				So(preDelete, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When getPreDelete method is called with an operation that does not have the '%s' extension", extTfPreDeleteMethod), func() {
+			preDelete := r.getPreDelete(&spec.Operation{})
+			Convey("Then the pre-delete returned should be nil", func() {
+				So(preDelete, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetBatchRead(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getBatchRead method is called with an operation that has the '%s' extension", extTfBatchReadIDsParam), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfBatchReadIDsParam, "ids")
+			batchRead := r.getBatchRead(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the batch read returned should use the default max batch size", func() {
+				So(batchRead, ShouldResemble, &specBatchRead{idsParam: "ids", maxBatchSize: batchReadDefaultMaxBatchSize})
+			})
+		})
+		Convey(fmt.Sprintf("When getBatchRead method is called with an operation that has the '%s' and '%s' extensions", extTfBatchReadIDsParam, extTfBatchReadMaxBatchSize), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfBatchReadIDsParam, "cdn_ids")
+			extensions.Add(extTfBatchReadMaxBatchSize, float64(20))
+			batchRead := r.getBatchRead(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the batch read returned should be configured with the extension values", func() {
+				So(batchRead, ShouldResemble, &specBatchRead{idsParam: "cdn_ids", maxBatchSize: 20})
+			})
+		})
+		Convey(fmt.Sprintf("When getBatchRead method is called with an operation that does not have the '%s' extension", extTfBatchReadIDsParam), func() {
+			batchRead := r.getBatchRead(&spec.Operation{})
+			Convey("Then the batch read returned should be nil", func() {
+				So(batchRead, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetRetryableErrors(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getRetryableErrors method is called with an operation that has the '%s' extension", extTfRetryableErrors), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfRetryableErrors, "409:operation_in_progress, 503, invalid, 42:some_code")
+			retryableErrors := r.getRetryableErrors(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the retryable errors returned should only contain the valid values", func() {
+				So(retryableErrors, ShouldResemble, []specRetryableError{
+					{statusCode: http.StatusConflict, errorCode: "operation_in_progress"},
+					{statusCode: http.StatusServiceUnavailable},
+				})
+			})
+		})
+		Convey(fmt.Sprintf("When getRetryableErrors method is called with an operation that does not have the '%s' extension", extTfRetryableErrors), func() {
+			retryableErrors := r.getRetryableErrors(&spec.Operation{})
+			Convey("Then the retryable errors returned should be empty", func() {
+				So(retryableErrors, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetRetryBackoff(t *testing.T) {
+	Convey("Given a SpecV2Resource which root path POST operation configures the retry backoff extensions", t, func() {
+		postExtensions := spec.Extensions{}
+		postExtensions.Add(extTfRetryMaxRetries, float64(10))
+		postExtensions.Add(extTfRetryInitialBackoff, "0.1s")
+		postExtensions.Add(extTfRetryMaxBackoff, "2s")
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: postExtensions}}}},
+		}
+		Convey("When getRetryBackoff method is called with an operation that does not configure the retry backoff extensions", func() {
+			retryBackoff := r.getRetryBackoff(&spec.Operation{})
+			Convey("Then the retry backoff returned should be the one configured for the resource", func() {
+				So(retryBackoff, ShouldResemble, &specRetryBackoff{maxRetries: 10, initialBackoff: 100 * time.Millisecond, maxBackoff: 2 * time.Second})
+			})
+		})
+		Convey("When getRetryBackoff method is called with an operation that overrides some of the retry backoff extensions", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfRetryMaxBackoff, "1m")
+			extensions.Add(extTfRetryMaxRetries, "invalid")
+			retryBackoff := r.getRetryBackoff(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the valid values configured for the operation should take preference", func() {
+				So(retryBackoff, ShouldResemble, &specRetryBackoff{maxRetries: 10, initialBackoff: 100 * time.Millisecond, maxBackoff: time.Minute})
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource which operations do not configure the retry backoff extensions", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{}}},
+		}
+		Convey("When getRetryBackoff method is called", func() {
+			retryBackoff := r.getRetryBackoff(&spec.Operation{})
+			Convey("Then the retry backoff returned should be nil", func() {
+				So(retryBackoff, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetPagination(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getPagination method is called with an operation that has the '%s' extension set to link", extTfPagination), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfPagination, paginationStyleLink)
+			pagination := r.getPagination(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the pagination returned should follow the Link header", func() {
+				So(pagination, ShouldResemble, &specPagination{style: paginationStyleLink})
+			})
+		})
+		Convey(fmt.Sprintf("When getPagination method is called with an operation that has the '%s' extension set to token along with the token extensions", extTfPagination), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfPagination, paginationStyleToken)
+			extensions.Add(extTfPaginationNextTokenHeader, "X-Next-Page-Token")
+			extensions.Add(extTfPaginationTokenParam, "page_token")
+			pagination := r.getPagination(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the pagination returned should use the next page token", func() {
+				So(pagination, ShouldResemble, &specPagination{style: paginationStyleToken, nextTokenHeader: "X-Next-Page-Token", tokenQueryParam: "page_token"})
+			})
+		})
+		Convey(fmt.Sprintf("When getPagination method is called with an operation that has the '%s' extension set to token but missing the token extensions", extTfPagination), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfPagination, paginationStyleToken)
+			pagination := r.getPagination(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the pagination returned should be nil", func() {
+				So(pagination, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When getPagination method is called with an operation that has the '%s' extension set to a not supported style", extTfPagination), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfPagination, "offset")
+			pagination := r.getPagination(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the pagination returned should be nil", func() {
+				So(pagination, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When getPagination method is called with an operation that does not have the '%s' extension", extTfPagination), func() {
+			pagination := r.getPagination(&spec.Operation{})
+			Convey("Then the pagination returned should be nil", func() {
+				So(pagination, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestCreateResponses(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When createResponses method is called with an operation that has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
+			expectedTarget := "deployed"
+			expectedStatus := "deploy_pending"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollEnabled, true)
+			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
+			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{
+						ResponsesProps: spec.ResponsesProps{
+							StatusCodeResponses: map[int]spec.Response{
+								http.StatusAccepted: {
+									VendorExtensible: spec.VendorExtensible{
+										Extensions: extensions,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			specResponses := r.createResponses(operation)
+			Convey("Then the result returned should be the expected one", func() {
+				So(specResponses, ShouldNotBeEmpty)
+				So(specResponses, ShouldContainKey, http.StatusAccepted)
+				So(specResponses[http.StatusAccepted].isPollingEnabled, ShouldBeTrue)
+				So(specResponses[http.StatusAccepted].pollTargetStatuses, ShouldContain, expectedTarget)
+				So(specResponses[http.StatusAccepted].pollPendingStatuses, ShouldContain, expectedStatus)
+			})
+		})
+
+		Convey("When createResponses method is called with an operation does not have any status responses", func() {
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{
+						ResponsesProps: spec.ResponsesProps{
+							StatusCodeResponses: map[int]spec.Response{},
+						},
+					},
+				},
+			}
+			specResponses := r.createResponses(operation)
+			Convey("Then the spec responses map returned should not be empty", func() {
+				So(specResponses, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestIsResourcePollingEnabled(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollEnabled, true)
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
+			Convey("Then the bool returned should be true", func() {
+				So(isResourcePollingEnabled, ShouldBeTrue)
+			})
+		})
+		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to false", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollEnabled, false)
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
+			Convey("Then the bool returned should be false", func() {
+				So(isResourcePollingEnabled, ShouldBeFalse)
+			})
+		})
+		Convey("When isResourcePollingEnabled method is called with list of responses where non of the codes match the given response http code", func() {
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusOK: {},
+					},
+				},
+			}
+			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusOK])
+			Convey("Then bool returned should be false", func() {
+				So(isResourcePollingEnabled, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetResourcePollTargetStatuses(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getResourcePollTargetStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
+			expectedTarget := "deployed"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			statuses := r.getResourcePollTargetStatuses(responses.StatusCodeResponses[http.StatusAccepted])
+			Convey("Then the status returned should contain", func() {
+				So(statuses, ShouldContain, expectedTarget)
+			})
+		})
+	})
+}
+
+func TestGetResourcePollPendingStatuses(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getResourcePollPendingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-pending-statuses'", func() {
+			expectedStatus := "deploy_pending"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
+			responses := spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
 							VendorExtensible: spec.VendorExtensible{
 								Extensions: extensions,
 							},
@@ -3764,13 +4721,67 @@ func TestGetPollingStatuses(t *testing.T) {
 	})
 }
 
-func TestGetTimeouts(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		expectedTimeout := "30s"
-		extensions := spec.Extensions{}
-		extensions.Add(extTfResourceTimeout, expectedTimeout)
-		op := &spec.Operation{
-			VendorExtensible: spec.VendorExtensible{
+func TestGetResourcePollStatusPath(t *testing.T) {
+	r := SpecV2Resource{}
+	testCases := []struct {
+		name         string
+		extensions   spec.Extensions
+		expectedPath []string
+	}{
+		{name: "response without the extension", extensions: spec.Extensions{}, expectedPath: nil},
+		{name: "response with the extension set to a top level property", extensions: spec.Extensions{extTfResourcePollStatusPath: "state"}, expectedPath: []string{"state"}},
+		{name: "response with the extension set to a nested property", extensions: spec.Extensions{extTfResourcePollStatusPath: " metadata.state.phase "}, expectedPath: []string{"metadata", "state", "phase"}},
+	}
+	for _, tc := range testCases {
+		statusPath := r.getResourcePollStatusPath(spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions}})
+		assert.Equal(t, tc.expectedPath, statusPath, tc.name)
+	}
+}
+
+func TestGetResourcePollCompletedConditions(t *testing.T) {
+	r := SpecV2Resource{}
+	testCases := []struct {
+		name               string
+		extensions         spec.Extensions
+		expectedConditions []specPollCondition
+	}{
+		{name: "response without the extension", extensions: spec.Extensions{}, expectedConditions: nil},
+		{
+			name:       "response with the extension containing multiple conditions",
+			extensions: spec.Extensions{extTfResourcePollCompletedConditions: "metadata.state.phase == ready, health.status==healthy"},
+			expectedConditions: []specPollCondition{
+				{path: []string{"metadata", "state", "phase"}, value: "ready"},
+				{path: []string{"health", "status"}, value: "healthy"},
+			},
+		},
+		{
+			name:               "response with the extension containing invalid conditions",
+			extensions:         spec.Extensions{extTfResourcePollCompletedConditions: "status = ready, health.status == healthy, == done"},
+			expectedConditions: []specPollCondition{{path: []string{"health", "status"}, value: "healthy"}},
+		},
+	}
+	for _, tc := range testCases {
+		conditions := r.getResourcePollCompletedConditions(spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions}})
+		assert.Equal(t, tc.expectedConditions, conditions, tc.name)
+	}
+}
+
+func TestCreateResourceOperationSuccessConditions(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfSuccessConditions: "status == ok, result.error == "}},
+		OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+	})
+	assert.Equal(t, []specPollCondition{{path: []string{"status"}, value: "ok"}}, operation.successConditions)
+}
+
+func TestGetTimeouts(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		expectedTimeout := "30s"
+		extensions := spec.Extensions{}
+		extensions.Add(extTfResourceTimeout, expectedTimeout)
+		op := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
 				Extensions: extensions,
 			},
 		}
@@ -3801,6 +4812,66 @@ func TestGetTimeouts(t *testing.T) {
 	})
 }
 
+func TestGetSchemaVersion(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		newResourceWithSchemaVersion := func(schemaVersion interface{}) SpecV2Resource {
+			extensions := spec.Extensions{}
+			if schemaVersion != nil {
+				extensions.Add(extTfResourceSchemaVersion, schemaVersion)
+			}
+			return SpecV2Resource{
+				RootPathItem: spec.PathItem{
+					PathItemProps: spec.PathItemProps{
+						Post: &spec.Operation{
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+		}
+		Convey(fmt.Sprintf("When getSchemaVersion method is called on a resource which root POST operation does not have the extension '%s'", extTfResourceSchemaVersion), func() {
+			r := newResourceWithSchemaVersion(nil)
+			schemaVersion, err := r.getSchemaVersion()
+			Convey("Then the schema version returned should be 0", func() {
+				So(err, ShouldBeNil)
+				So(schemaVersion, ShouldEqual, 0)
+			})
+		})
+		Convey(fmt.Sprintf("When getSchemaVersion method is called on a resource which root POST operation has the extension '%s' with a number value", extTfResourceSchemaVersion), func() {
+			r := newResourceWithSchemaVersion(float64(2))
+			schemaVersion, err := r.getSchemaVersion()
+			Convey("Then the schema version returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(schemaVersion, ShouldEqual, 2)
+			})
+		})
+		Convey(fmt.Sprintf("When getSchemaVersion method is called on a resource which root POST operation has the extension '%s' with a string value", extTfResourceSchemaVersion), func() {
+			r := newResourceWithSchemaVersion("3")
+			schemaVersion, err := r.getSchemaVersion()
+			Convey("Then the schema version returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(schemaVersion, ShouldEqual, 3)
+			})
+		})
+		Convey(fmt.Sprintf("When getSchemaVersion method is called on a resource which root POST operation has the extension '%s' with a negative value", extTfResourceSchemaVersion), func() {
+			r := newResourceWithSchemaVersion(float64(-1))
+			_, err := r.getSchemaVersion()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "invalid schema version value '-1': the value must be a positive integer")
+			})
+		})
+		Convey(fmt.Sprintf("When getSchemaVersion method is called on a resource which root POST operation has the extension '%s' with a decimal value", extTfResourceSchemaVersion), func() {
+			r := newResourceWithSchemaVersion(1.5)
+			_, err := r.getSchemaVersion()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "invalid schema version value '1.5': the value must be a positive integer")
+			})
+		})
+	})
+}
+
 func TestGetResourceTimeout(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
@@ -4018,6 +5089,92 @@ func TestSpecV2ResourceGetHost(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a SpecV2Resource that is multi region and which host contains an environment variable placeholder", t, func() {
+		os.Setenv("TEST_RESOURCE_HOST_ENV", "staging")
+		defer os.Unsetenv("TEST_RESOURCE_HOST_ENV")
+		r := SpecV2Resource{
+			Region: "rst1",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: "www.${env:TEST_RESOURCE_HOST_ENV}.${region}.some-host.com",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the host returned should contain the environment variable value and the region", func() {
+				So(err, ShouldBeNil)
+				So(host, ShouldEqual, "www.staging.rst1.some-host.com")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource which host contains an environment variable placeholder that is not set", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: "www.${env:TEST_RESOURCE_HOST_ENV_NOT_SET}.some-host.com",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "environment variable(s) 'TEST_RESOURCE_HOST_ENV_NOT_SET' required by 'www.${env:TEST_RESOURCE_HOST_ENV_NOT_SET}.some-host.com' not set")
+				So(host, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestSpecV2ResourceGetBasePath(t *testing.T) {
+	Convey("Given a SpecV2Resource which root POST operation contains the x-terraform-resource-base-path extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceBasePath: "/api/v2",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getBasePath method is called", func() {
+			basePath := r.getBasePath()
+			Convey("Then the value returned should be the extension value", func() {
+				So(basePath, ShouldEqual, "/api/v2")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource which root POST operation does not contain the x-terraform-resource-base-path extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When getBasePath method is called", func() {
+			basePath := r.getBasePath()
+			Convey("Then the value returned should be empty", func() {
+				So(basePath, ShouldBeEmpty)
+			})
+		})
+	})
 }
 
 func TestGetResourceOverrideHost(t *testing.T) {
@@ -4106,3 +5263,640 @@ func TestGetResourceOverrideHost(t *testing.T) {
 		})
 	})
 }
+
+func TestGetResourceVersion(t *testing.T) {
+	Convey("Given a list of resource paths", t, func() {
+		Convey("When getResourceVersion is called with a versioned resource path", func() {
+			Convey("Then the version returned should be the expected one", func() {
+				So(getResourceVersion("/v1/cdns"), ShouldEqual, "v1")
+				So(getResourceVersion("/v1/cdns/{id}/v2/firewalls"), ShouldEqual, "v2")
+			})
+		})
+		Convey("When getResourceVersion is called with a resource path that is not versioned", func() {
+			Convey("Then the version returned should be empty", func() {
+				So(getResourceVersion("/cdns"), ShouldBeEmpty)
+				So(getResourceVersion("/v1/cdns/{id}/firewalls"), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetDeprecationMessage(t *testing.T) {
+	Convey("Given a SpecV2Resource which root POST operation contains the extension x-terraform-resource-deprecated", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{extTfResourceDeprecated: "use cdns_v2 instead"},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getDeprecationMessage method is called", func() {
+			Convey("Then the message returned should be the extension value", func() {
+				So(r.getDeprecationMessage(), ShouldEqual, "use cdns_v2 instead")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource which root path does not have a POST operation", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getDeprecationMessage method is called", func() {
+			Convey("Then the message returned should be empty", func() {
+				So(r.getDeprecationMessage(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetResourceNameAliases(t *testing.T) {
+	testCases := []struct {
+		name            string
+		rootPathItem    spec.PathItem
+		region          string
+		expectedAliases []string
+	}{
+		{
+			name:            "no aliases",
+			rootPathItem:    spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{}}},
+			expectedAliases: nil,
+		},
+		{
+			name: "aliases in the root path level as a list",
+			rootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceNameAliases: []interface{}{"cdns_v1", "content_delivery_v1"}}},
+			},
+			expectedAliases: []string{"cdns_v1", "content_delivery_v1"},
+		},
+		{
+			name: "aliases in the root path POST operation as a comma separated string",
+			rootPathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceNameAliases: "cdns_v1, content_delivery_v1"}},
+			}}},
+			expectedAliases: []string{"cdns_v1", "content_delivery_v1"},
+		},
+		{
+			name: "aliases of a multi-region resource",
+			rootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceNameAliases: "cdns_v1"}},
+			},
+			region:          "rst1",
+			expectedAliases: []string{"cdns_v1_rst1"},
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{RootPathItem: tc.rootPathItem, Region: tc.region}
+		assert.Equal(t, tc.expectedAliases, r.getResourceNameAliases(), tc.name)
+	}
+}
+
+func TestGetResourceDescription(t *testing.T) {
+	Convey("Given a SpecV2Resource which root POST operation contains a summary and a description", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Summary:     "Create a CDN",
+							Description: "Creates a content delivery network serving the given hostnames",
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetResourceDescription method is called", func() {
+			Convey("Then the description returned should be the operation summary", func() {
+				So(r.GetResourceDescription(), ShouldEqual, "Create a CDN")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource which root POST operation contains only a description", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Description: " Creates a content delivery network serving the given hostnames\n",
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetResourceDescription method is called", func() {
+			Convey("Then the description returned should be the trimmed operation description", func() {
+				So(r.GetResourceDescription(), ShouldEqual, "Creates a content delivery network serving the given hostnames")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource (data source) which root path only exposes a GET operation with a summary", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Summary: "List CDNs",
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetResourceDescription method is called", func() {
+			Convey("Then the description returned should be the GET operation summary", func() {
+				So(r.GetResourceDescription(), ShouldEqual, "List CDNs")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource which root path does not have any operation", t, func() {
+		r := SpecV2Resource{}
+		Convey("When GetResourceDescription method is called", func() {
+			Convey("Then the description returned should be empty", func() {
+				So(r.GetResourceDescription(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestParentResourceLookup(t *testing.T) {
+	Convey("Given a SpecV2Resource which parent contains the x-terraform-resource-lookup-property extension and exposes a list endpoint", t, func() {
+		cdnSchema := spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: spec.StringOrArray{"object"},
+				Properties: map[string]spec.Schema{
+					"id":    {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+					"label": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+				},
+			},
+		}
+		newParentPathItem := func(lookupProperty string) spec.PathItem {
+			return spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{extTfResourceLookupProperty: lookupProperty},
+						},
+					},
+					Get: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Responses: &spec.Responses{
+								ResponsesProps: spec.ResponsesProps{
+									StatusCodeResponses: map[int]spec.Response{
+										http.StatusOK: {
+											ResponseProps: spec.ResponseProps{
+												Schema: &spec.Schema{
+													SchemaProps: spec.SchemaProps{
+														Type:  spec.StringOrArray{"array"},
+														Items: &spec.SchemaOrArray{Schema: &cdnSchema},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+		r := SpecV2Resource{
+			Path: "/v1/cdns/{cdn_id}/v1/firewalls",
+			SchemaDefinition: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"name": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+					},
+				},
+			},
+			Paths: map[string]spec.PathItem{"/v1/cdns": newParentPathItem("label")},
+		}
+		Convey("When GetParentResourceInfo is called", func() {
+			parentResourceInfo := r.GetParentResourceInfo()
+			Convey("Then the parent look up should be configured", func() {
+				So(parentResourceInfo.getParentLookup(0), ShouldNotBeNil)
+				So(parentResourceInfo.getParentLookup(0).lookupProperty, ShouldEqual, "label")
+				So(parentResourceInfo.getParentLookup(0).listResource.GetResourceName(), ShouldEqual, "cdns_v1")
+				So(parentResourceInfo.getParentLookupPropertyName(0), ShouldEqual, "cdns_v1_label")
+			})
+		})
+		Convey("When GetResourceSchema is called", func() {
+			resourceSchema, err := r.GetResourceSchema()
+			So(err, ShouldBeNil)
+			Convey("Then the parent ID property should be optional and computed", func() {
+				parentIDProperty, err := resourceSchema.getProperty("cdns_v1_id")
+				So(err, ShouldBeNil)
+				So(parentIDProperty.IsParentProperty, ShouldBeTrue)
+				So(parentIDProperty.Required, ShouldBeFalse)
+				So(parentIDProperty.isComputed(), ShouldBeTrue)
+				So(parentIDProperty.ForceNew, ShouldBeTrue)
+			})
+			Convey("And the parent look up property should be optional", func() {
+				lookupProperty, err := resourceSchema.getProperty("cdns_v1_label")
+				So(err, ShouldBeNil)
+				So(lookupProperty.IsParentProperty, ShouldBeTrue)
+				So(lookupProperty.Required, ShouldBeFalse)
+				So(lookupProperty.isComputed(), ShouldBeFalse)
+			})
+		})
+		Convey("When GetParentResourceInfo is called and the parent look up property is not returned by the parent list endpoint", func() {
+			r.Paths["/v1/cdns"] = newParentPathItem("unknown")
+			parentResourceInfo := r.GetParentResourceInfo()
+			Convey("Then the parent look up should not be configured", func() {
+				So(parentResourceInfo.getParentLookup(0), ShouldBeNil)
+				So(parentResourceInfo.getParentLookupPropertyName(0), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetExplicitParentURIs(t *testing.T) {
+	Convey("Given a SpecV2Resource which root POST operation maps its path parameters to parent resources", t, func() {
+		r := SpecV2Resource{
+			Path: "/api/admin/v1/projects/{projectId}/clusters/{cluster-name}/nodes",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Parameters: []spec.Parameter{
+								{
+									ParamProps:       spec.ParamProps{Name: "projectId", In: "path"},
+									VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfParentResource: "/api/admin/v1/projects"}},
+								},
+								{
+									ParamProps: spec.ParamProps{Name: "cluster-name", In: "path"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getExplicitParentURIs is called", func() {
+			parentURIs, parentInstanceURIs := r.getExplicitParentURIs()
+			Convey("Then the mapped parameter should use the configured parent and the rest default to the path preceding them", func() {
+				So(parentURIs, ShouldResemble, []string{"/api/admin/v1/projects", "/api/admin/v1/projects/{projectId}/clusters"})
+				So(parentInstanceURIs, ShouldResemble, []string{"/api/admin/v1/projects/{projectId}", "/api/admin/v1/projects/{projectId}/clusters/{cluster-name}"})
+			})
+		})
+		Convey("When GetParentResourceInfo is called", func() {
+			parentResourceInfo := r.GetParentResourceInfo()
+			Convey("Then the parent resource info should be built based on the explicit mapping", func() {
+				So(parentResourceInfo, ShouldNotBeNil)
+				So(parentResourceInfo.parentResourceNames, ShouldResemble, []string{"projects_v1", "clusters"})
+				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "projects_v1_clusters")
+				So(parentResourceInfo.GetParentPropertiesNames(), ShouldResemble, []string{"projects_v1_id", "clusters_id"})
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource which root POST operation does not map any path parameter to a parent resource", t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/cdns/{cdn_id}/v1/firewalls",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Parameters: []spec.Parameter{{ParamProps: spec.ParamProps{Name: "cdn_id", In: "path"}}},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getExplicitParentURIs is called", func() {
+			parentURIs, parentInstanceURIs := r.getExplicitParentURIs()
+			Convey("Then the URIs returned should be nil", func() {
+				So(parentURIs, ShouldBeNil)
+				So(parentInstanceURIs, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetParentIDFormat(t *testing.T) {
+	Convey("Given a SpecV2Resource which operations declare the parent path parameters", t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/projects/{project_id}/clusters/{cluster_id}/databases/{db_name}/users",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Parameters: []spec.Parameter{{ParamProps: spec.ParamProps{Name: "project_id", In: "path"}, SimpleSchema: spec.SimpleSchema{Type: "integer"}}},
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Parameters: []spec.Parameter{
+								{ParamProps: spec.ParamProps{Name: "cluster_id", In: "path"}, SimpleSchema: spec.SimpleSchema{Type: "string", Format: "uuid"}},
+								{ParamProps: spec.ParamProps{Name: "db_name", In: "path"}, SimpleSchema: spec.SimpleSchema{Type: "string"}},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getParentIDFormat is called with the parent instance URIs", func() {
+			Convey("Then the format returned should match how the path parameter identifying the parent is declared", func() {
+				So(r.getParentIDFormat("/v1/projects/{project_id}"), ShouldEqual, parentIDFormatInteger)
+				So(r.getParentIDFormat("/v1/projects/{project_id}/clusters/{cluster_id}"), ShouldEqual, parentIDFormatUUID)
+				So(r.getParentIDFormat("/v1/projects/{project_id}/clusters/{cluster_id}/databases/{db_name}"), ShouldBeEmpty)
+				So(r.getParentIDFormat("/v1/projects/{undeclared_id}"), ShouldBeEmpty)
+				So(r.getParentIDFormat("/v1/projects"), ShouldBeEmpty)
+			})
+		})
+		Convey("When GetParentResourceInfo is called", func() {
+			parentResourceInfo := r.GetParentResourceInfo()
+			Convey("Then the parent resource info should contain the format of each parent ID", func() {
+				So(parentResourceInfo.parentIDFormats, ShouldResemble, []string{parentIDFormatInteger, parentIDFormatUUID, ""})
+			})
+		})
+		Convey("When getSchemaDefinitionWithOptions is called adding the parent properties", func() {
+			schemaDefinition, err := r.getSchemaDefinitionWithOptions(&spec.Schema{}, true)
+			Convey("Then the parent properties should be configured with the format of the parent IDs", func() {
+				So(err, ShouldBeNil)
+				projectID, err := schemaDefinition.getProperty("projects_v1_id")
+				So(err, ShouldBeNil)
+				So(projectID.ParentIDFormat, ShouldEqual, parentIDFormatInteger)
+				clusterID, err := schemaDefinition.getProperty("clusters_id")
+				So(err, ShouldBeNil)
+				So(clusterID.ParentIDFormat, ShouldEqual, parentIDFormatUUID)
+			})
+		})
+	})
+}
+
+func TestGetClientGeneratedValue(t *testing.T) {
+	r := SpecV2Resource{}
+	testCases := []struct {
+		name                         string
+		property                     spec.Schema
+		propertyType                 schemaDefinitionPropertyType
+		expectedClientGeneratedValue *clientGeneratedValue
+		expectedError                string
+	}{
+		{
+			name:                         "extension not present",
+			property:                     *spec.StringProperty(),
+			propertyType:                 TypeString,
+			expectedClientGeneratedValue: nil,
+		},
+		{
+			name:                         "uuid",
+			property:                     spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "uuid"}}},
+			propertyType:                 TypeString,
+			expectedClientGeneratedValue: &clientGeneratedValue{kind: clientGeneratedUUID},
+		},
+		{
+			name:                         "timestamp",
+			property:                     spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "timestamp"}}},
+			propertyType:                 TypeString,
+			expectedClientGeneratedValue: &clientGeneratedValue{kind: clientGeneratedTimestamp},
+		},
+		{
+			name:                         "random string with default length and charset",
+			property:                     spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "random_string"}}},
+			propertyType:                 TypeString,
+			expectedClientGeneratedValue: &clientGeneratedValue{kind: clientGeneratedRandomString, length: clientGeneratedDefaultLength, charset: clientGeneratedDefaultCharset},
+		},
+		{
+			name:                         "random string with length and charset",
+			property:                     spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "random_string", extTfClientGeneratedLength: float64(8), extTfClientGeneratedCharset: "abc123"}}},
+			propertyType:                 TypeString,
+			expectedClientGeneratedValue: &clientGeneratedValue{kind: clientGeneratedRandomString, length: 8, charset: "abc123"},
+		},
+		{
+			name:          "random string with invalid length",
+			property:      spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "random_string", extTfClientGeneratedLength: float64(-1)}}},
+			propertyType:  TypeString,
+			expectedError: "failed to process property 'propertyName': invalid 'x-terraform-client-generated-length' value '-1', the value must be a positive integer",
+		},
+		{
+			name:          "unsupported value",
+			property:      spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "sequence"}}},
+			propertyType:  TypeString,
+			expectedError: "failed to process property 'propertyName': invalid 'x-terraform-client-generated' value 'sequence', supported values are: uuid, timestamp and random_string",
+		},
+		{
+			name:          "property that is not a string",
+			property:      spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "uuid"}}},
+			propertyType:  TypeInt,
+			expectedError: "failed to process property 'propertyName': the 'x-terraform-client-generated' extension is only supported in string properties that are not readOnly and do not have a default value",
+		},
+		{
+			name:          "readOnly property",
+			property:      spec.Schema{SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfClientGenerated: "uuid"}}},
+			propertyType:  TypeString,
+			expectedError: "failed to process property 'propertyName': the 'x-terraform-client-generated' extension is only supported in string properties that are not readOnly and do not have a default value",
+		},
+	}
+	for _, tc := range testCases {
+		clientGenerated, err := r.getClientGeneratedValue("propertyName", tc.property, tc.propertyType)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedClientGeneratedValue, clientGenerated, tc.name)
+	}
+}
+
+func TestGetUpload(t *testing.T) {
+	r := SpecV2Resource{}
+	testCases := []struct {
+		name           string
+		property       spec.Schema
+		propertyType   schemaDefinitionPropertyType
+		expectedUpload *specUpload
+		expectedError  string
+	}{
+		{
+			name:           "extension not present",
+			property:       *spec.StringProperty(),
+			propertyType:   TypeString,
+			expectedUpload: nil,
+		},
+		{
+			name:           "upload path with the default reference property",
+			property:       spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUploadPath: "/v1/uploads"}}},
+			propertyType:   TypeString,
+			expectedUpload: &specUpload{path: "/v1/uploads", referenceProperty: uploadDefaultReferenceProperty},
+		},
+		{
+			name:           "upload path with reference property and chunk size",
+			property:       spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUploadPath: "/v1/uploads", extTfUploadReferenceProperty: "upload_id", extTfUploadChunkSize: float64(8388608)}}},
+			propertyType:   TypeString,
+			expectedUpload: &specUpload{path: "/v1/uploads", referenceProperty: "upload_id", chunkSize: 8388608},
+		},
+		{
+			name:          "property that is not a string",
+			property:      spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUploadPath: "/v1/uploads"}}},
+			propertyType:  TypeInt,
+			expectedError: "failed to process property 'propertyName': the 'x-terraform-upload-path' extension is only supported in string properties that are not readOnly",
+		},
+		{
+			name:          "readOnly property",
+			property:      spec.Schema{SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUploadPath: "/v1/uploads"}}},
+			propertyType:  TypeString,
+			expectedError: "failed to process property 'propertyName': the 'x-terraform-upload-path' extension is only supported in string properties that are not readOnly",
+		},
+	}
+	for _, tc := range testCases {
+		upload, err := r.getUpload("propertyName", tc.property, tc.propertyType)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedUpload, upload, tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyClientGenerated(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When createSchemaDefinitionProperty is called with a required property that has the 'x-terraform-client-generated' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfClientGenerated: "uuid",
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("idempotency_key", propertySchema, []string{"idempotency_key"})
+			Convey("Then the property should be optional computed so the provider can generate the value", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.ClientGenerated, ShouldResemble, &clientGeneratedValue{kind: clientGeneratedUUID})
+				So(schemaDefinitionProperty.Required, ShouldBeFalse)
+				So(schemaDefinitionProperty.IsOptionalComputed(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestCreateSchemaDefinitionPropertyArrayItemsEnum(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When createSchemaDefinitionProperty is called with an array property which items declare the enum attribute", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+								Enum: []interface{}{"http", "https"},
+							},
+						},
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("protocols", propertySchema, []string{})
+			Convey("Then the property should contain the allowed values of the items", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.ArrayItemsType, ShouldEqual, TypeString)
+				So(schemaDefinitionProperty.ArrayItemsEnum, ShouldResemble, []interface{}{"http", "https"})
+			})
+		})
+		Convey("When createSchemaDefinitionProperty is called with an array property which items do not declare the enum attribute", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("protocols", propertySchema, []string{})
+			Convey("Then the property should not contain allowed values", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.ArrayItemsEnum, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestFindPathItem(t *testing.T) {
+	Convey("Given a map of paths", t, func() {
+		paths := map[string]spec.PathItem{
+			"/v1/projects/{id}": {PathItemProps: spec.PathItemProps{Get: &spec.Operation{}}},
+			"/v1/clusters/":     {PathItemProps: spec.PathItemProps{Post: &spec.Operation{}}},
+		}
+		Convey("When findPathItem is called with a path that exists", func() {
+			pathItem, exists := findPathItem(paths, "/v1/projects/{id}")
+			Convey("Then the path item should be returned", func() {
+				So(exists, ShouldBeTrue)
+				So(pathItem.Get, ShouldNotBeNil)
+			})
+		})
+		Convey("When findPathItem is called with a path that exists with trailing slash", func() {
+			pathItem, exists := findPathItem(paths, "/v1/clusters")
+			Convey("Then the path item should be returned", func() {
+				So(exists, ShouldBeTrue)
+				So(pathItem.Post, ShouldNotBeNil)
+			})
+		})
+		Convey("When findPathItem is called with a path that uses a different path parameter name", func() {
+			pathItem, exists := findPathItem(paths, "/v1/projects/{project_id}")
+			Convey("Then the path item should be returned", func() {
+				So(exists, ShouldBeTrue)
+				So(pathItem.Get, ShouldNotBeNil)
+			})
+		})
+		Convey("When findPathItem is called with a path that does not exist", func() {
+			_, exists := findPathItem(paths, "/v1/projects")
+			Convey("Then the path item should not be found", func() {
+				So(exists, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetOperationHost(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getOperationHost method is called with an operation that has the '%s' extension", extTfOperationHost), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfOperationHost, "replica.host.com")
+			host := r.getOperationHost(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the host returned should be the extension value", func() {
+				So(host, ShouldEqual, "replica.host.com")
+			})
+		})
+		Convey(fmt.Sprintf("When getOperationHost method is called with an operation that has the '%s' extension with an environment variable placeholder", extTfOperationHost), func() {
+			os.Setenv("OPERATION_HOST", "replica.env.com")
+			defer os.Unsetenv("OPERATION_HOST")
+			extensions := spec.Extensions{}
+			extensions.Add(extTfOperationHost, "${env:OPERATION_HOST}")
+			host := r.getOperationHost(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the host returned should be the interpolated value", func() {
+				So(host, ShouldEqual, "replica.env.com")
+			})
+		})
+		Convey(fmt.Sprintf("When getOperationHost method is called with an operation that has the '%s' extension referring to a missing environment variable", extTfOperationHost), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfOperationHost, "${env:OPERATION_HOST_NOT_SET}")
+			host := r.getOperationHost(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the host returned should be empty", func() {
+				So(host, ShouldBeEmpty)
+			})
+		})
+		Convey(fmt.Sprintf("When getOperationHost method is called with an operation that does not have the '%s' extension", extTfOperationHost), func() {
+			host := r.getOperationHost(&spec.Operation{})
+			Convey("Then the host returned should be empty", func() {
+				So(host, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a multi-region SpecV2Resource", t, func() {
+		r := SpecV2Resource{Region: "rst1"}
+		Convey(fmt.Sprintf("When getOperationHost method is called with an operation that has the '%s' extension with a parameterised host", extTfOperationHost), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfOperationHost, "replica.api.${region}.host.com")
+			host := r.getOperationHost(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the host returned should contain the resource region", func() {
+				So(host, ShouldEqual, "replica.api.rst1.host.com")
+			})
+		})
+	})
+}
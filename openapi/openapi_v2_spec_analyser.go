@@ -1,11 +1,14 @@
 package openapi
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 )
 
 const extTfResourceRegionsFmt = "x-terraform-resource-regions-%s"
+const extTfResourceVersions = "x-terraform-resource-versions"
 
 // specV2Analyser defines an SpecAnalyser implementation for OpenAPI v2 specification
 // Forcing creation of this object via constructor so proper input validation is performed before creating the struct
@@ -22,6 +26,8 @@ const extTfResourceRegionsFmt = "x-terraform-resource-regions-%s"
 type specV2Analyser struct {
 	openAPIDocumentURL string
 	d                  *loads.Document
+	// warnings contains the non-fatal issues found while analysing the document
+	warnings []string
 }
 
 // newSpecAnalyserV2 creates an instance of specV2Analyser which implements the SpecAnalyser interface
@@ -44,6 +50,23 @@ func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error)
 	}, nil
 }
 
+// newSpecAnalyserV2FromDocument creates an instance of specV2Analyser from the given OpenAPI document contents (e,g: a
+// document previously fetched from the openAPIDocumentURL and cached) instead of fetching the document
+func newSpecAnalyserV2FromDocument(openAPIDocumentURL string, document json.RawMessage) (*specV2Analyser, error) {
+	apiSpec, err := loads.Analyzed(document, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyse the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	apiSpec, err = apiSpec.Expanded()
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	return &specV2Analyser{
+		d:                  apiSpec,
+		openAPIDocumentURL: openAPIDocumentURL,
+	}, nil
+}
+
 func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string, resourceRootPath string, resourceRoot, pathItem spec.PathItem, resourcePayloadSchemaDef *spec.Schema) ([]SpecResource, error) {
 	var resources []SpecResource
 	for _, regionName := range regions {
@@ -51,28 +74,100 @@ func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string,
 		if err != nil {
 			return nil, fmt.Errorf("failed to create a resource with region: %s", err)
 		}
+		r.complexObjectLegacyConfigEnabled = specAnalyser.isComplexObjectLegacyConfigEnabled()
+		r.errorFormat = specAnalyser.getErrorFormat()
+		r.unsupportedTypes = specAnalyser.getUnsupportedTypes()
+		host, err := r.getHost()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the host for region '%s': %s", regionName, err)
+		}
+		if !openapiutils.IsValidHost(host) {
+			return nil, fmt.Errorf("the host '%s' built for region '%s' is not a valid host", host, regionName)
+		}
 		log.Printf("[INFO] multi region resource name = %s, region = '%s'", r.GetResourceName(), regionName)
 		resources = append(resources, r)
 	}
 	return resources, nil
 }
 
+// GetWarnings returns the non-fatal issues found while discovering the terraform compliant resources and data sources
+func (specAnalyser *specV2Analyser) GetWarnings() []string {
+	return specAnalyser.warnings
+}
+
+// getSpecVersion returns the version of the API described by the OpenAPI document (info.version). Empty is returned if the
+// document does not declare the version
+func (specAnalyser *specV2Analyser) getSpecVersion() string {
+	if info := specAnalyser.d.Spec().Info; info != nil {
+		return info.Version
+	}
+	return ""
+}
+
+// getSpecFingerprint returns a digest of the OpenAPI document contents that define the provider (everything but the info
+// section, which only contains metadata like the title or description), so material changes in the document can be told
+// apart from formatting changes
+func (specAnalyser *specV2Analyser) getSpecFingerprint() (string, error) {
+	swagger := *specAnalyser.d.Spec()
+	swagger.Info = nil
+	document, err := json.Marshal(swagger)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(document)), nil
+}
+
+// getSpecDocument returns the expanded OpenAPI document encoded as JSON, which can be analysed again with
+// newSpecAnalyserV2FromDocument without resolving any external reference
+func (specAnalyser *specV2Analyser) getSpecDocument() ([]byte, error) {
+	return json.Marshal(specAnalyser.d.Spec())
+}
+
+// addWarning logs the given issue and keeps it so it can be surfaced to the user. Issues already found (e,g: when the
+// resources are discovered more than once) are not added again.
+func (specAnalyser *specV2Analyser) addWarning(format string, args ...interface{}) {
+	warning := fmt.Sprintf(format, args...)
+	log.Printf("[WARN] %s", warning)
+	for _, existingWarning := range specAnalyser.warnings {
+		if existingWarning == warning {
+			return
+		}
+	}
+	specAnalyser.warnings = append(specAnalyser.warnings, warning)
+}
+
 func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSources() []SpecResource {
 	var dataSources []SpecResource
+	dataSourcePaths := map[string]string{}
 	spec := specAnalyser.d.Spec()
 	paths := spec.Paths
-	for resourcePath, pathItem := range paths.Paths {
+	for _, resourcePath := range sortedPaths(paths.Paths) {
+		pathItem := paths.Paths[resourcePath]
 		schemaDefinition, err := specAnalyser.isEndPointTerraformDataSourceCompliant(pathItem)
 		if err != nil {
 			log.Printf("[DEBUG] resource path '%s' not terraform data source compliant: %s", resourcePath, err)
 			continue
 		}
 
+		if !specAnalyser.isResourceVersionExposed(resourcePath) {
+			log.Printf("[INFO] ignoring data source '%s' as its version is not listed in the '%s' extension", resourcePath, extTfResourceVersions)
+			continue
+		}
+
 		d, err := newSpecV2DataSource(resourcePath, *schemaDefinition, pathItem, specAnalyser.d.Spec().Paths.Paths)
 		if err != nil {
-			log.Printf("[WARN] ignoring data source '%s' due to an error while creating a creating the SpecV2Resource: %s", resourcePath, err)
+			specAnalyser.addWarning("ignoring data source '%s' due to an error while creating the SpecV2Resource: %s", resourcePath, err)
 			continue
 		}
+		d.complexObjectLegacyConfigEnabled = specAnalyser.isComplexObjectLegacyConfigEnabled()
+		d.errorFormat = specAnalyser.getErrorFormat()
+		d.unsupportedTypes = specAnalyser.getUnsupportedTypes()
+
+		if conflictingPath, exists := dataSourcePaths[d.GetResourceName()]; exists {
+			specAnalyser.addWarning("ignoring data source '%s' as its name '%s' is already used by the data source '%s', use the '%s' extension to give them different names", resourcePath, d.GetResourceName(), conflictingPath, extTfResourceName)
+			continue
+		}
+		dataSourcePaths[d.GetResourceName()] = resourcePath
 
 		log.Printf("[INFO] found terraform compliant data source [name='%s', rootPath='%s']", d.GetResourceName(), resourcePath)
 		dataSources = append(dataSources, d)
@@ -82,44 +177,76 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSources() []SpecRes
 
 func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecResource, error) {
 	var resources []SpecResource
+	resourcePaths := map[string]string{}
 	start := time.Now()
 	spec := specAnalyser.d.Spec()
 	paths := spec.Paths
-	for resourcePath, pathItem := range paths.Paths {
+	for _, resourcePath := range sortedPaths(paths.Paths) {
+		pathItem := paths.Paths[resourcePath]
+		if specAnalyser.isDataSourceOnlyResource(resourcePath) {
+			log.Printf("[INFO] ignoring resource '%s' as its root path list operation has the '%s' extension, only the data source is exposed", resourcePath, extTfDataSourceOnly)
+			continue
+		}
 		resourceRootPath, resourceRoot, resourcePayloadSchemaDef, err := specAnalyser.isEndPointFullyTerraformResourceCompliant(resourcePath)
 		if err != nil {
+			// paths that are not resource instance paths are expected to not be terraform compliant (e,g: root paths)
+			if specAnalyser.validateInstancePath(resourcePath) == nil {
+				specAnalyser.addWarning("ignoring resource instance path '%s' as it is not terraform compliant: %s", resourcePath, err)
+				continue
+			}
 			log.Printf("[DEBUG] resource path '%s' not terraform compliant: %s", resourcePath, err)
 			continue
 		}
 
+		if !specAnalyser.isResourceVersionExposed(resourceRootPath) {
+			log.Printf("[INFO] ignoring resource '%s' as its version is not listed in the '%s' extension", resourceRootPath, extTfResourceVersions)
+			continue
+		}
+
+		if _, err := openapiutils.InterpolateEnvVariables(getResourceOverrideHost(resourceRoot.Post)); err != nil {
+			return nil, fmt.Errorf("failed to resolve the '%s' extension of resource '%s': %s", extTfResourceURL, resourceRootPath, err)
+		}
+
 		isMultiRegion, regions, err := specAnalyser.isMultiRegionResource(resourceRoot, specAnalyser.d.Spec().Extensions)
 		if err != nil {
-			log.Printf("multi region configuration for resource '%s' is not valid: ", err)
+			specAnalyser.addWarning("ignoring resource '%s' as its multi region configuration is not valid: %s", resourceRootPath, err)
 			continue
 		}
 		if isMultiRegion {
 			log.Printf("[INFO] resource '%s' is configured with host override AND multi region; creating one reasource per region", resourceRootPath)
 			multiRegionResources, err := specAnalyser.createMultiRegionResources(regions, resourceRootPath, *resourceRoot, pathItem, resourcePayloadSchemaDef)
 			if err != nil {
-				log.Printf("[WARN] ignoring multiregion resource '%s' due to an error: %s", resourceRootPath, err)
+				specAnalyser.addWarning("ignoring multiregion resource '%s' due to an error: %s", resourceRootPath, err)
 				continue
 			}
+			for _, multiRegionResource := range multiRegionResources {
+				if err := checkResourceNameCollision(resourcePaths, multiRegionResource, resourceRootPath); err != nil {
+					return nil, err
+				}
+			}
 			resources = append(resources, multiRegionResources...)
 			continue
 		}
 
 		r, err := newSpecV2Resource(resourceRootPath, *resourcePayloadSchemaDef, *resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, specAnalyser.d.Spec().Paths.Paths)
 		if err != nil {
-			log.Printf("[WARN] ignoring resource '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err)
+			specAnalyser.addWarning("ignoring resource '%s' due to an error while creating the SpecV2Resource: %s", resourceRootPath, err)
 			continue
 		}
+		r.complexObjectLegacyConfigEnabled = specAnalyser.isComplexObjectLegacyConfigEnabled()
+		r.errorFormat = specAnalyser.getErrorFormat()
+		r.unsupportedTypes = specAnalyser.getUnsupportedTypes()
 
 		err = specAnalyser.validateSubResourceTerraformCompliance(*r)
 		if err != nil {
-			log.Printf("[WARN] ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.GetResourceName(), resourceRootPath, err)
+			specAnalyser.addWarning("ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.GetResourceName(), resourceRootPath, err)
 			continue
 		}
 
+		if err := checkResourceNameCollision(resourcePaths, r, resourceRootPath); err != nil {
+			return nil, err
+		}
+
 		log.Printf("[INFO] found terraform compliant resource [name='%s', rootPath='%s', instancePath='%s']", r.GetResourceName(), resourceRootPath, resourcePath)
 		resources = append(resources, r)
 	}
@@ -127,6 +254,100 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 	return resources, nil
 }
 
+// isDataSourceOnlyResource returns true if the GET (list) operation of the root path of the given resource instance path
+// has the x-terraform-data-source-only extension enabled. These endpoints are read-only views (e,g: reports) that are
+// only exposed as data sources, even if the root path has a POST operation.
+func (specAnalyser *specV2Analyser) isDataSourceOnlyResource(resourceInstancePath string) bool {
+	if specAnalyser.validateInstancePath(resourceInstancePath) != nil {
+		return false
+	}
+	resourceRootPath, err := specAnalyser.findMatchingResourceRootPath(resourceInstancePath)
+	if err != nil {
+		return false
+	}
+	rootPathItem := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]
+	if rootPathItem.Get == nil {
+		return false
+	}
+	dataSourceOnly, _ := rootPathItem.Get.Extensions.GetBool(extTfDataSourceOnly)
+	return dataSourceOnly
+}
+
+// sortedPaths returns the paths sorted alphabetically so the resources are always discovered in the same order
+func sortedPaths(paths map[string]spec.PathItem) []string {
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// checkResourceNameCollision returns an error if the name of the given resource is already used by a resource with a
+// different root path; otherwise the resource name is registered in resourcePaths along with the resource root path.
+// Resources marked to be ignored are not registered in the provider, hence they can not collide.
+func checkResourceNameCollision(resourcePaths map[string]string, resource SpecResource, resourceRootPath string) error {
+	if resource.ShouldIgnoreResource() {
+		return nil
+	}
+	resourceName := resource.GetResourceName()
+	if conflictingPath, exists := resourcePaths[resourceName]; exists && conflictingPath != resourceRootPath {
+		return fmt.Errorf("resource name '%s' is used by more than one resource ('%s' and '%s'), use the '%s' extension to give them different names", resourceName, conflictingPath, resourceRootPath, extTfResourceName)
+	}
+	resourcePaths[resourceName] = resourceRootPath
+	return nil
+}
+
+// isComplexObjectLegacyConfigEnabled checks whether the root level x-terraform-complex-object-legacy-config extension is
+// enabled, in which case all the object properties of the resources and data sources are configured as blocks regardless
+// of whether the properties contain the extension
+func (specAnalyser *specV2Analyser) isComplexObjectLegacyConfigEnabled() bool {
+	enabled, _ := specAnalyser.d.Spec().Extensions.GetBool(extTfComplexObjectType)
+	return enabled
+}
+
+// getErrorFormat returns the error format configured in the root level x-terraform-error-format extension which applies
+// to all the operations that do not override it; empty if not present
+func (specAnalyser *specV2Analyser) getErrorFormat() string {
+	errorFormat, _ := specAnalyser.d.Spec().Extensions.GetString(extTfErrorFormat)
+	return errorFormat
+}
+
+// getUnsupportedTypes returns how the properties which type is not supported are handled as configured in the root level
+// x-terraform-unsupported-types extension: error (default), ignore or json. Not supported values are ignored with a warning.
+func (specAnalyser *specV2Analyser) getUnsupportedTypes() string {
+	unsupportedTypes, exists := specAnalyser.d.Spec().Extensions.GetString(extTfUnsupportedTypes)
+	if !exists {
+		return unsupportedTypesError
+	}
+	switch unsupportedTypes = strings.ToLower(strings.TrimSpace(unsupportedTypes)); unsupportedTypes {
+	case unsupportedTypesError, unsupportedTypesIgnore, unsupportedTypesJSON:
+		return unsupportedTypes
+	}
+	log.Printf("[WARN] ignoring the extension '%s' with not supported value '%s', the supported values are: %s, %s, %s", extTfUnsupportedTypes, unsupportedTypes, unsupportedTypesError, unsupportedTypesIgnore, unsupportedTypesJSON)
+	return unsupportedTypesError
+}
+
+// isResourceVersionExposed checks whether the version of the given resource path is listed in the root level
+// x-terraform-resource-versions extension (comma separated list of versions, e,g: "v2,v3"). If the extension is not
+// present all the versions are exposed. Resource paths that are not versioned are always exposed.
+func (specAnalyser *specV2Analyser) isResourceVersionExposed(resourcePath string) bool {
+	exposedVersions, exists := specAnalyser.d.Spec().Extensions.GetString(extTfResourceVersions)
+	if !exists || strings.TrimSpace(exposedVersions) == "" {
+		return true
+	}
+	version := getResourceVersion(resourcePath)
+	if version == "" {
+		return true
+	}
+	for _, exposedVersion := range strings.Split(exposedVersions, ",") {
+		if strings.TrimSpace(exposedVersion) == version {
+			return true
+		}
+	}
+	return false
+}
+
 func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r SpecV2Resource) error {
 	parentResourceInfo := r.GetParentResourceInfo()
 	if parentResourceInfo != nil {
@@ -151,13 +372,10 @@ func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r Spe
 }
 
 func (specAnalyser *specV2Analyser) pathExists(path string) (bool, spec.PathItem) {
-	p, exists := specAnalyser.d.Spec().Paths.Paths[path]
+	p, exists := findPathItem(specAnalyser.d.Spec().Paths.Paths, path)
 	if !exists {
-		log.Printf("[WARN] path %s not found, falling back to checking if the path with trailing slash %s/ exists", path, path)
-		p, exists = specAnalyser.d.Spec().Paths.Paths[path+"/"]
-		if !exists {
-			return false, spec.PathItem{}
-		}
+		log.Printf("[WARN] path %s not found (neither with trailing slash nor with different path parameter names)", path)
+		return false, spec.PathItem{}
 	}
 	return true, p
 }
@@ -167,7 +385,10 @@ func (specAnalyser *specV2Analyser) pathExists(path string) (bool, spec.PathItem
 // - there is a matching 'x-terraform-resource-regions-${keyword}' extension defined in the swagger root level (extensions passed in), where ${keyword} will be the value of the parameter in the above URL
 // - and finally the value of the extension is an array of strings containing the different regions where the resource can be created
 func (specAnalyser *specV2Analyser) isMultiRegionResource(resourceRoot *spec.PathItem, extensions spec.Extensions) (bool, []string, error) {
-	overrideHost := getResourceOverrideHost(resourceRoot.Post)
+	overrideHost, err := openapiutils.InterpolateEnvVariables(getResourceOverrideHost(resourceRoot.Post))
+	if err != nil {
+		return false, nil, err
+	}
 	if overrideHost == "" {
 		return false, nil, nil
 	}
@@ -283,6 +504,10 @@ func (specAnalyser *specV2Analyser) isEndPointFullyTerraformResourceCompliant(re
 	if err != nil {
 		return "", nil, nil, err
 	}
+	if specAnalyser.bodyParameterExists(resourceRootPathItem.Post) != nil {
+		resourceResponseSchemaDef := specAnalyser.getResourceResponseSchema(resourcePath, resourceRootPathItem.Post)
+		resourceRootPostSchemaDef = mergeRequestAndResponseSchemas(resourceRootPostSchemaDef, resourceResponseSchemaDef)
+	}
 	err = specAnalyser.validateResourceSchemaDefinition(resourceRootPostSchemaDef)
 	if err != nil {
 		return "", nil, nil, err
@@ -290,7 +515,86 @@ func (specAnalyser *specV2Analyser) isEndPointFullyTerraformResourceCompliant(re
 	return resourceRootPath, resourceRootPathItem, resourceRootPostSchemaDef, nil
 }
 
+// getResourceResponseSchema returns the schema of the resource as returned by the API: the resource instance GET operation
+// 200 response schema or, if not documented, the root POST operation successful response schema. Nil is returned if none
+// of them is documented or the schema returned does not describe an object.
+func (specAnalyser *specV2Analyser) getResourceResponseSchema(resourceInstancePath string, resourceRootPostOperation *spec.Operation) *spec.Schema {
+	var responseSchema *spec.Schema
+	instancePathItem := specAnalyser.d.Spec().Paths.Paths[resourceInstancePath]
+	if instancePathItem.Get != nil && instancePathItem.Get.Responses != nil {
+		if response, exists := instancePathItem.Get.Responses.StatusCodeResponses[http.StatusOK]; exists {
+			responseSchema = response.Schema
+		}
+	}
+	if responseSchema == nil {
+		responseSchema, _ = specAnalyser.getSuccessfulResponseDefinition(resourceRootPostOperation)
+	}
+	if responseSchema == nil || len(responseSchema.Properties) == 0 {
+		return nil
+	}
+	return responseSchema
+}
+
+// mergeRequestAndResponseSchemas returns the schema used to map the resource state when the response model differs from
+// the request model: the union of both schemas where the properties only present in the response (e,g: computed wrapper
+// fields) are marked as readOnly and the properties only present in the request are marked with the
+// x-terraform-request-only extension so their values are kept from the configuration. The request schema is returned
+// as is if the response schema is nil.
+func mergeRequestAndResponseSchemas(requestSchema, responseSchema *spec.Schema) *spec.Schema {
+	if responseSchema == nil || requestSchema == responseSchema {
+		return requestSchema
+	}
+	mergedSchema := *requestSchema
+	mergedSchema.Properties = map[string]spec.Schema{}
+	for propertyName, property := range requestSchema.Properties {
+		if _, returned := responseSchema.Properties[propertyName]; !returned && !property.ReadOnly {
+			extensions := spec.Extensions{}
+			for k, v := range property.Extensions {
+				extensions[k] = v
+			}
+			extensions.Add(extTfRequestOnly, true)
+			property.Extensions = extensions
+		}
+		mergedSchema.Properties[propertyName] = property
+	}
+	for propertyName, property := range responseSchema.Properties {
+		if _, exists := requestSchema.Properties[propertyName]; exists {
+			continue
+		}
+		// the properties only returned by the API are not part of the creation body, hence none of them (nor their nested
+		// properties) can be required regardless of the response schema required list
+		property = withoutRequiredProperties(property)
+		property.ReadOnly = true
+		mergedSchema.Properties[propertyName] = property
+	}
+	return &mergedSchema
+}
+
+// withoutRequiredProperties returns a copy of the given schema where the required list of the schema and of its nested
+// object schemas (including array items) is cleared. The given schema is not modified.
+func withoutRequiredProperties(schema spec.Schema) spec.Schema {
+	schema.Required = nil
+	if schema.Properties != nil {
+		properties := map[string]spec.Schema{}
+		for propertyName, property := range schema.Properties {
+			properties[propertyName] = withoutRequiredProperties(property)
+		}
+		schema.Properties = properties
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		itemsSchema := withoutRequiredProperties(*schema.Items.Schema)
+		schema.Items = &spec.SchemaOrArray{Schema: &itemsSchema}
+	}
+	return schema
+}
+
 func (specAnalyser *specV2Analyser) isEndPointTerraformDataSourceCompliant(path spec.PathItem) (*spec.Schema, error) {
+	return getListItemsSchema(path)
+}
+
+// getListItemsSchema returns the schema of the items returned by the path GET operation if the operation returns an array
+// of objects (list endpoint)
+func getListItemsSchema(path spec.PathItem) (*spec.Schema, error) {
 	if path.Get == nil {
 		return nil, errors.New("missing get operation")
 	}
@@ -347,15 +651,18 @@ func (specAnalyser *specV2Analyser) validateRootPath(resourcePath string) (strin
 		bodyParam := specAnalyser.bodyParameterExists(resourceRootPostOperation)
 		// Use case where resource does not expect any input as part of the POST root operation, and only produces computed properties
 		if bodyParam == nil {
-			resourceSchema, err := specAnalyser.getSuccessfulResponseDefinition(resourceRootPostOperation)
+			responseSchema, err := specAnalyser.getSuccessfulResponseDefinition(resourceRootPostOperation)
 			if err != nil {
 				return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation (without body parameter) error: %s", resourceRootPath, err)
 			}
-			err = specAnalyser.validateResourceSchemaDefWithOptions(resourceSchema, true)
+			// the resource is created without body, hence the properties marked as required in the response schema are
+			// not required to create the resource
+			resourceSchema := withoutRequiredProperties(*responseSchema)
+			err = specAnalyser.validateResourceSchemaDefWithOptions(&resourceSchema, true)
 			if err != nil {
 				return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation (without body parameter) validation error: %s", resourceRootPath, err)
 			}
-			return resourceRootPath, &resourceRootPathItem, resourceSchema, nil
+			return resourceRootPath, &resourceRootPathItem, &resourceSchema, nil
 		}
 		return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation validation error: %s", resourceRootPath, err)
 	}
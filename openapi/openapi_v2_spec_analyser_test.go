@@ -103,6 +103,19 @@ definitions:
 				So(i.Get, ShouldNotBeNil)
 			})
 		})
+		Convey("When pathExists is called with a path using a different path parameter name", func() {
+			b, i := a.pathExists("/users/{user-id}")
+			Convey("Then it returns true and the PathItem Operation is not nil", func() {
+				So(b, ShouldBeTrue)
+				So(i.Get, ShouldNotBeNil)
+			})
+		})
+		Convey("When pathExists is called with a path that does not exist", func() {
+			b, _ := a.pathExists("/users/{id}/groups")
+			Convey("Then it returns false", func() {
+				So(b, ShouldBeFalse)
+			})
+		})
 	})
 
 	Convey("Given a specV2Analyser initialized from a swagger doc with a path without a trailing slash", t, func() {
@@ -1076,6 +1089,173 @@ func TestIsMultiRegionResource(t *testing.T) {
 	})
 }
 
+func TestIsResourceVersionExposed(t *testing.T) {
+	Convey("Given a specV2Analyser loaded with a swagger file containing the root level extension x-terraform-resource-versions", t, func() {
+		swaggerContent := `swagger: "2.0"
+x-terraform-resource-versions: "v2, v3"
+paths:
+  /v1/cdns:
+    post:
+      responses:
+        201:
+          description: "created"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When isResourceVersionExposed is called with a resource path which version is listed in the extension", func() {
+			Convey("Then the result returned should be true", func() {
+				So(a.isResourceVersionExposed("/v2/cdns"), ShouldBeTrue)
+				So(a.isResourceVersionExposed("/v3/cdns"), ShouldBeTrue)
+			})
+		})
+		Convey("When isResourceVersionExposed is called with a resource path which version is not listed in the extension", func() {
+			Convey("Then the result returned should be false", func() {
+				So(a.isResourceVersionExposed("/v1/cdns"), ShouldBeFalse)
+				So(a.isResourceVersionExposed("/v2/cdns/{id}/v1/firewalls"), ShouldBeFalse)
+			})
+		})
+		Convey("When isResourceVersionExposed is called with a resource path that is not versioned", func() {
+			Convey("Then the result returned should be true", func() {
+				So(a.isResourceVersionExposed("/cdns"), ShouldBeTrue)
+			})
+		})
+		Convey("When GetTerraformCompliantResources is called", func() {
+			resources, err := a.GetTerraformCompliantResources()
+			Convey("Then the resources which version is not listed in the extension should not be returned", func() {
+				So(err, ShouldBeNil)
+				So(resources, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a specV2Analyser loaded with a swagger file that does not contain the root level extension x-terraform-resource-versions", t, func() {
+		a := initAPISpecAnalyser(`swagger: "2.0"`)
+		Convey("When isResourceVersionExposed is called with any versioned resource path", func() {
+			Convey("Then the result returned should be true", func() {
+				So(a.isResourceVersionExposed("/v1/cdns"), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGetUnsupportedTypes(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		swaggerContent           string
+		expectedUnsupportedTypes string
+	}{
+		{
+			name:                     "extension not present",
+			swaggerContent:           `swagger: "2.0"`,
+			expectedUnsupportedTypes: unsupportedTypesError,
+		},
+		{
+			name: "extension set to ignore",
+			swaggerContent: `swagger: "2.0"
+x-terraform-unsupported-types: ignore`,
+			expectedUnsupportedTypes: unsupportedTypesIgnore,
+		},
+		{
+			name: "extension set to json (case insensitive)",
+			swaggerContent: `swagger: "2.0"
+x-terraform-unsupported-types: JSON`,
+			expectedUnsupportedTypes: unsupportedTypesJSON,
+		},
+		{
+			name: "extension with a non supported value",
+			swaggerContent: `swagger: "2.0"
+x-terraform-unsupported-types: drop`,
+			expectedUnsupportedTypes: unsupportedTypesError,
+		},
+	}
+	for _, tc := range testCases {
+		a := initAPISpecAnalyser(tc.swaggerContent)
+		assert.Equal(t, tc.expectedUnsupportedTypes, a.getUnsupportedTypes(), tc.name)
+	}
+}
+
+func TestIsComplexObjectLegacyConfigEnabled(t *testing.T) {
+	Convey("Given a specV2Analyser loaded with a swagger file containing the root level extension x-terraform-complex-object-legacy-config", t, func() {
+		swaggerContent := `swagger: "2.0"
+x-terraform-complex-object-legacy-config: true
+paths:
+  /v1/cdns:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/ContentDeliveryNetwork"
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      object_property:
+        type: "object"
+        properties:
+          account:
+            type: "string"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When isComplexObjectLegacyConfigEnabled is called", func() {
+			Convey("Then the result returned should be true", func() {
+				So(a.isComplexObjectLegacyConfigEnabled(), ShouldBeTrue)
+			})
+		})
+		Convey("When GetTerraformCompliantResources is called", func() {
+			resources, err := a.GetTerraformCompliantResources()
+			So(err, ShouldBeNil)
+			So(resources, ShouldHaveLength, 1)
+			resourceSchema, err := resources[0].GetResourceSchema()
+			So(err, ShouldBeNil)
+			objectProperty, err := resourceSchema.getProperty("object_property")
+			So(err, ShouldBeNil)
+			Convey("Then the object properties of the resources should be configured as blocks", func() {
+				So(objectProperty.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects(), ShouldBeTrue)
+			})
+		})
+		Convey("When GetTerraformCompliantDataSources is called", func() {
+			dataSources := a.GetTerraformCompliantDataSources()
+			So(dataSources, ShouldHaveLength, 1)
+			dataSourceSchema, err := dataSources[0].GetResourceSchema()
+			So(err, ShouldBeNil)
+			objectProperty, err := dataSourceSchema.getProperty("object_property")
+			So(err, ShouldBeNil)
+			Convey("Then the object properties of the data sources should be configured as blocks too", func() {
+				So(objectProperty.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects(), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a specV2Analyser loaded with a swagger file that does not contain the root level extension x-terraform-complex-object-legacy-config", t, func() {
+		a := initAPISpecAnalyser(`swagger: "2.0"`)
+		Convey("When isComplexObjectLegacyConfigEnabled is called", func() {
+			Convey("Then the result returned should be false", func() {
+				So(a.isComplexObjectLegacyConfigEnabled(), ShouldBeFalse)
+			})
+		})
+	})
+}
+
 func TestResourceInstanceEndPoint(t *testing.T) {
 	Convey("Given an specV2Analyser", t, func() {
 		a := specV2Analyser{}
@@ -2328,6 +2508,181 @@ definitions:
 	})
 }
 
+func TestIsEndPointTerraformResourceCompliant_ResponseSchemaDivergence(t *testing.T) {
+	Convey("Given an specV2Analyser with a resource which response model differs from the request model", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /users:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/UserRequest"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/User"
+  /users/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/User"
+definitions:
+  UserRequest:
+    type: "object"
+    required:
+      - name
+    properties:
+      name:
+        type: "string"
+      password:
+        type: "string"
+  User:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+      name:
+        type: "string"
+      created_at:
+        type: "string"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called ", func() {
+			_, _, resourceSchema, err := a.isEndPointFullyTerraformResourceCompliant("/users/{id}")
+			Convey("Then the resource schema should be the union of the request and response models", func() {
+				So(err, ShouldBeNil)
+				So(resourceSchema.Properties, ShouldContainKey, "id")
+				So(resourceSchema.Properties, ShouldContainKey, "name")
+				So(resourceSchema.Properties, ShouldContainKey, "password")
+				So(resourceSchema.Properties, ShouldContainKey, "created_at")
+				So(resourceSchema.Required, ShouldResemble, []string{"name"})
+			})
+			Convey("And the properties only present in the response should be readOnly", func() {
+				So(resourceSchema.Properties["id"].ReadOnly, ShouldBeTrue)
+				So(resourceSchema.Properties["created_at"].ReadOnly, ShouldBeTrue)
+				So(resourceSchema.Properties["name"].ReadOnly, ShouldBeFalse)
+			})
+			Convey("And the properties only present in the request should be marked as request only", func() {
+				requestOnly, _ := resourceSchema.Properties["password"].Extensions.GetBool(extTfRequestOnly)
+				So(requestOnly, ShouldBeTrue)
+				_, exists := resourceSchema.Properties["name"].Extensions.GetBool(extTfRequestOnly)
+				So(exists, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestMergeRequestAndResponseSchemas(t *testing.T) {
+	Convey("Given a request schema", t, func() {
+		requestSchema := &spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Properties: map[string]spec.Schema{
+					"name":     *spec.StringProperty(),
+					"password": *spec.StringProperty(),
+				},
+			},
+		}
+		Convey("When mergeRequestAndResponseSchemas is called with a nil response schema", func() {
+			mergedSchema := mergeRequestAndResponseSchemas(requestSchema, nil)
+			Convey("Then the request schema should be returned as is", func() {
+				So(mergedSchema, ShouldEqual, requestSchema)
+			})
+		})
+		Convey("When mergeRequestAndResponseSchemas is called with a response schema containing different properties", func() {
+			responseSchema := &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"id":   *spec.StringProperty(),
+						"name": *spec.StringProperty(),
+					},
+				},
+			}
+			mergedSchema := mergeRequestAndResponseSchemas(requestSchema, responseSchema)
+			Convey("Then the merged schema should contain the union of the properties", func() {
+				So(mergedSchema.Properties, ShouldHaveLength, 3)
+				So(mergedSchema.Properties["id"].ReadOnly, ShouldBeTrue)
+				requestOnly, _ := mergedSchema.Properties["password"].Extensions.GetBool(extTfRequestOnly)
+				So(requestOnly, ShouldBeTrue)
+			})
+			Convey("And the request and response schemas should not be modified", func() {
+				So(requestSchema.Properties, ShouldHaveLength, 2)
+				So(requestSchema.Properties["password"].Extensions, ShouldBeNil)
+				So(responseSchema.Properties["id"].ReadOnly, ShouldBeFalse)
+			})
+		})
+		Convey("When mergeRequestAndResponseSchemas is called with a response schema that requires properties absent from the request schema", func() {
+			nestedObject := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:     spec.StringOrArray{"object"},
+					Required: []string{"state"},
+					Properties: map[string]spec.Schema{
+						"state": *spec.StringProperty(),
+					},
+				},
+			}
+			responseSchema := &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Required: []string{"id", "name", "status"},
+					Properties: map[string]spec.Schema{
+						"id":     *spec.StringProperty(),
+						"name":   *spec.StringProperty(),
+						"status": nestedObject,
+					},
+				},
+			}
+			mergedSchema := mergeRequestAndResponseSchemas(requestSchema, responseSchema)
+			Convey("Then the required properties should be the ones from the request schema only", func() {
+				So(mergedSchema.Required, ShouldBeNil)
+				So(mergedSchema.Properties["status"].Required, ShouldBeNil)
+				So(mergedSchema.Properties["status"].ReadOnly, ShouldBeTrue)
+			})
+			Convey("And the response schema should not be modified", func() {
+				So(responseSchema.Required, ShouldResemble, []string{"id", "name", "status"})
+				So(responseSchema.Properties["status"].Required, ShouldResemble, []string{"state"})
+			})
+		})
+	})
+}
+
+func TestWithoutRequiredProperties(t *testing.T) {
+	Convey("Given a schema with required properties including nested objects and arrays of objects", t, func() {
+		itemsSchema := spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type:       spec.StringOrArray{"object"},
+				Required:   []string{"name"},
+				Properties: map[string]spec.Schema{"name": *spec.StringProperty()},
+			},
+		}
+		s := spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Required: []string{"id", "members"},
+				Properties: map[string]spec.Schema{
+					"id":      *spec.StringProperty(),
+					"members": *spec.ArrayProperty(&itemsSchema),
+				},
+			},
+		}
+		Convey("When withoutRequiredProperties is called", func() {
+			result := withoutRequiredProperties(s)
+			Convey("Then the schema returned should not contain any required property", func() {
+				So(result.Required, ShouldBeNil)
+				So(result.Properties["members"].Items.Schema.Required, ShouldBeNil)
+			})
+			Convey("And the given schema should not be modified", func() {
+				So(s.Required, ShouldResemble, []string{"id", "members"})
+				So(s.Properties["members"].Items.Schema.Required, ShouldResemble, []string{"name"})
+			})
+		})
+	})
+}
+
 func getExpectedResource(terraformCompliantResources []SpecResource, expectedResourceName string) SpecResource {
 	for _, r := range terraformCompliantResources {
 		if r.GetResourceName() == expectedResourceName {
@@ -2515,6 +2870,73 @@ definitions:
 				So(multiRegionResources, ShouldBeNil)
 			})
 		})
+		Convey("When createMultiRegionResources method is called with a region that results into a host that is not valid", func() {
+			regions := []string{"rst 1"}
+			pathRootItem := a.d.Spec().Paths.Paths["/v1/cdns"]
+			pathItem := a.d.Spec().Paths.Paths["/v1/cdns/{id}"]
+			resourcePayloadSchemaDef := a.d.Spec().Definitions["ContentDeliveryNetwork"]
+			multiRegionResources, err := a.createMultiRegionResources(regions, "/v1/cdns", pathRootItem, pathItem, &resourcePayloadSchemaDef)
+			Convey("Then the error returned should be as expected", func() {
+				So(err.Error(), ShouldEqual, "the host 'some.subdomain.rst 1.domain.com' built for region 'rst 1' is not a valid host")
+				So(multiRegionResources, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetTerraformCompliantResourcesWithHostEnvVariables(t *testing.T) {
+	swaggerContent := `swagger: "2.0"
+paths:
+  /v1/cdns:
+    post:
+      x-terraform-resource-host: ${env:TEST_CDN_HOST_ENV}.domain.com
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true`
+	Convey("Given an specV2Analyser loaded with a swagger file containing a resource which host contains an environment variable placeholder", t, func() {
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When GetTerraformCompliantResources method is called and the environment variable is set", func() {
+			os.Setenv("TEST_CDN_HOST_ENV", "cdn")
+			defer os.Unsetenv("TEST_CDN_HOST_ENV")
+			resources, err := a.GetTerraformCompliantResources()
+			Convey("Then the resource returned should be configured with the interpolated host", func() {
+				So(err, ShouldBeNil)
+				So(len(resources), ShouldEqual, 1)
+				host, err := resources[0].getHost()
+				So(err, ShouldBeNil)
+				So(host, ShouldEqual, "cdn.domain.com")
+			})
+		})
+		Convey("When GetTerraformCompliantResources method is called and the environment variable is not set", func() {
+			_, err := a.GetTerraformCompliantResources()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to resolve the 'x-terraform-resource-host' extension of resource '/v1/cdns': environment variable(s) 'TEST_CDN_HOST_ENV' required by '${env:TEST_CDN_HOST_ENV}.domain.com' not set")
+			})
+		})
 	})
 }
 
@@ -2637,6 +3059,171 @@ definitions:
 	}
 }
 
+func TestGetWarnings(t *testing.T) {
+	Convey("Given an specV2Analyser loaded with a swagger file containing a compliant resource and a resource instance path missing the root path", t, func() {
+		swaggerContent := `swagger: "2.0"
+host: 127.0.0.1
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/users/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When GetWarnings method is called after GetTerraformCompliantResources is called twice", func() {
+			_, err := a.GetTerraformCompliantResources()
+			So(err, ShouldBeNil)
+			_, err = a.GetTerraformCompliantResources()
+			So(err, ShouldBeNil)
+			warnings := a.GetWarnings()
+			Convey("Then the warnings returned should only contain the resource instance path that is not compliant once", func() {
+				So(warnings, ShouldHaveLength, 1)
+				So(warnings[0], ShouldStartWith, "ignoring resource instance path '/v1/users/{id}' as it is not terraform compliant:")
+			})
+		})
+	})
+	Convey("Given an specV2Analyser loaded with a swagger file containing only compliant resources", t, func() {
+		a := initAPISpecAnalyser(`swagger: "2.0"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true`)
+		Convey("When GetWarnings method is called after GetTerraformCompliantResources", func() {
+			_, err := a.GetTerraformCompliantResources()
+			So(err, ShouldBeNil)
+			Convey("Then the warnings returned should be empty", func() {
+				So(a.GetWarnings(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetSpecFingerprint(t *testing.T) {
+	swaggerTemplate := `swagger: "2.0"
+info:
+  title: %s
+paths:
+  %s:
+    post:
+      responses:
+        201:
+          description: "created"`
+	Convey("Given an specV2Analyser loaded with a swagger file", t, func() {
+		a := initAPISpecAnalyser(fmt.Sprintf(swaggerTemplate, "Some API", "/v1/cdns"))
+		fingerprint, err := a.getSpecFingerprint()
+		So(err, ShouldBeNil)
+		So(fingerprint, ShouldNotBeEmpty)
+		Convey("When getSpecFingerprint is called on a document that only differs in the info section", func() {
+			other := initAPISpecAnalyser(fmt.Sprintf(swaggerTemplate, "Some API renamed", "/v1/cdns"))
+			otherFingerprint, err := other.getSpecFingerprint()
+			Convey("Then the fingerprint returned should be the same", func() {
+				So(err, ShouldBeNil)
+				So(otherFingerprint, ShouldEqual, fingerprint)
+			})
+		})
+		Convey("When getSpecFingerprint is called on a document with different paths", func() {
+			other := initAPISpecAnalyser(fmt.Sprintf(swaggerTemplate, "Some API", "/v2/cdns"))
+			otherFingerprint, err := other.getSpecFingerprint()
+			Convey("Then the fingerprint returned should be different", func() {
+				So(err, ShouldBeNil)
+				So(otherFingerprint, ShouldNotEqual, fingerprint)
+			})
+		})
+	})
+}
+
+func TestGetSpecVersion(t *testing.T) {
+	Convey("Given an specV2Analyser loaded with a swagger file that declares the API version", t, func() {
+		a := initAPISpecAnalyser(`swagger: "2.0"
+info:
+  title: Some API
+  version: 1.2.0
+paths: {}`)
+		Convey("When getSpecVersion is called", func() {
+			specVersion := a.getSpecVersion()
+			Convey("Then the version returned should be the one in the info section", func() {
+				So(specVersion, ShouldEqual, "1.2.0")
+			})
+		})
+	})
+	Convey("Given an specV2Analyser loaded with a swagger file that does not declare the info section", t, func() {
+		a := initAPISpecAnalyser(`swagger: "2.0"
+paths: {}`)
+		Convey("When getSpecVersion is called", func() {
+			specVersion := a.getSpecVersion()
+			Convey("Then the version returned should be empty", func() {
+				So(specVersion, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestGetTerraformCompliantResources(t *testing.T) {
 	Convey("Given an specV2Analyser loaded with a swagger file containing a compliant terraform subresource /v1/cdns/{id}/v1/firewalls but missing the parent resource resource description", t, func() {
 		swaggerContent := `swagger: "2.0"
@@ -2696,6 +3283,10 @@ definitions:
 				So(err, ShouldBeNil)
 				So(terraformCompliantResources, ShouldBeEmpty)
 			})
+			Convey("And the warnings should explain why the subresource is ignored", func() {
+				So(a.GetWarnings(), ShouldHaveLength, 1)
+				So(a.GetWarnings()[0], ShouldContainSubstring, "with rootPath='/v1/cdns/{parent_id}/v1/firewalls' due to not meeting validation requirements")
+			})
 		})
 	})
 
@@ -3481,6 +4072,74 @@ definitions:
 		})
 	})
 
+	Convey("Given an specV2Analyser loaded with a swagger file containing a compliant terraform resource which list operation has the 'x-terraform-data-source-only' with value true", t, func() {
+		var swaggerJSON = `
+{
+   "swagger":"2.0",
+   "paths":{
+      "/v1/reports":{
+         "get":{
+            "x-terraform-data-source-only": true,
+            "summary":"List reports",
+            "responses":{
+               "200":{
+                  "schema":{
+                     "type":"array",
+                     "items":{
+                        "$ref":"#/definitions/Report"
+                     }
+                  }
+               }
+            }
+         },
+         "post":{
+            "summary":"Generate report",
+            "parameters":[
+               {
+                  "in":"body",
+                  "name":"body",
+                  "schema":{
+                     "$ref":"#/definitions/Report"
+                  }
+               }
+            ]
+         }
+      },
+      "/v1/reports/{id}":{
+         "get":{
+            "summary":"Get report by id"
+         }
+      }
+   },
+   "definitions":{
+      "Report":{
+         "type":"object",
+         "properties":{
+            "id":{
+               "type":"string",
+               "readOnly":true
+            }
+         }
+      }
+   }
+}`
+		a := initAPISpecAnalyser(swaggerJSON)
+		Convey("When GetTerraformCompliantResources method is called ", func() {
+			terraformCompliantResources, err := a.GetTerraformCompliantResources()
+			Convey("Then the terraformCompliantResources should be empty since only the data source is exposed", func() {
+				So(err, ShouldBeNil)
+				So(terraformCompliantResources, ShouldBeEmpty)
+			})
+		})
+		Convey("When GetTerraformCompliantDataSources method is called ", func() {
+			dataSources := a.GetTerraformCompliantDataSources()
+			Convey("Then the data source of the list operation should still be returned", func() {
+				So(dataSources, ShouldHaveLength, 1)
+				So(dataSources[0].GetResourceName(), ShouldEqual, "reports_v1")
+			})
+		})
+	})
+
 	Convey("Given an specV2Analyser loaded with a swagger file containing a schema ref that is empty", t, func() {
 		var swaggerJSON = `
 {
@@ -2,6 +2,7 @@ package openapiutils
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 
 const swaggerResourcePayloadDefinitionRegex = "(\\w+)[^//]*$"
 const fqdnInURLRegex = `\b(?:(?:[^.-/]{0,1})[\w-]{1,63}[-]{0,1}[.]{1})+(?:[a-zA-Z]{2,63}|(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?))?(?:[:]\d+)?|localhost(?:[:]\d+)?\b`
+const envVariablePlaceholderRegex = `\$\{env:([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`
 const hostnameRegex = "^(([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\\-]*[a-zA-Z0-9])\\.)*([A-Za-z0-9]|[A-Za-z0-9][A-Za-z0-9\\-]*[A-Za-z0-9])(?:[:]\\d+)?$"
 
 // GetHostFromURL returns the fqdn of a given string (localhost including port number is also handled).
@@ -111,3 +113,27 @@ func IsMultiRegionHost(overrideHost string) (bool, *regexp.Regexp) {
 	regex, _ := regexp.Compile("(\\S+)(\\$\\{(\\S+)\\})(\\S+)")
 	return len(regex.FindStringSubmatch(overrideHost)) != 0, regex
 }
+
+// InterpolateEnvVariables replaces the environment variable placeholders found in the given value with the value of the
+// corresponding environment variables. Placeholders must follow the format ${env:VARIABLE_NAME} or ${env:VARIABLE_NAME:-default}
+// in which case the default value is used if the environment variable is not set. An error is returned if a variable
+// without a default value is not set.
+func InterpolateEnvVariables(value string) (string, error) {
+	re := regexp.MustCompile(envVariablePlaceholderRegex)
+	var missingVariables []string
+	interpolatedValue := re.ReplaceAllStringFunc(value, func(placeholder string) string {
+		matches := re.FindStringSubmatch(placeholder)
+		if envValue, exists := os.LookupEnv(matches[1]); exists && envValue != "" {
+			return envValue
+		}
+		if strings.Contains(placeholder, ":-") {
+			return matches[2]
+		}
+		missingVariables = append(missingVariables, matches[1])
+		return placeholder
+	})
+	if len(missingVariables) > 0 {
+		return "", fmt.Errorf("environment variable(s) '%s' required by '%s' not set", strings.Join(missingVariables, "', '"), value)
+	}
+	return interpolatedValue, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/spec"
 	. "github.com/smartystreets/goconvey/convey"
+	"os"
 	"testing"
 )
 
@@ -410,3 +411,37 @@ func initSwagger(swaggerContent string) *spec.Swagger {
 	d, _ := loads.Analyzed(swagger, "2.0")
 	return d.Spec()
 }
+
+func TestInterpolateEnvVariables(t *testing.T) {
+	Convey("Given a set of environment variables", t, func() {
+		os.Setenv("TEST_INTERPOLATE_ENV", "staging")
+		defer os.Unsetenv("TEST_INTERPOLATE_ENV")
+		Convey("When InterpolateEnvVariables method is called with a value containing placeholders of variables that are set", func() {
+			value, err := InterpolateEnvVariables("api.${env:TEST_INTERPOLATE_ENV}.${region}.domain.com")
+			Convey("Then the placeholders should be replaced with the environment variable values", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "api.staging.${region}.domain.com")
+			})
+		})
+		Convey("When InterpolateEnvVariables method is called with a placeholder containing a default value of a variable that is not set", func() {
+			value, err := InterpolateEnvVariables("api.${env:TEST_INTERPOLATE_ENV_NOT_SET:-prod}.domain.com")
+			Convey("Then the placeholder should be replaced with the default value", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "api.prod.domain.com")
+			})
+		})
+		Convey("When InterpolateEnvVariables method is called with placeholders of variables that are not set", func() {
+			_, err := InterpolateEnvVariables("${env:TEST_INTERPOLATE_ENV_NOT_SET}.${env:TEST_INTERPOLATE_ENV_NOT_SET_2}.domain.com")
+			Convey("Then the error returned should list the missing variables", func() {
+				So(err.Error(), ShouldEqual, "environment variable(s) 'TEST_INTERPOLATE_ENV_NOT_SET', 'TEST_INTERPOLATE_ENV_NOT_SET_2' required by '${env:TEST_INTERPOLATE_ENV_NOT_SET}.${env:TEST_INTERPOLATE_ENV_NOT_SET_2}.domain.com' not set")
+			})
+		})
+		Convey("When InterpolateEnvVariables method is called with a value that does not contain placeholders", func() {
+			value, err := InterpolateEnvVariables("api.domain.com")
+			Convey("Then the value should not be modified", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "api.domain.com")
+			})
+		})
+	})
+}
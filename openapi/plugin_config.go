@@ -32,12 +32,16 @@ type PluginConfiguration struct {
 	// the former takes preference. This allows the user to override the url specified in the configuration file with
 	// the value provided in the OTF_VAR_<provider_name>_SWAGGER_URL
 	Configuration io.Reader
+	// providerBlock contains the plugin configuration set in the provider block of the terraform configuration files,
+	// which is used when the equivalent environment variables are not set
+	providerBlock providerBlockConfiguration
 }
 
 // NewPluginConfiguration creates a new PluginConfiguration
 func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
 	var configurationFile io.Reader
-	configurationFilePath, err := getPluginConfigurationPath(providerName)
+	providerBlock := getProviderBlockConfiguration(providerName)
+	configurationFilePath, err := getPluginConfigurationPath(providerName, providerBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -54,10 +58,11 @@ func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
 	return &PluginConfiguration{
 		ProviderName:  providerName,
 		Configuration: configurationFile,
+		providerBlock: providerBlock,
 	}, nil
 }
 
-func getPluginConfigurationPath(providerName string) (string, error) {
+func getPluginConfigurationPath(providerName string, providerBlock providerBlockConfiguration) (string, error) {
 	pluginConfigurationFileEnvVar := fmt.Sprintf(otfVarPluginConfigurationFile, providerName)
 	pluginConfigurationFileEnvVars := []string{pluginConfigurationFileEnvVar, strings.ToUpper(pluginConfigurationFileEnvVar)}
 	pluginConfigurationFile, err := terraformutils.MultiEnvDefaultString(pluginConfigurationFileEnvVars, "")
@@ -67,6 +72,13 @@ func getPluginConfigurationPath(providerName string) (string, error) {
 	if pluginConfigurationFile != "" {
 		return pluginConfigurationFile, nil
 	}
+	if err := providerBlock.checkConflictingValues(providerPropertyPluginConfigurationFile); err != nil {
+		return "", fmt.Errorf("%s, please set the same value in all of them OR export the %s env variable", err, pluginConfigurationFileEnvVar)
+	}
+	if providerBlock.pluginConfigurationFile != "" {
+		log.Printf("[INFO] %s set in the provider block with value %s", providerPropertyPluginConfigurationFile, providerBlock.pluginConfigurationFile)
+		return providerBlock.pluginConfigurationFile, nil
+	}
 
 	terraformUtils, err := terraformutils.NewTerraformUtils()
 	if err != nil {
@@ -94,9 +106,16 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 	if err != nil {
 		return nil, err
 	}
-	// Found OTF_VAR_%s_SWAGGER_URL env variable
 	if apiDiscoveryURL != "" {
 		log.Printf("[INFO] %s set with value %s", swaggerURLEnvVar, apiDiscoveryURL)
+	} else if err := p.providerBlock.checkConflictingValues(providerPropertySwaggerURL); err != nil {
+		return nil, fmt.Errorf("%s, please set the same value in all of them OR export the %s env variable", err, swaggerURLEnvVar)
+	} else if p.providerBlock.swaggerURL != "" {
+		log.Printf("[INFO] %s set in the provider block with value %s", providerPropertySwaggerURL, p.providerBlock.swaggerURL)
+		apiDiscoveryURL = p.providerBlock.swaggerURL
+	}
+	// Found OTF_VAR_%s_SWAGGER_URL env variable or swagger_url in the provider block
+	if apiDiscoveryURL != "" {
 		pluginConfigV1.Services = map[string]*ServiceConfigV1{}
 		pluginConfigV1.Services[p.ProviderName] = NewServiceConfigV1(apiDiscoveryURL, skipVerify, nil)
 		serviceConfig, err = pluginConfigV1.GetServiceConfig(p.ProviderName)
@@ -130,7 +149,10 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 	log.Printf("[DEBUG] serviceConfig = %+v", serviceConfig)
 
 	if serviceConfig == nil || serviceConfig.GetSwaggerURL() == "" {
-		return nil, fmt.Errorf("swagger url not provided, please export OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where '%s' service provider is exposing the swagger file OR create a plugin configuration file at ~/.terraform.d/plugins following the Plugin configuration schema specifications", p.ProviderName)
+		if len(p.providerBlock.nonLiteralAttributes) > 0 {
+			return nil, fmt.Errorf("swagger url not provided, the provider block properties %v are not literal strings and can not be resolved (variables and other expressions are not supported), please set them with literal strings OR export the OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where '%s' service provider is exposing the swagger file", p.providerBlock.nonLiteralAttributes, p.ProviderName)
+		}
+		return nil, fmt.Errorf("swagger url not provided, please export OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where '%s' service provider is exposing the swagger file OR create a plugin configuration file at ~/.terraform.d/plugins following the Plugin configuration schema specifications OR set the %s in the provider block of the root module", p.ProviderName, providerPropertySwaggerURL)
 	}
 
 	if err = serviceConfig.Validate(version.Version); err != nil {
@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// providerBlockConfiguration contains the plugin configuration resolved from the provider block in the terraform
+// configuration files. This allows configuring the plugin where the plugin configuration file and the environment
+// variables can not be shipped (e,g: remote runs where the only thing uploaded is the terraform configuration).
+//
+// Terraform needs the provider schema before it evaluates the configuration, hence the provider block is read straight
+// from the files with the following limitations:
+// - Only the configuration files (*.tf and *.tf.json) of the root module, that is the working directory terraform runs
+// the plugin in (the directory set with -chdir if used), are read. Provider blocks in child modules are ignored.
+// - Only literal strings are resolved. Attributes set with expressions (e,g: variables) are recorded in
+// nonLiteralAttributes so the user can be told why they are ignored.
+type providerBlockConfiguration struct {
+	swaggerURL              string
+	pluginConfigurationFile string
+	// found is true if there is at least one provider block with the provider name
+	found bool
+	// attributes contains the names of the attributes set in the provider blocks with the provider name, regardless of
+	// whether their values can be resolved
+	attributes map[string]bool
+	// nonLiteralAttributes contains the names of the attributes read from the provider block which values are not
+	// literal strings and therefore can not be resolved
+	nonLiteralAttributes []string
+	// conflictingValues contains the different values set for the swagger_url and plugin_configuration_file attributes
+	// in several provider blocks with the provider name (e,g: aliased provider blocks). The plugin configuration is shared
+	// by all the provider blocks so these attributes can not be resolved if the values differ
+	conflictingValues map[string][]string
+}
+
+// checkConflictingValues returns an error if the given attribute is set with different values in several provider
+// blocks with the provider name
+func (c providerBlockConfiguration) checkConflictingValues(name string) error {
+	if values, conflicting := c.conflictingValues[name]; conflicting {
+		return fmt.Errorf("the provider blocks (including the aliased ones) set the property '%s' with different values %q and the plugin configuration is shared by all of them", name, values)
+	}
+	return nil
+}
+
+// isAttributeSet returns true if the given attribute is set in any of the provider blocks with the provider name
+func (c providerBlockConfiguration) isAttributeSet(name string) bool {
+	return c.attributes[name]
+}
+
+// getProviderBlockConfiguration returns the plugin configuration set in the provider block of the terraform configuration
+// files in the working directory, which is the root module directory when terraform executes the plugin
+func getProviderBlockConfiguration(providerName string) providerBlockConfiguration {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		log.Printf("[WARN] provider block configuration not resolved, failed to get the working directory: %s", err)
+		return providerBlockConfiguration{}
+	}
+	return readProviderBlockConfiguration(workingDir, providerName)
+}
+
+// readProviderBlockConfiguration reads the provider block with the given provider name in the terraform configuration
+// files (*.tf and *.tf.json) in the given directory. Subdirectories (e,g: child modules) are not read and files that can
+// not be parsed are skipped.
+func readProviderBlockConfiguration(dir, providerName string) providerBlockConfiguration {
+	config := providerBlockConfiguration{attributes: map[string]bool{}}
+	files, err := getTerraformConfigurationFiles(dir)
+	if err != nil {
+		log.Printf("[WARN] provider block configuration not resolved, failed to list the terraform configuration files: %s", err)
+		return config
+	}
+	parser := hclparse.NewParser()
+	for _, fileName := range files {
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		if filepath.Ext(fileName) == ".json" {
+			file, diags = parser.ParseJSONFile(fileName)
+		} else {
+			file, diags = parser.ParseHCLFile(fileName)
+		}
+		if diags.HasErrors() {
+			log.Printf("[WARN] skipping terraform configuration file '%s' while resolving the provider block configuration: %s", fileName, diags.Error())
+			continue
+		}
+		content, _, _ := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"name"}}},
+		})
+		for _, block := range content.Blocks {
+			if block.Labels[0] != providerName {
+				continue
+			}
+			config.found = true
+			attributes, _ := block.Body.JustAttributes()
+			for name := range attributes {
+				config.attributes[name] = true
+			}
+			if value, ok := config.readLiteralAttribute(attributes, providerPropertySwaggerURL); ok {
+				config.setLiteralAttribute(providerPropertySwaggerURL, &config.swaggerURL, value)
+			}
+			if value, ok := config.readLiteralAttribute(attributes, providerPropertyPluginConfigurationFile); ok {
+				config.setLiteralAttribute(providerPropertyPluginConfigurationFile, &config.pluginConfigurationFile, value)
+			}
+		}
+	}
+	if !config.found {
+		log.Printf("[DEBUG] provider block '%s' not found in the terraform configuration files in '%s' (provider blocks in child modules are not read)", providerName, dir)
+	}
+	return config
+}
+
+// getTerraformConfigurationFiles returns the terraform configuration files (*.tf and *.tf.json) in the given directory
+func getTerraformConfigurationFiles(dir string) ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"*.tf", "*.tf.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// setLiteralAttribute sets the given attribute value read from a provider block. If the attribute was already set with a
+// different value in another provider block (e,g: aliased provider blocks) the values are recorded as conflicting
+func (c *providerBlockConfiguration) setLiteralAttribute(name string, attribute *string, value string) {
+	if *attribute == "" || *attribute == value {
+		*attribute = value
+		return
+	}
+	if c.conflictingValues == nil {
+		c.conflictingValues = map[string][]string{}
+	}
+	if _, conflicting := c.conflictingValues[name]; !conflicting {
+		c.conflictingValues[name] = []string{*attribute}
+	}
+	for _, conflictingValue := range c.conflictingValues[name] {
+		if conflictingValue == value {
+			return
+		}
+	}
+	c.conflictingValues[name] = append(c.conflictingValues[name], value)
+	log.Printf("[WARN] provider blocks set the property '%s' with different values %q", name, c.conflictingValues[name])
+}
+
+// readLiteralAttribute returns the value of the given attribute if it's set with a literal string. The attributes set
+// with other expressions (e,g: variables) are recorded as non literal attributes
+func (c *providerBlockConfiguration) readLiteralAttribute(attributes hcl.Attributes, name string) (string, bool) {
+	attribute, exists := attributes[name]
+	if !exists {
+		return "", false
+	}
+	var value string
+	if diags := gohcl.DecodeExpression(attribute.Expr, nil, &value); diags.HasErrors() {
+		log.Printf("[WARN] provider block attribute '%s' ignored, only literal strings are supported: %s", name, diags.Error())
+		c.nonLiteralAttributes = append(c.nonLiteralAttributes, name)
+		return "", false
+	}
+	return value, value != ""
+}
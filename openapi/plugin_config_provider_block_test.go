@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProviderBlockConfiguration(t *testing.T) {
+	testCases := []struct {
+		name           string
+		files          map[string]string
+		expectedConfig providerBlockConfiguration
+	}{
+		{
+			name: "provider block with literal values",
+			files: map[string]string{
+				"main.tf": `
+provider "test" {
+  swagger_url = "https://api.service.com/swagger.yaml"
+  plugin_configuration_file = "config/terraform-provider-openapi.yaml"
+  apikey_auth = var.apikey_auth
+  endpoints {
+    cdn_v1 = "cdn.service.com"
+  }
+}`,
+			},
+			expectedConfig: providerBlockConfiguration{
+				swaggerURL:              "https://api.service.com/swagger.yaml",
+				pluginConfigurationFile: "config/terraform-provider-openapi.yaml",
+				found:                   true,
+				attributes:              map[string]bool{"swagger_url": true, "plugin_configuration_file": true, "apikey_auth": true},
+			},
+		},
+		{
+			name: "provider block using variables is not resolved",
+			files: map[string]string{
+				"main.tf": `
+provider "test" {
+  swagger_url = var.swagger_url
+  plugin_configuration_file = "${path.module}/terraform-provider-openapi.yaml"
+}`,
+			},
+			expectedConfig: providerBlockConfiguration{
+				found:                true,
+				attributes:           map[string]bool{"swagger_url": true, "plugin_configuration_file": true},
+				nonLiteralAttributes: []string{"swagger_url", "plugin_configuration_file"},
+			},
+		},
+		{
+			name: "provider block in json configuration file",
+			files: map[string]string{
+				"main.tf.json": `{"provider": {"test": {"swagger_url": "https://api.service.com/swagger.yaml", "refresh_skip_window": "5m"}}}`,
+			},
+			expectedConfig: providerBlockConfiguration{
+				swaggerURL: "https://api.service.com/swagger.yaml",
+				found:      true,
+				attributes: map[string]bool{"swagger_url": true, "refresh_skip_window": true},
+			},
+		},
+		{
+			name: "provider block in a child module is not read",
+			files: map[string]string{
+				"main.tf":         `module "child" { source = "./child" }`,
+				"child/child.tf":  `provider "test" { swagger_url = "https://api.service.com/swagger.yaml" }`,
+				"child/README.md": `child module`,
+			},
+			expectedConfig: providerBlockConfiguration{attributes: map[string]bool{}},
+		},
+		{
+			name: "provider block of a different provider and files that can not be parsed",
+			files: map[string]string{
+				"main.tf":    `provider "other" { swagger_url = "https://other.com/swagger.yaml" }`,
+				"invalid.tf": `provider "test" { swagger_url = `,
+				"notes.txt":  `provider "test" { swagger_url = "https://api.service.com/swagger.yaml" }`,
+			},
+			expectedConfig: providerBlockConfiguration{attributes: map[string]bool{}},
+		},
+		{
+			name: "aliased provider blocks with different values",
+			files: map[string]string{
+				"main.tf": `
+provider "test" {
+  swagger_url = "https://api.service.com/swagger.yaml"
+  plugin_configuration_file = "config/terraform-provider-openapi.yaml"
+}
+provider "test" {
+  alias = "eu"
+  swagger_url = "https://eu.api.service.com/swagger.yaml"
+  plugin_configuration_file = "config/terraform-provider-openapi.yaml"
+}`,
+			},
+			expectedConfig: providerBlockConfiguration{
+				swaggerURL:              "https://api.service.com/swagger.yaml",
+				pluginConfigurationFile: "config/terraform-provider-openapi.yaml",
+				found:                   true,
+				attributes:              map[string]bool{"alias": true, "swagger_url": true, "plugin_configuration_file": true},
+				conflictingValues:       map[string][]string{"swagger_url": {"https://api.service.com/swagger.yaml", "https://eu.api.service.com/swagger.yaml"}},
+			},
+		},
+		{
+			name:           "no terraform configuration files",
+			files:          map[string]string{},
+			expectedConfig: providerBlockConfiguration{attributes: map[string]bool{}},
+		},
+	}
+	for _, tc := range testCases {
+		dir, err := ioutil.TempDir("", "provider_block")
+		require.NoError(t, err, tc.name)
+		for fileName, content := range tc.files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, fileName)), 0700), tc.name)
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, fileName), []byte(content), 0600), tc.name)
+		}
+		assert.Equal(t, tc.expectedConfig, readProviderBlockConfiguration(dir, "test"), tc.name)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestGetProviderBlockConfiguration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "provider_block")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`provider "test" { swagger_url = "https://api.service.com/swagger.yaml" }`), 0600))
+
+	// terraform runs the plugin in the root module directory (the one set with -chdir if used)
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(workingDir)
+
+	assert.Equal(t, "https://api.service.com/swagger.yaml", getProviderBlockConfiguration("test").swaggerURL)
+}
+
+func TestGetServiceConfigurationFromProviderBlockNonLiteralSwaggerURL(t *testing.T) {
+	pluginConfiguration := PluginConfiguration{
+		ProviderName:  "test",
+		providerBlock: providerBlockConfiguration{found: true, nonLiteralAttributes: []string{"swagger_url"}},
+	}
+	_, err := pluginConfiguration.getServiceConfiguration()
+	assert.EqualError(t, err, "swagger url not provided, the provider block properties [swagger_url] are not literal strings and can not be resolved (variables and other expressions are not supported), please set them with literal strings OR export the OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where 'test' service provider is exposing the swagger file")
+}
+
+func TestGetServiceConfigurationFromAliasedProviderBlocksWithDifferentValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "provider_block")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`
+provider "test" {
+  swagger_url = "https://api.service.com/swagger.yaml"
+  plugin_configuration_file = "config/terraform-provider-openapi.yaml"
+}
+provider "test" {
+  alias = "eu"
+  swagger_url = "https://eu.api.service.com/swagger.yaml"
+  plugin_configuration_file = "config/eu/terraform-provider-openapi.yaml"
+}`), 0600))
+	providerBlock := readProviderBlockConfiguration(dir, "test")
+
+	pluginConfiguration := PluginConfiguration{ProviderName: "test", providerBlock: providerBlock}
+	_, err = pluginConfiguration.getServiceConfiguration()
+	assert.EqualError(t, err, `the provider blocks (including the aliased ones) set the property 'swagger_url' with different values ["https://api.service.com/swagger.yaml" "https://eu.api.service.com/swagger.yaml"] and the plugin configuration is shared by all of them, please set the same value in all of them OR export the OTF_VAR_test_SWAGGER_URL env variable`)
+
+	_, err = getPluginConfigurationPath("test", providerBlock)
+	assert.EqualError(t, err, `the provider blocks (including the aliased ones) set the property 'plugin_configuration_file' with different values ["config/terraform-provider-openapi.yaml" "config/eu/terraform-provider-openapi.yaml"] and the plugin configuration is shared by all of them, please set the same value in all of them OR export the OTF_VAR_test_PLUGIN_CONFIGURATION_FILE env variable`)
+}
+
+func TestGetServiceConfigurationFromProviderBlock(t *testing.T) {
+	pluginConfiguration := PluginConfiguration{
+		ProviderName:  "test",
+		providerBlock: providerBlockConfiguration{swaggerURL: "https://api.service.com/swagger.yaml"},
+	}
+	serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.service.com/swagger.yaml", serviceConfiguration.GetSwaggerURL())
+
+	pluginConfigurationFile, err := getPluginConfigurationPath("test", providerBlockConfiguration{pluginConfigurationFile: "config/terraform-provider-openapi.yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, "config/terraform-provider-openapi.yaml", pluginConfigurationFile)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/asaskevich/govalidator"
 	"log"
 	"os"
+	"time"
 )
 
 // ServiceConfiguration defines the interface/expected behaviour for ServiceConfiguration implementations.
@@ -23,6 +24,26 @@ type ServiceConfiguration interface {
 
 	// GetTelemetryConfiguration returns the telemetry configuration for this service provider
 	GetTelemetryConfiguration() TelemetryProvider
+
+	// GetMaxBodySize returns the max size (in bytes) allowed for the request and response bodies exchanged with the API. Zero
+	// or negative values mean there is no limit
+	GetMaxBodySize() int64
+
+	// GetSpecRevalidationInterval returns how often the OpenAPI document is re-validated while the plugin process is
+	// running to warn about upstream changes. Zero means the OpenAPI document is not re-validated
+	GetSpecRevalidationInterval() time.Duration
+
+	// GetSpecVersionConstraint returns the version constraint (e,g: >= 1.2.0, < 2.0.0) the OpenAPI document API version
+	// (info.version) must meet. Empty means the version is not checked
+	GetSpecVersionConstraint() string
+
+	// GetSpecVersionCheck returns what happens when the OpenAPI document API version does not meet the version
+	// constraint: error (default) makes the provider configuration fail and warn logs a warning
+	GetSpecVersionCheck() string
+
+	// GetSpecCacheTTL returns how long the OpenAPI document fetched from a remote URL is cached and reused by the plugin
+	// processes started afterwards. Zero means the OpenAPI document is not cached
+	GetSpecCacheTTL() time.Duration
 }
 
 // TelemetryConfig contains the configuration for the telemetry
@@ -46,6 +67,28 @@ type ServiceConfigV1 struct {
 	SchemaConfigurationV1 []ServiceSchemaPropertyConfigurationV1 `yaml:"schema_configuration,omitempty"`
 
 	TelemetryConfig *TelemetryConfig `yaml:"telemetry,omitempty"`
+
+	// MaxBodySize defines the max size (in bytes) allowed for the request and response bodies exchanged with the API. If not
+	// set (or not positive) there is no limit
+	MaxBodySize int64 `yaml:"max_body_size,omitempty"`
+
+	// SpecRevalidationInterval defines how often (e,g: 1h) the OpenAPI document is fetched and re-validated while the plugin
+	// process is running, warning when the document has materially changed since the process started. If not set the
+	// OpenAPI document is not re-validated
+	SpecRevalidationInterval string `yaml:"spec_revalidation_interval,omitempty"`
+
+	// SpecVersionConstraint defines the range of OpenAPI document API versions (info.version) the provider is expected to
+	// work with (e,g: >= 1.2.0, < 2.0.0). If not set the version is not checked
+	SpecVersionConstraint string `yaml:"spec_version_constraint,omitempty"`
+
+	// SpecVersionCheck defines what happens when the OpenAPI document API version does not meet the SpecVersionConstraint.
+	// Supported values are error (default), which makes the provider configuration fail, and warn, which logs a warning
+	SpecVersionCheck string `yaml:"spec_version_check,omitempty"`
+
+	// SpecCacheTTL defines how long (e,g: 5m) the OpenAPI document fetched from a remote URL is cached on disk and reused
+	// by the plugin processes started afterwards (e,g: editor integrations requesting the provider schema repeatedly)
+	// instead of fetching the document again. If not set the default (30s) applies, and 0 disables the cache
+	SpecCacheTTL string `yaml:"spec_cache_ttl,omitempty"`
 }
 
 // NewServiceConfigV1 creates a new instance of NewServiceConfigV1 struct with the values provided
@@ -74,6 +117,53 @@ func (s *ServiceConfigV1) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
+// GetMaxBodySize returns the max size (in bytes) allowed for the request and response bodies exchanged with the API
+func (s *ServiceConfigV1) GetMaxBodySize() int64 {
+	return s.MaxBodySize
+}
+
+// GetSpecRevalidationInterval returns how often the OpenAPI document is re-validated while the plugin process is running.
+// Zero is returned if the interval is not configured or is not valid
+func (s *ServiceConfigV1) GetSpecRevalidationInterval() time.Duration {
+	if s.SpecRevalidationInterval == "" {
+		return 0
+	}
+	interval, err := time.ParseDuration(s.SpecRevalidationInterval)
+	if err != nil || interval < 0 {
+		log.Printf("[WARN] ignoring invalid spec_revalidation_interval '%s'", s.SpecRevalidationInterval)
+		return 0
+	}
+	return interval
+}
+
+// GetSpecVersionConstraint returns the version constraint the OpenAPI document API version must meet
+func (s *ServiceConfigV1) GetSpecVersionConstraint() string {
+	return s.SpecVersionConstraint
+}
+
+// GetSpecVersionCheck returns what happens when the OpenAPI document API version does not meet the version constraint.
+// The default is error
+func (s *ServiceConfigV1) GetSpecVersionCheck() string {
+	if s.SpecVersionCheck == "" {
+		return specVersionCheckError
+	}
+	return s.SpecVersionCheck
+}
+
+// GetSpecCacheTTL returns how long the OpenAPI document fetched from a remote URL is cached. The default ttl is returned
+// if the ttl is not configured or is not valid
+func (s *ServiceConfigV1) GetSpecCacheTTL() time.Duration {
+	if s.SpecCacheTTL == "" {
+		return defaultSpecCacheTTL
+	}
+	ttl, err := time.ParseDuration(s.SpecCacheTTL)
+	if err != nil || ttl < 0 {
+		log.Printf("[WARN] ignoring invalid spec_cache_ttl '%s'", s.SpecCacheTTL)
+		return defaultSpecCacheTTL
+	}
+	return ttl
+}
+
 // GetTelemetryConfiguration returns a TelemetryProvider configured for Graphite or HTTPEndpoint
 func (s *ServiceConfigV1) GetTelemetryConfiguration() TelemetryProvider {
 	if s.TelemetryConfig != nil {
@@ -126,6 +216,24 @@ func (s *ServiceConfigV1) Validate(runningPluginVersion string) error {
 			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", s.SwaggerURL)
 		}
 	}
+	if s.SpecRevalidationInterval != "" {
+		if interval, err := time.ParseDuration(s.SpecRevalidationInterval); err != nil || interval <= 0 {
+			return fmt.Errorf("service spec_revalidation_interval configuration not valid ('%s'). The value must be a positive duration (e,g: 30m or 1h)", s.SpecRevalidationInterval)
+		}
+	}
+	if s.SpecCacheTTL != "" {
+		if ttl, err := time.ParseDuration(s.SpecCacheTTL); err != nil || ttl < 0 {
+			return fmt.Errorf("service spec_cache_ttl configuration not valid ('%s'). The value must be a duration (e,g: 30s or 5m), 0 disables the cache", s.SpecCacheTTL)
+		}
+	}
+	if s.SpecVersionConstraint != "" {
+		if _, err := newSpecVersionConstraint(s.SpecVersionConstraint); err != nil {
+			return fmt.Errorf("service spec_version_constraint configuration not valid: %s", err)
+		}
+	}
+	if s.SpecVersionCheck != "" && s.SpecVersionCheck != specVersionCheckError && s.SpecVersionCheck != specVersionCheckWarn {
+		return fmt.Errorf("service spec_version_check configuration not valid ('%s'). Supported values are: %s and %s", s.SpecVersionCheck, specVersionCheckError, specVersionCheckWarn)
+	}
 	if s.PluginVersion != "" {
 		if s.PluginVersion != runningPluginVersion {
 			return fmt.Errorf("plugin version '%s' in the plugin configuration file does not match the version of the OpenAPI plugin that is running '%s'", s.PluginVersion, runningPluginVersion)
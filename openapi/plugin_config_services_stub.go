@@ -1,5 +1,7 @@
 package openapi
 
+import "time"
+
 // ServiceConfigStub implements the ServiceConfiguration interface and can be used to simplify the creation of the ProviderOpenAPI
 // provider by calling the CreateSchemaProviderWithConfiguration function passing in the stub wit the swagger URL populated
 // with the URL where the openapi doc is hosted.
@@ -9,7 +11,16 @@ type ServiceConfigStub struct {
 	InsecureSkipVerify  bool
 	Telemetry           TelemetryProvider
 	SchemaConfiguration []*ServiceSchemaPropertyConfigurationStub
-	Err                 error
+	MaxBodySize         int64
+	// SpecRevalidationInterval is returned by GetSpecRevalidationInterval
+	SpecRevalidationInterval time.Duration
+	// SpecVersionConstraint is returned by GetSpecVersionConstraint
+	SpecVersionConstraint string
+	// SpecVersionCheck is returned by GetSpecVersionCheck
+	SpecVersionCheck string
+	// SpecCacheTTL is returned by GetSpecCacheTTL
+	SpecCacheTTL time.Duration
+	Err          error
 }
 
 // ServiceSchemaPropertyConfigurationStub implements the ServiceSchemaPropertyConfiguration and can be used to simplify
@@ -57,6 +68,31 @@ func (s ServiceConfigStub) GetTelemetryConfiguration() TelemetryProvider {
 	return s.Telemetry
 }
 
+// GetMaxBodySize returns the max body size configured in the ServiceConfigStub.MaxBodySize field
+func (s ServiceConfigStub) GetMaxBodySize() int64 {
+	return s.MaxBodySize
+}
+
+// GetSpecRevalidationInterval returns the interval configured in the ServiceConfigStub.SpecRevalidationInterval field
+func (s ServiceConfigStub) GetSpecRevalidationInterval() time.Duration {
+	return s.SpecRevalidationInterval
+}
+
+// GetSpecVersionConstraint returns the version constraint configured in the ServiceConfigStub.SpecVersionConstraint field
+func (s ServiceConfigStub) GetSpecVersionConstraint() string {
+	return s.SpecVersionConstraint
+}
+
+// GetSpecVersionCheck returns the version check configured in the ServiceConfigStub.SpecVersionCheck field
+func (s ServiceConfigStub) GetSpecVersionCheck() string {
+	return s.SpecVersionCheck
+}
+
+// GetSpecCacheTTL returns the ttl configured in the ServiceConfigStub.SpecCacheTTL field
+func (s ServiceConfigStub) GetSpecCacheTTL() time.Duration {
+	return s.SpecCacheTTL
+}
+
 // GetDefaultValue returns the default value configured in the ServiceSchemaPropertyConfigurationStub.defaultValue field
 func (s *ServiceSchemaPropertyConfigurationStub) GetDefaultValue() (string, error) {
 	if s.GetDefaultValueFunc != nil {
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestNewServiceConfigV1(t *testing.T) {
@@ -178,6 +179,99 @@ func TestServiceConfigV1Validate(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a ServiceConfigV1 containing an invalid spec revalidation interval", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:               "http://sevice-api.com/swagger.yaml",
+			SpecRevalidationInterval: "every hour",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service spec_revalidation_interval configuration not valid ('every hour'). The value must be a positive duration (e,g: 30m or 1h)")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing an invalid spec cache ttl", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:   "http://sevice-api.com/swagger.yaml",
+			SpecCacheTTL: "-5m",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service spec_cache_ttl configuration not valid ('-5m'). The value must be a duration (e,g: 30s or 5m), 0 disables the cache")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing an invalid spec version constraint", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:            "http://sevice-api.com/swagger.yaml",
+			SpecVersionConstraint: ">= 1.x",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service spec_version_constraint configuration not valid: version constraint '>= 1.x' not valid: version '1.x' not valid")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing an invalid spec version check", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:            "http://sevice-api.com/swagger.yaml",
+			SpecVersionConstraint: ">= 1.2.0, < 2.0.0",
+			SpecVersionCheck:      "fail",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service spec_version_check configuration not valid ('fail'). Supported values are: error and warn")
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetSpecRevalidationInterval(t *testing.T) {
+	testCases := []struct {
+		name             string
+		interval         string
+		expectedInterval time.Duration
+	}{
+		{name: "interval not configured", interval: "", expectedInterval: 0},
+		{name: "valid interval", interval: "30m", expectedInterval: 30 * time.Minute},
+		{name: "invalid interval", interval: "every hour", expectedInterval: 0},
+		{name: "negative interval", interval: "-1h", expectedInterval: 0},
+	}
+	for _, tc := range testCases {
+		serviceConfiguration := &ServiceConfigV1{SpecRevalidationInterval: tc.interval}
+		assert.Equal(t, tc.expectedInterval, serviceConfiguration.GetSpecRevalidationInterval(), tc.name)
+	}
+}
+
+func TestServiceConfigV1GetSpecCacheTTL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ttl         string
+		expectedTTL time.Duration
+	}{
+		{name: "ttl not configured", ttl: "", expectedTTL: defaultSpecCacheTTL},
+		{name: "valid ttl", ttl: "5m", expectedTTL: 5 * time.Minute},
+		{name: "cache disabled", ttl: "0", expectedTTL: 0},
+		{name: "invalid ttl", ttl: "five minutes", expectedTTL: defaultSpecCacheTTL},
+		{name: "negative ttl", ttl: "-1m", expectedTTL: defaultSpecCacheTTL},
+	}
+	for _, tc := range testCases {
+		serviceConfiguration := &ServiceConfigV1{SpecCacheTTL: tc.ttl}
+		assert.Equal(t, tc.expectedTTL, serviceConfiguration.GetSpecCacheTTL(), tc.name)
+	}
+}
+
+func TestServiceConfigV1GetSpecVersionCheck(t *testing.T) {
+	assert.Equal(t, specVersionCheckError, (&ServiceConfigV1{}).GetSpecVersionCheck())
+	assert.Equal(t, specVersionCheckWarn, (&ServiceConfigV1{SpecVersionCheck: specVersionCheckWarn}).GetSpecVersionCheck())
 }
 
 func TestGetTelemetryConfiguration(t *testing.T) {
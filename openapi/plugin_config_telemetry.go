@@ -1,6 +1,12 @@
 package openapi
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
 
 // TelemetryProviderConfiguration defines the struct type that specific telemetry providers can configure based on the
 // resource data received in GetTelemetryProviderConfiguration. The struct serves as a way to document in the metric
@@ -24,6 +30,16 @@ const (
 	TelemetryResourceOperationImport TelemetryResourceOperation = "import"
 )
 
+// TelemetryAPICallEvent defines the events observed by the provider client when performing the API calls for a resource
+type TelemetryAPICallEvent string
+
+const (
+	// TelemetryAPICallEventRetry represents an API call being retried after the API responded with a retryable error
+	TelemetryAPICallEventRetry TelemetryAPICallEvent = "retry"
+	// TelemetryAPICallEventRateLimited represents the API responding with 429 Too Many Requests
+	TelemetryAPICallEventRateLimited TelemetryAPICallEvent = "rate_limited"
+)
+
 // TelemetryProvider holds the behaviour expected to be implemented for the Telemetry Providers supported. At the moment
 // only Graphite is supported.
 type TelemetryProvider interface {
@@ -34,6 +50,60 @@ type TelemetryProvider interface {
 	// IncServiceProviderResourceTotalRunsCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase for service provider used along
 	// with tags for provider name, resource name, and Terraform operation
 	IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error
+	// IncServiceProviderAPICallEventsCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase for the events observed
+	// when calling the API (e,g: retries, rate limited responses) along with tags for provider name, resource name, HTTP method, operationId (if
+	// the operation has one) and event
+	IncServiceProviderAPICallEventsCounter(providerName, resourceName, httpMethod, operationID string, event TelemetryAPICallEvent, telemetryProviderConfiguration TelemetryProviderConfiguration) error
 	// GetTelemetryProviderConfiguration is the method responsible for getting a specific telemetry provider config given the input data provided
 	GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration
 }
+
+// telemetryResourceFilter is implemented by the telemetry providers that support excluding the metrics of some resources
+type telemetryResourceFilter interface {
+	isResourceExcluded(resourceName string) bool
+}
+
+// TelemetryProviderOptions defines the configuration common to all the telemetry providers
+type TelemetryProviderOptions struct {
+	// Labels defines static tags (label:value) added to all the metrics submitted (e,g: team or environment)
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// ExcludedResources defines the names of the resources (e,g: cdn_v1) which metrics are not submitted, which is handy
+	// when the resource names encode identifiers that must not end up in the metrics backends. The metrics of the data
+	// sources of the resources are not submitted either
+	ExcludedResources []string `yaml:"excluded_resources,omitempty"`
+}
+
+// isResourceExcluded returns true if the given resource name (as submitted in the metrics) belongs to one of the
+// excluded resources or their data sources (data_<resource_name>, data_<resource_name>_instance and data_<resource_name>_ids)
+func (o TelemetryProviderOptions) isResourceExcluded(resourceName string) bool {
+	name := strings.TrimPrefix(resourceName, "data_")
+	for _, excludedResource := range o.ExcludedResources {
+		if resourceName == excludedResource || name == excludedResource || name == excludedResource+"_instance" || name == excludedResource+"_ids" || name == excludedResource+"_list" {
+			return true
+		}
+	}
+	return false
+}
+
+// appendLabels returns the given tags along with the configured labels, sorted by label name
+func (o TelemetryProviderOptions) appendLabels(tags []string) []string {
+	labels := make([]string, 0, len(o.Labels))
+	for label := range o.Labels {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		tags = append(tags, fmt.Sprintf("%s:%s", label, o.Labels[label]))
+	}
+	return tags
+}
+
+// getAPICallEventTags returns the tags of the api_call_events metric. The operation_id tag is only included if the
+// operation the API call was made for has an operationId
+func getAPICallEventTags(providerName, resourceName, httpMethod, operationID string, event TelemetryAPICallEvent) []string {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, "http_method:" + httpMethod}
+	if operationID != "" {
+		tags = append(tags, "operation_id:"+operationID)
+	}
+	return append(tags, fmt.Sprintf("event:%s", event))
+}
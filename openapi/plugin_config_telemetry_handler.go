@@ -17,6 +17,9 @@ type TelemetryHandler interface {
 	SubmitPluginExecutionMetrics()
 	// SubmitResourceExecutionMetrics submits the metrics related to resource operation execution
 	SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation)
+	// SubmitAPICallEventMetrics submits the metrics related to the events observed when calling the API for a resource
+	// (e,g: retries, rate limited responses). The operationID is empty if the operation has no operationId
+	SubmitAPICallEventMetrics(resourceName, httpMethod, operationID string, event TelemetryAPICallEvent)
 }
 
 const telemetryTimeout = 2
@@ -48,12 +51,38 @@ func (t telemetryHandlerTimeoutSupport) SubmitResourceExecutionMetrics(resourceN
 		log.Println("[INFO] Telemetry provider not configured")
 		return
 	}
+	if t.isResourceExcluded(resourceName) {
+		return
+	}
 	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
 	t.submitMetric("IncServiceProviderResourceTotalRunsCounter", func() error {
 		return t.telemetryProvider.IncServiceProviderResourceTotalRunsCounter(t.providerName, resourceName, tfOperation, telemetryConfig)
 	})
 }
 
+func (t telemetryHandlerTimeoutSupport) SubmitAPICallEventMetrics(resourceName, httpMethod, operationID string, event TelemetryAPICallEvent) {
+	if t.telemetryProvider == nil {
+		log.Println("[INFO] Telemetry provider not configured")
+		return
+	}
+	if t.isResourceExcluded(resourceName) {
+		return
+	}
+	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
+	t.submitMetric("IncServiceProviderAPICallEventsCounter", func() error {
+		return t.telemetryProvider.IncServiceProviderAPICallEventsCounter(t.providerName, resourceName, httpMethod, operationID, event, telemetryConfig)
+	})
+}
+
+// isResourceExcluded returns true if the telemetry provider is configured to exclude the metrics of the given resource
+func (t telemetryHandlerTimeoutSupport) isResourceExcluded(resourceName string) bool {
+	if filter, ok := t.telemetryProvider.(telemetryResourceFilter); ok && filter.isResourceExcluded(resourceName) {
+		log.Printf("[DEBUG] skipping the metrics of the resource excluded from telemetry")
+		return true
+	}
+	return false
+}
+
 func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSubmitter MetricSubmitter) {
 	doneChan := make(chan error)
 	go func() {
@@ -3,6 +3,7 @@ package openapi
 type telemetryHandlerStub struct {
 	submitPluginExecutionMetricsFunc   func()
 	submitResourceExecutionMetricsFunc func(resourceName string, tfOperation TelemetryResourceOperation)
+	submitAPICallEventMetricsFunc      func(resourceName, httpMethod, operationID string, event TelemetryAPICallEvent)
 }
 
 func (t *telemetryHandlerStub) SubmitPluginExecutionMetrics() {
@@ -12,3 +13,7 @@ func (t *telemetryHandlerStub) SubmitPluginExecutionMetrics() {
 func (t *telemetryHandlerStub) SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation) {
 	t.submitResourceExecutionMetricsFunc(resourceName, tfOperation)
 }
+
+func (t *telemetryHandlerStub) SubmitAPICallEventMetrics(resourceName, httpMethod, operationID string, event TelemetryAPICallEvent) {
+	t.submitAPICallEventMetricsFunc(resourceName, httpMethod, operationID, event)
+}
@@ -52,6 +52,23 @@ func TestSubmitResourceExecutionMetrics(t *testing.T) {
 	assert.Equal(t, expectedTfOperation, stub.tfOperationReceived)
 }
 
+func TestSubmitResourceExecutionMetrics_ExcludedResource(t *testing.T) {
+	stub := &telemetryProviderStub{TelemetryProviderOptions: TelemetryProviderOptions{ExcludedResources: []string{"resourceName"}}}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:      "providerName",
+		timeout:           1,
+		openAPIVersion:    "0.25.0",
+		telemetryProvider: stub,
+	}
+	ths.SubmitResourceExecutionMetrics("resourceName", TelemetryResourceOperationCreate)
+	ths.SubmitResourceExecutionMetrics("data_resourceName_instance", TelemetryResourceOperationRead)
+	ths.SubmitAPICallEventMetrics("resourceName", "POST", "", TelemetryAPICallEventRetry)
+	// The below confirm that the metrics of the excluded resource were not submitted
+	assert.Empty(t, stub.resourceNameReceived)
+	ths.SubmitResourceExecutionMetrics("otherResourceName", TelemetryResourceOperationCreate)
+	assert.Equal(t, "otherResourceName", stub.resourceNameReceived)
+}
+
 func TestSubmitResourceExecutionMetrics_FailsNilTelemetryProvider(t *testing.T) {
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
@@ -65,6 +82,36 @@ func TestSubmitResourceExecutionMetrics_FailsNilTelemetryProvider(t *testing.T)
 	assert.Contains(t, buf.String(), "[INFO] Telemetry provider not configured")
 }
 
+func TestSubmitAPICallEventMetrics(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:      "providerName",
+		timeout:           1,
+		openAPIVersion:    "0.25.0",
+		telemetryProvider: stub,
+	}
+	ths.SubmitAPICallEventMetrics("resourceName", "POST", "createResource", TelemetryAPICallEventRateLimited)
+	// The below confirm that the corresponding inc methods were called and also the info passed in was the correct one
+	assert.Equal(t, ths.providerName, stub.providerNameReceived)
+	assert.Equal(t, "resourceName", stub.resourceNameReceived)
+	assert.Equal(t, "POST", stub.httpMethodReceived)
+	assert.Equal(t, "createResource", stub.operationIDReceived)
+	assert.Equal(t, TelemetryAPICallEventRateLimited, stub.apiCallEventReceived)
+}
+
+func TestSubmitAPICallEventMetrics_FailsNilTelemetryProvider(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:      "providerName",
+		timeout:           1,
+		openAPIVersion:    "0.25.0",
+		telemetryProvider: nil,
+	}
+	ths.SubmitAPICallEventMetrics("resourceName", "POST", "", TelemetryAPICallEventRetry)
+	assert.Contains(t, buf.String(), "[INFO] Telemetry provider not configured")
+}
+
 func TestSubmitMetric(t *testing.T) {
 	testCases := []struct {
 		name                 string
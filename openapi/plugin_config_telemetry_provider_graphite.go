@@ -18,6 +18,8 @@ type TelemetryProviderGraphite struct {
 	Port int `yaml:"port"`
 	// Prefix enables to append a prefix to the metrics pushed to graphite
 	Prefix string `yaml:"prefix,omitempty"`
+	// TelemetryProviderOptions defines the labels added to the metrics and the resources excluded from the metrics
+	TelemetryProviderOptions `yaml:",inline"`
 }
 
 // Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider registration. If this
@@ -37,7 +39,7 @@ func (g TelemetryProviderGraphite) Validate() error {
 // a tag containing the 'openapi_plugin_version' used.
 func (g TelemetryProviderGraphite) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
 	version := strings.Replace(openAPIPluginVersion, ".", "_", -1)
-	tags := []string{"openapi_plugin_version:" + version}
+	tags := g.appendLabels([]string{"openapi_plugin_version:" + version})
 	metricName := "terraform.openapi_plugin_version.total_runs"
 
 	log.Printf("[INFO] graphite metric to be submitted: %s", metricName)
@@ -51,7 +53,7 @@ func (g TelemetryProviderGraphite) IncOpenAPIPluginVersionTotalRunsCounter(openA
 // IncServiceProviderResourceTotalRunsCounter will increment the counter for a given provider 'statsd.<prefix>.terraform.provider' metric
 // to 1 and appends tags containing the 'provider_name', 'resource_name', and 'terraform_operation' called
 func (g TelemetryProviderGraphite) IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
-	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)}
+	tags := g.appendLabels([]string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)})
 	metricName := "terraform.provider"
 	log.Printf("[INFO] graphite metric to be submitted: %s", metricName)
 	if err := g.submitMetric("terraform.provider", tags); err != nil {
@@ -61,6 +63,20 @@ func (g TelemetryProviderGraphite) IncServiceProviderResourceTotalRunsCounter(pr
 	return nil
 }
 
+// IncServiceProviderAPICallEventsCounter will increment the counter for a given provider 'statsd.<prefix>.terraform.provider.api_call_events'
+// metric to 1 and appends tags containing the 'provider_name', 'resource_name', 'http_method', 'operation_id' (if the
+// operation has an operationId) and 'event' observed
+func (g TelemetryProviderGraphite) IncServiceProviderAPICallEventsCounter(providerName, resourceName, httpMethod, operationID string, event TelemetryAPICallEvent, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := g.appendLabels(getAPICallEventTags(providerName, resourceName, httpMethod, operationID, event))
+	metricName := "terraform.provider.api_call_events"
+	log.Printf("[INFO] graphite metric to be submitted: %s", metricName)
+	if err := g.submitMetric(metricName, tags); err != nil {
+		return err
+	}
+	log.Printf("[INFO] graphite metric successfully submitted: %s (tags: %s)", metricName, tags)
+	return nil
+}
+
 // GetTelemetryProviderConfiguration returns nil since Graphite does not need any TelemetryProviderConfiguration at the moment
 func (g TelemetryProviderGraphite) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
 	return nil
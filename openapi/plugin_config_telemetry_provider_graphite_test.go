@@ -122,6 +122,30 @@ func TestTelemetryProviderGraphite_IncServiceProviderResourceTotalRunsCounter_Ba
 	})
 }
 
+func TestTelemetryProviderGraphite_IncServiceProviderAPICallEventsCounter(t *testing.T) {
+	providerName := "myProviderName"
+	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.provider.api_call_events"
+	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.provider.api_call_events (tags: [provider_name:myProviderName resource_name:cdn_v1 http_method:POST event:rate_limited])"
+	expectedMetric := "myPrefixName.terraform.provider.api_call_events:1|c|#provider_name:myProviderName,resource_name:cdn_v1,http_method:POST,event:rate_limited"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	telemetryPortInt, err := strconv.Atoi(telemetryPort)
+	tpg := TelemetryProviderGraphite{
+		Host:   telemetryHost,
+		Port:   telemetryPortInt,
+		Prefix: "myPrefixName",
+	}
+	err = tpg.IncServiceProviderAPICallEventsCounter(providerName, "cdn_v1", "POST", "", TelemetryAPICallEventRateLimited, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
 func TestTelemetryProviderGraphite_GetTelemetryProviderConfiguration(t *testing.T) {
 	Convey("Given a TelemetryProviderGraphite", t, func() {
 		tpg := TelemetryProviderGraphite{}
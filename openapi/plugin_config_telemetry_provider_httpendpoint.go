@@ -20,6 +20,8 @@ type TelemetryProviderHTTPEndpoint struct {
 	URL string `yaml:"url"`
 	// Prefix enables to append a prefix to the metrics pushed to the HTTP endpoint
 	Prefix string `yaml:"prefix,omitempty"`
+	// TelemetryProviderOptions defines the labels added to the metrics and the resources excluded from the metrics
+	TelemetryProviderOptions `yaml:",inline"`
 	// ProviderSchemaProperties defines what specific provider configuration properties and their values that will be injected into
 	// metric API request headers. Values must match a real property name in provider schema configuration.
 	ProviderSchemaProperties []string `yaml:"provider_schema_properties,omitempty"`
@@ -67,7 +69,7 @@ func (g TelemetryProviderHTTPEndpoint) Validate() error {
 // any other tag present in the TelemetryProviderConfiguration.
 func (g TelemetryProviderHTTPEndpoint) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
 	version := strings.Replace(openAPIPluginVersion, ".", "_", -1)
-	tags := []string{"openapi_plugin_version:" + version}
+	tags := g.appendLabels([]string{"openapi_plugin_version:" + version})
 	metricName := "terraform.openapi_plugin_version.total_runs"
 	metric := createNewCounterMetric(g.Prefix, metricName, tags)
 	if err := g.submitMetric(metric, telemetryProviderConfiguration); err != nil {
@@ -79,7 +81,7 @@ func (g TelemetryProviderHTTPEndpoint) IncOpenAPIPluginVersionTotalRunsCounter(o
 // IncServiceProviderResourceTotalRunsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.provider'.
 // In addition, it will send tags with the provider name, resource name, and terrraform operation called.
 func (g TelemetryProviderHTTPEndpoint) IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
-	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)}
+	tags := g.appendLabels([]string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)})
 	metricName := "terraform.provider"
 	metric := createNewCounterMetric(g.Prefix, metricName, tags)
 	if err := g.submitMetric(metric, telemetryProviderConfiguration); err != nil {
@@ -88,6 +90,19 @@ func (g TelemetryProviderHTTPEndpoint) IncServiceProviderResourceTotalRunsCounte
 	return nil
 }
 
+// IncServiceProviderAPICallEventsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.provider.api_call_events'.
+// In addition, it will send tags with the provider name, resource name, HTTP method, operationId (if the operation has one)
+// and event observed.
+func (g TelemetryProviderHTTPEndpoint) IncServiceProviderAPICallEventsCounter(providerName, resourceName, httpMethod, operationID string, event TelemetryAPICallEvent, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := g.appendLabels(getAPICallEventTags(providerName, resourceName, httpMethod, operationID, event))
+	metricName := "terraform.provider.api_call_events"
+	metric := createNewCounterMetric(g.Prefix, metricName, tags)
+	if err := g.submitMetric(metric, telemetryProviderConfiguration); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetTelemetryProviderConfiguration returns a telemetryProviderConfigurationHTTPEndpoint loaded with headers mapping to
 // the plugin configuration schema properties that match the ones specified in the TelemetryProviderHTTPEndpoint ProviderSchemaProperties values
 func (g TelemetryProviderHTTPEndpoint) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
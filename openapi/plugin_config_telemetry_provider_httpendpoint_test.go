@@ -347,6 +347,72 @@ func TestTelemetryProviderHttpEndpointIncServiceProviderResourceTotalRunsCounter
 	}
 }
 
+func TestTelemetryProviderHttpEndpointIncServiceProviderResourceTotalRunsCounterWithLabels(t *testing.T) {
+	var tagsReceived []string
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		telemetryMetric := telemetryMetric{}
+		err := json.NewDecoder(req.Body).Decode(&telemetryMetric)
+		assert.Nil(t, err)
+		tagsReceived = telemetryMetric.Tags
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+	tph := TelemetryProviderHTTPEndpoint{
+		URL:                      fmt.Sprintf("%s/v1/metrics", api.URL),
+		TelemetryProviderOptions: TelemetryProviderOptions{Labels: map[string]string{"team": "networking"}},
+	}
+	err := tph.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_resource", TelemetryResourceOperationCreate, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"provider_name:cdn", "resource_name:cdn_resource", fmt.Sprintf("terraform_operation:%s", TelemetryResourceOperationCreate), "team:networking"}, tagsReceived)
+}
+
+func TestTelemetryProviderHttpEndpointIncServiceProviderAPICallEventsCounter(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		returnedResponseCode int
+		expectedErr          error
+	}{
+		{
+			testName:             "happy path",
+			returnedResponseCode: http.StatusOK,
+			expectedErr:          nil,
+		},
+		{
+			testName:             "metric submission fails",
+			returnedResponseCode: http.StatusNotFound,
+			expectedErr:          errors.New("/v1/metrics' returned a non expected status code 404"),
+		},
+	}
+
+	for _, tc := range testCases {
+
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			reqBody, err := ioutil.ReadAll(req.Body)
+			assert.Nil(t, err, tc.testName)
+			telemetryMetric := telemetryMetric{}
+			err = json.Unmarshal(reqBody, &telemetryMetric)
+			assert.Nil(t, err, tc.testName)
+			assert.Equal(t, metricTypeCounter, telemetryMetric.MetricType, tc.testName)
+			assert.Equal(t, "terraform.provider.api_call_events", telemetryMetric.MetricName, tc.testName)
+			assert.Equal(t, []string{"provider_name:cdn", "resource_name:cdn_resource", "http_method:GET", "operation_id:getCDN", fmt.Sprintf("event:%s", TelemetryAPICallEventRetry)}, telemetryMetric.Tags, tc.testName)
+			rw.WriteHeader(tc.returnedResponseCode)
+		}))
+		// Close the server when test finishes
+		defer api.Close()
+
+		tph := TelemetryProviderHTTPEndpoint{
+			URL: fmt.Sprintf("%s/v1/metrics", api.URL),
+		}
+		err := tph.IncServiceProviderAPICallEventsCounter("cdn", "cdn_resource", "GET", "getCDN", TelemetryAPICallEventRetry, nil)
+		if tc.expectedErr == nil {
+			assert.NoError(t, err, tc.testName)
+		} else {
+			assert.Error(t, err, tc.testName)
+			assert.Contains(t, err.Error(), tc.expectedErr.Error(), tc.testName)
+		}
+	}
+}
+
 func TestGetTelemetryProviderConfiguration(t *testing.T) {
 	tp := TelemetryProviderHTTPEndpoint{
 		ProviderSchemaProperties: []string{"prop_name"},
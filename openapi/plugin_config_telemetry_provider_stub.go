@@ -9,7 +9,11 @@ type telemetryProviderStub struct {
 	providerNameReceived         string
 	resourceNameReceived         string
 	tfOperationReceived          TelemetryResourceOperation
+	httpMethodReceived           string
+	operationIDReceived          string
+	apiCallEventReceived         TelemetryAPICallEvent
 	telemetryProviderConfig      TelemetryProviderConfiguration
+	TelemetryProviderOptions
 }
 
 func (t *telemetryProviderStub) Validate() error {
@@ -34,6 +38,15 @@ func (t *telemetryProviderStub) IncServiceProviderResourceTotalRunsCounter(provi
 	return nil
 }
 
+func (t *telemetryProviderStub) IncServiceProviderAPICallEventsCounter(providerName, resourceName, httpMethod, operationID string, event TelemetryAPICallEvent, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.httpMethodReceived = httpMethod
+	t.operationIDReceived = operationID
+	t.apiCallEventReceived = event
+	return nil
+}
+
 func (t *telemetryProviderStub) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
 	return t.telemetryProviderConfig
 }
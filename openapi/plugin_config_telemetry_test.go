@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTelemetryProviderOptionsIsResourceExcluded(t *testing.T) {
+	options := TelemetryProviderOptions{ExcludedResources: []string{"cdn_v1"}}
+	testCases := []struct {
+		resourceName     string
+		expectedExcluded bool
+	}{
+		{resourceName: "cdn_v1", expectedExcluded: true},
+		{resourceName: "data_cdn_v1", expectedExcluded: true},
+		{resourceName: "data_cdn_v1_instance", expectedExcluded: true},
+		{resourceName: "data_cdn_v1_ids", expectedExcluded: true},
+		{resourceName: "data_cdn_v1_list", expectedExcluded: true},
+		{resourceName: "cdn_v1_firewall", expectedExcluded: false},
+		{resourceName: "data_cdn_v1_firewall", expectedExcluded: false},
+		{resourceName: "lb_v1", expectedExcluded: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedExcluded, options.isResourceExcluded(tc.resourceName), tc.resourceName)
+	}
+	assert.False(t, TelemetryProviderOptions{}.isResourceExcluded("cdn_v1"))
+}
+
+func TestTelemetryProviderOptionsAppendLabels(t *testing.T) {
+	options := TelemetryProviderOptions{Labels: map[string]string{"team": "networking", "env": "prod"}}
+	assert.Equal(t, []string{"provider_name:cdn", "env:prod", "team:networking"}, options.appendLabels([]string{"provider_name:cdn"}))
+	assert.Equal(t, []string{"provider_name:cdn"}, TelemetryProviderOptions{}.appendLabels([]string{"provider_name:cdn"}))
+}
+
+func TestTelemetryProviderOptionsYaml(t *testing.T) {
+	telemetryConfig := TelemetryConfig{}
+	err := yaml.Unmarshal([]byte(`
+graphite:
+  host: some-host.com
+  port: 8125
+  labels:
+    team: networking
+  excluded_resources:
+    - cdn_v1
+`), &telemetryConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "networking"}, telemetryConfig.Graphite.Labels)
+	assert.Equal(t, []string{"cdn_v1"}, telemetryConfig.Graphite.ExcludedResources)
+}
@@ -24,7 +24,7 @@ func TestGetPluginConfigurationPath(t *testing.T) {
 	Convey("Given an environment variable set using lower case provider name with the plugin configuration file path", t, func() {
 		os.Setenv(otfVarPluginConfigurationFileLc, otfVarPluginConfigurationFileValue)
 		Convey("When getPluginConfigurationPath is called", func() {
-			pluginConfigurationFile, err := getPluginConfigurationPath(providerName)
+			pluginConfigurationFile, err := getPluginConfigurationPath(providerName, providerBlockConfiguration{})
 			Convey("Then the error returned should be nil and the pluginConfigurationFile returned should be match the env variable value", func() {
 				So(err, ShouldBeNil)
 				So(pluginConfigurationFile, ShouldEqual, otfVarPluginConfigurationFileValue)
@@ -35,7 +35,7 @@ func TestGetPluginConfigurationPath(t *testing.T) {
 	Convey("Given an environment variable set using lower case provider name with the plugin configuration file path", t, func() {
 		os.Setenv(otfVarPluginConfigurationFileUc, otfVarPluginConfigurationFileValue)
 		Convey("When getPluginConfigurationPath is called", func() {
-			pluginConfigurationFile, err := getPluginConfigurationPath(providerName)
+			pluginConfigurationFile, err := getPluginConfigurationPath(providerName, providerBlockConfiguration{})
 			Convey("Then the error returned should be nil and the pluginConfigurationFile returned should be match the env variable value", func() {
 				So(err, ShouldBeNil)
 				So(pluginConfigurationFile, ShouldEqual, otfVarPluginConfigurationFileValue)
@@ -45,7 +45,7 @@ func TestGetPluginConfigurationPath(t *testing.T) {
 	})
 	Convey("Given no environment variables set for the plugin configuration file", t, func() {
 		Convey("When getPluginConfigurationPath is called", func() {
-			pluginConfigurationFile, err := getPluginConfigurationPath(providerName)
+			pluginConfigurationFile, err := getPluginConfigurationPath(providerName, providerBlockConfiguration{})
 			Convey("Then the error returned should be nil and the returned config file should be the default location", func() {
 				So(err, ShouldBeNil)
 				So(pluginConfigurationFile, ShouldContainSubstring, ".terraform.d/plugins/terraform-provider-openapi.yaml")
@@ -14,8 +14,20 @@ import (
 // ProviderOpenAPI defines the struct for the OpenAPI Terraform Provider
 type ProviderOpenAPI struct {
 	ProviderName string
-	provider     *schema.Provider
-	err          error
+	// StateUpgradeFuncs enables providers to plug in custom state upgrade functions for resources which schema version
+	// (x-terraform-resource-schema-version) has been bumped. The functions are indexed by the resource name (eg: cdns_v1)
+	// and the schema version the function upgrades from. These are executed before the state is upgraded automatically
+	// based on the current resource schema (renamed properties and primitive type changes).
+	StateUpgradeFuncs map[string]map[int]schema.StateUpgradeFunc
+	// PayloadValidator enables providers to plug in a JSON Schema validation library to validate the request payloads
+	// of the resources against the resource definitions in the OpenAPI document before they are sent to the API. If
+	// nil, the request payloads are not validated.
+	PayloadValidator PayloadValidator
+	provider         *schema.Provider
+	specAnalyser     SpecAnalyser
+	// specWatcher re-validates the OpenAPI document periodically if the service configuration enables it
+	specWatcher *specWatcher
+	err         error
 }
 
 // CreateSchemaProvider returns a terraform.ResourceProvider.
@@ -38,7 +50,7 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 
 	log.Printf("[DEBUG] service configuration = %+v", serviceConfiguration)
 
-	openAPISpecAnalyser, err := CreateSpecAnalyser(specAnalyserV2, serviceConfiguration.GetSwaggerURL())
+	openAPISpecAnalyser, err := createSpecAnalyserWithCache(serviceConfiguration.GetSwaggerURL(), serviceConfiguration.GetSpecCacheTTL())
 	if err != nil {
 		return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
 	}
@@ -47,14 +59,40 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 	if err != nil {
 		return nil, fmt.Errorf("plugin provider factory init error: %s", err)
 	}
+	providerFactory.stateUpgradeFuncs = p.StateUpgradeFuncs
+	providerFactory.payloadValidator = p.PayloadValidator
+	providerFactory.providerBlock = getProviderBlockConfiguration(p.ProviderName)
+	p.specAnalyser = openAPISpecAnalyser
 
 	p.provider, err = providerFactory.createProvider()
 	if err != nil {
 		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
 	}
+
+	// stop the watcher of the provider previously created (if any) so it does not keep re-validating in the background
+	if p.specWatcher != nil {
+		p.specWatcher.stopWatching()
+		p.specWatcher = nil
+	}
+	if interval := serviceConfiguration.GetSpecRevalidationInterval(); interval > 0 {
+		p.specWatcher = newSpecWatcher(serviceConfiguration.GetSwaggerURL(), interval, openAPISpecAnalyser)
+		if p.specWatcher != nil {
+			p.specWatcher.start()
+		}
+	}
 	return p.provider, nil
 }
 
+// Validate analyses the OpenAPI document configured for the provider and returns the non-fatal issues found (e,g:
+// endpoints that could not be exposed as resources or data sources). An error is returned if the provider can not be
+// created from the OpenAPI document.
+func (p *ProviderOpenAPI) Validate() ([]string, error) {
+	if _, err := p.CreateSchemaProvider(); err != nil {
+		return nil, err
+	}
+	return p.specAnalyser.GetWarnings(), nil
+}
+
 // This function is implemented with temporary code thus it can serve as an example
 // on how the same code base can be used by binaries of this same provider named differently
 // but internally each will end up calling a different service provider's api
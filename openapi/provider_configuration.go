@@ -1,11 +1,31 @@
 package openapi
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 const providerPropertyRegion = "region"
 const providerPropertyEndPoints = "endpoints"
+const providerPropertyNotificationWebhookURL = "notification_webhook_url"
+const providerPropertyReadOnly = "read_only"
+const providerPropertyRefreshSkipWindow = "refresh_skip_window"
+const providerPropertyOperationTimeout = "operation_timeout"
+const providerPropertyStateEncryptionKey = "state_encryption_key"
+const providerPropertyWorkspace = "workspace"
+const providerPropertyRunID = "run_id"
+const providerPropertyRuntimeMetadataHeaders = "runtime_metadata_headers"
+const providerPropertyRuntimeMetadataProperties = "runtime_metadata_properties"
+const providerPropertyIdentityHeaders = "identity_headers"
+const providerPropertyPageSize = "page_size"
+const providerPropertyUnknownFields = "unknown_fields"
+const providerPropertySwaggerURL = "swagger_url"
+const providerPropertyPluginConfigurationFile = "plugin_configuration_file"
+const providerPropertyHostHeaders = "host_headers"
+const providerPropertyTLSServerNames = "tls_server_names"
+const providerPropertyMaxBodySize = "max_body_size"
 
 // providerConfiguration contains all the configuration related to the OpenAPI provider. The configuration at the moment
 // supports:
@@ -13,13 +33,47 @@ const providerPropertyEndPoints = "endpoints"
 // file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc)
 // - Security Definitions: The security definitions map contains the security definition names as well as the values provided by the user in the terraform configuration
 // file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc)
+// - SecondarySecuritySchemaDefinitions contains the secondary credentials (<security_definition>_secondary) configured by the user, which are
+// used when the API rejects the primary ones (e,g: during a key rotation)
 // - Endpoints contains the endpoints configured by the user, which effectively will override the default host set in the swagger file
 // - Region contains the region if user provided value for it (only supported for multi-region providers)
+// - NotificationWebhookURL contains the URL notified after each mutating resource operation if user provided value for it
+// - ReadOnly if set to true means that the create, update and delete resource operations are disabled
+// - RefreshSkipWindow is the period of time since the resources were last read during which the refresh is skipped
+// - OperationTimeout is the max time a resource or data source operation can take, including the retries and polling
+// - StateEncryptionKey contains the key used to encrypt the values of the properties configured to be encrypted in the state
+// - RetryBackoffs contains the retry backoffs configured by the user indexed by the resource name
+// - RuntimeMetadataHeaders contains the runtime metadata values (workspace and run_id) indexed by the header names they are sent in
+// - RuntimeMetadataProperties contains the runtime metadata values (workspace and run_id) indexed by the payload property names they are sent in
+// - GlobalHeaders contains the values of the headers required in every operation of the OpenAPI document, indexed by the header name
+// - IdentityHeaders contains the headers identifying the tenant, organization or project the API calls are scoped to, indexed by the header name
+// - PageSize is the number of items requested per page from the list operations that support it; zero means the API default
+// - UnknownFields defines how the properties returned by the API that are not defined in the OpenAPI document are treated (ignore, warn or error)
+// - HostHeaders contains the Host header values sent in the API calls, indexed by the host the API calls are sent to
+// - TLSServerNames contains the TLS server names (SNI) sent in the API calls, indexed by the host the API calls are sent to
+// - MaxBodySize is the max size (in bytes) of the request and response bodies exchanged with the API; zero means the value configured in the plugin configuration file applies
 type providerConfiguration struct {
 	Headers                   map[string]string
 	SecuritySchemaDefinitions map[string]specAPIKeyAuthenticator
-	Endpoints                 map[string]string
-	Region                    string
+	// SecondarySecuritySchemaDefinitions only contains the security definitions configured with secondary credentials
+	SecondarySecuritySchemaDefinitions map[string]specAPIKeyAuthenticator
+	Endpoints                          map[string]string
+	Region                             string
+	NotificationWebhookURL             string
+	ReadOnly                           bool
+	RefreshSkipWindow                  time.Duration
+	OperationTimeout                   time.Duration
+	StateEncryptionKey                 []byte
+	RetryBackoffs                      map[string]specRetryBackoff
+	RuntimeMetadataHeaders             map[string]string
+	RuntimeMetadataProperties          map[string]string
+	GlobalHeaders                      map[string]string
+	IdentityHeaders                    map[string]string
+	PageSize                           int
+	UnknownFields                      string
+	HostHeaders                        map[string]string
+	TLSServerNames                     map[string]string
+	MaxBodySize                        int64
 }
 
 // createProviderConfig returns a providerConfiguration populated with the values provided by the user in the provider's terraform
@@ -27,8 +81,10 @@ type providerConfiguration struct {
 func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceData, providerConfigurationEndPoints *providerConfigurationEndPoints) (*providerConfiguration, error) {
 	providerConfiguration := &providerConfiguration{}
 	providerConfiguration.Headers = map[string]string{}
+	providerConfiguration.GlobalHeaders = map[string]string{}
 	providerConfiguration.Endpoints = map[string]string{}
 	providerConfiguration.SecuritySchemaDefinitions = map[string]specAPIKeyAuthenticator{}
+	providerConfiguration.SecondarySecuritySchemaDefinitions = map[string]specAPIKeyAuthenticator{}
 
 	securitySchemaDefinitions, err := specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
 	if err != nil {
@@ -43,6 +99,9 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 				// Initialise the api authenticator with an empty value since the user did not provide one
 				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, "")
 			}
+			if value, ok := data.Get(secDefTerraformCompliantName + providerPropertySecondaryCredentialsSuffix).(string); ok && value != "" {
+				providerConfiguration.SecondarySecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, value)
+			}
 		}
 	}
 
@@ -52,6 +111,9 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 			headerTerraformCompliantName := headerParam.GetHeaderTerraformConfigurationName()
 			if value, exists := data.GetOkExists(headerTerraformCompliantName); exists {
 				providerConfiguration.Headers[headerTerraformCompliantName] = value.(string)
+				if headerParam.IsGlobal {
+					providerConfiguration.GlobalHeaders[headerParam.Name] = value.(string)
+				}
 			}
 		}
 	}
@@ -61,13 +123,129 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 		providerConfiguration.Region = region.(string)
 	}
 
+	notificationWebhookURL := data.Get(providerPropertyNotificationWebhookURL)
+	if notificationWebhookURL != nil {
+		providerConfiguration.NotificationWebhookURL = notificationWebhookURL.(string)
+	}
+
+	if readOnly, ok := data.Get(providerPropertyReadOnly).(bool); ok {
+		providerConfiguration.ReadOnly = readOnly
+	}
+
+	if refreshSkipWindow, ok := data.Get(providerPropertyRefreshSkipWindow).(string); ok && refreshSkipWindow != "" {
+		providerConfiguration.RefreshSkipWindow, err = time.ParseDuration(refreshSkipWindow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' value '%s': %s", providerPropertyRefreshSkipWindow, refreshSkipWindow, err)
+		}
+	}
+
+	if operationTimeout, ok := data.Get(providerPropertyOperationTimeout).(string); ok && operationTimeout != "" {
+		providerConfiguration.OperationTimeout, err = time.ParseDuration(operationTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' value '%s': %s", providerPropertyOperationTimeout, operationTimeout, err)
+		}
+	}
+
+	if stateEncryptionKey, ok := data.Get(providerPropertyStateEncryptionKey).(string); ok && stateEncryptionKey != "" {
+		providerConfiguration.StateEncryptionKey, err = parseStateEncryptionKey(stateEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' value: %s", providerPropertyStateEncryptionKey, err)
+		}
+	}
+
+	providerConfiguration.RuntimeMetadataHeaders, err = getRuntimeMetadataValues(data, providerPropertyRuntimeMetadataHeaders)
+	if err != nil {
+		return nil, err
+	}
+	providerConfiguration.RuntimeMetadataProperties, err = getRuntimeMetadataValues(data, providerPropertyRuntimeMetadataProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	providerConfiguration.IdentityHeaders = getStringMapValues(data, providerPropertyIdentityHeaders)
+
+	if pageSize, ok := data.Get(providerPropertyPageSize).(int); ok {
+		providerConfiguration.PageSize = pageSize
+	}
+
+	if unknownFields, ok := data.Get(providerPropertyUnknownFields).(string); ok {
+		providerConfiguration.UnknownFields = unknownFields
+	}
+
+	providerConfiguration.HostHeaders = getStringMapValues(data, providerPropertyHostHeaders)
+	providerConfiguration.TLSServerNames = getStringMapValues(data, providerPropertyTLSServerNames)
+
+	if maxBodySize, ok := data.Get(providerPropertyMaxBodySize).(int); ok {
+		providerConfiguration.MaxBodySize = int64(maxBodySize)
+	}
+
+	var resourceNames []string
 	if providerConfigurationEndPoints != nil {
 		providerConfiguration.Endpoints = providerConfigurationEndPoints.configureEndpoints(data)
+		resourceNames = providerConfigurationEndPoints.resourceNames
+	}
+
+	providerConfiguration.RetryBackoffs, err = getRetryBackoffs(data, resourceNames)
+	if err != nil {
+		return nil, err
 	}
 
 	return providerConfiguration, nil
 }
 
+// getRuntimeMetadataValues returns the runtime metadata values (workspace and run_id) indexed by the targets (header names
+// or payload property names) configured in the given provider property. The targets which metadata has no value are
+// left out
+func getRuntimeMetadataValues(data *schema.ResourceData, targetsPropertyName string) (map[string]string, error) {
+	values := map[string]string{}
+	targets, ok := data.Get(targetsPropertyName).(map[string]interface{})
+	if !ok {
+		return values, nil
+	}
+	metadata := map[string]string{}
+	for _, metadataName := range []string{providerPropertyWorkspace, providerPropertyRunID} {
+		if value, ok := data.Get(metadataName).(string); ok {
+			metadata[metadataName] = value
+		}
+	}
+	for target, metadataName := range targets {
+		value, exists := metadata[fmt.Sprint(metadataName)]
+		if !exists {
+			return nil, fmt.Errorf("invalid '%s' value '%v' for '%s': the supported values are '%s' and '%s'", targetsPropertyName, metadataName, target, providerPropertyWorkspace, providerPropertyRunID)
+		}
+		if value != "" {
+			values[target] = value
+		}
+	}
+	return values, nil
+}
+
+// getStringMapValues returns the values of the given map provider property indexed by the map keys
+func getStringMapValues(data *schema.ResourceData, propertyName string) map[string]string {
+	values := map[string]string{}
+	if properties, ok := data.Get(propertyName).(map[string]interface{}); ok {
+		for key, value := range properties {
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return values
+}
+
+// positiveDurationValidateFunc validates that the value of the provider property is a positive duration (e,g: 30m)
+func positiveDurationValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	if window, err := time.ParseDuration(value.(string)); err != nil || window <= 0 {
+		errs = append(errs, fmt.Errorf("property '%s' value '%s' is not valid, the value must be a positive duration (e,g: 30m or 1h)", key, value))
+	}
+	return
+}
+
+func positiveIntValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	if number, ok := value.(int); !ok || number <= 0 {
+		errs = append(errs, fmt.Errorf("property '%s' value '%v' is not valid, the value must be a positive integer", key, value))
+	}
+	return
+}
+
 func (p *providerConfiguration) getAuthenticatorFor(s SpecSecurityScheme) specAPIKeyAuthenticator {
 	securitySchemeConfigName := s.GetTerraformConfigurationName()
 	return p.SecuritySchemaDefinitions[securitySchemeConfigName]
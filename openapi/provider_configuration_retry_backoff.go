@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const providerPropertyRetryBackoff = "retry_backoff"
+const retryBackoffPropertyResourceName = "resource_name"
+const retryBackoffPropertyMaxRetries = "max_retries"
+const retryBackoffPropertyInitialBackoff = "initial_backoff"
+const retryBackoffPropertyMaxBackoff = "max_backoff"
+
+// retryBackoffSchema returns the schema of the provider's retry_backoff property, which allows users to override per
+// resource the backoff used to retry the requests failing with retryable errors
+func retryBackoffSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Overrides the backoff used to retry the requests of a resource that fail with retryable errors (x-terraform-retryable-errors)",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				retryBackoffPropertyResourceName: {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the resource the retry backoff applies to (e,g: cdn_v1)",
+				},
+				retryBackoffPropertyMaxRetries: {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: retryBackoffMaxRetriesValidateFunc,
+					Description:  "Max number of times a request is retried",
+				},
+				retryBackoffPropertyInitialBackoff: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: retryBackoffDurationValidateFunc,
+					Description:  "Time to wait before the first retry (e,g: 500ms). The wait time doubles on each retry",
+				},
+				retryBackoffPropertyMaxBackoff: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: retryBackoffDurationValidateFunc,
+					Description:  "Max time to wait between retries (e,g: 30s)",
+				},
+			},
+		},
+	}
+}
+
+func retryBackoffMaxRetriesValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	if maxRetries, ok := value.(int); ok && maxRetries <= 0 {
+		errs = append(errs, fmt.Errorf("property '%s' value '%d' is not valid, the value must be a positive integer", key, maxRetries))
+	}
+	return
+}
+
+func retryBackoffDurationValidateFunc(value interface{}, key string) (warns []string, errs []error) {
+	if duration, err := time.ParseDuration(value.(string)); err != nil || duration <= 0 {
+		errs = append(errs, fmt.Errorf("property '%s' value '%s' is not valid, the value must be a positive duration (e,g: 500ms, 2s or 1m)", key, value))
+	}
+	return
+}
+
+// getRetryBackoffs returns the retry backoffs configured by the user in the provider's retry_backoff property indexed by
+// the resource name. An error is returned if the resource name does not match any of the given resource names.
+func getRetryBackoffs(data *schema.ResourceData, resourceNames []string) (map[string]specRetryBackoff, error) {
+	retryBackoffs := map[string]specRetryBackoff{}
+	retryBackoffList, ok := data.Get(providerPropertyRetryBackoff).([]interface{})
+	if !ok {
+		return retryBackoffs, nil
+	}
+	for _, retryBackoffItem := range retryBackoffList {
+		retryBackoffValues, ok := retryBackoffItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resourceName, _ := retryBackoffValues[retryBackoffPropertyResourceName].(string)
+		if resourceNames != nil && !isResourceNameInList(resourceNames, resourceName) {
+			return nil, fmt.Errorf("invalid '%s' %s '%s': the provider does not contain any resource with that name", providerPropertyRetryBackoff, retryBackoffPropertyResourceName, resourceName)
+		}
+		retryBackoff := specRetryBackoff{}
+		retryBackoff.maxRetries, _ = retryBackoffValues[retryBackoffPropertyMaxRetries].(int)
+		if value, _ := retryBackoffValues[retryBackoffPropertyInitialBackoff].(string); value != "" {
+			retryBackoff.initialBackoff, _ = time.ParseDuration(value)
+		}
+		if value, _ := retryBackoffValues[retryBackoffPropertyMaxBackoff].(string); value != "" {
+			retryBackoff.maxBackoff, _ = time.ParseDuration(value)
+		}
+		retryBackoffs[resourceName] = retryBackoff
+	}
+	return retryBackoffs, nil
+}
+
+func isResourceNameInList(resourceNames []string, resourceName string) bool {
+	for _, name := range resourceNames {
+		if name == resourceName {
+			return true
+		}
+	}
+	return false
+}
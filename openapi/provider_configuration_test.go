@@ -1,8 +1,13 @@
 package openapi
 
 import (
-	. "github.com/smartystreets/goconvey/convey"
+	"encoding/base64"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewProviderConfiguration(t *testing.T) {
@@ -42,6 +47,307 @@ func TestNewProviderConfiguration(t *testing.T) {
 	})
 }
 
+func TestNewProviderConfigurationRefreshSkipWindow(t *testing.T) {
+	Convey("Given a provider configured with a refresh skip window", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			providerPropertyRefreshSkipWindow: {Type: schema.TypeString, Optional: true},
+		}, map[string]interface{}{
+			providerPropertyRefreshSkipWindow: "30m",
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(&specAnalyserStub{security: &specSecurityStub{}}, data, nil)
+			Convey("Then the provider configuration should contain the refresh skip window", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.RefreshSkipWindow, ShouldEqual, 30*time.Minute)
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationGlobalHeaders(t *testing.T) {
+	Convey("Given a provider configured with a global header and a header only used in some operations", t, func() {
+		specAnalyser := &specAnalyserStub{
+			headers: SpecHeaderParameters{
+				SpecHeaderParam{Name: "X-Api-Version", IsRequired: true, IsGlobal: true},
+				SpecHeaderParam{Name: "X-Request-ID"},
+			},
+			security: &specSecurityStub{},
+		}
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			"x_api_version": {Type: schema.TypeString, Required: true},
+			"x_request_id":  {Type: schema.TypeString, Optional: true},
+		}, map[string]interface{}{
+			"x_api_version": "2",
+			"x_request_id":  "someID",
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the provider configuration should contain the global header values indexed by the header name", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.Headers, ShouldResemble, map[string]string{"x_api_version": "2", "x_request_id": "someID"})
+				So(providerConfiguration.GlobalHeaders, ShouldResemble, map[string]string{"X-Api-Version": "2"})
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationSecondaryCredentials(t *testing.T) {
+	Convey("Given a provider configured with primary and secondary credentials for an API key security definition", t, func() {
+		specAnalyser := &specAnalyserStub{
+			security: &specSecurityStub{
+				securityDefinitions: &SpecSecurityDefinitions{
+					newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+					newAPIKeyHeaderSecurityDefinition("other_auth", "X-Other-Key"),
+				},
+			},
+		}
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			"apikey_auth":           {Type: schema.TypeString, Optional: true},
+			"apikey_auth_secondary": {Type: schema.TypeString, Optional: true},
+			"other_auth":            {Type: schema.TypeString, Optional: true},
+			"other_auth_secondary":  {Type: schema.TypeString, Optional: true},
+		}, map[string]interface{}{
+			"apikey_auth":           "primaryKey",
+			"apikey_auth_secondary": "secondaryKey",
+			"other_auth":            "otherKey",
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the provider configuration should only contain the secondary credentials configured", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.SecuritySchemaDefinitions["apikey_auth"].getContext().(apiKey).value, ShouldEqual, "primaryKey")
+				So(providerConfiguration.SecondarySecuritySchemaDefinitions, ShouldHaveLength, 1)
+				So(providerConfiguration.SecondarySecuritySchemaDefinitions["apikey_auth"].getContext().(apiKey).value, ShouldEqual, "secondaryKey")
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationUnknownFields(t *testing.T) {
+	Convey("Given a provider configured to fail when the API returns unknown fields", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			providerPropertyUnknownFields: {Type: schema.TypeString, Optional: true},
+		}, map[string]interface{}{
+			providerPropertyUnknownFields: unknownFieldsError,
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(&specAnalyserStub{security: &specSecurityStub{}}, data, nil)
+			Convey("Then the provider configuration should contain the unknown fields mode", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.UnknownFields, ShouldEqual, unknownFieldsError)
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationHostOverrides(t *testing.T) {
+	Convey("Given a provider configured with host header and TLS server name overrides", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			providerPropertyHostHeaders:    {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			providerPropertyTLSServerNames: {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		}, map[string]interface{}{
+			providerPropertyHostHeaders:    map[string]interface{}{"10.0.0.10:8443": "api.service.com"},
+			providerPropertyTLSServerNames: map[string]interface{}{"10.0.0.10:8443": "ingress.service.com"},
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(&specAnalyserStub{security: &specSecurityStub{}}, data, nil)
+			Convey("Then the provider configuration should contain the overrides indexed by host", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.HostHeaders, ShouldResemble, map[string]string{"10.0.0.10:8443": "api.service.com"})
+				So(providerConfiguration.TLSServerNames, ShouldResemble, map[string]string{"10.0.0.10:8443": "ingress.service.com"})
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationMaxBodySize(t *testing.T) {
+	Convey("Given a provider configured with a max body size", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			providerPropertyMaxBodySize: {Type: schema.TypeInt, Optional: true},
+		}, map[string]interface{}{
+			providerPropertyMaxBodySize: 1048576,
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(&specAnalyserStub{security: &specSecurityStub{}}, data, nil)
+			Convey("Then the provider configuration should contain the max body size", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.MaxBodySize, ShouldEqual, 1048576)
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationOperationTimeout(t *testing.T) {
+	Convey("Given a provider configured with an operation timeout", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			providerPropertyOperationTimeout: {Type: schema.TypeString, Optional: true},
+		}, map[string]interface{}{
+			providerPropertyOperationTimeout: "45m",
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(&specAnalyserStub{security: &specSecurityStub{}}, data, nil)
+			Convey("Then the provider configuration should contain the operation timeout", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.OperationTimeout, ShouldEqual, 45*time.Minute)
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationStateEncryptionKey(t *testing.T) {
+	Convey("Given a provider configured with a state encryption key", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			providerPropertyStateEncryptionKey: {Type: schema.TypeString, Optional: true},
+		}, map[string]interface{}{
+			providerPropertyStateEncryptionKey: base64.StdEncoding.EncodeToString(testStateEncryptionKey),
+		})
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(&specAnalyserStub{security: &specSecurityStub{}}, data, nil)
+			Convey("Then the provider configuration should contain the decoded state encryption key", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.StateEncryptionKey, ShouldResemble, testStateEncryptionKey)
+			})
+		})
+	})
+}
+
+func TestPositiveDurationValidateFunc(t *testing.T) {
+	_, errs := positiveDurationValidateFunc("30m", providerPropertyRefreshSkipWindow)
+	assert.Empty(t, errs)
+	_, errs = positiveDurationValidateFunc("half an hour", providerPropertyRefreshSkipWindow)
+	assert.Len(t, errs, 1)
+	_, errs = positiveDurationValidateFunc("-1h", providerPropertyOperationTimeout)
+	assert.Len(t, errs, 1)
+}
+
+func TestGetRuntimeMetadataValues(t *testing.T) {
+	runtimeMetadataSchema := map[string]*schema.Schema{
+		providerPropertyWorkspace:                 {Type: schema.TypeString, Optional: true},
+		providerPropertyRunID:                     {Type: schema.TypeString, Optional: true},
+		providerPropertyRuntimeMetadataHeaders:    {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		providerPropertyRuntimeMetadataProperties: {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	}
+	Convey("Given a provider configured with the workspace and headers containing the runtime metadata", t, func() {
+		data := schema.TestResourceDataRaw(t, runtimeMetadataSchema, map[string]interface{}{
+			providerPropertyWorkspace: "production",
+			providerPropertyRuntimeMetadataHeaders: map[string]interface{}{
+				"X-Terraform-Workspace": "workspace",
+				"X-Terraform-Run-Id":    "run_id",
+			},
+		})
+		Convey("When getRuntimeMetadataValues is called", func() {
+			values, err := getRuntimeMetadataValues(data, providerPropertyRuntimeMetadataHeaders)
+			Convey("Then the values returned should contain the headers which metadata has a value", func() {
+				So(err, ShouldBeNil)
+				So(values, ShouldResemble, map[string]string{"X-Terraform-Workspace": "production"})
+			})
+		})
+	})
+	Convey("Given a provider configured with a payload property containing a non supported runtime metadata", t, func() {
+		data := schema.TestResourceDataRaw(t, runtimeMetadataSchema, map[string]interface{}{
+			providerPropertyRuntimeMetadataProperties: map[string]interface{}{
+				"created_by": "user",
+			},
+		})
+		Convey("When getRuntimeMetadataValues is called", func() {
+			_, err := getRuntimeMetadataValues(data, providerPropertyRuntimeMetadataProperties)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "invalid 'runtime_metadata_properties' value 'user' for 'created_by': the supported values are 'workspace' and 'run_id'")
+			})
+		})
+	})
+	Convey("Given a provider that does not configure runtime metadata", t, func() {
+		data := schema.TestResourceDataRaw(t, runtimeMetadataSchema, map[string]interface{}{})
+		Convey("When getRuntimeMetadataValues is called", func() {
+			values, err := getRuntimeMetadataValues(data, providerPropertyRuntimeMetadataHeaders)
+			Convey("Then the values returned should be empty", func() {
+				So(err, ShouldBeNil)
+				So(values, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetStringMapValues(t *testing.T) {
+	identityHeadersSchema := map[string]*schema.Schema{
+		providerPropertyIdentityHeaders: {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	}
+	Convey("Given a provider configured with identity headers", t, func() {
+		data := schema.TestResourceDataRaw(t, identityHeadersSchema, map[string]interface{}{
+			providerPropertyIdentityHeaders: map[string]interface{}{
+				"X-Tenant-Id":  "tenant-a",
+				"X-Project-Id": "project-a",
+			},
+		})
+		Convey("When getStringMapValues is called", func() {
+			values := getStringMapValues(data, providerPropertyIdentityHeaders)
+			Convey("Then the values returned should contain the identity headers indexed by the header name", func() {
+				So(values, ShouldResemble, map[string]string{"X-Tenant-Id": "tenant-a", "X-Project-Id": "project-a"})
+			})
+		})
+	})
+	Convey("Given a provider that does not configure identity headers", t, func() {
+		data := schema.TestResourceDataRaw(t, identityHeadersSchema, map[string]interface{}{})
+		Convey("When getStringMapValues is called", func() {
+			values := getStringMapValues(data, providerPropertyIdentityHeaders)
+			Convey("Then the values returned should be empty", func() {
+				So(values, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetRetryBackoffs(t *testing.T) {
+	retryBackoffSchemaMap := map[string]*schema.Schema{providerPropertyRetryBackoff: retryBackoffSchema()}
+	Convey("Given a provider configured with the retry backoff of a resource", t, func() {
+		data := schema.TestResourceDataRaw(t, retryBackoffSchemaMap, map[string]interface{}{
+			providerPropertyRetryBackoff: []interface{}{
+				map[string]interface{}{
+					retryBackoffPropertyResourceName:   "cdn_v1",
+					retryBackoffPropertyMaxRetries:     10,
+					retryBackoffPropertyInitialBackoff: "100ms",
+				},
+			},
+		})
+		Convey("When getRetryBackoffs is called with the resource names of the provider", func() {
+			retryBackoffs, err := getRetryBackoffs(data, []string{"cdn_v1", "lb_v1"})
+			Convey("Then the retry backoffs returned should be indexed by the resource name", func() {
+				So(err, ShouldBeNil)
+				So(retryBackoffs, ShouldResemble, map[string]specRetryBackoff{"cdn_v1": {maxRetries: 10, initialBackoff: 100 * time.Millisecond}})
+			})
+		})
+		Convey("When getRetryBackoffs is called with resource names that do not contain the resource", func() {
+			_, err := getRetryBackoffs(data, []string{"lb_v1"})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "invalid 'retry_backoff' resource_name 'cdn_v1': the provider does not contain any resource with that name")
+			})
+		})
+	})
+	Convey("Given a provider that does not configure retry backoffs", t, func() {
+		data := schema.TestResourceDataRaw(t, retryBackoffSchemaMap, map[string]interface{}{})
+		Convey("When getRetryBackoffs is called", func() {
+			retryBackoffs, err := getRetryBackoffs(data, []string{"cdn_v1"})
+			Convey("Then the retry backoffs returned should be empty", func() {
+				So(err, ShouldBeNil)
+				So(retryBackoffs, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestRetryBackoffValidateFuncs(t *testing.T) {
+	_, errs := retryBackoffDurationValidateFunc("500ms", "initial_backoff")
+	assert.Empty(t, errs)
+	_, errs = retryBackoffDurationValidateFunc("fast", "initial_backoff")
+	assert.Len(t, errs, 1)
+	_, errs = retryBackoffDurationValidateFunc("-1s", "max_backoff")
+	assert.Len(t, errs, 1)
+	_, errs = retryBackoffMaxRetriesValidateFunc(3, "max_retries")
+	assert.Empty(t, errs)
+	_, errs = retryBackoffMaxRetriesValidateFunc(0, "max_retries")
+	assert.Len(t, errs, 1)
+}
+
 func TestGetAuthenticatorFor(t *testing.T) {
 	Convey("Given a providerConfiguration with some security schema definitions", t, func() {
 		providerConfiguration := providerConfiguration{
@@ -19,6 +19,11 @@ type providerFactory struct {
 	name                 string
 	specAnalyser         SpecAnalyser
 	serviceConfiguration ServiceConfiguration
+	stateUpgradeFuncs    map[string]map[int]schema.StateUpgradeFunc
+	payloadValidator     PayloadValidator
+	// providerBlock contains the provider block read from the terraform configuration files, used to expose the resource
+	// attributes that are only needed when the corresponding provider properties are configured
+	providerBlock providerBlockConfiguration
 }
 
 func newProviderFactory(name string, specAnalyser SpecAnalyser, serviceConfiguration ServiceConfiguration) (*providerFactory, error) {
@@ -46,6 +51,7 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 	var resourceMap map[string]*schema.Resource
 	var dataSources map[string]*schema.Resource
 	var dataSourcesInstance map[string]*schema.Resource
+	var resourceAliases map[string]string
 	var err error
 
 	openAPIBackendConfiguration, err := p.specAnalyser.GetAPIBackendConfiguration()
@@ -53,11 +59,11 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 		return nil, err
 	}
 
-	if resourceMap, dataSourcesInstance, err = p.createTerraformProviderResourceMapAndDataSourceInstanceMap(); err != nil {
+	if resourceMap, dataSourcesInstance, resourceAliases, err = p.createTerraformProviderResourceMapAndDataSourceInstanceMap(); err != nil {
 		return nil, err
 	}
 
-	resourceNames := p.getResourceNames(resourceMap)
+	resourceNames := p.getResourceNames(resourceMap, resourceAliases)
 	providerConfigurationEndPoints := &providerConfigurationEndPoints{resourceNames}
 
 	if providerSchema, err = p.createTerraformProviderSchema(openAPIBackendConfiguration, providerConfigurationEndPoints); err != nil {
@@ -84,6 +90,7 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 // - api key auth which will be used as the authentication mechanism when making http requests to the service provider
 // - specific headers used in operations
 // - endpoints override in case the user wants to point the resource to a different API (e,g: staging environment endpoint)
+// - notification webhook URL in case the user wants to be notified about the resource operations performed
 func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) (map[string]*schema.Schema, error) {
 	s := map[string]*schema.Schema{}
 
@@ -116,13 +123,118 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 			required = true
 		}
 		p.configureProviderPropertyFromPluginConfig(s, secDefName, required)
+		// the secondary credentials allow rotating the primary ones without downtime
+		secondaryName := secDefName + providerPropertySecondaryCredentialsSuffix
+		if _, exists := s[secondaryName]; !exists {
+			p.configureProviderPropertyFromPluginConfig(s, secondaryName, false)
+			s[secondaryName].Sensitive = true
+			s[secondaryName].Description = fmt.Sprintf("Secondary credentials used when the API rejects the '%s' credentials (e,g: while rotating them)", secDefName)
+		}
 	}
 
 	headers := p.specAnalyser.GetAllHeaderParameters()
 	log.Printf("[DEBUG] all header parameters: %+v", headers)
 	for _, headerParam := range headers {
 		headerTerraformCompliantName := headerParam.GetHeaderTerraformConfigurationName()
-		p.configureProviderPropertyFromPluginConfig(s, headerTerraformCompliantName, false)
+		// the headers required in every operation are required provider properties too
+		p.configureProviderPropertyFromPluginConfig(s, headerTerraformCompliantName, headerParam.IsGlobal)
+	}
+
+	s[providerPropertyNotificationWebhookURL] = terraformutils.CreateStringSchemaProperty(providerPropertyNotificationWebhookURL, false, "")
+	s[providerPropertyNotificationWebhookURL].Description = "URL notified with a summary of each resource create, update and delete operation performed by the provider"
+
+	s[providerPropertyRetryBackoff] = retryBackoffSchema()
+
+	s[providerPropertyReadOnly] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "If set to true, the create, update and delete operations of all the resources are disabled and fail with an error. The resources can still be read and imported, and the data sources are not affected",
+	}
+
+	s[providerPropertyRefreshSkipWindow] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: positiveDurationValidateFunc,
+		Description:  "If set (e,g: 30m), the refresh of the resources that were read within the given period of time (as recorded in the last_read_at attribute) is skipped and the values in the state are kept",
+	}
+
+	s[providerPropertyOperationTimeout] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: positiveDurationValidateFunc,
+		Description:  "If set (e,g: 30m), max time each resource and data source operation can take. Once it expires, the in-flight API requests, retries and polling of the operation are cancelled and the operation fails",
+	}
+
+	s[providerPropertyStateEncryptionKey] = terraformutils.CreateStringSchemaProperty(providerPropertyStateEncryptionKey, false, "")
+	s[providerPropertyStateEncryptionKey].Sensitive = true
+	s[providerPropertyStateEncryptionKey].ValidateFunc = stateEncryptionKeyValidateFunc
+	s[providerPropertyStateEncryptionKey].Description = "Base64 encoded 32 byte key used to encrypt (AES-256-GCM) the values of the resource properties configured with the x-terraform-encrypted extension before they are persisted in the state"
+
+	s[providerPropertyWorkspace] = terraformutils.CreateStringSchemaProperty(providerPropertyWorkspace, false, "")
+	s[providerPropertyWorkspace].Description = "Name of the terraform workspace (e,g: terraform.workspace) that can be sent to the API in the headers or payload properties configured in runtime_metadata_headers and runtime_metadata_properties"
+	s[providerPropertyRunID] = terraformutils.CreateStringSchemaProperty(providerPropertyRunID, false, "")
+	s[providerPropertyRunID].Description = "Identifier of the terraform run that can be sent to the API in the headers or payload properties configured in runtime_metadata_headers and runtime_metadata_properties"
+	s[providerPropertyRuntimeMetadataHeaders] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Headers sent in the API requests containing runtime metadata, indexed by the header name. The values must be either 'workspace' or 'run_id'",
+	}
+	s[providerPropertyRuntimeMetadataProperties] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Properties added to the create and update request payloads containing runtime metadata, indexed by the property name. The values must be either 'workspace' or 'run_id'",
+	}
+	s[providerPropertyIdentityHeaders] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Headers identifying the tenant, organization or project the API calls are scoped to (e,g: X-Tenant-Id), indexed by the header name. They are sent in every API request and can be overridden per resource via the resource identity_headers attribute",
+	}
+	s[providerPropertyPageSize] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: positiveIntValidateFunc,
+		Description:  "Number of items requested per page from the list operations that support it (x-terraform-pagination-page-size-param), capped to the max page size supported by the operation. If not set, the API default page size applies",
+	}
+	s[providerPropertyMaxBodySize] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: positiveIntValidateFunc,
+		Description:  "Max size (in bytes) allowed for the request and response bodies exchanged with the API, overriding the max_body_size configured in the plugin configuration file. Requests with bodies exceeding the max size are not sent and responses exceeding it fail without being buffered in memory",
+	}
+	s[providerPropertyUnknownFields] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		DefaultFunc:  schema.EnvDefaultFunc(otfVarUnknownFields, unknownFieldsIgnore),
+		ValidateFunc: unknownFieldsValidateFunc,
+		Description:  "How the properties returned by the API that are not defined in the OpenAPI document are treated: ignore (default) drops them, warn logs a warning listing them and error fails the operation listing them. Defaults to the OTF_UNKNOWN_FIELDS environment variable if set",
+	}
+	s[providerPropertyHostHeaders] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Host header values sent in the API calls, indexed by the host the API calls are sent to (the host configured in the OpenAPI document, the resource host or the endpoints override). Useful when the API sits behind a shared ingress that routes the requests based on a host name different from the connection address",
+	}
+	s[providerPropertyTLSServerNames] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "TLS server names (SNI) sent in the API calls, indexed by the host the API calls are sent to. The server certificate is verified against the configured server name",
+	}
+	// the plugin configuration is resolved from the provider block before terraform evaluates the configuration (see
+	// readProviderBlockConfiguration), the properties are only part of the schema so terraform accepts them
+	s[providerPropertySwaggerURL] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "URL of the OpenAPI document the provider is created from. Only literal strings are supported as the value is read before terraform evaluates the configuration. The OTF_VAR_<provider_name>_SWAGGER_URL environment variable takes precedence if set",
+	}
+	s[providerPropertyPluginConfigurationFile] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Path of the plugin configuration file, relative to the terraform working directory. Only literal strings are supported as the value is read before terraform evaluates the configuration. The OTF_VAR_<provider_name>_PLUGIN_CONFIGURATION_FILE environment variable takes precedence if set",
 	}
 
 	if providerConfigurationEndPoints != nil {
@@ -137,10 +249,13 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 
 // getResourceNames returns the resources exposed by the provider. The list of resources names returned will then be
 // used to create the provider's endpoint schema property as well as to configure the endpoints values with the data
-// provided bu the user
-func (p providerFactory) getResourceNames(resourceMap map[string]*schema.Resource) []string {
+// provided bu the user. The resource aliases are not included as they use the endpoints of the resources they alias
+func (p providerFactory) getResourceNames(resourceMap map[string]*schema.Resource, resourceAliases map[string]string) []string {
 	var resourceNames []string
 	for resourceName := range resourceMap {
+		if _, isAlias := resourceAliases[resourceName]; isAlias {
+			continue
+		}
 		resourceNames = append(resourceNames, strings.Replace(resourceName, fmt.Sprintf("%s_", p.name), "", 1))
 	}
 	return resourceNames
@@ -203,6 +318,45 @@ func (p providerFactory) createTerraformProviderDataSourceMap() (map[string]*sch
 		log.Printf("[INFO] data source '%s' successfully registered in the provider (time:%s)", dataSourceName, time.Since(start))
 		dataSourceMap[dataSourceName] = dataSourceTFSchema
 	}
+	// the IDs data sources are registered once all the data sources are registered so they never replace a data source
+	// with the same name
+	for _, openAPIDataSource := range openAPIDataResources {
+		start := time.Now()
+		d := newDataSourceIDsFactory(openAPIDataSource)
+		dataSourceIDsName, err := p.getProviderResourceName(d.getDataSourceIDsName())
+		if err != nil {
+			return nil, err
+		}
+		if _, alreadyThere := dataSourceMap[dataSourceIDsName]; alreadyThere {
+			log.Printf("[WARN] skipping IDs data source '%s' as there is already a data source with the same name", dataSourceIDsName)
+			continue
+		}
+		dataSourceIDsTFSchema, err := d.createTerraformIDsDataSource()
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] IDs data source '%s' successfully registered in the provider (time:%s)", dataSourceIDsName, time.Since(start))
+		dataSourceMap[dataSourceIDsName] = dataSourceIDsTFSchema
+	}
+	// the list data sources are registered last for the same reason
+	for _, openAPIDataSource := range openAPIDataResources {
+		start := time.Now()
+		d := newDataSourceListFactory(openAPIDataSource)
+		dataSourceListName, err := p.getProviderResourceName(d.getDataSourceListName())
+		if err != nil {
+			return nil, err
+		}
+		if _, alreadyThere := dataSourceMap[dataSourceListName]; alreadyThere {
+			log.Printf("[WARN] skipping list data source '%s' as there is already a data source with the same name", dataSourceListName)
+			continue
+		}
+		dataSourceListTFSchema, err := d.createTerraformListDataSource()
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] list data source '%s' successfully registered in the provider (time:%s)", dataSourceListName, time.Since(start))
+		dataSourceMap[dataSourceListName] = dataSourceListTFSchema
+	}
 	return dataSourceMap, nil
 }
 
@@ -210,19 +364,28 @@ func (p providerFactory) createTerraformProviderDataSourceMap() (map[string]*sch
 // - a map containing the resources that are terraform compatible
 // - a map containing the data sources from the resources that are terraform compatible. This data sources enable data
 //  source configuration on the resource instance GET operation.
-func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstanceMap() (resourceMap, dataSourceInstanceMap map[string]*schema.Resource, err error) {
+// - a map containing the names of the resources registered as aliases (x-terraform-resource-name-aliases) indexed by the
+//  alias name. The aliases are also registered in the resource and data source instance maps.
+func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstanceMap() (resourceMap, dataSourceInstanceMap map[string]*schema.Resource, resourceAliases map[string]string, err error) {
 	resourceMap = map[string]*schema.Resource{}
 	dataSourceInstanceMap = map[string]*schema.Resource{}
+	resourceAliases = map[string]string{}
 	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
+	isMultiRegion, err := p.isMultiRegion()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	apiVersions := getResourceAPIVersions(openAPIResources)
+	var aliasedResources []aliasedResource
 	for _, openAPIResource := range openAPIResources {
 		start := time.Now()
 
 		resourceName, err := p.getProviderResourceName(openAPIResource.GetResourceName())
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
 		if openAPIResource.ShouldIgnoreResource() {
@@ -231,6 +394,12 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 		}
 
 		r := newResourceFactory(openAPIResource)
+		r.stateUpgradeFuncs = p.stateUpgradeFuncs[openAPIResource.GetResourceName()]
+		r.payloadValidator = p.payloadValidator
+		r.multiRegion = isMultiRegion
+		r.apiVersions = apiVersions[openAPIResource.GetResourceName()]
+		r.refreshSkipWindowConfigured = p.providerBlock.isAttributeSet(providerPropertyRefreshSkipWindow)
+		r.identityHeadersConfigured = p.providerBlock.isAttributeSet(providerPropertyIdentityHeaders)
 		d := newDataSourceInstanceFactory(openAPIResource)
 		fullDataSourceInstanceName, _ := p.getProviderResourceName(d.getDataSourceInstanceName())
 
@@ -244,7 +413,7 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 		// Register resource
 		resource, err := r.createTerraformResource()
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		log.Printf("[INFO] resource '%s' successfully registered in the provider (time:%s)", resourceName, time.Since(start))
 		resourceMap[resourceName] = resource
@@ -253,16 +422,86 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 		dataSourceInstance, _ := d.createTerraformInstanceDataSource() // if createTerraformResource did not throw an error, it's assumed that the data source instance would work too considering it's subset of the resource
 		log.Printf("[INFO] data source instance '%s' successfully registered in the provider (time:%s)", fullDataSourceInstanceName, time.Since(start))
 		dataSourceInstanceMap[fullDataSourceInstanceName] = dataSourceInstance
+
+		for _, alias := range openAPIResource.getResourceNameAliases() {
+			aliasedResources = append(aliasedResources, aliasedResource{alias: alias, resourceName: resourceName, resourceFactory: r, dataSourceInstanceFactory: d})
+		}
+	}
+	// the aliases are registered once all the resources are registered so they never replace a resource with the same
+	// name nor are registered for duplicate resources that have been removed
+	for _, a := range aliasedResources {
+		aliasName, err := p.registerResourceAlias(a, resourceMap, dataSourceInstanceMap)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if aliasName != "" {
+			resourceAliases[aliasName] = a.resourceName
+		}
+	}
+	return resourceMap, dataSourceInstanceMap, resourceAliases, nil
+}
+
+// aliasedResource contains the previous name (alias) of a resource and the factories used to register the resource
+// and its data source instance under the alias
+type aliasedResource struct {
+	alias                     string
+	resourceName              string
+	resourceFactory           resourceFactory
+	dataSourceInstanceFactory dataSourceInstanceFactory
+}
+
+// registerResourceAlias registers the resource (and its data source instance) under the previous name of the resource
+// as deprecated, so existing states and configurations using the previous name keep working during the deprecation
+// window while users migrate to the current name. The name of the alias registered is returned; empty if the alias
+// is not registered
+func (p providerFactory) registerResourceAlias(a aliasedResource, resourceMap, dataSourceInstanceMap map[string]*schema.Resource) (string, error) {
+	if _, registered := resourceMap[a.resourceName]; !registered {
+		return "", nil
+	}
+	aliasName, err := p.getProviderResourceName(a.alias)
+	if err != nil {
+		return "", err
+	}
+	if _, alreadyThere := resourceMap[aliasName]; alreadyThere {
+		log.Printf("[WARN] skipping alias '%s' of resource '%s' as there is already a resource with the same name", aliasName, a.resourceName)
+		return "", nil
+	}
+	resource, err := a.resourceFactory.createTerraformResource()
+	if err != nil {
+		return "", err
+	}
+	resource.DeprecationMessage = fmt.Sprintf("'%s' has been renamed to '%s', please update the configuration and move the existing resources to '%s'", aliasName, a.resourceName, a.resourceName)
+	log.Printf("[INFO] resource alias '%s' of resource '%s' successfully registered in the provider", aliasName, a.resourceName)
+	resourceMap[aliasName] = resource
+
+	aliasDataSourceInstanceName := fmt.Sprintf("%s_instance", aliasName)
+	if _, alreadyThere := dataSourceInstanceMap[aliasDataSourceInstanceName]; alreadyThere {
+		return aliasName, nil
+	}
+	dataSourceInstanceName, _ := p.getProviderResourceName(a.dataSourceInstanceFactory.getDataSourceInstanceName())
+	dataSourceInstance, err := a.dataSourceInstanceFactory.createTerraformInstanceDataSource()
+	if err != nil {
+		return "", err
 	}
-	return resourceMap, dataSourceInstanceMap, nil
+	dataSourceInstance.DeprecationMessage = fmt.Sprintf("'%s' has been renamed to '%s', please update the configuration", aliasDataSourceInstanceName, dataSourceInstanceName)
+	dataSourceInstanceMap[aliasDataSourceInstanceName] = dataSourceInstance
+	return aliasName, nil
 }
 
 func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) schema.ConfigureFunc {
 	return func(data *schema.ResourceData) (interface{}, error) {
+		if err := checkSpecVersion(p.specAnalyser, p.serviceConfiguration); err != nil {
+			return nil, err
+		}
 		globalSecuritySchemes, err := p.specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
 		if err != nil {
 			return nil, err
 		}
+		// the plugin SDK does not support returning warnings when configuring the provider, hence the issues found in the
+		// OpenAPI document are logged so users can find them with TF_LOG
+		if warnings := p.specAnalyser.GetWarnings(); len(warnings) > 0 {
+			log.Printf("[WARN] %d issue(s) found while analysing the OpenAPI document, some resources might be missing or incomplete. Run 'terraform-provider-%s validate' for more details: %s", len(warnings), p.name, strings.Join(warnings, "; "))
+		}
 		authenticator := newAPIAuthenticator(&globalSecuritySchemes)
 		config, err := p.createProviderConfig(data, providerConfigurationEndPoints)
 		if err != nil {
@@ -272,17 +511,47 @@ func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBacke
 		if telemetryHandler != nil {
 			telemetryHandler.SubmitPluginExecutionMetrics()
 		}
+		rateLimitInterval, err := getRateLimitInterval(openAPIBackendConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		// the clients of all the provider instances (e,g: aliased provider blocks) wrap http.DefaultTransport, so the
+		// connections to the same host are pooled across the instances in the plugin process. The hosts configured with a
+		// TLS server name use a copy of it, which is also shared across the instances configured with the same TLS server name
+		transport := newHostOverrideTransport(config.HostHeaders, config.TLSServerNames, http.DefaultTransport)
+		maxBodySize := p.serviceConfiguration.GetMaxBodySize()
+		if config.MaxBodySize > 0 {
+			maxBodySize = config.MaxBodySize
+		}
 		openAPIClient := &ProviderClient{
 			openAPIBackendConfiguration: openAPIBackendConfiguration,
 			apiAuthenticator:            authenticator,
-			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{Transport: newMaxBodySizeTransport(maxBodySize, transport)}},
 			providerConfiguration:       *config,
 			telemetryHandler:            telemetryHandler,
+			rateLimiters:                sharedRateLimiters,
+			rateLimitInterval:           rateLimitInterval,
+			providerName:                p.name,
+			lastModified:                newLastModifiedCache(),
+			deleteQueues:                sharedDeleteQueues,
+			deleteBatcher:               newDeleteBatcher(),
+			readBatcher:                 newReadBatcher(),
+			credentialsRotation:         newCredentialsRotation(),
 		}
 		return openAPIClient, nil
 	}
 }
 
+// isMultiRegion returns true if the API backend configuration is multi-region
+func (p providerFactory) isMultiRegion() (bool, error) {
+	openAPIBackendConfiguration, err := p.specAnalyser.GetAPIBackendConfiguration()
+	if err != nil || openAPIBackendConfiguration == nil {
+		return false, err
+	}
+	isMultiRegion, _, _, err := openAPIBackendConfiguration.IsMultiRegion()
+	return isMultiRegion, err
+}
+
 // GetTelemetryHandler returns a handler containing validated telemetry providers
 func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) TelemetryHandler {
 	telemetryProvider := p.serviceConfiguration.GetTelemetryConfiguration()
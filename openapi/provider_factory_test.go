@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/dikhan/http_goclient"
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/stretchr/testify/assert"
@@ -85,11 +86,21 @@ func TestGetResourceNames(t *testing.T) {
 			resources := map[string]*schema.Resource{
 				"provider_resource_name_v1": {},
 			}
-			resourceNames := p.getResourceNames(resources)
+			resourceNames := p.getResourceNames(resources, map[string]string{})
 			Convey("Then the list should contain the expected resources", func() {
 				So(resourceNames, ShouldContain, "resource_name_v1")
 			})
 		})
+		Convey("When getResourceNames is called with a map of resources containing resource aliases", func() {
+			resources := map[string]*schema.Resource{
+				"provider_resource_name_v1": {},
+				"provider_old_name_v1":      {},
+			}
+			resourceNames := p.getResourceNames(resources, map[string]string{"provider_old_name_v1": "provider_resource_name_v1"})
+			Convey("Then the list should only contain the resources that are not aliases", func() {
+				So(resourceNames, ShouldResemble, []string{"resource_name_v1"})
+			})
+		})
 	})
 }
 
@@ -105,6 +116,11 @@ func TestCreateProvider(t *testing.T) {
 					SpecHeaderParam{
 						Name: headerProperty.Name,
 					},
+					SpecHeaderParam{
+						Name:       "X-Api-Version",
+						IsRequired: true,
+						IsGlobal:   true,
+					},
 				},
 				security: &specSecurityStub{
 					securityDefinitions: &SpecSecurityDefinitions{
@@ -128,7 +144,12 @@ func TestCreateProvider(t *testing.T) {
 				So(p.ResourcesMap, ShouldContainKey, "provider_resource_v1")
 				So(p.DataSourcesMap, ShouldContainKey, "provider_resource_v1_instance")
 				So(p.Schema[apiKeyAuthProperty.Name], ShouldNotBeNil)
+				So(p.Schema[apiKeyAuthProperty.Name+providerPropertySecondaryCredentialsSuffix], ShouldNotBeNil)
+				So(p.Schema[apiKeyAuthProperty.Name+providerPropertySecondaryCredentialsSuffix].Optional, ShouldBeTrue)
+				So(p.Schema[apiKeyAuthProperty.Name+providerPropertySecondaryCredentialsSuffix].Sensitive, ShouldBeTrue)
 				So(p.Schema[headerProperty.Name], ShouldNotBeNil)
+				So(p.Schema[headerProperty.Name].Optional, ShouldBeTrue)
+				So(p.Schema["x_api_version"].Required, ShouldBeTrue)
 				So(p.Schema["region"], ShouldBeNil)
 				So(p.Schema[providerPropertyEndPoints], ShouldNotBeNil)
 				So(p.Schema[providerPropertyEndPoints].Elem.(*schema.Resource).Schema, ShouldContainKey, "resource_v1")
@@ -515,6 +536,71 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 				So(providerSchema, ShouldContainKey, providerPropertyEndPoints)
 				So(providerSchema[providerPropertyEndPoints].Elem.(*schema.Resource).Schema, ShouldContainKey, "resourceName")
 			})
+			Convey("And the provider schema should contain the optional notification webhook URL property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyNotificationWebhookURL)
+				So(providerSchema[providerPropertyNotificationWebhookURL].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional retry backoff property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyRetryBackoff)
+				So(providerSchema[providerPropertyRetryBackoff].Type, ShouldEqual, schema.TypeList)
+			})
+			Convey("And the provider schema should contain the optional read only property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyReadOnly)
+				So(providerSchema[providerPropertyReadOnly].Type, ShouldEqual, schema.TypeBool)
+				So(providerSchema[providerPropertyReadOnly].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional refresh skip window property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyRefreshSkipWindow)
+				So(providerSchema[providerPropertyRefreshSkipWindow].Type, ShouldEqual, schema.TypeString)
+				So(providerSchema[providerPropertyRefreshSkipWindow].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional operation timeout property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyOperationTimeout)
+				So(providerSchema[providerPropertyOperationTimeout].Type, ShouldEqual, schema.TypeString)
+				So(providerSchema[providerPropertyOperationTimeout].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional sensitive state encryption key property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyStateEncryptionKey)
+				So(providerSchema[providerPropertyStateEncryptionKey].Optional, ShouldBeTrue)
+				So(providerSchema[providerPropertyStateEncryptionKey].Sensitive, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional runtime metadata properties", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyWorkspace)
+				So(providerSchema, ShouldContainKey, providerPropertyRunID)
+				So(providerSchema[providerPropertyRuntimeMetadataHeaders].Type, ShouldEqual, schema.TypeMap)
+				So(providerSchema[providerPropertyRuntimeMetadataProperties].Type, ShouldEqual, schema.TypeMap)
+			})
+			Convey("And the provider schema should contain the optional identity headers property", func() {
+				So(providerSchema[providerPropertyIdentityHeaders].Type, ShouldEqual, schema.TypeMap)
+				So(providerSchema[providerPropertyIdentityHeaders].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional page size property", func() {
+				So(providerSchema[providerPropertyPageSize].Type, ShouldEqual, schema.TypeInt)
+				So(providerSchema[providerPropertyPageSize].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional max body size property", func() {
+				So(providerSchema[providerPropertyMaxBodySize].Type, ShouldEqual, schema.TypeInt)
+				So(providerSchema[providerPropertyMaxBodySize].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional unknown fields property defaulting to ignore", func() {
+				So(providerSchema[providerPropertyUnknownFields].Type, ShouldEqual, schema.TypeString)
+				So(providerSchema[providerPropertyUnknownFields].Optional, ShouldBeTrue)
+				defaultValue, err := providerSchema[providerPropertyUnknownFields].DefaultValue()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldEqual, unknownFieldsIgnore)
+			})
+			Convey("And the provider schema should contain the optional host header and TLS server name overrides", func() {
+				So(providerSchema[providerPropertyHostHeaders].Type, ShouldEqual, schema.TypeMap)
+				So(providerSchema[providerPropertyHostHeaders].Optional, ShouldBeTrue)
+				So(providerSchema[providerPropertyTLSServerNames].Type, ShouldEqual, schema.TypeMap)
+				So(providerSchema[providerPropertyTLSServerNames].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional plugin configuration properties resolved from the provider block", func() {
+				So(providerSchema[providerPropertySwaggerURL].Type, ShouldEqual, schema.TypeString)
+				So(providerSchema[providerPropertySwaggerURL].Optional, ShouldBeTrue)
+				So(providerSchema[providerPropertyPluginConfigurationFile].Type, ShouldEqual, schema.TypeString)
+				So(providerSchema[providerPropertyPluginConfigurationFile].Optional, ShouldBeTrue)
+			})
 		})
 	})
 	Convey("Given a provider factory (testing endpoints)", t, func() {
@@ -659,6 +745,9 @@ func TestConfigureProvider(t *testing.T) {
 			Convey("And the client should implement ClientOpenAPI interface and the telemetry server should have been received the expected counter metrics increase", func() {
 				var _ ClientOpenAPI = providerClient
 				So(err, ShouldBeNil)
+				// And the body size should not be limited as the service configuration does not set a max body size
+				_, maxBodySizeLimited := providerClient.httpClient.(*http_goclient.HttpClient).HttpClient.Transport.(*maxBodySizeTransport)
+				So(maxBodySizeLimited, ShouldBeFalse)
 				assertExpectedMetric(t, metricChannel, "openapi.terraform.openapi_plugin_version.total_runs:1|c|#openapi_plugin_version:dev")
 			})
 		})
@@ -726,6 +815,22 @@ func TestConfigureProvider(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a provider factory configured with a spec version constraint the OpenAPI document version does not meet", t, func() {
+		p := providerFactory{
+			name:                 "provider",
+			specAnalyser:         &specAnalyserStub{specVersion: "2.0.0"},
+			serviceConfiguration: &ServiceConfigStub{SpecVersionConstraint: "~> 1.2", SpecVersionCheck: specVersionCheckError},
+		}
+		Convey("When configureProvider is called and the returned configureFunc is invoked", func() {
+			configureFunc := p.configureProvider(&specStubBackendConfiguration{}, &providerConfigurationEndPoints{})
+			client, err := configureFunc(newTestSchema().getResourceData(t))
+			Convey("Then the provider configuration should fail with the expected error", func() {
+				So(client, ShouldBeNil)
+				So(err.Error(), ShouldEqual, "the OpenAPI document API version (info.version) '2.0.0' does not meet the spec_version_constraint '~> 1.2' in the plugin configuration file")
+			})
+		})
+	})
 }
 
 func TestCreateProviderConfig(t *testing.T) {
@@ -935,7 +1040,7 @@ func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap(t *testing.T
 				specAnalyser: tc.specV2stub,
 			}
 			Convey(fmt.Sprintf("When createTerraformProviderResourceMapAndDataSourceInstanceMap method is called: %s", tc.name), func() {
-				resourceMap, dataSourceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+				resourceMap, dataSourceMap, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
 				Convey("Then the result returned should be the expected one", func() {
 					So(err, ShouldResemble, tc.expectedError)
 					if tc.expectedError == nil {
@@ -957,7 +1062,7 @@ func TestCreateTerraformProviderDataSourceInstanceMap_ignore_resource(t *testing
 			},
 		},
 	}
-	resourceMap, dataSourceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+	resourceMap, dataSourceMap, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
 	assert.Nil(t, err)
 	assert.Empty(t, resourceMap)
 	assert.Empty(t, dataSourceMap)
@@ -974,7 +1079,7 @@ func TestCreateTerraformProviderDataSourceInstanceMap_duplicate_resource(t *test
 			},
 		}
 		Convey("When the createTerraformProviderResourceMapAndDataSourceInstanceMap method is called", func() {
-			resourceMap, dataSourceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+			resourceMap, dataSourceMap, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
 			Convey("Then the returned resource and data source maps should be empty and the error should be nil", func() {
 				So(err, ShouldBeNil)
 				So(resourceMap, ShouldBeEmpty)
@@ -984,6 +1089,35 @@ func TestCreateTerraformProviderDataSourceInstanceMap_duplicate_resource(t *test
 	})
 }
 
+func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap_resource_aliases(t *testing.T) {
+	Convey("Given a providerFactory configured with a resource that has name aliases", t, func() {
+		aliasedResource := newSpecStubResource("cdn_v1", "/v1/cdns", false, &SpecSchemaDefinition{})
+		aliasedResource.nameAliases = []string{"cdns_v1", "other_v1"}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: []SpecResource{
+					aliasedResource,
+					newSpecStubResource("other_v1", "/v1/other", false, &SpecSchemaDefinition{}),
+				},
+			},
+		}
+		Convey("When the createTerraformProviderResourceMapAndDataSourceInstanceMap method is called", func() {
+			resourceMap, dataSourceMap, resourceAliases, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+			Convey("Then the resource and its data source instance should also be registered as deprecated under the aliases not clashing with other resources", func() {
+				So(err, ShouldBeNil)
+				So(resourceMap, ShouldHaveLength, 3)
+				So(resourceMap, ShouldContainKey, "provider_cdns_v1")
+				So(resourceMap["provider_cdns_v1"].DeprecationMessage, ShouldEqual, "'provider_cdns_v1' has been renamed to 'provider_cdn_v1', please update the configuration and move the existing resources to 'provider_cdn_v1'")
+				So(resourceMap["provider_other_v1"].DeprecationMessage, ShouldBeEmpty)
+				So(dataSourceMap, ShouldContainKey, "provider_cdns_v1_instance")
+				So(dataSourceMap["provider_cdns_v1_instance"].DeprecationMessage, ShouldEqual, "'provider_cdns_v1_instance' has been renamed to 'provider_cdn_v1_instance', please update the configuration")
+				So(resourceAliases, ShouldResemble, map[string]string{"provider_cdns_v1": "provider_cdn_v1"})
+			})
+		})
+	})
+}
+
 func TestCreateTerraformProviderDataSourceMap(t *testing.T) {
 
 	testCases := []struct {
@@ -999,6 +1133,20 @@ func TestCreateTerraformProviderDataSourceMap(t *testing.T) {
 			},
 			expectedResourceName: "provider_resource",
 		},
+		{
+			name: "IDs data source registered",
+			specV2stub: &specAnalyserStub{
+				dataSources: []SpecResource{newSpecStubResource("resource", "/v1/resource", false, &SpecSchemaDefinition{})},
+			},
+			expectedResourceName: "provider_resource_ids",
+		},
+		{
+			name: "list data source registered",
+			specV2stub: &specAnalyserStub{
+				dataSources: []SpecResource{newSpecStubResource("resource", "/v1/resource", false, &SpecSchemaDefinition{})},
+			},
+			expectedResourceName: "provider_resource_list",
+		},
 		{
 			name: "getProviderResourceName fails ",
 			specV2stub: &specAnalyserStub{
@@ -1107,3 +1255,38 @@ func assertExpectedMetricAndLogging(t *testing.T, metricChannel chan string, exp
 		t.Fatalf("[FAIL] '%s' not reveided within the expected timeframe (timed out)", expectedMetric)
 	}
 }
+
+func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap_provider_block(t *testing.T) {
+	Convey("Given a providerFactory", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: []SpecResource{newSpecStubResource("resource", "/v1/resource", false, &SpecSchemaDefinition{})},
+			},
+		}
+		Convey("When the provider block does not set the refresh_skip_window nor the identity_headers properties", func() {
+			resourceMap, _, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+			Convey("Then the resources should not contain the last_read_at nor the identity_headers attributes", func() {
+				So(err, ShouldBeNil)
+				So(resourceMap["provider_resource"].Schema, ShouldNotContainKey, lastReadAtAttribute)
+				So(resourceMap["provider_resource"].Schema, ShouldNotContainKey, identityHeadersAttribute)
+			})
+		})
+		Convey("When the provider block sets the refresh_skip_window property", func() {
+			p.providerBlock = providerBlockConfiguration{found: true, attributes: map[string]bool{providerPropertyRefreshSkipWindow: true}}
+			resourceMap, _, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+			Convey("Then the resources should contain the last_read_at attribute", func() {
+				So(err, ShouldBeNil)
+				So(resourceMap["provider_resource"].Schema, ShouldContainKey, lastReadAtAttribute)
+			})
+		})
+		Convey("When the provider block sets the identity_headers property", func() {
+			p.providerBlock = providerBlockConfiguration{found: true, attributes: map[string]bool{providerPropertyIdentityHeaders: true}}
+			resourceMap, _, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+			Convey("Then the resources should contain the identity_headers attribute", func() {
+				So(err, ShouldBeNil)
+				So(resourceMap["provider_resource"].Schema, ShouldContainKey, identityHeadersAttribute)
+			})
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultSpecCacheTTL is how long the OpenAPI documents fetched from remote URLs are reused if the service configuration
+// does not set the spec_cache_ttl
+const defaultSpecCacheTTL = 30 * time.Second
+
+// specCacheDirName is the directory, within the user cache directory, where the OpenAPI documents are cached
+const specCacheDirName = "terraform-provider-openapi"
+
+// userCacheDir returns the user cache directory where the specCacheDirName directory is created
+var userCacheDir = os.UserCacheDir
+
+// specDocumentAnalyser is implemented by the spec analysers that can return the analysed OpenAPI document so it can be
+// cached
+type specDocumentAnalyser interface {
+	getSpecDocument() ([]byte, error)
+}
+
+// specCache caches the OpenAPI documents fetched from remote URLs on disk. Each terraform command (and each call made
+// by editor integrations like terraform-ls to get the provider schema) starts a new plugin process which has to create
+// the provider schema from the OpenAPI document, so caching the document across processes for a short period of time
+// avoids fetching it from the host for each invocation.
+type specCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// newSpecCache returns a specCache storing the documents in the user cache directory. Nil is returned if the cache
+// directory can not be resolved
+func newSpecCache(ttl time.Duration) *specCache {
+	cacheDir, err := userCacheDir()
+	if err != nil {
+		log.Printf("[WARN] the OpenAPI document will not be cached: failed to resolve the user cache directory: %s", err)
+		return nil
+	}
+	return &specCache{dir: filepath.Join(cacheDir, specCacheDirName), ttl: ttl}
+}
+
+func (c *specCache) getDocumentPath(openAPIDocumentURL string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(openAPIDocumentURL))))
+}
+
+// load returns the cached document for the given URL if it was cached within the ttl
+func (c *specCache) load(openAPIDocumentURL string) ([]byte, bool) {
+	documentPath := c.getDocumentPath(openAPIDocumentURL)
+	info, err := os.Stat(documentPath)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return nil, false
+	}
+	document, err := ioutil.ReadFile(documentPath) // #nosec G304
+	if err != nil {
+		log.Printf("[WARN] failed to read the cached OpenAPI document '%s': %s", documentPath, err)
+		return nil, false
+	}
+	return document, true
+}
+
+// store caches the given document for the given URL. The document is written to a temporary file first and then renamed
+// so concurrent plugin processes never read a partially written document
+func (c *specCache) store(openAPIDocumentURL string, document []byte) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(c.dir, "document")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(document); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), c.getDocumentPath(openAPIDocumentURL))
+}
+
+// isRemoteSpecURL returns true if the OpenAPI document is fetched over HTTP(S), as opposed to documents read from disk
+// which are not cached
+func isRemoteSpecURL(openAPIDocumentURL string) bool {
+	lowerURL := strings.ToLower(openAPIDocumentURL)
+	return strings.HasPrefix(lowerURL, "http://") || strings.HasPrefix(lowerURL, "https://")
+}
+
+// createSpecAnalyserWithCache returns the spec analyser for the OpenAPI document at the given URL, reusing the document
+// cached by a previous plugin process within the given ttl instead of fetching it again. The document is fetched as
+// usual (and cached) if the ttl is not positive, the document is not remote or the cached document can not be analysed.
+func createSpecAnalyserWithCache(openAPIDocumentURL string, ttl time.Duration) (SpecAnalyser, error) {
+	if ttl <= 0 || !isRemoteSpecURL(openAPIDocumentURL) {
+		return CreateSpecAnalyser(specAnalyserV2, openAPIDocumentURL)
+	}
+	cache := newSpecCache(ttl)
+	if cache == nil {
+		return CreateSpecAnalyser(specAnalyserV2, openAPIDocumentURL)
+	}
+	if document, found := cache.load(openAPIDocumentURL); found {
+		specAnalyser, err := newSpecAnalyserV2FromDocument(openAPIDocumentURL, document)
+		if err == nil {
+			log.Printf("[INFO] using the OpenAPI document '%s' cached within the last %s", openAPIDocumentURL, ttl)
+			return specAnalyser, nil
+		}
+		log.Printf("[WARN] ignoring the cached OpenAPI document '%s': %s", openAPIDocumentURL, err)
+	}
+	specAnalyser, err := CreateSpecAnalyser(specAnalyserV2, openAPIDocumentURL)
+	if err != nil {
+		return nil, err
+	}
+	if analyser, ok := specAnalyser.(specDocumentAnalyser); ok {
+		document, err := analyser.getSpecDocument()
+		if err == nil {
+			err = cache.store(openAPIDocumentURL, document)
+		}
+		if err != nil {
+			log.Printf("[WARN] failed to cache the OpenAPI document '%s': %s", openAPIDocumentURL, err)
+		}
+	}
+	return specAnalyser, nil
+}
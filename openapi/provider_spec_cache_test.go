@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateSpecAnalyserWithCache(t *testing.T) {
+	Convey("Given an OpenAPI document served by a remote host and an empty cache directory", t, func() {
+		swaggerContent := `swagger: "2.0"
+host: "localhost:8443"
+basePath: "/api"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+		requestsReceived := 0
+		swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsReceived++
+			w.Write([]byte(swaggerContent))
+		}))
+		defer swaggerServer.Close()
+		cacheDir, err := ioutil.TempDir("", "spec_cache")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(cacheDir)
+		userCacheDir = func() (string, error) { return cacheDir, nil }
+		defer func() { userCacheDir = os.UserCacheDir }()
+
+		Convey("When createSpecAnalyserWithCache is called twice within the ttl", func() {
+			_, firstErr := createSpecAnalyserWithCache(swaggerServer.URL, time.Minute)
+			specAnalyser, secondErr := createSpecAnalyserWithCache(swaggerServer.URL, time.Minute)
+			Convey("Then the document should only be fetched once and the cached document should be analysed as the fetched one", func() {
+				So(firstErr, ShouldBeNil)
+				So(secondErr, ShouldBeNil)
+				So(requestsReceived, ShouldEqual, 1)
+				resources, err := specAnalyser.GetTerraformCompliantResources()
+				So(err, ShouldBeNil)
+				So(resources, ShouldHaveLength, 1)
+				So(resources[0].GetResourceName(), ShouldEqual, "cdns_v1")
+			})
+		})
+		Convey("When createSpecAnalyserWithCache is called twice after the ttl expired", func() {
+			_, firstErr := createSpecAnalyserWithCache(swaggerServer.URL, time.Nanosecond)
+			time.Sleep(time.Millisecond)
+			_, secondErr := createSpecAnalyserWithCache(swaggerServer.URL, time.Nanosecond)
+			Convey("Then the document should be fetched each time", func() {
+				So(firstErr, ShouldBeNil)
+				So(secondErr, ShouldBeNil)
+				So(requestsReceived, ShouldEqual, 2)
+			})
+		})
+		Convey("When createSpecAnalyserWithCache is called twice with the cache disabled", func() {
+			_, firstErr := createSpecAnalyserWithCache(swaggerServer.URL, 0)
+			_, secondErr := createSpecAnalyserWithCache(swaggerServer.URL, 0)
+			Convey("Then the document should be fetched each time and nothing should be cached", func() {
+				So(firstErr, ShouldBeNil)
+				So(secondErr, ShouldBeNil)
+				So(requestsReceived, ShouldEqual, 2)
+				_, err := os.Stat(newSpecCache(time.Minute).dir)
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
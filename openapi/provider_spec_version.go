@@ -0,0 +1,175 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const (
+	// specVersionCheckError makes the provider configuration fail when the OpenAPI document version is not compatible
+	specVersionCheckError = "error"
+	// specVersionCheckWarn makes the provider log a warning when the OpenAPI document version is not compatible
+	specVersionCheckWarn = "warn"
+)
+
+// specVersionAnalyser is implemented by the spec analysers that expose the version of the API described by the OpenAPI
+// document (info.version)
+type specVersionAnalyser interface {
+	getSpecVersion() string
+}
+
+// specVersionConstraint is a list of version conditions (e,g: >= 1.2.0, < 2.0.0) that must all be met by a version
+type specVersionConstraint []specVersionCondition
+
+type specVersionCondition struct {
+	operator string
+	version  []int
+}
+
+// newSpecVersionConstraint parses the given comma separated list of conditions. Each condition is made of an operator
+// (=, !=, >, >=, <, <= or ~>) and a version (e,g: 1.2.0). The = operator is assumed if the operator is not specified
+func newSpecVersionConstraint(constraint string) (specVersionConstraint, error) {
+	var versionConstraint specVersionConstraint
+	for _, condition := range strings.Split(constraint, ",") {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			return nil, fmt.Errorf("version constraint '%s' not valid: empty condition", constraint)
+		}
+		operator := "="
+		for _, op := range []string{">=", "<=", "!=", "~>", ">", "<", "="} {
+			if strings.HasPrefix(condition, op) {
+				operator = op
+				condition = strings.TrimSpace(strings.TrimPrefix(condition, op))
+				break
+			}
+		}
+		version, err := parseSpecVersion(condition)
+		if err != nil {
+			return nil, fmt.Errorf("version constraint '%s' not valid: %s", constraint, err)
+		}
+		versionConstraint = append(versionConstraint, specVersionCondition{operator: operator, version: version})
+	}
+	return versionConstraint, nil
+}
+
+// check returns true if the given version meets all the conditions of the constraint
+func (c specVersionConstraint) check(version string) (bool, error) {
+	v, err := parseSpecVersion(version)
+	if err != nil {
+		return false, err
+	}
+	for _, condition := range c {
+		if !condition.check(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c specVersionCondition) check(version []int) bool {
+	result := compareSpecVersions(version, c.version)
+	switch c.operator {
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case "~>":
+		// pessimistic constraint: only the right-most segment of the version is allowed to increase (e,g: ~> 1.2
+		// allows 1.x versions from 1.2 onwards)
+		upperBound := make([]int, len(c.version))
+		copy(upperBound, c.version)
+		bumpedSegment := len(upperBound) - 2
+		if bumpedSegment < 0 {
+			bumpedSegment = 0
+		}
+		upperBound = upperBound[:bumpedSegment+1]
+		upperBound[bumpedSegment]++
+		return result >= 0 && compareSpecVersions(version, upperBound) < 0
+	default:
+		return result == 0
+	}
+}
+
+// parseSpecVersion parses a dotted version (e,g: 1.2.0 or v1.2). Pre-release and build metadata suffixes (e,g: 1.2.0-beta
+// or 1.2.0+build1) are ignored
+func parseSpecVersion(version string) ([]int, error) {
+	trimmedVersion := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(trimmedVersion, "-+"); i >= 0 {
+		trimmedVersion = trimmedVersion[:i]
+	}
+	if trimmedVersion == "" {
+		return nil, fmt.Errorf("version '%s' not valid", version)
+	}
+	var segments []int
+	for _, segment := range strings.Split(trimmedVersion, ".") {
+		n, err := strconv.Atoi(segment)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("version '%s' not valid", version)
+		}
+		segments = append(segments, n)
+	}
+	return segments, nil
+}
+
+// compareSpecVersions returns -1, 0 or 1 if the version a is lower than, equal to or greater than the version b. Missing
+// segments are considered zero (e,g: 1.2 equals 1.2.0)
+func compareSpecVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var segmentA, segmentB int
+		if i < len(a) {
+			segmentA = a[i]
+		}
+		if i < len(b) {
+			segmentB = b[i]
+		}
+		if segmentA < segmentB {
+			return -1
+		}
+		if segmentA > segmentB {
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkSpecVersion makes sure the version of the OpenAPI document (info.version) the provider was created from meets the
+// spec_version_constraint configured in the service configuration, so unexpected API versions (which could bring schema
+// changes) are detected before any API call is made. An error is returned if the version is not compatible, unless the
+// service configuration sets spec_version_check to warn, in which case a warning is logged instead
+func checkSpecVersion(specAnalyser SpecAnalyser, serviceConfiguration ServiceConfiguration) error {
+	constraint := serviceConfiguration.GetSpecVersionConstraint()
+	if constraint == "" {
+		return nil
+	}
+	analyser, ok := specAnalyser.(specVersionAnalyser)
+	if !ok {
+		log.Printf("[WARN] spec_version_constraint '%s' ignored: the OpenAPI document version can not be checked", constraint)
+		return nil
+	}
+	versionConstraint, err := newSpecVersionConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("service spec_version_constraint configuration not valid: %s", err)
+	}
+	var specVersionErr error
+	specVersion := analyser.getSpecVersion()
+	if specVersion == "" {
+		specVersionErr = fmt.Errorf("the OpenAPI document does not declare the API version (info.version) required by the spec_version_constraint '%s'", constraint)
+	} else if compatible, err := versionConstraint.check(specVersion); err != nil {
+		specVersionErr = fmt.Errorf("the OpenAPI document API version (info.version) can not be checked against the spec_version_constraint '%s': %s", constraint, err)
+	} else if !compatible {
+		specVersionErr = fmt.Errorf("the OpenAPI document API version (info.version) '%s' does not meet the spec_version_constraint '%s' in the plugin configuration file", specVersion, constraint)
+	}
+	if specVersionErr != nil && serviceConfiguration.GetSpecVersionCheck() == specVersionCheckWarn {
+		log.Printf("[WARN] %s", specVersionErr)
+		return nil
+	}
+	return specVersionErr
+}
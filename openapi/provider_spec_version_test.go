@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecVersionConstraintCheck(t *testing.T) {
+	testCases := []struct {
+		name               string
+		constraint         string
+		version            string
+		expectedCompatible bool
+	}{
+		{name: "version within range", constraint: ">= 1.2.0, < 2.0.0", version: "1.5.3", expectedCompatible: true},
+		{name: "version equal to the lower bound", constraint: ">= 1.2.0, < 2.0.0", version: "1.2.0", expectedCompatible: true},
+		{name: "version equal to the upper bound", constraint: ">= 1.2.0, < 2.0.0", version: "2.0.0", expectedCompatible: false},
+		{name: "version lower than the lower bound", constraint: ">= 1.2.0, < 2.0.0", version: "1.1.9", expectedCompatible: false},
+		{name: "version with fewer segments", constraint: ">= 1.2.0", version: "1.2", expectedCompatible: true},
+		{name: "version with v prefix and pre-release", constraint: "> 1.0", version: "v1.0.1-beta", expectedCompatible: true},
+		{name: "exact version without operator", constraint: "1.2.0", version: "1.2.0", expectedCompatible: true},
+		{name: "exact version mismatch", constraint: "= 1.2.0", version: "1.2.1", expectedCompatible: false},
+		{name: "excluded version", constraint: "!= 1.3.0", version: "1.3.0", expectedCompatible: false},
+		{name: "lower or equal", constraint: "<= 1.3", version: "1.3.0", expectedCompatible: true},
+		{name: "pessimistic constraint with minor version allows minor upgrades", constraint: "~> 1.2", version: "1.9.0", expectedCompatible: true},
+		{name: "pessimistic constraint with minor version rejects major upgrades", constraint: "~> 1.2", version: "2.0.0", expectedCompatible: false},
+		{name: "pessimistic constraint with patch version allows patch upgrades", constraint: "~> 1.2.3", version: "1.2.9", expectedCompatible: true},
+		{name: "pessimistic constraint with patch version rejects minor upgrades", constraint: "~> 1.2.3", version: "1.3.0", expectedCompatible: false},
+		{name: "pessimistic constraint with major version only", constraint: "~> 1", version: "1.9.0", expectedCompatible: true},
+	}
+	for _, tc := range testCases {
+		constraint, err := newSpecVersionConstraint(tc.constraint)
+		assert.NoError(t, err, tc.name)
+		compatible, err := constraint.check(tc.version)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedCompatible, compatible, tc.name)
+	}
+}
+
+func TestNewSpecVersionConstraintErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		constraint    string
+		expectedError string
+	}{
+		{name: "version not numeric", constraint: ">= one", expectedError: "version constraint '>= one' not valid: version 'one' not valid"},
+		{name: "empty condition", constraint: ">= 1.0,", expectedError: "version constraint '>= 1.0,' not valid: empty condition"},
+		{name: "operator without version", constraint: "<", expectedError: "version constraint '<' not valid: version '' not valid"},
+	}
+	for _, tc := range testCases {
+		_, err := newSpecVersionConstraint(tc.constraint)
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestCheckSpecVersion(t *testing.T) {
+	Convey("Given a spec analyser for an OpenAPI document with version 2.1.0", t, func() {
+		specAnalyser := &specAnalyserStub{specVersion: "2.1.0"}
+		Convey("When checkSpecVersion is called with a service configuration that does not configure a version constraint", func() {
+			err := checkSpecVersion(specAnalyser, &ServiceConfigStub{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When checkSpecVersion is called with a version constraint the version meets", func() {
+			err := checkSpecVersion(specAnalyser, &ServiceConfigStub{SpecVersionConstraint: ">= 2.0.0, < 3.0.0", SpecVersionCheck: specVersionCheckError})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When checkSpecVersion is called with a version constraint the version does not meet", func() {
+			err := checkSpecVersion(specAnalyser, &ServiceConfigStub{SpecVersionConstraint: ">= 1.2.0, < 2.0.0", SpecVersionCheck: specVersionCheckError})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the OpenAPI document API version (info.version) '2.1.0' does not meet the spec_version_constraint '>= 1.2.0, < 2.0.0' in the plugin configuration file")
+			})
+		})
+		Convey("When checkSpecVersion is called with a version constraint the version does not meet and the version check configured to warn", func() {
+			err := checkSpecVersion(specAnalyser, &ServiceConfigStub{SpecVersionConstraint: ">= 1.2.0, < 2.0.0", SpecVersionCheck: specVersionCheckWarn})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When checkSpecVersion is called with an invalid version constraint", func() {
+			err := checkSpecVersion(specAnalyser, &ServiceConfigStub{SpecVersionConstraint: ">= latest", SpecVersionCheck: specVersionCheckWarn})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service spec_version_constraint configuration not valid: version constraint '>= latest' not valid: version 'latest' not valid")
+			})
+		})
+	})
+	Convey("Given a spec analyser for an OpenAPI document that does not declare the version", t, func() {
+		specAnalyser := &specAnalyserStub{}
+		Convey("When checkSpecVersion is called with a version constraint", func() {
+			err := checkSpecVersion(specAnalyser, &ServiceConfigStub{SpecVersionConstraint: ">= 1.2.0", SpecVersionCheck: specVersionCheckError})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the OpenAPI document does not declare the API version (info.version) required by the spec_version_constraint '>= 1.2.0'")
+			})
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"log"
+	"time"
+)
+
+// specFingerprintAnalyser is implemented by the spec analysers that support computing a fingerprint of the OpenAPI
+// document, which is used to detect material changes in the document
+type specFingerprintAnalyser interface {
+	getSpecFingerprint() (string, error)
+}
+
+// specWatcher periodically fetches and re-validates the OpenAPI document the provider was created from. The provider
+// schema can not change while the plugin process is running, hence when the plugin runs as a long-lived process (e,g:
+// Terraform Cloud agents) a warning is logged if the upstream document is no longer valid or has materially changed
+// since the process started, so operators know the plugin needs to be restarted to pick up the changes.
+type specWatcher struct {
+	openAPIDocumentURL string
+	interval           time.Duration
+	// fingerprint is the fingerprint of the OpenAPI document the provider was created from
+	fingerprint string
+	// reportedFingerprint is the fingerprint of the last change reported, so the same change is not reported on each check
+	reportedFingerprint string
+	// createSpecAnalyser loads and analyses the OpenAPI document from the given URL
+	createSpecAnalyser func(openAPIDocumentURL string) (SpecAnalyser, error)
+	stop               chan struct{}
+}
+
+// newSpecWatcher returns a specWatcher for the OpenAPI document analysed by the given spec analyser. Nil is returned if
+// the spec analyser does not support computing the fingerprint of the document
+func newSpecWatcher(openAPIDocumentURL string, interval time.Duration, specAnalyser SpecAnalyser) *specWatcher {
+	analyser, ok := specAnalyser.(specFingerprintAnalyser)
+	if !ok {
+		return nil
+	}
+	fingerprint, err := analyser.getSpecFingerprint()
+	if err != nil {
+		log.Printf("[WARN] the OpenAPI document '%s' will not be re-validated: failed to compute the document fingerprint: %s", openAPIDocumentURL, err)
+		return nil
+	}
+	return &specWatcher{
+		openAPIDocumentURL: openAPIDocumentURL,
+		interval:           interval,
+		fingerprint:        fingerprint,
+		createSpecAnalyser: func(openAPIDocumentURL string) (SpecAnalyser, error) {
+			return CreateSpecAnalyser(specAnalyserV2, openAPIDocumentURL)
+		},
+		stop: make(chan struct{}),
+	}
+}
+
+// start re-validates the OpenAPI document on each interval until stop is called
+func (w *specWatcher) start() {
+	log.Printf("[INFO] the OpenAPI document '%s' will be re-validated every %s", w.openAPIDocumentURL, w.interval)
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.check()
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopWatching stops re-validating the OpenAPI document
+func (w *specWatcher) stopWatching() {
+	close(w.stop)
+}
+
+// check fetches and re-validates the OpenAPI document, logging a warning if the document is no longer valid or has
+// materially changed since the provider was created. Returns true if a change that had not been reported yet was found.
+func (w *specWatcher) check() bool {
+	specAnalyser, err := w.createSpecAnalyser(w.openAPIDocumentURL)
+	if err != nil {
+		log.Printf("[WARN] the OpenAPI document '%s' failed re-validation, the provider keeps using the document loaded when the plugin process started: %s", w.openAPIDocumentURL, err)
+		return false
+	}
+	if _, err := specAnalyser.GetTerraformCompliantResources(); err != nil {
+		log.Printf("[WARN] the OpenAPI document '%s' failed re-validation, the provider keeps using the document loaded when the plugin process started: %s", w.openAPIDocumentURL, err)
+		return false
+	}
+	analyser, ok := specAnalyser.(specFingerprintAnalyser)
+	if !ok {
+		return false
+	}
+	fingerprint, err := analyser.getSpecFingerprint()
+	if err != nil {
+		log.Printf("[WARN] failed to compute the fingerprint of the OpenAPI document '%s': %s", w.openAPIDocumentURL, err)
+		return false
+	}
+	if fingerprint == w.fingerprint || fingerprint == w.reportedFingerprint {
+		return false
+	}
+	w.reportedFingerprint = fingerprint
+	log.Printf("[WARN] the OpenAPI document '%s' has materially changed since the plugin process started, the provider keeps using the document loaded at start up until the plugin process is restarted", w.openAPIDocumentURL)
+	return true
+}
@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// specFingerprintAnalyserStub is a spec analyser stub that supports computing the fingerprint of the OpenAPI document
+type specFingerprintAnalyserStub struct {
+	*specAnalyserStub
+	fingerprint string
+}
+
+func (s *specFingerprintAnalyserStub) getSpecFingerprint() (string, error) {
+	return s.fingerprint, nil
+}
+
+func TestNewSpecWatcher(t *testing.T) {
+	Convey("Given a spec analyser that supports computing the fingerprint of the OpenAPI document", t, func() {
+		specAnalyser := &specFingerprintAnalyserStub{specAnalyserStub: &specAnalyserStub{}, fingerprint: "fingerprint"}
+		Convey("When newSpecWatcher is called", func() {
+			watcher := newSpecWatcher("https://api.example.com/swagger.yaml", time.Hour, specAnalyser)
+			Convey("Then the watcher returned should be configured with the document fingerprint", func() {
+				So(watcher, ShouldNotBeNil)
+				So(watcher.openAPIDocumentURL, ShouldEqual, "https://api.example.com/swagger.yaml")
+				So(watcher.interval, ShouldEqual, time.Hour)
+				So(watcher.fingerprint, ShouldEqual, "fingerprint")
+			})
+		})
+	})
+	Convey("Given a spec analyser that does not support computing the fingerprint of the OpenAPI document", t, func() {
+		specAnalyser := &specAnalyserStub{}
+		Convey("When newSpecWatcher is called", func() {
+			watcher := newSpecWatcher("https://api.example.com/swagger.yaml", time.Hour, specAnalyser)
+			Convey("Then the watcher returned should be nil", func() {
+				So(watcher, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestSpecWatcherCheck(t *testing.T) {
+	Convey("Given a spec watcher created from an OpenAPI document", t, func() {
+		var upstreamSpecAnalyser SpecAnalyser
+		var upstreamErr error
+		watcher := newSpecWatcher("https://api.example.com/swagger.yaml", time.Hour, &specFingerprintAnalyserStub{specAnalyserStub: &specAnalyserStub{}, fingerprint: "original"})
+		watcher.createSpecAnalyser = func(openAPIDocumentURL string) (SpecAnalyser, error) {
+			return upstreamSpecAnalyser, upstreamErr
+		}
+		Convey("When check is called and the upstream document has not changed", func() {
+			upstreamSpecAnalyser = &specFingerprintAnalyserStub{specAnalyserStub: &specAnalyserStub{}, fingerprint: "original"}
+			changed := watcher.check()
+			Convey("Then no change should be reported", func() {
+				So(changed, ShouldBeFalse)
+			})
+		})
+		Convey("When check is called several times and the upstream document has materially changed", func() {
+			upstreamSpecAnalyser = &specFingerprintAnalyserStub{specAnalyserStub: &specAnalyserStub{}, fingerprint: "updated"}
+			firstCheck := watcher.check()
+			secondCheck := watcher.check()
+			Convey("Then the change should only be reported once", func() {
+				So(firstCheck, ShouldBeTrue)
+				So(secondCheck, ShouldBeFalse)
+				So(watcher.fingerprint, ShouldEqual, "original")
+				So(watcher.reportedFingerprint, ShouldEqual, "updated")
+			})
+		})
+		Convey("When check is called and the upstream document can not be loaded", func() {
+			upstreamErr = errors.New("connection refused")
+			changed := watcher.check()
+			Convey("Then no change should be reported", func() {
+				So(changed, ShouldBeFalse)
+			})
+		})
+		Convey("When check is called and the upstream document is no longer valid", func() {
+			upstreamSpecAnalyser = &specFingerprintAnalyserStub{specAnalyserStub: &specAnalyserStub{error: errors.New("invalid document")}, fingerprint: "updated"}
+			changed := watcher.check()
+			Convey("Then no change should be reported", func() {
+				So(changed, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestCreateSchemaProviderFromServiceConfigurationStopsPreviousSpecWatcher(t *testing.T) {
+	Convey("Given a ProviderOpenAPI which spec watcher was started for a provider previously created", t, func() {
+		swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`swagger: "2.0"
+paths: {}`))
+		}))
+		defer swaggerServer.Close()
+		previousWatcher := newSpecWatcher("https://api.example.com/swagger.yaml", time.Hour, &specFingerprintAnalyserStub{specAnalyserStub: &specAnalyserStub{}, fingerprint: "original"})
+		previousWatcher.start()
+		p := ProviderOpenAPI{ProviderName: "openapi", specWatcher: previousWatcher}
+		Convey("When CreateSchemaProviderFromServiceConfiguration is called", func() {
+			_, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL, SpecRevalidationInterval: time.Hour})
+			Convey("Then the previous spec watcher should be stopped and replaced", func() {
+				So(err, ShouldBeNil)
+				stopped := false
+				select {
+				case <-previousWatcher.stop:
+					stopped = true
+				default:
+				}
+				So(stopped, ShouldBeTrue)
+				So(p.specWatcher, ShouldNotBeNil)
+				So(p.specWatcher, ShouldNotEqual, previousWatcher)
+				p.specWatcher.stopWatching()
+			})
+		})
+	})
+}
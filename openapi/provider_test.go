@@ -353,7 +353,9 @@ definitions:
 
 				// the provider dataSource map should contain the cdn resource with the expected configuration
 				So(tfProvider.DataSourcesMap, ShouldNotBeNil)
-				So(len(tfProvider.DataSourcesMap), ShouldEqual, 1)
+				So(len(tfProvider.DataSourcesMap), ShouldEqual, 3)
+				So(tfProvider.DataSourcesMap, ShouldContainKey, fmt.Sprintf("%s_cdn_datasource_v1_ids", providerName))
+				So(tfProvider.DataSourcesMap, ShouldContainKey, fmt.Sprintf("%s_cdn_datasource_v1_list", providerName))
 				resourceName := fmt.Sprintf("%s_cdn_datasource_v1", providerName)
 				So(tfProvider.DataSourcesMap, ShouldContainKey, resourceName)
 				resourceName = fmt.Sprintf("%s_cdn_datasource_v1", providerName)
@@ -430,7 +432,7 @@ definitions:
 				So(err, ShouldBeNil)
 				So(tfProvider.Schema, ShouldNotBeNil)
 				So(tfProvider.DataSourcesMap, ShouldNotBeNil)
-				So(len(tfProvider.DataSourcesMap), ShouldEqual, 1)
+				So(len(tfProvider.DataSourcesMap), ShouldEqual, 3)
 
 				dataSourceName := fmt.Sprintf("%s_cdns_v1_firewalls", providerName)
 				So(tfProvider.DataSourcesMap, ShouldContainKey, dataSourceName)
@@ -451,6 +453,20 @@ definitions:
 				So(elements["name"].Type, ShouldEqual, schema.TypeString)
 				So(elements["values"].Type, ShouldEqual, schema.TypeList)
 				So(tfProvider.DataSourcesMap[dataSourceName].Read, ShouldNotBeNil)
+
+				// check the IDs data source only requires the parent id and exposes the ids of the sub-resources
+				dataSourceIDsName := fmt.Sprintf("%s_cdns_v1_firewalls_ids", providerName)
+				So(tfProvider.DataSourcesMap, ShouldContainKey, dataSourceIDsName)
+				So(tfProvider.DataSourcesMap[dataSourceIDsName].Schema, ShouldHaveLength, 5)
+				assertTerraformSchemaProperty(t, tfProvider.DataSourcesMap[dataSourceIDsName].Schema["cdns_v1_id"], schema.TypeString, true, false)
+				So(tfProvider.DataSourcesMap[dataSourceIDsName].Schema["ids"].Computed, ShouldBeTrue)
+				So(tfProvider.DataSourcesMap[dataSourceIDsName].Schema[dataSourceTotalCountPropertyName].Computed, ShouldBeTrue)
+				So(tfProvider.DataSourcesMap[dataSourceIDsName].Schema, ShouldContainKey, dataSourceSortByPropertyName)
+				So(tfProvider.DataSourcesMap[dataSourceIDsName].Schema, ShouldContainKey, dataSourceSortOrderPropertyName)
+				dataSourceListName := fmt.Sprintf("%s_cdns_v1_firewalls_list", providerName)
+				So(tfProvider.DataSourcesMap, ShouldContainKey, dataSourceListName)
+				assertTerraformSchemaProperty(t, tfProvider.DataSourcesMap[dataSourceListName].Schema["cdns_v1_id"], schema.TypeString, true, false)
+				So(tfProvider.DataSourcesMap[dataSourceListName].Schema[dataSourceListResultsPropertyName].Computed, ShouldBeTrue)
 				So(tfProvider.ResourcesMap, ShouldBeEmpty)
 				So(tfProvider.ConfigureFunc, ShouldNotBeNil)
 			})
@@ -664,41 +680,36 @@ definitions:
 			path2             string
 			preferredName2    string
 			expectedResources int
-			expectedWarning   string
+			expectedError     string
 		}{
 			{
-				label:             "resources with colliding x-terraform-resource-names",
-				preferredName1:    "collision",
-				preferredName2:    "collision",
-				expectedResources: 0,
-				expectedWarning:   "'collision_v1' is a duplicate resource name and is being removed from the provider"},
+				label:          "resources with colliding x-terraform-resource-names",
+				preferredName1: "collision",
+				preferredName2: "collision",
+				expectedError:  "resource name 'collision_v1' is used by more than one resource ('/v1/abc' and '/v1/xyz'), use the 'x-terraform-resource-name' extension to give them different names"},
 			{
-				label:             "resources with colliding x-terraform-resource-name calculated name and calculated versioned name",
-				path1:             "/v1/collision",
-				path2:             "/xyz",
-				preferredName2:    "collision_v1",
-				expectedResources: 0,
-				expectedWarning:   "'collision_v1' is a duplicate resource name and is being removed from the provider"},
+				label:          "resources with colliding x-terraform-resource-name calculated name and calculated versioned name",
+				path1:          "/v1/collision",
+				path2:          "/xyz",
+				preferredName2: "collision_v1",
+				expectedError:  "resource name 'collision_v1' is used by more than one resource ('/v1/collision' and '/xyz'), use the 'x-terraform-resource-name' extension to give them different names"},
 			{
-				label:             "resources with colliding x-terraform-resource-name calculated preferred name and calculated versioned name",
-				path1:             "/v1/collision",
-				path2:             "/v1/xyz",
-				preferredName2:    "collision",
-				expectedResources: 0,
-				expectedWarning:   "'collision_v1' is a duplicate resource name and is being removed from the provider"},
+				label:          "resources with colliding x-terraform-resource-name calculated preferred name and calculated versioned name",
+				path1:          "/v1/collision",
+				path2:          "/v1/xyz",
+				preferredName2: "collision",
+				expectedError:  "resource name 'collision_v1' is used by more than one resource ('/v1/collision' and '/v1/xyz'), use the 'x-terraform-resource-name' extension to give them different names"},
 			{
-				label:             "resources with colliding calculated names",
-				path1:             "/v1/collision",
-				path2:             "/collision_v1",
-				expectedResources: 0,
-				expectedWarning:   "'collision_v1' is a duplicate resource name and is being removed from the provider"},
+				label:         "resources with colliding calculated names",
+				path1:         "/v1/collision",
+				path2:         "/collision_v1",
+				expectedError: "resource name 'collision_v1' is used by more than one resource ('/collision_v1' and '/v1/collision'), use the 'x-terraform-resource-name' extension to give them different names"},
 			{
 				label:             "resources with identical paths and a colliding preferred name",
 				path1:             "/v1/collision",
 				path2:             "/v1/collision",
 				preferredName1:    "collision_v1",
 				expectedResources: 1,
-				expectedWarning:   "",
 			},
 			{
 				label:             "resources with identical paths and identical preferred names",
@@ -707,7 +718,6 @@ definitions:
 				preferredName1:    "collision",
 				preferredName2:    "collision",
 				expectedResources: 1,
-				expectedWarning:   "",
 			},
 		}
 
@@ -719,13 +729,14 @@ definitions:
 			Convey(fmt.Sprintf("When CreateSchemaProviderFromServiceConfiguration method is called: %s", tc.label), func() {
 				tfProvider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerDocServerURL(swaggerDoc)})
 				Convey("Then the result returned should be the expected one", func() {
+					if tc.expectedError != "" {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldContainSubstring, tc.expectedError)
+						return
+					}
 					So(err, ShouldBeNil)
 					So(len(tfProvider.ResourcesMap), ShouldEqual, tc.expectedResources)
-					if tc.expectedWarning == "" {
-						So(out.written, ShouldNotContainSubstring, "duplicate resource name")
-					} else {
-						So(out.written, ShouldContainSubstring, tc.expectedWarning)
-					}
+					So(out.written, ShouldNotContainSubstring, "duplicate resource name")
 				})
 			})
 		}
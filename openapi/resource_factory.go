@@ -1,11 +1,14 @@
 package openapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -21,11 +24,59 @@ type resourceFactory struct {
 	defaultPollInterval   time.Duration
 	defaultPollMinTimeout time.Duration
 	defaultPollDelay      time.Duration
+	stateUpgradeFuncs     map[int]schema.StateUpgradeFunc
+	// multiRegion is true if the API is multi-region (x-terraform-provider-multiregion-fqdn), in which case the region of
+	// the resources is tracked in the state
+	multiRegion bool
+	// apiVersions contains the versions the resource is exposed in (including the version of the resource) indexed by
+	// the version (e,g: v1), if the resource is exposed in multiple versions (e,g: /v1/cdns and /v2/cdns)
+	apiVersions map[string]SpecResource
+	// payloadValidator validates the request payloads against the JSON Schema of the resource before they are sent to
+	// the API; nil if the provider is not configured with one
+	payloadValidator PayloadValidator
+	// refreshSkipWindowConfigured is true if the provider block sets the refresh_skip_window property, in which case the
+	// resource maintains the last_read_at attribute
+	refreshSkipWindowConfigured bool
+	// identityHeadersConfigured is true if the provider block sets the identity_headers property, in which case the
+	// resource exposes the identity_headers attribute to override them
+	identityHeadersConfigured bool
 }
 
+// resourceKind is the kind of terraform resource used in the errors returned by the resource operations
+const resourceKind = "resource"
+
+// lastReadAtAttribute is the computed attribute maintained by the provider with the time (RFC3339) the resource was last
+// read from the API
+const lastReadAtAttribute = "last_read_at"
+
+// regionAttribute is the computed attribute maintained by the provider with the region the resource is managed in when
+// the API is multi-region
+const regionAttribute = "region"
+
+// parentRegionAttribute is the optional attribute of multi-region sub-resources containing the region of the parent
+// resource (e,g: <parent_resource>.<name>.region), which is validated against the region of the sub-resource at plan time
+const parentRegionAttribute = "parent_region"
+
+// apiVersionAttribute is the optional attribute of the resources exposed in multiple versions which selects the version
+// of the resource path the API calls of the resource instance are made against
+const apiVersionAttribute = "api_version"
+
+// identityHeadersAttribute is the optional attribute of the resources containing the identity headers (e,g: X-Tenant-Id)
+// that override the identity headers configured in the provider for the API calls of the resource instance
+const identityHeadersAttribute = "identity_headers"
+
+// importParentIDEnvVarPrefix is the prefix of the environment variables that can be used to provide the parent IDs when
+// importing a sub-resource
+const importParentIDEnvVarPrefix = "OPENAPI_IMPORT_"
+
 // only applicable when remote resource no longer exists and GET operations return 404 NotFound
 const defaultDestroyStatus = "destroyed"
 
+// internal statuses used when polling resources configured with completed conditions, which are met once all the
+// conditions are met and pending until then
+const pollConditionsMetStatus = "conditions_met"
+const pollConditionsPendingStatus = "conditions_pending"
+
 var defaultPollInterval = time.Duration(5 * time.Second)
 var defaultPollMinTimeout = time.Duration(10 * time.Second)
 var defaultPollDelay = time.Duration(1 * time.Second)
@@ -52,15 +103,29 @@ func (r resourceFactory) createTerraformResource() (*schema.Resource, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &schema.Resource{
-		Schema:   s,
-		Create:   r.create,
-		Read:     r.read,
-		Delete:   r.delete,
-		Update:   r.update,
-		Importer: r.importer(),
-		Timeouts: timeouts,
-	}, nil
+	schemaVersion, err := r.openAPIResource.getSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	resource := &schema.Resource{
+		Schema:             s,
+		Create:             r.withReadOnlyModeCheck(TelemetryResourceOperationCreate, r.withOperationNotification(TelemetryResourceOperationCreate, r.create)),
+		Read:               r.read,
+		Delete:             r.withReadOnlyModeCheck(TelemetryResourceOperationDelete, r.withOperationNotification(TelemetryResourceOperationDelete, r.delete)),
+		Update:             r.withReadOnlyModeCheck(TelemetryResourceOperationUpdate, r.withOperationNotification(TelemetryResourceOperationUpdate, r.update)),
+		Importer:           r.importer(),
+		Timeouts:           timeouts,
+		SchemaVersion:      schemaVersion,
+		StateUpgraders:     r.createStateUpgraders(schemaVersion, s),
+		DeprecationMessage: r.openAPIResource.getDeprecationMessage(),
+	}
+	if r.multiRegion || len(r.getResourceQueryParamAttributes()) > 0 || len(r.getForceNewItemFieldsProperties()) > 0 || r.payloadValidator != nil {
+		resource.CustomizeDiff = r.customizeDiff
+	}
+	if r.openAPIResource.getResourceOperations().Head != nil {
+		resource.Exists = r.exists
+	}
+	return resource, nil
 }
 
 func (r resourceFactory) createSchemaResourceTimeout() (*schema.ResourceTimeout, error) {
@@ -84,37 +149,287 @@ func (r resourceFactory) createTerraformResourceSchema() (map[string]*schema.Sch
 		return nil, err
 	}
 	log.Printf("[DEBUG] resource '%s' schemaDefinition: %s", r.openAPIResource.GetResourceName(), sPrettyPrint(schemaDefinition))
-	return schemaDefinition.createResourceSchema()
+	s, err := schemaDefinition.createResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	if r.maintainsLastReadAt() {
+		s[lastReadAtAttribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time (RFC3339) the resource was last read from the API, used to skip the refresh of the resource if the provider is configured with refresh_skip_window",
+		}
+	} else if r.refreshSkipWindowConfigured {
+		log.Printf("[WARN] the '%s' attribute can not be added to resource '%s' as there is already a property named '%s', the refresh of the resource will not be skipped", lastReadAtAttribute, r.openAPIResource.GetResourceName(), lastReadAtAttribute)
+	}
+	if r.maintainsRegion() {
+		s[regionAttribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Region the resource is managed in",
+		}
+	} else if r.multiRegion {
+		log.Printf("[WARN] the '%s' attribute can not be added to resource '%s' as there is already a property named '%s', the region of the resource will not be tracked", regionAttribute, r.openAPIResource.GetResourceName(), regionAttribute)
+	}
+	if r.supportsParentRegion() {
+		s[parentRegionAttribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Region of the parent resource (e,g: <parent_resource>.<name>.region). If set, it must match the region the resource is managed in",
+		}
+	} else if r.multiRegion && r.openAPIResource.GetParentResourceInfo() != nil {
+		log.Printf("[WARN] the '%s' attribute can not be added to resource '%s' as there is already a property named '%s', the region of the parent will not be validated", parentRegionAttribute, r.openAPIResource.GetResourceName(), parentRegionAttribute)
+	}
+	if r.supportsAPIVersion() {
+		versions := r.getAPIVersions()
+		s[apiVersionAttribute] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: apiVersionValidateFunc(versions),
+			Description:  fmt.Sprintf("Version of the resource path the API calls are made against (%s). If not set, the version of the resource is used", strings.Join(versions, ", ")),
+		}
+	} else if len(r.apiVersions) > 1 {
+		log.Printf("[WARN] the '%s' attribute can not be added to resource '%s' as there is already a property named '%s', the version of the resource path can not be selected", apiVersionAttribute, r.openAPIResource.GetResourceName(), apiVersionAttribute)
+	}
+	if r.supportsIdentityHeaders() {
+		s[identityHeadersAttribute] = &schema.Schema{
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Identity headers (e,g: X-Tenant-Id) sent in the API requests of the resource, indexed by the header name. They override the identity headers configured in the provider",
+		}
+	} else if r.identityHeadersConfigured {
+		log.Printf("[WARN] the '%s' attribute can not be added to resource '%s' as there is already a property named '%s', the identity headers configured in the provider can not be overridden", identityHeadersAttribute, r.openAPIResource.GetResourceName(), identityHeadersAttribute)
+	}
+	for attributeName, headerName := range r.openAPIResource.getResponseHeaderAttributes() {
+		if _, exists := s[attributeName]; exists {
+			log.Printf("[WARN] response header '%s' can not be exposed as an attribute of resource '%s' as there is already a property named '%s'", headerName, r.openAPIResource.GetResourceName(), attributeName)
+			continue
+		}
+		s[attributeName] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Value of the '%s' header returned by the API in the last create, read or update response containing it", headerName),
+		}
+	}
+	for _, headerParam := range r.getResourceHeaderAttributes() {
+		headerTerraformName := headerParam.GetHeaderTerraformConfigurationName()
+		if _, exists := s[headerTerraformName]; exists {
+			log.Printf("[WARN] header '%s' can not be exposed as an attribute of resource '%s' as there is already a property named '%s'", headerParam.Name, r.openAPIResource.GetResourceName(), headerTerraformName)
+			continue
+		}
+		s[headerTerraformName] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Value of the '%s' header sent in the API requests. If not set, the value configured in the provider is used", headerParam.Name),
+		}
+	}
+	for _, queryParam := range r.getResourceQueryParamAttributes() {
+		queryParamTerraformName := queryParam.GetQueryParamTerraformName()
+		if _, exists := s[queryParamTerraformName]; exists {
+			log.Printf("[WARN] query parameter '%s' can not be exposed as an attribute of resource '%s' as there is already a property named '%s'", queryParam.Name, r.openAPIResource.GetResourceName(), queryParamTerraformName)
+			continue
+		}
+		s[queryParamTerraformName] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Value of the '%s' query parameter sent in the API requests", queryParam.Name),
+		}
+	}
+	return s, nil
+}
+
+// supportsAPIVersion returns true if the resource exposes the api_version attribute, which is the case for the resources
+// exposed in multiple versions unless the resource schema already contains a property with the same name
+func (r resourceFactory) supportsAPIVersion() bool {
+	return len(r.apiVersions) > 1 && !r.hasPropertyNamed(apiVersionAttribute)
+}
+
+// supportsIdentityHeaders returns true if the resource exposes the identity_headers attribute, which is the case if the
+// provider is configured with identity_headers unless the resource schema already contains a property with the same name
+func (r resourceFactory) supportsIdentityHeaders() bool {
+	return r.identityHeadersConfigured && !r.hasPropertyNamed(identityHeadersAttribute)
+}
+
+// getAPIVersions returns the sorted versions the resource is exposed in
+func (r resourceFactory) getAPIVersions() []string {
+	var versions []string
+	for version := range r.apiVersions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+func apiVersionValidateFunc(versions []string) schema.SchemaValidateFunc {
+	return func(value interface{}, key string) (warns []string, errs []error) {
+		for _, version := range versions {
+			if value.(string) == version {
+				return
+			}
+		}
+		errs = append(errs, fmt.Errorf("property '%s' value '%s' is not valid, the supported values are: %s", key, value, strings.Join(versions, ", ")))
+		return
+	}
+}
+
+// withAPIVersion returns a copy of the resource factory which API calls are made against the path of the version
+// configured in the api_version attribute of the resource instance (e,g: /v2/cdns instead of /v1/cdns). If the version
+// is not configured, the resource factory is returned as is.
+func (r resourceFactory) withAPIVersion(data *schema.ResourceData) resourceFactory {
+	if !r.supportsAPIVersion() {
+		return r
+	}
+	apiVersion, _ := data.Get(apiVersionAttribute).(string)
+	apiVersionResource, exists := r.apiVersions[apiVersion]
+	if !exists || apiVersionResource == r.openAPIResource {
+		return r
+	}
+	log.Printf("[DEBUG] [%s='%s'] the API calls of '%s' are made against the resource path of version '%s'", resourceKind, r.openAPIResource.GetResourceName(), data.Id(), apiVersion)
+	r.openAPIResource = apiVersionSpecResource{SpecResource: r.openAPIResource, apiVersionResource: apiVersionResource}
+	return r
+}
+
+// getResourceHeaderAttributes returns the header parameters of the resource operations that are exposed as resource attributes
+func (r resourceFactory) getResourceHeaderAttributes() SpecHeaderParameters {
+	headerAttributes := SpecHeaderParameters{}
+	operations := r.openAPIResource.getResourceOperations()
+	for _, operation := range []*specResourceOperation{operations.Post, operations.Get, operations.Put, operations.Delete} {
+		if operation == nil {
+			continue
+		}
+		for _, headerParam := range operation.HeaderParameters {
+			if headerParam.IsResourceAttribute && !headerAttributes.specHeaderExists(headerParam) {
+				headerAttributes = append(headerAttributes, headerParam)
+			}
+		}
+	}
+	return headerAttributes
+}
+
+// getResourceQueryParamAttributes returns the query parameters of the resource operations that are exposed as resource attributes
+func (r resourceFactory) getResourceQueryParamAttributes() SpecQueryParameters {
+	queryParamAttributes := SpecQueryParameters{}
+	operations := r.openAPIResource.getResourceOperations()
+	for _, operation := range []*specResourceOperation{operations.Post, operations.Get, operations.Put, operations.Delete} {
+		if operation == nil {
+			continue
+		}
+		for _, queryParam := range operation.QueryParameters {
+			if queryParam.IsResourceAttribute && !queryParamAttributes.specQueryParamExists(queryParam) {
+				queryParamAttributes = append(queryParamAttributes, queryParam)
+			}
+		}
+	}
+	return queryParamAttributes
+}
+
+// getClientWithResourceAttributes returns a client configured to send the header values set in the resource header attributes
+// and the identity headers set in the identity_headers attribute instead of the values configured in the provider as well
+// as the query parameter values set in the resource query parameter attributes. If the client does not support it, the
+// given client is returned.
+func (r resourceFactory) getClientWithResourceAttributes(providerClient ClientOpenAPI, data *schema.ResourceData) ClientOpenAPI {
+	if client, ok := providerClient.(resourceHeadersClient); ok {
+		headers := map[string]string{}
+		for _, headerParam := range r.getResourceHeaderAttributes() {
+			headerTerraformName := headerParam.GetHeaderTerraformConfigurationName()
+			if value, exists := data.GetOk(headerTerraformName); exists {
+				headers[headerTerraformName] = value.(string)
+			}
+		}
+		providerClient = client.withResourceHeaders(headers)
+	}
+	if client, ok := providerClient.(resourceQueryParametersClient); ok {
+		queryParameters := map[string]string{}
+		for _, queryParam := range r.getResourceQueryParamAttributes() {
+			queryParamTerraformName := queryParam.GetQueryParamTerraformName()
+			if value, exists := data.GetOk(queryParamTerraformName); exists {
+				queryParameters[queryParamTerraformName] = value.(string)
+			}
+		}
+		providerClient = client.withResourceQueryParameters(queryParameters)
+	}
+	if client, ok := providerClient.(identityHeadersClient); ok && r.supportsIdentityHeaders() {
+		identityHeaders := map[string]string{}
+		if values, ok := data.Get(identityHeadersAttribute).(map[string]interface{}); ok {
+			for headerName, value := range values {
+				identityHeaders[headerName] = fmt.Sprint(value)
+			}
+		}
+		providerClient = client.withIdentityHeaders(identityHeaders)
+	}
+	return providerClient
+}
+
+// getClientWithTimeout returns a client that keeps retrying the requests failing with retryable errors until the
+// resource timeout configured for the given operation (e,g: schema.TimeoutCreate) expires. If the client does not
+// support it, the given client is returned.
+func (r resourceFactory) getClientWithTimeout(providerClient ClientOpenAPI, data *schema.ResourceData, timeoutFor string) ClientOpenAPI {
+	if client, ok := providerClient.(retryTimeoutClient); ok {
+		return client.withRetryTimeout(data.Timeout(timeoutFor))
+	}
+	return providerClient
 }
 
 func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error {
-	providerClient := i.(ClientOpenAPI)
+	providerClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
 
 	if r.openAPIResource == nil {
 		return fmt.Errorf("missing openAPI resource configuration")
 	}
+	r = r.withAPIVersion(data)
 	resourceName := r.openAPIResource.GetResourceName()
+	providerClient = r.getClientWithResourceAttributes(providerClient, data)
+	providerClient = r.getClientWithTimeout(providerClient, data, schema.TimeoutCreate)
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationCreate, resourceName, "")
 
+	if err := resolveParentIDs(r.openAPIResource, providerClient, data); err != nil {
+		return err
+	}
+
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
 		return err
 	}
 
+	if r.supportsParentRegion() {
+		if err := r.checkParentRegion(data.Get(parentRegionAttribute).(string), i); err != nil {
+			return err
+		}
+	}
+
+	if err := r.setClientGeneratedValues(data); err != nil {
+		return err
+	}
+
 	operation := r.openAPIResource.getResourceOperations().Post
 	requestPayload := r.createPayloadFromLocalStateData(data)
+	if err := r.validateRequestPayload(requestPayload); err != nil {
+		return err
+	}
 	responsePayload := map[string]interface{}{}
 
 	res, err := providerClient.Post(r.openAPIResource, requestPayload, &responsePayload, parentIDs...)
 	if err != nil {
-		return err
+		return newResourceOperationError(resourceKind, resourceName, http.MethodPost, resourcePath, err)
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
-		return fmt.Errorf("[resource='%s'] POST %s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, err)
+	if err := checkHTTPStatusCode(r.openAPIResource, res, operation.getSuccessStatusCodes([]int{http.StatusOK, http.StatusCreated, http.StatusAccepted}), operation.getErrorParser()); err != nil {
+		return newResourceOperationError(resourceKind, resourceName, http.MethodPost, resourcePath, err)
 	}
+	if err := operation.checkSuccessConditions(responsePayload); err != nil {
+		return newResourceOperationError(resourceKind, resourceName, http.MethodPost, resourcePath, err)
+	}
+	responseHeadersErr := populatePayloadWithResponseHeaders(r.openAPIResource, res, responsePayload)
 
+	// The ID is persisted in the state as soon as the API creates the resource, so if any of the following steps fail
+	// the resource is not lost from the state (leaking it in the API). Terraform marks the resource as tainted when the
+	// create operation fails after the ID is set, hence it will be replaced in the next apply.
 	err = setStateID(r.openAPIResource, data, responsePayload)
+	if responseHeadersErr != nil {
+		return r.createFailedAfterResourceCreated(data, newResourceOperationError(resourceKind, resourceName, http.MethodPost, resourcePath, responseHeadersErr))
+	}
 	if err != nil {
 		return err
 	}
@@ -122,59 +437,472 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 
 	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutCreate)
 	if err != nil {
-		return fmt.Errorf("polling mechanism failed after POST %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
+		return r.createFailedAfterResourceCreated(data, newResourceOperationError(resourceKind, resourceName, http.MethodPost, resourcePath, fmt.Errorf("polling mechanism failed after response status code (%d): %s", res.StatusCode, err)))
 	}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+	if err := encryptPayloadValues(r.openAPIResource, resourceKind, responsePayload, data, i); err != nil {
+		return r.createFailedAfterResourceCreated(data, err)
+	}
+	if err := checkUnknownPayloadFields(r.openAPIResource, responsePayload, i); err != nil {
+		return r.createFailedAfterResourceCreated(data, err)
+	}
+	if err := updateStateWithPayloadData(r.openAPIResource, responsePayload, data); err != nil {
+		return r.createFailedAfterResourceCreated(data, err)
+	}
+	if err := r.setResponseHeaderAttributes(data, res); err != nil {
+		return r.createFailedAfterResourceCreated(data, err)
+	}
+	if err := r.setLastReadAt(data, i); err != nil {
+		return r.createFailedAfterResourceCreated(data, err)
+	}
+	if err := r.setRegion(data, i); err != nil {
+		return r.createFailedAfterResourceCreated(data, err)
+	}
+	return nil
+}
+
+// withOperationNotification wraps the given mutating operation so the client notifies the result of the operation once
+// it completes (e,g: to the notification webhook configured in the provider)
+func (r resourceFactory) withOperationNotification(tfOperation TelemetryResourceOperation, operation func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(data *schema.ResourceData, i interface{}) error {
+		start := time.Now()
+		id := data.Id()
+		err := operation(data, i)
+		if client, ok := i.(operationNotificationClient); ok && r.openAPIResource != nil {
+			if data.Id() != "" {
+				id = data.Id()
+			}
+			client.notifyOperation(r.openAPIResource.GetResourceName(), id, tfOperation, time.Since(start), err)
+		}
+		return err
+	}
+}
+
+// withReadOnlyModeCheck wraps the given mutating operation so it fails without calling the API if the client is
+// configured in read only mode (e,g: read_only = true in the provider configuration)
+func (r resourceFactory) withReadOnlyModeCheck(tfOperation TelemetryResourceOperation, operation func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(data *schema.ResourceData, i interface{}) error {
+		if client, ok := i.(readOnlyModeClient); ok && client.isReadOnlyMode() {
+			resourceName := ""
+			if r.openAPIResource != nil {
+				resourceName = r.openAPIResource.GetResourceName()
+			}
+			return fmt.Errorf("[resource='%s'] %s operation not allowed: the provider is configured in read only mode (%s = true)", resourceName, tfOperation, providerPropertyReadOnly)
+		}
+		return operation(data, i)
+	}
+}
+
+// createFailedAfterResourceCreated returns the given error that occurred after the API created the resource. If the ID
+// is already in the state, the resource is kept in the state (marked as tainted by terraform) so it can be destroyed
+// or replaced in the next apply.
+func (r resourceFactory) createFailedAfterResourceCreated(data *schema.ResourceData, err error) error {
+	if data.Id() != "" {
+		log.Printf("[WARN] [%s='%s'] resource with id '%s' was created but the create operation failed afterwards, the resource is kept in the state as tainted: %s", resourceKind, r.openAPIResource.GetResourceName(), data.Id(), err)
+	}
+	return err
 }
 
 func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{}, handleNotFoundErr bool) error {
-	openAPIClient := i.(ClientOpenAPI)
+	_, err := r.readIntoState(data, i, handleNotFoundErr)
+	return err
+}
+
+// readIntoState reads the remote resource and saves it into the state. The payload returned by the API is returned too
+// (nil if the resource was not found and handleNotFoundErr is false).
+func (r resourceFactory) readIntoState(data *schema.ResourceData, i interface{}, handleNotFoundErr bool) (map[string]interface{}, error) {
+	openAPIClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
 
 	if r.openAPIResource == nil {
-		return fmt.Errorf("missing openAPI resource configuration")
+		return nil, fmt.Errorf("missing openAPI resource configuration")
 	}
+	r = r.withAPIVersion(data)
 	resourceName := r.openAPIResource.GetResourceName()
+	openAPIClient = r.getClientWithResourceAttributes(openAPIClient, data)
+	openAPIClient = r.getClientWithTimeout(openAPIClient, data, schema.TimeoutRead)
 
 	submitTelemetryMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "")
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	remoteData, err := r.readRemote(data.Id(), openAPIClient, parentsIDs...)
+	remoteData, res, err := r.refreshRemoteResponse(data.Id(), openAPIClient, parentsIDs...)
 
 	if err != nil {
 		if openapiErr, ok := err.(openapierr.Error); ok {
 			if openapierr.NotFound == openapiErr.Code() && !handleNotFoundErr {
-				return nil
+				return nil, nil
 			}
 		}
-		return fmt.Errorf("[resource='%s'] GET %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
+		return nil, newResourceOperationError(resourceKind, resourceName, http.MethodGet, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
 	}
 
-	return updateStateWithPayloadData(r.openAPIResource, remoteData, data)
+	if err := r.removeIgnoredDriftValues(remoteData, data); err != nil {
+		return nil, err
+	}
+
+	if err := encryptPayloadValues(r.openAPIResource, resourceKind, remoteData, data, i); err != nil {
+		return nil, err
+	}
+
+	if err := checkUnknownPayloadFields(r.openAPIResource, remoteData, i); err != nil {
+		return remoteData, err
+	}
+	if err := updateStateWithPayloadData(r.openAPIResource, remoteData, data); err != nil {
+		return remoteData, err
+	}
+	if err := r.setResponseHeaderAttributes(data, res); err != nil {
+		return remoteData, err
+	}
+	if err := r.setLastReadAt(data, i); err != nil {
+		return remoteData, err
+	}
+	return remoteData, r.setRegion(data, i)
+}
+
+// setResponseHeaderAttributes sets the computed attributes of the response headers configured with the
+// x-terraform-resource-response-headers extension. The attributes of the headers not present in the given response (e,g:
+// the Location header only returned when the resource is created) keep the value they already have in the state.
+func (r resourceFactory) setResponseHeaderAttributes(data *schema.ResourceData, res *http.Response) error {
+	if res == nil {
+		return nil
+	}
+	for attributeName, headerName := range r.getResponseHeaderAttributes() {
+		if value := res.Header.Get(headerName); value != "" {
+			if err := data.Set(attributeName, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getResponseHeaderAttributes returns the response headers exposed as computed attributes of the resource indexed by the
+// attribute name, leaving out the attributes that collide with the resource properties
+func (r resourceFactory) getResponseHeaderAttributes() map[string]string {
+	responseHeaderAttributes := map[string]string{}
+	for attributeName, headerName := range r.openAPIResource.getResponseHeaderAttributes() {
+		if !r.hasPropertyNamed(attributeName) {
+			responseHeaderAttributes[attributeName] = headerName
+		}
+	}
+	return responseHeaderAttributes
+}
+
+// removeIgnoredDriftValues removes from the remote data the properties configured with the x-terraform-ignore-drift
+// extension that already have a value in the state, so changes made by the API outside terraform are not reflected in
+// the state (and therefore do not show up as drift). If the state does not contain a value yet (e,g: on import) the
+// value returned by the API is kept.
+func (r resourceFactory) removeIgnoredDriftValues(remoteData map[string]interface{}, data *schema.ResourceData) error {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		if !property.IgnoreDrift {
+			continue
+		}
+		if _, exists := remoteData[property.Name]; !exists {
+			continue
+		}
+		if _, ok := data.GetOk(property.GetTerraformCompliantPropertyName()); ok {
+			log.Printf("[DEBUG] ignoring the value returned by the API for property '%s' as it is configured to ignore drift", property.Name)
+			delete(remoteData, property.Name)
+		}
+	}
+	return nil
 }
 
 func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
+	if err := r.checkRegion(data, i); err != nil {
+		return err
+	}
+	if r.isRefreshSkipped(data, i) {
+		return nil
+	}
 	return r.readWithOptions(data, i, false)
 }
 
+// maintainsRegion returns true if the resource exposes the region attribute, which is the case for multi-region APIs
+// unless the resource schema already contains a property with the same name
+func (r resourceFactory) maintainsRegion() bool {
+	return r.multiRegion && !r.hasPropertyNamed(regionAttribute)
+}
+
+// supportsParentRegion returns true if the resource exposes the parent_region attribute, which is the case for
+// sub-resources of multi-region APIs unless the resource schema already contains a property with the same name
+func (r resourceFactory) supportsParentRegion() bool {
+	return r.multiRegion && r.openAPIResource.GetParentResourceInfo() != nil && !r.hasPropertyNamed(parentRegionAttribute)
+}
+
+// hasPropertyNamed returns true if the resource schema contains a property with the given terraform name
+func (r resourceFactory) hasPropertyNamed(terraformName string) bool {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return false
+	}
+	_, err = resourceSchema.getPropertyBasedOnTerraformName(terraformName)
+	return err == nil
+}
+
+// getClientRegion returns the region the API calls are made against by the given client. An empty region is returned
+// if the client does not support multi-region APIs
+func (r resourceFactory) getClientRegion(i interface{}) (string, error) {
+	client, ok := i.(regionClient)
+	if !ok {
+		return "", nil
+	}
+	return client.getResolvedRegion()
+}
+
+// setRegion records in the region attribute the region the resource is managed in
+func (r resourceFactory) setRegion(data *schema.ResourceData, i interface{}) error {
+	if !r.maintainsRegion() {
+		return nil
+	}
+	region, err := r.getClientRegion(i)
+	if err != nil || region == "" {
+		return err
+	}
+	return data.Set(regionAttribute, region)
+}
+
+// checkRegion returns an error if the resource was created in a different region than the one the client makes the API
+// calls against (e,g: the resource was moved to a provider configured with a different region). Otherwise the API would
+// respond with 404 Not Found and the resource would be silently removed from the state
+func (r resourceFactory) checkRegion(data *schema.ResourceData, i interface{}) error {
+	if !r.maintainsRegion() {
+		return nil
+	}
+	stateRegion, _ := data.Get(regionAttribute).(string)
+	if stateRegion == "" {
+		return nil
+	}
+	region, err := r.getClientRegion(i)
+	if err != nil || region == "" || region == stateRegion {
+		return err
+	}
+	return fmt.Errorf("[%s='%s'] the resource '%s' was created in region '%s' but the provider is configured with region '%s'; resources can not be moved between regions, configure the resource with the provider for region '%s'", resourceKind, r.openAPIResource.GetResourceName(), data.Id(), stateRegion, region, stateRegion)
+}
+
+// checkParentRegion returns an error if the given parent region does not match the region the client makes the API
+// calls against, since the API can not create sub-resources in a different region than their parent
+func (r resourceFactory) checkParentRegion(parentRegion string, i interface{}) error {
+	if parentRegion == "" {
+		return nil
+	}
+	region, err := r.getClientRegion(i)
+	if err != nil || region == "" || region == parentRegion {
+		return err
+	}
+	return fmt.Errorf("[%s='%s'] the parent resource is in region '%s' but the resource is configured to be managed in region '%s'; sub-resources must be managed in the same region as their parent, configure the resource with the provider for region '%s'", resourceKind, r.openAPIResource.GetResourceName(), parentRegion, region, parentRegion)
+}
+
+// customizeDiff validates at plan time the values of the query parameter attributes, the request payload and the region
+// of the resources
+func (r resourceFactory) customizeDiff(diff *schema.ResourceDiff, i interface{}) error {
+	if err := r.validateRequiredQueryParamAttributes(diff); err != nil {
+		return err
+	}
+	if err := r.validatePlannedPayload(diff); err != nil {
+		return err
+	}
+	if err := r.forceNewOnItemFieldsChanges(diff); err != nil {
+		return err
+	}
+	return r.customizeRegionDiff(diff, i)
+}
+
+// getForceNewItemFieldsProperties returns the array properties of the resource configured with item fields which changes
+// force the re-creation of the resource (x-terraform-force-new-item-fields)
+func (r resourceFactory) getForceNewItemFieldsProperties() []*SpecSchemaDefinitionProperty {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return nil
+	}
+	var properties []*SpecSchemaDefinitionProperty
+	for _, property := range resourceSchema.Properties {
+		if property.isArrayOfObjectsProperty() && len(property.ForceNewItemFields) > 0 {
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+// forceNewOnItemFieldsChanges marks the resource to be re-created if any of the item fields configured to force new
+// changed in the existing items of the array properties. The items are compared by position, so adding or removing items
+// at the end of the list as well as changing any other item field update the resource in place.
+func (r resourceFactory) forceNewOnItemFieldsChanges(diff *schema.ResourceDiff) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	for _, property := range r.getForceNewItemFieldsProperties() {
+		propertyName := property.GetTerraformCompliantPropertyName()
+		if !diff.HasChange(propertyName) {
+			continue
+		}
+		oldValue, newValue := diff.GetChange(propertyName)
+		oldItems, _ := oldValue.([]interface{})
+		newItems, _ := newValue.([]interface{})
+		for idx := 0; idx < len(oldItems) && idx < len(newItems); idx++ {
+			oldItem, _ := oldItems[idx].(map[string]interface{})
+			newItem, _ := newItems[idx].(map[string]interface{})
+			for _, field := range property.ForceNewItemFields {
+				itemProperty, err := property.SpecSchemaDefinition.getProperty(field)
+				if err != nil {
+					return err
+				}
+				itemPropertyName := itemProperty.GetTerraformCompliantPropertyName()
+				if reflect.DeepEqual(oldItem[itemPropertyName], newItem[itemPropertyName]) {
+					continue
+				}
+				key := fmt.Sprintf("%s.%d.%s", propertyName, idx, itemPropertyName)
+				log.Printf("[INFO] [%s='%s'] the change in '%s' forces the re-creation of the resource", resourceKind, r.openAPIResource.GetResourceName(), key)
+				if err := diff.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateRequiredQueryParamAttributes returns an error if any of the required query parameters exposed as resource
+// attributes is missing the value, so the error is surfaced at plan time instead of when the API requests are performed.
+// The values that are not known at plan time (e,g: referencing an attribute of a resource that is not created yet) are
+// not validated; they are resolved when the changes are applied
+func (r resourceFactory) validateRequiredQueryParamAttributes(diff *schema.ResourceDiff) error {
+	operations := r.openAPIResource.getResourceOperations()
+	for _, operation := range []*specResourceOperation{operations.Post, operations.Get, operations.Put, operations.Delete} {
+		if operation == nil {
+			continue
+		}
+		for _, queryParam := range operation.QueryParameters {
+			if !queryParam.IsResourceAttribute || !queryParam.IsRequired || queryParam.Value != "" {
+				continue
+			}
+			queryParamTerraformName := queryParam.GetQueryParamTerraformName()
+			if !diff.NewValueKnown(queryParamTerraformName) {
+				log.Printf("[INFO] [%s='%s'] the value of the query parameter '%s' is not known at plan time, it will be resolved when the changes are applied", resourceKind, r.openAPIResource.GetResourceName(), queryParam.Name)
+				continue
+			}
+			if value, _ := diff.Get(queryParamTerraformName).(string); value == "" {
+				return fmt.Errorf("[%s='%s'] required query parameter '%s' is missing the value. Please make sure the property '%s' is configured with a value in the resource's terraform configuration", resourceKind, r.openAPIResource.GetResourceName(), queryParam.Name, queryParamTerraformName)
+			}
+		}
+	}
+	return nil
+}
+
+// customizeRegionDiff populates the region of the resources being created so it is known at plan time (and can be
+// referenced by the parent_region attribute of the sub-resources), and validates that the parent region of the
+// sub-resources matches the region they are managed in
+func (r resourceFactory) customizeRegionDiff(diff *schema.ResourceDiff, i interface{}) error {
+	if r.maintainsRegion() && diff.Id() == "" {
+		region, err := r.getClientRegion(i)
+		if err != nil {
+			return err
+		}
+		if region != "" {
+			if err := diff.SetNew(regionAttribute, region); err != nil {
+				return err
+			}
+		}
+	}
+	if r.supportsParentRegion() && diff.NewValueKnown(parentRegionAttribute) {
+		parentRegion, _ := diff.Get(parentRegionAttribute).(string)
+		return r.checkParentRegion(parentRegion, i)
+	}
+	return nil
+}
+
+// maintainsLastReadAt returns true if the resource exposes the last_read_at attribute, which is the case if the provider
+// is configured with refresh_skip_window unless the resource schema already contains a property with the same name
+func (r resourceFactory) maintainsLastReadAt() bool {
+	if !r.refreshSkipWindowConfigured {
+		return false
+	}
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return false
+	}
+	_, err = resourceSchema.getPropertyBasedOnTerraformName(lastReadAtAttribute)
+	return err != nil
+}
+
+// setLastReadAt records in the last_read_at attribute that the resource state has just been populated with the data
+// returned by the API. The attribute is only written if the client is configured with a refresh skip window so the state
+// does not change on every refresh otherwise
+func (r resourceFactory) setLastReadAt(data *schema.ResourceData, i interface{}) error {
+	client, ok := i.(refreshSkipWindowClient)
+	if !ok || client.getRefreshSkipWindow() <= 0 || !r.maintainsLastReadAt() {
+		return nil
+	}
+	return data.Set(lastReadAtAttribute, time.Now().UTC().Format(time.RFC3339))
+}
+
+// isRefreshSkipped returns true if the client is configured with a refresh skip window and the resource was last read
+// within that window, in which case the values in the state are kept without calling the API
+func (r resourceFactory) isRefreshSkipped(data *schema.ResourceData, i interface{}) bool {
+	client, ok := i.(refreshSkipWindowClient)
+	if !ok || data.Id() == "" || !r.maintainsLastReadAt() {
+		return false
+	}
+	window := client.getRefreshSkipWindow()
+	if window <= 0 {
+		return false
+	}
+	lastReadAt, err := time.Parse(time.RFC3339, data.Get(lastReadAtAttribute).(string))
+	if err != nil {
+		return false
+	}
+	elapsed := time.Since(lastReadAt)
+	if elapsed < 0 || elapsed >= window {
+		return false
+	}
+	log.Printf("[INFO] [%s='%s'] skipping the refresh of '%s' as it was last read %s ago (%s = %s)", resourceKind, r.openAPIResource.GetResourceName(), data.Id(), elapsed.Round(time.Second), providerPropertyRefreshSkipWindow, window)
+	return true
+}
+
 func (r resourceFactory) readRemote(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, error) {
+	responsePayload, _, err := r.readRemoteResponse(id, providerClient, parentIDs...)
+	return responsePayload, err
+}
+
+// readRemoteResponse reads the remote resource returning the response payload as well as the response
+func (r resourceFactory) readRemoteResponse(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, *http.Response, error) {
+	return r.readRemoteResponseWithGet(providerClient.Get, id, parentIDs...)
+}
+
+// refreshRemoteResponse reads the remote resource like readRemoteResponse but, if the client supports it, the read is
+// coalesced with the reads of other instances of the resource (x-terraform-batch-read-ids-param) to speed up the refresh
+func (r resourceFactory) refreshRemoteResponse(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, *http.Response, error) {
+	if client, ok := providerClient.(batchReadClient); ok {
+		return r.readRemoteResponseWithGet(client.batchGet, id, parentIDs...)
+	}
+	return r.readRemoteResponse(id, providerClient, parentIDs...)
+}
+
+// readRemoteResponseWithGet reads the remote resource with the given get function (e,g: ClientOpenAPI.Get) and checks the
+// response status code
+func (r resourceFactory) readRemoteResponseWithGet(get func(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error), id string, parentIDs ...string) (map[string]interface{}, *http.Response, error) {
 	var err error
 	responsePayload := map[string]interface{}{}
-	resp, err := providerClient.Get(r.openAPIResource, id, &responsePayload, parentIDs...)
+	resp, err := get(r.openAPIResource, id, &responsePayload, parentIDs...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return nil, err
+	operation := r.openAPIResource.getResourceOperations().Get
+	if err := checkHTTPStatusCode(r.openAPIResource, resp, operation.getSuccessStatusCodes([]int{http.StatusOK}), operation.getErrorParser()); err != nil {
+		return nil, nil, err
 	}
 
 	log.Printf("[DEBUG] GET '%s' response received", r.openAPIResource.GetResourceName())
-	return responsePayload, nil
+	return responsePayload, resp, nil
 }
 
 func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, error) {
@@ -201,12 +929,16 @@ func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, erro
 }
 
 func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error {
-	providerClient := i.(ClientOpenAPI)
+	providerClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
 
 	if r.openAPIResource == nil {
 		return fmt.Errorf("missing openAPI resource configuration")
 	}
+	r = r.withAPIVersion(data)
 	resourceName := r.openAPIResource.GetResourceName()
+	providerClient = r.getClientWithResourceAttributes(providerClient, data)
+	providerClient = r.getClientWithTimeout(providerClient, data, schema.TimeoutUpdate)
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationUpdate, resourceName, "")
 
@@ -215,38 +947,93 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 
+	// the values of the header and query parameter attributes (e,g: values that were not known at plan time) and the api
+	// version are not part of the resource payload, so the new values are just persisted in the state without calling the API
+	if !r.hasPayloadChanges(data) {
+		log.Printf("[INFO] [%s='%s'] none of the properties of '%s' changed (only attributes that are not part of the payload did), skipping the PUT operation", resourceKind, resourceName, data.Id())
+		return nil
+	}
+
 	operation := r.openAPIResource.getResourceOperations().Put
 	if operation == nil {
-		return fmt.Errorf("[resource='%s'] resource does not support PUT operation, check the swagger file exposed on '%s'", r.openAPIResource.GetResourceName(), resourcePath)
+		return fmt.Errorf("[%s='%s'] resource does not support PUT operation, check the swagger file exposed on '%s'", resourceKind, resourceName, resourcePath)
 	}
 	requestPayload := r.createPayloadFromLocalStateData(data)
+	r.mergeComputedObjectValuesFromState(requestPayload, data)
+	if err := r.validateRequestPayload(requestPayload); err != nil {
+		return err
+	}
 	responsePayload := map[string]interface{}{}
 	if err := r.checkImmutableFields(data, providerClient, parentsIDs...); err != nil {
 		return err
 	}
 	res, err := providerClient.Put(r.openAPIResource, data.Id(), requestPayload, &responsePayload, parentsIDs...)
 	if err != nil {
-		return err
+		return newResourceOperationError(resourceKind, resourceName, http.MethodPut, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusAccepted}); err != nil {
-		return fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
+	if err := checkHTTPStatusCode(r.openAPIResource, res, operation.getSuccessStatusCodes([]int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}), operation.getErrorParser()); err != nil {
+		return newResourceOperationError(resourceKind, resourceName, http.MethodPut, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
 	}
 
 	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutUpdate)
 	if err != nil {
-		return fmt.Errorf("polling mechanism failed after PUT %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
+		return newResourceOperationError(resourceKind, resourceName, http.MethodPut, fmt.Sprintf("%s/%s", resourcePath, data.Id()), fmt.Errorf("polling mechanism failed after response status code (%d): %s", res.StatusCode, err))
+	}
+
+	// APIs that do not return the resource in the update response (e,g: 204 No Content) would leave the computed values
+	// out of the state, so the resource is read again instead
+	if len(responsePayload) == 0 {
+		log.Printf("[DEBUG] [%s='%s'] PUT response (status code: %d) did not contain the resource, reading the resource again", resourceKind, resourceName, res.StatusCode)
+		responsePayload, err = r.readRemote(data.Id(), providerClient, parentsIDs...)
+		if err != nil {
+			return newResourceOperationError(resourceKind, resourceName, http.MethodGet, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
+		}
 	}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+	if err := encryptPayloadValues(r.openAPIResource, resourceKind, responsePayload, data, i); err != nil {
+		return err
+	}
+	if err := checkUnknownPayloadFields(r.openAPIResource, responsePayload, i); err != nil {
+		return err
+	}
+	if err := updateStateWithPayloadData(r.openAPIResource, responsePayload, data); err != nil {
+		return err
+	}
+	if err := r.setResponseHeaderAttributes(data, res); err != nil {
+		return err
+	}
+	if err := r.setLastReadAt(data, i); err != nil {
+		return err
+	}
+	return r.setRegion(data, i)
+}
+
+// hasPayloadChanges returns true if any of the resource properties sent in the payload changed. Changes of the attributes
+// that are not part of the payload (e,g: header and query parameter attributes) alone do not require calling the API
+func (r resourceFactory) hasPayloadChanges(data *schema.ResourceData) bool {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return true
+	}
+	for _, property := range resourceSchema.Properties {
+		if data.HasChange(property.GetTerraformCompliantPropertyName()) {
+			return true
+		}
+	}
+	return false
 }
 
 func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error {
-	providerClient := i.(ClientOpenAPI)
+	providerClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
 
 	if r.openAPIResource == nil {
 		return fmt.Errorf("missing openAPI resource configuration")
 	}
+	r = r.withAPIVersion(data)
 	resourceName := r.openAPIResource.GetResourceName()
+	providerClient = r.getClientWithResourceAttributes(providerClient, data)
+	providerClient = r.getClientWithTimeout(providerClient, data, schema.TimeoutDelete)
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationDelete, resourceName, "")
 
@@ -257,33 +1044,122 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 
 	operation := r.openAPIResource.getResourceOperations().Delete
 	if operation == nil {
-		return fmt.Errorf("[resource='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", r.openAPIResource.GetResourceName(), resourcePath)
+		return fmt.Errorf("[%s='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", resourceKind, resourceName, resourcePath)
+	}
+	if operation.existenceCheckEnabled && !r.resourceExists(data, providerClient, parentsIDs...) {
+		log.Printf("[INFO] [%s='%s'] resource with id '%s' no longer exists, skipping the DELETE operation", resourceKind, resourceName, data.Id())
+		return nil
+	}
+	if err := r.preDelete(data, providerClient, operation, resourcePath, parentsIDs...); err != nil {
+		return err
 	}
 	res, err := providerClient.Delete(r.openAPIResource, data.Id(), parentsIDs...)
 	if err != nil {
-		return err
+		return newResourceOperationError(resourceKind, resourceName, http.MethodDelete, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusNoContent, http.StatusOK, http.StatusAccepted}); err != nil {
+	if err := checkHTTPStatusCode(r.openAPIResource, res, operation.getSuccessStatusCodes([]int{http.StatusNoContent, http.StatusOK, http.StatusAccepted}), operation.getErrorParser()); err != nil {
 		if openapiErr, ok := err.(openapierr.Error); ok {
 			if openapierr.NotFound == openapiErr.Code() {
 				return nil
 			}
 		}
-		return fmt.Errorf("[resource='%s'] DELETE %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
+		return newResourceOperationError(resourceKind, resourceName, http.MethodDelete, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
 	}
 
 	err = r.handlePollingIfConfigured(nil, data, providerClient, operation, res.StatusCode, schema.TimeoutDelete)
 	if err != nil {
-		return fmt.Errorf("polling mechanism failed after DELETE %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
+		return newResourceOperationError(resourceKind, resourceName, http.MethodDelete, fmt.Sprintf("%s/%s", resourcePath, data.Id()), fmt.Errorf("polling mechanism failed after response status code (%d): %s", res.StatusCode, err))
 	}
 
 	return nil
 }
 
+// preDelete performs the request configured with the x-terraform-pre-delete-method extension, which the API requires
+// before the resource can be deleted (e,g: disabling the resource). A 404 Not Found response is not considered an error
+// since the DELETE operation handles resources that no longer exist.
+func (r resourceFactory) preDelete(data *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, resourcePath string, parentIDs ...string) error {
+	if operation.preDelete == nil {
+		return nil
+	}
+	resourceName := r.openAPIResource.GetResourceName()
+	client, ok := providerClient.(preDeleteClient)
+	if !ok {
+		log.Printf("[WARN] [%s='%s'] the client does not support pre-delete requests, skipping the %s request", resourceKind, resourceName, operation.preDelete.method)
+		return nil
+	}
+	res, err := client.preDelete(r.openAPIResource, data.Id(), parentIDs...)
+	if err != nil {
+		return newResourceOperationError(resourceKind, resourceName, operation.preDelete.method, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}, operation.getErrorParser()); err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() {
+			return nil
+		}
+		return newResourceOperationError(resourceKind, resourceName, operation.preDelete.method, fmt.Sprintf("%s/%s", resourcePath, data.Id()), err)
+	}
+	return nil
+}
+
+// resourceExists reads the resource from the API and returns false only if the API responded with a 404 Not Found. Any
+// other error is logged and the resource is considered to exist so the caller carries on as usual.
+func (r resourceFactory) resourceExists(data *schema.ResourceData, providerClient ClientOpenAPI, parentIDs ...string) bool {
+	if _, err := r.readRemote(data.Id(), providerClient, parentIDs...); err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() {
+			return false
+		}
+		log.Printf("[WARN] [%s='%s'] failed to check whether the resource with id '%s' exists: %s", resourceKind, r.openAPIResource.GetResourceName(), data.Id(), err)
+	}
+	return true
+}
+
+// exists checks whether the resource still exists with a HEAD request to the resource instance path, so the resources
+// deleted out of band are removed from the state during refresh without reading them. False is only returned if the API
+// responds with a 404 Not Found; any other error is logged and the resource is considered to exist so the read carries
+// on as usual.
+func (r resourceFactory) exists(data *schema.ResourceData, i interface{}) (bool, error) {
+	if err := r.checkRegion(data, i); err != nil {
+		return false, err
+	}
+	if r.isRefreshSkipped(data, i) {
+		return true, nil
+	}
+	openAPIClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+	defer cancel()
+
+	r = r.withAPIVersion(data)
+	resourceName := r.openAPIResource.GetResourceName()
+	openAPIClient = r.getClientWithResourceAttributes(openAPIClient, data)
+	openAPIClient = r.getClientWithTimeout(openAPIClient, data, schema.TimeoutRead)
+	client, ok := openAPIClient.(existsClient)
+	if !ok {
+		return true, nil
+	}
+
+	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
+	if err != nil {
+		return false, err
+	}
+
+	operation := r.openAPIResource.getResourceOperations().Head
+	res, err := client.Head(r.openAPIResource, data.Id(), parentsIDs...)
+	if err == nil {
+		err = checkHTTPStatusCode(r.openAPIResource, res, operation.getSuccessStatusCodes([]int{http.StatusOK, http.StatusNoContent}), operation.getErrorParser())
+	}
+	if err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() {
+			log.Printf("[INFO] [%s='%s'] resource with id '%s' no longer exists", resourceKind, resourceName, data.Id())
+			return false, nil
+		}
+		log.Printf("[WARN] [%s='%s'] failed to check whether the resource with id '%s' exists (%s %s/%s): %s", resourceKind, resourceName, data.Id(), http.MethodHead, resourcePath, data.Id(), err)
+	}
+	return true, nil
+}
+
 func (r resourceFactory) importer() *schema.ResourceImporter {
 	return &schema.ResourceImporter{
 		State: func(data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
-			providerClient := i.(ClientOpenAPI)
+			providerClient, cancel := getClientWithOperationTimeout(i.(ClientOpenAPI))
+			defer cancel()
 
 			if r.openAPIResource == nil {
 				return nil, fmt.Errorf("missing openAPI resource configuration")
@@ -297,30 +1173,30 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 			parentResourceInfo := r.openAPIResource.GetParentResourceInfo()
 			if parentResourceInfo != nil {
 				parentPropertyNames := parentResourceInfo.GetParentPropertiesNames()
-
-				// The expected format for the ID provided when importing a sub-resource is 1234/567 where 1234 would be the parentID and 567 the instance ID
-				ids := strings.Split(data.Id(), "/")
-				if len(ids) < 2 {
-					return results, fmt.Errorf("can not import a subresource without providing all the parent IDs (%d) and the instance ID", len(parentPropertyNames))
-				}
-				parentIDsLen := len(ids) - 1
-				if len(parentPropertyNames) < parentIDsLen {
-					return results, fmt.Errorf("the number of parent IDs provided %d is greater than the expected number of parent IDs %d", parentIDsLen, len(parentPropertyNames))
-				}
-				if len(parentPropertyNames) > parentIDsLen {
-					return results, fmt.Errorf("can not import a subresource without all the parent ids, expected %d and got %d parent IDs", len(parentPropertyNames), parentIDsLen)
+				parentIDs, instanceID, err := r.getImportParentIDs(data.Id(), parentPropertyNames)
+				if err != nil {
+					return results, err
 				}
+				var parsedSegments []string
 				for idx, parentPropertyName := range parentPropertyNames {
-					err := data.Set(parentPropertyName, ids[idx])
+					err := data.Set(parentPropertyName, parentIDs[idx])
 					if err != nil {
 						return nil, err
 					}
+					parsedSegments = append(parsedSegments, fmt.Sprintf("%s='%s'", parentPropertyName, parentIDs[idx]))
 				}
-				data.SetId(ids[len(ids)-1])
+				parsedSegments = append(parsedSegments, fmt.Sprintf("id='%s'", instanceID))
+				log.Printf("[INFO] [resource='%s'] importing the sub-resource with %s", resourceName, strings.Join(parsedSegments, ", "))
+				data.SetId(instanceID)
 			}
 			// If the resources is NOT a sub-resource and just a top level resource then the array passed in will just contain
 			// 	the data object we get from terraform core without any updates.
-			err := r.readWithOptions(data, i, true)
+			remoteData, err := r.readIntoState(data, i, true)
+			r.verifyImportedPayload(data.Id(), remoteData)
+			if err != nil {
+				return nil, err
+			}
+			err = r.setImportedDefaultValues(data)
 			if err != nil {
 				return nil, err
 			}
@@ -329,6 +1205,179 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 	}
 }
 
+// getImportParentIDs returns the parent IDs and the instance ID of the sub-resource being imported. The import ID is
+// expected to contain all the parent IDs followed by the instance ID separated by forward slashes (e,g: 1234/567 where
+// 1234 is the parent ID and 567 the instance ID). Alternatively, the import ID can contain just the instance ID in which
+// case the parent IDs are read from the environment variables named after the parent properties (see
+// getImportParentIDEnvVar). The parent IDs are validated against the parent ID format if declared in the resource path.
+func (r resourceFactory) getImportParentIDs(importID string, parentPropertyNames []string) ([]string, string, error) {
+	ids := strings.Split(importID, "/")
+	var parentIDs []string
+	instanceID := ids[len(ids)-1]
+	if len(ids) == 1 {
+		var missingEnvVars []string
+		for _, parentPropertyName := range parentPropertyNames {
+			envVar := getImportParentIDEnvVar(parentPropertyName)
+			parentID := os.Getenv(envVar)
+			if parentID == "" {
+				missingEnvVars = append(missingEnvVars, envVar)
+			}
+			parentIDs = append(parentIDs, parentID)
+		}
+		if len(missingEnvVars) > 0 {
+			return nil, "", fmt.Errorf("can not import a subresource without providing all the parent IDs (%d) and the instance ID (environment variables not set: %s): %s", len(parentPropertyNames), strings.Join(missingEnvVars, ", "), r.getImportIDHint(importID, parentPropertyNames))
+		}
+	} else {
+		parentIDs = ids[:len(ids)-1]
+		if len(parentPropertyNames) < len(parentIDs) {
+			return nil, "", fmt.Errorf("the number of parent IDs provided %d is greater than the expected number of parent IDs %d: %s", len(parentIDs), len(parentPropertyNames), r.getImportIDHint(importID, parentPropertyNames))
+		}
+		if len(parentPropertyNames) > len(parentIDs) {
+			return nil, "", fmt.Errorf("can not import a subresource without all the parent ids, expected %d and got %d parent IDs: %s", len(parentPropertyNames), len(parentIDs), r.getImportIDHint(importID, parentPropertyNames))
+		}
+	}
+	for idx, parentID := range parentIDs {
+		if parentID == "" {
+			return nil, "", fmt.Errorf("the parent ID for '%s' is empty: %s", parentPropertyNames[idx], r.getImportIDHint(importID, parentPropertyNames))
+		}
+	}
+	if instanceID == "" {
+		return nil, "", fmt.Errorf("the instance ID is empty: %s", r.getImportIDHint(importID, parentPropertyNames))
+	}
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, "", err
+	}
+	for idx, parentPropertyName := range parentPropertyNames {
+		parentProperty, err := resourceSchema.getProperty(parentPropertyName)
+		if err != nil {
+			continue
+		}
+		if err := parentProperty.validateParentIDFormat(parentIDs[idx]); err != nil {
+			return nil, "", fmt.Errorf("the import ID '%s' is not valid: %s", importID, err)
+		}
+	}
+	return parentIDs, instanceID, nil
+}
+
+// getImportIDHint returns the description of the import ID expected for the sub-resource, echoing the given import ID
+func (r resourceFactory) getImportIDHint(importID string, parentPropertyNames []string) string {
+	var segments []string
+	var envVars []string
+	for _, parentPropertyName := range parentPropertyNames {
+		segments = append(segments, fmt.Sprintf("{%s}", parentPropertyName))
+		envVars = append(envVars, getImportParentIDEnvVar(parentPropertyName))
+	}
+	segments = append(segments, "{id}")
+	return fmt.Sprintf("the import ID provided '%s' must be in the format '%s', or contain just the instance ID with the parent IDs provided via the environment variables %s", importID, strings.Join(segments, "/"), strings.Join(envVars, ", "))
+}
+
+// getImportParentIDEnvVar returns the name of the environment variable that can be used to provide the ID of the parent
+// when importing a sub-resource (e,g: OPENAPI_IMPORT_CDNS_V1_ID for the parent property cdns_v1_id)
+func getImportParentIDEnvVar(parentPropertyName string) string {
+	return importParentIDEnvVarPrefix + strings.ToUpper(parentPropertyName)
+}
+
+// verifyImportedPayload logs a warning enumerating the properties returned by the API for the imported resource that
+// are not specified in the resource schema. These properties can not be stored in the state, so without the warning
+// users would not notice the imported state is partial.
+func (r resourceFactory) verifyImportedPayload(id string, remoteData map[string]interface{}) {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return
+	}
+	unmappedProperties := getUnmappedPayloadProperties(resourceSchema, remoteData)
+	if len(unmappedProperties) > 0 {
+		log.Printf("[WARN] [resource='%s'] the API response for the imported resource '%s' contains properties that are not specified in the resource schema in the OpenAPI document and therefore were not stored in the state: %s", r.openAPIResource.GetResourceName(), id, strings.Join(unmappedProperties, ", "))
+	}
+}
+
+// getUnmappedPayloadProperties returns the sorted names of the properties in the payload that are not specified in the
+// given schema definition. Nested object properties are checked too and their names are prefixed with the parent
+// property name (e,g: object_property.unknown_property).
+func getUnmappedPayloadProperties(schemaDefinition *SpecSchemaDefinition, payload map[string]interface{}) []string {
+	unmapped := map[string]bool{}
+	collectUnmappedPayloadProperties(schemaDefinition, payload, "", unmapped)
+	unmappedProperties := make([]string, 0, len(unmapped))
+	for propertyName := range unmapped {
+		unmappedProperties = append(unmappedProperties, propertyName)
+	}
+	sort.Strings(unmappedProperties)
+	return unmappedProperties
+}
+
+func collectUnmappedPayloadProperties(schemaDefinition *SpecSchemaDefinition, payload map[string]interface{}, prefix string, unmapped map[string]bool) {
+	for propertyName, propertyValue := range payload {
+		property, err := schemaDefinition.getProperty(propertyName)
+		if err != nil {
+			unmapped[prefix+propertyName] = true
+			continue
+		}
+		if property.SpecSchemaDefinition == nil {
+			continue
+		}
+		switch value := propertyValue.(type) {
+		case map[string]interface{}:
+			collectUnmappedPayloadProperties(property.SpecSchemaDefinition, value, prefix+propertyName+".", unmapped)
+		case []interface{}:
+			for _, item := range value {
+				if itemValue, ok := item.(map[string]interface{}); ok {
+					collectUnmappedPayloadProperties(property.SpecSchemaDefinition, itemValue, prefix+propertyName+".", unmapped)
+				}
+			}
+		}
+	}
+}
+
+// setImportedDefaultValues populates the optional properties that have a default value and were not returned by the API
+// with their default value. This keeps imported states (and the configuration generated from them when running terraform
+// plan -generate-config-out) consistent with the resource schema, so the first plan after the import does not show diffs
+// for properties the user never configured.
+func (r resourceFactory) setImportedDefaultValues(data *schema.ResourceData) error {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		if property.Default == nil || !property.isOptional() || property.isComputed() || property.isPropertyNamedID() || property.IsParentProperty {
+			continue
+		}
+		if _, exists := data.GetOkExists(property.GetTerraformCompliantPropertyName()); exists {
+			continue
+		}
+		if err := setResourceDataProperty(*property, property.Default, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setClientGeneratedValues generates the values of the properties configured with the x-terraform-client-generated
+// extension that the user did not configure. The values are stored in the state so they are sent in the create request
+// and remain the same afterwards.
+func (r resourceFactory) setClientGeneratedValues(data *schema.ResourceData) error {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		if property.ClientGenerated == nil {
+			continue
+		}
+		if _, exists := data.GetOk(property.GetTerraformCompliantPropertyName()); exists {
+			continue
+		}
+		value, err := property.ClientGenerated.generate()
+		if err != nil {
+			return fmt.Errorf("failed to generate the value of property '%s': %s", property.GetTerraformCompliantPropertyName(), err)
+		}
+		if err := setResourceDataProperty(*property, value, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string) error {
 	response := operation.responses.getResponse(responseStatusCode)
 
@@ -336,6 +1385,7 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 		return nil
 	}
 
+	pollResponse := *response
 	targetStatuses := response.pollTargetStatuses
 	pendingStatuses := response.pollPendingStatuses
 
@@ -350,6 +1400,12 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 		}
 		log.Printf("[WARN] overriding target status with default destroy status")
 		targetStatuses = []string{defaultDestroyStatus}
+		pollResponse.pollCompletedConditions = nil
+	} else if len(pollResponse.pollCompletedConditions) > 0 {
+		// the resource is considered completed once all the conditions are met; until then the polling carries on as long
+		// as the resource status is one of the pending (or completed) statuses
+		targetStatuses = []string{pollConditionsMetStatus}
+		pendingStatuses = append([]string{pollConditionsPendingStatus}, pendingStatuses...)
 	}
 
 	log.Printf("[DEBUG] target statuses (%s); pending statuses (%s)", targetStatuses, pendingStatuses)
@@ -358,8 +1414,8 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	stateConf := &resource.StateChangeConf{
 		Pending:      pendingStatuses,
 		Target:       targetStatuses,
-		Refresh:      r.resourceStateRefreshFunc(resourceLocalData, providerClient),
-		Timeout:      resourceLocalData.Timeout(timeoutFor),
+		Refresh:      r.resourceStateRefreshFunc(resourceLocalData, providerClient, &pollResponse),
+		Timeout:      getPollingTimeout(providerClient, resourceLocalData.Timeout(timeoutFor)),
 		PollInterval: r.defaultPollInterval,
 		MinTimeout:   r.defaultPollMinTimeout,
 		Delay:        r.defaultPollDelay,
@@ -373,7 +1429,7 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	if responsePayload != nil {
 		remoteDataCasted, ok := remoteData.(map[string]interface{})
 		if ok {
-			*responsePayload = remoteDataCasted
+			*responsePayload = mergeTerminalStatusPayload(*responsePayload, remoteDataCasted)
 		} else {
 			return fmt.Errorf("failed to convert remote data (%s) to map[string]interface{}", reflect.TypeOf(remoteData))
 		}
@@ -381,7 +1437,22 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	return nil
 }
 
-func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) resource.StateRefreshFunc {
+// mergeTerminalStatusPayload returns the payload received when the resource reached the completion status merged into
+// the original operation response payload. The terminal payload usually contains the computed values populated by the
+// API while the operation was in progress (e,g: assigned IPs), so its values take precedence; whereas the properties
+// only returned in the original response (e,g: values returned just once on creation) are kept.
+func mergeTerminalStatusPayload(responsePayload, terminalPayload map[string]interface{}) map[string]interface{} {
+	mergedPayload := map[string]interface{}{}
+	for propertyName, value := range responsePayload {
+		mergedPayload[propertyName] = value
+	}
+	for propertyName, value := range terminalPayload {
+		mergedPayload[propertyName] = value
+	}
+	return mergedPayload
+}
+
+func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, response *specResponse) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 
 		remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient)
@@ -394,7 +1465,7 @@ func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.Reso
 			return nil, "", fmt.Errorf("error on retrieving resource '%s' (%s) when waiting: %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
 		}
 
-		newStatus, err := r.getStatusValueFromPayload(remoteData)
+		newStatus, err := r.getPollStatusValue(remoteData, response)
 		if err != nil {
 			return nil, "", fmt.Errorf("error occurred while retrieving status identifier value from payload for resource '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
 		}
@@ -407,7 +1478,8 @@ func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.Reso
 func (r resourceFactory) checkImmutableFields(updatedResourceLocalData *schema.ResourceData, openAPIClient ClientOpenAPI, parentIDs ...string) error {
 	remoteData, err := r.readRemote(updatedResourceLocalData.Id(), openAPIClient, parentIDs...)
 	if err != nil {
-		return err
+		resourcePath, _ := r.openAPIResource.getResourcePath(parentIDs)
+		return newResourceOperationError(resourceKind, r.openAPIResource.GetResourceName(), http.MethodGet, fmt.Sprintf("%s/%s", resourcePath, updatedResourceLocalData.Id()), err)
 	}
 	localData := r.createPayloadFromLocalStateData(updatedResourceLocalData)
 	s, _ := r.openAPIResource.GetResourceSchema()
@@ -416,6 +1488,9 @@ func (r resourceFactory) checkImmutableFields(updatedResourceLocalData *schema.R
 		if err != nil {
 			// Rolling back data so tf values are not stored in the state file; otherwise terraform would store the
 			// data inside the updated (*schema.ResourceData) in the state file
+			if encryptError := encryptPayloadValues(r.openAPIResource, resourceKind, remoteData, updatedResourceLocalData, openAPIClient); encryptError != nil {
+				return encryptError
+			}
 			updateError := updateStateWithPayloadData(r.openAPIResource, remoteData, updatedResourceLocalData)
 			if updateError != nil {
 				return updateError
@@ -459,6 +1534,14 @@ func (r resourceFactory) validateImmutableProperty(property *SpecSchemaDefinitio
 				}
 			}
 		}
+	case TypeMap:
+		if property.Immutable || checkObjectPropertiesUpdates {
+			localMap, _ := localData.(map[string]interface{})
+			remoteMap, _ := remoteData.(map[string]interface{})
+			if !property.equalMaps(localMap, remoteMap) {
+				return fmt.Errorf("user attempted to update an immutable map property ('%s'): [user input: %s; actual: %s]", property.Name, localData, remoteData)
+			}
+		}
 	case TypeObject:
 		localObject := localData.(map[string]interface{})
 		remoteObject := remoteData.(map[string]interface{})
@@ -523,6 +1606,63 @@ func (r resourceFactory) createPayloadFromLocalStateData(resourceLocalData *sche
 	return input
 }
 
+// mergeComputedObjectValuesFromState adds to the object properties of the given payload the values stored in the current
+// state for the nested optional computed properties that are not part of the desired values. This avoids updates wiping out
+// the values computed by the API for nested properties the user did not configure. Note that readOnly nested properties
+// are never sent to the API.
+func (r resourceFactory) mergeComputedObjectValuesFromState(payload map[string]interface{}, resourceLocalData *schema.ResourceData) {
+	resourceSchema, _ := r.openAPIResource.GetResourceSchema()
+	for _, property := range resourceSchema.Properties {
+		if !property.isObjectProperty() || property.isReadOnly() || property.IsParentProperty || property.SpecSchemaDefinition == nil {
+			continue
+		}
+		desiredValue, ok := payload[property.Name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		currentStateValue, _ := resourceLocalData.GetChange(property.GetTerraformCompliantPropertyName())
+		if currentStateValue == nil {
+			continue
+		}
+		currentInput := map[string]interface{}{}
+		if err := r.populatePayload(currentInput, property, currentStateValue); err != nil {
+			log.Printf("[DEBUG] [resource='%s'] current state value for property '%s' could not be merged into the payload: %s", r.openAPIResource.GetResourceName(), property.Name, err)
+			continue
+		}
+		if currentValue, ok := currentInput[property.Name].(map[string]interface{}); ok {
+			mergeComputedObjectValues(property.SpecSchemaDefinition, desiredValue, currentValue)
+		}
+	}
+}
+
+// mergeComputedObjectValues adds to the desired object the current values of the optional computed properties that are
+// missing in the desired object. Nested objects are merged recursively.
+func mergeComputedObjectValues(schemaDefinition *SpecSchemaDefinition, desiredValue, currentValue map[string]interface{}) {
+	for _, property := range schemaDefinition.Properties {
+		if property.isReadOnly() {
+			continue
+		}
+		current, exists := currentValue[property.Name]
+		if !exists || current == nil {
+			continue
+		}
+		desired, exists := desiredValue[property.Name]
+		if !exists || desired == nil {
+			if property.IsOptionalComputed() {
+				desiredValue[property.Name] = current
+			}
+			continue
+		}
+		if property.isObjectProperty() && property.SpecSchemaDefinition != nil {
+			desiredObject, desiredIsObject := desired.(map[string]interface{})
+			currentObject, currentIsObject := current.(map[string]interface{})
+			if desiredIsObject && currentIsObject {
+				mergeComputedObjectValues(property.SpecSchemaDefinition, desiredObject, currentObject)
+			}
+		}
+	}
+}
+
 func (r resourceFactory) populatePayload(input map[string]interface{}, property *SpecSchemaDefinitionProperty, dataValue interface{}) error {
 	if property == nil {
 		return errors.New("populatePayload must receive a non nil property")
@@ -533,6 +1673,12 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 	if property.isReadOnly() {
 		return nil
 	}
+	if property.RawJSON {
+		return r.populateRawJSONPayload(input, property, dataValue)
+	}
+	if property.isMapProperty() {
+		return r.populateMapPayload(input, property, dataValue)
+	}
 	dataValueKind := reflect.TypeOf(dataValue).Kind()
 	switch dataValueKind {
 	case reflect.Map:
@@ -602,7 +1748,7 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 			}
 			input[property.Name] = v
 		case TypeString:
-			input[property.Name] = dataValue.(string)
+			input[property.Name] = property.Transforms.apply(dataValue.(string))
 		default:
 			return fmt.Errorf("property '%s' type not supported for reflect value string", property.Type)
 		}
@@ -618,6 +1764,120 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 	return nil
 }
 
+// populateRawJSONPayload adds the value decoded from the JSON string stored in the state to the input. Empty strings mean
+// the property is not configured, hence nothing is added
+func (r resourceFactory) populateRawJSONPayload(input map[string]interface{}, property *SpecSchemaDefinitionProperty, dataValue interface{}) error {
+	rawJSONValue, ok := dataValue.(string)
+	if !ok {
+		return fmt.Errorf("property '%s' is expected to contain a JSON string but got '%v'", property.Name, dataValue)
+	}
+	if rawJSONValue == "" {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(rawJSONValue), &value); err != nil {
+		return fmt.Errorf("property '%s' value is not valid JSON: %s", property.Name, err)
+	}
+	input[property.Name] = value
+	return nil
+}
+
+// populateMapPayload adds the given map value to the input converting the map values (kept as strings in the terraform
+// state for maps of strings) to the map values type
+func (r resourceFactory) populateMapPayload(input map[string]interface{}, property *SpecSchemaDefinitionProperty, dataValue interface{}) error {
+	if property.isMapOfObjectsProperty() {
+		return r.populateMapOfObjectsPayload(input, property, dataValue)
+	}
+	mapValue, ok := dataValue.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("property '%s' is supposed to be a map", property.Name)
+	}
+	mapInput := map[string]interface{}{}
+	for key, value := range mapValue {
+		valueProperty := &SpecSchemaDefinitionProperty{Name: key, Type: property.MapValuesType}
+		if err := r.populatePayload(mapInput, valueProperty, value); err != nil {
+			return err
+		}
+	}
+	input[property.Name] = mapInput
+	return nil
+}
+
+// populateMapOfObjectsPayload adds the given list of blocks representing a map of objects to the input as a map keyed
+// by the value of the 'key' attribute of each block
+func (r resourceFactory) populateMapOfObjectsPayload(input map[string]interface{}, property *SpecSchemaDefinitionProperty, dataValue interface{}) error {
+	items, ok := dataValue.([]interface{})
+	if !ok {
+		return fmt.Errorf("property '%s' is supposed to be a list of map entries", property.Name)
+	}
+	mapInput := map[string]interface{}{}
+	for _, item := range items {
+		key, ok := getMapOfObjectsItemKey(item)
+		if !ok || key == "" {
+			return fmt.Errorf("property '%s' contains a map entry with no '%s'", property.Name, mapOfObjectsKeyAttributeName)
+		}
+		if _, exists := mapInput[key]; exists {
+			return fmt.Errorf("property '%s' contains more than one map entry with %s '%s'", property.Name, mapOfObjectsKeyAttributeName, key)
+		}
+		objectValue := map[string]interface{}{}
+		for propertyName, propertyValue := range item.(map[string]interface{}) {
+			if propertyName != mapOfObjectsKeyAttributeName {
+				objectValue[propertyName] = propertyValue
+			}
+		}
+		valueProperty := &SpecSchemaDefinitionProperty{Name: key, Type: TypeObject, SpecSchemaDefinition: property.SpecSchemaDefinition}
+		if err := r.populatePayload(mapInput, valueProperty, objectValue); err != nil {
+			return err
+		}
+	}
+	input[property.Name] = mapInput
+	return nil
+}
+
+// getPollStatusValue returns the status of the resource used by the polling mechanism. If the response is configured with
+// completed conditions, pollConditionsMetStatus is returned once all the conditions are met. Until then,
+// pollConditionsPendingStatus is returned as long as the resource status is one of the pending or completed statuses (or
+// no pending statuses are configured); otherwise the resource status is returned as is so the polling fails.
+func (r resourceFactory) getPollStatusValue(payload map[string]interface{}, response *specResponse) (string, error) {
+	if len(response.pollCompletedConditions) == 0 {
+		return r.getResponseStatusValue(payload, response)
+	}
+	if response.pollCompletedConditionsMet(payload) {
+		return pollConditionsMetStatus, nil
+	}
+	if len(response.pollPendingStatuses) == 0 {
+		return pollConditionsPendingStatus, nil
+	}
+	status, err := r.getResponseStatusValue(payload, response)
+	if err != nil {
+		return "", err
+	}
+	for _, expectedStatus := range append(response.pollPendingStatuses, response.pollTargetStatuses...) {
+		if status == expectedStatus {
+			return pollConditionsPendingStatus, nil
+		}
+	}
+	return status, nil
+}
+
+// getResponseStatusValue returns the value of the status property found at the status path configured in the response
+// or, if not configured, the value of the status property identified in the resource schema
+func (r resourceFactory) getResponseStatusValue(payload map[string]interface{}, response *specResponse) (string, error) {
+	if len(response.pollStatusPath) == 0 {
+		return r.getStatusValueFromPayload(payload)
+	}
+	statusPath := strings.Join(response.pollStatusPath, ".")
+	value, exists := getPayloadValueAtPath(payload, response.pollStatusPath)
+	if !exists {
+		return "", fmt.Errorf("payload does not contain the status field: %s", statusPath)
+	}
+	status, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("status property value '%s' does not have a supported type [string]", statusPath)
+	}
+	return status, nil
+}
+
 func (r resourceFactory) getStatusValueFromPayload(payload map[string]interface{}) (string, error) {
 	resourceSchema, err := r.openAPIResource.GetResourceSchema()
 	if err != nil {
@@ -9,12 +9,14 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"encoding/json"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -61,6 +63,111 @@ func TestCreateTerraformResource(t *testing.T) {
 				So(schemaResource.Read(resourceData, client), ShouldBeNil)
 				So(schemaResource.Update(resourceData, client), ShouldBeNil)
 				So(schemaResource.Delete(resourceData, client), ShouldBeNil)
+				So(schemaResource.DeprecationMessage, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a resource factory initialised with a spec resource that is deprecated", t, func() {
+		specResource := newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{idProperty, stringProperty},
+		})
+		specResource.deprecationMessage = "use cdns_v2 instead"
+		r := newResourceFactory(specResource)
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the schemaResource returned should contain the deprecation message", func() {
+				So(err, ShouldBeNil)
+				So(schemaResource.DeprecationMessage, ShouldEqual, "use cdns_v2 instead")
+			})
+		})
+	})
+}
+
+// clientOpenAPINotificationStub is a clientOpenAPIStub that records the operations notified
+type clientOpenAPINotificationStub struct {
+	*clientOpenAPIStub
+	resourceIDsNotified  []string
+	operationsNotified   []TelemetryResourceOperation
+	operationErrNotified error
+}
+
+func (c *clientOpenAPINotificationStub) notifyOperation(resourceName, resourceID string, tfOperation TelemetryResourceOperation, duration time.Duration, operationErr error) {
+	c.resourceIDsNotified = append(c.resourceIDsNotified, resourceID)
+	c.operationsNotified = append(c.operationsNotified, tfOperation)
+	c.operationErrNotified = operationErr
+}
+
+// clientOpenAPIReadOnlyModeStub is a clientOpenAPIStub that supports the read only mode
+type clientOpenAPIReadOnlyModeStub struct {
+	*clientOpenAPIStub
+	readOnly bool
+}
+
+func (c *clientOpenAPIReadOnlyModeStub) isReadOnlyMode() bool {
+	return c.readOnly
+}
+
+func TestWithReadOnlyModeCheck(t *testing.T) {
+	Convey("Given a resource factory and a client configured in read only mode", t, func() {
+		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+		client := &clientOpenAPIReadOnlyModeStub{clientOpenAPIStub: &clientOpenAPIStub{}, readOnly: true}
+		Convey("When the wrapped operation is called", func() {
+			called := false
+			err := r.withReadOnlyModeCheck(TelemetryResourceOperationDelete, func(data *schema.ResourceData, i interface{}) error {
+				called = true
+				return nil
+			})(resourceData, client)
+			Convey("Then the operation should fail without being performed", func() {
+				So(called, ShouldBeFalse)
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] delete operation not allowed: the provider is configured in read only mode (read_only = true)")
+			})
+		})
+	})
+	Convey("Given a resource factory and a client that is not configured in read only mode", t, func() {
+		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+		client := &clientOpenAPIReadOnlyModeStub{clientOpenAPIStub: &clientOpenAPIStub{}, readOnly: false}
+		Convey("When the wrapped operation is called", func() {
+			called := false
+			err := r.withReadOnlyModeCheck(TelemetryResourceOperationCreate, func(data *schema.ResourceData, i interface{}) error {
+				called = true
+				return nil
+			})(resourceData, client)
+			Convey("Then the operation should be performed", func() {
+				So(err, ShouldBeNil)
+				So(called, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestWithOperationNotification(t *testing.T) {
+	Convey("Given a resource factory and a client that supports notifying operations", t, func() {
+		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+		client := &clientOpenAPINotificationStub{clientOpenAPIStub: &clientOpenAPIStub{}}
+		Convey("When the wrapped operation sets the resource ID and succeeds", func() {
+			err := r.withOperationNotification(TelemetryResourceOperationCreate, func(data *schema.ResourceData, i interface{}) error {
+				data.SetId("someID")
+				return nil
+			})(resourceData, client)
+			Convey("Then the operation should be notified with the resource ID", func() {
+				So(err, ShouldBeNil)
+				So(client.operationsNotified, ShouldResemble, []TelemetryResourceOperation{TelemetryResourceOperationCreate})
+				So(client.resourceIDsNotified, ShouldResemble, []string{"someID"})
+				So(client.operationErrNotified, ShouldBeNil)
+			})
+		})
+		Convey("When the wrapped operation removes the resource ID and fails", func() {
+			resourceData.SetId("someID")
+			expectedErr := errors.New("some error")
+			err := r.withOperationNotification(TelemetryResourceOperationDelete, func(data *schema.ResourceData, i interface{}) error {
+				data.SetId("")
+				return expectedErr
+			})(resourceData, client)
+			Convey("Then the operation should be notified with the error and the ID the resource had before the operation", func() {
+				So(err, ShouldEqual, expectedErr)
+				So(client.operationsNotified, ShouldResemble, []TelemetryResourceOperation{TelemetryResourceOperationDelete})
+				So(client.resourceIDsNotified, ShouldResemble, []string{"someID"})
+				So(client.operationErrNotified, ShouldEqual, expectedErr)
 			})
 		})
 	})
@@ -76,6 +183,289 @@ func TestCreateTerraformResourceSchema(t *testing.T) {
 				// And the schema returned should not contain the ID property as schema already has a reserved ID field to store the unique identifier
 				So(schema, ShouldNotContainKey, idProperty.Name)
 				So(schema, ShouldContainKey, stringProperty.Name)
+				// And the schema returned should not contain the last_read_at attribute as the provider is not configured with refresh_skip_window
+				So(schema, ShouldNotContainKey, lastReadAtAttribute)
+			})
+		})
+	})
+	Convey("Given a resource factory of a provider configured with refresh_skip_window", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.refreshSkipWindowConfigured = true
+		Convey("When createResourceSchema is called", func() {
+			schema, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should contain the last_read_at attribute maintained by the provider", func() {
+				So(err, ShouldBeNil)
+				So(schema, ShouldContainKey, lastReadAtAttribute)
+				So(schema[lastReadAtAttribute].Computed, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a resource factory of a provider configured with refresh_skip_window and a resource that already has a property named last_read_at", t, func() {
+		lastReadAtProperty := newStringSchemaDefinitionPropertyWithDefaults(lastReadAtAttribute, "", true, false, nil)
+		r, _ := testCreateResourceFactory(t, idProperty, lastReadAtProperty)
+		r.refreshSkipWindowConfigured = true
+		Convey("When createResourceSchema is called", func() {
+			schema, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should keep the resource property", func() {
+				So(err, ShouldBeNil)
+				So(schema, ShouldContainKey, lastReadAtAttribute)
+				So(schema[lastReadAtAttribute].Required, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+// clientOpenAPIRefreshSkipWindowStub is a clientOpenAPIStub that supports skipping the refresh of the resources read recently
+type clientOpenAPIRefreshSkipWindowStub struct {
+	*clientOpenAPIStub
+	refreshSkipWindow time.Duration
+}
+
+func (c *clientOpenAPIRefreshSkipWindowStub) getRefreshSkipWindow() time.Duration {
+	return c.refreshSkipWindow
+}
+
+// clientOpenAPIStateEncryptionStub is a clientOpenAPIStub that supports encrypting property values in the state
+type clientOpenAPIStateEncryptionStub struct {
+	*clientOpenAPIStub
+	stateEncryptionKey []byte
+}
+
+func (c *clientOpenAPIStateEncryptionStub) getStateEncryptionKey() []byte {
+	return c.stateEncryptionKey
+}
+
+// clientOpenAPIRegionStub is a clientOpenAPIStub that makes the API calls against a region of a multi-region API
+type clientOpenAPIRegionStub struct {
+	*clientOpenAPIStub
+	region string
+}
+
+func (c *clientOpenAPIRegionStub) getResolvedRegion() (string, error) {
+	return c.region, nil
+}
+
+func TestEncryptPayloadValues(t *testing.T) {
+	Convey("Given a resource factory configured with a resource which schema contains an encrypted property", t, func() {
+		encryptedProperty := newStringSchemaDefinitionPropertyWithDefaults("secret", "", false, true, nil)
+		encryptedProperty.Encrypted = true
+		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty, encryptedProperty)
+		Convey("When read is called with a client configured with a state encryption key", func() {
+			client := &clientOpenAPIStateEncryptionStub{
+				clientOpenAPIStub:  &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue", encryptedProperty.Name: "secretValue"}},
+				stateEncryptionKey: testStateEncryptionKey,
+			}
+			err := r.read(resourceData, client)
+			So(err, ShouldBeNil)
+			stateValue := resourceData.Get(encryptedProperty.Name).(string)
+			Convey("Then the encrypted property should be persisted encrypted in the state and the rest of properties in plain text", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "remoteValue")
+				So(stateValue, ShouldStartWith, encryptedStateValuePrefix)
+				decryptedValue, err := decryptStateValue(testStateEncryptionKey, stateValue)
+				So(err, ShouldBeNil)
+				So(decryptedValue, ShouldEqual, "secretValue")
+			})
+			Convey("And when read is called again and the API returns the same value the encrypted value in the state should be kept", func() {
+				client.responsePayload = map[string]interface{}{stringProperty.Name: "remoteValue", encryptedProperty.Name: "secretValue"}
+				err := r.read(resourceData, client)
+				So(err, ShouldBeNil)
+				So(resourceData.Get(encryptedProperty.Name), ShouldEqual, stateValue)
+			})
+			Convey("And when read is called again and the API returns a different value the new value should be encrypted", func() {
+				client.responsePayload = map[string]interface{}{encryptedProperty.Name: "newSecretValue"}
+				err := r.read(resourceData, client)
+				So(err, ShouldBeNil)
+				decryptedValue, err := decryptStateValue(testStateEncryptionKey, resourceData.Get(encryptedProperty.Name).(string))
+				So(err, ShouldBeNil)
+				So(decryptedValue, ShouldEqual, "newSecretValue")
+			})
+		})
+		Convey("When read is called with a client that is not configured with a state encryption key", func() {
+			client := &clientOpenAPIStateEncryptionStub{
+				clientOpenAPIStub: &clientOpenAPIStub{responsePayload: map[string]interface{}{encryptedProperty.Name: "secretValue"}},
+			}
+			err := r.read(resourceData, client)
+			Convey("Then the error returned should be the expected one and the value should not be persisted", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] property 'secret' is configured to be encrypted in the state but the provider is not configured with the 'state_encryption_key' property")
+				So(resourceData.Get(encryptedProperty.Name), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestReadWithRefreshSkipWindow(t *testing.T) {
+	Convey("Given a resource factory of a provider configured with refresh_skip_window and a resource data read from the API within the last hour", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.refreshSkipWindowConfigured = true
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		resourceData := (&schema.Resource{Schema: s}).Data(nil)
+		resourceData.SetId("someID")
+		So(resourceData.Set(stringProperty.Name, "stateValue"), ShouldBeNil)
+		lastReadAt := time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+		So(resourceData.Set(lastReadAtAttribute, lastReadAt), ShouldBeNil)
+		Convey("When read is called with a client configured with a refresh skip window of one hour", func() {
+			client := &clientOpenAPIRefreshSkipWindowStub{clientOpenAPIStub: &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"}}, refreshSkipWindow: time.Hour}
+			err := r.read(resourceData, client)
+			Convey("Then the API should not be called and the state should be kept", func() {
+				So(err, ShouldBeNil)
+				So(client.idReceived, ShouldBeEmpty)
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "stateValue")
+				So(resourceData.Get(lastReadAtAttribute), ShouldEqual, lastReadAt)
+			})
+		})
+		Convey("When read is called with a client configured with a refresh skip window of five minutes", func() {
+			client := &clientOpenAPIRefreshSkipWindowStub{clientOpenAPIStub: &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"}}, refreshSkipWindow: 5 * time.Minute}
+			err := r.read(resourceData, client)
+			Convey("Then the resource should be refreshed and last_read_at updated", func() {
+				So(err, ShouldBeNil)
+				So(client.idReceived, ShouldEqual, "someID")
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "remoteValue")
+				newLastReadAt, err := time.Parse(time.RFC3339, resourceData.Get(lastReadAtAttribute).(string))
+				So(err, ShouldBeNil)
+				So(newLastReadAt, ShouldHappenWithin, time.Minute, time.Now())
+			})
+		})
+		Convey("When read is called with a client that is not configured with a refresh skip window", func() {
+			client := &clientOpenAPIRefreshSkipWindowStub{clientOpenAPIStub: &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"}}}
+			err := r.read(resourceData, client)
+			Convey("Then the resource should be refreshed and last_read_at should not be updated", func() {
+				So(err, ShouldBeNil)
+				So(client.idReceived, ShouldEqual, "someID")
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "remoteValue")
+				So(resourceData.Get(lastReadAtAttribute), ShouldEqual, lastReadAt)
+			})
+		})
+	})
+}
+
+func TestCreateTerraformResourceSchemaWithRegion(t *testing.T) {
+	Convey("Given a resource factory of a multi-region API", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.multiRegion = true
+		Convey("When createTerraformResourceSchema is called", func() {
+			schema, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should contain the computed region attribute and not the parent_region attribute", func() {
+				So(err, ShouldBeNil)
+				So(schema, ShouldContainKey, regionAttribute)
+				So(schema[regionAttribute].Computed, ShouldBeTrue)
+				So(schema, ShouldNotContainKey, parentRegionAttribute)
+			})
+		})
+	})
+	Convey("Given a sub-resource factory of a multi-region API", t, func() {
+		r, _ := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, "cdns_v1", idProperty, idProperty, stringProperty)
+		r.multiRegion = true
+		Convey("When createTerraformResourceSchema is called", func() {
+			schema, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should contain the computed region attribute and the optional parent_region attribute", func() {
+				So(err, ShouldBeNil)
+				So(schema[regionAttribute].Computed, ShouldBeTrue)
+				So(schema, ShouldContainKey, parentRegionAttribute)
+				So(schema[parentRegionAttribute].Optional, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a resource factory of an API that is not multi-region", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		Convey("When createTerraformResourceSchema is called", func() {
+			schema, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should not contain the region attributes", func() {
+				So(err, ShouldBeNil)
+				So(schema, ShouldNotContainKey, regionAttribute)
+				So(schema, ShouldNotContainKey, parentRegionAttribute)
+			})
+		})
+	})
+	Convey("Given a resource factory of a multi-region API which schema already contains a property named region", t, func() {
+		regionProperty := newStringSchemaDefinitionPropertyWithDefaults(regionAttribute, "", true, false, nil)
+		r, _ := testCreateResourceFactory(t, idProperty, regionProperty)
+		r.multiRegion = true
+		Convey("When createTerraformResourceSchema is called", func() {
+			schema, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should keep the resource property", func() {
+				So(err, ShouldBeNil)
+				So(schema[regionAttribute].Required, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestReadWithRegion(t *testing.T) {
+	Convey("Given a resource factory of a multi-region API and a resource data created in region us-west1", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.multiRegion = true
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		resourceData := (&schema.Resource{Schema: s}).Data(nil)
+		resourceData.SetId("someID")
+		So(resourceData.Set(regionAttribute, "us-west1"), ShouldBeNil)
+		Convey("When read is called with a client configured with the same region", func() {
+			client := &clientOpenAPIRegionStub{clientOpenAPIStub: &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"}}, region: "us-west1"}
+			err := r.read(resourceData, client)
+			Convey("Then the resource should be refreshed and the region kept", func() {
+				So(err, ShouldBeNil)
+				So(client.idReceived, ShouldEqual, "someID")
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "remoteValue")
+				So(resourceData.Get(regionAttribute), ShouldEqual, "us-west1")
+			})
+		})
+		Convey("When read is called with a client configured with a different region", func() {
+			client := &clientOpenAPIRegionStub{clientOpenAPIStub: &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"}}, region: "us-east1"}
+			err := r.read(resourceData, client)
+			Convey("Then the error returned should be the expected one and the API should not be called", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] the resource 'someID' was created in region 'us-west1' but the provider is configured with region 'us-east1'; resources can not be moved between regions, configure the resource with the provider for region 'us-west1'")
+				So(client.idReceived, ShouldBeEmpty)
+				So(resourceData.Id(), ShouldEqual, "someID")
+			})
+		})
+	})
+	Convey("Given a resource factory of a multi-region API and a resource data without region (e,g: created before the region was tracked)", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.multiRegion = true
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		resourceData := (&schema.Resource{Schema: s}).Data(nil)
+		resourceData.SetId("someID")
+		Convey("When read is called with a client configured with a region", func() {
+			client := &clientOpenAPIRegionStub{clientOpenAPIStub: &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"}}, region: "us-east1"}
+			err := r.read(resourceData, client)
+			Convey("Then the resource should be refreshed and the region populated", func() {
+				So(err, ShouldBeNil)
+				So(client.idReceived, ShouldEqual, "someID")
+				So(resourceData.Get(regionAttribute), ShouldEqual, "us-east1")
+			})
+		})
+	})
+}
+
+func TestCheckParentRegion(t *testing.T) {
+	Convey("Given a sub-resource factory of a multi-region API", t, func() {
+		r, _ := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, "cdns_v1", idProperty, idProperty, stringProperty)
+		r.multiRegion = true
+		client := &clientOpenAPIRegionStub{clientOpenAPIStub: &clientOpenAPIStub{}, region: "us-west1"}
+		Convey("When checkParentRegion is called with the same region the client is configured with", func() {
+			err := r.checkParentRegion("us-west1", client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When checkParentRegion is called with an empty parent region", func() {
+			err := r.checkParentRegion("", client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When checkParentRegion is called with a different region than the one the client is configured with", func() {
+			err := r.checkParentRegion("us-east1", client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='subResourceName'] the parent resource is in region 'us-east1' but the resource is configured to be managed in region 'us-west1'; sub-resources must be managed in the same region as their parent, configure the resource with the provider for region 'us-east1'")
+			})
+		})
+		Convey("When checkParentRegion is called with a client that does not support multi-region APIs", func() {
+			err := r.checkParentRegion("us-east1", &clientOpenAPIStub{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
 			})
 		})
 	})
@@ -120,7 +510,7 @@ func TestCreate(t *testing.T) {
 			}
 			err := r.create(resourceData, client)
 			Convey("Then the error returned should be the expected one", func() {
-				So(err, ShouldEqual, createError)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("[resource='resourceName'] POST /v1/resource failed: %s", createError))
 			})
 		})
 
@@ -131,7 +521,7 @@ func TestCreate(t *testing.T) {
 			}
 			err := r.create(resourceData, client)
 			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200 201 202] ()")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: HTTP Response Status Code 500 not matching expected one [200 201 202] ()")
 			})
 		})
 
@@ -157,6 +547,40 @@ func TestCreate(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory that has a create operation (post) configured with success conditions", t, func() {
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		postOperation := &specResourceOperation{successConditions: []specPollCondition{{path: []string{"result", "status"}, value: "ok"}}}
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), postOperation, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+		r := resourceFactory{openAPIResource: specResource}
+		Convey("When create is called with a client that returns a payload with an embedded error", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "someID",
+					"result":        map[string]interface{}{"status": "error", "message": "quota exceeded"},
+				},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be the expected one and the resource ID should not be set in the state", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: response payload does not meet the success condition 'result.status == ok' (actual value: error)")
+				So(resourceData.Id(), ShouldBeEmpty)
+			})
+		})
+		Convey("When create is called with a client that returns a payload meeting the success conditions", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "someID",
+					"result":        map[string]interface{}{"status": "ok"},
+				},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil and the resource ID should be set in the state", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Id(), ShouldEqual, "someID")
+			})
+		})
+	})
+
 	Convey("Given a resource factory that has an asynchronous create operation (post) but the polling operation fails for some reason", t, func() {
 		expectedReturnCode := 202
 		testSchema := newTestSchema(idProperty, stringProperty)
@@ -176,7 +600,10 @@ func TestCreate(t *testing.T) {
 			}
 			err := r.create(resourceData, client)
 			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "polling mechanism failed after POST /v1/resource call with response status code (202): error waiting for resource to reach a completion status ([]) [valid pending statuses ([])]: error on retrieving resource 'resourceName' (someID) when waiting: [resource='resourceName'] HTTP Response Status Code 202 not matching expected one [200] ()")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: polling mechanism failed after response status code (202): error waiting for resource to reach a completion status ([]) [valid pending statuses ([])]: error on retrieving resource 'resourceName' (someID) when waiting: HTTP Response Status Code 202 not matching expected one [200] ()")
+			})
+			Convey("And the resource ID should be kept in the state so the resource is not lost", func() {
+				So(resourceData.Id(), ShouldEqual, "someID")
 			})
 		})
 	})
@@ -258,6 +685,30 @@ func TestReadWithOptions(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory configured with properties that ignore drift and an OpenAPI client that returns a responsePayload", t, func() {
+		ignoreDriftProperty := newStringSchemaDefinitionPropertyWithDefaults("last_updated_by", "", false, true, "someUser")
+		ignoreDriftProperty.IgnoreDrift = true
+		ignoreDriftEmptyProperty := newStringSchemaDefinitionPropertyWithDefaults("revision", "", false, true, "")
+		ignoreDriftEmptyProperty.IgnoreDrift = true
+		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty, ignoreDriftProperty, ignoreDriftEmptyProperty)
+		client := &clientOpenAPIStub{
+			responsePayload: map[string]interface{}{
+				stringProperty.Name:           "someOtherStringValue",
+				ignoreDriftProperty.Name:      "someOtherUser",
+				ignoreDriftEmptyProperty.Name: "2",
+			},
+		}
+		Convey("When readWithOptions is called with handleNotFound set to false", func() {
+			err := r.readWithOptions(resourceData, client, false)
+			Convey("Then the properties that ignore drift should keep the state value if present and the rest should equal the responsePayload", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someOtherStringValue")
+				So(resourceData.Get(ignoreDriftProperty.Name), ShouldEqual, "someUser")
+				So(resourceData.Get(ignoreDriftEmptyProperty.Name), ShouldEqual, "2")
+			})
+		})
+	})
+
 	Convey("Given a resource factory with an empty OpenAPI resource and an empty OpenAPI client", t, func() {
 		r := resourceFactory{}
 		client := &clientOpenAPIStub{}
@@ -379,6 +830,44 @@ func TestGetParentID(t *testing.T) {
 	})
 }
 
+func TestResponseHeaderAttributes(t *testing.T) {
+	Convey("Given a resource factory configured with a resource exposing response headers as attributes", t, func() {
+		resource := newSpecStubResource("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{idProperty, stringProperty},
+		})
+		resource.responseHeaderAttributes = map[string]string{"x_version": "X-Version", "location": "Location", stringProperty.Name: "X-String"}
+		r := newResourceFactory(resource)
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		Convey("When createTerraformResourceSchema is called", func() {
+			Convey("Then the schema returned should contain the computed response header attributes", func() {
+				So(s["x_version"].Type, ShouldEqual, schema.TypeString)
+				So(s["x_version"].Computed, ShouldBeTrue)
+				So(s["location"].Computed, ShouldBeTrue)
+			})
+			Convey("And the response header attributes colliding with existing properties should not be added", func() {
+				So(s[stringProperty.Name].Computed, ShouldBeFalse)
+			})
+		})
+		Convey("When the resource is read with a response containing some of the headers", func() {
+			data := (&schema.Resource{Schema: s}).Data(nil)
+			data.SetId("id")
+			So(data.Set("location", "https://api.com/v1/resource/id"), ShouldBeNil)
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{stringProperty.Name: "someValue"},
+				responseHeaders: http.Header{"X-Version": []string{"3"}, "X-String": []string{"headerValue"}},
+			}
+			err := r.readWithOptions(data, client, false)
+			Convey("Then the attributes of the headers present in the response should be updated and the rest should be kept", func() {
+				So(err, ShouldBeNil)
+				So(data.Get("x_version"), ShouldEqual, "3")
+				So(data.Get("location"), ShouldEqual, "https://api.com/v1/resource/id")
+				So(data.Get(stringProperty.Name), ShouldEqual, "someValue")
+			})
+		})
+	})
+}
+
 func TestReadRemote(t *testing.T) {
 
 	Convey("Given a resource factory", t, func() {
@@ -411,7 +900,7 @@ func TestReadRemote(t *testing.T) {
 			}
 			_, err := r.readRemote("", client)
 			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] ()")
+				So(err.Error(), ShouldEqual, "HTTP Response Status Code 500 not matching expected one [200] ()")
 			})
 		})
 
@@ -493,7 +982,7 @@ func TestUpdate(t *testing.T) {
 			}
 			err := r.update(resourceData, client)
 			Convey("Then the error returned should be the error returned by the client update operation", func() {
-				So(err, ShouldEqual, updateError)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("[resource='resourceName'] GET /v1/resource/id failed: %s", updateError))
 			})
 		})
 	})
@@ -515,7 +1004,28 @@ func TestUpdate(t *testing.T) {
 			}
 			err := r.update(resourceData, client)
 			Convey("And the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200 202] ()")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] PUT /v1/resource/id failed: HTTP Response Status Code 500 not matching expected one [200 202 204] ()")
+			})
+		})
+		Convey("When update is called with resource data and a client that responds to the PUT request with 204 No Content", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "id",
+					stringProperty.Name: "valueReadAfterUpdate",
+				},
+				funcPut: func() (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusNoContent,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil
+				},
+			}
+			err := r.update(resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resourceData should be populated with the values read from the API after the update", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "valueReadAfterUpdate")
 			})
 		})
 		Convey("When update is called with resource data and a client returns a non expected error", func() {
@@ -531,7 +1041,7 @@ func TestUpdate(t *testing.T) {
 			}
 			err := r.update(resourceData, client)
 			Convey("And the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, expectedError)
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] PUT /v1/resource/id failed: "+expectedError)
 			})
 		})
 	})
@@ -586,7 +1096,7 @@ func TestUpdate(t *testing.T) {
 			}
 			err := r.update(resourceData, client)
 			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "polling mechanism failed after PUT /v1/resource call with response status code (202): error waiting for resource to reach a completion status ([]) [valid pending statuses ([])]: error occurred while retrieving status identifier value from payload for resource 'resourceName' (): could not find any status property. Please make sure the resource schema definition has either one property named 'status' or one property is marked with IsStatusIdentifier set to true")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] PUT /v1/resource/ failed: polling mechanism failed after response status code (202): error waiting for resource to reach a completion status ([]) [valid pending statuses ([])]: error occurred while retrieving status identifier value from payload for resource 'resourceName' (): could not find any status property. Please make sure the resource schema definition has either one property named 'status' or one property is marked with IsStatusIdentifier set to true")
 			})
 		})
 	})
@@ -607,6 +1117,105 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestExists(t *testing.T) {
+	Convey("Given a resource factory which instance path declares a HEAD operation", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty)
+		r.openAPIResource.(*specStubResource).resourceHeadOperation = &specResourceOperation{}
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the resource should be configured with the exists function", func() {
+				So(err, ShouldBeNil)
+				So(schemaResource.Exists, ShouldNotBeNil)
+			})
+		})
+		Convey("When exists is called with a client that returns a 200 status code", func() {
+			client := &clientOpenAPIStub{}
+			exists, err := r.exists(resourceData, client)
+			Convey("Then the resource should exist and the HEAD request should have been made for the resource id", func() {
+				So(err, ShouldBeNil)
+				So(exists, ShouldBeTrue)
+				So(client.idReceived, ShouldEqual, idProperty.Default)
+			})
+		})
+		Convey("When exists is called with a client that returns a 404 status code", func() {
+			client := &clientOpenAPIStub{returnHTTPCode: http.StatusNotFound}
+			exists, err := r.exists(resourceData, client)
+			Convey("Then the resource should not exist and the error should be nil", func() {
+				So(err, ShouldBeNil)
+				So(exists, ShouldBeFalse)
+			})
+		})
+		Convey("When exists is called with a client that returns a non expected status code", func() {
+			client := &clientOpenAPIStub{returnHTTPCode: http.StatusMethodNotAllowed}
+			exists, err := r.exists(resourceData, client)
+			Convey("Then the resource should be considered to exist so the read carries on as usual", func() {
+				So(err, ShouldBeNil)
+				So(exists, ShouldBeTrue)
+			})
+		})
+		Convey("When exists is called with a client that fails to perform the request", func() {
+			client := &clientOpenAPIStub{error: errors.New("some error")}
+			exists, err := r.exists(resourceData, client)
+			Convey("Then the resource should be considered to exist so the read carries on as usual", func() {
+				So(err, ShouldBeNil)
+				So(exists, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a resource factory which instance path does not declare a HEAD operation", t, func() {
+		r, _ := testCreateResourceFactoryWithID(t, idProperty)
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the resource should not be configured with the exists function", func() {
+				So(err, ShouldBeNil)
+				So(schemaResource.Exists, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestDeleteWithExistenceCheck(t *testing.T) {
+	Convey("Given a resource factory which DELETE operation has the existence check enabled", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty)
+		r.openAPIResource.getResourceOperations().Delete.existenceCheckEnabled = true
+		Convey("When delete is called with a client that returns a 404 status code", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: idProperty.Default,
+				},
+				returnHTTPCode: http.StatusNotFound,
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be nil and the DELETE operation should not have been performed", func() {
+				So(err, ShouldBeNil)
+				So(client.responsePayload, ShouldContainKey, idProperty.Name)
+			})
+		})
+		Convey("When delete is called with a client that returns the resource", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: idProperty.Default,
+				},
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be nil and the DELETE operation should have been performed", func() {
+				So(err, ShouldBeNil)
+				So(client.responsePayload, ShouldNotContainKey, idProperty.Name)
+			})
+		})
+		Convey("When delete is called with a client that returns a non expected status code", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{},
+				returnHTTPCode:  http.StatusInternalServerError,
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the DELETE operation should have been performed and the error returned should be the DELETE one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] DELETE /v1/resource/id failed: HTTP Response Status Code 500 not matching expected one [204 200 202] ()")
+			})
+		})
+	})
+}
+
 func TestDelete(t *testing.T) {
 	Convey("Given a resource factory", t, func() {
 		var telemetryHandlerResourceNameReceived string
@@ -632,101 +1241,539 @@ func TestDelete(t *testing.T) {
 				So(telemetryHandlerTFOperationReceived, ShouldEqual, TelemetryResourceOperationDelete)
 			})
 		})
-		Convey("When delete is called with resource data and a client configured to return an error when delete is called", func() {
-			deleteError := fmt.Errorf("some error when deleting")
-			client := &clientOpenAPIStub{
-				responsePayload: map[string]interface{}{
-					idProperty.Name: idProperty.Default,
-				},
-				error: deleteError,
-			}
-			err := r.delete(resourceData, client)
-			Convey("Then the error returned should be the error returned by the client delete operation", func() {
-				So(err, ShouldEqual, deleteError)
+		Convey("When delete is called with resource data and a client configured to return an error when delete is called", func() {
+			deleteError := fmt.Errorf("some error when deleting")
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: idProperty.Default,
+				},
+				error: deleteError,
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be the error returned by the client delete operation", func() {
+				So(err.Error(), ShouldEqual, fmt.Sprintf("[resource='resourceName'] DELETE /v1/resource/id failed: %s", deleteError))
+			})
+		})
+
+		Convey("When update is called with resource data and a client returns a non expected http code", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{},
+				returnHTTPCode:  http.StatusInternalServerError,
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] DELETE /v1/resource/id failed: HTTP Response Status Code 500 not matching expected one [204 200 202] ()")
+			})
+		})
+
+		Convey("When update is called with resource data and a client returns a 404 status code; hence the resource effectively no longer exists", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{},
+				returnHTTPCode:  http.StatusNotFound,
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should NOT be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a resource factory with a delete operation configured with a pre-delete request", t, func() {
+		testSchema := newTestSchema(idProperty)
+		resourceData := testSchema.getResourceData(t)
+		resourceData.SetId("id")
+		deleteOperation := &specResourceOperation{preDelete: &specPreDelete{method: http.MethodPatch, payload: map[string]interface{}{"status": "disabled"}}}
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, deleteOperation)
+		r := resourceFactory{openAPIResource: specResource}
+		Convey("When delete is called with resource data and a client", func() {
+			client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "id"}}
+			err := r.delete(resourceData, client)
+			Convey("Then the pre-delete request should be performed before the resource is deleted", func() {
+				So(err, ShouldBeNil)
+				So(client.preDeleteIDReceived, ShouldEqual, "id")
+				So(client.idReceived, ShouldEqual, "id")
+			})
+		})
+		Convey("When delete is called with resource data and a client that fails the pre-delete request", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{"id": "id"},
+				funcPreDelete: func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusConflict, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be the expected one and the resource should not be deleted", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] PATCH /v1/resource/id failed: HTTP Response Status Code 409 not matching expected one [200 202 204] ()")
+				So(client.idReceived, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a resource factory with no delete operation configured", t, func() {
+		specResource := newSpecStubResource("resourceName", "/v1/resource", false, nil)
+		r := newResourceFactory(specResource)
+		Convey("When delete is called with resource data and a client", func() {
+			client := &clientOpenAPIStub{}
+			err := r.delete(&schema.ResourceData{}, client)
+			Convey("Then the error returned should be", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] resource does not support DELETE operation, check the swagger file exposed on '/v1/resource'")
+			})
+		})
+	})
+
+	Convey("Given a resource factory with an empty OpenAPI resource", t, func() {
+		r := resourceFactory{}
+		Convey("When delete is called with empty data and a empty client", func() {
+			client := &clientOpenAPIStub{}
+			err := r.delete(nil, client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "missing openAPI resource configuration")
+			})
+		})
+	})
+
+	Convey("Given a resource factory that has an asynchronous create operation (delete) but the polling operation fails for some reason", t, func() {
+		expectedReturnCode := 202
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{responses: specResponses{expectedReturnCode: &specResponse{isPollingEnabled: true}}})
+		r := resourceFactory{
+			openAPIResource: specResource,
+			defaultTimeout:  time.Duration(0 * time.Second),
+		}
+		Convey("When create is called with resource data and a client", func() {
+			client := &clientOpenAPIStub{
+				returnHTTPCode: expectedReturnCode,
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "someID",
+					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
+				},
+			}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] DELETE /v1/resource/ failed: polling mechanism failed after response status code (202): error waiting for resource to reach a completion status ([destroyed]) [valid pending statuses ([])]: error on retrieving resource 'resourceName' () when waiting: HTTP Response Status Code 202 not matching expected one [200] ()")
+			})
+		})
+	})
+
+	Convey("Given a resource factory where getResourcePath returns an error", t, func() {
+		r := resourceFactory{
+			openAPIResource: &specStubResource{
+				funcGetResourcePath: func(parentIDs []string) (s string, e error) {
+					return "", errors.New("getResourcePath() failed")
+				}},
+		}
+		Convey("When delete is called with resource data and an empty clientOpenAPI", func() {
+			err := r.delete(&schema.ResourceData{}, &clientOpenAPIStub{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "getResourcePath() failed")
+			})
+		})
+	})
+}
+
+func TestResourceHeaderAttributes(t *testing.T) {
+	Convey("Given a resource factory configured with a resource which operations contain headers exposed as resource attributes", t, func() {
+		tenantHeader := SpecHeaderParam{Name: "X-Tenant-ID", IsRequired: true, IsResourceAttribute: true}
+		labelHeader := SpecHeaderParam{Name: "Label", IsResourceAttribute: true}
+		requestIDHeader := SpecHeaderParam{Name: "X-Request-ID"}
+		postOperation := &specResourceOperation{HeaderParameters: SpecHeaderParameters{tenantHeader, requestIDHeader, labelHeader}}
+		getOperation := &specResourceOperation{HeaderParameters: SpecHeaderParameters{tenantHeader}}
+		r := newResourceFactory(newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+			},
+		}, postOperation, nil, getOperation, nil))
+		Convey("When createTerraformResourceSchema is called", func() {
+			s, err := r.createTerraformResourceSchema()
+			Convey("Then the schema should contain the header exposed as an optional attribute", func() {
+				So(err, ShouldBeNil)
+				So(s, ShouldContainKey, "x_tenant_id")
+				So(s["x_tenant_id"].Type, ShouldEqual, schema.TypeString)
+				So(s["x_tenant_id"].Optional, ShouldBeTrue)
+			})
+			Convey("And the headers not exposed as resource attributes or colliding with existing properties should not be added", func() {
+				So(s, ShouldNotContainKey, "x_request_id")
+				So(s["label"].Required, ShouldBeTrue)
+			})
+		})
+		Convey("When getClientWithResourceAttributes is called with a provider client and a resource data containing the header attribute value", func() {
+			s, err := r.createTerraformResourceSchema()
+			So(err, ShouldBeNil)
+			data := (&schema.Resource{Schema: s}).Data(nil)
+			So(data.Set("x_tenant_id", "resourceTenant"), ShouldBeNil)
+			providerClient := &ProviderClient{providerConfiguration: providerConfiguration{Headers: map[string]string{"x_tenant_id": "providerTenant"}}}
+			client := r.getClientWithResourceAttributes(providerClient, data)
+			Convey("Then the client returned should send the header value configured in the resource", func() {
+				So(client.(*ProviderClient).providerConfiguration.getHeaderValueFor(tenantHeader), ShouldEqual, "resourceTenant")
+			})
+		})
+		Convey("When getClientWithResourceAttributes is called with a client that does not support resource headers", func() {
+			client := &clientOpenAPIStub{}
+			Convey("Then the same client should be returned", func() {
+				So(r.getClientWithResourceAttributes(client, nil), ShouldEqual, client)
+			})
+		})
+	})
+}
+
+func TestGetClientWithTimeout(t *testing.T) {
+	Convey("Given a resource factory and a resource data configured with a create timeout", t, func() {
+		r := newResourceFactory(&specStubResource{})
+		createTimeout := 30 * time.Minute
+		data := (&schema.Resource{Schema: map[string]*schema.Schema{}, Timeouts: &schema.ResourceTimeout{Create: &createTimeout}}).Data(nil)
+		Convey("When getClientWithTimeout is called with a provider client and the create timeout key", func() {
+			client := r.getClientWithTimeout(&ProviderClient{}, data, schema.TimeoutCreate)
+			Convey("Then the client returned should retry the requests until the create timeout expires", func() {
+				So(client.(*ProviderClient).retryDeadline, ShouldHappenWithin, time.Minute, time.Now().Add(createTimeout))
+			})
+		})
+		Convey("When getClientWithTimeout is called with a client that does not support retry timeouts", func() {
+			client := &clientOpenAPIStub{}
+			Convey("Then the same client should be returned", func() {
+				So(r.getClientWithTimeout(client, data, schema.TimeoutCreate), ShouldEqual, client)
+			})
+		})
+	})
+}
+
+func TestResourceQueryParamAttributes(t *testing.T) {
+	Convey("Given a resource factory configured with a resource which operations contain query parameters exposed as resource attributes", t, func() {
+		validateOnlyQueryParam := SpecQueryParam{Name: "validateOnly", IsResourceAttribute: true}
+		labelQueryParam := SpecQueryParam{Name: "label", IsResourceAttribute: true}
+		forceQueryParam := SpecQueryParam{Name: "force", Value: "true"}
+		postOperation := &specResourceOperation{QueryParameters: SpecQueryParameters{validateOnlyQueryParam, labelQueryParam}}
+		deleteOperation := &specResourceOperation{QueryParameters: SpecQueryParameters{forceQueryParam}}
+		r := newResourceFactory(newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+			},
+		}, postOperation, nil, nil, deleteOperation))
+		Convey("When createTerraformResourceSchema is called", func() {
+			s, err := r.createTerraformResourceSchema()
+			Convey("Then the schema should contain the query parameter exposed as an optional attribute", func() {
+				So(err, ShouldBeNil)
+				So(s, ShouldContainKey, "validate_only")
+				So(s["validate_only"].Type, ShouldEqual, schema.TypeString)
+				So(s["validate_only"].Optional, ShouldBeTrue)
+			})
+			Convey("And the query parameters pinned to a value or colliding with existing properties should not be added", func() {
+				So(s, ShouldNotContainKey, "force")
+				So(s["label"].Required, ShouldBeTrue)
+			})
+		})
+		Convey("When getClientWithResourceAttributes is called with a provider client and a resource data containing the query parameter attribute value", func() {
+			s, err := r.createTerraformResourceSchema()
+			So(err, ShouldBeNil)
+			data := (&schema.Resource{Schema: s}).Data(nil)
+			So(data.Set("validate_only", "true"), ShouldBeNil)
+			client := r.getClientWithResourceAttributes(&ProviderClient{}, data)
+			Convey("Then the client returned should send the query parameter value configured in the resource", func() {
+				So(client.(*ProviderClient).resourceQueryParameters, ShouldResemble, map[string]string{"validate_only": "true"})
+			})
+		})
+	})
+}
+
+func TestResourceIdentityHeaders(t *testing.T) {
+	Convey("Given a resource factory", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		Convey("When createTerraformResourceSchema is called", func() {
+			s, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should not contain the identity_headers attribute as the provider is not configured with identity_headers", func() {
+				So(err, ShouldBeNil)
+				So(s, ShouldNotContainKey, identityHeadersAttribute)
+			})
+		})
+	})
+	Convey("Given a resource factory of a provider configured with identity_headers", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.identityHeadersConfigured = true
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		Convey("When createTerraformResourceSchema is called", func() {
+			Convey("Then the schema returned should contain the optional identity_headers attribute", func() {
+				So(s, ShouldContainKey, identityHeadersAttribute)
+				So(s[identityHeadersAttribute].Type, ShouldEqual, schema.TypeMap)
+				So(s[identityHeadersAttribute].Optional, ShouldBeTrue)
+			})
+		})
+		Convey("When getClientWithResourceAttributes is called with a provider client and a resource data containing identity headers", func() {
+			data := (&schema.Resource{Schema: s}).Data(nil)
+			So(data.Set(identityHeadersAttribute, map[string]interface{}{"X-Tenant-Id": "tenant-b"}), ShouldBeNil)
+			providerClient := &ProviderClient{providerConfiguration: providerConfiguration{IdentityHeaders: map[string]string{"X-Tenant-Id": "tenant-a", "X-Project-Id": "project-a"}}}
+			client := r.getClientWithResourceAttributes(providerClient, data)
+			Convey("Then the client returned should send the identity headers configured in the resource instead of the provider ones", func() {
+				So(client.(*ProviderClient).providerConfiguration.IdentityHeaders, ShouldResemble, map[string]string{"X-Tenant-Id": "tenant-b", "X-Project-Id": "project-a"})
+			})
+		})
+	})
+	Convey("Given a resource factory of a provider configured with identity_headers and a resource that already has a property named identity_headers", t, func() {
+		identityHeadersProperty := newStringSchemaDefinitionPropertyWithDefaults(identityHeadersAttribute, "", true, false, nil)
+		r, _ := testCreateResourceFactory(t, idProperty, identityHeadersProperty)
+		r.identityHeadersConfigured = true
+		Convey("When createTerraformResourceSchema is called", func() {
+			s, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should keep the resource property", func() {
+				So(err, ShouldBeNil)
+				So(s[identityHeadersAttribute].Type, ShouldEqual, schema.TypeString)
+				So(s[identityHeadersAttribute].Required, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestResourceAPIVersion(t *testing.T) {
+	Convey("Given a resource factory configured with a resource exposed in multiple versions", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		resourceV2 := newSpecStubResource("resourceName_v2", "/v2/resource", false, nil)
+		r.apiVersions = map[string]SpecResource{"v1": r.openAPIResource, "v2": resourceV2}
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		Convey("When createTerraformResourceSchema is called", func() {
+			Convey("Then the schema should contain the optional api_version attribute accepting the versions of the resource", func() {
+				So(s, ShouldContainKey, apiVersionAttribute)
+				So(s[apiVersionAttribute].Optional, ShouldBeTrue)
+				_, errs := s[apiVersionAttribute].ValidateFunc("v2", apiVersionAttribute)
+				So(errs, ShouldBeEmpty)
+				_, errs = s[apiVersionAttribute].ValidateFunc("v3", apiVersionAttribute)
+				So(errs[0].Error(), ShouldEqual, "property 'api_version' value 'v3' is not valid, the supported values are: v1, v2")
+			})
+		})
+		Convey("When withAPIVersion is called with a resource data configured with a different version", func() {
+			data := (&schema.Resource{Schema: s}).Data(nil)
+			So(data.Set(apiVersionAttribute, "v2"), ShouldBeNil)
+			path, err := r.withAPIVersion(data).openAPIResource.getResourcePath(nil)
+			Convey("Then the resource factory returned should make the API calls against the path of the version configured", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/v2/resource")
+			})
+		})
+		Convey("When withAPIVersion is called with a resource data not configured with a version", func() {
+			data := (&schema.Resource{Schema: s}).Data(nil)
+			path, err := r.withAPIVersion(data).openAPIResource.getResourcePath(nil)
+			Convey("Then the resource factory returned should make the API calls against the path of the resource", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/v1/resource")
+			})
+		})
+	})
+	Convey("Given a resource factory configured with a resource exposed in a single version", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		Convey("When createTerraformResourceSchema is called", func() {
+			s, err := r.createTerraformResourceSchema()
+			Convey("Then the schema should not contain the api_version attribute", func() {
+				So(err, ShouldBeNil)
+				So(s, ShouldNotContainKey, apiVersionAttribute)
+			})
+		})
+	})
+}
+
+// testUnknownVariableValue is the value terraform uses to represent the values that are not known at plan time
+const testUnknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestValidateRequiredQueryParamAttributes(t *testing.T) {
+	Convey("Given a resource which create operation contains a required query parameter exposed as a resource attribute", t, func() {
+		postOperation := &specResourceOperation{QueryParameters: SpecQueryParameters{{Name: "validateOnly", IsRequired: true, IsResourceAttribute: true}}}
+		r := newResourceFactory(newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{stringProperty},
+		}, postOperation, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}))
+		resource, err := r.createTerraformResource()
+		So(err, ShouldBeNil)
+		Convey("When the resource is planned with a configuration where the query parameter attribute is not known yet", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{stringProperty.Name: "value", "validate_only": testUnknownVariableValue}), &clientOpenAPIStub{})
+			Convey("Then the validation should be deferred until the changes are applied", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the resource is planned with a configuration where the query parameter attribute has a value", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{stringProperty.Name: "value", "validate_only": "true"}), &clientOpenAPIStub{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the resource is planned with a configuration missing the query parameter attribute", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{stringProperty.Name: "value"}), &clientOpenAPIStub{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] required query parameter 'validateOnly' is missing the value. Please make sure the property 'validate_only' is configured with a value in the resource's terraform configuration")
+			})
+		})
+	})
+}
+
+func TestForceNewOnItemFieldsChanges(t *testing.T) {
+	Convey("Given a resource factory configured with an array of objects property which item field 'zone' forces new", t, func() {
+		listProperty := newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, TypeObject, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("zone", "", true, false, nil),
+			},
+		})
+		listProperty.ForceNewItemFields = []string{"zone"}
+		r := newResourceFactory(newSpecStubResource("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{idProperty, listProperty},
+		}))
+		resource, err := r.createTerraformResource()
+		So(err, ShouldBeNil)
+		So(resource.CustomizeDiff, ShouldNotBeNil)
+		state := &terraform.InstanceState{
+			ID: "id",
+			Attributes: map[string]string{
+				"id":           "id",
+				"rules.#":      "1",
+				"rules.0.name": "rule",
+				"rules.0.zone": "zone-a",
+			},
+		}
+		Convey("When the resource is planned with a configuration where the force new item field of an existing item changed", func() {
+			diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "rule", "zone": "zone-b"}}}), &clientOpenAPIStub{})
+			Convey("Then the diff should require the re-creation of the resource", func() {
+				So(err, ShouldBeNil)
+				So(diff.RequiresNew(), ShouldBeTrue)
+			})
+		})
+		Convey("When the resource is planned with a configuration where other item field of an existing item changed", func() {
+			diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "other-rule", "zone": "zone-a"}}}), &clientOpenAPIStub{})
+			Convey("Then the resource should be updated in place", func() {
+				So(err, ShouldBeNil)
+				So(diff.RequiresNew(), ShouldBeFalse)
 			})
 		})
-
-		Convey("When update is called with resource data and a client returns a non expected http code", func() {
-			client := &clientOpenAPIStub{
-				responsePayload: map[string]interface{}{},
-				returnHTTPCode:  http.StatusInternalServerError,
-			}
-			err := r.delete(resourceData, client)
-			Convey("Then the error returned should be", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] DELETE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [204 200 202] ()")
+		Convey("When the resource is planned with a configuration where a new item is added", func() {
+			diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "rule", "zone": "zone-a"}, map[string]interface{}{"name": "new-rule", "zone": "zone-b"}}}), &clientOpenAPIStub{})
+			Convey("Then the resource should be updated in place", func() {
+				So(err, ShouldBeNil)
+				So(diff.RequiresNew(), ShouldBeFalse)
 			})
 		})
+	})
+}
 
-		Convey("When update is called with resource data and a client returns a 404 status code; hence the resource effectively no longer exists", func() {
+func TestUpdateWithParameterAttributeChangesOnly(t *testing.T) {
+	Convey("Given a resource factory configured with a resource which operations contain a query parameter exposed as a resource attribute", t, func() {
+		postOperation := &specResourceOperation{QueryParameters: SpecQueryParameters{{Name: "validateOnly", IsResourceAttribute: true}}}
+		r := newResourceFactory(newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{idProperty, stringProperty},
+		}, postOperation, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}))
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		Convey("When update is called with a resource data where only the query parameter attribute changed (e,g: the value was not known at plan time)", func() {
+			data := (&schema.Resource{Schema: s}).Data(nil)
+			data.SetId("id")
+			So(data.Set("validate_only", "true"), ShouldBeNil)
+			putCalled := false
 			client := &clientOpenAPIStub{
-				responsePayload: map[string]interface{}{},
-				returnHTTPCode:  http.StatusNotFound,
+				funcPut: func() (*http.Response, error) {
+					putCalled = true
+					return &http.Response{StatusCode: http.StatusOK}, nil
+				},
 			}
-			err := r.delete(resourceData, client)
-			Convey("Then the error returned should NOT be nil", func() {
+			err := r.update(data, client)
+			Convey("Then the API should not be called and the new value should be kept", func() {
 				So(err, ShouldBeNil)
+				So(putCalled, ShouldBeFalse)
+				So(data.Get("validate_only"), ShouldEqual, "true")
 			})
 		})
 	})
+}
 
-	Convey("Given a resource factory with no delete operation configured", t, func() {
-		specResource := newSpecStubResource("resourceName", "/v1/resource", false, nil)
-		r := newResourceFactory(specResource)
-		Convey("When delete is called with resource data and a client", func() {
-			client := &clientOpenAPIStub{}
-			err := r.delete(&schema.ResourceData{}, client)
-			Convey("Then the error returned should be", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] resource does not support DELETE operation, check the swagger file exposed on '/v1/resource'")
+func TestSetImportedDefaultValues(t *testing.T) {
+	Convey("Given a resource factory configured with a resource containing optional properties with default values", t, func() {
+		r := newResourceFactory(newSpecStubResource("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				idProperty,
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, "default label"),
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, 80),
+				newStringSchemaDefinitionPropertyWithDefaults("status", "", false, true, "deployed"),
+			},
+		}))
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		data := (&schema.Resource{Schema: s}).Data(nil)
+		data.SetId("id")
+		So(data.Set("port", 8080), ShouldBeNil)
+		Convey("When setImportedDefaultValues is called with a state populated by the import read", func() {
+			err := r.setImportedDefaultValues(data)
+			Convey("Then the properties not returned by the API should be populated with their default values", func() {
+				So(err, ShouldBeNil)
+				So(data.Get("label"), ShouldEqual, "default label")
+			})
+			Convey("And the properties returned by the API should keep their values", func() {
+				So(data.Get("port"), ShouldEqual, 8080)
+			})
+			Convey("And the computed properties should not be populated", func() {
+				So(data.Get("status"), ShouldEqual, "")
 			})
 		})
 	})
+}
 
-	Convey("Given a resource factory with an empty OpenAPI resource", t, func() {
-		r := resourceFactory{}
-		Convey("When delete is called with empty data and a empty client", func() {
-			client := &clientOpenAPIStub{}
-			err := r.delete(nil, client)
-			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "missing openAPI resource configuration")
+func TestSetClientGeneratedValues(t *testing.T) {
+	Convey("Given a resource factory configured with a resource containing client generated properties", t, func() {
+		r := newResourceFactory(newSpecStubResource("resourceName", "/v1/resource", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				idProperty,
+				&SpecSchemaDefinitionProperty{Name: "request_id", Type: TypeString, Computed: true, ClientGenerated: &clientGeneratedValue{kind: clientGeneratedUUID}},
+				&SpecSchemaDefinitionProperty{Name: "token", Type: TypeString, Computed: true, ClientGenerated: &clientGeneratedValue{kind: clientGeneratedRandomString, length: 10, charset: "x"}},
+			},
+		}))
+		s, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		data := (&schema.Resource{Schema: s}).Data(nil)
+		So(data.Set("token", "configured"), ShouldBeNil)
+		Convey("When setClientGeneratedValues is called", func() {
+			err := r.setClientGeneratedValues(data)
+			Convey("Then the properties not configured should be populated with generated values", func() {
+				So(err, ShouldBeNil)
+				So(uuidRegex.MatchString(data.Get("request_id").(string)), ShouldBeTrue)
+			})
+			Convey("And the properties configured should keep their values", func() {
+				So(data.Get("token"), ShouldEqual, "configured")
 			})
 		})
 	})
+}
 
-	Convey("Given a resource factory that has an asynchronous create operation (delete) but the polling operation fails for some reason", t, func() {
-		expectedReturnCode := 202
-		testSchema := newTestSchema(idProperty, stringProperty)
-		resourceData := testSchema.getResourceData(t)
-		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{responses: specResponses{expectedReturnCode: &specResponse{isPollingEnabled: true}}})
-		r := resourceFactory{
-			openAPIResource: specResource,
-			defaultTimeout:  time.Duration(0 * time.Second),
+func TestGetUnmappedPayloadProperties(t *testing.T) {
+	Convey("Given a schema definition containing primitive, object and list of objects properties", t, func() {
+		objectSchemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("account", "", false, false, nil),
+			},
 		}
-		Convey("When create is called with resource data and a client", func() {
-			client := &clientOpenAPIStub{
-				returnHTTPCode: expectedReturnCode,
-				responsePayload: map[string]interface{}{
-					idProperty.Name:     "someID",
-					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
+		schemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				idProperty,
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				newObjectSchemaDefinitionPropertyWithDefaults("object_property", "", false, false, false, nil, objectSchemaDefinition),
+				newListSchemaDefinitionPropertyWithDefaults("list_property", "", false, false, false, nil, TypeObject, objectSchemaDefinition),
+			},
+		}
+		Convey("When getUnmappedPayloadProperties is called with a payload containing only properties specified in the schema", func() {
+			unmappedProperties := getUnmappedPayloadProperties(schemaDefinition, map[string]interface{}{
+				"id":              "someID",
+				"label":           "some label",
+				"object_property": map[string]interface{}{"account": "some account"},
+				"list_property":   []interface{}{map[string]interface{}{"account": "some account"}},
+			})
+			Convey("Then no properties should be returned", func() {
+				So(unmappedProperties, ShouldBeEmpty)
+			})
+		})
+		Convey("When getUnmappedPayloadProperties is called with a payload containing properties not specified in the schema", func() {
+			unmappedProperties := getUnmappedPayloadProperties(schemaDefinition, map[string]interface{}{
+				"id":              "someID",
+				"unknown":         "some value",
+				"object_property": map[string]interface{}{"account": "some account", "unknown": "some value"},
+				"list_property": []interface{}{
+					map[string]interface{}{"account": "some account", "unknown": "some value"},
+					map[string]interface{}{"account": "other account", "unknown": "other value"},
 				},
-			}
-			err := r.delete(resourceData, client)
-			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "polling mechanism failed after DELETE /v1/resource call with response status code (202): error waiting for resource to reach a completion status ([destroyed]) [valid pending statuses ([])]: error on retrieving resource 'resourceName' () when waiting: [resource='resourceName'] HTTP Response Status Code 202 not matching expected one [200] ()")
 			})
-		})
-	})
-
-	Convey("Given a resource factory where getResourcePath returns an error", t, func() {
-		r := resourceFactory{
-			openAPIResource: &specStubResource{
-				funcGetResourcePath: func(parentIDs []string) (s string, e error) {
-					return "", errors.New("getResourcePath() failed")
-				}},
-		}
-		Convey("When delete is called with resource data and an empty clientOpenAPI", func() {
-			err := r.delete(&schema.ResourceData{}, &clientOpenAPIStub{})
-			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "getResourcePath() failed")
+			Convey("Then the sorted names of the unmapped properties (prefixed with the parent property names) should be returned", func() {
+				So(unmappedProperties, ShouldResemble, []string{"list_property.unknown", "object_property.unknown", "unknown"})
 			})
 		})
 	})
@@ -851,7 +1898,7 @@ func TestImporter(t *testing.T) {
 			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
 				_, err := resourceImporter.State(resourceData, client)
 				Convey("Then the err returned should be the expected one", func() {
-					So(err.Error(), ShouldEqual, "can not import a subresource without providing all the parent IDs (1) and the instance ID")
+					So(err.Error(), ShouldEqual, "can not import a subresource without providing all the parent IDs (1) and the instance ID (environment variables not set: OPENAPI_IMPORT_CDNS_V1_ID): the import ID provided 'someStringThatDoesNotMatchTheExpectedSubResourceIDFormat' must be in the format '{cdns_v1_id}/{id}', or contain just the instance ID with the parent IDs provided via the environment variables OPENAPI_IMPORT_CDNS_V1_ID")
 				})
 			})
 		})
@@ -879,7 +1926,7 @@ func TestImporter(t *testing.T) {
 			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
 				_, err := resourceImporter.State(resourceData, client)
 				Convey("Then the err returned should be the expected one", func() {
-					So(err.Error(), ShouldEqual, "the number of parent IDs provided 3 is greater than the expected number of parent IDs 1")
+					So(err.Error(), ShouldEqual, "the number of parent IDs provided 3 is greater than the expected number of parent IDs 1: the import ID provided '/extraID/1234/23564' must be in the format '{cdns_v1_id}/{id}', or contain just the instance ID with the parent IDs provided via the environment variables OPENAPI_IMPORT_CDNS_V1_ID")
 				})
 			})
 		})
@@ -906,12 +1953,55 @@ func TestImporter(t *testing.T) {
 			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
 				_, err := resourceImporter.State(resourceData, client)
 				Convey("Then the err returned should be the expected one", func() {
-					So(err.Error(), ShouldEqual, "can not import a subresource without all the parent ids, expected 2 and got 1 parent IDs")
+					So(err.Error(), ShouldEqual, "can not import a subresource without all the parent ids, expected 2 and got 1 parent IDs: the import ID provided '1234/5647' must be in the format '{cdns_v1_id}/{cdns_v1_firewalls_v1_id}/{id}', or contain just the instance ID with the parent IDs provided via the environment variables OPENAPI_IMPORT_CDNS_V1_ID, OPENAPI_IMPORT_CDNS_V1_FIREWALLS_V1_ID")
 				})
 			})
 		})
 	})
 
+	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value containing just the instance ID)", t, func() {
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "159")
+		expectedParentProperty := newStringSchemaDefinitionProperty("cdns_v1_id", "", true, true, false, false, false, true, false, false, "")
+		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, "cdns_v1", importedIDProperty, stringProperty, expectedParentProperty)
+		Convey("When the resourceImporter State method is invoked with the parent ID provided via the environment variable", func() {
+			os.Setenv("OPENAPI_IMPORT_CDNS_V1_ID", "32")
+			defer os.Unsetenv("OPENAPI_IMPORT_CDNS_V1_ID")
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					stringProperty.Name: "someOtherStringValue",
+				},
+			}
+			data, err := r.importer().State(resourceData, client)
+			Convey("Then the parent ID should be the one provided in the environment variable and the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(data[0].Get("cdns_v1_id"), ShouldEqual, "32")
+				So(data[0].Id(), ShouldEqual, "159")
+				So(client.parentIDsReceived, ShouldResemble, []string{"32"})
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a sub-resource which parent ID is declared as integer", t, func() {
+		expectedParentProperty := newStringSchemaDefinitionProperty("cdns_v1_id", "", true, true, false, false, false, true, false, false, "")
+		expectedParentProperty.ParentIDFormat = parentIDFormatInteger
+		Convey("When the resourceImporter State method is invoked with an import ID containing an empty parent ID", func() {
+			importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "/159")
+			r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, "cdns_v1", importedIDProperty, stringProperty, expectedParentProperty)
+			_, err := r.importer().State(resourceData, &clientOpenAPIStub{})
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the parent ID for 'cdns_v1_id' is empty: the import ID provided '/159' must be in the format '{cdns_v1_id}/{id}', or contain just the instance ID with the parent IDs provided via the environment variables OPENAPI_IMPORT_CDNS_V1_ID")
+			})
+		})
+		Convey("When the resourceImporter State method is invoked with an import ID containing a parent ID that does not match the parent ID format", func() {
+			importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "my-cdn/159")
+			r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, "cdns_v1", importedIDProperty, stringProperty, expectedParentProperty)
+			_, err := r.importer().State(resourceData, &clientOpenAPIStub{})
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the import ID 'my-cdn/159' is not valid: property 'cdns_v1_id' must contain the parent resource ID which is expected to be of format integer but got 'my-cdn', make sure the parent is referenced by its ID (e,g: <parent_resource>.<name>.id) and not by its name")
+			})
+		})
+	})
+
 	Convey("Given a resource factory configured with a sub-resource missing the parent resource property in the schema", t, func() {
 		importedIDValue := "1234/5678"
 		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importedIDValue)
@@ -1001,6 +2091,43 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 			})
 		})
 
+		Convey("When handlePollingIfConfigured is called with an operation that has polling enabled AND the original response payload contains properties not returned once the resource reaches the target status", func() {
+			targetState := "deployed"
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     idProperty.Default,
+					stringProperty.Name: "assignedValue",
+					statusProperty.Name: targetState,
+				},
+				returnHTTPCode: http.StatusOK,
+			}
+			responsePayload := map[string]interface{}{
+				idProperty.Name:     idProperty.Default,
+				statusProperty.Name: "pending",
+				"initial_secret":    "returnedOnlyOnCreation",
+			}
+			responseStatusCode := http.StatusAccepted
+			operation := &specResourceOperation{
+				responses: map[int]*specResponse{
+					responseStatusCode: {
+						isPollingEnabled:    true,
+						pollPendingStatuses: []string{"pending"},
+						pollTargetStatuses:  []string{targetState},
+					},
+				},
+			}
+			err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			Convey("Then the terminal status payload should be merged into the original response payload and the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(responsePayload, ShouldResemble, map[string]interface{}{
+					idProperty.Name:     idProperty.Default,
+					stringProperty.Name: "assignedValue",
+					statusProperty.Name: targetState,
+					"initial_secret":    "returnedOnlyOnCreation",
+				})
+			})
+		})
+
 		Convey("When handlePollingIfConfigured is called with an operation that has a response defined for the API response status code passed in and polling is enabled AND the responsePayload is nil (meaning we are handling a DELETE operation)", func() {
 			targetState := "deployed"
 			client := &clientOpenAPIStub{
@@ -1027,6 +2154,38 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 			})
 		})
 
+		Convey("When handlePollingIfConfigured is called with an operation that has polling enabled with completed conditions AND the API returns a payload that meets all the conditions", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     idProperty.Default,
+					stringProperty.Name: stringProperty.Default,
+					statusProperty.Name: "deployed",
+					"health":            map[string]interface{}{"status": "healthy"},
+				},
+				returnHTTPCode: http.StatusOK,
+			}
+			responsePayload := map[string]interface{}{}
+			responseStatusCode := http.StatusAccepted
+			operation := &specResourceOperation{
+				responses: map[int]*specResponse{
+					responseStatusCode: {
+						isPollingEnabled:    true,
+						pollPendingStatuses: []string{"pending"},
+						pollTargetStatuses:  []string{"deployed"},
+						pollCompletedConditions: []specPollCondition{
+							{path: []string{statusProperty.Name}, value: "deployed"},
+							{path: []string{"health", "status"}, value: "healthy"},
+						},
+					},
+				},
+			}
+			err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			Convey("Then the err returned should be nil and the response payload should be the payload returned by the API", func() {
+				So(err, ShouldBeNil)
+				So(responsePayload["health"], ShouldResemble, map[string]interface{}{"status": "healthy"})
+			})
+		})
+
 		Convey("When handlePollingIfConfigured is called with a response status code that DOES NOT any of the operation's response definitions", func() {
 			client := &clientOpenAPIStub{}
 			responseStatusCode := http.StatusAccepted
@@ -1099,7 +2258,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					statusProperty.Name: statusProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, &specResponse{})
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the new status should match the one returned by the API and the remote data should be the payload returned by the API and the err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -1113,7 +2272,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			client := &clientOpenAPIStub{
 				returnHTTPCode: http.StatusNotFound,
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, &specResponse{})
 			_, newStatus, err := stateRefreshFunc()
 			Convey("Then the the new status should be the internal hardcoded status 'destroyed' as a response with 404 status code is not expected to have a body and err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -1126,7 +2285,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			client := &clientOpenAPIStub{
 				error: errors.New(expectedError),
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, &specResponse{})
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the remoteData should be empty, the new status should be empty and the err returned should not be the expected one", func() {
 				So(err, ShouldNotBeNil)
@@ -1146,7 +2305,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, &specResponse{})
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the remoteData should be empty, the new status should be empty and the err returned should not be the expected one", func() {
 				So(err.Error(), ShouldEqual, "error occurred while retrieving status identifier value from payload for resource 'resourceName' (id): could not find any status property. Please make sure the resource schema definition has either one property named 'status' or one property is marked with IsStatusIdentifier set to true")
@@ -1165,7 +2324,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, &specResponse{})
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the remoteData should be empty, the new status should be empty and the err returned should not be the expected one", func() {
 				So(err.Error(), ShouldEqual, "error occurred while retrieving status identifier value from payload for resource 'resourceName' (id): payload does not match resouce schema, could not find the status field: [status]")
@@ -1567,7 +2726,7 @@ func TestCheckImmutableFields(t *testing.T) {
 				error: errors.New("some error"),
 			},
 			expectedResult: nil,
-			expectedError:  errors.New("some error"),
+			expectedError:  errors.New("[resource='resourceName'] GET /v1/resource/ failed: some error"),
 		},
 		{
 			name: "immutable property is updated",
@@ -1605,6 +2764,31 @@ func TestCheckImmutableFields(t *testing.T) {
 
 }
 
+func TestCheckImmutableFieldsEncryptedProperty(t *testing.T) {
+	Convey("Given a resource factory configured with a resource which schema contains an immutable property and an encrypted property", t, func() {
+		immutableProperty := &SpecSchemaDefinitionProperty{Name: "immutable_prop", Type: TypeString, Immutable: true, Default: "updatedImmutableValue"}
+		encryptedProperty := newStringSchemaDefinitionPropertyWithDefaults("secret", "", false, true, nil)
+		encryptedProperty.Encrypted = true
+		r, resourceData := testCreateResourceFactory(t, immutableProperty, encryptedProperty)
+		Convey("When checkImmutableFields is called with a client configured with a state encryption key and the immutable property was updated", func() {
+			client := &clientOpenAPIStateEncryptionStub{
+				clientOpenAPIStub:  &clientOpenAPIStub{responsePayload: map[string]interface{}{"immutable_prop": "originalImmutablePropertyValue", "secret": "secretValue"}},
+				stateEncryptionKey: testStateEncryptionKey,
+			}
+			err := r.checkImmutableFields(resourceData, client)
+			Convey("Then the error should be returned and the encrypted property rolled back to the state encrypted", func() {
+				So(err, ShouldNotBeNil)
+				So(resourceData.Get("immutable_prop"), ShouldEqual, "originalImmutablePropertyValue")
+				stateValue := resourceData.Get("secret").(string)
+				So(stateValue, ShouldStartWith, encryptedStateValuePrefix)
+				decryptedValue, err := decryptStateValue(testStateEncryptionKey, stateValue)
+				So(err, ShouldBeNil)
+				So(decryptedValue, ShouldEqual, "secretValue")
+			})
+		})
+	})
+}
+
 func getMapFromJSON(t *testing.T, input string) map[string]interface{} {
 	var m map[string]interface{}
 	err := json.Unmarshal([]byte(input), &m)
@@ -1867,6 +3051,90 @@ func TestGetPropertyPayload(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory", t, func() {
+		r := resourceFactory{}
+		property := &SpecSchemaDefinitionProperty{Name: "settings", Type: TypeString, RawJSON: true}
+		Convey("When populatePayload is called with an empty map, a string property configured with transforms and its terraform state data value", func() {
+			payload := map[string]interface{}{}
+			transformedProperty := &SpecSchemaDefinitionProperty{Name: "bucket_name", Type: TypeString, Transforms: propertyTransforms{{kind: propertyTransformTrim}, {kind: propertyTransformLowercase}}}
+			err := r.populatePayload(payload, transformedProperty, " MyBucket ")
+			Convey("Then the payload should contain the transformed value and the error should be nil", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{"bucket_name": "mybucket"})
+			})
+		})
+		Convey("When populatePayload is called with an empty map, a property exposed as raw JSON and its terraform state data value", func() {
+			payload := map[string]interface{}{}
+			err := r.populatePayload(payload, property, `{"enabled": true, "ports": [80]}`)
+			Convey("Then the payload should contain the value decoded from the JSON string and the error should be nil", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{"settings": map[string]interface{}{"enabled": true, "ports": []interface{}{float64(80)}}})
+			})
+		})
+		Convey("When populatePayload is called with an empty map, a property exposed as raw JSON and an empty string", func() {
+			payload := map[string]interface{}{}
+			err := r.populatePayload(payload, property, "")
+			Convey("Then the payload should be empty and the error should be nil", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldBeEmpty)
+			})
+		})
+		Convey("When populatePayload is called with an empty map, a property exposed as raw JSON and an invalid JSON string", func() {
+			err := r.populatePayload(map[string]interface{}{}, property, `{"enabled": }`)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a resource factory", t, func() {
+		r := resourceFactory{}
+		Convey("When populatePayload is called with an empty map, a map property with int values and its terraform state data value", func() {
+			payload := map[string]interface{}{}
+			mapProperty := &SpecSchemaDefinitionProperty{Name: "limits", Type: TypeMap, MapValuesType: TypeInt}
+			err := r.populatePayload(payload, mapProperty, map[string]interface{}{"cpu": 2})
+			Convey("Then the payload returned should contain the map and the error should be nil", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{"limits": map[string]interface{}{"cpu": 2}})
+			})
+		})
+		Convey("When populatePayload is called with an empty map, a map property with object values and its terraform state data value", func() {
+			payload := map[string]interface{}{}
+			mapProperty := &SpecSchemaDefinitionProperty{
+				Name:          "environments",
+				Type:          TypeMap,
+				MapValuesType: TypeObject,
+				SpecSchemaDefinition: &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						newIntSchemaDefinitionPropertyWithDefaults("replicas", "", true, false, nil),
+					},
+				},
+			}
+			err := r.populatePayload(payload, mapProperty, []interface{}{
+				map[string]interface{}{"key": "prod", "replicas": 3},
+				map[string]interface{}{"key": "dev", "replicas": 1},
+			})
+			Convey("Then the payload returned should contain the map of objects keyed by the map entries key and the error should be nil", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{"environments": map[string]interface{}{
+					"prod": map[string]interface{}{"replicas": 3},
+					"dev":  map[string]interface{}{"replicas": 1},
+				}})
+			})
+		})
+		Convey("When populatePayload is called with a map property with object values and a state data value containing duplicated keys", func() {
+			payload := map[string]interface{}{}
+			mapProperty := &SpecSchemaDefinitionProperty{Name: "environments", Type: TypeMap, MapValuesType: TypeObject, SpecSchemaDefinition: &SpecSchemaDefinition{}}
+			err := r.populatePayload(payload, mapProperty, []interface{}{
+				map[string]interface{}{"key": "prod"},
+				map[string]interface{}{"key": "prod"},
+			})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "property 'environments' contains more than one map entry with key 'prod'")
+			})
+		})
+	})
+
 	Convey("Given a resource factory initialized with a schema definition containing an int property", t, func() {
 		// Use case - int property (terraform configuration pseudo representation below):
 		// int_property = 1234
@@ -2235,6 +3503,85 @@ func TestGetStatusValueFromPayload(t *testing.T) {
 
 }
 
+func TestGetPollStatusValue(t *testing.T) {
+	r, _ := testCreateResourceFactoryWithID(t, idProperty, stringProperty, statusProperty)
+	conditions := []specPollCondition{
+		{path: []string{"metadata", "state", "phase"}, value: "ready"},
+		{path: []string{"health", "healthy"}, value: "true"},
+	}
+	testCases := []struct {
+		name           string
+		response       *specResponse
+		payload        map[string]interface{}
+		expectedStatus string
+		expectedError  string
+	}{
+		{
+			name:           "response not configured with status path nor conditions",
+			response:       &specResponse{},
+			payload:        map[string]interface{}{statusProperty.Name: "deployed"},
+			expectedStatus: "deployed",
+		},
+		{
+			name:           "response configured with a nested status path",
+			response:       &specResponse{pollStatusPath: []string{"metadata", "state", "phase"}},
+			payload:        map[string]interface{}{statusProperty.Name: "ignored", "metadata": map[string]interface{}{"state": map[string]interface{}{"phase": "provisioning"}}},
+			expectedStatus: "provisioning",
+		},
+		{
+			name:          "response configured with a nested status path missing in the payload",
+			response:      &specResponse{pollStatusPath: []string{"metadata", "state", "phase"}},
+			payload:       map[string]interface{}{"metadata": map[string]interface{}{}},
+			expectedError: "payload does not contain the status field: metadata.state.phase",
+		},
+		{
+			name:          "response configured with a nested status path which value is not a string",
+			response:      &specResponse{pollStatusPath: []string{"metadata", "state"}},
+			payload:       map[string]interface{}{"metadata": map[string]interface{}{"state": map[string]interface{}{"phase": "provisioning"}}},
+			expectedError: "status property value 'metadata.state' does not have a supported type [string]",
+		},
+		{
+			name:           "response configured with completed conditions that are all met",
+			response:       &specResponse{pollCompletedConditions: conditions},
+			payload:        map[string]interface{}{"metadata": map[string]interface{}{"state": map[string]interface{}{"phase": "ready"}}, "health": map[string]interface{}{"healthy": true}},
+			expectedStatus: pollConditionsMetStatus,
+		},
+		{
+			name:           "response configured with completed conditions that are partially met and no pending statuses",
+			response:       &specResponse{pollCompletedConditions: conditions},
+			payload:        map[string]interface{}{"metadata": map[string]interface{}{"state": map[string]interface{}{"phase": "ready"}}, "health": map[string]interface{}{"healthy": false}},
+			expectedStatus: pollConditionsPendingStatus,
+		},
+		{
+			name:           "response configured with completed conditions not met and the status being a completed status",
+			response:       &specResponse{pollCompletedConditions: conditions, pollStatusPath: []string{"metadata", "state", "phase"}, pollPendingStatuses: []string{"provisioning"}, pollTargetStatuses: []string{"ready"}},
+			payload:        map[string]interface{}{"metadata": map[string]interface{}{"state": map[string]interface{}{"phase": "ready"}}},
+			expectedStatus: pollConditionsPendingStatus,
+		},
+		{
+			name:           "response configured with completed conditions not met and the status being a pending status",
+			response:       &specResponse{pollCompletedConditions: conditions, pollStatusPath: []string{"metadata", "state", "phase"}, pollPendingStatuses: []string{"provisioning"}},
+			payload:        map[string]interface{}{"metadata": map[string]interface{}{"state": map[string]interface{}{"phase": "provisioning"}}},
+			expectedStatus: pollConditionsPendingStatus,
+		},
+		{
+			name:           "response configured with completed conditions not met and the status not being a pending status",
+			response:       &specResponse{pollCompletedConditions: conditions, pollStatusPath: []string{"metadata", "state", "phase"}, pollPendingStatuses: []string{"provisioning"}},
+			payload:        map[string]interface{}{"metadata": map[string]interface{}{"state": map[string]interface{}{"phase": "failed"}}},
+			expectedStatus: "failed",
+		},
+	}
+	for _, tc := range testCases {
+		status, err := r.getPollStatusValue(tc.payload, tc.response)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedStatus, status, tc.name)
+	}
+}
+
 func TestGetResourceDataOKExists(t *testing.T) {
 	Convey("Given a resource factory initialized with a spec resource with some schema definition and resource data", t, func() {
 		r, resourceData := testCreateResourceFactory(t, stringProperty, stringWithPreferredNameProperty)
@@ -2303,3 +3650,54 @@ func testCreateSubResourceFactory(t *testing.T, path string, parentResourceNames
 	specResource.fullParentResourceName = fullParentResourceName
 	return newResourceFactory(specResource), resourceData
 }
+
+func TestMergeComputedObjectValues(t *testing.T) {
+	Convey("Given an object schema definition containing user set, optional computed, readOnly and nested object properties", t, func() {
+		optionalComputedProperty := newStringSchemaDefinitionPropertyWithDefaults("optional_computed", "", false, false, nil)
+		optionalComputedProperty.Computed = true
+		nestedOptionalComputedProperty := newIntSchemaDefinitionPropertyWithDefaults("nested_optional_computed", "", false, false, nil)
+		nestedOptionalComputedProperty.Computed = true
+		schemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("user_set", "", false, false, nil),
+				optionalComputedProperty,
+				newStringSchemaDefinitionPropertyWithDefaults("read_only", "", false, true, nil),
+				newObjectSchemaDefinitionPropertyWithDefaults("nested_object", "", false, false, false, nil, &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{nestedOptionalComputedProperty},
+				}),
+			},
+		}
+		Convey("When mergeComputedObjectValues is called with a desired object missing the computed values present in the current object", func() {
+			desiredValue := map[string]interface{}{
+				"user_set":      "newValue",
+				"nested_object": map[string]interface{}{},
+			}
+			currentValue := map[string]interface{}{
+				"user_set":          "oldValue",
+				"optional_computed": "computedValue",
+				"read_only":         "readOnlyValue",
+				"nested_object":     map[string]interface{}{"nested_optional_computed": 5},
+			}
+			mergeComputedObjectValues(schemaDefinition, desiredValue, currentValue)
+			Convey("Then the desired object should contain the current optional computed values and the rest should not be changed", func() {
+				So(desiredValue, ShouldResemble, map[string]interface{}{
+					"user_set":          "newValue",
+					"optional_computed": "computedValue",
+					"nested_object":     map[string]interface{}{"nested_optional_computed": 5},
+				})
+			})
+		})
+		Convey("When mergeComputedObjectValues is called with a desired object that already contains the optional computed values", func() {
+			desiredValue := map[string]interface{}{
+				"optional_computed": "newComputedValue",
+			}
+			currentValue := map[string]interface{}{
+				"optional_computed": "computedValue",
+			}
+			mergeComputedObjectValues(schemaDefinition, desiredValue, currentValue)
+			Convey("Then the desired values should be kept", func() {
+				So(desiredValue, ShouldResemble, map[string]interface{}{"optional_computed": "newComputedValue"})
+			})
+		})
+	})
+}
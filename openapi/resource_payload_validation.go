@@ -0,0 +1,156 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// unknownVariableValue is the value terraform uses to represent the values that are not known at plan time
+const unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// PayloadValidator validates the request payloads of the resources against the JSON Schema of the resource definitions
+// in the OpenAPI document. This enables plugging in external JSON Schema validation libraries (e,g:
+// github.com/xeipuuv/gojsonschema) to catch the constraint violations that the terraform schema can not express (e,g:
+// patterns, ranges, string lengths) before the requests are sent to the API.
+type PayloadValidator interface {
+	// Validate returns an error describing the constraint violations found if the payload does not match the schema.
+	// Both the JSON Schema and the payload are JSON documents.
+	Validate(jsonSchema, payload []byte) error
+}
+
+// jsonSchemaResource is implemented by the SpecResources that can return the JSON Schema of the resource definition the
+// request payloads are validated against
+type jsonSchemaResource interface {
+	getRequestJSONSchema() ([]byte, error)
+}
+
+// validateRequestPayload returns an error if the given request payload does not match the JSON Schema of the resource
+// according to the payload validator configured in the provider. The payload is not validated if the provider is not
+// configured with a payload validator. The given unknown properties are not required to be present in the payload (e,g:
+// the properties which values are not known at plan time).
+func (r resourceFactory) validateRequestPayload(requestPayload map[string]interface{}, unknownProperties ...string) error {
+	if r.payloadValidator == nil {
+		return nil
+	}
+	resource, ok := r.openAPIResource.(jsonSchemaResource)
+	if !ok {
+		return nil
+	}
+	resourceName := r.openAPIResource.GetResourceName()
+	jsonSchema, err := resource.getRequestJSONSchema()
+	if err != nil {
+		return fmt.Errorf("[%s='%s'] failed to get the JSON schema the request payload is validated against: %s", resourceKind, resourceName, err)
+	}
+	if len(unknownProperties) > 0 {
+		if jsonSchema, err = removeRequiredProperties(jsonSchema, unknownProperties); err != nil {
+			return fmt.Errorf("[%s='%s'] failed to get the JSON schema the request payload is validated against: %s", resourceKind, resourceName, err)
+		}
+	}
+	payload, err := json.Marshal(requestPayload)
+	if err != nil {
+		return fmt.Errorf("[%s='%s'] failed to marshal the request payload: %s", resourceKind, resourceName, err)
+	}
+	if err := r.payloadValidator.Validate(jsonSchema, payload); err != nil {
+		return fmt.Errorf("[%s='%s'] the request payload does not match the resource definition in the OpenAPI document: %s", resourceKind, resourceName, err)
+	}
+	return nil
+}
+
+// validatePlannedPayload validates at plan time the request payload built out of the planned values of the resource, so
+// the constraint violations are surfaced by terraform plan instead of when the changes are applied. The resources that
+// are not being created nor updated are not validated. The properties which values are not known at plan time (e,g:
+// referencing an attribute of a resource that is not created yet) are left out of the payload; they are validated when
+// the changes are applied
+func (r resourceFactory) validatePlannedPayload(diff *schema.ResourceDiff) error {
+	if r.payloadValidator == nil {
+		return nil
+	}
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	changed := diff.Id() == ""
+	requestPayload := map[string]interface{}{}
+	var unknownProperties []string
+	for _, property := range resourceSchema.Properties {
+		if property.isReadOnly() || property.IsParentProperty {
+			continue
+		}
+		propertyName := property.GetTerraformCompliantPropertyName()
+		if diff.HasChange(propertyName) {
+			changed = true
+		}
+		value, ok := diff.GetOk(propertyName)
+		if !ok && property.IsRequired() {
+			value, ok = diff.Get(propertyName), true
+		}
+		if !isPlannedValueKnown(diff, propertyName, value) {
+			log.Printf("[INFO] [%s='%s'] the value of the property '%s' is not known at plan time, it will be validated when the changes are applied", resourceKind, r.openAPIResource.GetResourceName(), property.Name)
+			unknownProperties = append(unknownProperties, property.Name)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := r.populatePayload(requestPayload, property, value); err != nil {
+			return err
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.validateRequestPayload(requestPayload, unknownProperties...)
+}
+
+// isPlannedValueKnown returns true if the planned value of the given key, including the values nested in lists and
+// objects, is known at plan time
+func isPlannedValueKnown(diff *schema.ResourceDiff, key string, value interface{}) bool {
+	if !diff.NewValueKnown(key) {
+		return false
+	}
+	switch v := value.(type) {
+	case string:
+		return v != unknownVariableValue
+	case []interface{}:
+		for idx, item := range v {
+			if !isPlannedValueKnown(diff, fmt.Sprintf("%s.%d", key, idx), item) {
+				return false
+			}
+		}
+	case map[string]interface{}:
+		for name, item := range v {
+			if !isPlannedValueKnown(diff, fmt.Sprintf("%s.%s", key, name), item) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// removeRequiredProperties returns the given JSON schema without the given properties in the list of required properties
+func removeRequiredProperties(jsonSchema []byte, properties []string) ([]byte, error) {
+	s := map[string]interface{}{}
+	if err := json.Unmarshal(jsonSchema, &s); err != nil {
+		return nil, err
+	}
+	removed := map[string]bool{}
+	for _, property := range properties {
+		removed[property] = true
+	}
+	required, _ := s["required"].([]interface{})
+	var filteredRequired []interface{}
+	for _, name := range required {
+		if !removed[fmt.Sprint(name)] {
+			filteredRequired = append(filteredRequired, name)
+		}
+	}
+	if len(filteredRequired) == 0 {
+		delete(s, "required")
+	} else {
+		s["required"] = filteredRequired
+	}
+	return json.Marshal(s)
+}
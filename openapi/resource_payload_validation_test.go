@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payloadValidatorStub struct {
+	jsonSchemaReceived []byte
+	payloadReceived    []byte
+	err                error
+}
+
+func (p *payloadValidatorStub) Validate(jsonSchema, payload []byte) error {
+	p.jsonSchemaReceived = jsonSchema
+	p.payloadReceived = payload
+	return p.err
+}
+
+func TestValidateRequestPayload(t *testing.T) {
+	schemaDefinition := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Required: []string{"id", "label", "config"},
+			Properties: map[string]spec.Schema{
+				"id":     {SchemaProps: spec.SchemaProps{Type: []string{"string"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+				"label":  {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Pattern: "^[a-z]+$"}},
+				"config": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Config")}},
+			},
+		},
+	}
+	schemaDefinitions := map[string]spec.Schema{
+		"Config": {SchemaProps: spec.SchemaProps{Type: []string{"object"}, Properties: map[string]spec.Schema{"port": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}}}}},
+	}
+	openAPIResource, err := newSpecV2Resource("/v1/cdns", schemaDefinition, spec.PathItem{}, spec.PathItem{}, schemaDefinitions, map[string]spec.PathItem{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name               string
+		payloadValidator   *payloadValidatorStub
+		expectedJSONSchema string
+		expectedError      string
+	}{
+		{
+			name:               "payload matching the JSON schema",
+			payloadValidator:   &payloadValidatorStub{},
+			expectedJSONSchema: `{"required":["label","config"],"properties":{"config":{"$ref":"#/definitions/Config"},"id":{"type":"string","readOnly":true},"label":{"type":"string","pattern":"^[a-z]+$"}},"definitions":{"Config":{"type":"object","properties":{"port":{"type":"integer"}}}}}`,
+		},
+		{
+			name:             "payload not matching the JSON schema",
+			payloadValidator: &payloadValidatorStub{err: errors.New("label: Does not match pattern '^[a-z]+$'")},
+			expectedError:    "[resource='cdns_v1'] the request payload does not match the resource definition in the OpenAPI document: label: Does not match pattern '^[a-z]+$'",
+		},
+	}
+	for _, tc := range testCases {
+		r := resourceFactory{openAPIResource: openAPIResource, payloadValidator: tc.payloadValidator}
+
+		err := r.validateRequestPayload(map[string]interface{}{"label": "My-Label", "config": map[string]interface{}{"port": 80}})
+
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.JSONEq(t, tc.expectedJSONSchema, string(tc.payloadValidator.jsonSchemaReceived), tc.name)
+		assert.JSONEq(t, `{"label":"My-Label","config":{"port":80}}`, string(tc.payloadValidator.payloadReceived), tc.name)
+	}
+}
+
+func TestValidateRequestPayload_NoPayloadValidator(t *testing.T) {
+	r := resourceFactory{openAPIResource: &SpecV2Resource{}}
+	assert.NoError(t, r.validateRequestPayload(map[string]interface{}{"label": "My-Label"}))
+}
+
+func TestValidatePlannedPayload(t *testing.T) {
+	schemaDefinition := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Required: []string{"label", "origin"},
+			Properties: map[string]spec.Schema{
+				"id":     {SchemaProps: spec.SchemaProps{Type: []string{"string"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+				"label":  {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Pattern: "^[a-z]+$"}},
+				"origin": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		},
+	}
+	openAPIResource, err := newSpecV2Resource("/v1/cdns", schemaDefinition, spec.PathItem{}, spec.PathItem{}, map[string]spec.Schema{}, map[string]spec.PathItem{})
+	require.NoError(t, err)
+	testCases := []struct {
+		name             string
+		config           map[string]interface{}
+		state            *terraform.InstanceState
+		payloadValidator *payloadValidatorStub
+		expectedPayload  string
+		expectedRequired string
+		expectedError    string
+	}{
+		{
+			name:             "planned payload not matching the JSON schema",
+			config:           map[string]interface{}{"label": "My-Label", "origin": "origin.com"},
+			payloadValidator: &payloadValidatorStub{err: errors.New("label: Does not match pattern '^[a-z]+$'")},
+			expectedPayload:  `{"label":"My-Label","origin":"origin.com"}`,
+			expectedError:    "[resource='cdns_v1'] the request payload does not match the resource definition in the OpenAPI document: label: Does not match pattern '^[a-z]+$'",
+		},
+		{
+			name:             "planned payload with values not known at plan time",
+			config:           map[string]interface{}{"label": "label", "origin": testUnknownVariableValue},
+			payloadValidator: &payloadValidatorStub{},
+			expectedPayload:  `{"label":"label"}`,
+			expectedRequired: `"required":["label"]`,
+		},
+		{
+			name:             "resource without changes",
+			config:           map[string]interface{}{"label": "My-Label", "origin": "origin.com"},
+			state:            &terraform.InstanceState{ID: "id", Attributes: map[string]string{"id": "id", "label": "My-Label", "origin": "origin.com"}},
+			payloadValidator: &payloadValidatorStub{err: errors.New("label: Does not match pattern '^[a-z]+$'")},
+		},
+	}
+	for _, tc := range testCases {
+		r := newResourceFactory(openAPIResource)
+		r.payloadValidator = tc.payloadValidator
+		resource, err := r.createTerraformResource()
+		require.NoError(t, err, tc.name)
+		require.NotNil(t, resource.CustomizeDiff, tc.name)
+
+		_, err = resource.Diff(tc.state, terraform.NewResourceConfigRaw(tc.config), &clientOpenAPIStub{})
+
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+		if tc.expectedPayload == "" {
+			assert.Nil(t, tc.payloadValidator.payloadReceived, tc.name)
+			continue
+		}
+		assert.JSONEq(t, tc.expectedPayload, string(tc.payloadValidator.payloadReceived), tc.name)
+		if tc.expectedRequired != "" {
+			assert.Contains(t, string(tc.payloadValidator.jsonSchemaReceived), tc.expectedRequired, tc.name)
+		}
+	}
+}
+
+func TestValidatePlannedPayload_UnknownRequiredProperties(t *testing.T) {
+	jsonSchema, err := removeRequiredProperties([]byte(`{"required":["label","origin"],"properties":{"label":{"type":"string"}}}`), []string{"origin"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"required":["label"],"properties":{"label":{"type":"string"}}}`, string(jsonSchema))
+
+	jsonSchema, err = removeRequiredProperties([]byte(`{"required":["origin"]}`), []string{"origin"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(jsonSchema))
+}
@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// createStateUpgraders returns the list of state upgraders required to upgrade states stored with any previous schema
+// version up to the current schemaVersion. Each upgrader executes first the custom state upgrade function registered for
+// the version (if any) and then upgrades the state based on the current resource schema definition.
+func (r resourceFactory) createStateUpgraders(schemaVersion int, s map[string]*schema.Schema) []schema.StateUpgrader {
+	if schemaVersion == 0 {
+		return nil
+	}
+	impliedType := (&schema.Resource{Schema: s}).CoreConfigSchema().ImpliedType()
+	var stateUpgraders []schema.StateUpgrader
+	for version := 0; version < schemaVersion; version++ {
+		stateUpgraders = append(stateUpgraders, schema.StateUpgrader{
+			Version: version,
+			Type:    impliedType,
+			Upgrade: r.stateUpgradeFunc(version),
+		})
+	}
+	return stateUpgraders
+}
+
+func (r resourceFactory) stateUpgradeFunc(version int) schema.StateUpgradeFunc {
+	return func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		log.Printf("[INFO] upgrading state of resource '%s' from schema version %d", r.openAPIResource.GetResourceName(), version)
+		if stateUpgradeFunc, exists := r.stateUpgradeFuncs[version]; exists {
+			var err error
+			if rawState, err = stateUpgradeFunc(rawState, meta); err != nil {
+				return nil, fmt.Errorf("[resource='%s'] failed to upgrade state from schema version %d: %s", r.openAPIResource.GetResourceName(), version, err)
+			}
+		}
+		resourceSchema, err := r.openAPIResource.GetResourceSchema()
+		if err != nil {
+			return nil, err
+		}
+		return upgradeStateToSpecSchema(resourceSchema, rawState), nil
+	}
+}
+
+// upgradeStateToSpecSchema moves the values of the properties that have been renamed (x-terraform-field-renamed-from) to
+// their current names and converts primitive values to the current property type. Values that can not be converted are
+// removed from the state so they get populated again from the API response in the next refresh.
+func upgradeStateToSpecSchema(resourceSchema *SpecSchemaDefinition, rawState map[string]interface{}) map[string]interface{} {
+	if rawState == nil {
+		return rawState
+	}
+	for _, property := range resourceSchema.Properties {
+		propertyName := property.GetTerraformCompliantPropertyName()
+		if property.RenamedFrom != "" {
+			if value, exists := rawState[property.RenamedFrom]; exists {
+				if _, alreadyThere := rawState[propertyName]; !alreadyThere || rawState[propertyName] == nil {
+					rawState[propertyName] = value
+				}
+				delete(rawState, property.RenamedFrom)
+			}
+		}
+		value, exists := rawState[propertyName]
+		if !exists || value == nil {
+			continue
+		}
+		upgradedValue, err := convertPrimitiveValueToPropertyType(property.Type, value)
+		if err != nil {
+			log.Printf("[WARN] removing property '%s' from the state while upgrading the state: %s", propertyName, err)
+			delete(rawState, propertyName)
+			continue
+		}
+		rawState[propertyName] = upgradedValue
+	}
+	return rawState
+}
@@ -0,0 +1,125 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateStateUpgraders(t *testing.T) {
+	Convey("Given a resource factory configured with a resource with schema version 2", t, func() {
+		r := newResourceFactory(newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+			},
+		}))
+		s := map[string]*schema.Schema{"label": {Type: schema.TypeString, Required: true}}
+		Convey("When createStateUpgraders is called with schema version 0", func() {
+			stateUpgraders := r.createStateUpgraders(0, s)
+			Convey("Then no state upgraders should be returned", func() {
+				So(stateUpgraders, ShouldBeEmpty)
+			})
+		})
+		Convey("When createStateUpgraders is called with schema version 2", func() {
+			stateUpgraders := r.createStateUpgraders(2, s)
+			Convey("Then the state upgraders returned should cover the previous versions", func() {
+				So(len(stateUpgraders), ShouldEqual, 2)
+				So(stateUpgraders[0].Version, ShouldEqual, 0)
+				So(stateUpgraders[1].Version, ShouldEqual, 1)
+				So(stateUpgraders[1].Type.IsObjectType(), ShouldBeTrue)
+			})
+			Convey("And the resource containing the state upgraders should be valid", func() {
+				resource := &schema.Resource{Schema: s, SchemaVersion: 2, StateUpgraders: stateUpgraders}
+				So(resource.InternalValidate(nil, true), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestStateUpgradeFunc(t *testing.T) {
+	Convey("Given a resource factory configured with a resource containing a renamed property and a custom state upgrade function for version 0", t, func() {
+		renamedProperty := newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil)
+		renamedProperty.RenamedFrom = "name"
+		r := newResourceFactory(newSpecStubResource("cdns_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				renamedProperty,
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, nil),
+			},
+		}))
+		r.stateUpgradeFuncs = map[int]schema.StateUpgradeFunc{
+			0: func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+				rawState["port"] = rawState["listen_port"]
+				delete(rawState, "listen_port")
+				return rawState, nil
+			},
+		}
+		Convey("When the state upgrade function for version 0 is executed", func() {
+			state, err := r.stateUpgradeFunc(0)(map[string]interface{}{"name": "label", "listen_port": "8080"}, nil)
+			Convey("Then the state should be upgraded by the custom function and the current resource schema", func() {
+				So(err, ShouldBeNil)
+				So(state, ShouldResemble, map[string]interface{}{"label": "label", "port": 8080})
+			})
+		})
+		Convey("When the state upgrade function for version 1 is executed (no custom function registered)", func() {
+			state, err := r.stateUpgradeFunc(1)(map[string]interface{}{"name": "label", "port": 8080.0}, nil)
+			Convey("Then the state should be upgraded by the current resource schema", func() {
+				So(err, ShouldBeNil)
+				So(state, ShouldResemble, map[string]interface{}{"label": "label", "port": 8080})
+			})
+		})
+		Convey("When the custom state upgrade function returns an error", func() {
+			r.stateUpgradeFuncs[0] = func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+				return nil, errors.New("some error")
+			}
+			_, err := r.stateUpgradeFunc(0)(map[string]interface{}{}, nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='cdns_v1'] failed to upgrade state from schema version 0: some error")
+			})
+		})
+	})
+}
+
+func TestUpgradeStateToSpecSchema(t *testing.T) {
+	Convey("Given a resource schema with string, int, float and bool properties", t, func() {
+		resourceSchema := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, nil),
+				&SpecSchemaDefinitionProperty{Name: "weight", Type: TypeFloat},
+				newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", false, false, nil),
+				&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString},
+			},
+		}
+		Convey("When upgradeStateToSpecSchema is called with a state where the primitive values were stored with a different type", func() {
+			state := upgradeStateToSpecSchema(resourceSchema, map[string]interface{}{"label": 1.0, "port": "80", "weight": "1.5", "enabled": "true", "tags": []interface{}{"tag"}})
+			Convey("Then the values should be converted to the current property types", func() {
+				So(state, ShouldResemble, map[string]interface{}{"label": "1", "port": 80, "weight": 1.5, "enabled": true, "tags": []interface{}{"tag"}})
+			})
+		})
+		Convey("When upgradeStateToSpecSchema is called with a state containing values that can not be converted", func() {
+			state := upgradeStateToSpecSchema(resourceSchema, map[string]interface{}{"label": "label", "port": "eighty"})
+			Convey("Then the values that can not be converted should be removed from the state", func() {
+				So(state, ShouldResemble, map[string]interface{}{"label": "label"})
+			})
+		})
+	})
+	Convey("Given a resource schema with a property renamed from a previous name", t, func() {
+		property := newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil)
+		property.RenamedFrom = "name"
+		resourceSchema := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{property}}
+		Convey("When upgradeStateToSpecSchema is called with a state containing the previous name", func() {
+			state := upgradeStateToSpecSchema(resourceSchema, map[string]interface{}{"name": "label"})
+			Convey("Then the value should be moved to the current property name", func() {
+				So(state, ShouldResemble, map[string]interface{}{"label": "label"})
+			})
+		})
+		Convey("When upgradeStateToSpecSchema is called with a state containing already the current name", func() {
+			state := upgradeStateToSpecSchema(resourceSchema, map[string]interface{}{"name": "old", "label": "current"})
+			Convey("Then the current value should be kept", func() {
+				So(state, ShouldResemble, map[string]interface{}{"label": "current"})
+			})
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/dikhan/terraform-provider-openapi/pkg/terraformcodegenerator/openapiterraformcodegenerator"
+)
+
+func main() {
+	var providerName, modulePath, openAPIDocURL, outputDir string
+	flag.StringVar(&providerName, "provider-name", "openapi", "name of the terraform provider to generate")
+	flag.StringVar(&modulePath, "module", "", "go module path of the generated provider project (defaults to terraform-provider-{provider-name})")
+	flag.StringVar(&openAPIDocURL, "spec", "https://raw.githubusercontent.com/dikhan/terraform-provider-openapi/master/examples/swaggercodegen/api/resources/swagger.yaml", "URL or local path of the OpenAPI document")
+	flag.StringVar(&outputDir, "output", "./generated", "folder where the provider project will be generated")
+	flag.Parse()
+
+	terraformProviderCodeGenerator, err := openapiterraformcodegenerator.NewTerraformProviderCodeGenerator(providerName, modulePath, openAPIDocURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c, err := terraformProviderCodeGenerator.GenerateCode()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = c.Write(outputDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("[INFO] Terraform provider '%s' source code generated at: %s", providerName, outputDir)
+}
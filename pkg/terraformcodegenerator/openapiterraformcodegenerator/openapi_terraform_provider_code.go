@@ -0,0 +1,157 @@
+package openapiterraformcodegenerator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// specSnapshotFileName defines the name of the file where the OpenAPI document used to generate the provider is stored
+const specSnapshotFileName = "openapi.json"
+
+// Resource defines the attributes needed to generate the source code of a terraform resource
+type Resource struct {
+	Name string
+	// Path contains the relative path to the resource collection, e,g: /v1/cdns or /v1/cdns/{id}/firewalls for sub-resources
+	Path string
+	// BaseURL contains the base URL of the resources served from a different host than the rest of the API (configured
+	// with the x-terraform-resource-host extension); empty if the resource is served from the provider base URL
+	BaseURL string
+	// Identifier contains the payload name of the property used as the resource identifier
+	Identifier       string
+	ParentProperties []string
+	Updatable        bool
+	Deletable        bool
+	Properties       []Property
+}
+
+// FuncName returns the name of the go function that returns the terraform resource
+func (r Resource) FuncName() string {
+	return "resource" + toCamelCase(r.Name)
+}
+
+// DefinitionName returns the name of the go variable holding the static resource definition
+func (r Resource) DefinitionName() string {
+	return "resource" + toCamelCase(r.Name) + "Definition"
+}
+
+// FileName returns the name of the go file where the resource source code is generated
+func (r Resource) FileName() string {
+	return fmt.Sprintf("resource_%s.go", r.Name)
+}
+
+// FieldNames returns the mapping between the terraform compliant names and the payload names of all the resource properties
+func (r Resource) FieldNames() map[string]string {
+	fieldNames := map[string]string{}
+	for _, p := range r.Properties {
+		for k, v := range p.FieldNames() {
+			fieldNames[k] = v
+		}
+	}
+	return fieldNames
+}
+
+// SortedFieldNames returns the keys of FieldNames sorted alphabetically so the generated code is deterministic
+func (r Resource) SortedFieldNames() []string {
+	var names []string
+	for k := range r.FieldNames() {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TerraformProviderCode defines the attributes needed to generate the source code of a standalone terraform provider
+type TerraformProviderCode struct {
+	ProviderName string
+	ModulePath   string
+	// BaseURL contains the default API base URL; if empty the generated provider will require it to be configured
+	BaseURL          string
+	ConfigProperties []ConfigProperty
+	Resources        []Resource
+	// SpecSnapshot contains the OpenAPI document the provider was generated from
+	SpecSnapshot []byte
+}
+
+// BaseURLEnvVariable returns the name of the environment variable that can be used to override the API base URL
+func (t TerraformProviderCode) BaseURLEnvVariable() string {
+	return fmt.Sprintf("%s_BASE_URL", strings.ToUpper(t.ProviderName))
+}
+
+// Files returns the generated provider project files keyed by their relative path. The go source code files are
+// gofmt formatted.
+func (t TerraformProviderCode) Files() (map[string][]byte, error) {
+	files := map[string][]byte{}
+	if err := t.renderFile(files, "go.mod", "go.mod", goModTmpl, t, false); err != nil {
+		return nil, err
+	}
+	if err := t.renderFile(files, "main.go", "main.go", mainTmpl, t, true); err != nil {
+		return nil, err
+	}
+	if err := t.renderFile(files, "provider.go", "provider.go", providerTmpl, t, true); err != nil {
+		return nil, err
+	}
+	if err := t.renderFile(files, "client.go", "client.go", clientTmpl, t, true); err != nil {
+		return nil, err
+	}
+	if err := t.renderFile(files, "resources.go", "resources.go", resourceDefinitionTmpl, t, true); err != nil {
+		return nil, err
+	}
+	for _, r := range t.Resources {
+		if err := t.renderFile(files, r.FileName(), r.Name, resourceTmpl, r, true); err != nil {
+			return nil, err
+		}
+	}
+	files[specSnapshotFileName] = t.SpecSnapshot
+	return files, nil
+}
+
+// Write writes the generated provider project files into the outputDir folder, creating it if needed
+func (t TerraformProviderCode) Write(outputDir string) error {
+	files, err := t.Files()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(outputDir, name), content, 0644); err != nil { // #nosec G306
+			return err
+		}
+	}
+	return nil
+}
+
+func (t TerraformProviderCode) renderFile(files map[string][]byte, fileName, templateName, templateContent string, data interface{}, goSource bool) error {
+	var b bytes.Buffer
+	if err := render(&b, templateName, templateContent, data); err != nil {
+		return err
+	}
+	content := b.Bytes()
+	if goSource {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("failed to format generated file '%s': %s", fileName, err)
+		}
+		content = formatted
+	}
+	files[fileName] = content
+	return nil
+}
+
+func toCamelCase(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
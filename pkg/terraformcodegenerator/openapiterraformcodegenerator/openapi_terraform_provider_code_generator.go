@@ -0,0 +1,257 @@
+package openapiterraformcodegenerator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+)
+
+const extTfAuthenticationSchemeBearer = "x-terraform-authentication-scheme-bearer"
+
+// TerraformProviderCodeGenerator defines the struct that holds the configuration needed to be able to generate the
+// source code of a standalone terraform provider
+type TerraformProviderCodeGenerator struct {
+	// ProviderName defines the provider name
+	ProviderName string
+	// ModulePath defines the go module path used in the generated go.mod file (e,g: github.com/company/terraform-provider-name)
+	ModulePath string
+	// SpecAnalyser analyses the swagger doc and provides helper methods to retrieve all the end points that can
+	// be used as terraform resources.
+	SpecAnalyser openapi.SpecAnalyser
+	// Document contains the OpenAPI document the code is generated from. The document is vendored in the generated
+	// project as a snapshot so reviewers can trace the generated code back to the spec
+	Document *loads.Document
+}
+
+// NewTerraformProviderCodeGenerator returns a TerraformProviderCodeGenerator configured with the OpenAPI document found
+// at the given openAPIDocURL
+func NewTerraformProviderCodeGenerator(providerName, modulePath, openAPIDocURL string) (TerraformProviderCodeGenerator, error) {
+	analyser, err := openapi.CreateSpecAnalyser("v2", openAPIDocURL)
+	if err != nil {
+		return TerraformProviderCodeGenerator{}, err
+	}
+	document, err := loads.JSONSpec(openAPIDocURL)
+	if err != nil {
+		return TerraformProviderCodeGenerator{}, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocURL, err)
+	}
+	return TerraformProviderCodeGenerator{ProviderName: providerName, ModulePath: modulePath, SpecAnalyser: analyser, Document: document}, nil
+}
+
+// GenerateCode creates a TerraformProviderCode object populated based on the OpenAPI document. The returned object
+// exposes methods to write the provider project to disk
+func (t TerraformProviderCodeGenerator) GenerateCode() (TerraformProviderCode, error) {
+	if t.ProviderName == "" {
+		return TerraformProviderCode{}, fmt.Errorf("provider name must not be empty")
+	}
+	if t.Document == nil {
+		return TerraformProviderCode{}, fmt.Errorf("missing OpenAPI document")
+	}
+	modulePath := t.ModulePath
+	if modulePath == "" {
+		modulePath = fmt.Sprintf("terraform-provider-%s", t.ProviderName)
+	}
+
+	configProperties, err := t.getConfigProperties()
+	if err != nil {
+		return TerraformProviderCode{}, err
+	}
+
+	r, err := t.SpecAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return TerraformProviderCode{}, err
+	}
+	resources, err := t.getProviderResources(r)
+	if err != nil {
+		return TerraformProviderCode{}, err
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].Name < resources[j].Name
+	})
+
+	specSnapshot, err := json.MarshalIndent(t.Document.Spec(), "", "  ")
+	if err != nil {
+		return TerraformProviderCode{}, fmt.Errorf("failed to create the OpenAPI document snapshot: %s", err)
+	}
+
+	return TerraformProviderCode{
+		ProviderName:     t.ProviderName,
+		ModulePath:       modulePath,
+		BaseURL:          t.getBaseURL(),
+		ConfigProperties: configProperties,
+		Resources:        resources,
+		SpecSnapshot:     specSnapshot,
+	}, nil
+}
+
+// getBaseURL returns the base URL built out of the scheme, host and base path defined in the OpenAPI document. If the
+// document does not specify a host, an empty string is returned and the generated provider will require the base_url
+// to be configured
+func (t TerraformProviderCodeGenerator) getBaseURL() string {
+	return t.getHostBaseURL(t.Document.Spec().Host)
+}
+
+// getHostBaseURL returns the base URL built out of the scheme and base path defined in the OpenAPI document and the given
+// host. An empty string is returned if the host is empty
+func (t TerraformProviderCodeGenerator) getHostBaseURL(host string) string {
+	swagger := t.Document.Spec()
+	if host == "" {
+		return ""
+	}
+	scheme := "https"
+	if len(swagger.Schemes) > 0 {
+		scheme = swagger.Schemes[0]
+		for _, s := range swagger.Schemes {
+			if s == "https" {
+				scheme = s
+				break
+			}
+		}
+	}
+	basePath := strings.TrimSuffix(swagger.BasePath, "/")
+	return fmt.Sprintf("%s://%s%s", scheme, host, basePath)
+}
+
+func (t TerraformProviderCodeGenerator) getConfigProperties() ([]ConfigProperty, error) {
+	swagger := t.Document.Spec()
+	globalSecuritySchemes := map[string]bool{}
+	for _, securityScheme := range swagger.Security {
+		for secDefName := range securityScheme {
+			globalSecuritySchemes[secDefName] = true
+		}
+	}
+
+	var configProps []ConfigProperty
+	for secDefName, secDef := range swagger.SecurityDefinitions {
+		if secDef.Type != "apiKey" {
+			continue
+		}
+		if secDef.In != "header" && secDef.In != "query" {
+			return nil, fmt.Errorf("apiKey In value '%s' not supported, only 'header' and 'query' values are valid", secDef.In)
+		}
+		configProps = append(configProps, ConfigProperty{
+			Name:      terraformutils.ConvertToTerraformCompliantName(secDefName),
+			In:        secDef.In,
+			Key:       t.getSecurityDefinitionKey(secDef),
+			Bearer:    t.isBearerScheme(secDef) && secDef.In == "header",
+			Required:  globalSecuritySchemes[secDefName],
+			Sensitive: true,
+		})
+	}
+	for _, header := range t.SpecAnalyser.GetAllHeaderParameters() {
+		configProps = append(configProps, ConfigProperty{
+			Name:     header.GetHeaderTerraformConfigurationName(),
+			In:       "header",
+			Key:      header.Name,
+			Required: header.IsRequired,
+		})
+	}
+	sort.SliceStable(configProps, func(i, j int) bool {
+		return configProps[i].Name < configProps[j].Name
+	})
+	return configProps, nil
+}
+
+func (t TerraformProviderCodeGenerator) getSecurityDefinitionKey(secDef *spec.SecurityScheme) string {
+	if t.isBearerScheme(secDef) {
+		if secDef.In == "query" {
+			return "access_token"
+		}
+		return "Authorization"
+	}
+	return secDef.Name
+}
+
+func (t TerraformProviderCodeGenerator) isBearerScheme(secDef *spec.SecurityScheme) bool {
+	authScheme, enabled := secDef.Extensions.GetBool(extTfAuthenticationSchemeBearer)
+	return authScheme && enabled
+}
+
+func (t TerraformProviderCodeGenerator) getProviderResources(resources []openapi.SpecResource) ([]Resource, error) {
+	r := []Resource{}
+	for _, resource := range resources {
+		if resource.ShouldIgnoreResource() {
+			continue
+		}
+		v2Resource, ok := resource.(*openapi.SpecV2Resource)
+		if !ok {
+			return nil, fmt.Errorf("resource '%s' is not supported by the code generator, only OpenAPI v2 resources can be generated", resource.GetResourceName())
+		}
+		resourceSchema, err := resource.GetResourceSchema()
+		if err != nil {
+			return nil, err
+		}
+		host, err := v2Resource.GetHost()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the x-terraform-resource-host of resource '%s': %s", resource.GetResourceName(), err)
+		}
+
+		var parentProperties []string
+		if parentInfo := resource.GetParentResourceInfo(); parentInfo != nil {
+			parentProperties = parentInfo.GetParentPropertiesNames()
+		}
+
+		identifier := "id"
+		props := []Property{}
+		for _, p := range resourceSchema.Properties {
+			if p.IsIdentifier {
+				identifier = p.Name
+			}
+			// Terraform already has a field ID reserved, hence the schema does not need to include an explicit ID property
+			if p.GetTerraformCompliantPropertyName() == "id" {
+				continue
+			}
+			props = append(props, t.resourceSchemaToProperty(*p))
+		}
+		sort.SliceStable(props, func(i, j int) bool {
+			return props[i].Name < props[j].Name
+		})
+
+		r = append(r, Resource{
+			Name:             resource.GetResourceName(),
+			Path:             v2Resource.Path,
+			BaseURL:          t.getHostBaseURL(host),
+			Identifier:       identifier,
+			ParentProperties: parentProperties,
+			Updatable:        v2Resource.InstancePathItem.Put != nil,
+			Deletable:        v2Resource.InstancePathItem.Delete != nil,
+			Properties:       props,
+		})
+	}
+	return r, nil
+}
+
+func (t TerraformProviderCodeGenerator) resourceSchemaToProperty(specSchemaDefinitionProperty openapi.SpecSchemaDefinitionProperty) Property {
+	var schema []Property
+	if specSchemaDefinitionProperty.Type == openapi.TypeObject || specSchemaDefinitionProperty.ArrayItemsType == openapi.TypeObject {
+		if specSchemaDefinitionProperty.SpecSchemaDefinition != nil {
+			for _, p := range specSchemaDefinitionProperty.SpecSchemaDefinition.Properties {
+				schema = append(schema, t.resourceSchemaToProperty(*p))
+			}
+			sort.SliceStable(schema, func(i, j int) bool {
+				return schema[i].Name < schema[j].Name
+			})
+		}
+	}
+	return Property{
+		Name:               specSchemaDefinitionProperty.GetTerraformCompliantPropertyName(),
+		PayloadName:        specSchemaDefinitionProperty.Name,
+		Type:               string(specSchemaDefinitionProperty.Type),
+		ArrayItemsType:     string(specSchemaDefinitionProperty.ArrayItemsType),
+		MapValuesType:      string(specSchemaDefinitionProperty.MapValuesType),
+		Required:           specSchemaDefinitionProperty.IsRequired(),
+		ReadOnly:           specSchemaDefinitionProperty.ReadOnly,
+		IsOptionalComputed: specSchemaDefinitionProperty.IsOptionalComputed(),
+		ForceNew:           specSchemaDefinitionProperty.ForceNew || specSchemaDefinitionProperty.IsParentProperty,
+		Sensitive:          specSchemaDefinitionProperty.Sensitive,
+		IsParent:           specSchemaDefinitionProperty.IsParentProperty,
+		Description:        specSchemaDefinitionProperty.Description,
+		Default:            specSchemaDefinitionProperty.Default,
+		Schema:             schema,
+	}
+}
@@ -0,0 +1,235 @@
+package openapiterraformcodegenerator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSwaggerServer(t *testing.T, swagger string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(swagger))
+		assert.NoError(t, err)
+	}))
+}
+
+const testSwagger = `swagger: "2.0"
+host: "api.example.com"
+basePath: "/api"
+schemes:
+- "http"
+- "https"
+security:
+  - apikey_auth: []
+securityDefinitions:
+  apikey_auth:
+    type: "apiKey"
+    name: "Authorization"
+    in: "header"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      - in: "header"
+        name: "X-Request-ID"
+        type: "string"
+        required: true
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    required:
+      - label
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      objectProperty:
+        type: "object"
+        properties:
+          account:
+            type: "string"`
+
+func TestNewTerraformProviderCodeGenerator(t *testing.T) {
+	swaggerServer := newSwaggerServer(t, `swagger: "2.0"`)
+	defer swaggerServer.Close()
+
+	providerName := "openapi"
+	cg, err := NewTerraformProviderCodeGenerator(providerName, "github.com/company/terraform-provider-openapi", swaggerServer.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, providerName, cg.ProviderName)
+	assert.Equal(t, "github.com/company/terraform-provider-openapi", cg.ModulePath)
+	assert.NotNil(t, cg.SpecAnalyser)
+	assert.NotNil(t, cg.Document)
+}
+
+func TestNewTerraformProviderCodeGenerator_ErrorLoadingSpecAnalyser(t *testing.T) {
+	cg, err := NewTerraformProviderCodeGenerator("openapi", "", "badURL")
+	assert.Empty(t, cg)
+	assert.EqualError(t, err, "failed to retrieve the OpenAPI document from 'badURL' - error = open badURL: no such file or directory")
+}
+
+func TestGenerateCode(t *testing.T) {
+	swaggerServer := newSwaggerServer(t, testSwagger)
+	defer swaggerServer.Close()
+
+	cg, err := NewTerraformProviderCodeGenerator("openapi", "", swaggerServer.URL)
+	require.NoError(t, err)
+
+	c, err := cg.GenerateCode()
+	require.NoError(t, err)
+	assert.Equal(t, "openapi", c.ProviderName)
+	assert.Equal(t, "terraform-provider-openapi", c.ModulePath)
+	assert.Equal(t, "https://api.example.com/api", c.BaseURL)
+	assert.NotEmpty(t, c.SpecSnapshot)
+
+	assert.Equal(t, []ConfigProperty{
+		{Name: "apikey_auth", In: "header", Key: "Authorization", Required: true, Sensitive: true},
+		{Name: "x_request_id", In: "header", Key: "X-Request-ID", Required: true},
+	}, c.ConfigProperties)
+
+	require.Len(t, c.Resources, 1)
+	assert.Equal(t, "cdns_v1", c.Resources[0].Name)
+	assert.Equal(t, "/v1/cdns", c.Resources[0].Path)
+	assert.Equal(t, "id", c.Resources[0].Identifier)
+	assert.False(t, c.Resources[0].Updatable)
+	assert.True(t, c.Resources[0].Deletable)
+	require.Len(t, c.Resources[0].Properties, 2)
+	assert.Equal(t, "label", c.Resources[0].Properties[0].Name)
+	assert.True(t, c.Resources[0].Properties[0].Required)
+	assert.Equal(t, "object_property", c.Resources[0].Properties[1].Name)
+	assert.Equal(t, "objectProperty", c.Resources[0].Properties[1].PayloadName)
+	require.Len(t, c.Resources[0].Properties[1].Schema, 1)
+	assert.Equal(t, "account", c.Resources[0].Properties[1].Schema[0].Name)
+
+	files, err := c.Files()
+	require.NoError(t, err)
+	for _, expectedFile := range []string{"go.mod", "main.go", "provider.go", "client.go", "resources.go", "resource_cdns_v1.go", "openapi.json"} {
+		assert.Contains(t, files, expectedFile)
+	}
+	assert.Contains(t, string(files["provider.go"]), `"openapi_cdns_v1": resourceCdnsV1(),`)
+	assert.Contains(t, string(files["resource_cdns_v1.go"]), `"object_property": "objectProperty",`)
+}
+
+func TestGenerateCode_ResourceHost(t *testing.T) {
+	swaggerServer := newSwaggerServer(t, `swagger: "2.0"
+host: "api.example.com"
+basePath: "/api"
+paths:
+  /v1/cdns:
+    post:
+      x-terraform-resource-host: "cdn.example.com"
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`)
+	defer swaggerServer.Close()
+
+	cg, err := NewTerraformProviderCodeGenerator("openapi", "", swaggerServer.URL)
+	require.NoError(t, err)
+
+	c, err := cg.GenerateCode()
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/api", c.BaseURL)
+	require.Len(t, c.Resources, 1)
+	assert.Equal(t, "https://cdn.example.com/api", c.Resources[0].BaseURL)
+
+	files, err := c.Files()
+	require.NoError(t, err)
+	assert.Contains(t, string(files["resource_cdns_v1.go"]), `baseURL:          "https://cdn.example.com/api",`)
+}
+
+func TestGenerateCode_MissingProviderName(t *testing.T) {
+	_, err := TerraformProviderCodeGenerator{}.GenerateCode()
+	assert.EqualError(t, err, "provider name must not be empty")
+}
+
+func TestGetBaseURL(t *testing.T) {
+	testCases := []struct {
+		name            string
+		swagger         string
+		expectedBaseURL string
+	}{
+		{
+			name:            "swagger with no host",
+			swagger:         `swagger: "2.0"`,
+			expectedBaseURL: "",
+		},
+		{
+			name: "swagger with host and no schemes defaults to https",
+			swagger: `swagger: "2.0"
+host: "api.example.com"`,
+			expectedBaseURL: "https://api.example.com",
+		},
+		{
+			name: "swagger with host, http scheme and base path",
+			swagger: `swagger: "2.0"
+host: "api.example.com"
+basePath: "/v1/"
+schemes:
+- "http"`,
+			expectedBaseURL: "http://api.example.com/v1",
+		},
+	}
+	for _, tc := range testCases {
+		swaggerServer := newSwaggerServer(t, tc.swagger)
+		cg, err := NewTerraformProviderCodeGenerator("openapi", "", swaggerServer.URL)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedBaseURL, cg.getBaseURL(), tc.name)
+		swaggerServer.Close()
+	}
+}
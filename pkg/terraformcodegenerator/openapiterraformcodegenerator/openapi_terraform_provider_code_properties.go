@@ -0,0 +1,163 @@
+package openapiterraformcodegenerator
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// ConfigProperty defines the attributes for describing a provider configuration property
+type ConfigProperty struct {
+	Name string
+	// In defines where the value is sent in the API requests (header or query)
+	In string
+	// Key defines the name of the header or query parameter the value is sent as
+	Key       string
+	Bearer    bool
+	Required  bool
+	Sensitive bool
+}
+
+// Property defines the attributes for describing a given property for a resource
+type Property struct {
+	Name               string
+	PayloadName        string
+	Type               string
+	ArrayItemsType     string
+	MapValuesType      string
+	Required           bool
+	ReadOnly           bool
+	IsOptionalComputed bool
+	ForceNew           bool
+	Sensitive          bool
+	IsParent           bool
+	Description        string
+	Default            interface{}
+	Schema             []Property // This is used to describe the schema for array of objects or object properties
+}
+
+// SchemaType returns the terraform schema value type corresponding to the property type
+func (p Property) SchemaType() string {
+	return schemaType(p.Type)
+}
+
+func schemaType(propertyType string) string {
+	switch propertyType {
+	case "integer":
+		return "schema.TypeInt"
+	case "number":
+		return "schema.TypeFloat"
+	case "boolean":
+		return "schema.TypeBool"
+	case "list", "object":
+		return "schema.TypeList"
+	case "map":
+		return "schema.TypeMap"
+	}
+	return "schema.TypeString"
+}
+
+// IsComputed returns true if the value of the property is populated by the API; either because it's readOnly or because
+// it's an optional computed property
+func (p Property) IsComputed() bool {
+	return !p.Required && (p.ReadOnly || p.IsOptionalComputed)
+}
+
+// DefaultValue returns the go literal of the property's default value or an empty string if the property does not have
+// a default value (or the default value type does not match the property type)
+func (p Property) DefaultValue() string {
+	if p.Default == nil || p.IsComputed() {
+		return ""
+	}
+	switch p.Type {
+	case "string":
+		if v, ok := p.Default.(string); ok {
+			return strconv.Quote(v)
+		}
+	case "integer":
+		switch v := p.Default.(type) {
+		case int:
+			return strconv.Itoa(v)
+		case float64:
+			return strconv.Itoa(int(v))
+		}
+	case "number":
+		switch v := p.Default.(type) {
+		case int:
+			return strconv.FormatFloat(float64(v), 'f', -1, 64)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case "boolean":
+		if v, ok := p.Default.(bool); ok {
+			return strconv.FormatBool(v)
+		}
+	}
+	return ""
+}
+
+// SchemaLiteral returns the go source code of the terraform schema describing the property
+func (p Property) SchemaLiteral() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "{\nType: %s,\n", p.SchemaType())
+	switch {
+	case p.Required:
+		b.WriteString("Required: true,\n")
+	case p.ReadOnly:
+		b.WriteString("Computed: true,\n")
+	default:
+		b.WriteString("Optional: true,\n")
+		if p.IsComputed() {
+			b.WriteString("Computed: true,\n")
+		}
+	}
+	if p.ForceNew && !(p.ReadOnly && !p.Required) {
+		b.WriteString("ForceNew: true,\n")
+	}
+	if p.Sensitive {
+		b.WriteString("Sensitive: true,\n")
+	}
+	if p.Description != "" {
+		fmt.Fprintf(&b, "Description: %s,\n", strconv.Quote(p.Description))
+	}
+	if d := p.DefaultValue(); d != "" {
+		fmt.Fprintf(&b, "Default: %s,\n", d)
+	}
+	switch {
+	case p.Type == "object":
+		fmt.Fprintf(&b, "MaxItems: 1,\nElem: %s,\n", p.resourceLiteral())
+	case p.Type == "list" && p.ArrayItemsType == "object":
+		fmt.Fprintf(&b, "Elem: %s,\n", p.resourceLiteral())
+	case p.Type == "list":
+		fmt.Fprintf(&b, "Elem: &schema.Schema{Type: %s},\n", schemaType(p.ArrayItemsType))
+	case p.Type == "map":
+		fmt.Fprintf(&b, "Elem: &schema.Schema{Type: %s},\n", schemaType(p.MapValuesType))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func (p Property) resourceLiteral() string {
+	var b bytes.Buffer
+	b.WriteString("&schema.Resource{\nSchema: map[string]*schema.Schema{\n")
+	for _, s := range p.Schema {
+		fmt.Fprintf(&b, "%s: %s,\n", strconv.Quote(s.Name), s.SchemaLiteral())
+	}
+	b.WriteString("},\n}")
+	return b.String()
+}
+
+// FieldNames returns the mapping between the terraform compliant names and the payload names of the property and its
+// nested properties. Only names that differ are included.
+func (p Property) FieldNames() map[string]string {
+	fieldNames := map[string]string{}
+	if p.Name != p.PayloadName && p.PayloadName != "" {
+		fieldNames[p.Name] = p.PayloadName
+	}
+	for _, s := range p.Schema {
+		for k, v := range s.FieldNames() {
+			fieldNames[k] = v
+		}
+	}
+	return fieldNames
+}
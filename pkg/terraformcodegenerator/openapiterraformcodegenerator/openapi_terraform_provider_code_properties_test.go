@@ -0,0 +1,108 @@
+package openapiterraformcodegenerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProperty_DefaultValue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		property      Property
+		expectedValue string
+	}{
+		{
+			name:          "property without default",
+			property:      Property{Type: "string"},
+			expectedValue: "",
+		},
+		{
+			name:          "string property with default",
+			property:      Property{Type: "string", Default: "some value"},
+			expectedValue: `"some value"`,
+		},
+		{
+			name:          "integer property with default unmarshalled as float",
+			property:      Property{Type: "integer", Default: float64(5)},
+			expectedValue: "5",
+		},
+		{
+			name:          "number property with default",
+			property:      Property{Type: "number", Default: 5.5},
+			expectedValue: "5.5",
+		},
+		{
+			name:          "boolean property with default",
+			property:      Property{Type: "boolean", Default: true},
+			expectedValue: "true",
+		},
+		{
+			name:          "computed property with default",
+			property:      Property{Type: "string", ReadOnly: true, Default: "some value"},
+			expectedValue: "",
+		},
+		{
+			name:          "default value type does not match property type",
+			property:      Property{Type: "boolean", Default: "true"},
+			expectedValue: "",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedValue, tc.property.DefaultValue(), tc.name)
+	}
+}
+
+func TestProperty_SchemaLiteral(t *testing.T) {
+	testCases := []struct {
+		name            string
+		property        Property
+		expectedLiteral string
+	}{
+		{
+			name:            "required string property",
+			property:        Property{Name: "label", Type: "string", Required: true, ForceNew: true},
+			expectedLiteral: "{\nType: schema.TypeString,\nRequired: true,\nForceNew: true,\n}",
+		},
+		{
+			name:            "readOnly property",
+			property:        Property{Name: "status", Type: "string", ReadOnly: true, ForceNew: true},
+			expectedLiteral: "{\nType: schema.TypeString,\nComputed: true,\n}",
+		},
+		{
+			name:            "optional computed sensitive property",
+			property:        Property{Name: "secret", Type: "string", IsOptionalComputed: true, Sensitive: true},
+			expectedLiteral: "{\nType: schema.TypeString,\nOptional: true,\nComputed: true,\nSensitive: true,\n}",
+		},
+		{
+			name:            "list of strings property",
+			property:        Property{Name: "tags", Type: "list", ArrayItemsType: "string"},
+			expectedLiteral: "{\nType: schema.TypeList,\nOptional: true,\nElem: &schema.Schema{Type: schema.TypeString},\n}",
+		},
+		{
+			name:            "map of strings property",
+			property:        Property{Name: "labels", Type: "map", MapValuesType: "string"},
+			expectedLiteral: "{\nType: schema.TypeMap,\nOptional: true,\nElem: &schema.Schema{Type: schema.TypeString},\n}",
+		},
+		{
+			name:            "object property",
+			property:        Property{Name: "obj", Type: "object", Schema: []Property{{Name: "count", Type: "integer", Default: float64(1)}}},
+			expectedLiteral: "{\nType: schema.TypeList,\nOptional: true,\nMaxItems: 1,\nElem: &schema.Resource{\nSchema: map[string]*schema.Schema{\n\"count\": {\nType: schema.TypeInt,\nOptional: true,\nDefault: 1,\n},\n},\n},\n}",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedLiteral, tc.property.SchemaLiteral(), tc.name)
+	}
+}
+
+func TestProperty_FieldNames(t *testing.T) {
+	p := Property{
+		Name:        "object_property",
+		PayloadName: "objectProperty",
+		Schema: []Property{
+			{Name: "account", PayloadName: "account"},
+			{Name: "account_id", PayloadName: "accountId"},
+		},
+	}
+	assert.Equal(t, map[string]string{"object_property": "objectProperty", "account_id": "accountId"}, p.FieldNames())
+}
@@ -0,0 +1,516 @@
+package openapiterraformcodegenerator
+
+const generatedCodeHeader = `// Code generated by openapi-tfgen from the OpenAPI document snapshot ` + specSnapshotFileName + `. DO NOT EDIT.
+
+`
+
+// goModTmpl contains the template used to render the go.mod file of the generated provider project
+var goModTmpl = `module {{.ModulePath}}
+
+go 1.12
+
+require github.com/hashicorp/terraform-plugin-sdk v1.16.0
+`
+
+// mainTmpl contains the template used to render the main.go file of the generated provider project
+var mainTmpl = generatedCodeHeader + `package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/plugin"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{ProviderFunc: Provider})
+}
+`
+
+// providerTmpl contains the template used to render the provider.go file of the generated provider project
+var providerTmpl = generatedCodeHeader + `package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// Provider returns the {{.ProviderName}} terraform provider
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"base_url": {
+				Type: schema.TypeString,
+				{{- if .BaseURL}}
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc({{printf "%q" .BaseURLEnvVariable}}, {{printf "%q" .BaseURL}}),
+				{{- else}}
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc({{printf "%q" .BaseURLEnvVariable}}, nil),
+				{{- end}}
+				Description: "Base URL of the API (scheme, host and base path)",
+			},
+			{{- range .ConfigProperties}}
+			{{printf "%q" .Name}}: {
+				Type: schema.TypeString,
+				{{- if .Required}}
+				Required: true,
+				{{- else}}
+				Optional: true,
+				{{- end}}
+				{{- if .Sensitive}}
+				Sensitive: true,
+				{{- end}}
+			},
+			{{- end}}
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			{{- range .Resources}}
+			{{printf "%q" (printf "%s_%s" $.ProviderName .Name)}}: {{.FuncName}}(),
+			{{- end}}
+		},
+		ConfigureFunc: configureProvider,
+	}
+}
+
+func configureProvider(d *schema.ResourceData) (interface{}, error) {
+	client := newAPIClient(d.Get("base_url").(string))
+	{{- range .ConfigProperties}}
+	if v, ok := d.GetOk({{printf "%q" .Name}}); ok {
+		client.{{if eq .In "query"}}queryParams{{else}}headers{{end}}[{{printf "%q" .Key}}] = {{if .Bearer}}"Bearer " + {{end}}v.(string)
+	}
+	{{- end}}
+	return client, nil
+}
+`
+
+// clientTmpl contains the template used to render the client.go file of the generated provider project
+var clientTmpl = generatedCodeHeader + `package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiClient performs the HTTP requests against the API
+type apiClient struct {
+	baseURL     string
+	headers     map[string]string
+	queryParams map[string]string
+	httpClient  *http.Client
+}
+
+func newAPIClient(baseURL string) *apiClient {
+	return &apiClient{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		headers:     map[string]string{},
+		queryParams: map[string]string{},
+		httpClient:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// apiError is returned when the API responds with a non successful status code
+type apiError struct {
+	statusCode int
+	body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("HTTP Response Status Code %d - Error '%s'", e.statusCode, e.body)
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.statusCode == http.StatusNotFound
+}
+
+// do performs the request against the given base URL, which defaults to the provider base URL if empty
+func (c *apiClient) do(method, baseURL, path string, in interface{}, out interface{}) error {
+	if baseURL == "" {
+		baseURL = c.baseURL
+	}
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/") + path)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	for k, v := range c.queryParams {
+		q.Set(k, v)
+	}
+	u.RawQuery = q.Encode()
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apiError{statusCode: resp.StatusCode, body: string(respBody)}
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(respBody))
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+`
+
+// resourceDefinitionTmpl contains the template used to render the resources.go file of the generated provider
+// project. This file contains the CRUD logic shared by all the generated resources
+var resourceDefinitionTmpl = generatedCodeHeader + `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var pathParameterRegex = regexp.MustCompile("{[^{}]+}")
+
+// resourceDefinition contains the static definition of a resource generated from the OpenAPI document
+type resourceDefinition struct {
+	name string
+	// path contains the resource collection path; sub-resource paths contain the parent ids path parameters (e,g: /v1/cdns/{id}/firewalls)
+	path string
+	// baseURL contains the base URL of the resources served from a different host than the rest of the API
+	// (x-terraform-resource-host); empty if the resource is served from the provider base URL
+	baseURL string
+	// identifier contains the name of the payload property used as the resource id
+	identifier       string
+	parentProperties []string
+	updatable        bool
+	deletable        bool
+	// fieldNames maps terraform property names to payload property names when they differ
+	fieldNames map[string]string
+	schema     map[string]*schema.Schema
+}
+
+func (r resourceDefinition) resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: r.schema,
+		Create: r.create,
+		Read:   r.read,
+		Update: r.update,
+		Delete: r.delete,
+		Importer: &schema.ResourceImporter{
+			State: r.importState,
+		},
+	}
+}
+
+func (r resourceDefinition) create(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient)
+	path, err := r.collectionPath(d)
+	if err != nil {
+		return err
+	}
+	response := map[string]interface{}{}
+	if err := client.do(http.MethodPost, r.baseURL, path, r.payload(d), &response); err != nil {
+		return fmt.Errorf("[resource='%s'] POST %s failed: %s", r.name, path, err)
+	}
+	id, ok := response[r.identifier]
+	if !ok || id == nil {
+		return fmt.Errorf("[resource='%s'] POST %s response does not contain the identifier property '%s'", r.name, path, r.identifier)
+	}
+	d.SetId(fmt.Sprintf("%v", id))
+	return r.updateState(d, response)
+}
+
+func (r resourceDefinition) read(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient)
+	path, err := r.instancePath(d)
+	if err != nil {
+		return err
+	}
+	response := map[string]interface{}{}
+	if err := client.do(http.MethodGet, r.baseURL, path, nil, &response); err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[resource='%s'] GET %s failed: %s", r.name, path, err)
+	}
+	return r.updateState(d, response)
+}
+
+func (r resourceDefinition) update(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient)
+	path, err := r.instancePath(d)
+	if err != nil {
+		return err
+	}
+	if !r.updatable {
+		return fmt.Errorf("[resource='%s'] resource does not support PUT operation, check the swagger file exposed on '%s'", r.name, path)
+	}
+	response := map[string]interface{}{}
+	if err := client.do(http.MethodPut, r.baseURL, path, r.payload(d), &response); err != nil {
+		return fmt.Errorf("[resource='%s'] UPDATE %s failed: %s", r.name, path, err)
+	}
+	return r.updateState(d, response)
+}
+
+func (r resourceDefinition) delete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient)
+	path, err := r.instancePath(d)
+	if err != nil {
+		return err
+	}
+	if !r.deletable {
+		return fmt.Errorf("[resource='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", r.name, path)
+	}
+	if err := client.do(http.MethodDelete, r.baseURL, path, nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("[resource='%s'] DELETE %s failed: %s", r.name, path, err)
+	}
+	return nil
+}
+
+// importState expects the id to be provided in the form parentID/.../instanceID for sub-resources
+func (r resourceDefinition) importState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if len(r.parentProperties) > 0 {
+		ids := strings.Split(d.Id(), "/")
+		if len(ids) != len(r.parentProperties)+1 {
+			return nil, fmt.Errorf("[resource='%s'] can not import a subresource without all the parent ids, expected %d parent IDs and the instance ID", r.name, len(r.parentProperties))
+		}
+		for idx, parentPropertyName := range r.parentProperties {
+			if err := d.Set(parentPropertyName, ids[idx]); err != nil {
+				return nil, err
+			}
+		}
+		d.SetId(ids[len(ids)-1])
+	}
+	if err := r.read(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func (r resourceDefinition) collectionPath(d *schema.ResourceData) (string, error) {
+	var err error
+	idx := 0
+	path := pathParameterRegex.ReplaceAllStringFunc(r.path, func(string) string {
+		if idx >= len(r.parentProperties) {
+			err = fmt.Errorf("[resource='%s'] could not resolve sub-resource path correctly '%s' - missing parent ids", r.name, r.path)
+			return ""
+		}
+		parentID := d.Get(r.parentProperties[idx]).(string)
+		idx++
+		return url.PathEscape(parentID)
+	})
+	return path, err
+}
+
+func (r resourceDefinition) instancePath(d *schema.ResourceData) (string, error) {
+	path, err := r.collectionPath(d)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(path, "/"), url.PathEscape(d.Id())), nil
+}
+
+func (r resourceDefinition) fieldName(name string) string {
+	if payloadName, ok := r.fieldNames[name]; ok {
+		return payloadName
+	}
+	return name
+}
+
+func (r resourceDefinition) isParentProperty(name string) bool {
+	for _, parentProperty := range r.parentProperties {
+		if parentProperty == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r resourceDefinition) payload(d *schema.ResourceData) map[string]interface{} {
+	payload := map[string]interface{}{}
+	for name, s := range r.schema {
+		if r.isParentProperty(name) || (s.Computed && !s.Optional) {
+			continue
+		}
+		if v, ok := d.GetOkExists(name); ok {
+			payload[r.fieldName(name)] = r.toPayloadValue(v, s)
+		}
+	}
+	return payload
+}
+
+func (r resourceDefinition) toPayloadValue(v interface{}, s *schema.Schema) interface{} {
+	elem, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return v
+	}
+	items, _ := v.([]interface{})
+	objects := []interface{}{}
+	for _, item := range items {
+		object, _ := item.(map[string]interface{})
+		objects = append(objects, r.toPayloadObject(object, elem))
+	}
+	if s.MaxItems == 1 {
+		if len(objects) == 0 {
+			return nil
+		}
+		return objects[0]
+	}
+	return objects
+}
+
+func (r resourceDefinition) toPayloadObject(object map[string]interface{}, elem *schema.Resource) map[string]interface{} {
+	payload := map[string]interface{}{}
+	for name, s := range elem.Schema {
+		if s.Computed && !s.Optional {
+			continue
+		}
+		if v, ok := object[name]; ok {
+			payload[r.fieldName(name)] = r.toPayloadValue(v, s)
+		}
+	}
+	return payload
+}
+
+func (r resourceDefinition) updateState(d *schema.ResourceData, response map[string]interface{}) error {
+	for name, s := range r.schema {
+		if r.isParentProperty(name) {
+			continue
+		}
+		// values not returned by the API (e,g: write only properties) are kept as configured
+		v, ok := response[r.fieldName(name)]
+		if !ok {
+			continue
+		}
+		if err := d.Set(name, r.fromPayloadValue(v, s)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r resourceDefinition) fromPayloadValue(v interface{}, s *schema.Schema) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch s.Type {
+	case schema.TypeInt:
+		if n, ok := v.(json.Number); ok {
+			i, _ := n.Int64()
+			return int(i)
+		}
+	case schema.TypeFloat:
+		if n, ok := v.(json.Number); ok {
+			f, _ := n.Float64()
+			return f
+		}
+	case schema.TypeString:
+		if n, ok := v.(json.Number); ok {
+			return n.String()
+		}
+	case schema.TypeList:
+		switch elem := s.Elem.(type) {
+		case *schema.Resource:
+			if s.MaxItems == 1 {
+				object, ok := v.(map[string]interface{})
+				if !ok {
+					return nil
+				}
+				return []interface{}{r.fromPayloadObject(object, elem)}
+			}
+			items, _ := v.([]interface{})
+			objects := []interface{}{}
+			for _, item := range items {
+				if object, ok := item.(map[string]interface{}); ok {
+					objects = append(objects, r.fromPayloadObject(object, elem))
+				}
+			}
+			return objects
+		case *schema.Schema:
+			items, _ := v.([]interface{})
+			values := []interface{}{}
+			for _, item := range items {
+				values = append(values, r.fromPayloadValue(item, elem))
+			}
+			return values
+		}
+	}
+	return v
+}
+
+func (r resourceDefinition) fromPayloadObject(object map[string]interface{}, elem *schema.Resource) map[string]interface{} {
+	state := map[string]interface{}{}
+	for name, s := range elem.Schema {
+		state[name] = r.fromPayloadValue(object[r.fieldName(name)], s)
+	}
+	return state
+}
+`
+
+// resourceTmpl contains the template used to render the static definition of each of the resources exposed by the
+// generated provider
+var resourceTmpl = generatedCodeHeader + `package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var {{.DefinitionName}} = resourceDefinition{
+	name:       {{printf "%q" .Name}},
+	path:       {{printf "%q" .Path}},
+	{{- if .BaseURL}}
+	baseURL: {{printf "%q" .BaseURL}},
+	{{- end}}
+	identifier: {{printf "%q" .Identifier}},
+	parentProperties: []string{
+		{{- range .ParentProperties}}
+		{{printf "%q" .}},
+		{{- end}}
+	},
+	updatable: {{.Updatable}},
+	deletable: {{.Deletable}},
+	fieldNames: map[string]string{
+		{{- $fieldNames := .FieldNames}}
+		{{- range .SortedFieldNames}}
+		{{printf "%q" .}}: {{printf "%q" (index $fieldNames .)}},
+		{{- end}}
+	},
+	schema: map[string]*schema.Schema{
+		{{- range .Properties}}
+		{{printf "%q" .Name}}: {{.SchemaLiteral}},
+		{{- end}}
+	},
+}
+
+func {{.FuncName}}() *schema.Resource {
+	return {{.DefinitionName}}.resource()
+}
+`
@@ -141,8 +141,9 @@ func (t TerraformProviderDocGenerator) getDataSourceFilters(dataSourcesFilter []
 			props = append(props, prop)
 		}
 		dataSources = append(dataSources, DataSource{
-			Name:       dataSource.GetResourceName(),
-			Properties: orderProps(props),
+			Name:        dataSource.GetResourceName(),
+			Description: dataSource.GetResourceDescription(),
+			Properties:  orderProps(props),
 		})
 	}
 	return dataSources, nil
@@ -202,7 +203,8 @@ func (t TerraformProviderDocGenerator) getProviderResources(resources []openapi.
 
 		r = append(r, Resource{
 			Name:             resource.GetResourceName(),
-			Description:      "",
+			Description:      resource.GetResourceDescription(),
+			Operations:       getOperations(resource.GetOperationIDs()),
 			Properties:       props,
 			ParentProperties: parentProperties,
 			ArgumentsReference: ArgumentsReference{
@@ -213,6 +215,18 @@ func (t TerraformProviderDocGenerator) getProviderResources(resources []openapi.
 	return r, nil
 }
 
+// getOperations returns the given operationIds (indexed by operation name) in the order the operations are performed
+// through the resource lifecycle
+func getOperations(operationIDs map[string]string) []Operation {
+	var operations []Operation
+	for _, name := range []string{"create", "read", "update", "delete", "list", "exists"} {
+		if operationID, ok := operationIDs[name]; ok {
+			operations = append(operations, Operation{Name: name, OperationID: operationID})
+		}
+	}
+	return operations
+}
+
 func (t TerraformProviderDocGenerator) resourceSchemaToProperty(specSchemaDefinitionProperty openapi.SpecSchemaDefinitionProperty) Property {
 	var schema []Property
 	if specSchemaDefinitionProperty.Type == openapi.TypeObject || specSchemaDefinitionProperty.ArrayItemsType == openapi.TypeObject {
@@ -232,10 +246,20 @@ func (t TerraformProviderDocGenerator) resourceSchemaToProperty(specSchemaDefini
 		IsSensitive:        specSchemaDefinitionProperty.Sensitive,
 		IsParent:           specSchemaDefinitionProperty.IsParentProperty,
 		Description:        specSchemaDefinitionProperty.Description,
+		Example:            specSchemaDefinitionProperty.GetExample(),
+		AllowedValues:      getAllowedValues(specSchemaDefinitionProperty.ArrayItemsEnum),
 		Schema:             orderProps(schema),
 	}
 }
 
+func getAllowedValues(enum []interface{}) []string {
+	var allowedValues []string
+	for _, value := range enum {
+		allowedValues = append(allowedValues, fmt.Sprintf("%v", value))
+	}
+	return allowedValues
+}
+
 func (t TerraformProviderDocGenerator) getRequiredProviderConfigurationProperties(regions []string, globalSecuritySchemes openapi.SpecSecuritySchemes, securityDefinitions *openapi.SpecSecurityDefinitions, headers openapi.SpecHeaderParameters) ([]string, []Property) {
 	var configProps []Property
 	if securityDefinitions != nil {
@@ -89,9 +89,11 @@ func (s *specStubBackendConfiguration) IsMultiRegion() (bool, string, []string,
 type specStubResource struct {
 	openapi.SpecResource
 	name                string
+	description         string
 	shouldIgnore        bool
 	schemaDefinition    *openapi.SpecSchemaDefinition
 	parentResourceNames []string
+	operationIDs        map[string]string
 	error               error
 }
 
@@ -106,6 +108,10 @@ func (s *specStubResource) GetResourceSchema() (*openapi.SpecSchemaDefinition, e
 
 func (s *specStubResource) GetResourceName() string { return s.name }
 
+func (s *specStubResource) GetResourceDescription() string { return s.description }
+
+func (s *specStubResource) GetOperationIDs() map[string]string { return s.operationIDs }
+
 func (s *specStubResource) GetParentResourceInfo() *openapi.ParentResourceInfo {
 	if len(s.parentResourceNames) > 0 {
 		subRes := openapi.ParentResourceInfo{}
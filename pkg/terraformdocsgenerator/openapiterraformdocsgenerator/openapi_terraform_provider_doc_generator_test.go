@@ -440,6 +440,18 @@ func TestGetDataSourceFilters(t *testing.T) {
 			},
 			expectedProps: []Property{{Name: "list_prop", Type: "list", ArrayItemsType: "string", Required: false, Computed: true, IsOptionalComputed: true}},
 		},
+		{
+			name: "happy path - list prop with items enum",
+			openapiProps: openapi.SpecSchemaDefinitionProperties{
+				&openapi.SpecSchemaDefinitionProperty{
+					Name:           "list_prop",
+					Type:           openapi.TypeList,
+					ArrayItemsType: openapi.TypeInt,
+					ArrayItemsEnum: []interface{}{float64(80), float64(443)},
+				},
+			},
+			expectedProps: []Property{{Name: "list_prop", Type: "list", ArrayItemsType: "integer", Required: false, Computed: true, IsOptionalComputed: true, AllowedValues: []string{"80", "443"}}},
+		},
 		{
 			name: "happy path - obj prop with multiple child props (child props should be ordered by their hash)",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
@@ -495,6 +507,21 @@ func TestGetDataSourceFilters(t *testing.T) {
 	}
 }
 
+func TestGetDataSourceFilters_HasDescription(t *testing.T) {
+	openapiDataSources := []openapi.SpecResource{
+		&specStubResource{
+			name:             "test_resource",
+			description:      "List the test resources",
+			schemaDefinition: &openapi.SpecSchemaDefinition{},
+		},
+	}
+	dg := TerraformProviderDocGenerator{}
+	actualDataSources, err := dg.getDataSourceFilters(openapiDataSources)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "List the test resources", actualDataSources[0].Description)
+}
+
 func TestGetDataSourceFilters_Error(t *testing.T) {
 	openapiDataSources := []openapi.SpecResource{
 		&specStubResource{
@@ -661,6 +688,17 @@ func TestGetProviderResources(t *testing.T) {
 			},
 			expectedProps: []Property{{Name: "float_prop", Type: "number", Required: false, Computed: false}},
 		},
+		{
+			name: "happy path - prop with example",
+			openapiProps: openapi.SpecSchemaDefinitionProperties{
+				&openapi.SpecSchemaDefinitionProperty{
+					Name:    "string_prop",
+					Type:    openapi.TypeString,
+					Example: "some example",
+				},
+			},
+			expectedProps: []Property{{Name: "string_prop", Type: "string", Required: false, Computed: false, Example: `"some example"`}},
+		},
 		{
 			name: "happy path - list prop",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
@@ -762,6 +800,36 @@ func TestGetProviderResources_HasParentProps(t *testing.T) {
 	assert.Equal(t, "parentResourceName_id", actualResources[0].ParentProperties[0])
 }
 
+func TestGetProviderResources_HasDescription(t *testing.T) {
+	openapiResources := []openapi.SpecResource{
+		&specStubResource{
+			name:             "test_resource",
+			description:      "Create a test resource",
+			schemaDefinition: &openapi.SpecSchemaDefinition{},
+		},
+	}
+	dg := TerraformProviderDocGenerator{}
+	actualResources, err := dg.getProviderResources(openapiResources)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Create a test resource", actualResources[0].Description)
+}
+
+func TestGetProviderResources_HasOperations(t *testing.T) {
+	openapiResources := []openapi.SpecResource{
+		&specStubResource{
+			name:             "test_resource",
+			operationIDs:     map[string]string{"delete": "deleteTestResource", "create": "createTestResource"},
+			schemaDefinition: &openapi.SpecSchemaDefinition{},
+		},
+	}
+	dg := TerraformProviderDocGenerator{}
+	actualResources, err := dg.getProviderResources(openapiResources)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Operation{{Name: "create", OperationID: "createTestResource"}, {Name: "delete", OperationID: "deleteTestResource"}}, actualResources[0].Operations)
+}
+
 func TestGetProviderResources_IgnoreResource(t *testing.T) {
 	openapiResources := []openapi.SpecResource{
 		&specStubResource{
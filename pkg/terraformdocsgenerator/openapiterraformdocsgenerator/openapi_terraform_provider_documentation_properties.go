@@ -12,6 +12,14 @@ type Property struct {
 	IsParent           bool
 	Description        string
 	Schema             []Property // This is used to describe the schema for array of objects or object properties
+
+	// Example contains the sample value of the property formatted following the terraform syntax. It's ignored when
+	// hashing the properties so the order of the properties is not affected by it
+	Example string `hash:"ignore"`
+
+	// AllowedValues contains the values the items of array properties are restricted to (items declaring the enum
+	// attribute). It's ignored when hashing the properties for the same reason as Example
+	AllowedValues []string `hash:"ignore"`
 }
 
 // ContainsComputedSubProperties checks if a schema contains properties that are computed recursively
@@ -23,6 +23,7 @@ func (r ProviderResources) ContainsResourcesWithSecretProperties() bool {
 type Resource struct {
 	Name               string
 	Description        string
+	Operations         []Operation
 	Properties         []Property
 	ParentProperties   []string
 	ExampleUsage       []ExampleUsage
@@ -46,6 +47,14 @@ func (r Resource) BuildImportIDsExample() string {
 	return idExamples
 }
 
+// Operation defines the operationId of a resource operation, which helps users correlate the resource with the API
+// documentation
+type Operation struct {
+	// Name is the name of the operation: create, read, update, delete, list or exists
+	Name        string
+	OperationID string
+}
+
 // ExampleUsage defines a block of code/commands to include in the docs
 type ExampleUsage struct {
 	Title   string
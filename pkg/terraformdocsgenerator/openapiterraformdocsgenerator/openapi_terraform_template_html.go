@@ -136,7 +136,9 @@ var ProviderConfigurationTmpl = `{{if or .Regions .ConfigProperties}}
 // ProviderResourcesTmpl contains the template used to render the TerraformProviderDocumentation.ProviderResources struct as HTML formatted for Zendesk
 var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
 {{- if .Required}}
-    {{if eq .Type "string" -}}
+    {{if and .Example (ne .Type "object") (ne .ArrayItemsType "object") -}}
+        <span>{{.Name}}  </span>= <span>{{.Example}}</span>
+    {{- else if eq .Type "string" -}}
         <span>{{.Name}}  </span>= <span>"{{.Name}}"</span>
     {{- else if eq .Type "integer" -}}
         <span>{{.Name}}  </span>= <span>1234</span>
@@ -178,6 +180,9 @@ var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
 {{if ne .Description "" -}}
 <p>{{.Description}}</p>
 {{- end}}
+{{- if .Operations}}
+<p>API operations: {{range $i, $operation := .Operations}}{{if $i}}, {{end}}{{$operation.Name}} ({{$operation.OperationID}}){{end}}</p>
+{{- end}}
 {{- if .KnownIssues}}
 <p>If you experience any issues using this resource, please check the <a href="#resource_{{.Name}}_known_issues" target="_self">Known Issues</a> section to see if there is a fix/workaround.</p>
 {{end -}}
@@ -273,6 +278,7 @@ var ArgumentReferenceTmpl = `{{- define "resource_argument_reference" -}}
     {{end}}
 	{{- if or .Required (and (not .Required) (not .Computed)) .IsOptionalComputed -}}
     <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{- if .IsSensitive -}}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>){{- end}} - ({{$required}}) {{if .IsParent}}The {{.Name}} that this resource belongs to{{else}}{{.Description}}{{end}}
+        {{- if .AllowedValues}}. Allowed values: {{range $i, $v := .AllowedValues}}{{if $i}}, {{end}}{{$v}}{{end}}{{end}}
         {{- if or (eq .Type "object") (eq .ArrayItemsType "object")}}. The following properties compose the object schema
         :<ul dir="ltr">
             {{- range .Schema}}
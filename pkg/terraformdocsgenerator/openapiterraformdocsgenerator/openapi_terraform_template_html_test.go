@@ -56,6 +56,11 @@ func TestArgumentReferenceTmpl(t *testing.T) {
 			property:       createArrayProperty("list_float_prop", "list", "number", "list_float_prop property description", true, false),
 			expectedOutput: "<li> list_float_prop [list of numbers] - (Required) list_float_prop property description</li>\n\t",
 		},
+		{
+			name:           "optional list string property with allowed values",
+			property:       Property{Name: "protocols", Type: "list", ArrayItemsType: "string", Description: "protocols supported", AllowedValues: []string{"http", "https"}},
+			expectedOutput: "<li> protocols [list of strings] - (Optional) protocols supported. Allowed values: http, https</li>\n\t",
+		},
 		{
 			name:           "required object property",
 			property:       Property{Name: "object_prop", Type: "object", Description: "this is an object property", Required: true, Schema: []Property{{Name: "objectPropertyRequired", Type: "string", Required: true}, {Name: "objectPropertyComputed", Type: "string", Computed: true}}},
@@ -181,6 +186,49 @@ func TestAttributeReferenceTmpl(t *testing.T) {
 	}
 }
 
+func TestResourceExampleTmpl(t *testing.T) {
+	testCases := []struct {
+		name           string
+		property       Property
+		expectedOutput string
+	}{
+		{
+			name:           "required string property without example",
+			property:       Property{Name: "label", Type: "string", Required: true},
+			expectedOutput: "\n    <span>label  </span>= <span>\"label\"</span>",
+		},
+		{
+			name:           "required string property with example",
+			property:       Property{Name: "label", Type: "string", Required: true, Example: `"some label"`},
+			expectedOutput: "\n    <span>label  </span>= <span>\"some label\"</span>",
+		},
+		{
+			name:           "required list property with example",
+			property:       Property{Name: "ips", Type: "list", ArrayItemsType: "string", Required: true, Example: `["127.0.0.1"]`},
+			expectedOutput: "\n    <span>ips  </span>= <span>[\"127.0.0.1\"]</span>",
+		},
+		{
+			name:           "required object property with example",
+			property:       Property{Name: "obj", Type: "object", Required: true, Example: `{"a":"b"}`, Schema: []Property{{Name: "a", Type: "string", Required: true, Example: `"b"`}}},
+			expectedOutput: "\n    <span>obj  </span><span>{</span>\n                \n    <span>a  </span>= <span>\"b\"</span>\n            <span>}</span>",
+		},
+		{
+			name:           "optional property with example",
+			property:       Property{Name: "label", Type: "string", Example: `"some label"`},
+			expectedOutput: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		var output bytes.Buffer
+		tmpl, err := template.New("ProviderResources").Parse(ProviderResourcesTmpl)
+		assert.Nil(t, err, tc.name)
+		err = tmpl.ExecuteTemplate(&output, "resource_example", tc.property)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedOutput, output.String(), tc.name)
+	}
+}
+
 func TestProviderInstallationTmpl(t *testing.T) {
 	pi := ProviderInstallation{
 		ProviderName: "openapi",
@@ -265,6 +313,7 @@ resource "openapi_cdn" "my_cdn" {
 			{
 				Name:        "cdn",
 				Description: "The 'cdn' allows you to manage 'cdn' resources using Terraform",
+				Operations:  []Operation{{Name: "create", OperationID: "createCDN"}, {Name: "read", OperationID: "getCDN"}},
 				ExampleUsage: []ExampleUsage{
 					{
 						Title:   "example title 1:",
@@ -311,6 +360,7 @@ resource "openapi_cdn" "my_cdn" {
 	
 <h3 id="cdn" dir="ltr">openapi_cdn</h3>
 <p>The 'cdn' allows you to manage 'cdn' resources using Terraform</p>
+<p>API operations: create (createCDN), read (getCDN)</p>
 <p>If you experience any issues using this resource, please check the <a href="#resource_cdn_known_issues" target="_self">Known Issues</a> section to see if there is a fix/workaround.</p>
 <h4 id="resource_cdn_example_usage" dir="ltr">Example usage</h4>
 <p>example title 1:</p>